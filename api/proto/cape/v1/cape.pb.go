@@ -0,0 +1,3119 @@
+// CAPE decision service, for running CAPE as a placement sidecar next to
+// another scheduler. Messages mirror pkg/models and pkg/decision; the Go
+// implementation behind each RPC is pkg/sidecar.Service.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: cape/v1/cape.proto
+
+package capev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Process struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type               string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	TenantId           string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Priority           int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	CpuRequirement     float64                `protobuf:"fixed64,5,opt,name=cpu_requirement,json=cpuRequirement,proto3" json:"cpu_requirement,omitempty"`
+	MemoryRequirement  int64                  `protobuf:"varint,6,opt,name=memory_requirement,json=memoryRequirement,proto3" json:"memory_requirement,omitempty"`
+	DiskRequirement    int64                  `protobuf:"varint,7,opt,name=disk_requirement,json=diskRequirement,proto3" json:"disk_requirement,omitempty"`
+	NetworkRequirement float64                `protobuf:"fixed64,8,opt,name=network_requirement,json=networkRequirement,proto3" json:"network_requirement,omitempty"`
+	InputSize          int64                  `protobuf:"varint,9,opt,name=input_size,json=inputSize,proto3" json:"input_size,omitempty"`
+	OutputSize         int64                  `protobuf:"varint,10,opt,name=output_size,json=outputSize,proto3" json:"output_size,omitempty"`
+	InputDatasetId     string                 `protobuf:"bytes,11,opt,name=input_dataset_id,json=inputDatasetId,proto3" json:"input_dataset_id,omitempty"`
+	OutputDatasetId    string                 `protobuf:"bytes,12,opt,name=output_dataset_id,json=outputDatasetId,proto3" json:"output_dataset_id,omitempty"`
+	InputStorageTier   string                 `protobuf:"bytes,13,opt,name=input_storage_tier,json=inputStorageTier,proto3" json:"input_storage_tier,omitempty"`
+	DataSensitivity    int32                  `protobuf:"varint,14,opt,name=data_sensitivity,json=dataSensitivity,proto3" json:"data_sensitivity,omitempty"`
+	EstimatedDuration  *durationpb.Duration   `protobuf:"bytes,15,opt,name=estimated_duration,json=estimatedDuration,proto3" json:"estimated_duration,omitempty"`
+	MaxDuration        *durationpb.Duration   `protobuf:"bytes,16,opt,name=max_duration,json=maxDuration,proto3" json:"max_duration,omitempty"`
+	RealTime           bool                   `protobuf:"varint,17,opt,name=real_time,json=realTime,proto3" json:"real_time,omitempty"`
+	SafetyCritical     bool                   `protobuf:"varint,18,opt,name=safety_critical,json=safetyCritical,proto3" json:"safety_critical,omitempty"`
+	Parallelizable     bool                   `protobuf:"varint,19,opt,name=parallelizable,proto3" json:"parallelizable,omitempty"`
+	MaxShards          int32                  `protobuf:"varint,20,opt,name=max_shards,json=maxShards,proto3" json:"max_shards,omitempty"`
+	GangSize           int32                  `protobuf:"varint,21,opt,name=gang_size,json=gangSize,proto3" json:"gang_size,omitempty"`
+	Dependencies       []string               `protobuf:"bytes,22,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	LocalityRequired   bool                   `protobuf:"varint,23,opt,name=locality_required,json=localityRequired,proto3" json:"locality_required,omitempty"`
+	SecurityLevel      int32                  `protobuf:"varint,24,opt,name=security_level,json=securityLevel,proto3" json:"security_level,omitempty"`
+	DataResidency      []string               `protobuf:"bytes,25,rep,name=data_residency,json=dataResidency,proto3" json:"data_residency,omitempty"`
+	SccApproved        bool                   `protobuf:"varint,26,opt,name=scc_approved,json=sccApproved,proto3" json:"scc_approved,omitempty"`
+	SubmissionTime     *timestamppb.Timestamp `protobuf:"bytes,27,opt,name=submission_time,json=submissionTime,proto3" json:"submission_time,omitempty"`
+	ProjectId          string                 `protobuf:"bytes,28,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+}
+
+func (x *Process) Reset() {
+	*x = Process{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Process) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Process) ProtoMessage() {}
+
+func (x *Process) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Process.ProtoReflect.Descriptor instead.
+func (*Process) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Process) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Process) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Process) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Process) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Process) GetCpuRequirement() float64 {
+	if x != nil {
+		return x.CpuRequirement
+	}
+	return 0
+}
+
+func (x *Process) GetMemoryRequirement() int64 {
+	if x != nil {
+		return x.MemoryRequirement
+	}
+	return 0
+}
+
+func (x *Process) GetDiskRequirement() int64 {
+	if x != nil {
+		return x.DiskRequirement
+	}
+	return 0
+}
+
+func (x *Process) GetNetworkRequirement() float64 {
+	if x != nil {
+		return x.NetworkRequirement
+	}
+	return 0
+}
+
+func (x *Process) GetInputSize() int64 {
+	if x != nil {
+		return x.InputSize
+	}
+	return 0
+}
+
+func (x *Process) GetOutputSize() int64 {
+	if x != nil {
+		return x.OutputSize
+	}
+	return 0
+}
+
+func (x *Process) GetInputDatasetId() string {
+	if x != nil {
+		return x.InputDatasetId
+	}
+	return ""
+}
+
+func (x *Process) GetOutputDatasetId() string {
+	if x != nil {
+		return x.OutputDatasetId
+	}
+	return ""
+}
+
+func (x *Process) GetInputStorageTier() string {
+	if x != nil {
+		return x.InputStorageTier
+	}
+	return ""
+}
+
+func (x *Process) GetDataSensitivity() int32 {
+	if x != nil {
+		return x.DataSensitivity
+	}
+	return 0
+}
+
+func (x *Process) GetEstimatedDuration() *durationpb.Duration {
+	if x != nil {
+		return x.EstimatedDuration
+	}
+	return nil
+}
+
+func (x *Process) GetMaxDuration() *durationpb.Duration {
+	if x != nil {
+		return x.MaxDuration
+	}
+	return nil
+}
+
+func (x *Process) GetRealTime() bool {
+	if x != nil {
+		return x.RealTime
+	}
+	return false
+}
+
+func (x *Process) GetSafetyCritical() bool {
+	if x != nil {
+		return x.SafetyCritical
+	}
+	return false
+}
+
+func (x *Process) GetParallelizable() bool {
+	if x != nil {
+		return x.Parallelizable
+	}
+	return false
+}
+
+func (x *Process) GetMaxShards() int32 {
+	if x != nil {
+		return x.MaxShards
+	}
+	return 0
+}
+
+func (x *Process) GetGangSize() int32 {
+	if x != nil {
+		return x.GangSize
+	}
+	return 0
+}
+
+func (x *Process) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *Process) GetLocalityRequired() bool {
+	if x != nil {
+		return x.LocalityRequired
+	}
+	return false
+}
+
+func (x *Process) GetSecurityLevel() int32 {
+	if x != nil {
+		return x.SecurityLevel
+	}
+	return 0
+}
+
+func (x *Process) GetDataResidency() []string {
+	if x != nil {
+		return x.DataResidency
+	}
+	return nil
+}
+
+func (x *Process) GetSccApproved() bool {
+	if x != nil {
+		return x.SccApproved
+	}
+	return false
+}
+
+func (x *Process) GetSubmissionTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmissionTime
+	}
+	return nil
+}
+
+func (x *Process) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type OffloadTarget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type              string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Location          string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	TotalCapacity     float64                `protobuf:"fixed64,4,opt,name=total_capacity,json=totalCapacity,proto3" json:"total_capacity,omitempty"`
+	AvailableCapacity float64                `protobuf:"fixed64,5,opt,name=available_capacity,json=availableCapacity,proto3" json:"available_capacity,omitempty"`
+	MemoryTotal       int64                  `protobuf:"varint,6,opt,name=memory_total,json=memoryTotal,proto3" json:"memory_total,omitempty"`
+	MemoryAvailable   int64                  `protobuf:"varint,7,opt,name=memory_available,json=memoryAvailable,proto3" json:"memory_available,omitempty"`
+	NetworkLatency    *durationpb.Duration   `protobuf:"bytes,8,opt,name=network_latency,json=networkLatency,proto3" json:"network_latency,omitempty"`
+	NetworkBandwidth  float64                `protobuf:"fixed64,9,opt,name=network_bandwidth,json=networkBandwidth,proto3" json:"network_bandwidth,omitempty"` // Bytes/sec
+	NetworkStability  float64                `protobuf:"fixed64,10,opt,name=network_stability,json=networkStability,proto3" json:"network_stability,omitempty"`
+	NetworkCost       float64                `protobuf:"fixed64,11,opt,name=network_cost,json=networkCost,proto3" json:"network_cost,omitempty"`
+	ProcessingSpeed   float64                `protobuf:"fixed64,12,opt,name=processing_speed,json=processingSpeed,proto3" json:"processing_speed,omitempty"`
+	Reliability       float64                `protobuf:"fixed64,13,opt,name=reliability,proto3" json:"reliability,omitempty"`
+	ComputeCost       float64                `protobuf:"fixed64,14,opt,name=compute_cost,json=computeCost,proto3" json:"compute_cost,omitempty"`
+	EnergyCost        float64                `protobuf:"fixed64,15,opt,name=energy_cost,json=energyCost,proto3" json:"energy_cost,omitempty"`
+	SecurityLevel     int32                  `protobuf:"varint,16,opt,name=security_level,json=securityLevel,proto3" json:"security_level,omitempty"`
+	DataJurisdiction  string                 `protobuf:"bytes,17,opt,name=data_jurisdiction,json=dataJurisdiction,proto3" json:"data_jurisdiction,omitempty"`
+	ComplianceFlags   []string               `protobuf:"bytes,18,rep,name=compliance_flags,json=complianceFlags,proto3" json:"compliance_flags,omitempty"`
+	EnergySource      string                 `protobuf:"bytes,19,opt,name=energy_source,json=energySource,proto3" json:"energy_source,omitempty"`
+	Capabilities      []string               `protobuf:"bytes,20,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	CurrentLoad       float64                `protobuf:"fixed64,21,opt,name=current_load,json=currentLoad,proto3" json:"current_load,omitempty"`
+	EstimatedWaitTime *durationpb.Duration   `protobuf:"bytes,22,opt,name=estimated_wait_time,json=estimatedWaitTime,proto3" json:"estimated_wait_time,omitempty"`
+	LastSeen          *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Power             *PowerProfile          `protobuf:"bytes,24,opt,name=power,proto3" json:"power,omitempty"`                                  // Unset when energy is priced by energy_cost
+	EnergyPrice       float64                `protobuf:"fixed64,25,opt,name=energy_price,json=energyPrice,proto3" json:"energy_price,omitempty"` // Per kWh, with a power profile
+	Temperature       float64                `protobuf:"fixed64,26,opt,name=temperature,proto3" json:"temperature,omitempty"`                    // °C; 0 when not reported
+	Battery           *BatteryState          `protobuf:"bytes,27,opt,name=battery,proto3" json:"battery,omitempty"`                              // Unset for mains-powered targets
+}
+
+func (x *OffloadTarget) Reset() {
+	*x = OffloadTarget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OffloadTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OffloadTarget) ProtoMessage() {}
+
+func (x *OffloadTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OffloadTarget.ProtoReflect.Descriptor instead.
+func (*OffloadTarget) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OffloadTarget) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OffloadTarget) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OffloadTarget) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *OffloadTarget) GetTotalCapacity() float64 {
+	if x != nil {
+		return x.TotalCapacity
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetAvailableCapacity() float64 {
+	if x != nil {
+		return x.AvailableCapacity
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetMemoryTotal() int64 {
+	if x != nil {
+		return x.MemoryTotal
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetMemoryAvailable() int64 {
+	if x != nil {
+		return x.MemoryAvailable
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetNetworkLatency() *durationpb.Duration {
+	if x != nil {
+		return x.NetworkLatency
+	}
+	return nil
+}
+
+func (x *OffloadTarget) GetNetworkBandwidth() float64 {
+	if x != nil {
+		return x.NetworkBandwidth
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetNetworkStability() float64 {
+	if x != nil {
+		return x.NetworkStability
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetNetworkCost() float64 {
+	if x != nil {
+		return x.NetworkCost
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetProcessingSpeed() float64 {
+	if x != nil {
+		return x.ProcessingSpeed
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetReliability() float64 {
+	if x != nil {
+		return x.Reliability
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetComputeCost() float64 {
+	if x != nil {
+		return x.ComputeCost
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetEnergyCost() float64 {
+	if x != nil {
+		return x.EnergyCost
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetSecurityLevel() int32 {
+	if x != nil {
+		return x.SecurityLevel
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetDataJurisdiction() string {
+	if x != nil {
+		return x.DataJurisdiction
+	}
+	return ""
+}
+
+func (x *OffloadTarget) GetComplianceFlags() []string {
+	if x != nil {
+		return x.ComplianceFlags
+	}
+	return nil
+}
+
+func (x *OffloadTarget) GetEnergySource() string {
+	if x != nil {
+		return x.EnergySource
+	}
+	return ""
+}
+
+func (x *OffloadTarget) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *OffloadTarget) GetCurrentLoad() float64 {
+	if x != nil {
+		return x.CurrentLoad
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetEstimatedWaitTime() *durationpb.Duration {
+	if x != nil {
+		return x.EstimatedWaitTime
+	}
+	return nil
+}
+
+func (x *OffloadTarget) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *OffloadTarget) GetPower() *PowerProfile {
+	if x != nil {
+		return x.Power
+	}
+	return nil
+}
+
+func (x *OffloadTarget) GetEnergyPrice() float64 {
+	if x != nil {
+		return x.EnergyPrice
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *OffloadTarget) GetBattery() *BatteryState {
+	if x != nil {
+		return x.Battery
+	}
+	return nil
+}
+
+type BatteryState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level          float64 `protobuf:"fixed64,1,opt,name=level,proto3" json:"level,omitempty"` // State of charge, 0.0-1.0
+	CapacityWh     float64 `protobuf:"fixed64,2,opt,name=capacity_wh,json=capacityWh,proto3" json:"capacity_wh,omitempty"`
+	Charging       bool    `protobuf:"varint,3,opt,name=charging,proto3" json:"charging,omitempty"`
+	DutyCycleLimit float64 `protobuf:"fixed64,4,opt,name=duty_cycle_limit,json=dutyCycleLimit,proto3" json:"duty_cycle_limit,omitempty"` // 0 = unlimited
+	DutyCycleUsed  float64 `protobuf:"fixed64,5,opt,name=duty_cycle_used,json=dutyCycleUsed,proto3" json:"duty_cycle_used,omitempty"`
+}
+
+func (x *BatteryState) Reset() {
+	*x = BatteryState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatteryState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatteryState) ProtoMessage() {}
+
+func (x *BatteryState) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatteryState.ProtoReflect.Descriptor instead.
+func (*BatteryState) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatteryState) GetLevel() float64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *BatteryState) GetCapacityWh() float64 {
+	if x != nil {
+		return x.CapacityWh
+	}
+	return 0
+}
+
+func (x *BatteryState) GetCharging() bool {
+	if x != nil {
+		return x.Charging
+	}
+	return false
+}
+
+func (x *BatteryState) GetDutyCycleLimit() float64 {
+	if x != nil {
+		return x.DutyCycleLimit
+	}
+	return 0
+}
+
+func (x *BatteryState) GetDutyCycleUsed() float64 {
+	if x != nil {
+		return x.DutyCycleUsed
+	}
+	return 0
+}
+
+type PowerProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IdleWatts float64 `protobuf:"fixed64,1,opt,name=idle_watts,json=idleWatts,proto3" json:"idle_watts,omitempty"`
+	PeakWatts float64 `protobuf:"fixed64,2,opt,name=peak_watts,json=peakWatts,proto3" json:"peak_watts,omitempty"`
+	// Share of the idle-to-peak range at evenly spaced utilizations from 0 to 1
+	Curve []float64 `protobuf:"fixed64,3,rep,packed,name=curve,proto3" json:"curve,omitempty"`
+	Pue   float64   `protobuf:"fixed64,4,opt,name=pue,proto3" json:"pue,omitempty"`
+}
+
+func (x *PowerProfile) Reset() {
+	*x = PowerProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PowerProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerProfile) ProtoMessage() {}
+
+func (x *PowerProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerProfile.ProtoReflect.Descriptor instead.
+func (*PowerProfile) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PowerProfile) GetIdleWatts() float64 {
+	if x != nil {
+		return x.IdleWatts
+	}
+	return 0
+}
+
+func (x *PowerProfile) GetPeakWatts() float64 {
+	if x != nil {
+		return x.PeakWatts
+	}
+	return 0
+}
+
+func (x *PowerProfile) GetCurve() []float64 {
+	if x != nil {
+		return x.Curve
+	}
+	return nil
+}
+
+func (x *PowerProfile) GetPue() float64 {
+	if x != nil {
+		return x.Pue
+	}
+	return 0
+}
+
+type SystemState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueueDepth        int32                  `protobuf:"varint,1,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	QueueThreshold    int32                  `protobuf:"varint,2,opt,name=queue_threshold,json=queueThreshold,proto3" json:"queue_threshold,omitempty"`
+	QueueWaitTime     *durationpb.Duration   `protobuf:"bytes,3,opt,name=queue_wait_time,json=queueWaitTime,proto3" json:"queue_wait_time,omitempty"`
+	QueueThroughput   float64                `protobuf:"fixed64,4,opt,name=queue_throughput,json=queueThroughput,proto3" json:"queue_throughput,omitempty"`
+	ComputeUsage      float64                `protobuf:"fixed64,5,opt,name=compute_usage,json=computeUsage,proto3" json:"compute_usage,omitempty"`
+	MemoryUsage       float64                `protobuf:"fixed64,6,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
+	DiskUsage         float64                `protobuf:"fixed64,7,opt,name=disk_usage,json=diskUsage,proto3" json:"disk_usage,omitempty"`
+	NetworkUsage      float64                `protobuf:"fixed64,8,opt,name=network_usage,json=networkUsage,proto3" json:"network_usage,omitempty"`
+	MasterUsage       float64                `protobuf:"fixed64,9,opt,name=master_usage,json=masterUsage,proto3" json:"master_usage,omitempty"`
+	ActiveConnections int32                  `protobuf:"varint,10,opt,name=active_connections,json=activeConnections,proto3" json:"active_connections,omitempty"`
+	Timestamp         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *SystemState) Reset() {
+	*x = SystemState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemState) ProtoMessage() {}
+
+func (x *SystemState) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemState.ProtoReflect.Descriptor instead.
+func (*SystemState) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SystemState) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *SystemState) GetQueueThreshold() int32 {
+	if x != nil {
+		return x.QueueThreshold
+	}
+	return 0
+}
+
+func (x *SystemState) GetQueueWaitTime() *durationpb.Duration {
+	if x != nil {
+		return x.QueueWaitTime
+	}
+	return nil
+}
+
+func (x *SystemState) GetQueueThroughput() float64 {
+	if x != nil {
+		return x.QueueThroughput
+	}
+	return 0
+}
+
+func (x *SystemState) GetComputeUsage() float64 {
+	if x != nil {
+		return x.ComputeUsage
+	}
+	return 0
+}
+
+func (x *SystemState) GetMemoryUsage() float64 {
+	if x != nil {
+		return x.MemoryUsage
+	}
+	return 0
+}
+
+func (x *SystemState) GetDiskUsage() float64 {
+	if x != nil {
+		return x.DiskUsage
+	}
+	return 0
+}
+
+func (x *SystemState) GetNetworkUsage() float64 {
+	if x != nil {
+		return x.NetworkUsage
+	}
+	return 0
+}
+
+func (x *SystemState) GetMasterUsage() float64 {
+	if x != nil {
+		return x.MasterUsage
+	}
+	return 0
+}
+
+func (x *SystemState) GetActiveConnections() int32 {
+	if x != nil {
+		return x.ActiveConnections
+	}
+	return 0
+}
+
+func (x *SystemState) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type DecideRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Process *Process         `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
+	Targets []*OffloadTarget `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	State   *SystemState     `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// Decision deadline; CAPE also applies its own MaxDecisionLatency.
+	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *DecideRequest) Reset() {
+	*x = DecideRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideRequest) ProtoMessage() {}
+
+func (x *DecideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideRequest.ProtoReflect.Descriptor instead.
+func (*DecideRequest) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DecideRequest) GetProcess() *Process {
+	if x != nil {
+		return x.Process
+	}
+	return nil
+}
+
+func (x *DecideRequest) GetTargets() []*OffloadTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *DecideRequest) GetState() *SystemState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *DecideRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Decision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DecisionId        string               `protobuf:"bytes,1,opt,name=decision_id,json=decisionId,proto3" json:"decision_id,omitempty"`
+	ShouldOffload     bool                 `protobuf:"varint,2,opt,name=should_offload,json=shouldOffload,proto3" json:"should_offload,omitempty"`
+	TargetId          string               `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // Empty when the process stays local
+	Confidence        float64              `protobuf:"fixed64,4,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Score             float64              `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	Strategy          string               `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	ExpectedBenefit   float64              `protobuf:"fixed64,7,opt,name=expected_benefit,json=expectedBenefit,proto3" json:"expected_benefit,omitempty"`
+	EstimatedCost     float64              `protobuf:"fixed64,8,opt,name=estimated_cost,json=estimatedCost,proto3" json:"estimated_cost,omitempty"`
+	DataSize          int64                `protobuf:"varint,9,opt,name=data_size,json=dataSize,proto3" json:"data_size,omitempty"`
+	TransferTime      *durationpb.Duration `protobuf:"bytes,10,opt,name=transfer_time,json=transferTime,proto3" json:"transfer_time,omitempty"`
+	RetrievalTime     *durationpb.Duration `protobuf:"bytes,11,opt,name=retrieval_time,json=retrievalTime,proto3" json:"retrieval_time,omitempty"`
+	PolicyViolations  []string             `protobuf:"bytes,12,rep,name=policy_violations,json=policyViolations,proto3" json:"policy_violations,omitempty"`
+	DecisionLatency   *durationpb.Duration `protobuf:"bytes,13,opt,name=decision_latency,json=decisionLatency,proto3" json:"decision_latency,omitempty"`
+	AlgorithmVersion  string               `protobuf:"bytes,14,opt,name=algorithm_version,json=algorithmVersion,proto3" json:"algorithm_version,omitempty"`
+	EstimatedEnergyWh float64              `protobuf:"fixed64,15,opt,name=estimated_energy_wh,json=estimatedEnergyWh,proto3" json:"estimated_energy_wh,omitempty"`
+}
+
+func (x *Decision) Reset() {
+	*x = Decision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decision) ProtoMessage() {}
+
+func (x *Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decision.ProtoReflect.Descriptor instead.
+func (*Decision) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Decision) GetDecisionId() string {
+	if x != nil {
+		return x.DecisionId
+	}
+	return ""
+}
+
+func (x *Decision) GetShouldOffload() bool {
+	if x != nil {
+		return x.ShouldOffload
+	}
+	return false
+}
+
+func (x *Decision) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Decision) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *Decision) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Decision) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Decision) GetExpectedBenefit() float64 {
+	if x != nil {
+		return x.ExpectedBenefit
+	}
+	return 0
+}
+
+func (x *Decision) GetEstimatedCost() float64 {
+	if x != nil {
+		return x.EstimatedCost
+	}
+	return 0
+}
+
+func (x *Decision) GetDataSize() int64 {
+	if x != nil {
+		return x.DataSize
+	}
+	return 0
+}
+
+func (x *Decision) GetTransferTime() *durationpb.Duration {
+	if x != nil {
+		return x.TransferTime
+	}
+	return nil
+}
+
+func (x *Decision) GetRetrievalTime() *durationpb.Duration {
+	if x != nil {
+		return x.RetrievalTime
+	}
+	return nil
+}
+
+func (x *Decision) GetPolicyViolations() []string {
+	if x != nil {
+		return x.PolicyViolations
+	}
+	return nil
+}
+
+func (x *Decision) GetDecisionLatency() *durationpb.Duration {
+	if x != nil {
+		return x.DecisionLatency
+	}
+	return nil
+}
+
+func (x *Decision) GetAlgorithmVersion() string {
+	if x != nil {
+		return x.AlgorithmVersion
+	}
+	return ""
+}
+
+func (x *Decision) GetEstimatedEnergyWh() float64 {
+	if x != nil {
+		return x.EstimatedEnergyWh
+	}
+	return 0
+}
+
+type Outcome struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DecisionId        string               `protobuf:"bytes,1,opt,name=decision_id,json=decisionId,proto3" json:"decision_id,omitempty"`
+	ProcessId         string               `protobuf:"bytes,2,opt,name=process_id,json=processId,proto3" json:"process_id,omitempty"`
+	TargetId          string               `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Success           bool                 `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	CompletedOnTime   bool                 `protobuf:"varint,5,opt,name=completed_on_time,json=completedOnTime,proto3" json:"completed_on_time,omitempty"`
+	ErrorType         string               `protobuf:"bytes,6,opt,name=error_type,json=errorType,proto3" json:"error_type,omitempty"`
+	ExecutionTime     *durationpb.Duration `protobuf:"bytes,7,opt,name=execution_time,json=executionTime,proto3" json:"execution_time,omitempty"`
+	LatencyActual     *durationpb.Duration `protobuf:"bytes,8,opt,name=latency_actual,json=latencyActual,proto3" json:"latency_actual,omitempty"`
+	CostActual        float64              `protobuf:"fixed64,9,opt,name=cost_actual,json=costActual,proto3" json:"cost_actual,omitempty"`
+	EnergyConsumed    float64              `protobuf:"fixed64,10,opt,name=energy_consumed,json=energyConsumed,proto3" json:"energy_consumed,omitempty"`
+	NetworkCongestion bool                 `protobuf:"varint,11,opt,name=network_congestion,json=networkCongestion,proto3" json:"network_congestion,omitempty"`
+	TargetOverloaded  bool                 `protobuf:"varint,12,opt,name=target_overloaded,json=targetOverloaded,proto3" json:"target_overloaded,omitempty"`
+	PolicyViolation   bool                 `protobuf:"varint,13,opt,name=policy_violation,json=policyViolation,proto3" json:"policy_violation,omitempty"`
+	Reward            float64              `protobuf:"fixed64,14,opt,name=reward,proto3" json:"reward,omitempty"`
+}
+
+func (x *Outcome) Reset() {
+	*x = Outcome{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Outcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Outcome) ProtoMessage() {}
+
+func (x *Outcome) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Outcome.ProtoReflect.Descriptor instead.
+func (*Outcome) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Outcome) GetDecisionId() string {
+	if x != nil {
+		return x.DecisionId
+	}
+	return ""
+}
+
+func (x *Outcome) GetProcessId() string {
+	if x != nil {
+		return x.ProcessId
+	}
+	return ""
+}
+
+func (x *Outcome) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Outcome) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *Outcome) GetCompletedOnTime() bool {
+	if x != nil {
+		return x.CompletedOnTime
+	}
+	return false
+}
+
+func (x *Outcome) GetErrorType() string {
+	if x != nil {
+		return x.ErrorType
+	}
+	return ""
+}
+
+func (x *Outcome) GetExecutionTime() *durationpb.Duration {
+	if x != nil {
+		return x.ExecutionTime
+	}
+	return nil
+}
+
+func (x *Outcome) GetLatencyActual() *durationpb.Duration {
+	if x != nil {
+		return x.LatencyActual
+	}
+	return nil
+}
+
+func (x *Outcome) GetCostActual() float64 {
+	if x != nil {
+		return x.CostActual
+	}
+	return 0
+}
+
+func (x *Outcome) GetEnergyConsumed() float64 {
+	if x != nil {
+		return x.EnergyConsumed
+	}
+	return 0
+}
+
+func (x *Outcome) GetNetworkCongestion() bool {
+	if x != nil {
+		return x.NetworkCongestion
+	}
+	return false
+}
+
+func (x *Outcome) GetTargetOverloaded() bool {
+	if x != nil {
+		return x.TargetOverloaded
+	}
+	return false
+}
+
+func (x *Outcome) GetPolicyViolation() bool {
+	if x != nil {
+		return x.PolicyViolation
+	}
+	return false
+}
+
+func (x *Outcome) GetReward() float64 {
+	if x != nil {
+		return x.Reward
+	}
+	return 0
+}
+
+type ReportOutcomeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReportOutcomeResponse) Reset() {
+	*x = ReportOutcomeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportOutcomeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportOutcomeResponse) ProtoMessage() {}
+
+func (x *ReportOutcomeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportOutcomeResponse.ProtoReflect.Descriptor instead.
+func (*ReportOutcomeResponse) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{8}
+}
+
+type GetWeightsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetWeightsRequest) Reset() {
+	*x = GetWeightsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWeightsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWeightsRequest) ProtoMessage() {}
+
+func (x *GetWeightsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWeightsRequest.ProtoReflect.Descriptor instead.
+func (*GetWeightsRequest) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{9}
+}
+
+type Weights struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueueDepth    float64 `protobuf:"fixed64,1,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	ProcessorLoad float64 `protobuf:"fixed64,2,opt,name=processor_load,json=processorLoad,proto3" json:"processor_load,omitempty"`
+	NetworkCost   float64 `protobuf:"fixed64,3,opt,name=network_cost,json=networkCost,proto3" json:"network_cost,omitempty"`
+	LatencyCost   float64 `protobuf:"fixed64,4,opt,name=latency_cost,json=latencyCost,proto3" json:"latency_cost,omitempty"`
+	EnergyCost    float64 `protobuf:"fixed64,5,opt,name=energy_cost,json=energyCost,proto3" json:"energy_cost,omitempty"`
+	PolicyCost    float64 `protobuf:"fixed64,6,opt,name=policy_cost,json=policyCost,proto3" json:"policy_cost,omitempty"`
+}
+
+func (x *Weights) Reset() {
+	*x = Weights{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Weights) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Weights) ProtoMessage() {}
+
+func (x *Weights) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Weights.ProtoReflect.Descriptor instead.
+func (*Weights) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Weights) GetQueueDepth() float64 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *Weights) GetProcessorLoad() float64 {
+	if x != nil {
+		return x.ProcessorLoad
+	}
+	return 0
+}
+
+func (x *Weights) GetNetworkCost() float64 {
+	if x != nil {
+		return x.NetworkCost
+	}
+	return 0
+}
+
+func (x *Weights) GetLatencyCost() float64 {
+	if x != nil {
+		return x.LatencyCost
+	}
+	return 0
+}
+
+func (x *Weights) GetEnergyCost() float64 {
+	if x != nil {
+		return x.EnergyCost
+	}
+	return 0
+}
+
+func (x *Weights) GetPolicyCost() float64 {
+	if x != nil {
+		return x.PolicyCost
+	}
+	return 0
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{11}
+}
+
+type PhaseStat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int64                `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Mean  *durationpb.Duration `protobuf:"bytes,2,opt,name=mean,proto3" json:"mean,omitempty"`
+	Max   *durationpb.Duration `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *PhaseStat) Reset() {
+	*x = PhaseStat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhaseStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseStat) ProtoMessage() {}
+
+func (x *PhaseStat) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseStat.ProtoReflect.Descriptor instead.
+func (*PhaseStat) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PhaseStat) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PhaseStat) GetMean() *durationpb.Duration {
+	if x != nil {
+		return x.Mean
+	}
+	return nil
+}
+
+func (x *PhaseStat) GetMax() *durationpb.Duration {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+type WindowStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Decisions        int64                `protobuf:"varint,1,opt,name=decisions,proto3" json:"decisions,omitempty"`
+	Offloads         int64                `protobuf:"varint,2,opt,name=offloads,proto3" json:"offloads,omitempty"`
+	DecisionRate     float64              `protobuf:"fixed64,3,opt,name=decision_rate,json=decisionRate,proto3" json:"decision_rate,omitempty"`
+	MeanDecisionTime *durationpb.Duration `protobuf:"bytes,4,opt,name=mean_decision_time,json=meanDecisionTime,proto3" json:"mean_decision_time,omitempty"`
+	Outcomes         int64                `protobuf:"varint,5,opt,name=outcomes,proto3" json:"outcomes,omitempty"`
+	SuccessRate      float64              `protobuf:"fixed64,6,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+}
+
+func (x *WindowStats) Reset() {
+	*x = WindowStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WindowStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowStats) ProtoMessage() {}
+
+func (x *WindowStats) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowStats.ProtoReflect.Descriptor instead.
+func (*WindowStats) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WindowStats) GetDecisions() int64 {
+	if x != nil {
+		return x.Decisions
+	}
+	return 0
+}
+
+func (x *WindowStats) GetOffloads() int64 {
+	if x != nil {
+		return x.Offloads
+	}
+	return 0
+}
+
+func (x *WindowStats) GetDecisionRate() float64 {
+	if x != nil {
+		return x.DecisionRate
+	}
+	return 0
+}
+
+func (x *WindowStats) GetMeanDecisionTime() *durationpb.Duration {
+	if x != nil {
+		return x.MeanDecisionTime
+	}
+	return nil
+}
+
+func (x *WindowStats) GetOutcomes() int64 {
+	if x != nil {
+		return x.Outcomes
+	}
+	return 0
+}
+
+func (x *WindowStats) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+type DecisionStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Decisions          int64                `protobuf:"varint,1,opt,name=decisions,proto3" json:"decisions,omitempty"`
+	Offloads           int64                `protobuf:"varint,2,opt,name=offloads,proto3" json:"offloads,omitempty"`
+	MeanDecisionTime   *durationpb.Duration `protobuf:"bytes,3,opt,name=mean_decision_time,json=meanDecisionTime,proto3" json:"mean_decision_time,omitempty"`
+	RecentDecisionTime *durationpb.Duration `protobuf:"bytes,4,opt,name=recent_decision_time,json=recentDecisionTime,proto3" json:"recent_decision_time,omitempty"`
+	Outcomes           int64                `protobuf:"varint,5,opt,name=outcomes,proto3" json:"outcomes,omitempty"`
+	SuccessRate        float64              `protobuf:"fixed64,6,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	RecentSuccessRate  float64              `protobuf:"fixed64,7,opt,name=recent_success_rate,json=recentSuccessRate,proto3" json:"recent_success_rate,omitempty"`
+	// Trailing 5m, 1h and 24h views, by window name.
+	Windows map[string]*WindowStats `protobuf:"bytes,8,rep,name=windows,proto3" json:"windows,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DecisionStats) Reset() {
+	*x = DecisionStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecisionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecisionStats) ProtoMessage() {}
+
+func (x *DecisionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecisionStats.ProtoReflect.Descriptor instead.
+func (*DecisionStats) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DecisionStats) GetDecisions() int64 {
+	if x != nil {
+		return x.Decisions
+	}
+	return 0
+}
+
+func (x *DecisionStats) GetOffloads() int64 {
+	if x != nil {
+		return x.Offloads
+	}
+	return 0
+}
+
+func (x *DecisionStats) GetMeanDecisionTime() *durationpb.Duration {
+	if x != nil {
+		return x.MeanDecisionTime
+	}
+	return nil
+}
+
+func (x *DecisionStats) GetRecentDecisionTime() *durationpb.Duration {
+	if x != nil {
+		return x.RecentDecisionTime
+	}
+	return nil
+}
+
+func (x *DecisionStats) GetOutcomes() int64 {
+	if x != nil {
+		return x.Outcomes
+	}
+	return 0
+}
+
+func (x *DecisionStats) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *DecisionStats) GetRecentSuccessRate() float64 {
+	if x != nil {
+		return x.RecentSuccessRate
+	}
+	return 0
+}
+
+func (x *DecisionStats) GetWindows() map[string]*WindowStats {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+type Stats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DecisionCount      int64                 `protobuf:"varint,1,opt,name=decision_count,json=decisionCount,proto3" json:"decision_count,omitempty"`
+	CurrentWeights     *Weights              `protobuf:"bytes,2,opt,name=current_weights,json=currentWeights,proto3" json:"current_weights,omitempty"`
+	DiscoveredPatterns int32                 `protobuf:"varint,3,opt,name=discovered_patterns,json=discoveredPatterns,proto3" json:"discovered_patterns,omitempty"`
+	ValidatedPatterns  int32                 `protobuf:"varint,4,opt,name=validated_patterns,json=validatedPatterns,proto3" json:"validated_patterns,omitempty"`
+	PerformanceGain    float64               `protobuf:"fixed64,5,opt,name=performance_gain,json=performanceGain,proto3" json:"performance_gain,omitempty"`
+	IsConverged        bool                  `protobuf:"varint,6,opt,name=is_converged,json=isConverged,proto3" json:"is_converged,omitempty"`
+	PhaseStats         map[string]*PhaseStat `protobuf:"bytes,7,rep,name=phase_stats,json=phaseStats,proto3" json:"phase_stats,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Version            string                `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	Stats              *DecisionStats        `protobuf:"bytes,9,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Stats) GetDecisionCount() int64 {
+	if x != nil {
+		return x.DecisionCount
+	}
+	return 0
+}
+
+func (x *Stats) GetCurrentWeights() *Weights {
+	if x != nil {
+		return x.CurrentWeights
+	}
+	return nil
+}
+
+func (x *Stats) GetDiscoveredPatterns() int32 {
+	if x != nil {
+		return x.DiscoveredPatterns
+	}
+	return 0
+}
+
+func (x *Stats) GetValidatedPatterns() int32 {
+	if x != nil {
+		return x.ValidatedPatterns
+	}
+	return 0
+}
+
+func (x *Stats) GetPerformanceGain() float64 {
+	if x != nil {
+		return x.PerformanceGain
+	}
+	return 0
+}
+
+func (x *Stats) GetIsConverged() bool {
+	if x != nil {
+		return x.IsConverged
+	}
+	return false
+}
+
+func (x *Stats) GetPhaseStats() map[string]*PhaseStat {
+	if x != nil {
+		return x.PhaseStats
+	}
+	return nil
+}
+
+func (x *Stats) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Stats) GetStats() *DecisionStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type ListSpikeReportsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSpikeReportsRequest) Reset() {
+	*x = ListSpikeReportsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSpikeReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpikeReportsRequest) ProtoMessage() {}
+
+func (x *ListSpikeReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpikeReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListSpikeReportsRequest) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{16}
+}
+
+type SpikeReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartedAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	DetectedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	EndedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	DetectionLag    *durationpb.Duration   `protobuf:"bytes,5,opt,name=detection_lag,json=detectionLag,proto3" json:"detection_lag,omitempty"`
+	PreScaleLead    *durationpb.Duration   `protobuf:"bytes,6,opt,name=pre_scale_lead,json=preScaleLead,proto3" json:"pre_scale_lead,omitempty"`
+	PeakQueueDepth  int32                  `protobuf:"varint,7,opt,name=peak_queue_depth,json=peakQueueDepth,proto3" json:"peak_queue_depth,omitempty"`
+	Processes       int32                  `protobuf:"varint,8,opt,name=processes,proto3" json:"processes,omitempty"`
+	SlaViolations   int32                  `protobuf:"varint,9,opt,name=sla_violations,json=slaViolations,proto3" json:"sla_violations,omitempty"`
+	PendingOutcomes int32                  `protobuf:"varint,10,opt,name=pending_outcomes,json=pendingOutcomes,proto3" json:"pending_outcomes,omitempty"`
+	Cost            float64                `protobuf:"fixed64,11,opt,name=cost,proto3" json:"cost,omitempty"`
+}
+
+func (x *SpikeReport) Reset() {
+	*x = SpikeReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpikeReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpikeReport) ProtoMessage() {}
+
+func (x *SpikeReport) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpikeReport.ProtoReflect.Descriptor instead.
+func (*SpikeReport) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SpikeReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SpikeReport) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *SpikeReport) GetDetectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return nil
+}
+
+func (x *SpikeReport) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *SpikeReport) GetDetectionLag() *durationpb.Duration {
+	if x != nil {
+		return x.DetectionLag
+	}
+	return nil
+}
+
+func (x *SpikeReport) GetPreScaleLead() *durationpb.Duration {
+	if x != nil {
+		return x.PreScaleLead
+	}
+	return nil
+}
+
+func (x *SpikeReport) GetPeakQueueDepth() int32 {
+	if x != nil {
+		return x.PeakQueueDepth
+	}
+	return 0
+}
+
+func (x *SpikeReport) GetProcesses() int32 {
+	if x != nil {
+		return x.Processes
+	}
+	return 0
+}
+
+func (x *SpikeReport) GetSlaViolations() int32 {
+	if x != nil {
+		return x.SlaViolations
+	}
+	return 0
+}
+
+func (x *SpikeReport) GetPendingOutcomes() int32 {
+	if x != nil {
+		return x.PendingOutcomes
+	}
+	return 0
+}
+
+func (x *SpikeReport) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type ListSpikeReportsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reports []*SpikeReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+}
+
+func (x *ListSpikeReportsResponse) Reset() {
+	*x = ListSpikeReportsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSpikeReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpikeReportsResponse) ProtoMessage() {}
+
+func (x *ListSpikeReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpikeReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListSpikeReportsResponse) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListSpikeReportsResponse) GetReports() []*SpikeReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type GetChargebackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Month string `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"` // YYYY-MM; empty for every month
+}
+
+func (x *GetChargebackRequest) Reset() {
+	*x = GetChargebackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChargebackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChargebackRequest) ProtoMessage() {}
+
+func (x *GetChargebackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChargebackRequest.ProtoReflect.Descriptor instead.
+func (*GetChargebackRequest) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetChargebackRequest) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+type CostBreakdown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Infra    float64 `protobuf:"fixed64,1,opt,name=infra,proto3" json:"infra,omitempty"`
+	Transfer float64 `protobuf:"fixed64,2,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	Energy   float64 `protobuf:"fixed64,3,opt,name=energy,proto3" json:"energy,omitempty"`
+}
+
+func (x *CostBreakdown) Reset() {
+	*x = CostBreakdown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CostBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostBreakdown) ProtoMessage() {}
+
+func (x *CostBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostBreakdown.ProtoReflect.Descriptor instead.
+func (*CostBreakdown) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CostBreakdown) GetInfra() float64 {
+	if x != nil {
+		return x.Infra
+	}
+	return 0
+}
+
+func (x *CostBreakdown) GetTransfer() float64 {
+	if x != nil {
+		return x.Transfer
+	}
+	return 0
+}
+
+func (x *CostBreakdown) GetEnergy() float64 {
+	if x != nil {
+		return x.Energy
+	}
+	return 0
+}
+
+type ChargebackRollup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Month      string         `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`
+	TenantId   string         `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ProjectId  string         `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Executions int32          `protobuf:"varint,4,opt,name=executions,proto3" json:"executions,omitempty"`
+	Cost       *CostBreakdown `protobuf:"bytes,5,opt,name=cost,proto3" json:"cost,omitempty"`
+	Currency   string         `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217 code of the cost
+}
+
+func (x *ChargebackRollup) Reset() {
+	*x = ChargebackRollup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChargebackRollup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargebackRollup) ProtoMessage() {}
+
+func (x *ChargebackRollup) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargebackRollup.ProtoReflect.Descriptor instead.
+func (*ChargebackRollup) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ChargebackRollup) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *ChargebackRollup) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ChargebackRollup) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ChargebackRollup) GetExecutions() int32 {
+	if x != nil {
+		return x.Executions
+	}
+	return 0
+}
+
+func (x *ChargebackRollup) GetCost() *CostBreakdown {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+func (x *ChargebackRollup) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetChargebackResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rollups []*ChargebackRollup `protobuf:"bytes,1,rep,name=rollups,proto3" json:"rollups,omitempty"`
+}
+
+func (x *GetChargebackResponse) Reset() {
+	*x = GetChargebackResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cape_v1_cape_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChargebackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChargebackResponse) ProtoMessage() {}
+
+func (x *GetChargebackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cape_v1_cape_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChargebackResponse.ProtoReflect.Descriptor instead.
+func (*GetChargebackResponse) Descriptor() ([]byte, []int) {
+	return file_cape_v1_cape_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetChargebackResponse) GetRollups() []*ChargebackRollup {
+	if x != nil {
+		return x.Rollups
+	}
+	return nil
+}
+
+var File_cape_v1_cape_proto protoreflect.FileDescriptor
+
+var file_cape_v1_cape_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x63, 0x61, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe1,
+	0x08, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70,
+	0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x70, 0x75, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0e, 0x63, 0x70, 0x75, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x2d, 0x0a, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x29, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x64, 0x69, 0x73, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x13, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x49,
+	0x64, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x69, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x54, 0x69, 0x65, 0x72, 0x12,
+	0x29, 0x0a, 0x10, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76,
+	0x69, 0x74, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x64, 0x61, 0x74, 0x61, 0x53,
+	0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x48, 0x0a, 0x12, 0x65, 0x73,
+	0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x11, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x27, 0x0a, 0x0f, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x5f, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63,
+	0x61, 0x6c, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79,
+	0x43, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x70, 0x61, 0x72, 0x61,
+	0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x7a, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x7a, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18, 0x14,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x67, 0x61, 0x6e, 0x67, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x15, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x67, 0x61, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x16, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
+	0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x25, 0x0a,
+	0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x72, 0x65, 0x73,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x19, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x73, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73,
+	0x63, 0x63, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x1a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x73, 0x63, 0x63, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x43,
+	0x0a, 0x0f, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x64, 0x22, 0xd7, 0x08, 0x0a, 0x0d, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x2d, 0x0a, 0x12, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x29, 0x0a,
+	0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x41,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x42, 0x0a, 0x0f, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2b, 0x0a, 0x11,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x2b, 0x0a, 0x11, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x53,
+	0x70, 0x65, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x69, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x63, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x65,
+	0x72, 0x67, 0x79, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6a, 0x75, 0x72, 0x69, 0x73, 0x64,
+	0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64, 0x61,
+	0x74, 0x61, 0x4a, 0x75, 0x72, 0x69, 0x73, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29,
+	0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x66, 0x6c, 0x61,
+	0x67, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x69,
+	0x61, 0x6e, 0x63, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6e, 0x65,
+	0x72, 0x67, 0x79, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x22,
+	0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x14,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x6f,
+	0x61, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x4c, 0x6f, 0x61, 0x64, 0x12, 0x49, 0x0a, 0x13, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x65,
+	0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x57, 0x61, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x37, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x2b, 0x0a, 0x05, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52,
+	0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79,
+	0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x65, 0x6e,
+	0x65, 0x72, 0x67, 0x79, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b,
+	0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x62,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63,
+	0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x07, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x22, 0xb3, 0x01, 0x0a,
+	0x0c, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x5f,
+	0x77, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69,
+	0x74, 0x79, 0x57, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67,
+	0x12, 0x28, 0x0a, 0x10, 0x64, 0x75, 0x74, 0x79, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x64, 0x75, 0x74, 0x79,
+	0x43, 0x79, 0x63, 0x6c, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x64, 0x75,
+	0x74, 0x79, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0d, 0x64, 0x75, 0x74, 0x79, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x55, 0x73,
+	0x65, 0x64, 0x22, 0x74, 0x0a, 0x0c, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x77, 0x61, 0x74, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x69, 0x64, 0x6c, 0x65, 0x57, 0x61, 0x74, 0x74,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x77, 0x61, 0x74, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x70, 0x65, 0x61, 0x6b, 0x57, 0x61, 0x74, 0x74, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x75, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x03, 0x70, 0x75, 0x65, 0x22, 0xdd, 0x03, 0x0a, 0x0b, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x71,
+	0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x12, 0x41, 0x0a, 0x0f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x77, 0x61, 0x69, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x71, 0x75, 0x65, 0x75, 0x65, 0x57, 0x61, 0x69,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x74,
+	0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x5f, 0x75, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f,
+	0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b,
+	0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x69,
+	0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x2d, 0x0a, 0x12, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x38,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xce, 0x01, 0x0a, 0x0d, 0x44, 0x65, 0x63,
+	0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x61,
+	0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x07, 0x70,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
+	0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x82, 0x05, 0x0a, 0x08, 0x44, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x68, 0x6f, 0x75, 0x6c,
+	0x64, 0x5f, 0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0d, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x29, 0x0a,
+	0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x6e, 0x65, 0x66, 0x69,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x42, 0x65, 0x6e, 0x65, 0x66, 0x69, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x73, 0x74, 0x69,
+	0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0d, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x3e, 0x0a, 0x0d,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x0e,
+	0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0d, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2b,
+	0x0a, 0x11, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x44, 0x0a, 0x10, 0x64,
+	0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0f, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x61, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e,
+	0x0a, 0x13, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x65, 0x72,
+	0x67, 0x79, 0x5f, 0x77, 0x68, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x65, 0x73, 0x74,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x57, 0x68, 0x22, 0xb8,
+	0x04, 0x0a, 0x07, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x6f,
+	0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x40, 0x0a, 0x0e,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0d, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x40,
+	0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0d, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6f, 0x73, 0x74, 0x41, 0x63, 0x74, 0x75, 0x61,
+	0x6c, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x73,
+	0x75, 0x6d, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x65, 0x6e, 0x65, 0x72,
+	0x67, 0x79, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43,
+	0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72,
+	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x5f, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xd9, 0x01, 0x0a, 0x07, 0x57, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x65, 0x70,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x44,
+	0x65, 0x70, 0x74, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f,
+	0x72, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x4c, 0x6f, 0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x43, 0x6f, 0x73,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x63, 0x6f, 0x73, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x43, 0x6f,
+	0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x63, 0x6f, 0x73,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x43,
+	0x6f, 0x73, 0x74, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x7d, 0x0a, 0x09, 0x50, 0x68, 0x61, 0x73, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x65, 0x61,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x04, 0x6d, 0x65, 0x61, 0x6e, 0x12, 0x2b, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0xf4, 0x01, 0x0a, 0x0b, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x61, 0x74, 0x65, 0x12, 0x47, 0x0a, 0x12, 0x6d, 0x65, 0x61, 0x6e, 0x5f, 0x64, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x6d, 0x65, 0x61,
+	0x6e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0b, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x61, 0x74, 0x65, 0x22, 0xdf, 0x03, 0x0a,
+	0x0d, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x47, 0x0a, 0x12, 0x6d, 0x65, 0x61, 0x6e,
+	0x5f, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x10, 0x6d, 0x65, 0x61, 0x6e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x4b, 0x0a, 0x14, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x72, 0x65, 0x63, 0x65,
+	0x6e, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x61, 0x74, 0x65, 0x12, 0x2e, 0x0a,
+	0x13, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x72, 0x65, 0x63, 0x65,
+	0x6e, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x61, 0x74, 0x65, 0x12, 0x3d, 0x0a,
+	0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x1a, 0x50, 0x0a, 0x0c,
+	0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf3,
+	0x03, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x39, 0x0a, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x0e, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x64, 0x69,
+	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x65, 0x64, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x65,
+	0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x67, 0x61, 0x69, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x70, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x47, 0x61, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x67, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67, 0x65, 0x64, 0x12, 0x3f, 0x0a, 0x0b, 0x70, 0x68, 0x61, 0x73,
+	0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x50, 0x68,
+	0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70,
+	0x68, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x1a, 0x51, 0x0a, 0x0f, 0x50, 0x68, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x68, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x19, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x70, 0x69, 0x6b,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0xfb, 0x03, 0x0a, 0x0b, 0x53, 0x70, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x64, 0x65,
+	0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x64, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3e,
+	0x0a, 0x0d, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x67, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0c, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x12, 0x3f,
+	0x0a, 0x0e, 0x70, 0x72, 0x65, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f, 0x6c, 0x65, 0x61, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x4c, 0x65, 0x61, 0x64, 0x12,
+	0x28, 0x0a, 0x10, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x65,
+	0x70, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x65, 0x61, 0x6b, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x6c, 0x61, 0x5f, 0x76,
+	0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x73, 0x6c, 0x61, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29,
+	0x0a, 0x10, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d,
+	0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73,
+	0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x22, 0x4a, 0x0a,
+	0x18, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x70, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x61, 0x70,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x2c, 0x0a, 0x14, 0x47, 0x65, 0x74,
+	0x43, 0x68, 0x61, 0x72, 0x67, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x22, 0x59, 0x0a, 0x0d, 0x43, 0x6f, 0x73, 0x74, 0x42,
+	0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x66, 0x72,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x69, 0x6e, 0x66, 0x72, 0x61, 0x12, 0x1a,
+	0x0a, 0x08, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e,
+	0x65, 0x72, 0x67, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x65, 0x6e, 0x65, 0x72,
+	0x67, 0x79, 0x22, 0xcc, 0x01, 0x0a, 0x10, 0x43, 0x68, 0x61, 0x72, 0x67, 0x65, 0x62, 0x61, 0x63,
+	0x6b, 0x52, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2a, 0x0a, 0x04, 0x63, 0x6f, 0x73,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x73, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52,
+	0x04, 0x63, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x22, 0x4c, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x72, 0x67, 0x65, 0x62, 0x61,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x6f,
+	0x6c, 0x6c, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x61,
+	0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x72, 0x67, 0x65, 0x62, 0x61, 0x63, 0x6b,
+	0x52, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x52, 0x07, 0x72, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x73, 0x32,
+	0xa4, 0x03, 0x0a, 0x0f, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x12, 0x16, 0x2e,
+	0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x10, 0x2e, 0x63, 0x61, 0x70, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x1a, 0x1e, 0x2e, 0x63, 0x61,
+	0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x47,
+	0x65, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x61, 0x70, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e,
+	0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x57, 0x0a,
+	0x10, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x70, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x12, 0x20, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x70, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x70, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61,
+	0x72, 0x67, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x1d, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x72, 0x67, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x61, 0x70, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x72, 0x67, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x57, 0x5a, 0x55, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x61, 0x73, 0x70, 0x65, 0x72, 0x6c, 0x75, 0x6e, 0x64, 0x62,
+	0x65, 0x72, 0x67, 0x2f, 0x63, 0x6f, 0x6c, 0x6f, 0x6e, 0x79, 0x2d, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x2d, 0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2d, 0x61, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x63, 0x61, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x61, 0x70, 0x65, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_cape_v1_cape_proto_rawDescOnce sync.Once
+	file_cape_v1_cape_proto_rawDescData = file_cape_v1_cape_proto_rawDesc
+)
+
+func file_cape_v1_cape_proto_rawDescGZIP() []byte {
+	file_cape_v1_cape_proto_rawDescOnce.Do(func() {
+		file_cape_v1_cape_proto_rawDescData = protoimpl.X.CompressGZIP(file_cape_v1_cape_proto_rawDescData)
+	})
+	return file_cape_v1_cape_proto_rawDescData
+}
+
+var file_cape_v1_cape_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_cape_v1_cape_proto_goTypes = []any{
+	(*Process)(nil),                  // 0: cape.v1.Process
+	(*OffloadTarget)(nil),            // 1: cape.v1.OffloadTarget
+	(*BatteryState)(nil),             // 2: cape.v1.BatteryState
+	(*PowerProfile)(nil),             // 3: cape.v1.PowerProfile
+	(*SystemState)(nil),              // 4: cape.v1.SystemState
+	(*DecideRequest)(nil),            // 5: cape.v1.DecideRequest
+	(*Decision)(nil),                 // 6: cape.v1.Decision
+	(*Outcome)(nil),                  // 7: cape.v1.Outcome
+	(*ReportOutcomeResponse)(nil),    // 8: cape.v1.ReportOutcomeResponse
+	(*GetWeightsRequest)(nil),        // 9: cape.v1.GetWeightsRequest
+	(*Weights)(nil),                  // 10: cape.v1.Weights
+	(*GetStatsRequest)(nil),          // 11: cape.v1.GetStatsRequest
+	(*PhaseStat)(nil),                // 12: cape.v1.PhaseStat
+	(*WindowStats)(nil),              // 13: cape.v1.WindowStats
+	(*DecisionStats)(nil),            // 14: cape.v1.DecisionStats
+	(*Stats)(nil),                    // 15: cape.v1.Stats
+	(*ListSpikeReportsRequest)(nil),  // 16: cape.v1.ListSpikeReportsRequest
+	(*SpikeReport)(nil),              // 17: cape.v1.SpikeReport
+	(*ListSpikeReportsResponse)(nil), // 18: cape.v1.ListSpikeReportsResponse
+	(*GetChargebackRequest)(nil),     // 19: cape.v1.GetChargebackRequest
+	(*CostBreakdown)(nil),            // 20: cape.v1.CostBreakdown
+	(*ChargebackRollup)(nil),         // 21: cape.v1.ChargebackRollup
+	(*GetChargebackResponse)(nil),    // 22: cape.v1.GetChargebackResponse
+	nil,                              // 23: cape.v1.DecisionStats.WindowsEntry
+	nil,                              // 24: cape.v1.Stats.PhaseStatsEntry
+	(*durationpb.Duration)(nil),      // 25: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),    // 26: google.protobuf.Timestamp
+}
+var file_cape_v1_cape_proto_depIdxs = []int32{
+	25, // 0: cape.v1.Process.estimated_duration:type_name -> google.protobuf.Duration
+	25, // 1: cape.v1.Process.max_duration:type_name -> google.protobuf.Duration
+	26, // 2: cape.v1.Process.submission_time:type_name -> google.protobuf.Timestamp
+	25, // 3: cape.v1.OffloadTarget.network_latency:type_name -> google.protobuf.Duration
+	25, // 4: cape.v1.OffloadTarget.estimated_wait_time:type_name -> google.protobuf.Duration
+	26, // 5: cape.v1.OffloadTarget.last_seen:type_name -> google.protobuf.Timestamp
+	3,  // 6: cape.v1.OffloadTarget.power:type_name -> cape.v1.PowerProfile
+	2,  // 7: cape.v1.OffloadTarget.battery:type_name -> cape.v1.BatteryState
+	25, // 8: cape.v1.SystemState.queue_wait_time:type_name -> google.protobuf.Duration
+	26, // 9: cape.v1.SystemState.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 10: cape.v1.DecideRequest.process:type_name -> cape.v1.Process
+	1,  // 11: cape.v1.DecideRequest.targets:type_name -> cape.v1.OffloadTarget
+	4,  // 12: cape.v1.DecideRequest.state:type_name -> cape.v1.SystemState
+	25, // 13: cape.v1.DecideRequest.timeout:type_name -> google.protobuf.Duration
+	25, // 14: cape.v1.Decision.transfer_time:type_name -> google.protobuf.Duration
+	25, // 15: cape.v1.Decision.retrieval_time:type_name -> google.protobuf.Duration
+	25, // 16: cape.v1.Decision.decision_latency:type_name -> google.protobuf.Duration
+	25, // 17: cape.v1.Outcome.execution_time:type_name -> google.protobuf.Duration
+	25, // 18: cape.v1.Outcome.latency_actual:type_name -> google.protobuf.Duration
+	25, // 19: cape.v1.PhaseStat.mean:type_name -> google.protobuf.Duration
+	25, // 20: cape.v1.PhaseStat.max:type_name -> google.protobuf.Duration
+	25, // 21: cape.v1.WindowStats.mean_decision_time:type_name -> google.protobuf.Duration
+	25, // 22: cape.v1.DecisionStats.mean_decision_time:type_name -> google.protobuf.Duration
+	25, // 23: cape.v1.DecisionStats.recent_decision_time:type_name -> google.protobuf.Duration
+	23, // 24: cape.v1.DecisionStats.windows:type_name -> cape.v1.DecisionStats.WindowsEntry
+	10, // 25: cape.v1.Stats.current_weights:type_name -> cape.v1.Weights
+	24, // 26: cape.v1.Stats.phase_stats:type_name -> cape.v1.Stats.PhaseStatsEntry
+	14, // 27: cape.v1.Stats.stats:type_name -> cape.v1.DecisionStats
+	26, // 28: cape.v1.SpikeReport.started_at:type_name -> google.protobuf.Timestamp
+	26, // 29: cape.v1.SpikeReport.detected_at:type_name -> google.protobuf.Timestamp
+	26, // 30: cape.v1.SpikeReport.ended_at:type_name -> google.protobuf.Timestamp
+	25, // 31: cape.v1.SpikeReport.detection_lag:type_name -> google.protobuf.Duration
+	25, // 32: cape.v1.SpikeReport.pre_scale_lead:type_name -> google.protobuf.Duration
+	17, // 33: cape.v1.ListSpikeReportsResponse.reports:type_name -> cape.v1.SpikeReport
+	20, // 34: cape.v1.ChargebackRollup.cost:type_name -> cape.v1.CostBreakdown
+	21, // 35: cape.v1.GetChargebackResponse.rollups:type_name -> cape.v1.ChargebackRollup
+	13, // 36: cape.v1.DecisionStats.WindowsEntry.value:type_name -> cape.v1.WindowStats
+	12, // 37: cape.v1.Stats.PhaseStatsEntry.value:type_name -> cape.v1.PhaseStat
+	5,  // 38: cape.v1.DecisionService.Decide:input_type -> cape.v1.DecideRequest
+	7,  // 39: cape.v1.DecisionService.ReportOutcome:input_type -> cape.v1.Outcome
+	9,  // 40: cape.v1.DecisionService.GetWeights:input_type -> cape.v1.GetWeightsRequest
+	11, // 41: cape.v1.DecisionService.GetStats:input_type -> cape.v1.GetStatsRequest
+	16, // 42: cape.v1.DecisionService.ListSpikeReports:input_type -> cape.v1.ListSpikeReportsRequest
+	19, // 43: cape.v1.DecisionService.GetChargeback:input_type -> cape.v1.GetChargebackRequest
+	6,  // 44: cape.v1.DecisionService.Decide:output_type -> cape.v1.Decision
+	8,  // 45: cape.v1.DecisionService.ReportOutcome:output_type -> cape.v1.ReportOutcomeResponse
+	10, // 46: cape.v1.DecisionService.GetWeights:output_type -> cape.v1.Weights
+	15, // 47: cape.v1.DecisionService.GetStats:output_type -> cape.v1.Stats
+	18, // 48: cape.v1.DecisionService.ListSpikeReports:output_type -> cape.v1.ListSpikeReportsResponse
+	22, // 49: cape.v1.DecisionService.GetChargeback:output_type -> cape.v1.GetChargebackResponse
+	44, // [44:50] is the sub-list for method output_type
+	38, // [38:44] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
+}
+
+func init() { file_cape_v1_cape_proto_init() }
+func file_cape_v1_cape_proto_init() {
+	if File_cape_v1_cape_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_cape_v1_cape_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Process); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*OffloadTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*BatteryState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*PowerProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*DecideRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Decision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Outcome); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ReportOutcomeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GetWeightsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*Weights); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*GetStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*PhaseStat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*WindowStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*DecisionStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*Stats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSpikeReportsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*SpikeReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSpikeReportsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*GetChargebackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*CostBreakdown); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*ChargebackRollup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cape_v1_cape_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*GetChargebackResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_cape_v1_cape_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cape_v1_cape_proto_goTypes,
+		DependencyIndexes: file_cape_v1_cape_proto_depIdxs,
+		MessageInfos:      file_cape_v1_cape_proto_msgTypes,
+	}.Build()
+	File_cape_v1_cape_proto = out.File
+	file_cape_v1_cape_proto_rawDesc = nil
+	file_cape_v1_cape_proto_goTypes = nil
+	file_cape_v1_cape_proto_depIdxs = nil
+}
@@ -0,0 +1,331 @@
+// CAPE decision service, for running CAPE as a placement sidecar next to
+// another scheduler. Messages mirror pkg/models and pkg/decision; the Go
+// implementation behind each RPC is pkg/sidecar.Service.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: cape/v1/cape.proto
+
+package capev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DecisionService_Decide_FullMethodName           = "/cape.v1.DecisionService/Decide"
+	DecisionService_ReportOutcome_FullMethodName    = "/cape.v1.DecisionService/ReportOutcome"
+	DecisionService_GetWeights_FullMethodName       = "/cape.v1.DecisionService/GetWeights"
+	DecisionService_GetStats_FullMethodName         = "/cape.v1.DecisionService/GetStats"
+	DecisionService_ListSpikeReports_FullMethodName = "/cape.v1.DecisionService/ListSpikeReports"
+	DecisionService_GetChargeback_FullMethodName    = "/cape.v1.DecisionService/GetChargeback"
+)
+
+// DecisionServiceClient is the client API for DecisionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DecisionServiceClient interface {
+	// Decide returns placement advice for a process. The caller reports how
+	// the process turned out with ReportOutcome, quoting the decision ID.
+	Decide(ctx context.Context, in *DecideRequest, opts ...grpc.CallOption) (*Decision, error)
+	// ReportOutcome feeds the result of an executed decision into learning.
+	ReportOutcome(ctx context.Context, in *Outcome, opts ...grpc.CallOption) (*ReportOutcomeResponse, error)
+	// GetWeights returns the objective weights decisions are scored with.
+	GetWeights(ctx context.Context, in *GetWeightsRequest, opts ...grpc.CallOption) (*Weights, error)
+	// GetStats returns decision, learning and latency statistics.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error)
+	// ListSpikeReports returns the post-mortems of ended queue spikes.
+	ListSpikeReports(ctx context.Context, in *ListSpikeReportsRequest, opts ...grpc.CallOption) (*ListSpikeReportsResponse, error)
+	// GetChargeback returns the cost of executed offloads by month, tenant and
+	// project.
+	GetChargeback(ctx context.Context, in *GetChargebackRequest, opts ...grpc.CallOption) (*GetChargebackResponse, error)
+}
+
+type decisionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDecisionServiceClient(cc grpc.ClientConnInterface) DecisionServiceClient {
+	return &decisionServiceClient{cc}
+}
+
+func (c *decisionServiceClient) Decide(ctx context.Context, in *DecideRequest, opts ...grpc.CallOption) (*Decision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Decision)
+	err := c.cc.Invoke(ctx, DecisionService_Decide_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionServiceClient) ReportOutcome(ctx context.Context, in *Outcome, opts ...grpc.CallOption) (*ReportOutcomeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportOutcomeResponse)
+	err := c.cc.Invoke(ctx, DecisionService_ReportOutcome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionServiceClient) GetWeights(ctx context.Context, in *GetWeightsRequest, opts ...grpc.CallOption) (*Weights, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Weights)
+	err := c.cc.Invoke(ctx, DecisionService_GetWeights_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stats)
+	err := c.cc.Invoke(ctx, DecisionService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionServiceClient) ListSpikeReports(ctx context.Context, in *ListSpikeReportsRequest, opts ...grpc.CallOption) (*ListSpikeReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSpikeReportsResponse)
+	err := c.cc.Invoke(ctx, DecisionService_ListSpikeReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionServiceClient) GetChargeback(ctx context.Context, in *GetChargebackRequest, opts ...grpc.CallOption) (*GetChargebackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChargebackResponse)
+	err := c.cc.Invoke(ctx, DecisionService_GetChargeback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DecisionServiceServer is the server API for DecisionService service.
+// All implementations must embed UnimplementedDecisionServiceServer
+// for forward compatibility.
+type DecisionServiceServer interface {
+	// Decide returns placement advice for a process. The caller reports how
+	// the process turned out with ReportOutcome, quoting the decision ID.
+	Decide(context.Context, *DecideRequest) (*Decision, error)
+	// ReportOutcome feeds the result of an executed decision into learning.
+	ReportOutcome(context.Context, *Outcome) (*ReportOutcomeResponse, error)
+	// GetWeights returns the objective weights decisions are scored with.
+	GetWeights(context.Context, *GetWeightsRequest) (*Weights, error)
+	// GetStats returns decision, learning and latency statistics.
+	GetStats(context.Context, *GetStatsRequest) (*Stats, error)
+	// ListSpikeReports returns the post-mortems of ended queue spikes.
+	ListSpikeReports(context.Context, *ListSpikeReportsRequest) (*ListSpikeReportsResponse, error)
+	// GetChargeback returns the cost of executed offloads by month, tenant and
+	// project.
+	GetChargeback(context.Context, *GetChargebackRequest) (*GetChargebackResponse, error)
+	mustEmbedUnimplementedDecisionServiceServer()
+}
+
+// UnimplementedDecisionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDecisionServiceServer struct{}
+
+func (UnimplementedDecisionServiceServer) Decide(context.Context, *DecideRequest) (*Decision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decide not implemented")
+}
+func (UnimplementedDecisionServiceServer) ReportOutcome(context.Context, *Outcome) (*ReportOutcomeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportOutcome not implemented")
+}
+func (UnimplementedDecisionServiceServer) GetWeights(context.Context, *GetWeightsRequest) (*Weights, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWeights not implemented")
+}
+func (UnimplementedDecisionServiceServer) GetStats(context.Context, *GetStatsRequest) (*Stats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedDecisionServiceServer) ListSpikeReports(context.Context, *ListSpikeReportsRequest) (*ListSpikeReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSpikeReports not implemented")
+}
+func (UnimplementedDecisionServiceServer) GetChargeback(context.Context, *GetChargebackRequest) (*GetChargebackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChargeback not implemented")
+}
+func (UnimplementedDecisionServiceServer) mustEmbedUnimplementedDecisionServiceServer() {}
+func (UnimplementedDecisionServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeDecisionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DecisionServiceServer will
+// result in compilation errors.
+type UnsafeDecisionServiceServer interface {
+	mustEmbedUnimplementedDecisionServiceServer()
+}
+
+func RegisterDecisionServiceServer(s grpc.ServiceRegistrar, srv DecisionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDecisionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DecisionService_ServiceDesc, srv)
+}
+
+func _DecisionService_Decide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).Decide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_Decide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).Decide(ctx, req.(*DecideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionService_ReportOutcome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Outcome)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).ReportOutcome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_ReportOutcome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).ReportOutcome(ctx, req.(*Outcome))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionService_GetWeights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWeightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).GetWeights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_GetWeights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).GetWeights(ctx, req.(*GetWeightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionService_ListSpikeReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSpikeReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).ListSpikeReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_ListSpikeReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).ListSpikeReports(ctx, req.(*ListSpikeReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionService_GetChargeback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChargebackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).GetChargeback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_GetChargeback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).GetChargeback(ctx, req.(*GetChargebackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DecisionService_ServiceDesc is the grpc.ServiceDesc for DecisionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DecisionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cape.v1.DecisionService",
+	HandlerType: (*DecisionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Decide",
+			Handler:    _DecisionService_Decide_Handler,
+		},
+		{
+			MethodName: "ReportOutcome",
+			Handler:    _DecisionService_ReportOutcome_Handler,
+		},
+		{
+			MethodName: "GetWeights",
+			Handler:    _DecisionService_GetWeights_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _DecisionService_GetStats_Handler,
+		},
+		{
+			MethodName: "ListSpikeReports",
+			Handler:    _DecisionService_ListSpikeReports_Handler,
+		},
+		{
+			MethodName: "GetChargeback",
+			Handler:    _DecisionService_GetChargeback_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cape/v1/cape.proto",
+}
@@ -0,0 +1,78 @@
+// Command cape-sidecar serves CAPE placement decisions over gRPC
+// (cape.v1.DecisionService), so other schedulers can run it as a decision
+// sidecar.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	capev1 "github.com/casperlundberg/colony-process-offloader-algorithm/api/proto/cape/v1"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/cape"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/sidecar"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run serves decisions until interrupted and returns the process exit code
+func run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("cape-sidecar", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", "", "JSON algorithm configuration (default: cape.DefaultConfig)")
+	listen := flags.String("listen", ":50051", "Address to serve gRPC on")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	config := cape.DefaultConfig()
+	if *configPath != "" {
+		loaded, err := algorithm.LoadConfig(*configPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "Failed to load configuration: %v\n", err)
+			return 1
+		}
+		loaded.RewardFunction = config.RewardFunction
+		config = loaded
+	}
+
+	alg, err := algorithm.NewAlgorithm(config)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to initialize algorithm: %v\n", err)
+		return 1
+	}
+	defer alg.Close()
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to listen on %s: %v\n", *listen, err)
+		return 1
+	}
+	server := grpc.NewServer()
+	capev1.RegisterDecisionServiceServer(server, sidecar.NewGRPCServer(sidecar.NewService(alg)))
+
+	// Finish in-flight calls on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	fmt.Fprintf(stderr, "Serving cape.v1.DecisionService on %s\n", listener.Addr())
+	if err := server.Serve(listener); err != nil {
+		fmt.Fprintf(stderr, "Failed to serve: %v\n", err)
+		return 1
+	}
+	return 0
+}
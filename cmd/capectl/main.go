@@ -0,0 +1,13 @@
+// Command capectl runs simulations, validates configurations and evaluates
+// candidate configurations offline.
+package main
+
+import (
+	"os"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/internal/capectl"
+)
+
+func main() {
+	os.Exit(capectl.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,374 @@
+// Package embedded shows how to embed the offloading algorithm inside another
+// Go service. The host supplies live metrics through a MetricsSource and runs
+// processes through an Executor; Service turns each submitted process into a
+// decision, executes it and feeds the outcome back to the learner.
+//
+// A minimal host looks like:
+//
+//	service, err := embedded.NewService(embedded.DefaultConfig(), metrics, executor)
+//	if err != nil {
+//		return err
+//	}
+//	defer service.Close()
+//
+//	result, err := service.Submit(ctx, process)
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/cape"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// MetricsSource reports the host's current system state and the targets it
+// can offload to
+type MetricsSource interface {
+	SystemState(ctx context.Context) (models.SystemState, error)
+	Targets(ctx context.Context) ([]models.OffloadTarget, error)
+}
+
+// StaticMetrics is a MetricsSource returning fixed values
+type StaticMetrics struct {
+	State models.SystemState
+	Fleet []models.OffloadTarget
+}
+
+// SystemState returns the fixed system state
+func (m StaticMetrics) SystemState(ctx context.Context) (models.SystemState, error) {
+	return m.State, ctx.Err()
+}
+
+// Targets returns the fixed fleet
+func (m StaticMetrics) Targets(ctx context.Context) ([]models.OffloadTarget, error) {
+	return m.Fleet, ctx.Err()
+}
+
+// ExecutionResult describes how a process ran
+type ExecutionResult struct {
+	Duration  time.Duration // Wall time of the execution
+	Latency   time.Duration // Network latency observed when offloaded
+	Cost      float64
+	Energy    float64
+	ErrorType string // Empty on success
+}
+
+// Executor runs a process on a target. A nil target means the process runs
+// locally.
+type Executor interface {
+	Execute(ctx context.Context, process models.Process, target *models.OffloadTarget) (ExecutionResult, error)
+}
+
+// ExecutorFunc adapts a function to the Executor interface
+type ExecutorFunc func(ctx context.Context, process models.Process, target *models.OffloadTarget) (ExecutionResult, error)
+
+// Execute calls f(ctx, process, target)
+func (f ExecutorFunc) Execute(ctx context.Context, process models.Process, target *models.OffloadTarget) (ExecutionResult, error) {
+	return f(ctx, process, target)
+}
+
+// Result is the decision made for a submitted process and its outcome. With
+// retries, they are those of the last attempt.
+type Result struct {
+	Decision decision.OffloadDecision
+	Outcome  decision.OffloadOutcome
+	Attempts int
+}
+
+// DeadLetter is a process that failed on every attempt
+type DeadLetter struct {
+	Process   models.Process
+	Attempts  int
+	LastError error
+	Time      time.Time
+}
+
+// RetryStats counts submissions and retries
+type RetryStats struct {
+	Submitted    int
+	Succeeded    int
+	Retries      int // Attempts beyond the first
+	Recovered    int // Submissions that succeeded after a retry
+	DeadLettered int
+}
+
+// Service wires the algorithm to a host's metrics and executor. It is safe
+// for concurrent use; decisions are serialized.
+type Service struct {
+	alg         *algorithm.Algorithm
+	metrics     MetricsSource
+	executor    Executor
+	retry       policy.RetryPolicy
+	logger      *slog.Logger
+	deadLetters []DeadLetter
+	stats       RetryStats
+	mu          sync.Mutex
+}
+
+// DefaultConfig returns a programmatic algorithm configuration suitable for
+// embedding, logging through slog.Default
+func DefaultConfig() algorithm.Config {
+	config := cape.DefaultConfig()
+	config.MonitoringConfig = algorithm.MonitoringConfig{
+		EnableMetrics:   true,
+		EnableAuditLogs: true,
+	}
+	config.Logger = slog.Default()
+	return config
+}
+
+// NewService creates a service embedding a new algorithm instance
+func NewService(config algorithm.Config, metrics MetricsSource, executor Executor) (*Service, error) {
+	if metrics == nil || executor == nil {
+		return nil, fmt.Errorf("metrics source and executor are required")
+	}
+
+	alg, err := algorithm.NewAlgorithm(config)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Service{
+		alg:      alg,
+		metrics:  metrics,
+		executor: executor,
+		retry:    policy.RetryPolicyFor(config.SafetyConstraints),
+		logger:   logger.With("component", "embedded"),
+	}, nil
+}
+
+// SetRetryPolicy replaces the retry policy derived from the safety constraints
+func (s *Service) SetRetryPolicy(retry policy.RetryPolicy) error {
+	if err := retry.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.retry = retry
+	return nil
+}
+
+// Submit decides where the process runs, executes it there and feeds the
+// outcome back for learning. Failed executions are recorded as failed
+// outcomes and retried according to the retry policy, avoiding the targets
+// that failed. A process that fails every attempt is dead-lettered and its
+// last error returned.
+func (s *Service) Submit(ctx context.Context, process models.Process) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.refreshTargets(ctx); err != nil {
+		return Result{}, err
+	}
+	s.stats.Submitted++
+
+	failed := make([]models.OffloadTarget, 0)
+	for attempt := 1; ; attempt++ {
+		result, err := s.attempt(ctx, process, failed)
+		result.Attempts = attempt
+		if err == nil {
+			s.stats.Succeeded++
+			if attempt > 1 {
+				s.stats.Recovered++
+			}
+			return result, nil
+		}
+
+		retryable := result.Outcome.ProcessID != "" && ctx.Err() == nil && attempt <= s.retry.MaxRetries
+		if result.Decision.ShouldOffload && result.Decision.Target != nil {
+			failed = append(failed, *result.Decision.Target)
+			retryable = retryable && (s.retry.LocalFallback || s.hasUntriedTarget(process, failed))
+		}
+		if !retryable {
+			if result.Outcome.ProcessID != "" {
+				s.deadLetter(process, attempt, err)
+			}
+			return result, err
+		}
+
+		s.stats.Retries++
+		s.logger.Info("retrying failed process",
+			"process_id", process.ID, "attempt", attempt+1, "error", err)
+		select {
+		case <-ctx.Done():
+			s.deadLetter(process, attempt, err)
+			return result, err
+		case <-time.After(s.retry.Delay(attempt)):
+		}
+	}
+}
+
+// DeadLetters returns the processes that failed every attempt, oldest first
+func (s *Service) DeadLetters() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]DeadLetter(nil), s.deadLetters...)
+}
+
+// Stats returns submission and retry counts
+func (s *Service) Stats() RetryStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stats
+}
+
+// Algorithm returns the embedded algorithm, for explanations and metrics
+func (s *Service) Algorithm() *algorithm.Algorithm {
+	return s.alg
+}
+
+// Close releases the algorithm's resources
+func (s *Service) Close() error {
+	return s.alg.Close()
+}
+
+// attempt makes one decision, avoiding failed targets, executes it and
+// processes the outcome. Errors before execution leave the outcome empty.
+func (s *Service) attempt(ctx context.Context, process models.Process, failed []models.OffloadTarget) (Result, error) {
+	state, err := s.metrics.SystemState(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read system state: %w", err)
+	}
+
+	dec, err := s.alg.MakeOffloadDecisionContext(ctx, process, s.candidates(process, failed), state)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var target *models.OffloadTarget
+	if dec.ShouldOffload {
+		target = dec.Target
+	}
+
+	start := time.Now()
+	execution, execErr := s.executor.Execute(ctx, process, target)
+	end := time.Now()
+	if execErr != nil && execution.ErrorType == "" {
+		execution.ErrorType = execErr.Error()
+	}
+
+	outcome := buildOutcome(dec, process, target, execution, start, end)
+	if err := s.alg.ProcessOutcome(outcome); err != nil {
+		return Result{Decision: dec, Outcome: outcome}, fmt.Errorf("failed to process outcome: %w", err)
+	}
+	if execErr != nil {
+		return Result{Decision: dec, Outcome: outcome}, fmt.Errorf("execution failed: %w", execErr)
+	}
+	return Result{Decision: dec, Outcome: outcome}, nil
+}
+
+// candidates returns the registry's candidates for the process, excluding
+// failed targets and, when configured, every target of a failed type
+func (s *Service) candidates(process models.Process, failed []models.OffloadTarget) []models.OffloadTarget {
+	candidates := s.alg.TargetRegistry().CandidatesFor(process)
+	if len(failed) == 0 {
+		return candidates
+	}
+
+	remaining := make([]models.OffloadTarget, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !s.avoided(candidate, failed) {
+			remaining = append(remaining, candidate)
+		}
+	}
+	return remaining
+}
+
+// avoided reports whether a target is excluded by earlier failures
+func (s *Service) avoided(target models.OffloadTarget, failed []models.OffloadTarget) bool {
+	for _, f := range failed {
+		if f.ID == target.ID || (s.retry.AvoidFailedClass && f.Type == target.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasUntriedTarget reports whether any candidate target remains to retry on
+func (s *Service) hasUntriedTarget(process models.Process, failed []models.OffloadTarget) bool {
+	return len(s.candidates(process, failed)) > 0
+}
+
+// deadLetter records a process that will not be retried
+func (s *Service) deadLetter(process models.Process, attempts int, err error) {
+	s.stats.DeadLettered++
+	s.deadLetters = append(s.deadLetters, DeadLetter{
+		Process:   process,
+		Attempts:  attempts,
+		LastError: err,
+		Time:      time.Now(),
+	})
+}
+
+// refreshTargets syncs the algorithm's target registry with the metrics source
+func (s *Service) refreshTargets(ctx context.Context) error {
+	targets, err := s.metrics.Targets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read targets: %w", err)
+	}
+
+	registry := s.alg.TargetRegistry()
+	current := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		registry.Upsert(target)
+		current[target.ID] = true
+	}
+	for _, target := range registry.All() {
+		if !current[target.ID] {
+			registry.Remove(target.ID)
+		}
+	}
+	return nil
+}
+
+// buildOutcome converts an execution result into a learning outcome
+func buildOutcome(
+	dec decision.OffloadDecision,
+	process models.Process,
+	target *models.OffloadTarget,
+	execution ExecutionResult,
+	start, end time.Time,
+) decision.OffloadOutcome {
+	targetID := "local"
+	if target != nil {
+		targetID = target.ID
+	}
+
+	duration := execution.Duration
+	if duration == 0 {
+		duration = end.Sub(start)
+	}
+
+	return decision.OffloadOutcome{
+		DecisionID:        dec.DecisionID,
+		ProcessID:         process.ID,
+		TargetID:          targetID,
+		ExecutionTime:     duration,
+		CompletedOnTime:   process.MaxDuration <= 0 || duration <= process.MaxDuration,
+		Success:           execution.ErrorType == "",
+		ErrorType:         execution.ErrorType,
+		LatencyActual:     execution.Latency,
+		NetworkCostActual: execution.Cost,
+		CostActual:        execution.Cost,
+		EnergyConsumed:    execution.Energy,
+		StartTime:         start,
+		EndTime:           end,
+		MeasurementTime:   end,
+	}
+}
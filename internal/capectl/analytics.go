@@ -0,0 +1,212 @@
+package capectl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/tenancy"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// analyticsSummary totals the decisions and outcomes of a replay log
+type analyticsSummary struct {
+	Records           int            `json:"records"`
+	Offloads          int            `json:"offloads"`
+	OffloadRate       float64        `json:"offload_rate"`
+	SuccessRate       float64        `json:"success_rate"` // Of the offloads
+	TotalCost         units.Money    `json:"total_cost"`
+	MeanExecutionTime time.Duration  `json:"mean_execution_time"` // Of the offloads
+	OffloadsByTarget  map[string]int `json:"offloads_by_target"`
+}
+
+// analytics serves the analytics of a replay log. The log is read on every
+// request, so a log still being written is served as it grows.
+type analytics struct {
+	logPath   string
+	candidate algorithm.Config
+	location  *time.Location
+	display   units.Config
+}
+
+// runServeAnalytics serves a replay log's summary, chargeback and the
+// -config candidate's replay estimate as JSON over HTTP until interrupted
+func runServeAnalytics(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("serve-analytics", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	common := addCommonFlags(flags)
+	logPath := flags.String("log", "", "Replay log written by 'simulate -replay-log' (required)")
+	listen := flags.String("listen", ":8080", "Address to serve HTTP on")
+	timeZone := flags.String("timezone", "", "IANA time zone chargeback months are closed in (default: UTC)")
+	currency := flags.String("currency", "", "Currency to report costs in (default: USD)")
+	rates := flags.String("rates", "", "Exchange rates per USD, as CUR=rate[,CUR=rate...]")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *logPath == "" {
+		fmt.Fprintln(stderr, "serve-analytics: -log is required")
+		return 2
+	}
+	location := time.UTC
+	if *timeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(*timeZone); err != nil {
+			fmt.Fprintf(stderr, "serve-analytics: -timezone: %v\n", err)
+			return 2
+		}
+	}
+	display, err := parseDisplay(*currency, *rates)
+	if err != nil {
+		fmt.Fprintf(stderr, "serve-analytics: %v\n", err)
+		return 2
+	}
+	candidate, err := common.loadConfig()
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to listen on %s: %v\n", *listen, err)
+		return 1
+	}
+	server := &http.Server{
+		Handler:           (&analytics{logPath: *logPath, candidate: candidate, location: location, display: display}).handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Finish in-flight requests on interrupt
+	shutdown := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		shutdown <- server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(stdout, "Serving analytics for %s on %s\n", *logPath, listener.Addr())
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(stderr, "Failed to serve: %v\n", err)
+		return 1
+	}
+	if err := <-shutdown; err != nil {
+		fmt.Fprintf(stderr, "Failed to shut down: %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(stdout, "✓ Stopped")
+	return 0
+}
+
+// handler routes the analytics endpoints
+func (a *analytics) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /summary", a.serveSummary)
+	mux.HandleFunc("GET /chargeback", a.serveChargeback)
+	mux.HandleFunc("GET /replay", a.serveReplay)
+	return mux
+}
+
+// serveSummary writes the log's decision and outcome totals
+func (a *analytics) serveSummary(w http.ResponseWriter, r *http.Request) {
+	records, ok := a.records(w)
+	if !ok {
+		return
+	}
+
+	summary := analyticsSummary{Records: len(records), OffloadsByTarget: map[string]int{}}
+	var successes int
+	var cost float64
+	var execution time.Duration
+	for _, record := range records {
+		if !record.Explanation.ShouldOffload {
+			continue
+		}
+		summary.Offloads++
+		summary.OffloadsByTarget[record.Explanation.SelectedTargetID]++
+		if record.Outcome.Success {
+			successes++
+		}
+		cost += record.Outcome.CostActual
+		execution += record.Outcome.ExecutionTime
+	}
+	if summary.Records > 0 {
+		summary.OffloadRate = float64(summary.Offloads) / float64(summary.Records)
+	}
+	if summary.Offloads > 0 {
+		summary.SuccessRate = float64(successes) / float64(summary.Offloads)
+		summary.MeanExecutionTime = execution / time.Duration(summary.Offloads)
+	}
+	summary.TotalCost = a.display.Display(cost)
+	writeJSONResponse(w, http.StatusOK, summary)
+}
+
+// serveChargeback writes the log's offload costs by month, tenant and
+// project, for the ?month=YYYY-MM month or every month
+func (a *analytics) serveChargeback(w http.ResponseWriter, r *http.Request) {
+	month := r.URL.Query().Get("month")
+	if month != "" {
+		if _, err := time.Parse(tenancy.MonthLayout, month); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("month must be YYYY-MM, got %q", month))
+			return
+		}
+	}
+	records, ok := a.records(w)
+	if !ok {
+		return
+	}
+	ledger, err := chargebackLedger(records, a.location, a.display)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, ledger.Rollup(month))
+}
+
+// serveReplay writes the candidate configuration's estimated reward on the
+// log
+func (a *analytics) serveReplay(w http.ResponseWriter, r *http.Request) {
+	records, ok := a.records(w)
+	if !ok {
+		return
+	}
+	report, err := algorithm.Replay(a.candidate, records, algorithm.ReplayConfig{})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, report)
+}
+
+// records reads the replay log, writing an error response if it cannot
+func (a *analytics) records(w http.ResponseWriter) ([]algorithm.ReplayRecord, bool) {
+	var records []algorithm.ReplayRecord
+	if err := readJSON(a.logPath, &records); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("reading replay log: %w", err))
+		return nil, false
+	}
+	return records, true
+}
+
+func writeJSONResponse(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSONResponse(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,200 @@
+package capectl
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// arrivalProcess draws the gaps between process arrivals in a simulation
+type arrivalProcess interface {
+	// Next returns the time from the previous arrival to the next one
+	Next() time.Duration
+}
+
+// arrivalConfig parameterizes the simulate command's arrival processes
+type arrivalConfig struct {
+	kind        string
+	meanGap     time.Duration // Mean time between arrivals
+	burstFactor float64       // MMPP: rate multiplier of the burst state
+	paretoAlpha float64       // Pareto: tail index, heavier tails for smaller values
+	hurst       float64       // Self-similar: Hurst parameter in [0.5, 1)
+}
+
+// arrivalKinds are the names accepted by -arrivals
+var arrivalKinds = []string{"fixed", "poisson", "mmpp", "pareto", "self-similar"}
+
+// validate checks the configuration
+func (c arrivalConfig) validate() error {
+	switch c.kind {
+	case "fixed", "poisson":
+	case "mmpp":
+		if c.burstFactor < 1 {
+			return fmt.Errorf("-burst-factor must be at least 1")
+		}
+	case "pareto":
+		if c.paretoAlpha <= 1 {
+			return fmt.Errorf("-pareto-alpha must be greater than 1 for a finite mean")
+		}
+	case "self-similar":
+		if c.hurst < 0.5 || c.hurst >= 1 {
+			return fmt.Errorf("-hurst must be in [0.5, 1)")
+		}
+	default:
+		return fmt.Errorf("unknown arrival process %q (want one of %v)", c.kind, arrivalKinds)
+	}
+	if c.meanGap <= 0 {
+		return fmt.Errorf("-arrival-gap must be positive")
+	}
+	return nil
+}
+
+// newArrivalProcess builds the configured arrival process for n arrivals
+func newArrivalProcess(config arrivalConfig, rng *rand.Rand, n int) arrivalProcess {
+	mean := config.meanGap.Seconds()
+	switch config.kind {
+	case "poisson":
+		return &poissonArrivals{rng: rng, mean: mean}
+	case "mmpp":
+		return newMMPPArrivals(rng, mean, config.burstFactor)
+	case "pareto":
+		return &paretoArrivals{rng: rng, alpha: config.paretoAlpha, scale: mean * (config.paretoAlpha - 1) / config.paretoAlpha}
+	case "self-similar":
+		return newSelfSimilarArrivals(rng, mean, config.hurst, n)
+	}
+	return fixedArrivals{gap: config.meanGap}
+}
+
+// seconds converts a gap in seconds to a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// fixedArrivals arrive at a constant rate
+type fixedArrivals struct {
+	gap time.Duration
+}
+
+func (a fixedArrivals) Next() time.Duration {
+	return a.gap
+}
+
+// poissonArrivals have exponential gaps
+type poissonArrivals struct {
+	rng  *rand.Rand
+	mean float64
+}
+
+func (a *poissonArrivals) Next() time.Duration {
+	return seconds(a.rng.ExpFloat64() * a.mean)
+}
+
+// mmppArrivals is a two-state Markov-modulated Poisson process: a calm state
+// and a burst state whose rate is burstFactor times higher. The rates are
+// chosen so the long-run mean gap matches the configured one.
+type mmppArrivals struct {
+	rng      *rand.Rand
+	gaps     [2]float64 // Mean gap in the calm and burst state
+	leave    [2]float64 // Probability of leaving each state after an arrival
+	bursting bool
+}
+
+func newMMPPArrivals(rng *rand.Rand, mean, burstFactor float64) *mmppArrivals {
+	// Bursts are entered after one arrival in ten and last four arrivals on
+	// average, so a fifth of arrivals happen in bursts
+	leave := [2]float64{0.1, 0.25}
+	burstShare := leave[0] / (leave[0] + leave[1])
+	calm := mean / ((1 - burstShare) + burstShare/burstFactor)
+	return &mmppArrivals{rng: rng, gaps: [2]float64{calm, calm / burstFactor}, leave: leave}
+}
+
+func (a *mmppArrivals) Next() time.Duration {
+	state := 0
+	if a.bursting {
+		state = 1
+	}
+	gap := a.rng.ExpFloat64() * a.gaps[state]
+	if a.rng.Float64() < a.leave[state] {
+		a.bursting = !a.bursting
+	}
+	return seconds(gap)
+}
+
+// paretoArrivals have heavy-tailed Pareto gaps with tail index alpha
+type paretoArrivals struct {
+	rng   *rand.Rand
+	alpha float64
+	scale float64 // Minimum gap
+}
+
+func (a *paretoArrivals) Next() time.Duration {
+	return seconds(a.scale / math.Pow(1-a.rng.Float64(), 1/a.alpha))
+}
+
+// selfSimilarArrivals modulate exponential gaps with fractional Gaussian
+// noise, so bursts are correlated over long ranges of arrivals according to
+// the Hurst parameter
+type selfSimilarArrivals struct {
+	rng   *rand.Rand
+	mean  float64
+	noise []float64
+	next  int
+}
+
+// selfSimilarSpread is the standard deviation of the log rate modulation
+const selfSimilarSpread = 0.75
+
+func newSelfSimilarArrivals(rng *rand.Rand, mean, hurst float64, n int) *selfSimilarArrivals {
+	return &selfSimilarArrivals{rng: rng, mean: mean, noise: fractionalGaussianNoise(rng, hurst, n)}
+}
+
+func (a *selfSimilarArrivals) Next() time.Duration {
+	noise := 0.0
+	if a.next < len(a.noise) {
+		noise = a.noise[a.next]
+		a.next++
+	}
+	// Log-normal modulation with unit mean keeps the configured mean gap
+	modulation := math.Exp(selfSimilarSpread*noise - selfSimilarSpread*selfSimilarSpread/2)
+	return seconds(a.rng.ExpFloat64() * a.mean * modulation)
+}
+
+// fractionalGaussianNoise draws n samples of unit-variance fractional
+// Gaussian noise with Hurst parameter hurst using Hosking's method
+func fractionalGaussianNoise(rng *rand.Rand, hurst float64, n int) []float64 {
+	if n <= 0 {
+		return nil
+	}
+	autocov := func(k int) float64 {
+		h2 := 2 * hurst
+		fk := float64(k)
+		return 0.5 * (math.Pow(math.Abs(fk+1), h2) - 2*math.Pow(fk, h2) + math.Pow(math.Abs(fk-1), h2))
+	}
+
+	noise := make([]float64, n)
+	phi := make([]float64, n)
+	prev := make([]float64, n)
+	variance := 1.0
+	noise[0] = rng.NormFloat64()
+	for i := 1; i < n; i++ {
+		// Durbin-Levinson update of the prediction coefficients
+		num := autocov(i)
+		for j := 0; j < i-1; j++ {
+			num -= prev[j] * autocov(i-1-j)
+		}
+		phi[i-1] = num / variance
+		for j := 0; j < i-1; j++ {
+			phi[j] = prev[j] - phi[i-1]*prev[i-2-j]
+		}
+		variance *= 1 - phi[i-1]*phi[i-1]
+		copy(prev, phi[:i])
+
+		mean := 0.0
+		for j := 0; j < i; j++ {
+			mean += phi[j] * noise[i-1-j]
+		}
+		noise[i] = mean + math.Sqrt(variance)*rng.NormFloat64()
+	}
+	return noise
+}
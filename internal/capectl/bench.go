@@ -0,0 +1,228 @@
+package capectl
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// benchResult is the decision throughput and latency for one fleet size and
+// goal count
+type benchResult struct {
+	Targets         int           `json:"targets"`
+	Goals           int           `json:"goals"`
+	Decisions       int           `json:"decisions"`
+	DecisionsPerSec float64       `json:"decisions_per_sec"`
+	P50             time.Duration `json:"p50"`
+	P99             time.Duration `json:"p99"`
+	BudgetExhausted int           `json:"budget_exhausted"` // Decisions cut short by the latency budget
+}
+
+// benchRun is one invocation of bench, as kept in the history file
+type benchRun struct {
+	Time      time.Time     `json:"time"`
+	Version   string        `json:"version"` // Algorithm version
+	GoVersion string        `json:"go_version"`
+	CPUs      int           `json:"cpus"`
+	Results   []benchResult `json:"results"`
+}
+
+// runBench measures decision throughput and latency for growing fleets and
+// numbers of custom objective goals
+func runBench(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	common := addCommonFlags(flags)
+	targetSizes := flags.String("targets", "10,100,1000,10000", "Comma-separated fleet sizes to benchmark")
+	goalCounts := flags.String("goals", "0,4", "Comma-separated numbers of custom objective goals to benchmark")
+	decisions := flags.Int("decisions", 200, "Decisions measured per fleet size and goal count")
+	history := flags.String("history", "", "Append the results to this JSON file to track performance over versions")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	sizes, err := parseCounts(*targetSizes, 1)
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid -targets: %v\n", err)
+		return 2
+	}
+	goals, err := parseCounts(*goalCounts, 0)
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid -goals: %v\n", err)
+		return 2
+	}
+	if *decisions < 1 {
+		fmt.Fprintln(stderr, "-decisions must be positive")
+		return 2
+	}
+
+	config, err := common.loadConfig()
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	rng := common.rng()
+
+	run := benchRun{Time: time.Now(), GoVersion: runtime.Version(), CPUs: runtime.NumCPU()}
+	fmt.Fprintf(stdout, "Decision Benchmark (%d decisions per case, %d CPUs):\n", *decisions, run.CPUs)
+	fmt.Fprintf(stdout, "  %8s %6s %14s %12s %12s\n", "targets", "goals", "decisions/sec", "p50", "p99")
+	for _, size := range sizes {
+		for _, goalCount := range goals {
+			result, version, err := benchCase(config, size, goalCount, *decisions, rng.Int63())
+			if err != nil {
+				fmt.Fprintf(stderr, "Benchmark with %d targets and %d goals failed: %v\n", size, goalCount, err)
+				return 1
+			}
+			run.Version = version
+			run.Results = append(run.Results, result)
+
+			exhausted := ""
+			if result.BudgetExhausted > 0 {
+				exhausted = fmt.Sprintf(" [%d over budget]", result.BudgetExhausted)
+			}
+			fmt.Fprintf(stdout, "  %8d %6d %14.1f %12s %12s%s\n", size, goalCount,
+				result.DecisionsPerSec, result.P50.Round(time.Microsecond), result.P99.Round(time.Microsecond), exhausted)
+		}
+	}
+
+	if *history != "" {
+		var runs []benchRun
+		if err := readJSON(*history, &runs); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(stderr, "Failed to read benchmark history: %v\n", err)
+			return 1
+		}
+		if err := writeJSON(*history, append(runs, run)); err != nil {
+			fmt.Fprintf(stderr, "Failed to write benchmark history: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "✓ Results appended to %s (%d runs)\n", *history, len(runs)+1)
+	}
+	return 0
+}
+
+// benchCase times decisions against a fleet of size targets, scoring goals
+// custom objectives. Outcomes are fed back outside the timed section so
+// offloads do not pile up against the concurrency limit.
+func benchCase(config algorithm.Config, size, goals, decisions int, seed int64) (benchResult, string, error) {
+	config.Objectives = nil
+	for i := 0; i < goals; i++ {
+		name := fmt.Sprintf("bench-objective-%d", i)
+		// Registration is global; earlier cases may have registered it
+		_ = decision.RegisterObjective(decision.NewObjectiveMetric(name, benchObjective(i)))
+		config.Objectives = append(config.Objectives, decision.ObjectiveGoal{Name: name, Weight: 0.5 / float64(goals)})
+	}
+
+	alg, err := algorithm.NewAlgorithm(config)
+	if err != nil {
+		return benchResult{}, "", err
+	}
+	defer alg.Close()
+
+	rng := rand.New(rand.NewSource(seed))
+	targets := benchFleet(size)
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		NetworkUsage:   0.20,
+		MasterUsage:    0.20,
+		Timestamp:      time.Now(),
+	}
+
+	result := benchResult{Targets: size, Goals: goals, Decisions: decisions}
+	latencies := make([]float64, 0, decisions)
+	var total time.Duration
+	for i := 0; i < decisions; i++ {
+		process := models.Process{
+			ID:                fmt.Sprintf("bench-%d", i),
+			Priority:          1 + rng.Intn(10),
+			CPURequirement:    float64(1 + rng.Intn(4)),
+			MemoryRequirement: int64(1+rng.Intn(4)) * 1024 * 1024 * 1024,
+			InputSize:         int64(1+rng.Intn(50)) * 1024 * 1024,
+			OutputSize:        int64(1+rng.Intn(10)) * 1024 * 1024,
+			EstimatedDuration: time.Duration(30+rng.Intn(300)) * time.Second,
+			Status:            models.QUEUED,
+		}
+
+		start := time.Now()
+		dec, err := alg.MakeOffloadDecision(process, targets, state)
+		elapsed := time.Since(start)
+		if err != nil {
+			return benchResult{}, "", err
+		}
+		total += elapsed
+		latencies = append(latencies, float64(elapsed))
+		if dec.BudgetExhausted {
+			result.BudgetExhausted++
+		}
+
+		if err := alg.ProcessOutcome(simulateOutcome(rng, dec, process, stageExecution(dec, process))); err != nil {
+			return benchResult{}, "", err
+		}
+	}
+
+	result.DecisionsPerSec = float64(decisions) / total.Seconds()
+	result.P50 = time.Duration(percentile(latencies, 0.50))
+	result.P99 = time.Duration(percentile(latencies, 0.99))
+	return result, alg.GetPerformanceMetrics().Version, nil
+}
+
+// benchObjective returns a cheap, deterministic custom objective
+func benchObjective(i int) func(models.Process, models.OffloadTarget, models.SystemState) float64 {
+	return func(_ models.Process, target models.OffloadTarget, _ models.SystemState) float64 {
+		return float64((len(target.ID)+i)%10) / 10
+	}
+}
+
+// benchFleet returns size edge targets of varied latency, speed and load
+func benchFleet(size int) []models.OffloadTarget {
+	targets := make([]models.OffloadTarget, size)
+	for i := range targets {
+		targets[i] = models.OffloadTarget{
+			ID:                fmt.Sprintf("edge-%05d", i),
+			Type:              models.EDGE,
+			TotalCapacity:     8.0,
+			AvailableCapacity: 6.0,
+			MemoryTotal:       16 * 1024 * 1024 * 1024,
+			MemoryAvailable:   10 * 1024 * 1024 * 1024,
+			NetworkLatency:    time.Duration(5+i%100) * time.Millisecond,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			NetworkStability:  0.95,
+			ProcessingSpeed:   1.0 + float64(i%7)/10,
+			Reliability:       0.95,
+			ComputeCost:       0.10,
+			CurrentLoad:       float64(i%10) / 20,
+			SecurityLevel:     5,
+			DataJurisdiction:  "domestic",
+			LastSeen:          time.Now(),
+		}
+	}
+	return targets
+}
+
+// parseCounts parses a comma-separated list of counts of at least minimum
+func parseCounts(list string, minimum int) ([]int, error) {
+	var counts []int
+	for _, field := range strings.Split(list, ",") {
+		count, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		if count < minimum {
+			return nil, fmt.Errorf("%d is below %d", count, minimum)
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
@@ -0,0 +1,217 @@
+// Package capectl implements the capectl command line interface. Every
+// subcommand shares configuration loading, logging and seed flags.
+package capectl
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// command is a capectl subcommand
+type command struct {
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = map[string]command{
+	"simulate":         {"Run decisions for synthetic processes and learn from simulated outcomes", runSimulate},
+	"validate-config":  {"Check configuration files for unknown fields, type errors and invalid values", runValidateConfig},
+	"replay":           {"Estimate a candidate configuration's reward on a replay log", runReplay},
+	"export":           {"Write the states, decisions and outcomes of a replay log as CSV", runExport},
+	"plan":             {"Preview the placements of a queue snapshot against a target catalog", runPlan},
+	"verify-audit":     {"Check that an audit trail's hash chain and signatures are intact", runVerifyAudit},
+	"bench":            {"Measure decision throughput and latency for growing fleets and goal counts", runBench},
+	"golden":           {"Record a simulation's metrics as a golden baseline or check them for drift", runGolden},
+	"chargeback":       {"Attribute a replay log's offload costs to tenants and projects by month, as CSV", runChargeback},
+	"serve-analytics":  {"Serve a replay log's summary, chargeback and replay estimate as JSON over HTTP", runServeAnalytics},
+	"run-orchestrator": {"Serve decisions over gRPC and run the configured telemetry, event and outcome loops", runOrchestrator},
+}
+
+// Run executes the subcommand named by the first argument and returns the
+// process exit code
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	cmd, exists := commands[args[0]]
+	if !exists {
+		fmt.Fprintf(stderr, "capectl: unknown command %q\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	return cmd.run(args[1:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: capectl <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'capectl <command> -h' for the command's flags.")
+}
+
+// commonFlags are the flags every subcommand accepts
+type commonFlags struct {
+	config    string
+	seed      int64
+	logLevel  string
+	logFormat string
+}
+
+func addCommonFlags(flags *flag.FlagSet) *commonFlags {
+	common := &commonFlags{}
+	flags.StringVar(&common.config, "config", "", "JSON algorithm configuration (default: built-in demo configuration)")
+	flags.Int64Var(&common.seed, "seed", 0, "Random seed for reproducible runs (0 = time-based)")
+	flags.StringVar(&common.logLevel, "log-level", "", "Override the configured log level (debug, info, warn, error)")
+	flags.StringVar(&common.logFormat, "log-format", "", "Override the configured log format (text, json)")
+	return common
+}
+
+// loadConfig loads the -config file, or the demo configuration, and applies
+// the logging overrides
+func (c *commonFlags) loadConfig() (algorithm.Config, error) {
+	config := defaultConfig()
+	if c.config != "" {
+		loaded, err := algorithm.LoadConfig(c.config)
+		if err != nil {
+			return algorithm.Config{}, err
+		}
+		loaded.RewardFunction = config.RewardFunction
+		config = loaded
+	}
+
+	if c.logLevel != "" {
+		if _, err := logging.ParseLevel(c.logLevel); err != nil {
+			return algorithm.Config{}, err
+		}
+		config.MonitoringConfig.Logging.Level = c.logLevel
+	}
+	if c.logFormat != "" {
+		config.MonitoringConfig.Logging.Format = logging.Format(c.logFormat)
+	}
+	return config, nil
+}
+
+// rng returns the random source for the run
+func (c *commonFlags) rng() *rand.Rand {
+	seed := c.seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// defaultConfig is the demo configuration used when no -config is given
+func defaultConfig() algorithm.Config {
+	return algorithm.Config{
+		InitialWeights: decision.AdaptiveWeights{
+			QueueDepth:    0.2,
+			ProcessorLoad: 0.2,
+			NetworkCost:   0.2,
+			LatencyCost:   0.2,
+			EnergyCost:    0.1,
+			PolicyCost:    0.1,
+		},
+		LearningConfig: learning.LearningConfig{
+			WindowSize:      100,
+			LearningRate:    0.01,
+			ExplorationRate: 0.1,
+			MinSamples:      10,
+		},
+		SafetyConstraints: policy.SafetyConstraints{
+			MinLocalCompute:       0.2,
+			MinLocalMemory:        0.2,
+			MaxConcurrentOffloads: 10,
+			DataSovereignty:       true,
+			SecurityClearance:     true,
+			MaxLatencyTolerance:   500 * time.Millisecond,
+			MinReliability:        0.5,
+		},
+		PerformanceTargets: algorithm.PerformanceTargets{
+			MaxDecisionLatency:  500 * time.Millisecond,
+			MinDecisionAccuracy: 0.85,
+			MaxPolicyViolations: 5,
+			MinPerformanceGain:  0.1,
+			ConvergenceTimeout:  200,
+		},
+		SLAPolicy: learning.SLAPolicy{
+			MaxLatency:             50 * time.Millisecond,
+			DeadlineMissPenalty:    0.2,
+			PolicyViolationPenalty: 0.5,
+		},
+		Transfers: decision.TransferConfig{
+			Enabled: true,
+			Links: []decision.LinkConfig{{
+				ID:            "wan",
+				Capacity:      200 * 1024 * 1024,
+				MaxConcurrent: 4,
+				Targets:       []string{"cloud-1"},
+			}},
+			HourlyLoad: businessHoursLoad(),
+		},
+		RewardFunction: learning.NewDefaultRewardFunction(),
+	}
+}
+
+// businessHoursLoad is a congestion profile with busy links during the
+// working day and quiet ones at night
+func businessHoursLoad() []float64 {
+	load := make([]float64, 24)
+	for hour := range load {
+		switch {
+		case hour >= 9 && hour < 17:
+			load[hour] = 0.6
+		case hour >= 7 && hour < 20:
+			load[hour] = 0.3
+		default:
+			load[hour] = 0.1
+		}
+	}
+	return load
+}
+
+// readJSON decodes a JSON file into value
+func readJSON(path string, value interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSON encodes value as indented JSON to a file
+func writeJSON(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
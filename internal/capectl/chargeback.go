@@ -0,0 +1,140 @@
+package capectl
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/tenancy"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// runChargeback attributes the cost of the offloads in a replay log to
+// tenants and projects and writes the monthly rollup as CSV for finance
+func runChargeback(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("chargeback", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	logPath := flags.String("log", "", "Replay log written by 'simulate -replay-log' (required)")
+	month := flags.String("month", "", "Month to report, as YYYY-MM (default: every month)")
+	timeZone := flags.String("timezone", "", "IANA time zone months are closed in (default: UTC)")
+	currency := flags.String("currency", "", "Currency to report costs in (default: USD)")
+	rates := flags.String("rates", "", "Exchange rates per USD, as CUR=rate[,CUR=rate...]")
+	outPath := flags.String("out", "", "CSV file to write (default: stdout)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *logPath == "" {
+		fmt.Fprintln(stderr, "chargeback: -log is required")
+		return 2
+	}
+	if *month != "" {
+		if _, err := time.Parse(tenancy.MonthLayout, *month); err != nil {
+			fmt.Fprintf(stderr, "chargeback: -month must be YYYY-MM, got %q\n", *month)
+			return 2
+		}
+	}
+	location := time.UTC
+	if *timeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(*timeZone); err != nil {
+			fmt.Fprintf(stderr, "chargeback: -timezone: %v\n", err)
+			return 2
+		}
+	}
+
+	display, err := parseDisplay(*currency, *rates)
+	if err != nil {
+		fmt.Fprintf(stderr, "chargeback: %v\n", err)
+		return 2
+	}
+
+	var records []algorithm.ReplayRecord
+	if err := readJSON(*logPath, &records); err != nil {
+		fmt.Fprintf(stderr, "Failed to read replay log: %v\n", err)
+		return 1
+	}
+
+	ledger, err := chargebackLedger(records, location, display)
+	if err != nil {
+		fmt.Fprintf(stderr, "chargeback: %v\n", err)
+		return 1
+	}
+
+	out := stdout
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "Failed to create %s: %v\n", *outPath, err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := tenancy.WriteChargebackCSV(out, ledger.Rollup(*month)); err != nil {
+		fmt.Fprintf(stderr, "Failed to write chargeback: %v\n", err)
+		return 1
+	}
+	if *outPath != "" {
+		fmt.Fprintf(stdout, "✓ Wrote %s\n", *outPath)
+	}
+	return 0
+}
+
+// chargebackLedger attributes the cost of every offload in a replay log to
+// its tenant and project, closing months in location
+func chargebackLedger(records []algorithm.ReplayRecord, location *time.Location, display units.Config) (*tenancy.Ledger, error) {
+	ledger := tenancy.NewLedger(location)
+	ledger.SetDisplay(display)
+	for _, record := range records {
+		if !record.Explanation.ShouldOffload {
+			continue
+		}
+		targetID := record.Outcome.TargetID
+		if targetID == "" {
+			targetID = record.Explanation.SelectedTargetID
+		}
+		for _, target := range record.Targets {
+			if target.ID != targetID {
+				continue
+			}
+			at := record.Outcome.EndTime
+			if at.IsZero() {
+				at = record.Explanation.Timestamp
+			}
+			target, err := target.InCurrency(display.Rates, units.BaseCurrency)
+			if err != nil {
+				return nil, fmt.Errorf("target %s: %w", target.ID, err)
+			}
+			breakdown := tenancy.AttributeCost(record.Process, target, record.Outcome.ExecutionTime, record.Outcome.CostActual)
+			ledger.Record(record.Process, breakdown, at)
+			break
+		}
+	}
+	return ledger, nil
+}
+
+// parseDisplay reads the display currency and the exchange rates to it,
+// given as comma-separated CUR=rate pairs
+func parseDisplay(currency, rates string) (units.Config, error) {
+	display := units.Config{DisplayCurrency: units.Currency(currency), Rates: units.Rates{}}
+	for _, pair := range strings.Split(rates, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		code, value, found := strings.Cut(pair, "=")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !found || err != nil {
+			return units.Config{}, fmt.Errorf("-rates: expected CUR=rate, got %q", pair)
+		}
+		display.Rates[units.Currency(strings.TrimSpace(code))] = rate
+	}
+	if err := display.Validate(); err != nil {
+		return units.Config{}, err
+	}
+	return display, nil
+}
@@ -0,0 +1,207 @@
+package capectl
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// resourcePhase is a stretch of a process's execution with a constant
+// resource intensity, as a fraction of its CPU and memory requirements
+type resourcePhase struct {
+	share  float64 // Fraction of the execution time
+	cpu    float64
+	memory float64
+}
+
+// resourceProfiles are the execution phases of each simulated process type.
+// IO phases stage data in and out with little CPU; compute phases run at
+// full intensity.
+var resourceProfiles = map[string][]resourcePhase{
+	"compute": {{0.1, 0.2, 0.5}, {0.8, 1.0, 1.0}, {0.1, 0.2, 0.5}},
+	"data":    {{0.4, 0.3, 0.6}, {0.4, 0.7, 1.0}, {0.2, 0.3, 0.6}},
+	"ml":      {{0.15, 0.2, 0.4}, {0.75, 1.0, 1.0}, {0.1, 0.3, 0.8}},
+	"batch":   {{0.25, 0.4, 0.5}, {0.5, 0.9, 0.9}, {0.25, 0.4, 0.5}},
+}
+
+// backgroundUsage is the local compute and memory usage of work outside the
+// simulation
+const (
+	backgroundCompute = 0.2
+	backgroundMemory  = 0.3
+)
+
+// Thermal model of simulated targets: each heats toward the ambient
+// temperature plus its rise at full load, with a first-order lag
+const (
+	ambientTemperature = 25.0            // °C
+	thermalLag         = 3 * time.Minute // Time constant of heating and cooling
+)
+
+// fullLoadRise is the temperature rise of each target type at full load.
+// Passively cooled edge devices run hottest.
+var fullLoadRise = map[models.TargetType]float64{
+	models.EDGE: 85,
+	models.FOG:  75,
+}
+
+// defaultFullLoadRise is the full-load rise of actively cooled targets
+const defaultFullLoadRise = 60.0
+
+// execution is a process running on a target
+type execution struct {
+	process  models.Process
+	targetID string
+	start    time.Time
+	duration time.Duration
+}
+
+// usage returns the CPU cores and memory bytes the execution uses at a time
+func (e execution) usage(at time.Time) (float64, int64, bool) {
+	elapsed := at.Sub(e.start)
+	if elapsed < 0 || elapsed >= e.duration || e.duration <= 0 {
+		return 0, 0, false
+	}
+	phases, exists := resourceProfiles[e.process.Type]
+	if !exists {
+		phases = []resourcePhase{{1, 1, 1}}
+	}
+	progress := elapsed.Seconds() / e.duration.Seconds()
+	phase := phases[len(phases)-1]
+	for _, p := range phases {
+		if progress < p.share {
+			phase = p
+			break
+		}
+		progress -= p.share
+	}
+	return e.process.CPURequirement * phase.cpu, int64(float64(e.process.MemoryRequirement) * phase.memory), true
+}
+
+// executionTracker follows the processes running on each target, so target
+// capacity and local system usage reflect assigned work rather than random
+// fluctuation
+type executionTracker struct {
+	running     []execution
+	peak        map[string]float64 // Peak CPU utilization per target
+	sum         map[string]float64 // Sum of sampled CPU utilization per target
+	samples     int
+	thermal     models.ThermalPolicy
+	temperature map[string]float64       // Current temperature per target, °C
+	peakTemp    map[string]float64       // Peak temperature per target, °C
+	throttled   map[string]time.Duration // Execution time added by throttling per target
+	lastApplied time.Time
+}
+
+func newExecutionTracker(thermal models.ThermalPolicy) *executionTracker {
+	return &executionTracker{
+		peak:        make(map[string]float64),
+		sum:         make(map[string]float64),
+		thermal:     thermal,
+		temperature: make(map[string]float64),
+		peakTemp:    make(map[string]float64),
+		throttled:   make(map[string]time.Duration),
+	}
+}
+
+// throttle returns how long an execution takes on a target at its current
+// temperature, and records the time throttling adds
+func (t *executionTracker) throttle(targetID string, duration time.Duration) time.Duration {
+	temperature, exists := t.temperature[targetID]
+	if !exists {
+		return duration
+	}
+	throttled := time.Duration(float64(duration) / t.thermal.SpeedFactor(temperature))
+	t.throttled[targetID] += throttled - duration
+	return throttled
+}
+
+// start records a process starting on a target
+func (t *executionTracker) start(process models.Process, targetID string, at time.Time, duration time.Duration) {
+	t.running = append(t.running, execution{process: process, targetID: targetID, start: at, duration: duration})
+}
+
+// apply updates the targets' available capacity, temperature and the local
+// usage in state from the executions running at the state's time, and
+// forgets finished executions
+func (t *executionTracker) apply(targets []models.OffloadTarget, state *models.SystemState) {
+	cpu := make(map[string]float64)
+	memory := make(map[string]int64)
+	running := t.running[:0]
+	for _, e := range t.running {
+		cores, bytes, active := e.usage(state.Timestamp)
+		if !active && !state.Timestamp.Before(e.start) {
+			continue
+		}
+		running = append(running, e)
+		cpu[e.targetID] += cores
+		memory[e.targetID] += bytes
+	}
+	t.running = running
+
+	t.samples++
+	elapsed := time.Duration(0)
+	if !t.lastApplied.IsZero() {
+		elapsed = state.Timestamp.Sub(t.lastApplied)
+	}
+	t.lastApplied = state.Timestamp
+	for i := range targets {
+		target := &targets[i]
+		target.AvailableCapacity = math.Max(0, target.TotalCapacity-cpu[target.ID])
+		target.MemoryAvailable = max(0, target.MemoryTotal-memory[target.ID])
+
+		utilization := 0.0
+		if target.TotalCapacity > 0 {
+			utilization = math.Min(1, cpu[target.ID]/target.TotalCapacity)
+		}
+		t.peak[target.ID] = math.Max(t.peak[target.ID], utilization)
+		t.sum[target.ID] += utilization
+		target.Temperature = t.heat(*target, utilization, elapsed)
+
+		if target.Type == models.LOCAL {
+			state.ComputeUsage = models.Utilization(math.Min(1, backgroundCompute+utilization*(1-backgroundCompute)))
+			if target.MemoryTotal > 0 {
+				used := float64(memory[target.ID]) / float64(target.MemoryTotal)
+				state.MemoryUsage = models.Utilization(math.Min(1, backgroundMemory+used*(1-backgroundMemory)))
+			}
+		}
+	}
+}
+
+// heat moves a target's temperature toward the equilibrium of its
+// utilization over the elapsed time, and returns it
+func (t *executionTracker) heat(target models.OffloadTarget, utilization float64, elapsed time.Duration) float64 {
+	rise, exists := fullLoadRise[target.Type]
+	if !exists {
+		rise = defaultFullLoadRise
+	}
+	equilibrium := ambientTemperature + utilization*rise
+	temperature, exists := t.temperature[target.ID]
+	if !exists {
+		temperature = equilibrium
+	}
+	temperature = equilibrium + (temperature-equilibrium)*math.Exp(-elapsed.Seconds()/thermalLag.Seconds())
+	t.temperature[target.ID] = temperature
+	t.peakTemp[target.ID] = math.Max(t.peakTemp[target.ID], temperature)
+	return temperature
+}
+
+// print writes the mean and peak CPU utilization, peak temperature and
+// throttled time of every target
+func (t *executionTracker) print(w io.Writer) {
+	fmt.Fprintf(w, "\nResource Traces (%d samples):\n", t.samples)
+	ids := make([]string, 0, len(t.peak))
+	for id := range t.peak {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		fmt.Fprintf(w, "  %s: mean CPU %.1f%%, peak CPU %.1f%%, peak %.1f°C, throttled %v\n",
+			id, t.sum[id]/float64(max(1, t.samples))*100, t.peak[id]*100,
+			t.peakTemp[id], t.throttled[id].Round(time.Second))
+	}
+}
@@ -0,0 +1,155 @@
+package capectl
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+)
+
+// exportTable is one CSV file written by export
+type exportTable struct {
+	file   string
+	header []string
+	rows   func(record algorithm.ReplayRecord) [][]string
+}
+
+var exportTables = []exportTable{
+	{
+		file:   "states.csv",
+		header: []string{"timestamp", "process_id", "queue_depth", "queue_threshold", "compute_usage", "memory_usage", "network_usage", "master_usage"},
+		rows: func(record algorithm.ReplayRecord) [][]string {
+			state := record.State
+			return [][]string{{
+				formatTime(state.Timestamp), record.Process.ID,
+				strconv.Itoa(state.QueueDepth), strconv.Itoa(state.QueueThreshold),
+				formatFloat(float64(state.ComputeUsage)), formatFloat(float64(state.MemoryUsage)),
+				formatFloat(float64(state.NetworkUsage)), formatFloat(float64(state.MasterUsage)),
+			}}
+		},
+	},
+	{
+		file:   "decisions.csv",
+		header: []string{"decision_id", "process_id", "timestamp", "should_offload", "target_id", "score", "reason", "candidates"},
+		rows: func(record algorithm.ReplayRecord) [][]string {
+			explanation := record.Explanation
+			return [][]string{{
+				explanation.DecisionID, explanation.ProcessID, formatTime(explanation.Timestamp),
+				strconv.FormatBool(explanation.ShouldOffload), explanation.SelectedTargetID,
+				formatFloat(explanation.Score), explanation.Reason, strconv.Itoa(len(explanation.Candidates)),
+			}}
+		},
+	},
+	{
+		file:   "candidates.csv",
+		header: []string{"decision_id", "target_id", "viable", "policy_allowed", "selected", "score", "rejection_reason"},
+		rows: func(record algorithm.ReplayRecord) [][]string {
+			rows := make([][]string, 0, len(record.Explanation.Candidates))
+			for _, candidate := range record.Explanation.Candidates {
+				rows = append(rows, []string{
+					record.Explanation.DecisionID, candidate.TargetID,
+					strconv.FormatBool(candidate.Viable), strconv.FormatBool(candidate.PolicyAllowed),
+					strconv.FormatBool(candidate.Selected), formatFloat(candidate.Score), candidate.RejectionReason,
+				})
+			}
+			return rows
+		},
+	},
+	{
+		file:   "outcomes.csv",
+		header: []string{"decision_id", "process_id", "target_id", "success", "completed_on_time", "execution_time_ms", "latency_ms", "cost_actual", "energy_consumed", "reward"},
+		rows: func(record algorithm.ReplayRecord) [][]string {
+			outcome := record.Outcome
+			return [][]string{{
+				record.Explanation.DecisionID, outcome.ProcessID, outcome.TargetID,
+				strconv.FormatBool(outcome.Success), strconv.FormatBool(outcome.CompletedOnTime),
+				formatMillis(outcome.ExecutionTime), formatMillis(outcome.LatencyActual),
+				formatFloat(outcome.CostActual), formatFloat(outcome.EnergyConsumed), formatFloat(outcome.Reward),
+			}}
+		},
+	},
+}
+
+// runExport writes the system states, decisions, candidate scores and
+// outcomes of a replay log to CSV files for analysis
+func runExport(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	addCommonFlags(flags)
+	logPath := flags.String("log", "", "Replay log written by 'simulate -replay-log' (required)")
+	outDir := flags.String("out", ".", "Directory to write the CSV files to")
+	format := flags.String("format", "csv", "Output format (csv)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *logPath == "" {
+		fmt.Fprintln(stderr, "export: -log is required")
+		return 2
+	}
+	if *format != "csv" {
+		fmt.Fprintf(stderr, "export: unsupported format %q\n", *format)
+		return 2
+	}
+
+	var records []algorithm.ReplayRecord
+	if err := readJSON(*logPath, &records); err != nil {
+		fmt.Fprintf(stderr, "Failed to read replay log: %v\n", err)
+		return 1
+	}
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		fmt.Fprintf(stderr, "Failed to create output directory: %v\n", err)
+		return 1
+	}
+
+	for _, table := range exportTables {
+		path := filepath.Join(*outDir, table.file)
+		if err := writeCSV(path, table, records); err != nil {
+			fmt.Fprintf(stderr, "Failed to write %s: %v\n", path, err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "✓ Wrote %s\n", path)
+	}
+	return 0
+}
+
+// writeCSV writes one table for all records
+func writeCSV(path string, table exportTable, records []algorithm.ReplayRecord) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(table.header); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := writer.WriteAll(table.rows(record)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatMillis(duration time.Duration) string {
+	return formatFloat(float64(duration) / float64(time.Millisecond))
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,138 @@
+package capectl
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// goldenBaseline is a recorded simulation scenario and the steady-state
+// metrics it produced
+type goldenBaseline struct {
+	Args       []string           `json:"args"`                 // simulate arguments of the scenario
+	Metrics    map[string]float64 `json:"metrics"`              // Recorded steady-state metrics
+	Tolerance  float64            `json:"tolerance"`            // Allowed relative drift of every metric
+	Tolerances map[string]float64 `json:"tolerances,omitempty"` // Per-metric overrides of the tolerance
+}
+
+// tolerance returns the allowed relative drift of a metric
+func (g goldenBaseline) tolerance(metric string) float64 {
+	if tolerance, ok := g.Tolerances[metric]; ok {
+		return tolerance
+	}
+	return g.Tolerance
+}
+
+// runGolden records a seeded simulation's metrics as a golden baseline, or
+// re-runs a recorded scenario and fails if its metrics drifted beyond the
+// baseline's tolerances
+func runGolden(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("golden", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	file := flags.String("file", "", "Golden baseline JSON file")
+	update := flags.Bool("update", false, "Record the baseline from the simulate arguments after --, or re-record it with its own")
+	tolerance := flags.Float64("tolerance", 0.05, "Allowed relative drift of each metric when recording a new baseline")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(stderr, "golden: -file is required")
+		return 2
+	}
+	if *tolerance < 0 {
+		fmt.Fprintln(stderr, "golden: -tolerance must be non-negative")
+		return 2
+	}
+
+	var baseline goldenBaseline
+	err := readJSON(*file, &baseline)
+	exists := err == nil
+	if err != nil && !(*update && errors.Is(err, fs.ErrNotExist)) {
+		fmt.Fprintf(stderr, "Failed to read golden baseline: %v\n", err)
+		return 1
+	}
+	if flags.NArg() > 0 {
+		if !*update {
+			fmt.Fprintln(stderr, "golden: simulate arguments can only be given with -update")
+			return 2
+		}
+		baseline.Args = flags.Args()
+	}
+	if !exists {
+		baseline.Tolerance = *tolerance
+	}
+
+	metrics, err := simulateMetrics(baseline.Args, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "Golden scenario failed: %v\n", err)
+		return 1
+	}
+
+	if *update {
+		baseline.Metrics = metrics
+		if err := writeJSON(*file, baseline); err != nil {
+			fmt.Fprintf(stderr, "Failed to write golden baseline: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "✓ Golden baseline with %d metrics written to %s\n", len(metrics), *file)
+		return 0
+	}
+
+	names := make([]string, 0, len(baseline.Metrics))
+	for name := range baseline.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(stdout, "Golden Scenario %s:\n", *file)
+	drifted := 0
+	for _, name := range names {
+		golden := baseline.Metrics[name]
+		actual, ok := metrics[name]
+		allowed := baseline.tolerance(name)
+		status := ""
+		switch {
+		case !ok:
+			status = " [MISSING]"
+			drifted++
+		case math.Abs(actual-golden) > allowed*math.Abs(golden):
+			status = " [DRIFT]"
+			drifted++
+		}
+		fmt.Fprintf(stdout, "  %s: %.4f (golden %.4f ±%.1f%%)%s\n", name, actual, golden, allowed*100, status)
+	}
+	if drifted > 0 {
+		fmt.Fprintf(stderr, "%d of %d metrics drifted beyond their tolerance\n", drifted, len(names))
+		return 1
+	}
+	fmt.Fprintln(stdout, "✓ All metrics within tolerance")
+	return 0
+}
+
+// simulateMetrics runs simulate with args and returns its steady-state
+// metrics. The simulation's own output is discarded.
+func simulateMetrics(args []string, stderr io.Writer) (map[string]float64, error) {
+	dir, err := os.MkdirTemp("", "capectl-golden-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "metrics.json")
+	run := append(append([]string(nil), args...), "-metrics-out", path)
+	if code := runSimulate(run, io.Discard, stderr); code != 0 {
+		return nil, fmt.Errorf("simulate exited with status %d", code)
+	}
+
+	var metrics map[string]float64
+	if err := readJSON(path, &metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
@@ -0,0 +1,111 @@
+package capectl
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	capev1 "github.com/casperlundberg/colony-process-offloader-algorithm/api/proto/cape/v1"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/sidecar"
+)
+
+// runOrchestrator runs the algorithm as a long-lived service until
+// interrupted: it serves decisions over gRPC, runs the telemetry, event,
+// probing, pricing and catalog loops the configuration enables, and learns
+// from the outcomes published on the outcome topic
+func runOrchestrator(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("run-orchestrator", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	common := addCommonFlags(flags)
+	listen := flags.String("listen", ":50051", "Address to serve cape.v1.DecisionService on")
+	ingestInterval := flags.Duration("ingest-interval", 5*time.Second, "How often to poll the outcome topic, when a consumer is configured")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *ingestInterval <= 0 {
+		fmt.Fprintln(stderr, "run-orchestrator: -ingest-interval must be positive")
+		return 2
+	}
+
+	config, err := common.loadConfig()
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	alg, err := algorithm.NewAlgorithm(config)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to initialize algorithm: %v\n", err)
+		return 1
+	}
+	defer alg.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to listen on %s: %v\n", *listen, err)
+		return 1
+	}
+	service := sidecar.NewService(alg)
+	server := grpc.NewServer()
+	capev1.RegisterDecisionServiceServer(server, sidecar.NewGRPCServer(service))
+
+	var loops sync.WaitGroup
+	for _, loop := range []func(context.Context){alg.RunTelemetry, alg.RunEvents, alg.RunProbes, alg.RunPricing, alg.RunCatalog} {
+		loops.Add(1)
+		go func(loop func(context.Context)) {
+			defer loops.Done()
+			loop(ctx)
+		}(loop)
+	}
+	loops.Add(1)
+	go func() {
+		defer loops.Done()
+		ingestOutcomes(ctx, service, *ingestInterval, stderr)
+	}()
+
+	// Finish in-flight calls on interrupt
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	fmt.Fprintf(stdout, "Serving cape.v1.DecisionService on %s\n", listener.Addr())
+	serveErr := server.Serve(listener)
+	stop()
+	loops.Wait()
+	if serveErr != nil {
+		fmt.Fprintf(stderr, "Failed to serve: %v\n", serveErr)
+		return 1
+	}
+	fmt.Fprintln(stdout, "✓ Stopped")
+	return 0
+}
+
+// ingestOutcomes polls the outcome topic every interval until the context is
+// cancelled. Failed polls are reported and retried at the next tick.
+func ingestOutcomes(ctx context.Context, service *sidecar.Service, interval time.Duration, stderr io.Writer) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := service.IngestOutcomes(ctx); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(stderr, "Failed to ingest outcomes: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package capectl
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// queueSnapshot is the queued work and system state a plan is made for
+type queueSnapshot struct {
+	State     models.SystemState `json:"state"`
+	Processes []models.Process   `json:"processes"`
+}
+
+// runPlan decides every process in a queue snapshot against a target catalog
+// and prints the would-be placements, so configuration and catalog changes
+// can be checked offline. Decisions are made by a throwaway algorithm and
+// nothing is executed.
+func runPlan(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("plan", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	common := addCommonFlags(flags)
+	queuePath := flags.String("queue", "", "JSON queue snapshot with the system state and queued processes (required)")
+	catalogPath := flags.String("targets", "", "JSON catalog of offload targets (required)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *queuePath == "" || *catalogPath == "" {
+		fmt.Fprintln(stderr, "plan: -queue and -targets are required")
+		return 2
+	}
+
+	config, err := common.loadConfig()
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	var snapshot queueSnapshot
+	if err := readJSON(*queuePath, &snapshot); err != nil {
+		fmt.Fprintf(stderr, "Failed to read queue snapshot: %v\n", err)
+		return 1
+	}
+	var targets []models.OffloadTarget
+	if err := readJSON(*catalogPath, &targets); err != nil {
+		fmt.Fprintf(stderr, "Failed to read target catalog: %v\n", err)
+		return 1
+	}
+
+	// Catalog entries are planned as if just seen, not as stale
+	now := time.Now()
+	for i := range targets {
+		targets[i].LastSeen = now
+	}
+
+	alg, err := algorithm.NewAlgorithm(config)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to initialize algorithm: %v\n", err)
+		return 1
+	}
+	defer alg.Close()
+
+	offloaded, failed := 0, 0
+	totalCost := 0.0
+	for _, process := range snapshot.Processes {
+		dec, err := alg.MakeOffloadDecision(process, targets, snapshot.State)
+		if err != nil {
+			fmt.Fprintf(stdout, "Process %s: ERROR %v\n", process.ID, err)
+			failed++
+			continue
+		}
+
+		targetID := "local"
+		reason := "no target scored above keeping it local"
+		if explanation, err := alg.Explain(dec.DecisionID); err == nil && explanation.Reason != "" {
+			reason = explanation.Reason
+		}
+		if dec.ShouldOffload && dec.Target != nil {
+			targetID = dec.Target.ID
+			offloaded++
+			totalCost += dec.EstimatedCost
+		}
+		fmt.Fprintf(stdout, "Process %s -> %s (score: %.3f, confidence: %.3f, cost: %.4f): %s\n",
+			process.ID, targetID, dec.Score, dec.Confidence, dec.EstimatedCost, reason)
+	}
+
+	fmt.Fprintf(stdout, "\nPlanned %d processes: %d offloaded, %d local, %d failed, estimated cost %.4f\n",
+		len(snapshot.Processes), offloaded, len(snapshot.Processes)-offloaded-failed, failed, totalCost)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
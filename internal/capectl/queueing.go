@@ -0,0 +1,211 @@
+package capectl
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// serverCores is the size of an execution slot: a target with 16 cores runs
+// four processes at once and queues the rest
+const serverCores = 4
+
+// divergenceTolerance is the relative difference between simulated and
+// analytical waits beyond which a pool is flagged
+const divergenceTolerance = 0.5
+
+// expectedWaitMMc returns the mean queueing delay of an M/M/c queue with
+// arrival rate lambda and per-server service rate mu (Erlang C), or +Inf
+// when the queue is unstable
+func expectedWaitMMc(lambda, mu float64, c int) float64 {
+	if lambda <= 0 {
+		return 0
+	}
+	if c < 1 || mu <= 0 || lambda >= float64(c)*mu {
+		return math.Inf(1)
+	}
+	a := lambda / mu // Offered load in Erlangs
+	rho := a / float64(c)
+
+	// Erlang C from the Erlang B recursion, which avoids large factorials
+	erlangB := 1.0
+	for k := 1; k <= c; k++ {
+		erlangB = a * erlangB / (float64(k) + a*erlangB)
+	}
+	erlangC := erlangB / (1 - rho*(1-erlangB))
+	return erlangC / (float64(c)*mu - lambda)
+}
+
+// expectedWaitMGc approximates the mean queueing delay of a G/G/c queue by
+// scaling the M/M/c wait with the squared coefficients of variation of the
+// inter-arrival and service times (Allen-Cunneen). Both are 1 for M/M/c.
+func expectedWaitMGc(lambda, mu float64, c int, arrivalCV2, serviceCV2 float64) float64 {
+	return expectedWaitMMc(lambda, mu, c) * (arrivalCV2 + serviceCV2) / 2
+}
+
+// queueSample is a process arriving at a pool
+type queueSample struct {
+	arrival time.Time
+	service time.Duration
+}
+
+// queueValidator simulates FCFS queueing of processes on pools of execution
+// slots, one pool per target type, and compares the simulated waits with
+// queueing theory
+type queueValidator struct {
+	servers map[models.TargetType]int
+	samples map[models.TargetType][]queueSample
+}
+
+// newQueueValidator sizes each target type's pool from its targets' cores
+func newQueueValidator(targets []models.OffloadTarget) *queueValidator {
+	v := &queueValidator{
+		servers: make(map[models.TargetType]int),
+		samples: make(map[models.TargetType][]queueSample),
+	}
+	for _, target := range targets {
+		v.servers[target.Type] += max(1, int(target.TotalCapacity)/serverCores)
+	}
+	return v
+}
+
+// record adds a process arriving at a target type's pool
+func (v *queueValidator) record(targetType models.TargetType, arrival time.Time, service time.Duration) {
+	v.samples[targetType] = append(v.samples[targetType], queueSample{arrival: arrival, service: service})
+}
+
+// queueComparison is the simulated and analytical wait of a pool
+type queueComparison struct {
+	targetType    models.TargetType
+	servers       int
+	arrivals      int
+	utilization   float64
+	simulatedWait time.Duration
+	expectedWait  float64 // Seconds, +Inf for an unstable pool
+	diverges      bool
+}
+
+// compare runs the FCFS simulation of every pool with at least two arrivals
+// and compares its mean wait with the M/G/c approximation for the observed
+// arrival and service rates
+func (v *queueValidator) compare() []queueComparison {
+	types := make([]string, 0, len(v.samples))
+	for targetType := range v.samples {
+		types = append(types, string(targetType))
+	}
+	sort.Strings(types)
+
+	comparisons := make([]queueComparison, 0, len(types))
+	for _, name := range types {
+		targetType := models.TargetType(name)
+		samples := v.samples[targetType]
+		if len(samples) < 2 {
+			continue
+		}
+		c := v.servers[targetType]
+
+		// FCFS on c slots: each process starts on the earliest free slot
+		free := make([]time.Time, c)
+		var waited time.Duration
+		gaps := make([]float64, 0, len(samples)-1)
+		services := make([]float64, 0, len(samples))
+		for i, sample := range samples {
+			slot := 0
+			for j := range free {
+				if free[j].Before(free[slot]) {
+					slot = j
+				}
+			}
+			start := sample.arrival
+			if free[slot].After(start) {
+				waited += free[slot].Sub(start)
+				start = free[slot]
+			}
+			free[slot] = start.Add(sample.service)
+
+			services = append(services, sample.service.Seconds())
+			if i > 0 {
+				gaps = append(gaps, sample.arrival.Sub(samples[i-1].arrival).Seconds())
+			}
+		}
+
+		meanGap, gapCV2 := meanAndCV2(gaps)
+		meanService, serviceCV2 := meanAndCV2(services)
+		comparison := queueComparison{
+			targetType:    targetType,
+			servers:       c,
+			arrivals:      len(samples),
+			simulatedWait: waited / time.Duration(len(samples)),
+			expectedWait:  math.Inf(1),
+		}
+		if meanGap > 0 && meanService > 0 {
+			lambda, mu := 1/meanGap, 1/meanService
+			comparison.utilization = lambda / (float64(c) * mu)
+			comparison.expectedWait = expectedWaitMGc(lambda, mu, c, gapCV2, serviceCV2)
+		}
+
+		// Differences small against a service time are sampling noise
+		simulated := comparison.simulatedWait.Seconds()
+		if !math.IsInf(comparison.expectedWait, 1) {
+			difference := math.Abs(simulated - comparison.expectedWait)
+			comparison.diverges = difference > 0.1*meanService &&
+				difference > divergenceTolerance*math.Max(simulated, comparison.expectedWait)
+		}
+		comparisons = append(comparisons, comparison)
+	}
+	return comparisons
+}
+
+// print writes the comparison of every pool
+func (v *queueValidator) print(w io.Writer) {
+	comparisons := v.compare()
+	fmt.Fprintf(w, "\nQueueing Validation (%d pools):\n", len(comparisons))
+	for _, c := range comparisons {
+		expected := "unstable"
+		if !math.IsInf(c.expectedWait, 1) {
+			expected = seconds(c.expectedWait).Round(time.Millisecond).String()
+		}
+		flag := ""
+		if c.diverges {
+			flag = " [DIVERGES]"
+		}
+		fmt.Fprintf(w, "  %s: %d slots, %d arrivals, utilization %.1f%%, simulated wait %s, M/G/c wait %s%s\n",
+			c.targetType, c.servers, c.arrivals, c.utilization*100,
+			c.simulatedWait.Round(time.Millisecond), expected, flag)
+	}
+}
+
+// meanAndCV2 returns the mean and squared coefficient of variation of values
+func meanAndCV2(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum, squares float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	if mean == 0 {
+		return 0, 0
+	}
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	variance := squares / float64(len(values))
+	return mean, variance / (mean * mean)
+}
+
+// percentile returns the nearest-rank p-quantile of values
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
@@ -0,0 +1,56 @@
+package capectl
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+)
+
+// runReplay evaluates the -config candidate against a replay log written by
+// 'simulate -replay-log' and prints the report as JSON
+func runReplay(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	common := addCommonFlags(flags)
+	logPath := flags.String("log", "", "Replay log of completed decisions (required)")
+	temperature := flags.Float64("temperature", 0, "Softmax temperature for action propensities (default 0.1)")
+	maxWeight := flags.Float64("max-weight", 0, "Importance weight clip (default 10)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *logPath == "" {
+		fmt.Fprintln(stderr, "replay: -log is required")
+		return 2
+	}
+
+	candidate, err := common.loadConfig()
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	var records []algorithm.ReplayRecord
+	if err := readJSON(*logPath, &records); err != nil {
+		fmt.Fprintf(stderr, "Failed to read replay log: %v\n", err)
+		return 1
+	}
+
+	report, err := algorithm.Replay(candidate, records, algorithm.ReplayConfig{
+		Temperature: *temperature,
+		MaxWeight:   *maxWeight,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Replay failed: %v\n", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fmt.Fprintf(stderr, "Failed to write report: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,296 @@
+package capectl
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// spikeScript is a compiled -spike-script expression. It gives the extra
+// queue depth injected at each simulated decision, so new spike shapes need
+// no code changes.
+//
+// The language has numbers, the variables t (the decision number, from 1)
+// and threshold (the queue threshold), the operators + - * / ^, comparisons
+// (< <= > >= == !=) and && || ! that yield 1 or 0, parentheses, and the
+// functions abs, sin, cos, exp, log, sqrt, floor, ceil, min and max. For
+// example, a ramp between decisions 5 and 10:
+//
+//	(t >= 5 && t < 10) * (t - 4) * threshold
+type spikeScript struct {
+	root spikeNode
+}
+
+// spikeNode is a node of a compiled expression
+type spikeNode func(vars spikeVars) float64
+
+// spikeVars are the variables a spike script can read
+type spikeVars struct {
+	t         float64
+	threshold float64
+}
+
+// compileSpikeScript parses a spike script
+func compileSpikeScript(source string) (*spikeScript, error) {
+	p := &spikeParser{source: source}
+	p.next()
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "" {
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.token, p.offset)
+	}
+	return &spikeScript{root: root}, nil
+}
+
+// queueDepth evaluates the script for a decision, clamped to a non-negative
+// whole queue depth
+func (s *spikeScript) queueDepth(decision, threshold int) (int, error) {
+	value := s.root(spikeVars{t: float64(decision), threshold: float64(threshold)})
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("spike script is not finite at decision %d", decision)
+	}
+	return int(math.Round(math.Max(0, value))), nil
+}
+
+// spikeParser is a recursive descent parser over the script's tokens
+type spikeParser struct {
+	source string
+	pos    int
+	token  string // Current token, empty at the end of the source
+	offset int    // Offset of the current token
+}
+
+// next advances to the next token
+func (p *spikeParser) next() {
+	for p.pos < len(p.source) && unicode.IsSpace(rune(p.source[p.pos])) {
+		p.pos++
+	}
+	p.offset = p.pos
+	if p.pos >= len(p.source) {
+		p.token = ""
+		return
+	}
+
+	c := rune(p.source[p.pos])
+	start := p.pos
+	switch {
+	case unicode.IsDigit(c) || c == '.':
+		for p.pos < len(p.source) && (unicode.IsDigit(rune(p.source[p.pos])) || p.source[p.pos] == '.') {
+			p.pos++
+		}
+	case unicode.IsLetter(c) || c == '_':
+		for p.pos < len(p.source) && (unicode.IsLetter(rune(p.source[p.pos])) || unicode.IsDigit(rune(p.source[p.pos])) || p.source[p.pos] == '_') {
+			p.pos++
+		}
+	default:
+		p.pos++
+		if p.pos < len(p.source) {
+			switch two := p.source[start : p.pos+1]; two {
+			case "<=", ">=", "==", "!=", "&&", "||":
+				p.pos++
+			}
+		}
+	}
+	p.token = p.source[start:p.pos]
+}
+
+// expect consumes the given token
+func (p *spikeParser) expect(token string) error {
+	if p.token != token {
+		return fmt.Errorf("expected %q at offset %d", token, p.offset)
+	}
+	p.next()
+	return nil
+}
+
+// binary parses a left-associative chain of operators over operands
+func (p *spikeParser) binary(operand func() (spikeNode, error), ops map[string]func(a, b float64) float64) (spikeNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, exists := ops[p.token]
+		if !exists {
+			return left, nil
+		}
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(vars spikeVars) float64 { return op(l(vars), right(vars)) }
+	}
+}
+
+func (p *spikeParser) parseOr() (spikeNode, error) {
+	return p.binary(p.parseAnd, map[string]func(a, b float64) float64{
+		"||": func(a, b float64) float64 { return truth(a != 0 || b != 0) },
+	})
+}
+
+func (p *spikeParser) parseAnd() (spikeNode, error) {
+	return p.binary(p.parseComparison, map[string]func(a, b float64) float64{
+		"&&": func(a, b float64) float64 { return truth(a != 0 && b != 0) },
+	})
+}
+
+func (p *spikeParser) parseComparison() (spikeNode, error) {
+	return p.binary(p.parseSum, map[string]func(a, b float64) float64{
+		"<":  func(a, b float64) float64 { return truth(a < b) },
+		"<=": func(a, b float64) float64 { return truth(a <= b) },
+		">":  func(a, b float64) float64 { return truth(a > b) },
+		">=": func(a, b float64) float64 { return truth(a >= b) },
+		"==": func(a, b float64) float64 { return truth(a == b) },
+		"!=": func(a, b float64) float64 { return truth(a != b) },
+	})
+}
+
+func (p *spikeParser) parseSum() (spikeNode, error) {
+	return p.binary(p.parseProduct, map[string]func(a, b float64) float64{
+		"+": func(a, b float64) float64 { return a + b },
+		"-": func(a, b float64) float64 { return a - b },
+	})
+}
+
+func (p *spikeParser) parseProduct() (spikeNode, error) {
+	return p.binary(p.parseUnary, map[string]func(a, b float64) float64{
+		"*": func(a, b float64) float64 { return a * b },
+		"/": func(a, b float64) float64 { return a / b },
+	})
+}
+
+func (p *spikeParser) parseUnary() (spikeNode, error) {
+	switch p.token {
+	case "-":
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(vars spikeVars) float64 { return -operand(vars) }, nil
+	case "!":
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(vars spikeVars) float64 { return truth(operand(vars) == 0) }, nil
+	}
+	return p.parsePower()
+}
+
+// parsePower parses exponentiation, which is right-associative
+func (p *spikeParser) parsePower() (spikeNode, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "^" {
+		return base, nil
+	}
+	p.next()
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return func(vars spikeVars) float64 { return math.Pow(base(vars), exponent(vars)) }, nil
+}
+
+func (p *spikeParser) parsePrimary() (spikeNode, error) {
+	token, offset := p.token, p.offset
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of spike script")
+	case token == "(":
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	case unicode.IsDigit(rune(token[0])) || token[0] == '.':
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at offset %d", token, offset)
+		}
+		p.next()
+		return func(spikeVars) float64 { return value }, nil
+	}
+
+	p.next()
+	switch token {
+	case "t":
+		return func(vars spikeVars) float64 { return vars.t }, nil
+	case "threshold":
+		return func(vars spikeVars) float64 { return vars.threshold }, nil
+	}
+	if p.token != "(" {
+		return nil, fmt.Errorf("unknown variable %q at offset %d", token, offset)
+	}
+	return p.parseCall(token, offset)
+}
+
+// spikeFunctions are the functions a spike script can call, by arity
+var spikeFunctions = map[string]interface{}{
+	"abs":   math.Abs,
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"exp":   math.Exp,
+	"log":   math.Log,
+	"sqrt":  math.Sqrt,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+	"min":   math.Min,
+	"max":   math.Max,
+}
+
+// parseCall parses the arguments of a function call
+func (p *spikeParser) parseCall(name string, offset int) (spikeNode, error) {
+	fn, exists := spikeFunctions[strings.ToLower(name)]
+	if !exists {
+		return nil, fmt.Errorf("unknown function %q at offset %d", name, offset)
+	}
+	p.next()
+	var args []spikeNode
+	for p.token != ")" {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next()
+
+	switch fn := fn.(type) {
+	case func(float64) float64:
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%s takes 1 argument, got %d", name, len(args))
+		}
+		return func(vars spikeVars) float64 { return fn(args[0](vars)) }, nil
+	case func(float64, float64) float64:
+		if len(args) != 2 {
+			return nil, fmt.Errorf("%s takes 2 arguments, got %d", name, len(args))
+		}
+		return func(vars spikeVars) float64 { return fn(args[0](vars), args[1](vars)) }, nil
+	}
+	return nil, fmt.Errorf("unsupported function %q", name)
+}
+
+// truth converts a condition to 1 or 0
+func truth(condition bool) float64 {
+	if condition {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+package capectl
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+)
+
+// runValidateConfig checks each configuration file named by -config or as an
+// argument, reporting every problem found
+func runValidateConfig(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	common := addCommonFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	paths := flags.Args()
+	if common.config != "" {
+		paths = append([]string{common.config}, paths...)
+	}
+	if len(paths) == 0 {
+		fmt.Fprintln(stderr, "validate-config: no configuration files given")
+		return 2
+	}
+
+	status := 0
+	for _, path := range paths {
+		problems := algorithm.ValidateConfigFile(path)
+		for _, problem := range problems {
+			fmt.Fprintln(stderr, problem)
+		}
+		if len(problems) > 0 {
+			status = 1
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: valid\n", path)
+	}
+	return status
+}
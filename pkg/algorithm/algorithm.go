@@ -1,20 +1,55 @@
 package algorithm
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
 	"time"
 
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/events"
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/pricing"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/probe"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/telemetry"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/tenancy"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
 )
 
 // Algorithm is the main orchestrator that integrates all components
 type Algorithm struct {
 	decisionEngine *decision.DecisionEngine
 	learner        *learning.AdaptiveLearner
+	canary         *learning.CanaryController // nil when learned weights apply immediately
+	strategies     *learning.ThompsonSampler  // nil when decisions use the learned weights only
 	policyEngine   *policy.PolicyEngine
+	smoother       *learning.MetricSmoother
+	health         *learning.HealthMonitor // nil when reliability is taken as reported
+	resize         *learning.ResizeAdvisor // nil when resize recommendations are disabled
+	forecaster     *learning.Forecaster    // nil when load forecasting is disabled
+	prober         *probe.Monitor          // nil when network metrics are taken as reported
+	prices         *pricing.Catalog        // nil when compute costs are taken as configured
+	telemetry      *telemetry.Subscriber   // nil when targets are not updated from MQTT telemetry
+	events         *events.Producer        // nil when events are not published to Kafka
+	outcomeFeed    *events.Consumer        // nil when external outcomes are not consumed
+	gravity        *learning.GravityLearner // nil when data gravity is not learned
+	targets        *decision.TargetRegistry
+	calendar       *models.Calendar
+	budget         *policy.BudgetManager // nil when no budget is configured
+	failover       *policy.FailoverPolicy // nil when offloads are not restricted by region
+	tenants        *tenancy.Manager
+	chargeback     *tenancy.Ledger
+	ruleWatcher    *policy.RuleWatcher
+	logger         *slog.Logger
+	now            func() time.Time // Config.Clock, or the wall clock
+	logCloser      io.Closer
+	auditWriter    *policy.AuditWriter // nil when the audit trail is kept in memory only
 	
 	// Configuration
 	config      Config
@@ -24,15 +59,80 @@ type Algorithm struct {
 	// Runtime state
 	decisionCount       int
 	lastPerformanceEval time.Time
+	pending             *pendingTracker                    // Decisions and decision inputs awaiting outcomes, by process ID
+	history             *decisionHistory                   // Recent decision explanations, by decision ID
+	phaseStats          map[string]*PhaseStat              // Latency statistics, by decision phase
+	stats               *statsTracker                      // Decision and outcome statistics
+	replayLog           []ReplayRecord                     // Completed decisions for counterfactual replay
+	recurring           map[string]*recurringDefinition     // Recurring process definitions, by ID
+	cordoned            map[string]time.Time                // Draining targets and when they were cordoned, by ID
+	lastActive          map[string]time.Time                // Last placement on or outcome from each target, by ID
+	spikes              *spikeTracker                       // nil when spike detection is disabled
+	scalingPublished    map[string]learning.ResizeRecommendation // Last scaling event published, by target ID
+	journal             *outcomeJournal                     // nil when decisions and outcomes are not journaled
+	replayed            JournalReplay                       // Records replayed from the journal on startup
+	memo                *resultMemo                         // nil when call results are not cached
+	catalog             *decision.CatalogManager            // nil when targets are not registered from an executor catalog
 }
 
 // Config contains algorithm configuration
 type Config struct {
 	InitialWeights      decision.AdaptiveWeights `json:"initial_weights"`
+	Objectives          []decision.ObjectiveGoal `json:"objectives"` // Registered custom objectives and their score shares
 	LearningConfig      learning.LearningConfig  `json:"learning_config"`
 	SafetyConstraints   policy.SafetyConstraints `json:"safety_constraints"`
 	PerformanceTargets  PerformanceTargets       `json:"performance_targets"`
 	MonitoringConfig    MonitoringConfig         `json:"monitoring_config"`
+	SLAPolicy           learning.SLAPolicy       `json:"sla_policy"`
+	Smoothing           learning.SmoothingConfig `json:"smoothing"`
+	Health              learning.HealthConfig    `json:"health"`  // Score target reliability from heartbeats
+	Resize              learning.ResizeConfig    `json:"resize"`  // Recommend size classes from heartbeat load
+	Forecast            learning.ForecastConfig  `json:"forecast"` // Forecast target load from heartbeats
+	Probing             probe.Config             `json:"probing"` // Measure target latency and bandwidth
+	Pricing             pricing.Config           `json:"pricing"` // Refresh target compute prices from price sources
+	Telemetry           telemetry.Config         `json:"telemetry"` // Update targets from executor telemetry over MQTT
+	Events              events.Config            `json:"events"`    // Publish decisions and outcomes to Kafka, and consume observed outcomes
+	Journal             JournalConfig            `json:"journal"`   // Write-ahead journal of decisions and outcomes, replayed on startup
+	Memo                MemoConfig               `json:"memo"`      // Answer repeated idempotent function calls from a result cache
+	Catalog             decision.ExecutorCatalogConfig `json:"catalog"` // Register targets from a versioned executor catalog, reloaded live
+	DataGravity         learning.GravityConfig   `json:"data_gravity"` // Learn data movement cost from outcomes
+	Strategies          learning.StrategyConfig  `json:"strategies"`   // Thompson sampling over named weight profiles
+	PolicyRulesFile     string                   `json:"policy_rules_file"` // Declarative JSON/YAML rules, hot-reloadable
+	OPA                 policy.OPAConfig         `json:"opa"`               // Delegate placement policy to OPA (empty URL disables)
+	Jurisdictions       []models.TransferEdge    `json:"jurisdictions"`     // Permitted cross-jurisdiction data transfers (empty = unrestricted)
+	AffinityGroups      []models.AffinityGroup   `json:"affinity_groups"`
+	Security            models.SecurityPolicy    `json:"security"` // Security levels and transfer encryption by data sensitivity
+	Transfers           decision.TransferConfig  `json:"transfers"`    // Model transfers sharing congested links
+	DataCatalog         decision.CatalogConfig   `json:"data_catalog"` // Dataset cache capacity on targets
+	Reservations        decision.ReservationConfig `json:"reservations"` // Reserve target capacity for committed offloads until their outcomes
+	Budget              policy.BudgetConfig      `json:"budget"`
+	Failover            policy.FailoverConfig    `json:"failover"` // Ranked regions offloads fall back through
+	TenantQuotas        map[string]tenancy.Quota `json:"tenant_quotas"`       // By tenant ID
+	TenantQuotaPeriod   time.Duration            `json:"tenant_quota_period"` // Quota reset period (0 = 24h)
+	ReplayLogSize       int                      `json:"replay_log_size"`     // Completed decisions kept for Replay (0 disables)
+	Recurring           []models.RecurringProcess `json:"recurring"`          // Processes submitted on cron schedules
+	Drain               DrainConfig               `json:"drain"`              // Idle detection and draining of targets
+	Spike               SpikeConfig               `json:"spike"`              // Queue spike detection and post-mortems
+	History             HistoryConfig             `json:"history"`            // Decision explanations kept for Explain
+	Calendar            models.CalendarConfig     `json:"calendar"`           // Time zone and business days of schedules
+	Units               units.Config              `json:"units"`              // Exchange rates and the currency of reports
+	Energy              models.EnergyConfig       `json:"energy"`             // Power usage effectiveness of target sites
+	Thermal             models.ThermalPolicy      `json:"thermal"`            // Throttling of hot targets
+	Battery             models.BatteryPolicy      `json:"battery"`            // Charge kept on battery-powered targets
+
+	// RewardFunction shapes outcome rewards before learning. When nil, the
+	// reward reported on the outcome is used as-is.
+	RewardFunction learning.RewardFunction `json:"-"`
+
+	// Logger receives structured logs from all components. When nil, a logger
+	// is built from MonitoringConfig.Logging.
+	Logger *slog.Logger `json:"-"`
+
+	// Clock is the time source of decision and outcome times, quota and
+	// budget windows, and expiry, for all components. When nil, the wall
+	// clock is used. Decision latencies are always measured on the wall
+	// clock.
+	Clock func() time.Time `json:"-"`
 }
 
 // PerformanceTargets defines expected performance levels
@@ -49,7 +149,9 @@ type MonitoringConfig struct {
 	EnableMetrics    bool          `json:"enable_metrics"`
 	MetricsInterval  time.Duration `json:"metrics_interval"`
 	EnableAuditLogs  bool          `json:"enable_audit_logs"`
+	AuditLog         policy.AuditConfig `json:"audit_log"` // Persist the audit trail as hash-chained records
 	EnableAlerts     bool          `json:"enable_alerts"`
+	Logging          logging.Config `json:"logging"`
 }
 
 // NewAlgorithm creates a new algorithm instance
@@ -59,15 +161,68 @@ func NewAlgorithm(config Config) (*Algorithm, error) {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Close the files opened so far when a later step fails
+	var closers []func() error
+	succeeded := false
+	defer func() {
+		if succeeded {
+			return
+		}
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}()
+
+	// Initialize structured logging
+	logger := config.Logger
+	var logCloser io.Closer
+	if logger == nil {
+		var err error
+		logger, logCloser, err = logging.New(config.MonitoringConfig.Logging)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize logging: %w", err)
+		}
+		closers = append(closers, logCloser.Close)
+	}
+
+	// Time every component by the configured clock
+	now := config.Clock
+	if now == nil {
+		now = time.Now
+	}
+
+	// Read schedules in the deployment region's time zone and business days
+	calendar, err := models.NewCalendar(config.Calendar)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calendar: %w", err)
+	}
+
 	// Initialize decision engine
 	decisionEngine := decision.NewDecisionEngine(config.InitialWeights)
+	decisionEngine.SetLogger(logger.With("component", "decision"))
+	decisionEngine.SetClock(now)
 
-	// Initialize learning component
-	learner := learning.NewAdaptiveLearner(config.LearningConfig)
+	// Initialize learning component, using the performance target as the
+	// convergence timeout unless one is configured explicitly
+	learningConfig := config.LearningConfig
+	if learningConfig.Convergence.Timeout == 0 {
+		learningConfig.Convergence.Timeout = config.PerformanceTargets.ConvergenceTimeout
+	}
+	learner := learning.NewAdaptiveLearner(learningConfig)
+	learner.SetLogger(logger.With("component", "learning"))
+	learner.SetClock(now)
+
+	// Roll learned weights out to a canary cohort first
+	var canary *learning.CanaryController
+	if learningConfig.Canary.Enabled {
+		canary = learning.NewCanaryController(learningConfig.Canary, decisionEngine.GetWeights())
+	}
 
 	// Initialize policy engine
 	policyEngine := policy.NewPolicyEngine()
 	policyEngine.SetSafetyConstraints(config.SafetyConstraints)
+	policyEngine.SetLogger(logger.With("component", "policy"))
+	policyEngine.SetClock(now)
 
 	// Add default policy rules
 	defaultRules := createDefaultPolicyRules()
@@ -77,14 +232,306 @@ func NewAlgorithm(config Config) (*Algorithm, error) {
 		}
 	}
 
-	return &Algorithm{
-		decisionEngine: decisionEngine,
-		learner:        learner,
-		policyEngine:   policyEngine,
-		config:         config,
-		version:        "1.0.0",
-		initialized:    true,
-	}, nil
+	// Restrict data transfers between jurisdictions
+	targets := decision.NewTargetRegistry()
+	if len(config.Jurisdictions) > 0 {
+		graph, err := models.NewJurisdictionGraph(config.Jurisdictions...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jurisdiction graph: %w", err)
+		}
+		decisionEngine.SetJurisdictionGraph(graph)
+		targets.SetJurisdictionGraph(graph)
+		if err := policyEngine.AddRule(policy.JurisdictionRule(graph)); err != nil {
+			return nil, fmt.Errorf("failed to add jurisdiction policy rule: %w", err)
+		}
+	}
+
+	// Negotiate security levels and transfer encryption from data sensitivity
+	if config.Security.Enabled {
+		decisionEngine.SetSecurityPolicy(config.Security)
+		if err := policyEngine.AddRule(policy.SecurityRule(config.Security)); err != nil {
+			return nil, fmt.Errorf("failed to add security policy rule: %w", err)
+		}
+	}
+
+	// Throttle hot targets and keep heavy processes off them
+	if config.Thermal.Enabled {
+		decisionEngine.SetThermalPolicy(config.Thermal)
+	}
+
+	// Preserve the uptime of battery-powered targets
+	if config.Battery.Enabled {
+		decisionEngine.SetBatteryPolicy(config.Battery)
+		if err := policyEngine.AddRule(policy.BatteryRule(config.Battery)); err != nil {
+			return nil, fmt.Errorf("failed to add battery policy rule: %w", err)
+		}
+	}
+
+	// Declare affinity groups
+	for _, group := range config.AffinityGroups {
+		if err := decisionEngine.Affinity().DeclareGroup(group); err != nil {
+			return nil, fmt.Errorf("invalid affinity group: %w", err)
+		}
+	}
+
+	// Score custom objectives registered with decision.RegisterObjective
+	if err := decisionEngine.SetObjectives(config.Objectives); err != nil {
+		return nil, fmt.Errorf("invalid objectives: %w", err)
+	}
+
+	// Track datasets cached on targets
+	decisionEngine.SetDataCatalog(decision.NewDataCatalog(config.DataCatalog))
+
+	// Model concurrent transfers on shared links
+	if config.Transfers.Enabled {
+		transfers, err := decision.NewTransferEstimator(config.Transfers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transfer configuration: %w", err)
+		}
+		transfers.SetLocation(calendar.Location())
+		decisionEngine.SetTransferEstimator(transfers)
+	}
+	if len(config.Transfers.StorageTiers) > 0 {
+		decisionEngine.SetStorageTiers(config.Transfers.StorageTiers)
+	}
+
+	// Reserve the capacity of committed offloads, so concurrent decisions
+	// do not double-book a nearly full target
+	if config.Reservations.Enabled {
+		decisionEngine.SetReservations(decision.NewReservationLedger(config.Reservations))
+	}
+
+	// Enforce cost budgets
+	var budget *policy.BudgetManager
+	if config.Budget.IsEnabled() {
+		var err error
+		if budget, err = policy.NewBudgetManager(config.Budget); err != nil {
+			return nil, fmt.Errorf("invalid budget: %w", err)
+		}
+		budget.SetClock(now)
+	}
+
+	// Fall back through ranked regions when the preferred one is unusable
+	var failover *policy.FailoverPolicy
+	if config.Failover.Enabled {
+		var err error
+		if failover, err = policy.NewFailoverPolicy(config.Failover); err != nil {
+			return nil, fmt.Errorf("invalid failover: %w", err)
+		}
+	}
+
+	// Track tenant usage against quotas
+	tenants := tenancy.NewManager(config.TenantQuotaPeriod)
+	tenants.SetClock(now)
+	for tenantID, quota := range config.TenantQuotas {
+		if err := tenants.SetQuota(tenantID, quota); err != nil {
+			return nil, fmt.Errorf("invalid tenant quota: %w", err)
+		}
+	}
+
+	// Load declarative policy rules
+	var ruleWatcher *policy.RuleWatcher
+	if config.PolicyRulesFile != "" {
+		ruleWatcher = policy.NewRuleWatcher(policyEngine, config.PolicyRulesFile, 0)
+		if err := ruleWatcher.Load(); err != nil {
+			return nil, fmt.Errorf("failed to load policy rules: %w", err)
+		}
+	}
+
+	// Delegate placement policy to Open Policy Agent
+	if config.OPA.URL != "" {
+		opa, err := policy.NewOPAClient(config.OPA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure OPA: %w", err)
+		}
+		opa.SetLogger(logger.With("component", "policy"))
+		if err := policyEngine.ReplaceRules(policy.OPASource, []policy.PolicyRule{opa.Rule()}); err != nil {
+			return nil, fmt.Errorf("failed to add OPA policy rule: %w", err)
+		}
+	}
+
+	// Initialize optional metric smoothing
+	var smoother *learning.MetricSmoother
+	if config.Smoothing.Enabled {
+		smoother = learning.NewMetricSmoother(config.Smoothing)
+	}
+
+	// Score target health from executor heartbeats
+	var health *learning.HealthMonitor
+	if config.Health.Enabled {
+		health = learning.NewHealthMonitor(config.Health)
+	}
+
+	// Recommend resizing targets with persistently high or low load
+	var resize *learning.ResizeAdvisor
+	if config.Resize.Enabled {
+		resize = learning.NewResizeAdvisor(config.Resize)
+		if ledger := decisionEngine.Reservations(); ledger != nil {
+			resize.SetReservations(ledger)
+		}
+	}
+
+	// Forecast target load with the most accurate predictor per target type
+	var forecaster *learning.Forecaster
+	if config.Forecast.Enabled {
+		forecaster = learning.NewForecaster(config.Forecast)
+	}
+
+	// Learn data gravity from offload outcomes
+	var gravity *learning.GravityLearner
+	if config.DataGravity.Enabled {
+		gravity = learning.NewGravityLearner(config.DataGravity)
+	}
+
+	// Sample weight profiles for decisions
+	var strategies *learning.ThompsonSampler
+	if config.Strategies.Enabled {
+		var err error
+		if strategies, err = learning.NewThompsonSampler(config.Strategies); err != nil {
+			return nil, fmt.Errorf("invalid strategies: %w", err)
+		}
+		strategies.SetCalendar(calendar)
+	}
+
+	// Probe target endpoints for current network conditions
+	var prober *probe.Monitor
+	if config.Probing.Enabled {
+		var err error
+		if prober, err = probe.NewMonitor(config.Probing); err != nil {
+			return nil, fmt.Errorf("invalid probing configuration: %w", err)
+		}
+	}
+
+	// Refresh target compute prices from price sources
+	var prices *pricing.Catalog
+	if config.Pricing.Enabled {
+		var err error
+		if prices, err = pricing.NewCatalog(config.Pricing); err != nil {
+			return nil, fmt.Errorf("invalid pricing configuration: %w", err)
+		}
+		prices.SetRates(config.Units.Rates)
+	}
+
+	// Persist the audit trail for tamper-evident compliance records
+	var auditWriter *policy.AuditWriter
+	if config.MonitoringConfig.AuditLog.Dir != "" {
+		var err error
+		if auditWriter, err = policy.NewAuditWriter(config.MonitoringConfig.AuditLog); err != nil {
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+		closers = append(closers, auditWriter.Close)
+		policyEngine.SetAuditWriter(auditWriter)
+	}
+
+	// Charge offloads by the calendar's months, reported in the display
+	// currency
+	chargeback := tenancy.NewLedger(calendar.Location())
+	chargeback.SetDisplay(config.Units)
+
+	// Bound the decision explanations kept for Explain
+	history, err := newDecisionHistory(config.History)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open decision history spill file: %w", err)
+	}
+	closers = append(closers, history.close)
+
+	algorithm := &Algorithm{
+		decisionEngine:   decisionEngine,
+		learner:          learner,
+		canary:           canary,
+		strategies:       strategies,
+		policyEngine:     policyEngine,
+		smoother:         smoother,
+		health:           health,
+		resize:           resize,
+		forecaster:       forecaster,
+		prober:           prober,
+		prices:           prices,
+		gravity:          gravity,
+		targets:          targets,
+		calendar:         calendar,
+		budget:           budget,
+		failover:         failover,
+		tenants:          tenants,
+		chargeback:       chargeback,
+		ruleWatcher:      ruleWatcher,
+		logger:           logger.With("component", "algorithm"),
+		now:              now,
+		logCloser:        logCloser,
+		auditWriter:      auditWriter,
+		config:           config,
+		version:          "1.0.0",
+		initialized:      true,
+		pending:          newPendingTracker(config.History),
+		history:          history,
+		phaseStats:       make(map[string]*PhaseStat),
+		stats:            &statsTracker{},
+		recurring:        make(map[string]*recurringDefinition),
+		cordoned:         make(map[string]time.Time),
+		lastActive:       make(map[string]time.Time),
+	}
+	// The algorithm owns the files opened so far, and those opened below
+	closers = []func() error{algorithm.Close}
+	if config.Spike.Enabled {
+		algorithm.spikes = newSpikeTracker(config.Spike)
+	}
+	if config.Memo.Enabled {
+		algorithm.memo = newResultMemo(config.Memo)
+	}
+
+	// Register the executor catalog's targets
+	if config.Catalog.Source != "" {
+		if algorithm.catalog, err = decision.NewCatalogManager(config.Catalog, algorithm.targets); err != nil {
+			return nil, fmt.Errorf("invalid catalog configuration: %w", err)
+		}
+		if _, _, err = algorithm.catalog.Load(context.Background()); err != nil {
+			return nil, fmt.Errorf("failed to load executor catalog: %w", err)
+		}
+	}
+
+	// Update registered targets from executor telemetry
+	if config.Telemetry.Enabled {
+		if algorithm.telemetry, err = telemetry.NewSubscriber(config.Telemetry, algorithm.ApplyTelemetry); err != nil {
+			return nil, fmt.Errorf("invalid telemetry configuration: %w", err)
+		}
+	}
+
+	// Restore learning, statistics and pending decisions from the journal
+	// before anything that would publish the replayed records again
+	if config.Journal.Path != "" {
+		if algorithm.journal, err = algorithm.openJournal(config.Journal); err != nil {
+			return nil, fmt.Errorf("failed to replay journal: %w", err)
+		}
+		if algorithm.replayed.Decisions+algorithm.replayed.Outcomes > 0 {
+			algorithm.logger.Info("journal replayed",
+				"decisions", algorithm.replayed.Decisions,
+				"outcomes", algorithm.replayed.Outcomes,
+				"pending", algorithm.replayed.Pending)
+		}
+	}
+
+	// Publish decisions and outcomes, and consume outcomes observed elsewhere
+	if config.Events.Enabled {
+		if algorithm.events, err = events.NewProducer(config.Events); err != nil {
+			return nil, fmt.Errorf("invalid events configuration: %w", err)
+		}
+		algorithm.scalingPublished = make(map[string]learning.ResizeRecommendation)
+	}
+	if config.Events.Consumer.Enabled {
+		if algorithm.outcomeFeed, err = events.NewConsumer(config.Events); err != nil {
+			return nil, fmt.Errorf("invalid events configuration: %w", err)
+		}
+	}
+
+	// Expand recurring processes from now on
+	for _, definition := range config.Recurring {
+		if err := algorithm.AddRecurring(definition); err != nil {
+			return nil, err
+		}
+	}
+
+	succeeded = true
+	return algorithm, nil
 }
 
 // MakeOffloadDecision makes an intelligent offloading decision
@@ -92,13 +539,35 @@ func (a *Algorithm) MakeOffloadDecision(
 	process models.Process,
 	availableTargets []models.OffloadTarget,
 	systemState models.SystemState,
+) (decision.OffloadDecision, error) {
+	return a.MakeOffloadDecisionContext(context.Background(), process, availableTargets, systemState)
+}
+
+// MakeOffloadDecisionContext makes an offloading decision bounded by
+// PerformanceTargets.MaxDecisionLatency and the context's own deadline.
+// Policy evaluation, filtering and scoring examine the most promising targets
+// first and stop once the budget expires, and the best target scored so far
+// is returned.
+func (a *Algorithm) MakeOffloadDecisionContext(
+	ctx context.Context,
+	process models.Process,
+	availableTargets []models.OffloadTarget,
+	systemState models.SystemState,
 ) (decision.OffloadDecision, error) {
 	if !a.initialized {
 		return decision.OffloadDecision{}, fmt.Errorf("algorithm not initialized")
 	}
 
 	startTime := time.Now()
+	now := a.now()
 	a.decisionCount++
+	a.learner.ObserveDecision()
+
+	var phases decision.PhaseTimings
+	phaseStart := startTime
+
+	ctx, cancel := context.WithTimeout(ctx, a.config.PerformanceTargets.MaxDecisionLatency)
+	defer cancel()
 
 	// Step 1: Validate inputs
 	if err := process.Validate(); err != nil {
@@ -108,40 +577,170 @@ func (a *Algorithm) MakeOffloadDecision(
 		return decision.OffloadDecision{}, fmt.Errorf("invalid system state: %w", err)
 	}
 
+	// Free what decisions whose outcomes were lost still hold
+	a.expirePending(now)
+
+	// Answer a repeated idempotent call with its cached result instead of
+	// running it again
+	if a.memo != nil {
+		if result, ok := a.memo.lookup(process, now); ok {
+			explain := explanationContext{process: process, targets: availableTargets, state: systemState}
+			phases.StateSnapshot = time.Since(phaseStart)
+			return a.finalizeDecision(a.createCachedDecision(result, startTime), explain, phases), nil
+		}
+	}
+
+	// Draining targets take no new processes, costs are compared in the
+	// base currency, and energy is drawn at the PUE of each target's site
+	availableTargets = a.schedulableTargets(availableTargets)
+	availableTargets = a.baseCurrencyTargets(availableTargets)
+	availableTargets = a.sitedTargets(availableTargets)
+
+	// Track queue spikes for post-mortems
+	if a.spikes != nil {
+		a.observeSpike(systemState, availableTargets)
+	}
+
+	// Decide canary cohort processes with the candidate weights
+	if a.canary != nil {
+		a.decisionEngine.UpdateWeights(a.canary.Weights(process.ID))
+		defer a.decisionEngine.UpdateWeights(a.canary.Stable())
+	}
+
+	// Decide with a sampled strategy's weights when one applies
+	if a.strategies != nil {
+		if strategy, ok := a.strategies.Select(process, now); ok {
+			defer a.decisionEngine.UpdateWeights(a.decisionEngine.GetWeights())
+			a.decisionEngine.UpdateWeights(strategy.Weights)
+			a.logger.Debug("strategy selected", "process_id", process.ID, "strategy", strategy.Name)
+		}
+	}
+
+	// Replace configured network metrics with probe measurements and
+	// compute costs with current prices
+	if a.prober != nil {
+		availableTargets = a.prober.ApplyTargets(availableTargets)
+	}
+	if a.prices != nil {
+		availableTargets = a.prices.ApplyTargets(availableTargets)
+	}
+
+	// Smooth jittery metrics before they enter decision making
+	if a.smoother != nil {
+		systemState = a.smoother.SmoothState(systemState)
+		availableTargets = a.smoother.SmoothTargets(availableTargets)
+	}
+
+	// Scale reliability by heartbeat health, so unhealthy targets score lower
+	// and fail the reliability checks
+	if a.health != nil {
+		availableTargets = a.health.ApplyTargets(availableTargets, now)
+	}
+
+	phases.StateSnapshot = time.Since(phaseStart)
+
+	explain := explanationContext{
+		process:     process,
+		targets:     availableTargets,
+		state:       systemState,
+		evaluations: make(map[string]policy.PolicyEvaluation),
+	}
+
 	// Step 2: Check safety constraints
-	if !a.policyEngine.CheckSafetyConstraints(systemState, a.getCurrentOffloadCount()) {
-		return a.createSafetyBlockedDecision(process, "safety constraints not met", startTime), nil
+	phaseStart = time.Now()
+	safe := a.policyEngine.CheckSafetyConstraints(systemState, a.getCurrentOffloadCount())
+	phases.FeasibilityFilter = time.Since(phaseStart)
+	if !safe {
+		return a.finalizeDecision(a.createSafetyBlockedDecision(process, "safety constraints not met", startTime), explain, phases), nil
 	}
 
-	// Step 3: Filter targets by hard policy constraints
-	viableTargets := a.policyEngine.FilterTargetsByPolicy(process, availableTargets)
+	// Step 3: Filter targets by hard policy constraints, most promising
+	// first, until the budget expires with a compliant target found
+	phaseStart = time.Now()
+	viableTargets := make([]models.OffloadTarget, 0, len(availableTargets))
+	policyExhausted := false
+	for _, index := range decision.HeuristicOrder(availableTargets) {
+		if len(viableTargets) > 0 && decision.BudgetExpired(ctx) {
+			policyExhausted = true
+			break
+		}
+		target := availableTargets[index]
+		evaluation := a.policyEngine.EvaluatePolicy(process, target)
+		explain.evaluations[target.ID] = evaluation
+		if evaluation.Allowed {
+			viableTargets = append(viableTargets, target)
+		}
+	}
+	phases.PolicyEvaluation = time.Since(phaseStart)
 	if len(viableTargets) == 0 {
-		return a.createLocalDecision(process, "no policy-compliant targets", startTime), nil
+		return a.finalizeDecision(a.createLocalDecision(process, "no policy-compliant targets", startTime), explain, phases), nil
+	}
+
+	// Keep to the most preferred region with a healthy, unsaturated target.
+	// Only policy-compliant targets are considered, so failover never
+	// crosses a sovereignty rule.
+	if a.failover != nil {
+		regional, event, ok := a.failover.Select(process.ID, viableTargets, startTime)
+		if event != nil {
+			a.logger.Warn("region failover",
+				"process_id", process.ID,
+				"preferred_region", event.PreferredRegion,
+				"region", event.Region,
+				"reason", event.Reason)
+		}
+		if !ok {
+			return a.finalizeDecision(a.createLocalDecision(process, "no healthy region available", startTime), explain, phases), nil
+		}
+		viableTargets = regional
+	}
+
+	// Exclude targets the remaining cost budget cannot cover, so over-budget
+	// work is downgraded to cheaper targets or kept local
+	if a.budget != nil {
+		remaining := a.budget.Remaining()
+		affordable := make([]models.OffloadTarget, 0, len(viableTargets))
+		for _, target := range viableTargets {
+			if target.GetTotalCost(process) <= remaining {
+				affordable = append(affordable, target)
+			}
+		}
+		if len(affordable) == 0 {
+			return a.finalizeDecision(a.createLocalDecision(process, "cost budget exceeded", startTime), explain, phases), nil
+		}
+		viableTargets = affordable
+		a.decisionEngine.SetCostPressure(a.budget.Pressure(), remaining)
 	}
 
 	// Step 4: Apply discovered patterns to the decision engine
+	phaseStart = time.Now()
 	patterns := a.learner.GetPatterns()
 	for _, pattern := range patterns {
 		if pattern.ValidationStatus == decision.VALIDATED {
 			a.decisionEngine.AddPattern(pattern)
 		}
 	}
+	phases.LearnerUpdate = time.Since(phaseStart)
 
-	// Step 5: Get current adaptive weights from learner
-	currentWeights := a.decisionEngine.GetWeights()
-
-	// Step 6: Make the core decision
-	coreDecision, err := a.decisionEngine.MakeDecision(process, viableTargets, systemState)
+	// Step 5: Make the core decision, explaining candidates from the scores
+	// it computed
+	coreDecision, err := a.decisionEngine.MakeDecisionContext(ctx, process, viableTargets, systemState)
 	if err != nil {
 		return decision.OffloadDecision{}, fmt.Errorf("decision engine error: %w", err)
 	}
+	coreDecision.BudgetExhausted = coreDecision.BudgetExhausted || policyExhausted
+	phaseStart = time.Now()
+	explain.candidates, explain.unevaluated = decisionCandidates(availableTargets, viableTargets, coreDecision.Candidates, explain.evaluations)
+	phases.Explanation = time.Since(phaseStart)
 
-	// Step 7: Apply policy score adjustments
+	// Step 6: Apply policy score adjustments
 	if coreDecision.ShouldOffload && coreDecision.Target != nil {
+		phaseStart = time.Now()
 		policyEval := a.policyEngine.EvaluatePolicy(process, *coreDecision.Target)
+		phases.PolicyEvaluation += time.Since(phaseStart)
 		if !policyEval.Allowed {
 			// Hard constraint violation - should not happen after filtering
-			return a.createLocalDecision(process, "policy violation detected", startTime), nil
+			a.decisionEngine.ReleaseReservation(process.ID)
+			return a.finalizeDecision(a.createLocalDecision(process, "policy violation detected", startTime), explain, phases), nil
 		}
 		
 		// Apply soft policy score adjustment
@@ -153,34 +752,417 @@ func (a *Algorithm) MakeOffloadDecision(
 		}
 	}
 
-	// Step 8: Final validation
+	// Charge the offload against the cost budget and the tenant's quota
+	if coreDecision.ShouldOffload && coreDecision.Target != nil {
+		if reason := a.admitOffload(process, coreDecision); reason != "" {
+			a.decisionEngine.Affinity().Release(process.ID)
+			a.decisionEngine.ReleaseTransfer(process.ID)
+			a.decisionEngine.ReleaseReservation(process.ID)
+			a.decisionEngine.Catalog().Cancel(process.ID)
+			return a.finalizeDecision(a.createLocalDecision(process, reason, startTime), explain, coreDecision.Phases.Add(phases)), nil
+		}
+	}
+
+	// Step 7: Final validation
 	if coreDecision.DecisionLatency > a.config.PerformanceTargets.MaxDecisionLatency {
 		// Log performance issue but don't fail
-		fmt.Printf("Warning: Decision latency %v exceeds target %v\n", 
-			coreDecision.DecisionLatency, a.config.PerformanceTargets.MaxDecisionLatency)
+		a.logger.Warn("decision latency exceeds target",
+			"process_id", process.ID,
+			"latency", coreDecision.DecisionLatency,
+			"target", a.config.PerformanceTargets.MaxDecisionLatency)
+	}
+
+	if coreDecision.BudgetExhausted {
+		a.logger.Info("decision budget exhausted, using best target found",
+			"process_id", process.ID,
+			"targets_evaluated", coreDecision.TargetsEvaluated,
+			"viable_targets", len(viableTargets))
 	}
 
+	coreDecision = a.finalizeDecision(coreDecision, explain, coreDecision.Phases.Add(phases))
+	a.pending.add(process.ID, coreDecision, coreDecision.DecisionTime)
+	a.expirePending(coreDecision.DecisionTime)
+	a.markActive(coreDecision, startTime)
+
 	return coreDecision, nil
 }
 
+// MakeRegistryDecision makes an offloading decision using the targets in the
+// algorithm's registry, narrowed by index to those that can satisfy the
+// process's placement constraints
+func (a *Algorithm) MakeRegistryDecision(
+	ctx context.Context,
+	process models.Process,
+	systemState models.SystemState,
+) (decision.OffloadDecision, error) {
+	return a.MakeOffloadDecisionContext(ctx, process, a.targets.CandidatesFor(process), systemState)
+}
+
+// WatchPolicyRules reloads the policy rules file whenever it changes, until
+// the context is cancelled. It returns immediately if no rules file is configured.
+func (a *Algorithm) WatchPolicyRules(ctx context.Context) {
+	if a.ruleWatcher == nil {
+		return
+	}
+	a.ruleWatcher.OnReload(func(rules []policy.PolicyRule, err error) {
+		if err != nil {
+			a.logger.Error("policy rule reload failed", "file", a.config.PolicyRulesFile, "error", err)
+			return
+		}
+		a.logger.Info("policy rules reloaded", "file", a.config.PolicyRulesFile, "rules", len(rules))
+	})
+	a.ruleWatcher.Run(ctx)
+}
+
+// RunCatalog reloads the executor catalog every Catalog.Interval, applying
+// each new version, until the context is cancelled. It returns immediately
+// if no catalog is configured.
+func (a *Algorithm) RunCatalog(ctx context.Context) {
+	if a.catalog == nil {
+		return
+	}
+	a.catalog.OnChange(func(change decision.CatalogChange, err error) {
+		if err != nil {
+			a.logger.Error("executor catalog reload failed", "version", a.catalog.Version(), "error", err)
+			return
+		}
+		a.logger.Info("executor catalog applied",
+			"version", change.Version,
+			"previous", change.Previous,
+			"added", len(change.Added),
+			"updated", len(change.Updated),
+			"removed", len(change.Removed))
+	})
+	a.catalog.Run(ctx)
+}
+
+// CatalogVersion returns the applied executor catalog version, or false when
+// no catalog is configured
+func (a *Algorithm) CatalogVersion() (string, bool) {
+	if a.catalog == nil {
+		return "", false
+	}
+	return a.catalog.Version(), true
+}
+
+// CatalogChanges returns the recently applied executor catalog versions,
+// oldest first, or nil when no catalog is configured
+func (a *Algorithm) CatalogChanges() []decision.CatalogChange {
+	if a.catalog == nil {
+		return nil
+	}
+	return a.catalog.Changes()
+}
+
+// DeclareAffinityGroup adds or replaces an affinity group that processes can
+// join through Affinity.Groups
+func (a *Algorithm) DeclareAffinityGroup(group models.AffinityGroup) error {
+	return a.decisionEngine.Affinity().DeclareGroup(group)
+}
+
+// SetObjectives replaces the custom objectives decisions are scored on
+func (a *Algorithm) SetObjectives(goals []decision.ObjectiveGoal) error {
+	if err := a.decisionEngine.SetObjectives(goals); err != nil {
+		return err
+	}
+	a.config.Objectives = goals
+	return nil
+}
+
+// RemainingBudget returns the cost budget left in the tightest window, or
+// +Inf when no budget is configured
+func (a *Algorithm) RemainingBudget() float64 {
+	if a.budget == nil {
+		return math.Inf(1)
+	}
+	return a.budget.Remaining()
+}
+
+// FailoverEvents returns the recorded region failovers, oldest first, or nil
+// when failover is disabled
+func (a *Algorithm) FailoverEvents() []policy.FailoverEvent {
+	if a.failover == nil {
+		return nil
+	}
+	return a.failover.Events()
+}
+
+// FailoverStats returns the region failover counts, or nil when failover is
+// disabled
+func (a *Algorithm) FailoverStats() *policy.FailoverStats {
+	if a.failover == nil {
+		return nil
+	}
+	stats := a.failover.Stats()
+	return &stats
+}
+
+// Tenants returns the tenant quota manager, shared with any FairQueue feeding
+// processes to the algorithm
+func (a *Algorithm) Tenants() *tenancy.Manager {
+	return a.tenants
+}
+
+// RunProbes probes the configured target endpoints every Probing.Interval
+// until the context is cancelled. It returns immediately if probing is disabled.
+func (a *Algorithm) RunProbes(ctx context.Context) {
+	if a.prober == nil {
+		return
+	}
+	a.prober.Run(ctx)
+}
+
+// RunPricing refreshes target prices every Pricing.Interval until the context
+// is cancelled. It returns immediately if pricing is disabled.
+func (a *Algorithm) RunPricing(ctx context.Context) {
+	if a.prices == nil {
+		return
+	}
+	a.prices.Run(ctx)
+}
+
+// Prices returns the current instance prices, or nil when pricing is disabled
+func (a *Algorithm) Prices() []pricing.Quote {
+	if a.prices == nil {
+		return nil
+	}
+	return a.prices.Quotes()
+}
+
+// GetNetworkEstimates returns the probed network estimates of every target,
+// or nil when probing is disabled
+func (a *Algorithm) GetNetworkEstimates() []probe.Estimate {
+	if a.prober == nil {
+		return nil
+	}
+	return a.prober.Estimates()
+}
+
+// GetDataGravity returns the learned data gravity, overall and by location,
+// or nil when data gravity is not learned
+func (a *Algorithm) GetDataGravity() []learning.GravityEstimate {
+	if a.gravity == nil {
+		return nil
+	}
+	return a.gravity.Estimates()
+}
+
+// learnDataGravity fits the outcome of an offload against the data it moved
+// and updates the factors used in scoring
+func (a *Algorithm) learnDataGravity(outcome decision.OffloadOutcome) {
+	pending, exists := a.pending.get(outcome.ProcessID)
+	if !exists || !pending.ShouldOffload || pending.Target == nil {
+		return
+	}
+	latency := outcome.ExecutionTime
+	if latency == 0 && outcome.EndTime.After(outcome.StartTime) {
+		latency = outcome.EndTime.Sub(outcome.StartTime)
+	}
+	a.gravity.Observe(learning.GravityObservation{
+		Location: pending.Target.Location,
+		DataSize: pending.DataSize,
+		Latency:  latency,
+		Cost:     outcome.CostActual,
+	})
+	a.decisionEngine.SetDataGravity(a.gravity.Factors())
+}
+
+// RecordHeartbeat feeds an executor heartbeat to target health scoring,
+// resize recommendations and load forecasting. It is a no-op when all are
+// disabled. Heartbeats without a timestamp are taken as received now.
+func (a *Algorithm) RecordHeartbeat(heartbeat learning.Heartbeat) error {
+	if heartbeat.Timestamp.IsZero() {
+		heartbeat.Timestamp = a.now()
+	}
+	if a.forecaster != nil && heartbeat.TargetID != "" {
+		executorType := "unknown"
+		if target, exists := a.targets.Get(heartbeat.TargetID); exists {
+			executorType = string(target.Type)
+		}
+		a.forecaster.Observe(heartbeat.TargetID, executorType, heartbeat.ActualLoad)
+	}
+	if a.resize != nil {
+		if err := a.resize.Observe(heartbeat); err != nil {
+			return err
+		}
+	}
+	if a.health == nil {
+		return nil
+	}
+	return a.health.Observe(heartbeat)
+}
+
+// ResizeRecommendations returns the size class changes due for registered
+// targets, or nil when resize recommendations are disabled
+func (a *Algorithm) ResizeRecommendations() []learning.ResizeRecommendation {
+	if a.resize == nil {
+		return nil
+	}
+	recommendations := a.resize.Recommend(a.targets.All(), a.now())
+	a.publishScaling(recommendations)
+	return recommendations
+}
+
+// ForecastLoad predicts the next load a target's heartbeats will report.
+// Returns false when forecasting is disabled or the target has no history.
+func (a *Algorithm) ForecastLoad(targetID string) (learning.Forecast, bool) {
+	if a.forecaster == nil {
+		return learning.Forecast{}, false
+	}
+	return a.forecaster.Forecast(targetID)
+}
+
+// ForecastAccuracy returns the recent load forecast error of every predictor
+// by target type, or nil when forecasting is disabled
+func (a *Algorithm) ForecastAccuracy() []learning.ForecastAccuracy {
+	if a.forecaster == nil {
+		return nil
+	}
+	return a.forecaster.Accuracy()
+}
+
+// ResizeStats returns the rewards earned by resize decisions, or nil when
+// resize recommendations are disabled
+func (a *Algorithm) ResizeStats() *learning.ResizeStats {
+	if a.resize == nil {
+		return nil
+	}
+	stats := a.resize.Stats()
+	return &stats
+}
+
+// GetTargetHealth returns the current health of every target that has sent a
+// heartbeat, or nil when health scoring is disabled
+func (a *Algorithm) GetTargetHealth() []learning.HealthScore {
+	if a.health == nil {
+		return nil
+	}
+	return a.health.Scores(a.now())
+}
+
+// DataCatalog returns the catalog of datasets held by targets, for executors
+// to report materialized and evicted datasets
+func (a *Algorithm) DataCatalog() *decision.DataCatalog {
+	return a.decisionEngine.Catalog()
+}
+
+// Chargeback returns the ledger attributing the cost of executed offloads to
+// tenants and projects by month
+func (a *Algorithm) Chargeback() *tenancy.Ledger {
+	return a.chargeback
+}
+
+// Calendar returns the calendar schedules are read in, for adding holiday
+// providers
+func (a *Algorithm) Calendar() *models.Calendar {
+	return a.calendar
+}
+
+// TargetRegistry returns the registry of known offload targets
+func (a *Algorithm) TargetRegistry() *decision.TargetRegistry {
+	return a.targets
+}
+
+// ReservationStats returns the counts of capacity reservations and the
+// capacity currently reserved, or false when capacity is not reserved
+func (a *Algorithm) ReservationStats() (decision.ReservationStats, bool) {
+	ledger := a.decisionEngine.Reservations()
+	if ledger == nil {
+		return decision.ReservationStats{}, false
+	}
+	return ledger.Stats(), true
+}
+
 // ProcessOutcome processes the outcome of an offloading decision for learning
 func (a *Algorithm) ProcessOutcome(outcome decision.OffloadOutcome) error {
 	if !a.initialized {
 		return fmt.Errorf("algorithm not initialized")
 	}
+	if err := a.journalOutcome(outcome); err != nil {
+		return err
+	}
+	a.expirePending(a.now())
+
+	// Step 1: Shape the reward if a reward function is configured
+	if a.config.RewardFunction != nil {
+		outcome.Reward = a.config.RewardFunction.Evaluate(a.lookupDecision(outcome), outcome, a.config.SLAPolicy)
+	}
+	// Credit the decision's factors by their Shapley attribution unless the
+	// caller measured its own
+	if len(outcome.Attribution) == 0 {
+		if pending, exists := a.pending.get(outcome.ProcessID); exists {
+			outcome.Attribution = decision.CreditAssignment(pending.Attribution)
+		}
+	}
+
+	if a.config.ReplayLogSize > 0 {
+		a.recordReplay(outcome)
+	}
+
+	if a.gravity != nil {
+		a.learnDataGravity(outcome)
+	}
+	if a.strategies != nil {
+		a.strategies.Observe(outcome)
+	}
+	if a.resize != nil {
+		a.resize.ObserveOutcome(outcome)
+	}
+	if a.spikes != nil {
+		a.spikes.complete(outcome)
+	}
+	completedAt := outcome.EndTime
+	if completedAt.IsZero() {
+		completedAt = a.now()
+	}
+	a.stats.recordOutcome(outcome, completedAt)
+	if a.memo != nil {
+		a.memo.store(outcome, completedAt)
+	}
 
-	// Step 1: Update adaptive weights based on outcome
+	// Correct the budget and tenant charges with the actual usage
+	a.tenants.Complete(outcome.ProcessID, outcome.ExecutionTime, outcome.CostActual)
+	a.chargeback.Close(outcome.ProcessID, outcome.ExecutionTime, outcome.CostActual, completedAt)
+	if a.budget != nil && outcome.CostActual > 0 {
+		if pending, exists := a.pending.get(outcome.ProcessID); exists && pending.ShouldOffload {
+			a.budget.Record(outcome.CostActual - pending.EstimatedCost)
+		}
+	}
+	if pending, exists := a.pending.get(outcome.ProcessID); exists {
+		a.markActive(pending, a.now())
+	}
+	a.pending.remove(outcome.ProcessID)
+	a.decisionEngine.Affinity().Release(outcome.ProcessID)
+	a.decisionEngine.ReleaseTransfer(outcome.ProcessID)
+	a.decisionEngine.ReleaseReservation(outcome.ProcessID)
+	a.decisionEngine.Catalog().Complete(outcome.ProcessID, outcome.Success, a.now())
+
+	a.logger.Debug("outcome received",
+		"process_id", outcome.ProcessID, "target_id", outcome.TargetID,
+		"success", outcome.Success, "reward", outcome.Reward)
+	a.publish(events.TypeOutcome, outcome.ProcessID, outcome)
+
+	// Step 2: Update adaptive weights based on outcome
+	learningStart := time.Now()
 	currentWeights := a.decisionEngine.GetWeights()
+	if a.canary != nil {
+		currentWeights = a.canary.Candidate()
+	}
 	a.learner.UpdateWeights(&currentWeights, outcome)
-	a.decisionEngine.UpdateWeights(currentWeights)
+	if a.canary != nil {
+		a.rolloutWeights(currentWeights, outcome)
+	} else {
+		a.decisionEngine.UpdateWeights(currentWeights)
+	}
 
-	// Step 2: Pattern discovery - create dummy state and process for pattern learning
+	// Step 3: Pattern discovery - create dummy state and process for pattern learning
 	// In a real system, these would be stored from the original decision
+	now := a.calendar.In(a.now())
 	dummyState := models.SystemState{
 		QueueDepth: 10,
-		Timestamp: time.Now(),
-		TimeSlot: time.Now().Hour(),
-		DayOfWeek: int(time.Now().Weekday()),
+		Timestamp: now,
+		TimeSlot: now.Hour(),
+		DayOfWeek: int(now.Weekday()),
 	}
 	
 	dummyProcess := models.Process{
@@ -191,10 +1173,11 @@ func (a *Algorithm) ProcessOutcome(outcome decision.OffloadOutcome) error {
 
 	patterns := a.learner.DiscoverPatterns(dummyState, dummyProcess, outcome)
 	
-	// Step 3: Update decision engine with new patterns
+	// Step 4: Update decision engine with new patterns
 	for _, pattern := range patterns {
 		a.decisionEngine.AddPattern(pattern)
 	}
+	a.recordPhase(PhaseOutcomeLearning, time.Since(learningStart))
 
 	return nil
 }
@@ -204,6 +1187,11 @@ func (a *Algorithm) GetPerformanceMetrics() PerformanceMetrics {
 	learningProgress := a.learner.GetProgress()
 	policyStats := a.policyEngine.GetStats()
 	
+	var memo *MemoStats
+	if stats, ok := a.MemoStats(); ok {
+		memo = &stats
+	}
+
 	return PerformanceMetrics{
 		DecisionCount:        a.decisionCount,
 		LearningProgress:     *learningProgress,
@@ -213,10 +1201,88 @@ func (a *Algorithm) GetPerformanceMetrics() PerformanceMetrics {
 		ValidatedPatterns:   learningProgress.PatternsValidated,
 		PerformanceGain:     a.learner.GetPerformanceImprovement(),
 		IsConverged:         a.learner.IsConverged(),
+		PhaseStats:          a.GetPhaseStats(),
+		Stats:               a.GetStats(),
+		ForecastAccuracy:    a.ForecastAccuracy(),
+		Memo:                memo,
+		Pending:             a.PendingStats(),
 		Version:             a.version,
 	}
 }
 
+// PendingStats returns the number of processes awaiting outcomes, and how
+// many were dropped without one after History.PendingTTL or as the oldest
+// beyond History.Size. Decisions past the TTL are dropped first.
+func (a *Algorithm) PendingStats() PendingStats {
+	a.expirePending(a.now())
+	return a.pending.snapshot()
+}
+
+// GetWeightEvolution returns the learned weight trajectory indexed by decision
+// count, including decisions whose outcomes have not arrived, sampled every
+// step decisions
+func (a *Algorithm) GetWeightEvolution(step int) learning.WeightEvolution {
+	return a.learner.GetWeightEvolution(step)
+}
+
+// OnConvergenceEvent registers a handler for learner convergence events
+func (a *Algorithm) OnConvergenceEvent(handler func(learning.ConvergenceEvent)) {
+	a.learner.OnConvergenceEvent(handler)
+}
+
+// GetStrategyStats returns the outcomes of each strategy profile, or nil when
+// strategy sampling is disabled
+func (a *Algorithm) GetStrategyStats() []learning.StrategyStats {
+	if a.strategies == nil {
+		return nil
+	}
+	return a.strategies.Stats()
+}
+
+// GetCanaryEvents returns the promotions and rollbacks of canaried weights
+func (a *Algorithm) GetCanaryEvents() []learning.CanaryEvent {
+	if a.canary == nil {
+		return nil
+	}
+	return a.canary.Events()
+}
+
+// rolloutWeights feeds the outcome to the running canary trial and proposes
+// the learned weights as the next candidate. Weights learned from a candidate
+// that was just rolled back are dropped.
+func (a *Algorithm) rolloutWeights(learned decision.AdaptiveWeights, outcome decision.OffloadOutcome) {
+	event := a.canary.Observe(outcome)
+	if event != nil {
+		a.logger.Info("canary trial ended",
+			"action", event.Action,
+			"stable_sla", event.StableSLA, "canary_sla", event.CanarySLA,
+			"stable_mean_cost", event.StableMeanCost, "canary_mean_cost", event.CanaryMeanCost)
+	}
+	if event == nil || event.Action == learning.CANARY_PROMOTED {
+		a.canary.Propose(learned)
+	}
+	a.decisionEngine.UpdateWeights(a.canary.Stable())
+}
+
+// Close releases the log sink, audit file and journal opened for this
+// algorithm, publishes any queued events and leaves the outcome consumer
+// group
+func (a *Algorithm) Close() error {
+	errs := []error{a.history.close(), a.closeEvents(), a.closeJournal()}
+	if a.auditWriter != nil {
+		errs = append(errs, a.auditWriter.Close())
+	}
+	if a.logCloser != nil {
+		errs = append(errs, a.logCloser.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// GetAuditLogs returns the policy and decision audit trail
+func (a *Algorithm) GetAuditLogs() []policy.AuditLog {
+	return a.policyEngine.GetAuditLogs()
+}
+
 // GetConfiguration returns the current algorithm configuration
 func (a *Algorithm) GetConfiguration() Config {
 	return a.config
@@ -248,6 +1314,30 @@ func (a *Algorithm) IsHealthy() bool {
 
 // Helper methods
 
+// admitOffload charges an offload decision against the cost budget and the
+// process's tenant quota, returning why it was refused or an empty string.
+// Split and gang decisions are checked in total.
+func (a *Algorithm) admitOffload(process models.Process, dec decision.OffloadDecision) string {
+	if a.budget != nil && !a.budget.Allows(dec.EstimatedCost) {
+		return "cost budget exceeded"
+	}
+
+	cores := process.CPURequirement
+	if len(dec.Gang) > 0 {
+		cores *= float64(process.GangSize)
+	}
+	duration := dec.Target.EstimateExecutionTime(process)
+	if err := a.tenants.Admit(process, cores, duration, dec.EstimatedCost); err != nil {
+		return fmt.Sprintf("tenant quota exceeded: %v", err)
+	}
+	a.chargeback.Open(process, *dec.Target)
+
+	if a.budget != nil {
+		a.budget.Record(dec.EstimatedCost)
+	}
+	return ""
+}
+
 func (a *Algorithm) createLocalDecision(process models.Process, reason string, startTime time.Time) decision.OffloadDecision {
 	return decision.OffloadDecision{
 		ShouldOffload:     false,
@@ -258,7 +1348,7 @@ func (a *Algorithm) createLocalDecision(process models.Process, reason string, s
 		Strategy:          decision.IMMEDIATE,
 		ExpectedBenefit:   0.0,
 		EstimatedCost:     0.0,
-		DecisionTime:      startTime,
+		DecisionTime:      a.now(),
 		DecisionLatency:   time.Since(startTime),
 		AlgorithmVersion:  a.version,
 		ScoreComponents:   decision.ScoreBreakdown{WeightsUsed: a.decisionEngine.GetWeights()},
@@ -275,13 +1365,24 @@ func (a *Algorithm) createSafetyBlockedDecision(process models.Process, reason s
 		Strategy:          decision.IMMEDIATE,
 		ExpectedBenefit:   0.0,
 		EstimatedCost:     0.0,
-		DecisionTime:      startTime,
+		DecisionTime:      a.now(),
 		DecisionLatency:   time.Since(startTime),
 		AlgorithmVersion:  a.version,
 		ScoreComponents:   decision.ScoreBreakdown{WeightsUsed: a.decisionEngine.GetWeights()},
 	}
 }
 
+// lookupDecision returns the decision that produced an outcome. Decisions made
+// outside this algorithm instance are reconstructed from the outcome target.
+func (a *Algorithm) lookupDecision(outcome decision.OffloadOutcome) decision.OffloadDecision {
+	if dec, exists := a.pending.get(outcome.ProcessID); exists {
+		return dec
+	}
+	return decision.OffloadDecision{
+		ShouldOffload: outcome.TargetID != "" && outcome.TargetID != "local",
+	}
+}
+
 func (a *Algorithm) getCurrentOffloadCount() int {
 	// In a real implementation, this would track active offloads
 	return 0
@@ -343,23 +1444,149 @@ func createDefaultPolicyRules() []policy.PolicyRule {
 
 // validate validates algorithm configuration
 func (c *Config) validate() error {
+	var problems []error
+	check := func(failed bool, format string, args ...interface{}) {
+		if failed {
+			problems = append(problems, fmt.Errorf(format, args...))
+		}
+	}
+
 	// Validate weights sum to approximately 1.0
 	sum := c.InitialWeights.Sum()
-	if sum < 0.99 || sum > 1.01 {
-		return fmt.Errorf("initial weights must sum to 1.0, got %f", sum)
+	check(sum < 0.99 || sum > 1.01, "initial_weights: initial weights must sum to 1.0, got %f", sum)
+
+	if err := decision.ValidateObjectiveGoals(c.Objectives); err != nil {
+		problems = append(problems, fmt.Errorf("objectives: %w", err))
 	}
-	
+
 	// Validate learning config
-	if c.LearningConfig.LearningRate <= 0 || c.LearningConfig.LearningRate > 1 {
-		return fmt.Errorf("learning rate must be between 0 and 1")
+	learningConfig := c.LearningConfig
+	check(learningConfig.LearningRate <= 0 || learningConfig.LearningRate > 1,
+		"learning_config.learning_rate: learning rate must be between 0 and 1")
+	check(learningConfig.ExplorationRate < 0 || learningConfig.ExplorationRate > 1,
+		"learning_config.exploration_rate: must be between 0 and 1, got %f", learningConfig.ExplorationRate)
+	check(learningConfig.WindowSize < 0, "learning_config.window_size: must be non-negative")
+	check(learningConfig.HistorySize < 0, "learning_config.history_size: must be non-negative")
+	check(learningConfig.Canary.Fraction < 0 || learningConfig.Canary.Fraction >= 1,
+		"learning_config.canary.fraction: must be in [0, 1), got %f", learningConfig.Canary.Fraction)
+	if err := learningConfig.Drift.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("learning_config.drift: %w", err))
 	}
-	
+
+	// Validate safety constraints
+	safety := c.SafetyConstraints
+	check(safety.MinLocalCompute < 0 || safety.MinLocalCompute > 1,
+		"safety_constraints.min_local_compute: must be between 0 and 1, got %f", safety.MinLocalCompute)
+	check(safety.MinLocalMemory < 0 || safety.MinLocalMemory > 1,
+		"safety_constraints.min_local_memory: must be between 0 and 1, got %f", safety.MinLocalMemory)
+	check(safety.MinReliability < 0 || safety.MinReliability > 1,
+		"safety_constraints.min_reliability: must be between 0 and 1, got %f", safety.MinReliability)
+	check(safety.MaxConcurrentOffloads < 0, "safety_constraints.max_concurrent_offloads: must be non-negative")
+
 	// Validate performance targets
-	if c.PerformanceTargets.MaxDecisionLatency <= 0 {
-		return fmt.Errorf("max decision latency must be positive")
+	check(c.PerformanceTargets.MaxDecisionLatency <= 0,
+		"performance_targets.max_decision_latency: max decision latency must be positive")
+
+	// Validate optional components
+	if err := c.DataCatalog.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("data_catalog: %w", err))
 	}
-	
-	return nil
+	if err := c.Transfers.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("transfers: %w", err))
+	}
+	if err := c.Reservations.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("reservations: %w", err))
+	}
+	if err := c.DataGravity.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("data_gravity: %w", err))
+	}
+	if err := c.Strategies.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("strategies: %w", err))
+	}
+	if err := c.Health.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("health: %w", err))
+	}
+	if err := c.Resize.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("resize: %w", err))
+	}
+	if err := c.Forecast.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("forecast: %w", err))
+	}
+	if err := c.Probing.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("probing: %w", err))
+	}
+	if err := c.Pricing.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("pricing: %w", err))
+	}
+	if err := c.Telemetry.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("telemetry: %w", err))
+	}
+	if err := c.Events.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("events: %w", err))
+	}
+	if err := c.MonitoringConfig.AuditLog.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("monitoring_config.audit_log: %w", err))
+	}
+	if err := c.Security.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("security: %w", err))
+	}
+	if err := c.Drain.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("drain: %w", err))
+	}
+	if err := c.Spike.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("spike: %w", err))
+	}
+	if err := c.History.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("history: %w", err))
+	}
+	if err := c.Memo.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("memo: %w", err))
+	}
+	if err := c.Catalog.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("catalog: %w", err))
+	}
+	if err := c.Calendar.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("calendar: %w", err))
+	}
+	if err := c.Units.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("units: %w", err))
+	}
+	if err := c.Energy.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("energy: %w", err))
+	}
+	if err := c.Thermal.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("thermal: %w", err))
+	}
+	if err := c.Battery.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("battery: %w", err))
+	}
+	if err := c.Budget.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("budget: %w", err))
+	}
+	if err := c.Failover.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("failover: %w", err))
+	}
+	for tenantID, quota := range c.TenantQuotas {
+		check(quota.CPUHours < 0 || quota.Cost < 0 || quota.ConcurrentOffloads < 0 || quota.Weight < 0,
+			"tenant_quotas.%s: quota limits must be non-negative", tenantID)
+	}
+	check(c.TenantQuotaPeriod < 0, "tenant_quota_period: must be non-negative")
+	for i, group := range c.AffinityGroups {
+		if err := group.Validate(); err != nil {
+			problems = append(problems, fmt.Errorf("affinity_groups[%d]: %w", i, err))
+		}
+	}
+	check(c.ReplayLogSize < 0, "replay_log_size: must be non-negative")
+	seenRecurring := make(map[string]bool)
+	for i, definition := range c.Recurring {
+		if err := definition.Validate(); err != nil {
+			problems = append(problems, fmt.Errorf("recurring[%d]: %w", i, err))
+		}
+		check(seenRecurring[definition.ID], "recurring[%d]: duplicate ID %s", i, definition.ID)
+		seenRecurring[definition.ID] = true
+	}
+
+	return errors.Join(problems...)
 }
 
 // PerformanceMetrics aggregates performance data from all components
@@ -372,5 +1599,10 @@ type PerformanceMetrics struct {
 	ValidatedPatterns  int                        `json:"validated_patterns"`
 	PerformanceGain    float64                    `json:"performance_gain"`
 	IsConverged        bool                       `json:"is_converged"`
+	PhaseStats         map[string]PhaseStat       `json:"phase_stats"`
+	Stats              DecisionStats              `json:"stats"` // Lifetime, recent and windowed decision and outcome statistics
+	ForecastAccuracy   []learning.ForecastAccuracy `json:"forecast_accuracy,omitempty"` // Load forecast error by target type
+	Memo               *MemoStats                 `json:"memo,omitempty"` // Result cache hits and the execution they saved (nil when disabled)
+	Pending            PendingStats               `json:"pending"` // Decisions awaiting outcomes and those dropped without one
 	Version            string                     `json:"version"`
 }
\ No newline at end of file
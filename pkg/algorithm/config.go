@@ -0,0 +1,120 @@
+package algorithm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigError locates a problem in a configuration file
+type ConfigError struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// LoadConfig reads a JSON configuration file. See ParseConfig.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	config, err := ParseConfig(data)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ParseConfig decodes and validates a JSON configuration. Unknown fields and
+// values of the wrong type are rejected with a ConfigError giving their
+// position, rather than silently becoming zero values. Durations are
+// nanoseconds.
+func ParseConfig(data []byte) (Config, error) {
+	var config Config
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, decodeError(data, decoder, err)
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		line, column := position(data, decoder.InputOffset())
+		return Config{}, &ConfigError{Line: line, Column: column, Message: "unexpected data after configuration"}
+	}
+
+	if err := config.validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// ValidateConfigFile checks a configuration file, returning every problem found
+func ValidateConfigFile(path string) []error {
+	_, err := LoadConfig(path)
+	if err == nil {
+		return nil
+	}
+	if joined, ok := errors.Unwrap(err).(interface{ Unwrap() []error }); ok {
+		problems := joined.Unwrap()
+		for i, problem := range problems {
+			problems[i] = fmt.Errorf("%s: %w", path, problem)
+		}
+		return problems
+	}
+	return []error{err}
+}
+
+// decodeError converts a JSON decoding error into a positioned ConfigError
+func decodeError(data []byte, decoder *json.Decoder, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, column := position(data, syntaxErr.Offset)
+		return &ConfigError{Line: line, Column: column, Message: syntaxErr.Error()}
+	case errors.As(err, &typeErr):
+		line, column := position(data, typeErr.Offset)
+		message := fmt.Sprintf("%s: cannot use %s as %s", typeErr.Field, typeErr.Value, typeErr.Type)
+		if typeErr.Type == reflect.TypeOf(time.Duration(0)) {
+			message += " (durations are integer nanoseconds)"
+		}
+		return &ConfigError{Line: line, Column: column, Message: message}
+	case strings.HasPrefix(err.Error(), "json: unknown field"):
+		// The decoder reads past unknown fields, so find the key itself
+		offset := decoder.InputOffset()
+		if field, unquoteErr := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field ")); unquoteErr == nil {
+			if match := regexp.MustCompile(`"` + regexp.QuoteMeta(field) + `"\s*:`).FindIndex(data); match != nil {
+				offset = int64(match[0])
+			}
+		}
+		line, column := position(data, offset)
+		return &ConfigError{Line: line, Column: column, Message: strings.TrimPrefix(err.Error(), "json: ")}
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		line, column := position(data, int64(len(data)))
+		return &ConfigError{Line: line, Column: column, Message: "unexpected end of configuration"}
+	}
+	return err
+}
+
+// position converts a byte offset into a 1-based line and column
+func position(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
@@ -0,0 +1,38 @@
+package algorithm
+
+import (
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// baseCurrencyTargets returns the targets with their costs converted to the
+// base currency, so targets priced in different currencies compare. Targets
+// whose currency has no exchange rate are left out.
+func (a *Algorithm) baseCurrencyTargets(targets []models.OffloadTarget) []models.OffloadTarget {
+	foreign := false
+	for _, target := range targets {
+		if target.Currency.OrBase() != units.BaseCurrency {
+			foreign = true
+			break
+		}
+	}
+	if !foreign {
+		return targets
+	}
+
+	converted := make([]models.OffloadTarget, 0, len(targets))
+	for _, target := range targets {
+		based, err := target.InCurrency(a.config.Units.Rates, units.BaseCurrency)
+		if err != nil {
+			a.logger.Warn("target currency has no exchange rate", "target_id", target.ID, "error", err)
+			continue
+		}
+		converted = append(converted, based)
+	}
+	return converted
+}
+
+// DisplayCurrency returns the currency reports are expressed in
+func (a *Algorithm) DisplayCurrency() units.Currency {
+	return a.config.Units.DisplayCurrency.OrBase()
+}
@@ -0,0 +1,192 @@
+package algorithm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// DrainConfig configures how targets are scaled down: idle targets are
+// suggested for draining, and drained targets are removed once their active
+// processes finish or the drain times out
+type DrainConfig struct {
+	IdleTimeout  time.Duration `json:"idle_timeout"`  // Time without placements before a target is idle (0 disables)
+	DrainTimeout time.Duration `json:"drain_timeout"` // Longest a draining target waits for active processes (0 = until they finish)
+}
+
+// Validate checks the drain configuration
+func (dc DrainConfig) Validate() error {
+	if dc.IdleTimeout < 0 || dc.DrainTimeout < 0 {
+		return fmt.Errorf("idle_timeout and drain_timeout must be non-negative")
+	}
+	return nil
+}
+
+// DrainStatus is the progress of draining a target
+type DrainStatus struct {
+	TargetID        string    `json:"target_id"`
+	CordonedAt      time.Time `json:"cordoned_at"`
+	Deadline        time.Time `json:"deadline"`         // Zero when the drain waits for all processes
+	ActiveProcesses []string  `json:"active_processes"` // Processes placed on the target awaiting outcomes
+	Drained         bool      `json:"drained"`          // No processes remain
+	TimedOut        bool      `json:"timed_out"`
+}
+
+// Removable returns true if the target can be removed
+func (ds DrainStatus) Removable() bool {
+	return ds.Drained || ds.TimedOut
+}
+
+// DrainTarget cordons a target: no new processes are placed on it, and it
+// becomes removable once its active processes finish or the drain times out
+func (a *Algorithm) DrainTarget(targetID string) {
+	if _, exists := a.cordoned[targetID]; !exists {
+		a.cordoned[targetID] = a.now()
+	}
+}
+
+// UncordonTarget lets processes be placed on a draining target again
+func (a *Algorithm) UncordonTarget(targetID string) {
+	delete(a.cordoned, targetID)
+}
+
+// DrainStatuses returns the progress of every draining target, ordered by
+// target ID
+func (a *Algorithm) DrainStatuses() []DrainStatus {
+	now := a.now()
+	active := a.activeProcesses()
+	statuses := make([]DrainStatus, 0, len(a.cordoned))
+	for targetID, cordonedAt := range a.cordoned {
+		status := DrainStatus{
+			TargetID:        targetID,
+			CordonedAt:      cordonedAt,
+			ActiveProcesses: active[targetID],
+			Drained:         len(active[targetID]) == 0,
+		}
+		if status.ActiveProcesses == nil {
+			status.ActiveProcesses = []string{}
+		}
+		if a.config.Drain.DrainTimeout > 0 {
+			status.Deadline = cordonedAt.Add(a.config.Drain.DrainTimeout)
+			status.TimedOut = !status.Drained && !now.Before(status.Deadline)
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].TargetID < statuses[j].TargetID })
+	return statuses
+}
+
+// RemoveDrained removes the targets that finished draining from the target
+// registry and returns their final status. Decisions still active on targets
+// whose drain timed out are discarded; callers resubmit those processes.
+func (a *Algorithm) RemoveDrained() []DrainStatus {
+	removed := make([]DrainStatus, 0)
+	for _, status := range a.DrainStatuses() {
+		if !status.Removable() {
+			continue
+		}
+		for _, processID := range status.ActiveProcesses {
+			dec, _ := a.pending.get(processID)
+			a.discardDecision(processID, dec)
+		}
+		a.targets.Remove(status.TargetID)
+		delete(a.cordoned, status.TargetID)
+		delete(a.lastActive, status.TargetID)
+		a.logger.Info("drained target removed",
+			"target_id", status.TargetID,
+			"timed_out", status.TimedOut,
+			"abandoned_processes", len(status.ActiveProcesses))
+		removed = append(removed, status)
+	}
+	return removed
+}
+
+// IdleTargets returns the registered targets that have had no processes
+// placed on or running on them for the idle timeout, ordered by ID, as
+// candidates for draining. Targets are idle from when they are first seen.
+// Returns nil when the idle timeout is disabled.
+func (a *Algorithm) IdleTargets() []string {
+	if a.config.Drain.IdleTimeout == 0 {
+		return nil
+	}
+	now := a.now()
+	active := a.activeProcesses()
+	idle := make([]string, 0)
+	for _, target := range a.targets.All() {
+		if _, draining := a.cordoned[target.ID]; draining || len(active[target.ID]) > 0 {
+			continue
+		}
+		lastActive, seen := a.lastActive[target.ID]
+		if !seen {
+			a.lastActive[target.ID] = now
+			continue
+		}
+		if now.Sub(lastActive) >= a.config.Drain.IdleTimeout {
+			idle = append(idle, target.ID)
+		}
+	}
+	sort.Strings(idle)
+	return idle
+}
+
+// schedulableTargets returns the targets that are not draining
+func (a *Algorithm) schedulableTargets(targets []models.OffloadTarget) []models.OffloadTarget {
+	if len(a.cordoned) == 0 {
+		return targets
+	}
+	schedulable := make([]models.OffloadTarget, 0, len(targets))
+	for _, target := range targets {
+		if _, draining := a.cordoned[target.ID]; !draining {
+			schedulable = append(schedulable, target)
+		}
+	}
+	return schedulable
+}
+
+// markActive records that targets of a decision were just used
+func (a *Algorithm) markActive(dec decision.OffloadDecision, at time.Time) {
+	for _, targetID := range decisionTargets(dec) {
+		a.lastActive[targetID] = at
+	}
+}
+
+// activeProcesses returns the processes awaiting outcomes on each target
+func (a *Algorithm) activeProcesses() map[string][]string {
+	active := make(map[string][]string)
+	for processID, dec := range a.pending.all() {
+		for _, targetID := range decisionTargets(dec) {
+			active[targetID] = append(active[targetID], processID)
+		}
+	}
+	for _, processes := range active {
+		sort.Strings(processes)
+	}
+	return active
+}
+
+// decisionTargets returns the IDs of the targets an offload decision uses,
+// including gang members and shards
+func decisionTargets(dec decision.OffloadDecision) []string {
+	if !dec.ShouldOffload {
+		return nil
+	}
+	seen := make(map[string]bool)
+	ids := make([]string, 0, 1)
+	add := func(target *models.OffloadTarget) {
+		if target != nil && !seen[target.ID] {
+			seen[target.ID] = true
+			ids = append(ids, target.ID)
+		}
+	}
+	add(dec.Target)
+	for _, allocation := range dec.Gang {
+		add(allocation.Target)
+	}
+	for _, shard := range dec.Shards {
+		add(shard.Target)
+	}
+	return ids
+}
@@ -0,0 +1,18 @@
+package algorithm
+
+import (
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// sitedTargets returns the targets with the PUE of their site set on power
+// profiles that have none
+func (a *Algorithm) sitedTargets(targets []models.OffloadTarget) []models.OffloadTarget {
+	if len(a.config.Energy.SitePUE) == 0 {
+		return targets
+	}
+	sited := make([]models.OffloadTarget, 0, len(targets))
+	for _, target := range targets {
+		sited = append(sited, a.config.Energy.ApplyTarget(target))
+	}
+	return sited
+}
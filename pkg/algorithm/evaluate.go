@@ -0,0 +1,112 @@
+package algorithm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// TargetEvaluation is how a target would fare if a process were decided now
+type TargetEvaluation struct {
+	decision.CandidateExplanation
+	Rank          int                       `json:"rank"`           // Position in the ranking, from 1
+	AdjustedScore float64                   `json:"adjusted_score"` // Score with the policy score adjustment
+	Eligible      bool                      `json:"eligible"`       // Viable, policy-compliant and affordable
+	Affordable    bool                      `json:"affordable"`     // Within the remaining cost budget
+	Policy        PolicyEvaluationSummary   `json:"policy"`
+	Predicted     decision.PredictedOutcome `json:"predicted"`
+}
+
+// Evaluation is the full ranking of targets for a process, without a
+// decision being made
+type Evaluation struct {
+	ProcessID   string                   `json:"process_id"`
+	Timestamp   time.Time                `json:"timestamp"`
+	Safe        bool                     `json:"safe"`        // Safety constraints allow offloading
+	Recommended string                   `json:"recommended"` // Best eligible target, or "local"
+	Weights     decision.AdaptiveWeights `json:"weights"`
+	Targets     []TargetEvaluation       `json:"targets"` // Eligible targets first, by adjusted score
+}
+
+// EvaluateOnly scores, policy-checks and predicts the outcome of every target
+// for a process as a decision would, without committing one: decision
+// counts, pending decisions, staged datasets, budgets, quotas, policy
+// statistics and learning state are left untouched. Draining targets are
+// left out. Metric smoothing and strategy sampling are stateful and are not
+// applied.
+func (a *Algorithm) EvaluateOnly(
+	process models.Process,
+	targets []models.OffloadTarget,
+	state models.SystemState,
+) (Evaluation, error) {
+	if !a.initialized {
+		return Evaluation{}, fmt.Errorf("algorithm not initialized")
+	}
+	if err := process.Validate(); err != nil {
+		return Evaluation{}, fmt.Errorf("invalid process: %w", err)
+	}
+	if err := state.Validate(); err != nil {
+		return Evaluation{}, fmt.Errorf("invalid system state: %w", err)
+	}
+
+	now := a.now()
+	targets = a.schedulableTargets(targets)
+	targets = a.baseCurrencyTargets(targets)
+	targets = a.sitedTargets(targets)
+	if a.prober != nil {
+		targets = a.prober.ApplyTargets(targets)
+	}
+	if a.prices != nil {
+		targets = a.prices.ApplyTargets(targets)
+	}
+	if a.health != nil {
+		targets = a.health.ApplyTargets(targets, now)
+	}
+
+	evaluation := Evaluation{
+		ProcessID:   process.ID,
+		Timestamp:   now,
+		Safe:        a.policyEngine.CheckSafetyConstraints(state, a.getCurrentOffloadCount()),
+		Recommended: localAction,
+		Weights:     a.decisionEngine.GetWeights(),
+		Targets:     make([]TargetEvaluation, 0, len(targets)),
+	}
+
+	remaining := 0.0
+	if a.budget != nil {
+		remaining = a.budget.Remaining()
+	}
+	candidates := a.decisionEngine.ExplainCandidates(process, targets, state)
+	for i, target := range targets {
+		policyEval := a.policyEngine.PreviewPolicy(process, target)
+		report := TargetEvaluation{
+			CandidateExplanation: candidates[i],
+			AdjustedScore:        candidates[i].Score + policyEval.ScoreAdjustment,
+			Affordable:           a.budget == nil || target.GetTotalCost(process) <= remaining,
+			Policy:               summarizeEvaluation(policyEval),
+			Predicted:            a.decisionEngine.PredictOutcome(process, target),
+		}
+		report.Eligible = report.Viable && policyEval.Allowed && report.Affordable
+		evaluation.Targets = append(evaluation.Targets, report)
+	}
+
+	sort.SliceStable(evaluation.Targets, func(i, j int) bool {
+		if evaluation.Targets[i].Eligible != evaluation.Targets[j].Eligible {
+			return evaluation.Targets[i].Eligible
+		}
+		return evaluation.Targets[i].AdjustedScore > evaluation.Targets[j].AdjustedScore
+	})
+	for i := range evaluation.Targets {
+		evaluation.Targets[i].Rank = i + 1
+	}
+
+	if evaluation.Safe && len(evaluation.Targets) > 0 && evaluation.Targets[0].Eligible &&
+		evaluation.Targets[0].Score > localActionScore {
+		evaluation.Recommended = evaluation.Targets[0].TargetID
+	}
+
+	return evaluation, nil
+}
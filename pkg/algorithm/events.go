@@ -0,0 +1,82 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/events"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// publish queues an event when events are published
+func (a *Algorithm) publish(eventType events.Type, key string, data interface{}) {
+	if a.events == nil {
+		return
+	}
+	a.events.Emit(events.Event{Type: eventType, Key: key, Time: a.now(), Data: data})
+}
+
+// publishScaling publishes the resize recommendations that differ from the
+// last one published for their target, so polling for recommendations does
+// not repeat them
+func (a *Algorithm) publishScaling(recommendations []learning.ResizeRecommendation) {
+	if a.events == nil {
+		return
+	}
+	for _, recommendation := range recommendations {
+		last, exists := a.scalingPublished[recommendation.TargetID]
+		if exists && last.Direction == recommendation.Direction && last.To == recommendation.To {
+			continue
+		}
+		a.scalingPublished[recommendation.TargetID] = recommendation
+		a.publish(events.TypeScaling, recommendation.TargetID, recommendation)
+	}
+}
+
+// RunEvents publishes queued decision, outcome and scaling events every
+// Events.FlushInterval until the context is cancelled. It returns
+// immediately if events are not published.
+func (a *Algorithm) RunEvents(ctx context.Context) {
+	if a.events == nil {
+		return
+	}
+	a.events.Run(ctx)
+}
+
+// EventStats returns the counts of published events, or false when events
+// are not published
+func (a *Algorithm) EventStats() (events.Stats, bool) {
+	if a.events == nil {
+		return events.Stats{}, false
+	}
+	return a.events.Stats(), true
+}
+
+// IngestOutcomes polls the outcome topic once and processes the outcomes
+// observed there like reported ones, returning how many were processed. It
+// is a no-op when the consumer is disabled. Like ProcessOutcome it must not
+// run concurrently with decisions.
+func (a *Algorithm) IngestOutcomes(ctx context.Context) (int, error) {
+	if a.outcomeFeed == nil {
+		return 0, nil
+	}
+	outcomes, pollErr := a.outcomeFeed.Poll(ctx)
+	errs := []error{pollErr}
+	for _, outcome := range outcomes {
+		errs = append(errs, a.ProcessOutcome(outcome))
+	}
+	return len(outcomes), errors.Join(errs...)
+}
+
+// closeEvents publishes the queued events and leaves the outcome consumer
+// group
+func (a *Algorithm) closeEvents() error {
+	var errs []error
+	if a.events != nil {
+		errs = append(errs, a.events.Close())
+	}
+	if a.outcomeFeed != nil {
+		errs = append(errs, a.outcomeFeed.Close())
+	}
+	return errors.Join(errs...)
+}
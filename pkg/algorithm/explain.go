@@ -0,0 +1,317 @@
+package algorithm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/events"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// DecisionExplanation is a structured account of why a decision was made
+type DecisionExplanation struct {
+	DecisionID             string                      `json:"decision_id"`
+	ProcessID              string                      `json:"process_id"`
+	Timestamp              time.Time                   `json:"timestamp"`
+	ShouldOffload          bool                        `json:"should_offload"`
+	SelectedTargetID       string                      `json:"selected_target_id,omitempty"`
+	Reason                 string                      `json:"reason"`
+	Score                  float64                     `json:"score"`
+	ObjectiveContributions map[string]float64          `json:"objective_contributions"`
+	PolicyEvaluations      []PolicyEvaluationSummary   `json:"policy_evaluations"`
+	Candidates             []CandidateReport           `json:"candidates"`
+	DataGravity            *decision.DataGravityImpact `json:"data_gravity,omitempty"` // Selected target only
+	PredictionInputs       PredictionInputs            `json:"prediction_inputs"`
+	CatalogVersion         string                      `json:"catalog_version,omitempty"` // Executor catalog version decided against
+	Unevaluated            int                         `json:"unevaluated,omitempty"`     // Targets the decision budget expired before examining
+}
+
+// PolicyEvaluationSummary is the policy verdict for one target
+type PolicyEvaluationSummary struct {
+	TargetID        string   `json:"target_id"`
+	Allowed         bool     `json:"allowed"`
+	ViolatedRules   []string `json:"violated_rules"`
+	ScoreAdjustment float64  `json:"score_adjustment"`
+}
+
+// CandidateReport explains a single candidate target, including why it was
+// not selected
+type CandidateReport struct {
+	decision.CandidateExplanation
+	PolicyAllowed  bool   `json:"policy_allowed"`
+	Selected       bool   `json:"selected"`
+	Counterfactual string `json:"counterfactual"`
+}
+
+// PredictionInputs captures the inputs the decision was based on
+type PredictionInputs struct {
+	SystemState    models.SystemState       `json:"system_state"`
+	Weights        decision.AdaptiveWeights `json:"weights"`
+	AppliedPattern string                   `json:"applied_pattern,omitempty"`
+	ProcessProfile string                   `json:"process_profile"`
+}
+
+// explanationContext collects what is known about a decision as it is made
+type explanationContext struct {
+	process     models.Process
+	targets     []models.OffloadTarget
+	state       models.SystemState
+	evaluations map[string]policy.PolicyEvaluation
+	candidates  []decision.CandidateExplanation
+	unevaluated int
+}
+
+// Explain returns the explanation for a previously made decision. Only the
+// most recent History.Size decisions can be explained.
+func (a *Algorithm) Explain(decisionID string) (DecisionExplanation, error) {
+	explanation, exists := a.history.get(decisionID)
+	if !exists {
+		return DecisionExplanation{}, fmt.Errorf("no explanation for decision %s", decisionID)
+	}
+	return explanation, nil
+}
+
+// ExplainJSON returns the explanation for a decision serialized as JSON
+func (a *Algorithm) ExplainJSON(decisionID string) ([]byte, error) {
+	explanation, err := a.Explain(decisionID)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(explanation)
+}
+
+// finalizeDecision assigns the decision ID, records its explanation and
+// accumulates its phase timings and statistics
+func (a *Algorithm) finalizeDecision(
+	dec decision.OffloadDecision,
+	ctx explanationContext,
+	phases decision.PhaseTimings,
+) decision.OffloadDecision {
+	explainStart := time.Now()
+	dec.DecisionID = fmt.Sprintf("decision_%d", a.decisionCount)
+	if a.catalog != nil {
+		dec.CatalogVersion = a.catalog.Version()
+	}
+
+	explanation := a.buildExplanation(dec, ctx)
+	if err := a.history.add(explanation); err != nil {
+		a.logger.Error("failed to spill evicted decision explanation", "error", err)
+	}
+	if a.spikes != nil {
+		a.spikes.attribute(ctx.process.ID, dec)
+	}
+	if a.config.ReplayLogSize > 0 {
+		a.pending.addInputs(ctx.process.ID, ReplayRecord{
+			Process:     ctx.process,
+			Targets:     ctx.targets,
+			State:       ctx.state,
+			Explanation: explanation,
+		}, dec.DecisionTime)
+	}
+
+	a.logger.Debug("decision made",
+		"decision_id", dec.DecisionID,
+		"process_id", ctx.process.ID,
+		"offload", dec.ShouldOffload,
+		"target_id", explanation.SelectedTargetID,
+		"score", dec.Score,
+		"reason", explanation.Reason)
+
+	if a.config.MonitoringConfig.EnableAuditLogs {
+		if data, err := json.Marshal(explanation); err == nil {
+			outcome := "local"
+			if dec.ShouldOffload {
+				outcome = "offload"
+			}
+			a.policyEngine.RecordAuditEvent("decision_explanation", ctx.process.ID, explanation.SelectedTargetID, outcome,
+				map[string]interface{}{
+					"decision_id": dec.DecisionID,
+					"explanation": string(data),
+				})
+		}
+	}
+
+	phases.Explanation += time.Since(explainStart)
+	dec.Phases = phases
+	var latency time.Duration
+	for phase, duration := range phases.ByPhase() {
+		a.recordPhase(phase, duration)
+		latency += duration
+	}
+	decidedAt := a.now()
+	a.stats.recordDecision(dec, latency, decidedAt)
+	a.journalDecision(ctx.process.ID, dec, latency, decidedAt)
+	a.publish(events.TypeDecision, ctx.process.ID, dec)
+
+	return dec
+}
+
+// buildExplanation assembles the explanation for a decision
+func (a *Algorithm) buildExplanation(dec decision.OffloadDecision, ctx explanationContext) DecisionExplanation {
+	explanation := DecisionExplanation{
+		DecisionID:             dec.DecisionID,
+		ProcessID:              ctx.process.ID,
+		Timestamp:              dec.DecisionTime,
+		ShouldOffload:          dec.ShouldOffload,
+		Score:                  dec.Score,
+		ObjectiveContributions: dec.ScoreComponents.Contributions(),
+		PolicyEvaluations:      make([]PolicyEvaluationSummary, 0, len(ctx.evaluations)),
+		Candidates:             make([]CandidateReport, 0, len(ctx.candidates)),
+		PredictionInputs: PredictionInputs{
+			SystemState:    ctx.state,
+			Weights:        dec.ScoreComponents.WeightsUsed,
+			ProcessProfile: ctx.process.GetResourceProfile(),
+		},
+		CatalogVersion: dec.CatalogVersion,
+		Unevaluated:    ctx.unevaluated,
+	}
+	if dec.AppliedPattern != nil {
+		explanation.PredictionInputs.AppliedPattern = dec.AppliedPattern.ID
+	}
+
+	if dec.ShouldOffload && dec.Target != nil {
+		explanation.SelectedTargetID = dec.Target.ID
+		explanation.Reason = "highest scoring viable target"
+	} else if dec.Strategy == decision.CACHED {
+		explanation.Reason = "result cached from an identical call"
+	} else if len(dec.PolicyViolations) > 0 {
+		explanation.Reason = dec.PolicyViolations[0]
+	}
+
+	for i := range ctx.targets {
+		if evaluation, exists := ctx.evaluations[ctx.targets[i].ID]; exists {
+			explanation.PolicyEvaluations = append(explanation.PolicyEvaluations, summarizeEvaluation(evaluation))
+		}
+	}
+
+	var selected *decision.CandidateExplanation
+	for i := range ctx.candidates {
+		if ctx.candidates[i].TargetID == explanation.SelectedTargetID {
+			selected = &ctx.candidates[i]
+			gravity := selected.DataGravity
+			explanation.DataGravity = &gravity
+		}
+	}
+
+	for _, candidate := range ctx.candidates {
+		report := CandidateReport{
+			CandidateExplanation: candidate,
+			PolicyAllowed:        true,
+			Selected:             candidate.TargetID == explanation.SelectedTargetID,
+		}
+		if evaluation, exists := ctx.evaluations[candidate.TargetID]; exists {
+			report.PolicyAllowed = evaluation.Allowed
+		}
+		report.Counterfactual = counterfactual(report, ctx.evaluations[candidate.TargetID], selected, explanation.Reason)
+		explanation.Candidates = append(explanation.Candidates, report)
+	}
+
+	return explanation
+}
+
+// decisionCandidates explains the available targets from the engine's
+// evaluation of the targets it was offered. Targets held back from the
+// engine by policy, region failover or the cost budget were not evaluated,
+// nor were any when the engine decided before evaluating targets. Targets
+// the decision budget expired before examining are left out and counted.
+func decisionCandidates(
+	available []models.OffloadTarget,
+	offered []models.OffloadTarget,
+	evaluated []decision.CandidateExplanation,
+	evaluations map[string]policy.PolicyEvaluation,
+) ([]decision.CandidateExplanation, int) {
+	byID := make(map[string]decision.CandidateExplanation, len(evaluated))
+	for _, candidate := range evaluated {
+		byID[candidate.TargetID] = candidate
+	}
+	wasOffered := make(map[string]bool, len(offered))
+	for _, target := range offered {
+		wasOffered[target.ID] = true
+	}
+
+	candidates := make([]decision.CandidateExplanation, 0, len(available))
+	unevaluated := 0
+	for i := range available {
+		id := available[i].ID
+		candidate, exists := byID[id]
+		_, policyEvaluated := evaluations[id]
+		switch {
+		case exists:
+		case wasOffered[id] && evaluated == nil:
+			candidate = decision.CandidateExplanation{TargetID: id, Viable: true}
+		case wasOffered[id] || !policyEvaluated:
+			unevaluated++
+			continue
+		default:
+			candidate = decision.CandidateExplanation{TargetID: id, RejectionReason: "excluded by region failover or the cost budget"}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, unevaluated
+}
+
+// summarizeEvaluation reduces a policy evaluation to its verdict
+func summarizeEvaluation(evaluation policy.PolicyEvaluation) PolicyEvaluationSummary {
+	summary := PolicyEvaluationSummary{
+		TargetID:        evaluation.Target.ID,
+		Allowed:         evaluation.Allowed,
+		ViolatedRules:   make([]string, 0, len(evaluation.ViolatedRules)),
+		ScoreAdjustment: evaluation.ScoreAdjustment,
+	}
+	for _, rule := range evaluation.ViolatedRules {
+		summary.ViolatedRules = append(summary.ViolatedRules, rule.Description)
+	}
+	return summary
+}
+
+// counterfactual explains why a candidate was or was not selected
+func counterfactual(
+	report CandidateReport,
+	evaluation policy.PolicyEvaluation,
+	selected *decision.CandidateExplanation,
+	decisionReason string,
+) string {
+	switch {
+	case report.Selected:
+		return "selected"
+	case !report.PolicyAllowed:
+		rules := make([]string, 0, len(evaluation.ViolatedRules))
+		for _, rule := range evaluation.ViolatedRules {
+			if rule.Type == models.HARD {
+				rules = append(rules, rule.Description)
+			}
+		}
+		return fmt.Sprintf("rejected by policy: %s", strings.Join(rules, "; "))
+	case !report.Viable:
+		return fmt.Sprintf("filtered: %s", report.RejectionReason)
+	case selected == nil:
+		return fmt.Sprintf("not selected: %s", decisionReason)
+	case !report.Scored:
+		return "not scored within the decision budget"
+	}
+
+	// Find the objective term where the candidate fell furthest behind
+	terms := make([]string, 0, len(selected.Contributions))
+	for term := range selected.Contributions {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	largestGap, gapTerm := 0.0, ""
+	for _, term := range terms {
+		if gap := selected.Contributions[term] - report.Contributions[term]; gap > largestGap {
+			largestGap, gapTerm = gap, term
+		}
+	}
+
+	if gapTerm == "" {
+		return fmt.Sprintf("score %.3f below selected %.3f", report.Score, selected.Score)
+	}
+	return fmt.Sprintf("score %.3f below selected %.3f (largest gap: %s %.3f)",
+		report.Score, selected.Score, gapTerm, largestGap)
+}
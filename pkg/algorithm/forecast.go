@@ -0,0 +1,188 @@
+package algorithm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// StageForecast is the predicted outcome of one workflow stage on a target
+type StageForecast struct {
+	StageID   string                    `json:"stage_id"`
+	Finish    time.Duration             `json:"finish"` // After the workflow starts, once its dependencies finish
+	Predicted decision.PredictedOutcome `json:"predicted"`
+}
+
+// TargetForecast is the predicted cost, latency and energy of running a
+// process, or every stage of its workflow, on a target
+type TargetForecast struct {
+	TargetID        string          `json:"target_id"`
+	Eligible        bool            `json:"eligible"` // Viable and policy-compliant for the process or every stage
+	RejectionReason string          `json:"rejection_reason,omitempty"`
+	Cost            float64         `json:"cost"`
+	Latency         time.Duration   `json:"latency"`          // Along the critical path for workflows
+	EnergyCost      float64         `json:"energy_cost"`      // Included in the cost
+	Energy          units.Energy    `json:"energy"`           // Watt-hours drawn (0 without a power profile)
+	Stages          []StageForecast `json:"stages,omitempty"` // In topological order, for workflows
+}
+
+// CostForecast is the predicted cost, latency and energy of a process or
+// workflow on every candidate target
+type CostForecast struct {
+	ProcessID string           `json:"process_id"`
+	DAGID     string           `json:"dag_id,omitempty"`
+	Start     time.Time        `json:"start"`    // When the process is expected to start
+	Currency  units.Currency   `json:"currency"` // Of every cost, the display currency
+	Targets   []TargetForecast `json:"targets"`  // Eligible targets first, by cost
+}
+
+// Forecast predicts the cost, latency and energy cost of a process on every
+// target if it started after the horizon, using the learned transfer,
+// dataset cache and health models. Processes with a DAG are forecast stage
+// by stage, with each stage on the same target. Like EvaluateOnly, nothing
+// is scheduled and no state changes; draining targets are left out.
+func (a *Algorithm) Forecast(
+	process models.Process,
+	targets []models.OffloadTarget,
+	horizon time.Duration,
+) (CostForecast, error) {
+	if !a.initialized {
+		return CostForecast{}, fmt.Errorf("algorithm not initialized")
+	}
+	if err := process.Validate(); err != nil {
+		return CostForecast{}, fmt.Errorf("invalid process: %w", err)
+	}
+	if horizon < 0 {
+		return CostForecast{}, fmt.Errorf("horizon must be non-negative, got %v", horizon)
+	}
+
+	now := a.now()
+	targets = a.schedulableTargets(targets)
+	targets = a.baseCurrencyTargets(targets)
+	targets = a.sitedTargets(targets)
+	if a.prober != nil {
+		targets = a.prober.ApplyTargets(targets)
+	}
+	if a.prices != nil {
+		targets = a.prices.ApplyTargets(targets)
+	}
+	if a.health != nil {
+		targets = a.health.ApplyTargets(targets, now)
+	}
+
+	forecast := CostForecast{
+		ProcessID: process.ID,
+		Start:     now.Add(horizon),
+		Currency:  a.DisplayCurrency(),
+		Targets:   make([]TargetForecast, 0, len(targets)),
+	}
+	var stages []models.Stage
+	if process.HasDAG && process.DAG != nil {
+		forecast.DAGID = process.DAG.ID
+		stages = process.DAG.TopologicalSort()
+	}
+
+	for _, target := range targets {
+		var report TargetForecast
+		if len(stages) == 0 {
+			report = a.forecastProcess(process, target, forecast.Start)
+		} else {
+			report = a.forecastWorkflow(stages, target, forecast.Start)
+		}
+		forecast.Targets = append(forecast.Targets, a.displayForecast(report))
+	}
+
+	sort.SliceStable(forecast.Targets, func(i, j int) bool {
+		if forecast.Targets[i].Eligible != forecast.Targets[j].Eligible {
+			return forecast.Targets[i].Eligible
+		}
+		return forecast.Targets[i].Cost < forecast.Targets[j].Cost
+	})
+	return forecast, nil
+}
+
+// displayForecast converts the costs of a target forecast from the base
+// currency to the display currency
+func (a *Algorithm) displayForecast(report TargetForecast) TargetForecast {
+	display := a.config.Units
+	report.Cost = display.Display(report.Cost).Amount
+	report.EnergyCost = display.Display(report.EnergyCost).Amount
+	for i := range report.Stages {
+		predicted := &report.Stages[i].Predicted
+		predicted.EstimatedCost = display.Display(predicted.EstimatedCost).Amount
+		predicted.EnergyCost = display.Display(predicted.EnergyCost).Amount
+	}
+	return report
+}
+
+// forecastProcess predicts a single process on a target
+func (a *Algorithm) forecastProcess(process models.Process, target models.OffloadTarget, start time.Time) TargetForecast {
+	predicted := a.decisionEngine.PredictOutcomeAt(process, target, start)
+	report := TargetForecast{
+		TargetID:        target.ID,
+		RejectionReason: a.forecastRejection(process, target),
+		Cost:            predicted.EstimatedCost,
+		Latency:         predicted.ExecutionTime,
+		EnergyCost:      predicted.EnergyCost,
+		Energy:          predicted.Energy,
+	}
+	report.Eligible = report.RejectionReason == ""
+	return report
+}
+
+// forecastWorkflow predicts every stage of a workflow on a target. Stages
+// start once all their dependencies finish, so the latency is the finish of
+// the last stage.
+func (a *Algorithm) forecastWorkflow(stages []models.Stage, target models.OffloadTarget, start time.Time) TargetForecast {
+	report := TargetForecast{
+		TargetID: target.ID,
+		Stages:   make([]StageForecast, 0, len(stages)),
+	}
+	finish := make(map[string]time.Duration, len(stages))
+	for _, stage := range stages {
+		process := stage.ToProcess()
+		var ready time.Duration
+		for _, dependency := range stage.Dependencies {
+			if finish[dependency] > ready {
+				ready = finish[dependency]
+			}
+		}
+		predicted := a.decisionEngine.PredictOutcomeAt(process, target, start.Add(ready))
+		finish[stage.ID] = ready + predicted.ExecutionTime
+		report.Stages = append(report.Stages, StageForecast{
+			StageID:   stage.ID,
+			Finish:    finish[stage.ID],
+			Predicted: predicted,
+		})
+
+		report.Cost += predicted.EstimatedCost
+		report.EnergyCost += predicted.EnergyCost
+		report.Energy += predicted.Energy
+		if finish[stage.ID] > report.Latency {
+			report.Latency = finish[stage.ID]
+		}
+		if report.RejectionReason == "" {
+			if reason := a.forecastRejection(process, target); reason != "" {
+				report.RejectionReason = fmt.Sprintf("stage %s: %s", stage.ID, reason)
+			}
+		}
+	}
+	report.Eligible = report.RejectionReason == ""
+	return report
+}
+
+// forecastRejection returns why a target could not take a process, from the
+// decision engine's viability checks and the policy, or an empty string
+func (a *Algorithm) forecastRejection(process models.Process, target models.OffloadTarget) string {
+	if reason := a.decisionEngine.RejectionReason(process, target); reason != "" {
+		return reason
+	}
+	if evaluation := a.policyEngine.PreviewPolicy(process, target); !evaluation.Allowed {
+		return "rejected by policy"
+	}
+	return ""
+}
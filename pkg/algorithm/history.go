@@ -0,0 +1,107 @@
+package algorithm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultHistorySize is the number of decision explanations kept when
+// HistoryConfig.Size is not set
+const DefaultHistorySize = 10000
+
+// DefaultPendingTTL is how long a decision awaits its outcome when
+// HistoryConfig.PendingTTL is not set
+const DefaultPendingTTL = 24 * time.Hour
+
+// HistoryConfig bounds the decision explanations kept for Explain. Once Size
+// decisions have been made, each new decision evicts the oldest explanation.
+// Size also bounds the processes whose decisions await outcomes, which are
+// dropped after PendingTTL without one.
+type HistoryConfig struct {
+	Size       int           `json:"size"`        // Explanations kept (default 10000)
+	SpillFile  string        `json:"spill_file"`  // Append evicted explanations as JSON lines (empty discards them)
+	PendingTTL time.Duration `json:"pending_ttl"` // Decisions awaiting outcomes are dropped after this (default 24h)
+}
+
+// Validate checks the history configuration
+func (hc HistoryConfig) Validate() error {
+	if hc.Size < 0 {
+		return fmt.Errorf("size must be non-negative")
+	}
+	if hc.PendingTTL < 0 {
+		return fmt.Errorf("pending_ttl must be non-negative")
+	}
+	return nil
+}
+
+// decisionHistory is a ring buffer of decision explanations with lookup by
+// decision ID
+type decisionHistory struct {
+	order   []string                       // Decision IDs, oldest at next once full
+	next    int                            // Slot the next decision is written to
+	byID    map[string]DecisionExplanation // Explanations, by decision ID
+	spill   *os.File                       // nil when evicted explanations are discarded
+	encoder *json.Encoder
+}
+
+// newDecisionHistory creates a decision history, opening the spill file for
+// appending when one is configured
+func newDecisionHistory(config HistoryConfig) (*decisionHistory, error) {
+	if config.Size == 0 {
+		config.Size = DefaultHistorySize
+	}
+	history := &decisionHistory{
+		order: make([]string, 0, config.Size),
+		byID:  make(map[string]DecisionExplanation, config.Size),
+	}
+	if config.SpillFile != "" {
+		file, err := os.OpenFile(config.SpillFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		history.spill = file
+		history.encoder = json.NewEncoder(file)
+	}
+	return history, nil
+}
+
+// add records an explanation, evicting the oldest one when the history is
+// full. A failure to spill the evicted explanation is returned after the
+// new explanation is recorded.
+func (h *decisionHistory) add(explanation DecisionExplanation) error {
+	id := explanation.DecisionID
+	if _, exists := h.byID[id]; exists {
+		h.byID[id] = explanation
+		return nil
+	}
+	h.byID[id] = explanation
+	if len(h.order) < cap(h.order) {
+		h.order = append(h.order, id)
+		return nil
+	}
+
+	evicted := h.byID[h.order[h.next]]
+	delete(h.byID, h.order[h.next])
+	h.order[h.next] = id
+	h.next = (h.next + 1) % len(h.order)
+	if h.encoder == nil {
+		return nil
+	}
+	return h.encoder.Encode(evicted)
+}
+
+// get returns the explanation of a decision still in the history
+func (h *decisionHistory) get(decisionID string) (DecisionExplanation, bool) {
+	explanation, exists := h.byID[decisionID]
+	return explanation, exists
+}
+
+// close closes the spill file
+func (h *decisionHistory) close() error {
+	if h.spill == nil {
+		return nil
+	}
+	return h.spill.Close()
+}
@@ -0,0 +1,96 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Invariants every decision must satisfy, whatever its inputs
+const (
+	InvariantHardConstraints = "hard_constraints" // Offloads never violate a hard policy rule
+	InvariantOfferedTarget   = "offered_target"   // Offloads only go to one of the offered targets
+	InvariantFiniteScores    = "finite_scores"    // Scores, confidence, benefit and cost are finite
+	InvariantConfidence      = "confidence"       // Confidence is within [0,1]
+	InvariantWeights         = "weights"          // Weights are finite, non-negative and sum to 1
+)
+
+// weightSumTolerance bounds the rounding error of normalized weights
+const weightSumTolerance = 1e-6
+
+// InvariantViolation is a decision invariant that did not hold
+type InvariantViolation struct {
+	Invariant string `json:"invariant"`
+	Detail    string `json:"detail"`
+}
+
+// Error implements the error interface
+func (iv InvariantViolation) Error() string {
+	return fmt.Sprintf("%s: %s", iv.Invariant, iv.Detail)
+}
+
+// CheckInvariants checks a decision made for a process among targets
+// against the invariants every decision must satisfy, and the algorithm's
+// current weights. Policy rules are previewed, so checking records no
+// statistics or audit events. It returns nil when every invariant holds.
+func (a *Algorithm) CheckInvariants(
+	process models.Process,
+	targets []models.OffloadTarget,
+	dec decision.OffloadDecision,
+) []InvariantViolation {
+	var violations []InvariantViolation
+	violate := func(invariant, format string, args ...interface{}) {
+		violations = append(violations, InvariantViolation{Invariant: invariant, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	if dec.ShouldOffload && dec.Target != nil {
+		if evaluation := a.policyEngine.PreviewPolicy(process, *dec.Target); !evaluation.Allowed {
+			for _, rule := range evaluation.ViolatedRules {
+				if rule.Type == models.HARD {
+					violate(InvariantHardConstraints, "offloaded to %s violating rule %s", dec.Target.ID, rule.ID)
+				}
+			}
+		}
+		offered := false
+		for _, target := range targets {
+			offered = offered || target.ID == dec.Target.ID
+		}
+		if !offered {
+			violate(InvariantOfferedTarget, "offloaded to %s, which was not offered", dec.Target.ID)
+		}
+	}
+
+	for _, value := range []struct {
+		name  string
+		value float64
+	}{
+		{"score", dec.Score},
+		{"confidence", dec.Confidence},
+		{"expected_benefit", dec.ExpectedBenefit},
+		{"estimated_cost", dec.EstimatedCost},
+	} {
+		if math.IsNaN(value.value) || math.IsInf(value.value, 0) {
+			violate(InvariantFiniteScores, "%s is %v", value.name, value.value)
+		}
+	}
+	if dec.Confidence < 0 || dec.Confidence > 1 {
+		violate(InvariantConfidence, "confidence %v outside [0,1]", dec.Confidence)
+	}
+
+	weights := a.decisionEngine.GetWeights()
+	for _, weight := range []float64{
+		weights.QueueDepth, weights.ProcessorLoad, weights.NetworkCost,
+		weights.LatencyCost, weights.EnergyCost, weights.PolicyCost,
+	} {
+		if math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 0 {
+			violate(InvariantWeights, "weight %v is negative or not finite", weight)
+		}
+	}
+	if sum := weights.Sum(); math.Abs(sum-1) > weightSumTolerance {
+		violate(InvariantWeights, "weights sum to %v", sum)
+	}
+
+	return violations
+}
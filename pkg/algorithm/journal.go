@@ -0,0 +1,197 @@
+package algorithm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+)
+
+// JournalConfig configures the write-ahead journal of decisions and
+// outcomes. Every decision is appended before it is returned and every
+// outcome before it is processed, and the journal is replayed on startup so
+// learned weights, statistics and the decisions awaiting outcomes survive a
+// crash.
+type JournalConfig struct {
+	Path string `json:"path"` // JSON lines file (empty disables the journal)
+	Sync bool   `json:"sync"` // Sync each record to disk, surviving machine as well as process crashes
+}
+
+// Journal record kinds
+const (
+	journalDecision = "decision"
+	journalOutcome  = "outcome"
+)
+
+// journalRecord is one line of the journal
+type journalRecord struct {
+	Kind      string                    `json:"kind"`
+	Time      time.Time                 `json:"time"`
+	ProcessID string                    `json:"process_id,omitempty"` // Of decisions
+	Latency   time.Duration             `json:"latency,omitempty"`    // Of decisions
+	Decision  *decision.OffloadDecision `json:"decision,omitempty"`
+	Outcome   *decision.OffloadOutcome  `json:"outcome,omitempty"`
+}
+
+// JournalReplay summarizes the journal replayed on startup
+type JournalReplay struct {
+	Decisions int `json:"decisions"`
+	Outcomes  int `json:"outcomes"`
+	Pending   int `json:"pending"` // Offloads still awaiting outcomes
+}
+
+// outcomeJournal appends records to the journal file
+type outcomeJournal struct {
+	file    *os.File
+	encoder *json.Encoder
+	sync    bool
+}
+
+// append writes a record, syncing it when configured
+func (j *outcomeJournal) append(record journalRecord) error {
+	if err := j.encoder.Encode(record); err != nil {
+		return err
+	}
+	if j.sync {
+		return j.file.Sync()
+	}
+	return nil
+}
+
+// close closes the journal file
+func (j *outcomeJournal) close() error {
+	return j.file.Close()
+}
+
+// openJournal replays the configured journal into the algorithm and opens it
+// for appending. A record cut short by a crash at the end of the journal is
+// discarded; a malformed record before the end is an error.
+func (a *Algorithm) openJournal(config JournalConfig) (*outcomeJournal, error) {
+	file, err := os.OpenFile(config.Path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := a.replayJournal(file)
+	if err == nil {
+		// Drop a torn final record so appends start on a fresh line
+		if err = file.Truncate(valid); err == nil {
+			_, err = file.Seek(valid, io.SeekStart)
+		}
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("journal %s: %w", config.Path, err)
+	}
+	a.replayed.Pending = a.pending.count()
+	return &outcomeJournal{file: file, encoder: json.NewEncoder(file), sync: config.Sync}, nil
+}
+
+// replayJournal applies every record of the journal, returning the length of
+// its valid prefix
+func (a *Algorithm) replayJournal(file *os.File) (int64, error) {
+	reader := bufio.NewReader(file)
+	var valid int64
+	for line := 1; ; line++ {
+		data, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return 0, readErr
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			var record journalRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				if readErr == io.EOF {
+					a.logger.Warn("discarding torn journal record", "line", line)
+					return valid, nil
+				}
+				return 0, fmt.Errorf("line %d: %w", line, err)
+			}
+			if readErr == io.EOF {
+				// Complete but unterminated: keep it and end the line
+				if _, err := file.WriteAt([]byte{'\n'}, valid+int64(len(data))); err != nil {
+					return 0, err
+				}
+				data = append(data, '\n')
+			}
+			if err := a.replayRecord(record); err != nil {
+				return 0, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		valid += int64(len(data))
+		if readErr == io.EOF {
+			return valid, nil
+		}
+	}
+}
+
+// replayRecord reapplies a journaled decision or outcome. Decisions count
+// toward statistics, and offloads await their outcomes again; outcomes are
+// processed as when they were reported.
+func (a *Algorithm) replayRecord(record journalRecord) error {
+	switch {
+	case record.Kind == journalDecision && record.Decision != nil:
+		dec := *record.Decision
+		a.decisionCount++
+		a.learner.ObserveDecision()
+		a.stats.recordDecision(dec, record.Latency, record.Time)
+		if dec.ShouldOffload && record.ProcessID != "" {
+			a.pending.add(record.ProcessID, dec, dec.DecisionTime)
+			a.markActive(dec, record.Time)
+		}
+		a.replayed.Decisions++
+	case record.Kind == journalOutcome && record.Outcome != nil:
+		if err := a.ProcessOutcome(*record.Outcome); err != nil {
+			return err
+		}
+		a.replayed.Outcomes++
+	default:
+		return fmt.Errorf("unknown journal record kind %q", record.Kind)
+	}
+	return nil
+}
+
+// journalDecision appends a decision to the journal. Failures are logged
+// rather than failing the decision.
+func (a *Algorithm) journalDecision(processID string, dec decision.OffloadDecision, latency time.Duration, at time.Time) {
+	if a.journal == nil {
+		return
+	}
+	record := journalRecord{Kind: journalDecision, Time: at, ProcessID: processID, Latency: latency, Decision: &dec}
+	if err := a.journal.append(record); err != nil {
+		a.logger.Error("failed to journal decision", "decision_id", dec.DecisionID, "error", err)
+	}
+}
+
+// journalOutcome appends an outcome to the journal before it is processed,
+// stamped with when it completed
+func (a *Algorithm) journalOutcome(outcome decision.OffloadOutcome) error {
+	if a.journal == nil {
+		return nil
+	}
+	if outcome.EndTime.IsZero() {
+		outcome.EndTime = a.now()
+	}
+	if err := a.journal.append(journalRecord{Kind: journalOutcome, Time: outcome.EndTime, Outcome: &outcome}); err != nil {
+		return fmt.Errorf("failed to journal outcome: %w", err)
+	}
+	return nil
+}
+
+// JournalReplay returns what was replayed from the journal on startup, or
+// false when the journal is disabled
+func (a *Algorithm) JournalReplay() (JournalReplay, bool) {
+	return a.replayed, a.journal != nil
+}
+
+// closeJournal closes the journal file
+func (a *Algorithm) closeJournal() error {
+	if a.journal == nil {
+		return nil
+	}
+	return a.journal.close()
+}
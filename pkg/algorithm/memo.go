@@ -0,0 +1,185 @@
+package algorithm
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// MemoConfig configures caching the results of idempotent function calls. A
+// process calling an idempotent function with the same arguments and input
+// hash as an earlier successful call is answered with that call's result
+// instead of being executed again.
+type MemoConfig struct {
+	Enabled    bool          `json:"enabled"`
+	TTL        time.Duration `json:"ttl"`         // How long a result is reused (default 1h)
+	MaxEntries int           `json:"max_entries"` // Results kept, the least recently stored evicted first (default 10000)
+	Functions  []string      `json:"functions"`   // Idempotent functions, in addition to processes marked idempotent
+}
+
+// Validate checks the result cache configuration
+func (mc MemoConfig) Validate() error {
+	if mc.TTL < 0 || mc.MaxEntries < 0 {
+		return fmt.Errorf("ttl and max_entries must be non-negative")
+	}
+	for i, function := range mc.Functions {
+		if function == "" {
+			return fmt.Errorf("functions[%d]: name cannot be empty", i)
+		}
+	}
+	return nil
+}
+
+// MemoStats counts the result cache's lookups and the execution they saved
+type MemoStats struct {
+	Hits           int64         `json:"hits"`
+	Misses         int64         `json:"misses"`
+	Stored         int64         `json:"stored"`
+	Expired        int64         `json:"expired"`
+	Evicted        int64         `json:"evicted"`
+	Entries        int           `json:"entries"`
+	HitRate        float64       `json:"hit_rate"`
+	TimeSaved      time.Duration `json:"time_saved"`      // Estimated duration of the calls answered from the cache
+	ThroughputGain float64       `json:"throughput_gain"` // Calls answered from the cache per call executed
+}
+
+// memoEntry is a cached call result
+type memoEntry struct {
+	key    string
+	result json.RawMessage
+	stored time.Time
+}
+
+// resultMemo caches the results of idempotent calls by call key
+type resultMemo struct {
+	config    MemoConfig
+	functions map[string]bool
+	entries   map[string]*list.Element // Of *memoEntry, by call key
+	order     *list.List               // Least recently stored first
+	pending   map[string]string        // Call key by process ID, until the outcome is reported
+	stats     MemoStats
+}
+
+// newResultMemo creates a result cache, applying defaults
+func newResultMemo(config MemoConfig) *resultMemo {
+	if config.TTL == 0 {
+		config.TTL = time.Hour
+	}
+	if config.MaxEntries == 0 {
+		config.MaxEntries = 10000
+	}
+	functions := make(map[string]bool, len(config.Functions))
+	for _, function := range config.Functions {
+		functions[function] = true
+	}
+	return &resultMemo{
+		config:    config,
+		functions: functions,
+		entries:   make(map[string]*list.Element),
+		order:     list.New(),
+		pending:   make(map[string]string),
+	}
+}
+
+// callKey returns the key a process's result is cached under, or false if
+// it is not an idempotent function call
+func (m *resultMemo) callKey(process models.Process) (string, bool) {
+	if !process.Idempotent && !m.functions[process.FuncName] {
+		return "", false
+	}
+	key := process.CallKey()
+	return key, key != ""
+}
+
+// lookup returns the cached result of a process's call. On a miss the call
+// is remembered, so its result is cached when its outcome is reported.
+func (m *resultMemo) lookup(process models.Process, now time.Time) (json.RawMessage, bool) {
+	key, ok := m.callKey(process)
+	if !ok {
+		return nil, false
+	}
+	if element, exists := m.entries[key]; exists {
+		entry := element.Value.(*memoEntry)
+		if now.Sub(entry.stored) < m.config.TTL {
+			m.stats.Hits++
+			m.stats.TimeSaved += process.EstimatedDuration
+			return entry.result, true
+		}
+		m.remove(element)
+		m.stats.Expired++
+	}
+	m.stats.Misses++
+	m.pending[process.ID] = key
+	return nil, false
+}
+
+// store caches the result of a successful call awaiting its outcome
+func (m *resultMemo) store(outcome decision.OffloadOutcome, now time.Time) {
+	key, exists := m.pending[outcome.ProcessID]
+	if !exists {
+		return
+	}
+	delete(m.pending, outcome.ProcessID)
+	if !outcome.Success || len(outcome.Result) == 0 {
+		return
+	}
+
+	if element, exists := m.entries[key]; exists {
+		m.remove(element)
+	}
+	m.entries[key] = m.order.PushBack(&memoEntry{key: key, result: outcome.Result, stored: now})
+	m.stats.Stored++
+	for m.order.Len() > m.config.MaxEntries {
+		m.remove(m.order.Front())
+		m.stats.Evicted++
+	}
+}
+
+// remove drops a cached result
+func (m *resultMemo) remove(element *list.Element) {
+	delete(m.entries, element.Value.(*memoEntry).key)
+	m.order.Remove(element)
+}
+
+// snapshot returns the cache statistics with the derived rates
+func (m *resultMemo) snapshot() MemoStats {
+	stats := m.stats
+	stats.Entries = m.order.Len()
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(lookups)
+	}
+	if stats.Misses > 0 {
+		stats.ThroughputGain = float64(stats.Hits) / float64(stats.Misses)
+	}
+	return stats
+}
+
+// createCachedDecision answers a process from the result cache: it is not
+// executed, so the whole of its execution time is saved
+func (a *Algorithm) createCachedDecision(result json.RawMessage, startTime time.Time) decision.OffloadDecision {
+	return decision.OffloadDecision{
+		ShouldOffload:    false,
+		Confidence:       1.0,
+		Score:            1.0,
+		Strategy:         decision.CACHED,
+		ExpectedBenefit:  1.0,
+		CachedResult:     result,
+		DecisionTime:     a.now(),
+		DecisionLatency:  time.Since(startTime),
+		AlgorithmVersion: a.version,
+		ScoreComponents:  decision.ScoreBreakdown{WeightsUsed: a.decisionEngine.GetWeights()},
+	}
+}
+
+// MemoStats returns the result cache's hits, misses and the execution time
+// they saved, or false when result caching is disabled
+func (a *Algorithm) MemoStats() (MemoStats, bool) {
+	if a.memo == nil {
+		return MemoStats{}, false
+	}
+	return a.memo.snapshot(), true
+}
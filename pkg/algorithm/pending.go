@@ -0,0 +1,232 @@
+package algorithm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+)
+
+// PendingStats counts the decisions awaiting outcomes and those dropped
+// without one
+type PendingStats struct {
+	Awaiting int   `json:"awaiting"` // Processes with a decision or decision inputs awaiting an outcome
+	Expired  int64 `json:"expired"`  // Dropped after History.PendingTTL without an outcome
+	Evicted  int64 `json:"evicted"`  // Dropped as the oldest beyond History.Size
+}
+
+// pendingEntry is what is held for a process until its outcome arrives
+type pendingEntry struct {
+	decision  decision.OffloadDecision
+	inputs    ReplayRecord
+	hasDec    bool
+	hasInputs bool
+	at        time.Time // When the process was decided
+}
+
+// pendingKey identifies a process's entry in decision order. Keys whose
+// entry was removed or decided again since are skipped.
+type pendingKey struct {
+	processID string
+	at        time.Time
+}
+
+// pendingTracker holds the decisions and decision inputs awaiting outcomes,
+// by process ID. Outcomes that never arrive would keep them forever, so the
+// oldest are dropped beyond History.Size processes and after
+// History.PendingTTL. Outcomes may be processed while decisions are made, so
+// every access is synchronized.
+type pendingTracker struct {
+	size    int
+	ttl     time.Duration
+	entries map[string]*pendingEntry
+	order   []pendingKey // Oldest first
+	stats   PendingStats
+	mu      sync.Mutex
+}
+
+// newPendingTracker creates an empty pending tracker bounded by the history
+// configuration
+func newPendingTracker(config HistoryConfig) *pendingTracker {
+	if config.Size == 0 {
+		config.Size = DefaultHistorySize
+	}
+	if config.PendingTTL == 0 {
+		config.PendingTTL = DefaultPendingTTL
+	}
+	return &pendingTracker{
+		size:    config.Size,
+		ttl:     config.PendingTTL,
+		entries: make(map[string]*pendingEntry),
+	}
+}
+
+// add records a decision awaiting its outcome
+func (pt *pendingTracker) add(processID string, dec decision.OffloadDecision, at time.Time) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	entry := pt.entry(processID, at)
+	entry.decision = dec
+	entry.hasDec = true
+}
+
+// addInputs records the inputs of a decision for replay once its outcome
+// arrives
+func (pt *pendingTracker) addInputs(processID string, record ReplayRecord, at time.Time) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	entry := pt.entry(processID, at)
+	entry.inputs = record
+	entry.hasInputs = true
+}
+
+// entry returns the entry of a process decided at the given time, replacing
+// any entry of an earlier decision
+func (pt *pendingTracker) entry(processID string, at time.Time) *pendingEntry {
+	if entry, exists := pt.entries[processID]; exists && entry.at.Equal(at) {
+		return entry
+	}
+	entry := &pendingEntry{at: at}
+	pt.entries[processID] = entry
+	pt.order = append(pt.order, pendingKey{processID: processID, at: at})
+	return entry
+}
+
+// expire drops the oldest entries beyond the size and those older than the
+// TTL, and the keys of entries no longer held. It returns the processes
+// dropped, whose offloads still hold what they were granted.
+func (pt *pendingTracker) expire(now time.Time) []string {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	var dropped []string
+	for len(pt.order) > 0 {
+		key := pt.order[0]
+		entry, exists := pt.entries[key.processID]
+		current := exists && entry.at.Equal(key.at)
+		switch {
+		case !current:
+		case len(pt.entries) > pt.size:
+			delete(pt.entries, key.processID)
+			dropped = append(dropped, key.processID)
+			pt.stats.Evicted++
+		case now.Sub(key.at) > pt.ttl:
+			delete(pt.entries, key.processID)
+			dropped = append(dropped, key.processID)
+			pt.stats.Expired++
+		default:
+			return dropped
+		}
+		pt.order = pt.order[1:]
+	}
+	return dropped
+}
+
+// get returns the decision awaiting a process's outcome
+func (pt *pendingTracker) get(processID string) (decision.OffloadDecision, bool) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	if entry, exists := pt.entries[processID]; exists && entry.hasDec {
+		return entry.decision, true
+	}
+	return decision.OffloadDecision{}, false
+}
+
+// remove stops tracking a process's decision
+func (pt *pendingTracker) remove(processID string) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	if entry, exists := pt.entries[processID]; exists {
+		entry.decision = decision.OffloadDecision{}
+		entry.hasDec = false
+		pt.release(processID, entry)
+	}
+}
+
+// takeInputs returns and stops tracking the inputs of a process's decision
+func (pt *pendingTracker) takeInputs(processID string) (ReplayRecord, bool) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	entry, exists := pt.entries[processID]
+	if !exists || !entry.hasInputs {
+		return ReplayRecord{}, false
+	}
+	record := entry.inputs
+	entry.inputs = ReplayRecord{}
+	entry.hasInputs = false
+	pt.release(processID, entry)
+	return record, true
+}
+
+// release drops an entry once neither its decision nor its inputs are held.
+// Its key is skipped when it reaches the front of the order, and the order
+// is compacted once most keys are stale.
+func (pt *pendingTracker) release(processID string, entry *pendingEntry) {
+	if entry.hasDec || entry.hasInputs {
+		return
+	}
+	delete(pt.entries, processID)
+	if len(pt.order) > 2*len(pt.entries)+pt.size {
+		order := make([]pendingKey, 0, len(pt.entries))
+		for _, key := range pt.order {
+			if entry, exists := pt.entries[key.processID]; exists && entry.at.Equal(key.at) {
+				order = append(order, key)
+			}
+		}
+		pt.order = order
+	}
+}
+
+// all returns a copy of the decisions awaiting outcomes
+func (pt *pendingTracker) all() map[string]decision.OffloadDecision {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	decisions := make(map[string]decision.OffloadDecision, len(pt.entries))
+	for processID, entry := range pt.entries {
+		if entry.hasDec {
+			decisions[processID] = entry.decision
+		}
+	}
+	return decisions
+}
+
+// count returns the number of decisions awaiting outcomes
+func (pt *pendingTracker) count() int {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	count := 0
+	for _, entry := range pt.entries {
+		if entry.hasDec {
+			count++
+		}
+	}
+	return count
+}
+
+// snapshot returns the processes awaiting outcomes and the counts dropped
+func (pt *pendingTracker) snapshot() PendingStats {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	stats := pt.stats
+	stats.Awaiting = len(pt.entries)
+	return stats
+}
+
+// expirePending drops the decisions that waited too long for their outcomes
+// and releases what their offloads hold, so a lost outcome does not keep a
+// tenant's concurrency slot or stale placement constraints. Their estimated
+// cost stays charged to the budget.
+func (a *Algorithm) expirePending(now time.Time) {
+	for _, processID := range a.pending.expire(now) {
+		a.logger.Debug("decision dropped awaiting outcome", "process_id", processID)
+		a.releaseOffload(processID)
+	}
+}
@@ -0,0 +1,41 @@
+package algorithm
+
+import "time"
+
+// PhaseOutcomeLearning is the phase covering weight and pattern updates when
+// an outcome is processed. Decision phases use the names from
+// decision.PhaseTimings.ByPhase.
+const PhaseOutcomeLearning = "outcome_learning"
+
+// PhaseStat aggregates the latency of one phase across decisions
+type PhaseStat struct {
+	Count int64         `json:"count"`
+	Total time.Duration `json:"total"`
+	Mean  time.Duration `json:"mean"`
+	Max   time.Duration `json:"max"`
+}
+
+// GetPhaseStats returns latency statistics per phase
+func (a *Algorithm) GetPhaseStats() map[string]PhaseStat {
+	stats := make(map[string]PhaseStat, len(a.phaseStats))
+	for phase, stat := range a.phaseStats {
+		stats[phase] = *stat
+	}
+	return stats
+}
+
+// recordPhase adds a phase duration to the aggregated statistics
+func (a *Algorithm) recordPhase(phase string, duration time.Duration) {
+	stat, exists := a.phaseStats[phase]
+	if !exists {
+		stat = &PhaseStat{}
+		a.phaseStats[phase] = stat
+	}
+
+	stat.Count++
+	stat.Total += duration
+	stat.Mean = stat.Total / time.Duration(stat.Count)
+	if duration > stat.Max {
+		stat.Max = duration
+	}
+}
@@ -0,0 +1,161 @@
+package algorithm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// recurringDefinition is a recurring process and how far it has been expanded
+type recurringDefinition struct {
+	definition models.RecurringProcess
+	expanded   time.Time // Runs up to this time have been submitted
+}
+
+// PlannedRun is an upcoming run of a recurring process and the target it is
+// expected to be placed on
+type PlannedRun struct {
+	DefinitionID string    `json:"definition_id"`
+	ProcessID    string    `json:"process_id"`
+	At           time.Time `json:"at"`
+	TargetID     string    `json:"target_id"` // "local" when no target is expected to be chosen
+	Score        float64   `json:"score"`
+}
+
+// CapacityReservation is the capacity a target needs to absorb planned runs
+type CapacityReservation struct {
+	TargetID   string    `json:"target_id"`
+	Runs       int       `json:"runs"`
+	PeakCPU    float64   `json:"peak_cpu"`    // Cores needed by concurrently running planned runs
+	PeakMemory int64     `json:"peak_memory"` // Bytes needed by concurrently running planned runs
+	PeakAt     time.Time `json:"peak_at"`     // Start of the CPU peak
+}
+
+// RecurringPlan is the expected placement of recurring runs over a horizon
+type RecurringPlan struct {
+	From         time.Time             `json:"from"`
+	To           time.Time             `json:"to"`
+	Runs         []PlannedRun          `json:"runs"`
+	Reservations []CapacityReservation `json:"reservations"` // Ordered by target ID
+}
+
+// AddRecurring adds or replaces a recurring process definition. Its runs are
+// submitted by DueRecurring from now on.
+func (a *Algorithm) AddRecurring(definition models.RecurringProcess) error {
+	if err := definition.Validate(); err != nil {
+		return fmt.Errorf("invalid recurring process %s: %w", definition.ID, err)
+	}
+	a.recurring[definition.ID] = &recurringDefinition{definition: definition, expanded: a.now()}
+	return nil
+}
+
+// RemoveRecurring removes a recurring process definition
+func (a *Algorithm) RemoveRecurring(id string) {
+	delete(a.recurring, id)
+}
+
+// DueRecurring expands recurring definitions into the processes due for
+// submission up to the given time, ordered by submission time. Each run is
+// returned once; the caller submits them to the process queue.
+func (a *Algorithm) DueRecurring(now time.Time) []models.Process {
+	due := make([]models.Process, 0)
+	for _, recurring := range a.recurring {
+		if !now.After(recurring.expanded) {
+			continue
+		}
+		runs, _ := recurring.definition.Runs(recurring.expanded, now, a.calendar) // Validated when added
+		for _, at := range runs {
+			due = append(due, recurring.definition.Instance(at))
+		}
+		recurring.expanded = now
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].SubmissionTime.Equal(due[j].SubmissionTime) {
+			return due[i].SubmissionTime.Before(due[j].SubmissionTime)
+		}
+		return due[i].ID < due[j].ID
+	})
+	return due
+}
+
+// PlanRecurring scores each recurring run in (from, to] against the targets
+// and system state as they are now, and reserves the capacity each target
+// needs at its peak. It does not change decision state; policies and
+// admission are evaluated when the runs are actually submitted.
+func (a *Algorithm) PlanRecurring(targets []models.OffloadTarget, state models.SystemState, from, to time.Time) RecurringPlan {
+	plan := RecurringPlan{From: from, To: to, Runs: make([]PlannedRun, 0)}
+	processes := make(map[string]models.Process)
+	for id, recurring := range a.recurring {
+		runs, _ := recurring.definition.Runs(from, to, a.calendar)
+		for _, at := range runs {
+			process := recurring.definition.Instance(at)
+			run := PlannedRun{DefinitionID: id, ProcessID: process.ID, At: at, TargetID: localAction, Score: localActionScore}
+			for _, candidate := range a.decisionEngine.ExplainCandidates(process, targets, state) {
+				if candidate.Viable && candidate.Score > run.Score {
+					run.TargetID = candidate.TargetID
+					run.Score = candidate.Score
+				}
+			}
+			plan.Runs = append(plan.Runs, run)
+			processes[process.ID] = process
+		}
+	}
+	sort.Slice(plan.Runs, func(i, j int) bool {
+		if !plan.Runs[i].At.Equal(plan.Runs[j].At) {
+			return plan.Runs[i].At.Before(plan.Runs[j].At)
+		}
+		return plan.Runs[i].ProcessID < plan.Runs[j].ProcessID
+	})
+
+	byTarget := make(map[string][]PlannedRun)
+	for _, run := range plan.Runs {
+		byTarget[run.TargetID] = append(byTarget[run.TargetID], run)
+	}
+	plan.Reservations = make([]CapacityReservation, 0, len(byTarget))
+	for targetID, runs := range byTarget {
+		plan.Reservations = append(plan.Reservations, reserveCapacity(targetID, runs, processes))
+	}
+	sort.Slice(plan.Reservations, func(i, j int) bool { return plan.Reservations[i].TargetID < plan.Reservations[j].TargetID })
+	return plan
+}
+
+// reserveCapacity finds the peak concurrent demand of runs on one target,
+// each occupying its requirements for its estimated duration
+func reserveCapacity(targetID string, runs []PlannedRun, processes map[string]models.Process) CapacityReservation {
+	type event struct {
+		at     time.Time
+		cpu    float64
+		memory int64
+	}
+	events := make([]event, 0, 2*len(runs))
+	for _, run := range runs {
+		process := processes[run.ProcessID]
+		events = append(events,
+			event{at: run.At, cpu: process.CPURequirement, memory: process.MemoryRequirement},
+			event{at: run.At.Add(process.EstimatedDuration), cpu: -process.CPURequirement, memory: -process.MemoryRequirement})
+	}
+	// Runs ending at an instant free their capacity before others start
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].at.Equal(events[j].at) {
+			return events[i].at.Before(events[j].at)
+		}
+		return events[i].cpu < events[j].cpu
+	})
+
+	reservation := CapacityReservation{TargetID: targetID, Runs: len(runs)}
+	cpu, memory := 0.0, int64(0)
+	for _, e := range events {
+		cpu += e.cpu
+		memory += e.memory
+		if cpu > reservation.PeakCPU {
+			reservation.PeakCPU = cpu
+			reservation.PeakAt = e.at
+		}
+		if memory > reservation.PeakMemory {
+			reservation.PeakMemory = memory
+		}
+	}
+	return reservation
+}
@@ -0,0 +1,201 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// localAction identifies the keep-local action in replay propensities
+const localAction = "local"
+
+// localActionScore is the score of keeping work local, matching the engine's
+// minimum offload score
+const localActionScore = 0.3
+
+// ReplayRecord is a logged decision with the inputs it was made from and its
+// observed outcome
+type ReplayRecord struct {
+	Process     models.Process          `json:"process"`
+	Targets     []models.OffloadTarget  `json:"targets"`
+	State       models.SystemState      `json:"state"`
+	Explanation DecisionExplanation     `json:"explanation"`
+	Outcome     decision.OffloadOutcome `json:"outcome"`
+}
+
+// ReplayConfig configures counterfactual replay
+type ReplayConfig struct {
+	Temperature float64 `json:"temperature"` // Softmax temperature turning scores into action propensities (default 0.1)
+	MaxWeight   float64 `json:"max_weight"`  // Importance weight clip (default 10)
+}
+
+// ReplayReport estimates how a candidate configuration would have performed
+// on logged decisions
+type ReplayReport struct {
+	Records             int     `json:"records"`
+	Agreement           float64 `json:"agreement"`             // Fraction of decisions the candidate would make identically
+	LoggedReward        float64 `json:"logged_reward"`         // Mean observed reward
+	EstimatedReward     float64 `json:"estimated_reward"`      // Self-normalized importance-weighted reward under the candidate
+	EffectiveSampleSize float64 `json:"effective_sample_size"` // Low values mean the estimate rests on few records
+	ClippedWeights      int     `json:"clipped_weights"`
+}
+
+// Improvement returns the estimated reward change of the candidate configuration
+func (r ReplayReport) Improvement() float64 {
+	return r.EstimatedReward - r.LoggedReward
+}
+
+// ReplayLog returns the logged decisions with outcomes, oldest first. Logging
+// is enabled by Config.ReplayLogSize.
+func (a *Algorithm) ReplayLog() []ReplayRecord {
+	return append([]ReplayRecord(nil), a.replayLog...)
+}
+
+// Replay evaluates a candidate configuration offline against logged decisions.
+// Each record's inputs are re-decided by a fresh algorithm built from the
+// candidate configuration, which neither executes nor learns from them. Both
+// the logged and the candidate policy are treated as softmax policies over
+// their candidate scores, and logged rewards are reweighted by the ratio of
+// the candidate's to the logged policy's propensity for the logged action.
+func Replay(candidate Config, records []ReplayRecord, config ReplayConfig) (ReplayReport, error) {
+	if config.Temperature <= 0 {
+		config.Temperature = 0.1
+	}
+	if config.MaxWeight <= 0 {
+		config.MaxWeight = 10.0
+	}
+
+	report := ReplayReport{Records: len(records)}
+	if len(records) == 0 {
+		return report, nil
+	}
+
+	alg, err := NewAlgorithm(candidate)
+	if err != nil {
+		return ReplayReport{}, fmt.Errorf("invalid candidate configuration: %w", err)
+	}
+	defer alg.Close()
+
+	var weightSum, weightSquares, weightedReward, loggedReward float64
+	agreed := 0
+	for _, record := range records {
+		dec, err := alg.MakeOffloadDecision(record.Process, record.Targets, record.State)
+		if err != nil {
+			return ReplayReport{}, fmt.Errorf("replay of process %s: %w", record.Process.ID, err)
+		}
+		replayed, err := alg.Explain(dec.DecisionID)
+		if err != nil {
+			return ReplayReport{}, err
+		}
+		alg.discardDecision(record.Process.ID, dec)
+
+		action := selectedAction(record.Explanation)
+		if selectedAction(replayed) == action {
+			agreed++
+		}
+
+		weight := 0.0
+		if logged := actionPropensities(record.Explanation, config.Temperature)[action]; logged > 0 {
+			weight = actionPropensities(replayed, config.Temperature)[action] / logged
+		}
+		if weight > config.MaxWeight {
+			weight = config.MaxWeight
+			report.ClippedWeights++
+		}
+
+		loggedReward += record.Outcome.Reward
+		weightedReward += weight * record.Outcome.Reward
+		weightSum += weight
+		weightSquares += weight * weight
+	}
+
+	count := float64(len(records))
+	report.Agreement = float64(agreed) / count
+	report.LoggedReward = loggedReward / count
+	if weightSum > 0 {
+		report.EstimatedReward = weightedReward / weightSum
+		report.EffectiveSampleSize = weightSum * weightSum / weightSquares
+	}
+	return report, nil
+}
+
+// recordReplay adds a completed decision to the replay log, dropping the
+// oldest record when the log is full
+func (a *Algorithm) recordReplay(outcome decision.OffloadOutcome) {
+	record, exists := a.pending.takeInputs(outcome.ProcessID)
+	if !exists {
+		return
+	}
+
+	record.Outcome = outcome
+	a.replayLog = append(a.replayLog, record)
+	if excess := len(a.replayLog) - a.config.ReplayLogSize; excess > 0 {
+		a.replayLog = a.replayLog[excess:]
+	}
+}
+
+// discardDecision undoes the bookkeeping of a decision that will not be
+// carried out
+func (a *Algorithm) discardDecision(processID string, dec decision.OffloadDecision) {
+	a.pending.remove(processID)
+	a.releaseOffload(processID)
+	if a.budget != nil && dec.ShouldOffload {
+		a.budget.Record(-dec.EstimatedCost)
+	}
+}
+
+// releaseOffload releases the placement, capacity and quota an offload holds
+// without counting it as an outcome
+func (a *Algorithm) releaseOffload(processID string) {
+	a.decisionEngine.Affinity().Release(processID)
+	a.decisionEngine.ReleaseTransfer(processID)
+	a.decisionEngine.ReleaseReservation(processID)
+	a.decisionEngine.Catalog().Cancel(processID)
+	a.tenants.Cancel(processID)
+	a.chargeback.Cancel(processID)
+	if a.strategies != nil {
+		a.strategies.Forget(processID)
+	}
+}
+
+// selectedAction returns the target a decision offloaded to, or localAction
+func selectedAction(explanation DecisionExplanation) string {
+	if !explanation.ShouldOffload || explanation.SelectedTargetID == "" {
+		return localAction
+	}
+	return explanation.SelectedTargetID
+}
+
+// actionPropensities turns a decision's candidate scores into a softmax
+// distribution over viable, policy-compliant targets and keeping work local.
+// Decisions kept local are certain, since offloading was gated before scoring.
+func actionPropensities(explanation DecisionExplanation, temperature float64) map[string]float64 {
+	if !explanation.ShouldOffload {
+		return map[string]float64{localAction: 1.0}
+	}
+
+	scores := map[string]float64{localAction: localActionScore}
+	for _, candidate := range explanation.Candidates {
+		if candidate.Viable && candidate.PolicyAllowed {
+			scores[candidate.TargetID] = candidate.Score
+		}
+	}
+
+	maxScore := math.Inf(-1)
+	for _, score := range scores {
+		maxScore = math.Max(maxScore, score)
+	}
+
+	propensities := make(map[string]float64, len(scores))
+	total := 0.0
+	for action, score := range scores {
+		propensities[action] = math.Exp((score - maxScore) / temperature)
+		total += propensities[action]
+	}
+	for action := range propensities {
+		propensities[action] /= total
+	}
+	return propensities
+}
@@ -0,0 +1,247 @@
+package algorithm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// ShadowDecision is the projection of one decision, live or hypothetical
+type ShadowDecision struct {
+	DecisionID        string        `json:"decision_id"`
+	TargetID          string        `json:"target_id"` // "local" when kept local
+	Score             float64       `json:"score"`
+	ExpectedBenefit   float64       `json:"expected_benefit"`
+	EstimatedCost     float64       `json:"estimated_cost"`
+	EstimatedDuration time.Duration `json:"estimated_duration"`
+}
+
+// ShadowComparison pairs the live decision for a process with the shadow's
+type ShadowComparison struct {
+	ProcessID string                   `json:"process_id"`
+	Timestamp time.Time                `json:"timestamp"`
+	Live      ShadowDecision           `json:"live"`
+	Shadow    ShadowDecision           `json:"shadow"`
+	Diverged  bool                     `json:"diverged"`
+	Outcome   *decision.OffloadOutcome `json:"outcome,omitempty"` // Observed outcome of the live decision
+}
+
+// DivergenceReport summarizes how shadow decisions differ from live ones
+type DivergenceReport struct {
+	Decisions      int            `json:"decisions"`
+	Diverged       int            `json:"diverged"`
+	DivergenceRate float64        `json:"divergence_rate"`
+	Transitions    map[string]int `json:"transitions"` // Diverged decisions by "live->shadow" target
+
+	LiveEstimatedCost     float64       `json:"live_estimated_cost"`
+	ShadowEstimatedCost   float64       `json:"shadow_estimated_cost"`
+	LiveExpectedBenefit   float64       `json:"live_expected_benefit"`
+	ShadowExpectedBenefit float64       `json:"shadow_expected_benefit"`
+	LiveMeanDuration      time.Duration `json:"live_mean_duration"`
+	ShadowMeanDuration    time.Duration `json:"shadow_mean_duration"`
+
+	// Observed results of live decisions the shadow agreed and disagreed with
+	AgreedMeanReward   float64 `json:"agreed_mean_reward"`
+	DivergedMeanReward float64 `json:"diverged_mean_reward"`
+}
+
+// Shadow runs a candidate configuration alongside a live algorithm. Every
+// decision is made by both with the same inputs; only the live decision is
+// returned for execution, while the shadow's is logged for comparison. The
+// shadow never learns, since its decisions have no outcomes.
+type Shadow struct {
+	live        *Algorithm
+	shadow      *Algorithm
+	comparisons []ShadowComparison
+	byProcess   map[string]int // Index into comparisons of decisions awaiting outcomes
+	logSize     int
+	mu          sync.Mutex
+}
+
+// NewShadow creates a shadow of the live algorithm running the candidate
+// configuration, keeping the most recent logSize comparisons (0 = 1000). The
+// shadow runs on the live algorithm's clock unless the candidate sets one.
+func NewShadow(live *Algorithm, candidate Config, logSize int) (*Shadow, error) {
+	if candidate.Clock == nil {
+		candidate.Clock = live.now
+	}
+	shadow, err := NewAlgorithm(candidate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shadow configuration: %w", err)
+	}
+	if logSize <= 0 {
+		logSize = 1000
+	}
+
+	return &Shadow{
+		live:        live,
+		shadow:      shadow,
+		comparisons: make([]ShadowComparison, 0),
+		byProcess:   make(map[string]int),
+		logSize:     logSize,
+	}, nil
+}
+
+// MakeOffloadDecision makes the live decision and records the shadow's
+// hypothetical decision for the same inputs. Shadow failures are logged and
+// never affect the live decision.
+func (s *Shadow) MakeOffloadDecision(
+	process models.Process,
+	availableTargets []models.OffloadTarget,
+	systemState models.SystemState,
+) (decision.OffloadDecision, error) {
+	liveDecision, err := s.live.MakeOffloadDecision(process, availableTargets, systemState)
+	if err != nil {
+		return liveDecision, err
+	}
+
+	shadowDecision, err := s.shadow.MakeOffloadDecision(process, availableTargets, systemState)
+	if err != nil {
+		s.shadow.logger.Warn("shadow decision failed", "process_id", process.ID, "error", err)
+		return liveDecision, nil
+	}
+	s.shadow.discardDecision(process.ID, shadowDecision)
+
+	comparison := ShadowComparison{
+		ProcessID: process.ID,
+		Timestamp: s.live.now(),
+		Live:      projectDecision(process, liveDecision),
+		Shadow:    projectDecision(process, shadowDecision),
+	}
+	comparison.Diverged = comparison.Live.TargetID != comparison.Shadow.TargetID
+	if comparison.Diverged {
+		s.shadow.logger.Info("shadow decision diverged",
+			"process_id", process.ID,
+			"live_target", comparison.Live.TargetID,
+			"shadow_target", comparison.Shadow.TargetID)
+	}
+
+	s.record(comparison)
+	return liveDecision, nil
+}
+
+// ProcessOutcome passes the outcome to the live algorithm and attaches it to
+// the matching comparison
+func (s *Shadow) ProcessOutcome(outcome decision.OffloadOutcome) error {
+	s.mu.Lock()
+	if index, exists := s.byProcess[outcome.ProcessID]; exists {
+		observed := outcome
+		s.comparisons[index].Outcome = &observed
+		delete(s.byProcess, outcome.ProcessID)
+	}
+	s.mu.Unlock()
+
+	return s.live.ProcessOutcome(outcome)
+}
+
+// Live returns the live algorithm
+func (s *Shadow) Live() *Algorithm {
+	return s.live
+}
+
+// Comparisons returns the logged comparisons, oldest first
+func (s *Shadow) Comparisons() []ShadowComparison {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ShadowComparison(nil), s.comparisons...)
+}
+
+// Report summarizes the logged comparisons
+func (s *Shadow) Report() DivergenceReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := DivergenceReport{
+		Decisions:   len(s.comparisons),
+		Transitions: make(map[string]int),
+	}
+	if report.Decisions == 0 {
+		return report
+	}
+
+	var liveDuration, shadowDuration time.Duration
+	var agreedReward, divergedReward float64
+	var agreedOutcomes, divergedOutcomes int
+	for _, comparison := range s.comparisons {
+		report.LiveEstimatedCost += comparison.Live.EstimatedCost
+		report.ShadowEstimatedCost += comparison.Shadow.EstimatedCost
+		report.LiveExpectedBenefit += comparison.Live.ExpectedBenefit
+		report.ShadowExpectedBenefit += comparison.Shadow.ExpectedBenefit
+		liveDuration += comparison.Live.EstimatedDuration
+		shadowDuration += comparison.Shadow.EstimatedDuration
+
+		if comparison.Diverged {
+			report.Diverged++
+			report.Transitions[comparison.Live.TargetID+"->"+comparison.Shadow.TargetID]++
+		}
+		if comparison.Outcome == nil {
+			continue
+		}
+		if comparison.Diverged {
+			divergedReward += comparison.Outcome.Reward
+			divergedOutcomes++
+		} else {
+			agreedReward += comparison.Outcome.Reward
+			agreedOutcomes++
+		}
+	}
+
+	report.DivergenceRate = float64(report.Diverged) / float64(report.Decisions)
+	report.LiveMeanDuration = liveDuration / time.Duration(report.Decisions)
+	report.ShadowMeanDuration = shadowDuration / time.Duration(report.Decisions)
+	if agreedOutcomes > 0 {
+		report.AgreedMeanReward = agreedReward / float64(agreedOutcomes)
+	}
+	if divergedOutcomes > 0 {
+		report.DivergedMeanReward = divergedReward / float64(divergedOutcomes)
+	}
+	return report
+}
+
+// Close closes the shadow algorithm; the live algorithm stays open
+func (s *Shadow) Close() error {
+	return s.shadow.Close()
+}
+
+// record appends a comparison, dropping the oldest when the log is full
+func (s *Shadow) record(comparison ShadowComparison) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.comparisons = append(s.comparisons, comparison)
+	s.byProcess[comparison.ProcessID] = len(s.comparisons) - 1
+
+	excess := len(s.comparisons) - s.logSize
+	if excess <= 0 {
+		return
+	}
+	s.comparisons = s.comparisons[excess:]
+	for processID, index := range s.byProcess {
+		if index < excess {
+			delete(s.byProcess, processID)
+		} else {
+			s.byProcess[processID] = index - excess
+		}
+	}
+}
+
+// projectDecision summarizes a decision's target and projected outcome
+func projectDecision(process models.Process, dec decision.OffloadDecision) ShadowDecision {
+	projection := ShadowDecision{
+		DecisionID:        dec.DecisionID,
+		TargetID:          localAction,
+		Score:             dec.Score,
+		ExpectedBenefit:   dec.ExpectedBenefit,
+		EstimatedCost:     dec.EstimatedCost,
+		EstimatedDuration: process.EstimatedDuration,
+	}
+	if dec.ShouldOffload && dec.Target != nil {
+		projection.TargetID = dec.Target.ID
+		projection.EstimatedDuration = dec.Target.EstimateExecutionTime(process)
+	}
+	return projection
+}
@@ -0,0 +1,221 @@
+package algorithm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// SpikeConfig configures queue spike detection. A spike starts when the
+// queue depth of a decision's system state reaches Threshold times its queue
+// threshold, is detected once ConfirmSamples consecutive states have been
+// above it, and ends with the first state below it.
+type SpikeConfig struct {
+	Enabled        bool    `json:"enabled"`
+	Threshold      float64 `json:"threshold"`       // Multiple of the queue threshold that is a spike (default 1.5)
+	ConfirmSamples int     `json:"confirm_samples"` // Consecutive states above the threshold before detection (default 3)
+	MaxReports     int     `json:"max_reports"`     // Post-mortems kept (default 100)
+}
+
+// Validate checks the spike configuration
+func (sc SpikeConfig) Validate() error {
+	if sc.Threshold < 0 || sc.ConfirmSamples < 0 || sc.MaxReports < 0 {
+		return fmt.Errorf("threshold, confirm_samples and max_reports must be non-negative")
+	}
+	return nil
+}
+
+// SpikeReport is the post-mortem of a queue spike. Processes decided during
+// the spike are attributed to it; their outcomes keep updating the report
+// after the spike ends.
+type SpikeReport struct {
+	ID              string        `json:"id"`
+	StartedAt       time.Time     `json:"started_at"`
+	DetectedAt      time.Time     `json:"detected_at"`
+	EndedAt         time.Time     `json:"ended_at"`
+	DetectionLag    time.Duration `json:"detection_lag"`
+	PreScaleLead    time.Duration `json:"pre_scale_lead"` // How long before the spike offered capacity last grew (0 = not pre-scaled)
+	PeakQueueDepth  int           `json:"peak_queue_depth"`
+	Processes       int           `json:"processes"`        // Processes decided during the spike
+	SLAViolations   int           `json:"sla_violations"`   // Processes that failed or completed late
+	PendingOutcomes int           `json:"pending_outcomes"` // Processes whose outcomes are not yet reported
+	Cost            float64       `json:"cost"`             // Actual cost where reported, estimated otherwise
+}
+
+// spikeTracker detects queue spikes from the system states decisions are
+// made in and writes their post-mortems
+type spikeTracker struct {
+	config      SpikeConfig
+	active      *SpikeReport       // Open spike, detected or not
+	above       int                // Consecutive states above the threshold
+	attributed  map[string]string  // Process ID -> spike ID, until the outcome is reported
+	estimated   map[string]float64 // Process ID -> estimated cost counted in its spike
+	reports     []SpikeReport      // Ended spikes, oldest first
+	capacity    float64            // Total capacity offered in the previous state
+	lastScaleUp time.Time
+	sequence    int
+}
+
+// newSpikeTracker creates a spike tracker, applying defaults
+func newSpikeTracker(config SpikeConfig) *spikeTracker {
+	if config.Threshold == 0 {
+		config.Threshold = 1.5
+	}
+	if config.ConfirmSamples == 0 {
+		config.ConfirmSamples = 3
+	}
+	if config.MaxReports == 0 {
+		config.MaxReports = 100
+	}
+	return &spikeTracker{
+		config:     config,
+		attributed: make(map[string]string),
+		estimated:  make(map[string]float64),
+		reports:    make([]SpikeReport, 0),
+	}
+}
+
+// observe records the system state and targets of a decision, taking states
+// without a timestamp as observed now. It returns the spike when it is
+// detected or ends with this state.
+func (st *spikeTracker) observe(state models.SystemState, targets []models.OffloadTarget, now time.Time) (report *SpikeReport, detected, ended bool) {
+	at := state.Timestamp
+	if at.IsZero() {
+		at = now
+	}
+
+	capacity := 0.0
+	for _, target := range targets {
+		capacity += target.TotalCapacity
+	}
+	if st.capacity > 0 && capacity > st.capacity {
+		st.lastScaleUp = at
+	}
+	st.capacity = capacity
+
+	threshold := st.config.Threshold * float64(state.QueueThreshold)
+	if state.QueueThreshold <= 0 || float64(state.QueueDepth) < threshold {
+		st.above = 0
+		if st.active == nil {
+			return nil, false, false
+		}
+		spike := st.active
+		st.active = nil
+		if spike.DetectedAt.IsZero() {
+			// Too short to be a spike; its processes are not attributed
+			for processID, spikeID := range st.attributed {
+				if spikeID == spike.ID {
+					delete(st.attributed, processID)
+					delete(st.estimated, processID)
+				}
+			}
+			return nil, false, false
+		}
+		spike.EndedAt = at
+		st.reports = append(st.reports, *spike)
+		if len(st.reports) > st.config.MaxReports {
+			st.reports = st.reports[len(st.reports)-st.config.MaxReports:]
+		}
+		return spike, false, true
+	}
+
+	st.above++
+	if st.active == nil {
+		st.sequence++
+		st.active = &SpikeReport{ID: fmt.Sprintf("spike_%d", st.sequence), StartedAt: at}
+		if !st.lastScaleUp.IsZero() {
+			st.active.PreScaleLead = at.Sub(st.lastScaleUp)
+		}
+	}
+	st.active.PeakQueueDepth = max(st.active.PeakQueueDepth, state.QueueDepth)
+	if st.active.DetectedAt.IsZero() && st.above >= st.config.ConfirmSamples {
+		st.active.DetectedAt = at
+		st.active.DetectionLag = at.Sub(st.active.StartedAt)
+		return st.active, true, false
+	}
+	return nil, false, false
+}
+
+// attribute charges a decision made during the open spike to it
+func (st *spikeTracker) attribute(processID string, dec decision.OffloadDecision) {
+	if st.active == nil {
+		return
+	}
+	estimated := 0.0
+	if dec.ShouldOffload {
+		estimated = dec.EstimatedCost
+	}
+	st.attributed[processID] = st.active.ID
+	st.estimated[processID] = estimated
+	st.active.Processes++
+	st.active.PendingOutcomes++
+	st.active.Cost += estimated
+}
+
+// complete updates the report of the spike a process was decided in with
+// its outcome
+func (st *spikeTracker) complete(outcome decision.OffloadOutcome) {
+	spikeID, exists := st.attributed[outcome.ProcessID]
+	if !exists {
+		return
+	}
+	estimated := st.estimated[outcome.ProcessID]
+	delete(st.attributed, outcome.ProcessID)
+	delete(st.estimated, outcome.ProcessID)
+
+	report := st.find(spikeID)
+	if report == nil {
+		return // Evicted
+	}
+	report.PendingOutcomes--
+	if !outcome.Success || !outcome.CompletedOnTime {
+		report.SLAViolations++
+	}
+	if outcome.CostActual > 0 {
+		report.Cost += outcome.CostActual - estimated
+	}
+}
+
+// find returns the open or ended spike with the given ID
+func (st *spikeTracker) find(spikeID string) *SpikeReport {
+	if st.active != nil && st.active.ID == spikeID {
+		return st.active
+	}
+	for i := range st.reports {
+		if st.reports[i].ID == spikeID {
+			return &st.reports[i]
+		}
+	}
+	return nil
+}
+
+// observeSpike feeds a decision's system state to spike detection and logs
+// detected and ended spikes
+func (a *Algorithm) observeSpike(state models.SystemState, targets []models.OffloadTarget) {
+	spike, detected, ended := a.spikes.observe(state, targets, a.now())
+	switch {
+	case detected:
+		a.logger.Warn("queue spike detected",
+			"spike_id", spike.ID,
+			"queue_depth", state.QueueDepth,
+			"detection_lag", spike.DetectionLag)
+	case ended:
+		a.logger.Info("queue spike ended",
+			"spike_id", spike.ID,
+			"duration", spike.EndedAt.Sub(spike.StartedAt),
+			"peak_queue_depth", spike.PeakQueueDepth,
+			"processes", spike.Processes,
+			"sla_violations", spike.SLAViolations)
+	}
+}
+
+// SpikeReports returns the post-mortems of ended queue spikes, oldest first,
+// or nil when spike detection is disabled
+func (a *Algorithm) SpikeReports() []SpikeReport {
+	if a.spikes == nil {
+		return nil
+	}
+	return append([]SpikeReport(nil), a.spikes.reports...)
+}
@@ -0,0 +1,159 @@
+package algorithm
+
+import (
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+)
+
+// statsAlpha is the smoothing factor of the recent decision time and success
+// rate; each new sample carries this share of the average
+const statsAlpha = 0.1
+
+// statsBuckets is the number of one-minute buckets kept for windowed views,
+// covering the longest window
+const statsBuckets = 24 * 60
+
+// statsWindows are the trailing windows decision statistics are reported
+// over, by name
+var statsWindows = []struct {
+	name    string
+	minutes int64
+}{
+	{"5m", 5},
+	{"1h", 60},
+	{"24h", 24 * 60},
+}
+
+// DecisionStats are streaming aggregates of decisions and their outcomes.
+// Means are weighted by count over the algorithm's lifetime, recent values
+// are exponentially weighted, and Windows restricts the counts to the last
+// 5 minutes, hour and day.
+type DecisionStats struct {
+	Decisions          int64                  `json:"decisions"`
+	Offloads           int64                  `json:"offloads"`
+	MeanDecisionTime   time.Duration          `json:"mean_decision_time"`
+	RecentDecisionTime time.Duration          `json:"recent_decision_time"` // Exponentially weighted
+	Outcomes           int64                  `json:"outcomes"`
+	SuccessRate        float64                `json:"success_rate"`
+	RecentSuccessRate  float64                `json:"recent_success_rate"` // Exponentially weighted
+	Windows            map[string]WindowStats `json:"windows"`             // Trailing 5m, 1h and 24h, by name
+}
+
+// WindowStats are the decisions and outcomes of a trailing window
+type WindowStats struct {
+	Decisions        int64         `json:"decisions"`
+	Offloads         int64         `json:"offloads"`
+	DecisionRate     float64       `json:"decision_rate"` // Decisions per second
+	MeanDecisionTime time.Duration `json:"mean_decision_time"`
+	Outcomes         int64         `json:"outcomes"`
+	SuccessRate      float64       `json:"success_rate"`
+}
+
+// statsBucket counts the decisions and outcomes of one minute
+type statsBucket struct {
+	minute       int64 // Unix minute the counts belong to
+	decisions    int64
+	offloads     int64
+	decisionTime time.Duration
+	outcomes     int64
+	successes    int64
+}
+
+// statsTracker aggregates decisions and outcomes in constant memory
+type statsTracker struct {
+	stats        DecisionStats
+	decisionTime time.Duration // Total over all decisions
+	successes    int64
+	buckets      [statsBuckets]statsBucket
+}
+
+// bucket returns the bucket of the minute containing at, clearing it when it
+// last held an older minute
+func (st *statsTracker) bucket(at time.Time) *statsBucket {
+	minute := at.Unix() / 60
+	bucket := &st.buckets[minute%statsBuckets]
+	if bucket.minute != minute {
+		*bucket = statsBucket{minute: minute}
+	}
+	return bucket
+}
+
+// recordDecision adds a decision taking latency, made at the given time
+func (st *statsTracker) recordDecision(dec decision.OffloadDecision, latency time.Duration, at time.Time) {
+	st.stats.Decisions++
+	st.decisionTime += latency
+	st.stats.MeanDecisionTime = st.decisionTime / time.Duration(st.stats.Decisions)
+	if st.stats.Decisions == 1 {
+		st.stats.RecentDecisionTime = latency
+	} else {
+		st.stats.RecentDecisionTime += time.Duration(statsAlpha * float64(latency-st.stats.RecentDecisionTime))
+	}
+
+	bucket := st.bucket(at)
+	bucket.decisions++
+	bucket.decisionTime += latency
+	if dec.ShouldOffload {
+		st.stats.Offloads++
+		bucket.offloads++
+	}
+}
+
+// recordOutcome adds an outcome completed at the given time
+func (st *statsTracker) recordOutcome(outcome decision.OffloadOutcome, at time.Time) {
+	st.stats.Outcomes++
+	success := 0.0
+	if outcome.Success {
+		st.successes++
+		success = 1.0
+	}
+	st.stats.SuccessRate = float64(st.successes) / float64(st.stats.Outcomes)
+	if st.stats.Outcomes == 1 {
+		st.stats.RecentSuccessRate = success
+	} else {
+		st.stats.RecentSuccessRate += statsAlpha * (success - st.stats.RecentSuccessRate)
+	}
+
+	bucket := st.bucket(at)
+	bucket.outcomes++
+	if outcome.Success {
+		bucket.successes++
+	}
+}
+
+// snapshot returns the statistics with windows ending at now
+func (st *statsTracker) snapshot(now time.Time) DecisionStats {
+	stats := st.stats
+	stats.Windows = make(map[string]WindowStats, len(statsWindows))
+	current := now.Unix() / 60
+	for _, window := range statsWindows {
+		var view WindowStats
+		var decisionTime time.Duration
+		var successes int64
+		for _, bucket := range st.buckets {
+			if bucket.minute > current || bucket.minute <= current-window.minutes {
+				continue
+			}
+			view.Decisions += bucket.decisions
+			view.Offloads += bucket.offloads
+			view.Outcomes += bucket.outcomes
+			decisionTime += bucket.decisionTime
+			successes += bucket.successes
+		}
+		view.DecisionRate = float64(view.Decisions) / (float64(window.minutes) * 60)
+		if view.Decisions > 0 {
+			view.MeanDecisionTime = decisionTime / time.Duration(view.Decisions)
+		}
+		if view.Outcomes > 0 {
+			view.SuccessRate = float64(successes) / float64(view.Outcomes)
+		}
+		stats.Windows[window.name] = view
+	}
+	return stats
+}
+
+// GetStats returns decision and outcome statistics, with windowed views
+// ending now
+func (a *Algorithm) GetStats() DecisionStats {
+	return a.stats.snapshot(a.now())
+}
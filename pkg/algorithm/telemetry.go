@@ -0,0 +1,95 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/telemetry"
+)
+
+// ApplyTelemetry updates a registered target's runtime state from a
+// telemetry reading, and feeds readings carrying load or request counts to
+// RecordHeartbeat. Readings for unregistered targets are refused.
+func (a *Algorithm) ApplyTelemetry(reading telemetry.Reading) error {
+	target, exists := a.targets.Get(reading.TargetID)
+	if !exists {
+		return fmt.Errorf("telemetry for unregistered target %q", reading.TargetID)
+	}
+
+	load, hasLoad := reading.Value(telemetry.FieldLoad)
+	if hasLoad {
+		target.CurrentLoad = load
+	}
+	capacity, hasCapacity := reading.Value(telemetry.FieldAvailableCapacity)
+	if hasCapacity {
+		target.AvailableCapacity = capacity
+	}
+	memory, hasMemory := reading.Value(telemetry.FieldMemoryAvailable)
+	if hasMemory {
+		target.MemoryAvailable = int64(memory)
+	}
+	// Reported capacity already reflects offloads reserved before the reading
+	if ledger := a.decisionEngine.Reservations(); ledger != nil && (hasCapacity || hasMemory) {
+		ledger.Settle(reading.TargetID, reading.Timestamp)
+	}
+	if temperature, exists := reading.Value(telemetry.FieldTemperature); exists {
+		target.Temperature = temperature
+	}
+	level, hasLevel := reading.Value(telemetry.FieldBatteryLevel)
+	charging, hasCharging := reading.Value(telemetry.FieldCharging)
+	if hasLevel || hasCharging {
+		battery := models.BatteryState{}
+		if target.Battery != nil {
+			battery = *target.Battery
+		}
+		if hasLevel {
+			battery.Level = level
+		}
+		if hasCharging {
+			battery.Charging = charging != 0
+		}
+		target.Battery = &battery
+	}
+	if reading.Timestamp.After(target.LastSeen) {
+		target.LastSeen = reading.Timestamp
+	}
+	a.targets.Upsert(target)
+
+	requests, hasRequests := reading.Value(telemetry.FieldRequests)
+	if !hasLoad && !hasRequests {
+		return nil
+	}
+	errors, _ := reading.Value(telemetry.FieldErrors)
+	latency, _ := reading.Value(telemetry.FieldLatency)
+	return a.RecordHeartbeat(learning.Heartbeat{
+		TargetID:     reading.TargetID,
+		Timestamp:    reading.Timestamp,
+		Latency:      time.Duration(latency * float64(time.Millisecond)),
+		Requests:     int(requests),
+		Errors:       int(errors),
+		ReportedLoad: load,
+		ActualLoad:   load,
+	})
+}
+
+// RunTelemetry subscribes to the configured telemetry topics, reconnecting
+// when the broker drops the connection, until the context is cancelled. It
+// returns immediately if telemetry is disabled.
+func (a *Algorithm) RunTelemetry(ctx context.Context) {
+	if a.telemetry == nil {
+		return
+	}
+	a.telemetry.Run(ctx)
+}
+
+// TelemetryStats returns the telemetry subscriber's message counts, or false
+// when telemetry is disabled
+func (a *Algorithm) TelemetryStats() (telemetry.Stats, bool) {
+	if a.telemetry == nil {
+		return telemetry.Stats{}, false
+	}
+	return a.telemetry.Stats(), true
+}
@@ -0,0 +1,225 @@
+// Package cape is the entry point for embedding CAPE in Go services. New
+// builds an algorithm from sensible defaults adjusted by functional options,
+// so callers state what they care about instead of filling every nested
+// configuration struct.
+//
+//	alg, err := cape.New(
+//		cape.WithGoals(cape.Goal{Objective: cape.LATENCY, Weight: 3}, cape.Goal{Objective: cape.NETWORK, Weight: 1}),
+//		cape.WithPolicyFile("rules.yaml"),
+//	)
+package cape
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// Objective is something placement decisions are optimized for
+type Objective string
+
+const (
+	THROUGHPUT Objective = "throughput" // Drain the local queue
+	BALANCE    Objective = "balance"    // Even out load between local and targets
+	NETWORK    Objective = "network"    // Move little data over cheap, fast links
+	LATENCY    Objective = "latency"    // Finish processes quickly
+	ENERGY     Objective = "energy"     // Prefer energy-efficient targets
+	COMPLIANCE Objective = "compliance" // Prefer targets policies favor
+)
+
+// Goal weights an objective relative to the other goals
+type Goal struct {
+	Objective Objective `json:"objective"`
+	Weight    float64   `json:"weight"`
+}
+
+// Option adjusts the configuration New builds the algorithm from
+type Option func(*algorithm.Config) error
+
+// New creates an algorithm from DefaultConfig and the given options
+func New(options ...Option) (*algorithm.Algorithm, error) {
+	config, err := Config(options...)
+	if err != nil {
+		return nil, err
+	}
+	return algorithm.NewAlgorithm(config)
+}
+
+// Config returns the configuration New would build an algorithm from
+func Config(options ...Option) (algorithm.Config, error) {
+	config := DefaultConfig()
+	for _, option := range options {
+		if err := option(&config); err != nil {
+			return algorithm.Config{}, err
+		}
+	}
+	return config, nil
+}
+
+// DefaultConfig returns balanced objective weights, conservative learning and
+// safety limits, and decisions bounded at 100ms
+func DefaultConfig() algorithm.Config {
+	return algorithm.Config{
+		InitialWeights: decision.AdaptiveWeights{
+			QueueDepth:    0.2,
+			ProcessorLoad: 0.2,
+			NetworkCost:   0.2,
+			LatencyCost:   0.2,
+			EnergyCost:    0.1,
+			PolicyCost:    0.1,
+		},
+		LearningConfig: learning.LearningConfig{
+			WindowSize:      100,
+			LearningRate:    0.01,
+			ExplorationRate: 0.1,
+			MinSamples:      10,
+		},
+		SafetyConstraints: policy.SafetyConstraints{
+			MinLocalCompute:       0.2,
+			MinLocalMemory:        0.2,
+			MaxConcurrentOffloads: 10,
+			DataSovereignty:       true,
+			SecurityClearance:     true,
+			MaxLatencyTolerance:   500 * time.Millisecond,
+			MinReliability:        0.5,
+		},
+		PerformanceTargets: algorithm.PerformanceTargets{
+			MaxDecisionLatency:  100 * time.Millisecond,
+			MinDecisionAccuracy: 0.85,
+			MaxPolicyViolations: 5,
+			MinPerformanceGain:  0.1,
+			ConvergenceTimeout:  200,
+		},
+		SLAPolicy: learning.SLAPolicy{
+			MaxLatency:             50 * time.Millisecond,
+			DeadlineMissPenalty:    0.2,
+			PolicyViolationPenalty: 0.5,
+		},
+		RewardFunction: learning.NewDefaultRewardFunction(),
+	}
+}
+
+// WithGoals sets the initial objective weights from goals. Objectives without
+// a goal get no weight; weights are normalized to sum to 1.
+func WithGoals(goals ...Goal) Option {
+	return func(config *algorithm.Config) error {
+		var weights decision.AdaptiveWeights
+		total := 0.0
+		for _, goal := range goals {
+			if goal.Weight < 0 {
+				return fmt.Errorf("goal %s: weight must be non-negative", goal.Objective)
+			}
+			switch goal.Objective {
+			case THROUGHPUT:
+				weights.QueueDepth += goal.Weight
+			case BALANCE:
+				weights.ProcessorLoad += goal.Weight
+			case NETWORK:
+				weights.NetworkCost += goal.Weight
+			case LATENCY:
+				weights.LatencyCost += goal.Weight
+			case ENERGY:
+				weights.EnergyCost += goal.Weight
+			case COMPLIANCE:
+				weights.PolicyCost += goal.Weight
+			default:
+				return fmt.Errorf("unknown objective %q", goal.Objective)
+			}
+			total += goal.Weight
+		}
+		if total <= 0 {
+			return fmt.Errorf("goals must have a positive total weight")
+		}
+		weights.Normalize()
+		config.InitialWeights = weights
+		return nil
+	}
+}
+
+// WithPolicyFile loads declarative policy rules from a JSON or YAML file,
+// reloaded when it changes (see Algorithm.WatchPolicyRules)
+func WithPolicyFile(path string) Option {
+	return func(config *algorithm.Config) error {
+		config.PolicyRulesFile = path
+		return nil
+	}
+}
+
+// WithSmoothing filters jittery utilization and latency metrics before
+// decisions. Without an argument, learning.DefaultSmoothingConfig is used.
+func WithSmoothing(smoothing ...learning.SmoothingConfig) Option {
+	return func(config *algorithm.Config) error {
+		if len(smoothing) > 1 {
+			return fmt.Errorf("at most one smoothing configuration can be given")
+		}
+		config.Smoothing = learning.DefaultSmoothingConfig()
+		if len(smoothing) == 1 {
+			config.Smoothing = smoothing[0]
+			config.Smoothing.Enabled = true
+		}
+		return nil
+	}
+}
+
+// WithLearning sets how fast weights adapt to outcomes and how often
+// decisions explore
+func WithLearning(rate, exploration float64) Option {
+	return func(config *algorithm.Config) error {
+		config.LearningConfig.LearningRate = rate
+		config.LearningConfig.ExplorationRate = exploration
+		return nil
+	}
+}
+
+// WithSafety replaces the safety constraints every offload must satisfy
+func WithSafety(constraints policy.SafetyConstraints) Option {
+	return func(config *algorithm.Config) error {
+		config.SafetyConstraints = constraints
+		return nil
+	}
+}
+
+// WithMaxConcurrentOffloads limits the offloads in flight at once
+func WithMaxConcurrentOffloads(n int) Option {
+	return func(config *algorithm.Config) error {
+		config.SafetyConstraints.MaxConcurrentOffloads = n
+		return nil
+	}
+}
+
+// WithDecisionLatency bounds how long a decision may take
+func WithDecisionLatency(max time.Duration) Option {
+	return func(config *algorithm.Config) error {
+		config.PerformanceTargets.MaxDecisionLatency = max
+		return nil
+	}
+}
+
+// WithBudget caps the cost spent on offloading
+func WithBudget(budget policy.BudgetConfig) Option {
+	return func(config *algorithm.Config) error {
+		config.Budget = budget
+		return nil
+	}
+}
+
+// WithLogger sends structured logs from all components to a logger
+func WithLogger(logger *slog.Logger) Option {
+	return func(config *algorithm.Config) error {
+		config.Logger = logger
+		return nil
+	}
+}
+
+// WithConfig adjusts any configuration field the other options do not cover
+func WithConfig(adjust func(*algorithm.Config)) Option {
+	return func(config *algorithm.Config) error {
+		adjust(config)
+		return nil
+	}
+}
@@ -0,0 +1,173 @@
+package decision
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// preferredAffinityPenalty is the score penalty for each violated preferred
+// affinity group
+const preferredAffinityPenalty = 0.2
+
+// placement records where an offloaded process runs
+type placement struct {
+	targetID string
+	location string
+	groups   []string
+}
+
+// AffinityTracker tracks placements of offloaded processes and evaluates
+// affinity rules against them. Placements are held until released.
+type AffinityTracker struct {
+	groups     map[string]models.AffinityGroup
+	placements map[string]placement // By process ID
+	mu         sync.RWMutex
+}
+
+// NewAffinityTracker creates an empty affinity tracker
+func NewAffinityTracker() *AffinityTracker {
+	return &AffinityTracker{
+		groups:     make(map[string]models.AffinityGroup),
+		placements: make(map[string]placement),
+	}
+}
+
+// DeclareGroup adds or replaces an affinity group
+func (at *AffinityTracker) DeclareGroup(group models.AffinityGroup) error {
+	if err := group.Validate(); err != nil {
+		return err
+	}
+
+	at.mu.Lock()
+	defer at.mu.Unlock()
+
+	at.groups[group.ID] = group
+	return nil
+}
+
+// RemoveGroup removes an affinity group; processes referencing it become
+// unconstrained by it
+func (at *AffinityTracker) RemoveGroup(groupID string) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+
+	delete(at.groups, groupID)
+}
+
+// Groups returns the declared affinity groups ordered by ID
+func (at *AffinityTracker) Groups() []models.AffinityGroup {
+	at.mu.RLock()
+	defer at.mu.RUnlock()
+
+	groups := make([]models.AffinityGroup, 0, len(at.groups))
+	for _, group := range at.groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].ID < groups[j].ID })
+	return groups
+}
+
+// Place records that a process was offloaded to a target
+func (at *AffinityTracker) Place(process models.Process, target models.OffloadTarget) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+
+	at.placements[process.ID] = placement{
+		targetID: target.ID,
+		location: target.Location,
+		groups:   process.Affinity.Groups,
+	}
+}
+
+// Release forgets a process's placement, typically once it has completed
+func (at *AffinityTracker) Release(processID string) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+
+	delete(at.placements, processID)
+}
+
+// PlacedOn returns the target a process was placed on
+func (at *AffinityTracker) PlacedOn(processID string) (string, bool) {
+	at.mu.RLock()
+	defer at.mu.RUnlock()
+
+	p, exists := at.placements[processID]
+	return p.targetID, exists
+}
+
+// Evaluate checks a candidate placement against the process's affinity. It
+// returns the reason a required rule is violated, or an empty string, and the
+// score penalty for violated preferred groups.
+func (at *AffinityTracker) Evaluate(process models.Process, target models.OffloadTarget) (string, float64) {
+	affinity := process.Affinity
+	if affinity.IsEmpty() {
+		return "", 0
+	}
+
+	at.mu.RLock()
+	defer at.mu.RUnlock()
+
+	for _, avoid := range affinity.AvoidTargets {
+		if avoid == target.ID {
+			return fmt.Sprintf("affinity avoids target %s", target.ID), 0
+		}
+	}
+	for _, peerID := range affinity.CoLocateWith {
+		if peer, placed := at.placements[peerID]; placed && peer.targetID != target.ID {
+			return fmt.Sprintf("must co-locate with process %s on %s", peerID, peer.targetID), 0
+		}
+	}
+	for _, peerID := range affinity.AvoidProcesses {
+		if peer, placed := at.placements[peerID]; placed && peer.targetID == target.ID {
+			return fmt.Sprintf("must not share target with process %s", peerID), 0
+		}
+	}
+
+	penalty := 0.0
+	for _, groupID := range affinity.Groups {
+		group, declared := at.groups[groupID]
+		if !declared {
+			continue
+		}
+		reason := at.groupViolation(group, process.ID, target)
+		if reason == "" {
+			continue
+		}
+		if !group.Preferred {
+			return reason, 0
+		}
+		penalty += preferredAffinityPenalty
+	}
+	return "", penalty
+}
+
+// groupViolation checks a placement against the other placed members of a group
+func (at *AffinityTracker) groupViolation(group models.AffinityGroup, processID string, target models.OffloadTarget) string {
+	for peerID, peer := range at.placements {
+		if peerID == processID || !containsString(peer.groups, group.ID) {
+			continue
+		}
+		switch {
+		case group.CoLocate && peer.targetID != target.ID:
+			return fmt.Sprintf("group %s co-locates members on %s", group.ID, peer.targetID)
+		case group.AntiAffinity && peer.targetID == target.ID:
+			return fmt.Sprintf("group %s member %s already on %s", group.ID, peerID, target.ID)
+		case group.SpreadRegions && target.Location != "" && peer.location == target.Location:
+			return fmt.Sprintf("group %s member %s already in %s", group.ID, peerID, target.Location)
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package decision
+
+import (
+	"math"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// attributionBackgroundSize caps the candidates averaged into the attribution baseline
+const attributionBackgroundSize = 16
+
+// attributionFactors are the weighted factors in Contributions order
+var attributionFactors = []string{"QueueDepth", "ProcessorLoad", "NetworkCost", "LatencyCost", "EnergyCost", "PolicyCost"}
+
+// ShapleyAttribution returns each weighted factor's exact Shapley value for the
+// score of actual relative to baseline, using actual's weights. The values sum
+// to the score difference between the two breakdowns; custom objectives are
+// held at their actual values and not attributed.
+func ShapleyAttribution(actual, baseline ScoreBreakdown) map[string]float64 {
+	actualValues := actual.factorValues()
+	baselineValues := baseline.factorValues()
+	weights := actual.WeightsUsed.factorWeights()
+	n := len(attributionFactors)
+	share := actual.builtinShare()
+	custom := 0.0
+	for name, value := range actual.Objectives {
+		custom += actual.ObjectiveWeights[name] * value
+	}
+
+	// Score of every coalition, where members take their actual value
+	scores := make([]float64, 1<<n)
+	for mask := range scores {
+		total := custom
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				total += share * weights[i] * actualValues[i]
+			} else {
+				total += share * weights[i] * baselineValues[i]
+			}
+		}
+		scores[mask] = math.Max(0.0, math.Min(1.0, total))
+	}
+
+	// Coalition weight |S|!(n-|S|-1)!/n! by coalition size
+	coalitionWeights := make([]float64, n)
+	for size := 0; size < n; size++ {
+		coalitionWeights[size] = factorial(size) * factorial(n-size-1) / factorial(n)
+	}
+
+	attribution := make(map[string]float64, n)
+	for i, factor := range attributionFactors {
+		value := 0.0
+		for mask := range scores {
+			if mask&(1<<i) != 0 {
+				continue
+			}
+			value += coalitionWeights[popcount(mask)] * (scores[mask|1<<i] - scores[mask])
+		}
+		attribution[factor] = value
+	}
+	return attribution
+}
+
+// CreditAssignment normalizes attribution values so their magnitudes sum to
+// one, for weighting learning updates. Returns nil if every value is zero.
+func CreditAssignment(attribution map[string]float64) map[string]float64 {
+	total := 0.0
+	for _, value := range attribution {
+		total += math.Abs(value)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	credit := make(map[string]float64, len(attribution))
+	for factor, value := range attribution {
+		credit[factor] = value / total
+	}
+	return credit
+}
+
+// attributionBaseline averages the score components of the first candidates,
+// giving the typical placement the selected target is compared against
+func (de *DecisionEngine) attributionBaseline(
+	process models.Process,
+	targets []models.OffloadTarget,
+	state models.SystemState,
+	weights AdaptiveWeights,
+) ScoreBreakdown {
+	if len(targets) > attributionBackgroundSize {
+		targets = targets[:attributionBackgroundSize]
+	}
+
+	baseline := ScoreBreakdown{WeightsUsed: weights}
+	for _, target := range targets {
+		components := de.computeScoreComponents(process, target, state, weights)
+		baseline.QueueImpact += components.QueueImpact
+		baseline.LoadBalance += components.LoadBalance
+		baseline.NetworkCost += components.NetworkCost
+		baseline.LatencyImpact += components.LatencyImpact
+		baseline.EnergyImpact += components.EnergyImpact
+		baseline.PolicyMatch += components.PolicyMatch
+	}
+
+	if count := float64(len(targets)); count > 0 {
+		baseline.QueueImpact /= count
+		baseline.LoadBalance /= count
+		baseline.NetworkCost /= count
+		baseline.LatencyImpact /= count
+		baseline.EnergyImpact /= count
+		baseline.PolicyMatch /= count
+	}
+	return baseline
+}
+
+// factorValues returns the component values in attributionFactors order
+func (sb ScoreBreakdown) factorValues() []float64 {
+	return []float64{sb.QueueImpact, sb.LoadBalance, sb.NetworkCost, sb.LatencyImpact, sb.EnergyImpact, sb.PolicyMatch}
+}
+
+// factorWeights returns the weights in attributionFactors order
+func (w AdaptiveWeights) factorWeights() []float64 {
+	return []float64{w.QueueDepth, w.ProcessorLoad, w.NetworkCost, w.LatencyCost, w.EnergyCost, w.PolicyCost}
+}
+
+func factorial(n int) float64 {
+	result := 1.0
+	for i := 2; i <= n; i++ {
+		result *= float64(i)
+	}
+	return result
+}
+
+func popcount(mask int) int {
+	count := 0
+	for ; mask != 0; mask &= mask - 1 {
+		count++
+	}
+	return count
+}
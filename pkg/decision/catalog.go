@@ -0,0 +1,346 @@
+package decision
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// DatasetState is the state of a dataset replica on a target
+type DatasetState string
+
+const (
+	DATASET_STAGING      DatasetState = "staging"      // Transfer started by a placement decision
+	DATASET_CACHED       DatasetState = "cached"       // Input copy confirmed by a completed execution
+	DATASET_MATERIALIZED DatasetState = "materialized" // Produced on the target by an execution
+)
+
+// DatasetReplica is a copy of a dataset held by a target
+type DatasetReplica struct {
+	DatasetID  string       `json:"dataset_id"`
+	TargetID   string       `json:"target_id"`
+	Size       int64        `json:"size"`
+	State      DatasetState `json:"state"`
+	Created    time.Time    `json:"created"`
+	LastAccess time.Time    `json:"last_access"`
+	Hits       int          `json:"hits"` // Placements that reused the replica
+}
+
+// CatalogConfig bounds the datasets targets are assumed to keep
+type CatalogConfig struct {
+	CacheCapacity   map[string]int64 `json:"cache_capacity"`   // Bytes by target ID
+	DefaultCapacity int64            `json:"default_capacity"` // Bytes for other targets (0 = unlimited)
+	TTL             time.Duration    `json:"ttl"`              // Replicas unused for this long are dropped (0 = never)
+}
+
+// Validate checks the catalog configuration
+func (cc CatalogConfig) Validate() error {
+	if cc.DefaultCapacity < 0 || cc.TTL < 0 {
+		return fmt.Errorf("default_capacity and ttl must be non-negative")
+	}
+	for targetID, capacity := range cc.CacheCapacity {
+		if capacity < 0 {
+			return fmt.Errorf("cache_capacity.%s: must be non-negative", targetID)
+		}
+	}
+	return nil
+}
+
+// catalogPlacement is a dataset placement awaiting its process's outcome
+type catalogPlacement struct {
+	targetID        string
+	inputDatasetID  string
+	inputSize       int64
+	staged          bool // The placement started the input transfer
+	outputDatasetID string
+	outputSize      int64
+}
+
+// stagingStats counts how staging transfers on a target turned out
+type stagingStats struct {
+	confirmed int
+	failed    int
+}
+
+// DataCatalog tracks which datasets are cached or materialized on which
+// targets. Placements stage inputs on their target; outcomes confirm them
+// and materialize outputs, or drop them when the execution failed. Targets
+// hold a bounded cache, evicting the least recently used replicas.
+type DataCatalog struct {
+	config   CatalogConfig
+	replicas map[string]map[string]*DatasetReplica // Target ID -> dataset ID -> replica
+	used     map[string]int64                      // Bytes held, by target ID
+	pending  map[string][]catalogPlacement         // By process ID
+	staging  map[string]*stagingStats              // By target ID
+	mu       sync.RWMutex
+}
+
+// NewDataCatalog creates an empty data catalog
+func NewDataCatalog(config CatalogConfig) *DataCatalog {
+	return &DataCatalog{
+		config:   config,
+		replicas: make(map[string]map[string]*DatasetReplica),
+		used:     make(map[string]int64),
+		pending:  make(map[string][]catalogPlacement),
+		staging:  make(map[string]*stagingStats),
+	}
+}
+
+// Place records that a process was placed on a target: its input dataset is
+// staged there unless already present, and the placement awaits Complete
+func (dc *DataCatalog) Place(process models.Process, targetID string, at time.Time) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	placement := catalogPlacement{
+		targetID:        targetID,
+		inputDatasetID:  process.InputDatasetID,
+		inputSize:       process.InputSize,
+		outputDatasetID: process.OutputDatasetID,
+		outputSize:      process.OutputSize,
+	}
+	if process.InputDatasetID != "" {
+		if replica := dc.lookup(targetID, process.InputDatasetID, at); replica != nil {
+			replica.Hits++
+			replica.LastAccess = at
+		} else {
+			dc.put(targetID, process.InputDatasetID, process.InputSize, DATASET_STAGING, at)
+			placement.staged = true
+		}
+	}
+	dc.pending[process.ID] = append(dc.pending[process.ID], placement)
+}
+
+// Complete updates placement after a process's execution. On success its
+// staged inputs are confirmed as cached and its output is materialized on
+// the target; on failure inputs it staged are dropped.
+func (dc *DataCatalog) Complete(processID string, success bool, at time.Time) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	placements := dc.pending[processID]
+	delete(dc.pending, processID)
+	for _, placement := range placements {
+		if placement.staged {
+			stats := dc.statsFor(placement.targetID)
+			if success {
+				stats.confirmed++
+			} else {
+				stats.failed++
+			}
+		}
+		replica := dc.replicas[placement.targetID][placement.inputDatasetID]
+		switch {
+		case replica == nil:
+		case success && replica.State == DATASET_STAGING:
+			replica.State = DATASET_CACHED
+			replica.LastAccess = at
+		case !success && placement.staged && replica.State == DATASET_STAGING:
+			dc.remove(placement.targetID, placement.inputDatasetID)
+		}
+		if success && placement.outputDatasetID != "" {
+			dc.put(placement.targetID, placement.outputDatasetID, placement.outputSize, DATASET_MATERIALIZED, at)
+		}
+	}
+}
+
+// Cancel forgets a process's placements without counting them as outcomes,
+// dropping inputs they staged (e.g. when the offload is revoked)
+func (dc *DataCatalog) Cancel(processID string) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	for _, placement := range dc.pending[processID] {
+		replica := dc.replicas[placement.targetID][placement.inputDatasetID]
+		if placement.staged && replica != nil && replica.State == DATASET_STAGING && replica.Hits == 0 {
+			dc.remove(placement.targetID, placement.inputDatasetID)
+		}
+	}
+	delete(dc.pending, processID)
+}
+
+// Record adds or refreshes a replica reported by a target, e.g. a dataset
+// materialized outside CAPE's placements
+func (dc *DataCatalog) Record(targetID, datasetID string, size int64, state DatasetState, at time.Time) {
+	if datasetID == "" {
+		return
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.put(targetID, datasetID, size, state, at)
+}
+
+// Evict removes a replica from a target
+func (dc *DataCatalog) Evict(targetID, datasetID string) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.remove(targetID, datasetID)
+}
+
+// Clear removes every replica held by a target
+func (dc *DataCatalog) Clear(targetID string) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	delete(dc.replicas, targetID)
+	delete(dc.used, targetID)
+}
+
+// Lookup returns the replica of a dataset on a target, if it is held there
+func (dc *DataCatalog) Lookup(targetID, datasetID string, at time.Time) (DatasetReplica, bool) {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	if replica := dc.lookup(targetID, datasetID, at); replica != nil {
+		return *replica, true
+	}
+	return DatasetReplica{}, false
+}
+
+// HitProbability returns the probability that a placement on the target
+// finds the dataset already there. Confirmed replicas always hit; replicas
+// still staging hit as often as staging on the target has succeeded.
+func (dc *DataCatalog) HitProbability(targetID, datasetID string, at time.Time) float64 {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	replica := dc.lookup(targetID, datasetID, at)
+	switch {
+	case replica == nil:
+		return 0.0
+	case replica.State != DATASET_STAGING:
+		return 1.0
+	}
+	stats, exists := dc.staging[targetID]
+	if !exists {
+		return 1.0
+	}
+	// Laplace smoothing keeps a single failure from ruling the target out
+	return float64(stats.confirmed+1) / float64(stats.confirmed+stats.failed+1)
+}
+
+// Locations returns the IDs of the targets holding a dataset, in order
+func (dc *DataCatalog) Locations(datasetID string, at time.Time) []string {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	locations := make([]string, 0)
+	for targetID := range dc.replicas {
+		if dc.lookup(targetID, datasetID, at) != nil {
+			locations = append(locations, targetID)
+		}
+	}
+	sort.Strings(locations)
+	return locations
+}
+
+// Replicas returns the replicas held by a target ordered by dataset ID
+func (dc *DataCatalog) Replicas(targetID string, at time.Time) []DatasetReplica {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	replicas := make([]DatasetReplica, 0, len(dc.replicas[targetID]))
+	for datasetID := range dc.replicas[targetID] {
+		if replica := dc.lookup(targetID, datasetID, at); replica != nil {
+			replicas = append(replicas, *replica)
+		}
+	}
+	sort.Slice(replicas, func(i, j int) bool { return replicas[i].DatasetID < replicas[j].DatasetID })
+	return replicas
+}
+
+// lookup returns a live replica, treating replicas past their TTL as absent
+func (dc *DataCatalog) lookup(targetID, datasetID string, at time.Time) *DatasetReplica {
+	if datasetID == "" {
+		return nil
+	}
+	replica, exists := dc.replicas[targetID][datasetID]
+	if !exists || (dc.config.TTL > 0 && at.Sub(replica.LastAccess) > dc.config.TTL) {
+		return nil
+	}
+	return replica
+}
+
+// put adds or refreshes a replica, evicting least recently used replicas
+// to stay within the target's capacity
+func (dc *DataCatalog) put(targetID, datasetID string, size int64, state DatasetState, at time.Time) {
+	if dc.replicas[targetID] == nil {
+		dc.replicas[targetID] = make(map[string]*DatasetReplica)
+	}
+	if existing, exists := dc.replicas[targetID][datasetID]; exists {
+		dc.used[targetID] -= existing.Size
+		existing.Size = size
+		existing.LastAccess = at
+		if existing.State == DATASET_STAGING || state != DATASET_STAGING {
+			existing.State = state
+		}
+		dc.used[targetID] += size
+	} else {
+		dc.replicas[targetID][datasetID] = &DatasetReplica{
+			DatasetID:  datasetID,
+			TargetID:   targetID,
+			Size:       size,
+			State:      state,
+			Created:    at,
+			LastAccess: at,
+		}
+		dc.used[targetID] += size
+	}
+	dc.evict(targetID, datasetID, at)
+}
+
+// evict drops expired replicas, then least recently used ones until the
+// target is within capacity. The given dataset is kept.
+func (dc *DataCatalog) evict(targetID, keep string, at time.Time) {
+	for datasetID := range dc.replicas[targetID] {
+		if datasetID != keep && dc.lookup(targetID, datasetID, at) == nil {
+			dc.remove(targetID, datasetID)
+		}
+	}
+
+	capacity := dc.capacity(targetID)
+	for capacity > 0 && dc.used[targetID] > capacity {
+		var oldest *DatasetReplica
+		for datasetID, replica := range dc.replicas[targetID] {
+			if datasetID != keep && (oldest == nil || replica.LastAccess.Before(oldest.LastAccess)) {
+				oldest = replica
+			}
+		}
+		if oldest == nil {
+			return
+		}
+		dc.remove(targetID, oldest.DatasetID)
+	}
+}
+
+// remove deletes a replica
+func (dc *DataCatalog) remove(targetID, datasetID string) {
+	if replica, exists := dc.replicas[targetID][datasetID]; exists {
+		dc.used[targetID] -= replica.Size
+		delete(dc.replicas[targetID], datasetID)
+	}
+}
+
+// capacity returns a target's cache capacity in bytes (0 = unlimited)
+func (dc *DataCatalog) capacity(targetID string) int64 {
+	if capacity, exists := dc.config.CacheCapacity[targetID]; exists {
+		return capacity
+	}
+	return dc.config.DefaultCapacity
+}
+
+// statsFor returns the staging statistics of a target
+func (dc *DataCatalog) statsFor(targetID string) *stagingStats {
+	stats, exists := dc.staging[targetID]
+	if !exists {
+		stats = &stagingStats{}
+		dc.staging[targetID] = stats
+	}
+	return stats
+}
@@ -1,20 +1,47 @@
 package decision
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"log/slog"
 	"math"
+	"slices"
 	"sort"
 	"time"
 
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
 )
 
+// energyBaseline is the energy a process draws when its energy impact
+// scores 0.5
+const energyBaseline = 10 * units.WattHour
+
 // DecisionEngine makes offloading decisions based on system state and process requirements
 type DecisionEngine struct {
 	weights          AdaptiveWeights
 	patterns         []*DiscoveredPattern
 	safetyMargins    SafetyMargins
+	splitConfig      SplitConfig
+	scoringConfig    ScoringConfig
+	catalog          *DataCatalog // Datasets cached or materialized on targets
+	jurisdictions    *models.JurisdictionGraph  // Permitted data transfers (nil = unrestricted)
+	affinity         *AffinityTracker
+	transfers        *TransferEstimator // nil = transfers run at the target's full bandwidth
+	reservations     *ReservationLedger // nil = capacity is taken as reported
+	gravityFactors   map[string]float64 // Learned data size multipliers by location ("" = all locations)
+	storageTiers     map[models.StorageTier]models.StorageTierSpec // Retrieval characteristics of input storage
+	security         models.SecurityPolicy // Security levels and transfer encryption required by data sensitivity
+	thermal          models.ThermalPolicy  // Throttling of hot targets and the heavy processes kept off them
+	battery          models.BatteryPolicy  // Charge kept on battery-powered targets
+	objectives       []weightedObjective // Custom objectives scored alongside the built-in factors
+	costPressure     float64 // Budget pressure on target cost (0 = none)
+	budgetRemaining  float64 // Remaining cost budget the pressure is relative to
 	algorithmVersion string
+	logger           *slog.Logger
+	now              func() time.Time // Time source of decision times, transfers, dataset caching and reservations
 }
 
 // SafetyMargins defines safety constraints for decision making
@@ -33,6 +60,8 @@ func NewDecisionEngine(weights AdaptiveWeights) *DecisionEngine {
 		weights:          weights,
 		patterns:         make([]*DiscoveredPattern, 0),
 		algorithmVersion: "1.0.0",
+		logger:           logging.Discard(),
+		now:              time.Now,
 		safetyMargins: SafetyMargins{
 			MinLocalCompute:       0.2,  // Keep 20% compute local
 			MinLocalMemory:        0.2,  // Keep 20% memory local
@@ -40,6 +69,11 @@ func NewDecisionEngine(weights AdaptiveWeights) *DecisionEngine {
 			MaxLatencyTolerance:   500 * time.Millisecond,
 			MinReliability:        0.5,
 		},
+		splitConfig:    DefaultSplitConfig(),
+		scoringConfig:  DefaultScoringConfig(),
+		catalog:        NewDataCatalog(CatalogConfig{}),
+		affinity:       NewAffinityTracker(),
+		storageTiers:   models.DefaultStorageTiers(),
 	}
 }
 
@@ -48,6 +82,18 @@ func (de *DecisionEngine) MakeDecision(
 	process models.Process,
 	targets []models.OffloadTarget,
 	state models.SystemState,
+) (OffloadDecision, error) {
+	return de.MakeDecisionContext(context.Background(), process, targets, state)
+}
+
+// MakeDecisionContext makes an offloading decision within the context's
+// deadline. Targets are scored in order of a cheap heuristic, and when the
+// deadline expires the best target found so far is used.
+func (de *DecisionEngine) MakeDecisionContext(
+	ctx context.Context,
+	process models.Process,
+	targets []models.OffloadTarget,
+	state models.SystemState,
 ) (OffloadDecision, error) {
 	startTime := time.Now()
 
@@ -59,42 +105,139 @@ func (de *DecisionEngine) MakeDecision(
 		return OffloadDecision{}, fmt.Errorf("invalid system state: %w", err)
 	}
 
+	var phases PhaseTimings
+	phaseStart := time.Now()
+
+	// Offer targets with the capacity committed offloads reserved taken off
+	offered := targets
+	if de.reservations != nil {
+		targets = de.reservations.ApplyTargets(targets, startTime)
+	}
+
 	// Step 1: Check if we should consider offloading
 	shouldOffload, reason := de.shouldConsiderOffloading(state)
 	if !shouldOffload {
-		return de.createLocalDecision(process, reason, startTime), nil
+		phases.FeasibilityFilter = time.Since(phaseStart)
+		return de.createLocalDecision(process, reason, startTime).withPhases(phases), nil
 	}
 
-	// Step 2: Filter targets by safety and policy constraints
-	viableTargets := de.filterTargets(process, targets, state)
+	// Step 2: Filter targets by safety and policy constraints, most
+	// promising first, until the budget expires
+	order := HeuristicOrder(targets)
+	viableTargets, rejections, examined := de.filterTargetsWithin(ctx, process, targets, order, state)
+	phases.FeasibilityFilter = time.Since(phaseStart)
 	if len(viableTargets) == 0 {
-		return de.createLocalDecision(process, "no viable targets", startTime), nil
+		return de.createLocalDecision(process, "no viable targets", startTime).withPhases(phases).
+			withCandidates(candidateExplanations(targets, order[:examined], rejections, nil)), nil
 	}
+	phaseStart = time.Now()
 
 	// Step 3: Check for applicable patterns
 	pattern := de.findBestPattern(process, state)
 
-	// Step 4: Score each target
-	scores := de.scoreTargets(process, viableTargets, state, pattern)
-
-	// Step 5: Select best target
-	bestTarget, bestScore := de.selectBestTarget(scores, viableTargets)
-	if bestTarget == nil || bestScore < 0.3 { // Minimum score threshold
-		return de.createLocalDecision(process, "scores below threshold", startTime), nil
+	// Step 4: Score targets, most promising first, until the budget expires
+	scored, exhausted := de.scoreTargetsWithin(ctx, process, viableTargets, state, pattern)
+	exhausted = exhausted || examined < len(targets)
+	scores := make(map[string]float64, len(scored))
+	for id, result := range scored {
+		scores[id] = result.score
 	}
+	// Explain candidates from their scores before the selected target's
+	// dataset is staged
+	candidates := candidateExplanations(targets, order[:examined], rejections, scored)
+
+	// Step 5: Select best target, falling back to the next best when a
+	// concurrent decision reserved a chosen target's capacity first
+	evaluated := len(scores)
+	var decision OffloadDecision
+	for {
+		bestTarget, bestScore := de.selectBestTarget(scores, viableTargets)
+		if bestTarget == nil || bestScore < 0.3 { // Minimum score threshold
+			phases.Scoring = time.Since(phaseStart)
+			return de.createLocalDecision(process, "scores below threshold", startTime).withPhases(phases).withCandidates(candidates), nil
+		}
+
+		// Gangs are offloaded all-or-nothing, possibly across several targets
+		var gang []GangAllocation
+		if process.GangSize > 1 {
+			if gang = de.planGang(process, viableTargets, scores); gang == nil {
+				phases.Scoring = time.Since(phaseStart)
+				reason := fmt.Sprintf("gang of %d cannot be placed atomically", process.GangSize)
+				return de.createLocalDecision(process, reason, startTime).withPhases(phases).withCandidates(candidates), nil
+			}
+		}
+
+		// Step 6: Create offload decision
+		decision = de.createOffloadDecision(process, bestTarget, bestScore, pattern, startTime)
+		weights := de.effectiveWeights(pattern)
+		decision.ScoreComponents = de.computeScoreComponents(process, *bestTarget, state, weights)
+		decision.Attribution = ShapleyAttribution(decision.ScoreComponents, de.attributionBaseline(process, viableTargets, state, weights))
+		decision.TargetsEvaluated = evaluated
+		decision.BudgetExhausted = exhausted
+		decision.Candidates = candidates
+		now := de.now()
+		if de.transfers != nil {
+			view := de.transferView(process, *bestTarget, now)
+			de.transfers.Start(process.ID, *bestTarget, view.InputSize+view.OutputSize, now)
+		}
+		if gang == nil {
+			de.catalog.Place(process, bestTarget.ID, now)
+		}
+		de.affinity.Place(process, *bestTarget)
 
-	// Step 6: Create offload decision
-	decision := de.createOffloadDecision(process, bestTarget, bestScore, pattern, startTime)
+		// Step 7: Place gangs, or split parallelizable workloads when it shortens the makespan
+		if gang != nil {
+			de.applyGang(&decision, process, gang)
+		} else if process.Parallelizable && de.splitConfig.Enabled {
+			de.applySplit(&decision, process, viableTargets, scores)
+		}
+
+		if de.reservations == nil {
+			break
+		}
+		conflict, reserved := de.reservations.TryReserve(process, decision, offered, now)
+		if reserved {
+			break
+		}
+		de.logger.Debug("target reserved by a concurrent decision, trying the next best",
+			"process_id", process.ID, "target_id", conflict)
+		de.unplace(process.ID)
+		delete(scores, conflict)
+		viableTargets = withoutTarget(viableTargets, conflict)
+	}
+	phases.Scoring = time.Since(phaseStart)
+	decision.Phases = phases
+	decision.DecisionLatency = time.Since(startTime)
 	
 	// Ensure decision latency is within requirement
 	if decision.DecisionLatency > 500*time.Millisecond {
 		// Log warning but don't fail
-		fmt.Printf("Warning: Decision latency %v exceeds 500ms requirement\n", decision.DecisionLatency)
+		de.logger.Warn("decision latency exceeds requirement",
+			"process_id", process.ID, "latency", decision.DecisionLatency, "limit", 500*time.Millisecond)
 	}
 
 	return decision, nil
 }
 
+// unplace undoes the transfer, dataset and affinity placements made for a
+// process's offload
+func (de *DecisionEngine) unplace(processID string) {
+	de.affinity.Release(processID)
+	de.ReleaseTransfer(processID)
+	de.catalog.Cancel(processID)
+}
+
+// withoutTarget returns the targets other than the one with the given ID
+func withoutTarget(targets []models.OffloadTarget, id string) []models.OffloadTarget {
+	remaining := make([]models.OffloadTarget, 0, len(targets))
+	for i := range targets {
+		if targets[i].ID != id {
+			remaining = append(remaining, targets[i])
+		}
+	}
+	return remaining
+}
+
 // shouldConsiderOffloading checks if offloading should be considered
 func (de *DecisionEngine) shouldConsiderOffloading(state models.SystemState) (bool, string) {
 	// Don't offload if local resources are underutilized
@@ -121,90 +264,233 @@ func (de *DecisionEngine) shouldConsiderOffloading(state models.SystemState) (bo
 	return false, "no offload trigger"
 }
 
-// filterTargets filters targets based on constraints
-func (de *DecisionEngine) filterTargets(
+// filterTargetsWithin filters targets in the given order of their indexes
+// until the context is done and a viable target has been found. It returns
+// the viable targets in that order, the rejection reasons of the others by
+// ID, and how many targets were examined.
+func (de *DecisionEngine) filterTargetsWithin(
+	ctx context.Context,
 	process models.Process,
 	targets []models.OffloadTarget,
+	order []int,
 	state models.SystemState,
-) []models.OffloadTarget {
+) ([]models.OffloadTarget, map[string]string, int) {
 	viable := make([]models.OffloadTarget, 0)
+	rejections := make(map[string]string)
 
-	for _, target := range targets {
-		// Skip unhealthy targets
-		if !target.IsHealthy() {
-			continue
+	for examined, index := range order {
+		if len(viable) > 0 && BudgetExpired(ctx) {
+			return viable, rejections, examined
 		}
-
-		// Skip targets below minimum reliability
-		if target.Reliability < de.safetyMargins.MinReliability {
-			continue
+		target := targets[index]
+		if reason := de.RejectionReason(process, target); reason != "" {
+			rejections[target.ID] = reason
+		} else {
+			viable = append(viable, target)
 		}
+	}
 
-		// Skip targets that can't accommodate the process
-		if !target.CanAccommodate(process) {
-			continue
-		}
+	return viable, rejections, len(order)
+}
 
-		// Skip targets with excessive latency for real-time processes
-		if process.RealTime && target.NetworkLatency > de.safetyMargins.MaxLatencyTolerance {
-			continue
-		}
+// RejectionReason returns why a target cannot run the process, or an empty
+// string if the target is viable
+func (de *DecisionEngine) RejectionReason(process models.Process, target models.OffloadTarget) string {
+	// Skip unhealthy targets
+	if !target.IsHealthy() {
+		return "target is unhealthy"
+	}
 
-		// Skip non-local targets for safety-critical processes
-		if process.SafetyCritical && target.Type != models.LOCAL {
-			continue
-		}
+	// Skip targets below minimum reliability
+	if target.Reliability < de.safetyMargins.MinReliability {
+		return fmt.Sprintf("reliability %.2f below minimum %.2f", target.Reliability, de.safetyMargins.MinReliability)
+	}
 
-		// Skip targets that don't meet security requirements
-		if process.SecurityLevel > target.SecurityLevel {
-			continue
+	// Skip targets that can't accommodate the process
+	if !target.CanAccommodate(process) {
+		return "insufficient capacity for process requirements"
+	}
+
+	// Skip targets with excessive latency for real-time processes
+	if process.RealTime && target.NetworkLatency > de.safetyMargins.MaxLatencyTolerance {
+		return fmt.Sprintf("latency %v exceeds real-time tolerance %v", target.NetworkLatency, de.safetyMargins.MaxLatencyTolerance)
+	}
+
+	// Skip non-local targets for safety-critical processes
+	if process.SafetyCritical && target.Type != models.LOCAL {
+		return "safety-critical process must run locally"
+	}
+
+	// Skip targets that don't meet security requirements, including the
+	// level negotiated from the data's sensitivity
+	if required := de.security.RequiredLevel(process); required > target.SecurityLevel {
+		return fmt.Sprintf("security level %d below required %d", target.SecurityLevel, required)
+	}
+
+	// Keep heavy processes off targets too hot to run them
+	if de.thermal.TooHot(process, target) {
+		return fmt.Sprintf("temperature %.0f°C too high for a heavy process", target.Temperature)
+	}
+
+	// Preserve the uptime of battery-powered devices
+	if reason := de.battery.Refusal(process, target); reason != "" {
+		return reason
+	}
+
+	// Check data locality requirements
+	if process.LocalityRequired && target.Type != models.LOCAL && target.Type != models.EDGE {
+		return "process requires data locality"
+	}
+
+	// Check capability requirements
+	if process.Requirements != "" {
+		ok, err := target.SatisfiesRequirements(process.Requirements)
+		if err != nil {
+			return fmt.Sprintf("invalid capability requirements: %v", err)
+		}
+		if !ok {
+			return fmt.Sprintf("capabilities do not satisfy %q", process.Requirements)
 		}
+	}
 
-		// Check data locality requirements
-		if process.LocalityRequired && target.Type != models.LOCAL && target.Type != models.EDGE {
-			continue
+	// Check data residency against permitted transfers
+	if de.jurisdictions != nil {
+		if ok, reason := de.jurisdictions.CheckPlacement(process, target); !ok {
+			return reason
 		}
+	}
 
-		viable = append(viable, target)
+	// Check required affinity rules
+	if reason, _ := de.affinity.Evaluate(process, target); reason != "" {
+		return reason
 	}
 
-	return viable
+	return ""
+}
+
+// scoredTarget is a target's score and the components and data movement it
+// was computed from
+type scoredTarget struct {
+	score      float64
+	components ScoreBreakdown
+	gravity    DataGravityImpact
 }
 
-// scoreTargets computes scores for each target
-func (de *DecisionEngine) scoreTargets(
+// scoreTargetsWithin scores targets, in the order of HeuristicOrder, until
+// the context is done. At least one target is always scored. It returns the
+// targets scored, by ID, and whether the budget expired before every target
+// was scored.
+func (de *DecisionEngine) scoreTargetsWithin(
+	ctx context.Context,
 	process models.Process,
 	targets []models.OffloadTarget,
 	state models.SystemState,
 	pattern *DiscoveredPattern,
-) map[string]float64 {
-	scores := make(map[string]float64)
-	weights := de.weights
+) (map[string]scoredTarget, bool) {
+	weights := de.effectiveWeights(pattern)
+	ordered := targets
+
+	if de.scoringConfig.Parallel && len(ordered) >= de.scoringConfig.Threshold {
+		return de.scoreTargetsParallel(ctx, process, ordered, state, weights)
+	}
+
+	scored := make(map[string]scoredTarget)
+	for i, target := range ordered {
+		if i > 0 && BudgetExpired(ctx) {
+			return scored, true
+		}
+		scored[target.ID] = de.scoreTarget(process, target, state, weights)
+	}
+
+	return scored, false
+}
 
-	// Apply pattern weight adjustments if applicable
+// BudgetExpired reports whether the context is done or past its deadline.
+// The deadline is checked directly, as the timer cancelling the context can
+// fire well after it on a busy processor.
+func BudgetExpired(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && !time.Now().Before(deadline)
+}
+
+// HeuristicOrder returns the indexes of targets sorted by a cheap estimate
+// of their score: idle, fast, reliable and close targets first. Targets are
+// examined in this order, so when the decision budget expires the most
+// promising have been. Indexes are sorted rather than the targets
+// themselves, which are large to copy.
+func HeuristicOrder(targets []models.OffloadTarget) []int {
+	heuristic := func(t *models.OffloadTarget) float64 {
+		latencyMs := float64(t.NetworkLatency) / float64(time.Millisecond)
+		return (1.0 - t.CurrentLoad) * math.Max(t.ProcessingSpeed, 0.1) * t.Reliability / (1.0 + latencyMs/100.0)
+	}
+
+	keys := make([]float64, len(targets))
+	order := make([]int, len(targets))
+	for i := range targets {
+		keys[i] = heuristic(&targets[i])
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		return cmp.Compare(keys[j], keys[i])
+	})
+	return order
+}
+
+// effectiveWeights returns the weights used for scoring, with validated
+// pattern adjustments applied
+func (de *DecisionEngine) effectiveWeights(pattern *DiscoveredPattern) AdaptiveWeights {
 	if pattern != nil && pattern.ValidationStatus == VALIDATED {
-		weights = de.applyPatternWeights(weights, pattern)
+		return de.applyPatternWeights(de.weights, pattern)
 	}
+	return de.weights
+}
 
-	for _, target := range targets {
-		score := de.computeTargetScore(process, target, state, weights)
-		scores[target.ID] = score
+// scoreTarget computes a single target's score
+func (de *DecisionEngine) scoreTarget(
+	process models.Process,
+	target models.OffloadTarget,
+	state models.SystemState,
+	weights AdaptiveWeights,
+) scoredTarget {
+	components := de.computeScoreComponents(process, target, state, weights)
+	result := scoredTarget{components: components, gravity: de.dataGravity(process, target)}
+	score := components.WeightedScore()
+
+	// Preferred affinity violations lower the score without excluding the target
+	if _, penalty := de.affinity.Evaluate(process, target); penalty > 0 {
+		score = math.Max(0.0, score-penalty)
 	}
 
-	return scores
+	// Budget pressure discourages targets that would use up much of the remaining budget
+	if de.costPressure > 0 {
+		share := 1.0
+		if de.budgetRemaining > 0 {
+			share = math.Min(1.0, target.GetTotalCost(de.transferView(process, target, de.now()))/de.budgetRemaining)
+		}
+		score = math.Max(0.0, score-de.costPressure*share)
+	}
+	result.score = score
+	return result
 }
 
-// computeTargetScore computes a single target's score
-func (de *DecisionEngine) computeTargetScore(
+// computeScoreComponents computes the individual score factors for a target
+func (de *DecisionEngine) computeScoreComponents(
 	process models.Process,
 	target models.OffloadTarget,
 	state models.SystemState,
 	weights AdaptiveWeights,
-) float64 {
+) ScoreBreakdown {
 	components := ScoreBreakdown{
 		WeightsUsed: weights,
 	}
 
+	// Inputs already staged on the target are not transferred again
+	now := de.now()
+	process = de.transferView(process, target, now)
+
 	// Queue impact: How much this helps reduce queue pressure
 	if state.QueueThreshold > 0 {
 		queuePressure := float64(state.QueueDepth) / float64(state.QueueThreshold)
@@ -220,12 +506,16 @@ func (de *DecisionEngine) computeTargetScore(
 	// Network cost: Normalized network transfer cost
 	dataSize := float64(process.InputSize + process.OutputSize)
 	maxDataSize := float64(100 * 1024 * 1024) // 100MB baseline
+	if de.jurisdictions != nil {
+		dataSize *= de.jurisdictions.TransferCostFactor(process, target)
+	}
+	dataSize *= de.gravityFactor(target.Location)
 	normalizedDataCost := math.Min(1.0, dataSize/maxDataSize)
 	latencyFactor := math.Min(1.0, float64(target.NetworkLatency)/(100*float64(time.Millisecond)))
 	components.NetworkCost = 1.0 - (0.5*normalizedDataCost + 0.5*latencyFactor)
 
 	// Latency impact: How latency affects the process
-	estimatedTime := target.EstimateExecutionTime(process)
+	estimatedTime := de.estimateExecutionTime(process, target, now)
 	if process.MaxDuration > 0 {
 		timeRatio := float64(estimatedTime) / float64(process.MaxDuration)
 		components.LatencyImpact = math.Max(0.0, 1.0-timeRatio)
@@ -234,8 +524,15 @@ func (de *DecisionEngine) computeTargetScore(
 		components.LatencyImpact = 1.0 / (1.0 + float64(estimatedTime)/(30*float64(time.Second)))
 	}
 
-	// Energy impact: Favor energy-efficient targets
+	// Energy impact: Favor energy-efficient targets, by the energy the
+	// process is estimated to draw when the target has a power profile, and
+	// battery-powered targets with energy budget to spare
 	energyScore := 1.0 - target.EnergyCost/10.0 // Normalized energy cost
+	if target.Power != nil {
+		energy := target.EstimateEnergy(process, estimatedTime)
+		energyScore = 1.0 / (1.0 + float64(energy/energyBaseline))
+	}
+	energyScore *= de.battery.BudgetHeadroom(process, target, estimatedTime)
 	components.EnergyImpact = math.Max(0.0, math.Min(1.0, energyScore))
 
 	// Policy match: How well target matches policy preferences
@@ -246,16 +543,13 @@ func (de *DecisionEngine) computeTargetScore(
 		components.PolicyMatch = components.PolicyMatch*0.7 + target.HistoricalSuccess*0.3
 	}
 
-	// Compute weighted score
-	finalScore := weights.QueueDepth*components.QueueImpact +
-		weights.ProcessorLoad*components.LoadBalance +
-		weights.NetworkCost*components.NetworkCost +
-		weights.LatencyCost*components.LatencyImpact +
-		weights.EnergyCost*components.EnergyImpact +
-		weights.PolicyCost*components.PolicyMatch
+	// Prefer cooler targets for heavy processes
+	components.PolicyMatch *= de.thermal.Headroom(process, target)
+
+	// Custom objectives
+	de.evaluateObjectives(process, target, state, &components)
 
-	// Ensure score is in [0.0, 1.0] range
-	return math.Max(0.0, math.Min(1.0, finalScore))
+	return components
 }
 
 // selectBestTarget selects the target with the highest score
@@ -445,7 +739,7 @@ func (de *DecisionEngine) createLocalDecision(
 		Strategy:         IMMEDIATE,
 		ExpectedBenefit:  0.0,
 		EstimatedCost:    0.0,
-		DecisionTime:     startTime,
+		DecisionTime:     de.now(),
 		DecisionLatency:  time.Since(startTime),
 		AlgorithmVersion: de.algorithmVersion,
 		ScoreComponents: ScoreBreakdown{
@@ -468,14 +762,7 @@ func (de *DecisionEngine) createOffloadDecision(
 	pattern *DiscoveredPattern,
 	startTime time.Time,
 ) OffloadDecision {
-	// Calculate expected benefit
-	localExecutionTime := process.EstimatedDuration
-	targetExecutionTime := target.EstimateExecutionTime(process)
-	timeSavings := float64(localExecutionTime - targetExecutionTime)
-	expectedBenefit := math.Max(0, timeSavings/float64(localExecutionTime))
-
-	// Calculate estimated cost
-	estimatedCost := target.GetTotalCost(process)
+	predicted := de.PredictOutcome(process, *target)
 
 	// Determine execution strategy
 	strategy := IMMEDIATE
@@ -499,9 +786,14 @@ func (de *DecisionEngine) createOffloadDecision(
 		AppliedPattern:   pattern,
 		PolicyViolations: []string{},
 		Strategy:         strategy,
-		ExpectedBenefit:  expectedBenefit,
-		EstimatedCost:    estimatedCost,
-		DecisionTime:     startTime,
+		ExpectedBenefit:  predicted.ExpectedBenefit,
+		EstimatedCost:    predicted.EstimatedCost,
+		EstimatedEnergy:  predicted.Energy,
+		DataSize:         predicted.DataSize,
+		TransferTime:     predicted.TransferTime,
+		RetrievalTime:    predicted.RetrievalTime,
+		EncryptionTime:   predicted.EncryptionTime,
+		DecisionTime:     de.now(),
 		DecisionLatency:  time.Since(startTime),
 		AlgorithmVersion: de.algorithmVersion,
 		ScoreComponents:  ScoreBreakdown{WeightsUsed: de.weights},
@@ -533,6 +825,208 @@ func (de *DecisionEngine) SetSafetyMargins(margins SafetyMargins) {
 	de.safetyMargins = margins
 }
 
+// SetLogger sets the structured logger; records are discarded until one is set
+func (de *DecisionEngine) SetLogger(logger *slog.Logger) {
+	de.logger = logger
+}
+
+// SetClock replaces the time source, for tests and simulations. Decision
+// latencies are still measured on the wall clock.
+func (de *DecisionEngine) SetClock(now func() time.Time) {
+	de.now = now
+}
+
+// SetScoringConfig sets the parallel scoring configuration
+func (de *DecisionEngine) SetScoringConfig(config ScoringConfig) {
+	de.scoringConfig = config
+}
+
+// SetSplitConfig updates the partial offload configuration
+func (de *DecisionEngine) SetSplitConfig(config SplitConfig) {
+	de.splitConfig = config
+}
+
+// SetJurisdictionGraph restricts placement to targets the process's data may
+// be transferred to, and scales transfer costs by the graph's cost factors
+func (de *DecisionEngine) SetJurisdictionGraph(graph *models.JurisdictionGraph) {
+	de.jurisdictions = graph
+}
+
+// SetCostPressure sets how strongly target cost is discouraged, from 0 to 1,
+// relative to the remaining cost budget
+func (de *DecisionEngine) SetCostPressure(pressure, remaining float64) {
+	de.costPressure = math.Max(0.0, math.Min(1.0, pressure))
+	de.budgetRemaining = remaining
+}
+
+// Affinity returns the tracker used to evaluate affinity rules
+func (de *DecisionEngine) Affinity() *AffinityTracker {
+	return de.affinity
+}
+
+// SetSecurityPolicy sets the security levels and transfer encryption that
+// data sensitivity requires
+func (de *DecisionEngine) SetSecurityPolicy(policy models.SecurityPolicy) {
+	de.security = policy
+}
+
+// SetThermalPolicy sets how hot targets are throttled and which processes
+// are kept off them
+func (de *DecisionEngine) SetThermalPolicy(policy models.ThermalPolicy) {
+	de.thermal = policy
+}
+
+// SetBatteryPolicy sets the charge kept on battery-powered targets
+func (de *DecisionEngine) SetBatteryPolicy(policy models.BatteryPolicy) {
+	de.battery = policy
+}
+
+// SetDataCatalog replaces the catalog of datasets held by targets
+func (de *DecisionEngine) SetDataCatalog(catalog *DataCatalog) {
+	de.catalog = catalog
+}
+
+// Catalog returns the catalog of datasets held by targets
+func (de *DecisionEngine) Catalog() *DataCatalog {
+	return de.catalog
+}
+
+// RecordDatasetStaged marks an input dataset as present on a target so later
+// processes sharing that dataset are not charged for transferring it again
+func (de *DecisionEngine) RecordDatasetStaged(targetID, datasetID string) {
+	de.catalog.Record(targetID, datasetID, 0, DATASET_STAGING, de.now())
+}
+
+// IsDatasetStaged returns true if the dataset is already present on the target
+func (de *DecisionEngine) IsDatasetStaged(targetID, datasetID string) bool {
+	_, exists := de.catalog.Lookup(targetID, datasetID, de.now())
+	return exists
+}
+
+// SetTransferEstimator models transfers as sharing congested links when
+// estimating transfer times
+func (de *DecisionEngine) SetTransferEstimator(estimator *TransferEstimator) {
+	de.transfers = estimator
+}
+
+// SetDataGravity sets the learned data size multipliers used in network cost
+// scoring, by target location. The "" entry applies to locations without
+// their own factor; without either, data size is taken as is.
+func (de *DecisionEngine) SetDataGravity(factors map[string]float64) {
+	de.gravityFactors = factors
+}
+
+// gravityFactor returns the data size multiplier for a target location
+func (de *DecisionEngine) gravityFactor(location string) float64 {
+	if factor, exists := de.gravityFactors[location]; exists {
+		return factor
+	}
+	if factor, exists := de.gravityFactors[""]; exists {
+		return factor
+	}
+	return 1.0
+}
+
+// SetStorageTiers overrides the retrieval characteristics of storage tiers.
+// Tiers not given keep their defaults.
+func (de *DecisionEngine) SetStorageTiers(tiers map[models.StorageTier]models.StorageTierSpec) {
+	merged := models.DefaultStorageTiers()
+	for tier, spec := range tiers {
+		merged[tier] = spec
+	}
+	de.storageTiers = merged
+}
+
+// retrievalTime estimates how long reading a process's input from its
+// storage tier takes before the transfer can start
+func (de *DecisionEngine) retrievalTime(process models.Process) time.Duration {
+	if spec, exists := de.storageTiers[process.InputStorageTier]; exists {
+		return spec.RetrievalTime(process.InputSize)
+	}
+	return 0
+}
+
+// retrievalCost estimates the cost of reading a process's input from its
+// storage tier
+func (de *DecisionEngine) retrievalCost(process models.Process) float64 {
+	if spec, exists := de.storageTiers[process.InputStorageTier]; exists {
+		return spec.RetrievalCost(process.InputSize)
+	}
+	return 0
+}
+
+// ReleaseTransfer removes a process's transfer from the transfer model, e.g.
+// when it completes or its offload is revoked
+func (de *DecisionEngine) ReleaseTransfer(processID string) {
+	if de.transfers != nil {
+		de.transfers.Complete(processID)
+	}
+}
+
+// SetReservations takes the capacity held in a reservation ledger off
+// targets before deciding, and reserves the capacity of offload decisions
+func (de *DecisionEngine) SetReservations(ledger *ReservationLedger) {
+	de.reservations = ledger
+}
+
+// Reservations returns the reservation ledger, or nil when capacity is not
+// reserved
+func (de *DecisionEngine) Reservations() *ReservationLedger {
+	return de.reservations
+}
+
+// ReleaseReservation frees the capacity a process reserved, e.g. when it
+// completes or its offload is revoked
+func (de *DecisionEngine) ReleaseReservation(processID string) {
+	if de.reservations != nil {
+		de.reservations.Release(processID)
+	}
+}
+
+// transferTime estimates how long moving the given bytes to a target takes
+// when starting at the given time
+func (de *DecisionEngine) transferTime(target models.OffloadTarget, bytes int64, at time.Time) time.Duration {
+	if de.transfers != nil {
+		return de.transfers.Estimate(target, bytes, at)
+	}
+	if bytes > 0 && target.NetworkBandwidth > 0 {
+		return time.Duration(float64(bytes) / target.NetworkBandwidth * float64(time.Second))
+	}
+	return 0
+}
+
+// estimateExecutionTime estimates a process's execution time on a target,
+// with transfer time from the transfer model when one is set and the time to
+// retrieve its input from storage, when starting at the given time
+func (de *DecisionEngine) estimateExecutionTime(process models.Process, target models.OffloadTarget, at time.Time) time.Duration {
+	retrieval := de.retrievalTime(process)
+	if de.transfers == nil {
+		return target.EstimateExecutionTime(process) + retrieval
+	}
+	bytes := process.InputSize + process.OutputSize
+	process.InputSize, process.OutputSize = 0, 0
+	return target.EstimateExecutionTime(process) + de.transfers.Estimate(target, bytes, at) + retrieval
+}
+
+// ClearStagedDatasets forgets the datasets staged on a target (e.g. after eviction)
+func (de *DecisionEngine) ClearStagedDatasets(targetID string) {
+	de.catalog.Clear(targetID)
+}
+
+// transferView returns the process as seen by transfer estimation on a target,
+// with the input size reduced by the chance its dataset is already there and
+// the duration extended by the CPU time spent encrypting its transfers and
+// by thermal throttling, when starting at the given time
+func (de *DecisionEngine) transferView(process models.Process, target models.OffloadTarget, at time.Time) models.Process {
+	if process.InputDatasetID != "" {
+		hit := de.catalog.HitProbability(target.ID, process.InputDatasetID, at)
+		process.InputSize = int64(float64(process.InputSize) * (1 - hit))
+	}
+	process.EstimatedDuration += de.security.EncryptionOverhead(process)
+	process.EstimatedDuration = de.thermal.Throttled(process.EstimatedDuration, target)
+	return process
+}
+
 // GetWeights returns current weights
 func (de *DecisionEngine) GetWeights() AdaptiveWeights {
 	return de.weights
@@ -0,0 +1,304 @@
+package decision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// maxCatalogChanges is the number of applied catalog changes kept
+const maxCatalogChanges = 100
+
+// ExecutorCatalogConfig configures the executor catalog: a versioned JSON
+// document of offload targets, read from a file or an HTTP endpoint, that
+// registers its targets and is reloaded when it changes
+type ExecutorCatalogConfig struct {
+	Source   string        `json:"source"`   // File path or http(s) URL (empty disables the catalog)
+	Interval time.Duration `json:"interval"` // Time between checks for a new version (default 30s)
+	Timeout  time.Duration `json:"timeout"`  // Per-request timeout of HTTP sources (default 10s)
+}
+
+// Validate checks the catalog configuration
+func (c ExecutorCatalogConfig) Validate() error {
+	if c.Interval < 0 || c.Timeout < 0 {
+		return fmt.Errorf("interval and timeout must be non-negative")
+	}
+	return nil
+}
+
+// ExecutorCatalog is one version of the executor catalog
+type ExecutorCatalog struct {
+	Version string                 `json:"version"`
+	Targets []models.OffloadTarget `json:"targets"`
+}
+
+// Validate checks that the catalog is versioned and its targets are valid
+// and uniquely identified
+func (c ExecutorCatalog) Validate() error {
+	if c.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+	seen := make(map[string]bool, len(c.Targets))
+	for i, target := range c.Targets {
+		if err := target.Validate(); err != nil {
+			return fmt.Errorf("targets[%d]: %w", i, err)
+		}
+		if seen[target.ID] {
+			return fmt.Errorf("targets[%d]: duplicate target %s", i, target.ID)
+		}
+		seen[target.ID] = true
+	}
+	return nil
+}
+
+// CatalogChange records a catalog version being applied to the registry
+type CatalogChange struct {
+	Version   string    `json:"version"`
+	Previous  string    `json:"previous,omitempty"` // Empty for the first version
+	Added     []string  `json:"added,omitempty"`    // Target IDs
+	Updated   []string  `json:"updated,omitempty"`
+	Removed   []string  `json:"removed,omitempty"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// CatalogManager keeps a target registry in step with the executor catalog.
+// Each new version is validated before anything is applied, and its
+// additions, updates and removals are applied in one step, so decisions
+// never see a partly applied catalog. A version that fails to load or
+// validate leaves the previous one in place. Targets registered by other
+// means are left alone.
+type CatalogManager struct {
+	config   ExecutorCatalogConfig
+	registry *TargetRegistry
+	client   *http.Client
+	onChange func(change CatalogChange, err error)
+
+	mu      sync.RWMutex
+	version string
+	targets map[string]models.OffloadTarget // Of the applied version, by ID
+	changes []CatalogChange                 // Oldest first
+	modTime time.Time                       // Of a file source when last loaded
+	etag    string                          // Of an HTTP source when last loaded
+}
+
+// NewCatalogManager creates a manager applying the configured catalog to a
+// registry. Nothing is loaded until Load is called.
+func NewCatalogManager(config ExecutorCatalogConfig, registry *TargetRegistry) (*CatalogManager, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Source == "" {
+		return nil, fmt.Errorf("source is required")
+	}
+	if config.Interval == 0 {
+		config.Interval = 30 * time.Second
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &CatalogManager{
+		config:   config,
+		registry: registry,
+		client:   &http.Client{},
+		targets:  make(map[string]models.OffloadTarget),
+	}, nil
+}
+
+// OnChange registers a callback invoked after every load that applied a new
+// version or failed
+func (cm *CatalogManager) OnChange(callback func(change CatalogChange, err error)) {
+	cm.onChange = callback
+}
+
+// Load reads the catalog and applies it if its version differs from the
+// applied one. It returns the applied change, or false if the catalog was
+// unchanged. A catalog whose targets changed without a new version is
+// refused, so a version always identifies the targets decided against.
+func (cm *CatalogManager) Load(ctx context.Context) (CatalogChange, bool, error) {
+	catalog, unchanged, err := cm.fetch(ctx)
+	if err == nil && !unchanged {
+		err = catalog.Validate()
+	}
+	if err != nil {
+		return CatalogChange{}, false, cm.report(CatalogChange{}, fmt.Errorf("catalog %s: %w", cm.config.Source, err))
+	}
+	if unchanged {
+		return CatalogChange{}, false, nil
+	}
+
+	cm.mu.Lock()
+	change := cm.diff(catalog)
+	changed := len(change.Added)+len(change.Updated)+len(change.Removed) > 0
+	switch {
+	case catalog.Version == cm.version && changed:
+		cm.mu.Unlock()
+		return CatalogChange{}, false, cm.report(CatalogChange{}, fmt.Errorf("catalog %s: targets changed without a new version (still %s)", cm.config.Source, cm.version))
+	case catalog.Version == cm.version:
+		cm.mu.Unlock()
+		return CatalogChange{}, false, nil
+	}
+
+	upserts := make([]models.OffloadTarget, 0, len(change.Added)+len(change.Updated))
+	targets := make(map[string]models.OffloadTarget, len(catalog.Targets))
+	for _, target := range catalog.Targets {
+		targets[target.ID] = target
+	}
+	for _, id := range append(append([]string{}, change.Added...), change.Updated...) {
+		upserts = append(upserts, targets[id])
+	}
+	cm.registry.Apply(upserts, change.Removed)
+	cm.version = catalog.Version
+	cm.targets = targets
+	cm.changes = append(cm.changes, change)
+	if len(cm.changes) > maxCatalogChanges {
+		cm.changes = cm.changes[len(cm.changes)-maxCatalogChanges:]
+	}
+	cm.mu.Unlock()
+
+	cm.report(change, nil)
+	return change, true, nil
+}
+
+// diff returns the change from the applied catalog to a new version
+func (cm *CatalogManager) diff(catalog ExecutorCatalog) CatalogChange {
+	change := CatalogChange{Version: catalog.Version, Previous: cm.version, AppliedAt: time.Now()}
+	listed := make(map[string]bool, len(catalog.Targets))
+	for _, target := range catalog.Targets {
+		listed[target.ID] = true
+		previous, exists := cm.targets[target.ID]
+		switch {
+		case !exists:
+			change.Added = append(change.Added, target.ID)
+		case !reflect.DeepEqual(previous, target):
+			change.Updated = append(change.Updated, target.ID)
+		}
+	}
+	for id := range cm.targets {
+		if !listed[id] {
+			change.Removed = append(change.Removed, id)
+		}
+	}
+	sort.Strings(change.Removed)
+	return change
+}
+
+// fetch reads the catalog from its source, reporting true when the source
+// is known not to have changed since the last load
+func (cm *CatalogManager) fetch(ctx context.Context) (ExecutorCatalog, bool, error) {
+	var catalog ExecutorCatalog
+	var data []byte
+	if strings.HasPrefix(cm.config.Source, "http://") || strings.HasPrefix(cm.config.Source, "https://") {
+		body, unchanged, err := cm.fetchHTTP(ctx)
+		if err != nil || unchanged {
+			return catalog, unchanged, err
+		}
+		data = body
+	} else {
+		info, err := os.Stat(cm.config.Source)
+		if err != nil {
+			return catalog, false, err
+		}
+		cm.mu.RLock()
+		unchanged := info.ModTime().Equal(cm.modTime)
+		cm.mu.RUnlock()
+		if unchanged {
+			return catalog, true, nil
+		}
+		if data, err = os.ReadFile(cm.config.Source); err != nil {
+			return catalog, false, err
+		}
+		cm.mu.Lock()
+		cm.modTime = info.ModTime() // A broken file is reported once, not on every poll
+		cm.mu.Unlock()
+	}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return catalog, false, fmt.Errorf("invalid catalog: %w", err)
+	}
+	return catalog, false, nil
+}
+
+// fetchHTTP requests the catalog, conditionally on the last version's ETag
+func (cm *CatalogManager) fetchHTTP(ctx context.Context) ([]byte, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, cm.config.Timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, cm.config.Source, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	request.Header.Set("Accept", "application/json")
+	cm.mu.RLock()
+	if cm.etag != "" {
+		request.Header.Set("If-None-Match", cm.etag)
+	}
+	cm.mu.RUnlock()
+
+	response, err := cm.client.Do(request)
+	if err != nil {
+		return nil, false, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotModified {
+		return nil, true, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected status %s", response.Status)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	cm.mu.Lock()
+	cm.etag = response.Header.Get("ETag")
+	cm.mu.Unlock()
+	return body, false, nil
+}
+
+// Run loads the catalog every Interval until the context is cancelled
+func (cm *CatalogManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(cm.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cm.Load(ctx)
+		}
+	}
+}
+
+// Version returns the applied catalog version, empty before the first load
+func (cm *CatalogManager) Version() string {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return cm.version
+}
+
+// Changes returns the most recently applied catalog changes, oldest first
+func (cm *CatalogManager) Changes() []CatalogChange {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return append([]CatalogChange(nil), cm.changes...)
+}
+
+// report invokes the change callback and passes the error through
+func (cm *CatalogManager) report(change CatalogChange, err error) error {
+	if cm.onChange != nil {
+		cm.onChange(change, err)
+	}
+	return err
+}
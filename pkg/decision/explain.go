@@ -0,0 +1,148 @@
+package decision
+
+import (
+	"math"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// CandidateExplanation describes how the engine evaluated a single target
+type CandidateExplanation struct {
+	TargetID        string             `json:"target_id"`
+	Viable          bool               `json:"viable"`
+	RejectionReason string             `json:"rejection_reason,omitempty"`
+	Scored          bool               `json:"scored"` // False for viable targets the decision budget left unscored
+	Score           float64            `json:"score"`
+	Components      ScoreBreakdown     `json:"components"`
+	Contributions   map[string]float64 `json:"contributions"`
+	DataGravity     DataGravityImpact  `json:"data_gravity"`
+}
+
+// DataGravityImpact describes the cost of moving a process's data to a target
+type DataGravityImpact struct {
+	DataSize      int64              `json:"data_size"`      // Bytes that must be transferred
+	TransferTime  time.Duration      `json:"transfer_time"`  // Estimated transfer time
+	DatasetStaged bool               `json:"dataset_staged"` // Input dataset already on the target
+	GravityFactor float64            `json:"gravity_factor"` // Learned data size multiplier for the target's location
+	CacheHit      float64            `json:"cache_hit"`      // Probability the input dataset is found on the target
+	StorageTier   models.StorageTier `json:"storage_tier"`   // Tier the input is read from
+	RetrievalTime time.Duration      `json:"retrieval_time"` // Estimated time to read the input from its tier
+}
+
+// ExplainCandidates scores every target with the current weights and reports
+// why each target would or would not be selected. It does not change engine state.
+func (de *DecisionEngine) ExplainCandidates(
+	process models.Process,
+	targets []models.OffloadTarget,
+	state models.SystemState,
+) []CandidateExplanation {
+	weights := de.effectiveWeights(de.findBestPattern(process, state))
+
+	explanations := make([]CandidateExplanation, 0, len(targets))
+	for _, target := range targets {
+		components := de.computeScoreComponents(process, target, state, weights)
+		reason := de.RejectionReason(process, target)
+		explanations = append(explanations, CandidateExplanation{
+			TargetID:        target.ID,
+			Viable:          reason == "",
+			RejectionReason: reason,
+			Scored:          true,
+			Score:           components.WeightedScore(),
+			Components:      components,
+			Contributions:   components.Contributions(),
+			DataGravity:     de.dataGravity(process, target),
+		})
+	}
+
+	return explanations
+}
+
+// candidateExplanations explains the targets a decision examined, given by
+// index, with the rejection reasons and scores computed while making it,
+// without scoring any target again
+func candidateExplanations(
+	targets []models.OffloadTarget,
+	examined []int,
+	rejections map[string]string,
+	scored map[string]scoredTarget,
+) []CandidateExplanation {
+	explanations := make([]CandidateExplanation, 0, len(examined))
+	for _, index := range examined {
+		target := targets[index]
+		explanation := CandidateExplanation{TargetID: target.ID, Viable: true}
+		if reason, rejected := rejections[target.ID]; rejected {
+			explanation.Viable = false
+			explanation.RejectionReason = reason
+		}
+		if result, exists := scored[target.ID]; exists {
+			explanation.Scored = true
+			explanation.Score = result.components.WeightedScore()
+			explanation.Components = result.components
+			explanation.Contributions = result.components.Contributions()
+			explanation.DataGravity = result.gravity
+		}
+		explanations = append(explanations, explanation)
+	}
+	return explanations
+}
+
+// PredictedOutcome is what offloading a process to a target is expected to
+// yield
+type PredictedOutcome struct {
+	ExecutionTime   time.Duration `json:"execution_time"`   // Including transfer and retrieval
+	ExpectedBenefit float64       `json:"expected_benefit"` // Fraction of the local duration saved
+	EstimatedCost   float64       `json:"estimated_cost"`
+	EnergyCost      float64       `json:"energy_cost"` // Share of the estimated cost spent on energy
+	Energy          units.Energy  `json:"energy"`      // Watt-hours drawn at the target's site (0 without a power profile)
+	DataSize        int64         `json:"data_size"`
+	TransferTime    time.Duration `json:"transfer_time"`
+	RetrievalTime   time.Duration `json:"retrieval_time"`
+	EncryptionTime  time.Duration `json:"encryption_time"` // Included in the execution time
+}
+
+// PredictOutcome estimates the outcome of offloading a process to a target.
+// It does not change engine state.
+func (de *DecisionEngine) PredictOutcome(process models.Process, target models.OffloadTarget) PredictedOutcome {
+	return de.PredictOutcomeAt(process, target, de.now())
+}
+
+// PredictOutcomeAt estimates the outcome of offloading a process to a target
+// if it started at the given time, with transfers over the link load and
+// dataset cache expected then. It does not change engine state.
+func (de *DecisionEngine) PredictOutcomeAt(process models.Process, target models.OffloadTarget, at time.Time) PredictedOutcome {
+	localExecutionTime := process.EstimatedDuration
+	process = de.transferView(process, target, at)
+	predicted := PredictedOutcome{
+		ExecutionTime:  de.estimateExecutionTime(process, target, at),
+		EstimatedCost:  target.GetTotalCost(process) + de.retrievalCost(process),
+		EnergyCost:     target.GetEnergyCost(process),
+		Energy:         target.EstimateEnergy(process, target.EstimateExecutionTime(process)),
+		DataSize:       process.InputSize + process.OutputSize,
+		RetrievalTime:  de.retrievalTime(process),
+		EncryptionTime: de.security.EncryptionOverhead(process),
+	}
+	predicted.TransferTime = de.transferTime(target, predicted.DataSize, at)
+	if localExecutionTime > 0 {
+		timeSavings := float64(localExecutionTime - predicted.ExecutionTime)
+		predicted.ExpectedBenefit = math.Max(0, timeSavings/float64(localExecutionTime))
+	}
+	return predicted
+}
+
+// dataGravity computes the data transfer impact of running a process on a target
+func (de *DecisionEngine) dataGravity(process models.Process, target models.OffloadTarget) DataGravityImpact {
+	now := de.now()
+	view := de.transferView(process, target, now)
+	impact := DataGravityImpact{
+		DataSize:      view.InputSize + view.OutputSize,
+		DatasetStaged: de.IsDatasetStaged(target.ID, process.InputDatasetID),
+		GravityFactor: de.gravityFactor(target.Location),
+		CacheHit:      de.catalog.HitProbability(target.ID, process.InputDatasetID, now),
+		StorageTier:   process.InputStorageTier,
+		RetrievalTime: de.retrievalTime(view),
+	}
+	impact.TransferTime = de.transferTime(target, impact.DataSize, now)
+	return impact
+}
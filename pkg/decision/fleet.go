@@ -0,0 +1,180 @@
+package decision
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// InstanceType is a kind of executor a fleet can be built from
+type InstanceType struct {
+	Name        string        `json:"name"`
+	CPU         float64       `json:"cpu"`    // Cores
+	Memory      int64         `json:"memory"` // Bytes
+	CostPerHour float64       `json:"cost_per_hour"`
+	StartupTime time.Duration `json:"startup_time"` // Time from request until the executor accepts work
+	Spot        bool          `json:"spot"`         // Preemptible capacity
+	MaxCount    int           `json:"max_count"`    // Instances available (0 = unlimited)
+}
+
+// FleetRequirement is the capacity a fleet must provide
+type FleetRequirement struct {
+	CPU            float64       `json:"cpu"`
+	Memory         int64         `json:"memory"`
+	MaxStartupTime time.Duration `json:"max_startup_time"` // Latest acceptable readiness (0 = unbounded)
+	MaxSpotShare   float64       `json:"max_spot_share"`   // Share of the required CPU spot instances may provide (0.0-1.0)
+}
+
+// FleetAllocation is the number of instances of one type in a fleet
+type FleetAllocation struct {
+	InstanceType string `json:"instance_type"`
+	Count        int    `json:"count"`
+}
+
+// FleetPlan is the cheapest combination of instances meeting a requirement
+type FleetPlan struct {
+	Allocations []FleetAllocation `json:"allocations"` // Ordered by instance type name
+	CPU         float64           `json:"cpu"`
+	Memory      int64             `json:"memory"`
+	CostPerHour float64           `json:"cost_per_hour"`
+	StartupTime time.Duration     `json:"startup_time"` // Slowest instance to start
+	Instances   int               `json:"instances"`
+}
+
+// fleetSearch is the state of a branch and bound search for a fleet
+type fleetSearch struct {
+	types       []InstanceType
+	limits      []int
+	requirement FleetRequirement
+	minOnDemand float64 // CPU that must come from on-demand instances
+	counts      []int
+	best        []int
+	bestCost    float64
+	bestCount   int
+}
+
+// OptimizeFleet selects how many instances of each type to run so the fleet
+// covers the required CPU and memory at the lowest hourly cost. Types that
+// start too slowly are excluded, and spot instances may cover at most
+// MaxSpotShare of the CPU. Ties are broken by fewer instances. The search is
+// exact and meant for catalogs of a handful of types.
+func OptimizeFleet(types []InstanceType, requirement FleetRequirement) (FleetPlan, error) {
+	if requirement.CPU < 0 || requirement.Memory < 0 {
+		return FleetPlan{}, fmt.Errorf("required capacity must be non-negative")
+	}
+	if requirement.MaxSpotShare < 0 || requirement.MaxSpotShare > 1 {
+		return FleetPlan{}, fmt.Errorf("max spot share must be between 0 and 1, got %f", requirement.MaxSpotShare)
+	}
+
+	search := fleetSearch{
+		requirement: requirement,
+		minOnDemand: requirement.CPU * (1 - requirement.MaxSpotShare),
+		bestCost:    math.Inf(1),
+	}
+	for _, instanceType := range types {
+		if instanceType.CPU <= 0 || instanceType.Memory < 0 || instanceType.CostPerHour < 0 || instanceType.MaxCount < 0 {
+			return FleetPlan{}, fmt.Errorf("instance type %s: capacity and cost must be non-negative, cpu positive", instanceType.Name)
+		}
+		if requirement.MaxStartupTime > 0 && instanceType.StartupTime > requirement.MaxStartupTime {
+			continue
+		}
+		search.types = append(search.types, instanceType)
+	}
+
+	// Try cheap capacity first so good fleets are found early and prune more
+	sort.SliceStable(search.types, func(i, j int) bool {
+		return search.types[i].CostPerHour/search.types[i].CPU < search.types[j].CostPerHour/search.types[j].CPU
+	})
+	for _, instanceType := range search.types {
+		search.limits = append(search.limits, search.limit(instanceType))
+	}
+	search.counts = make([]int, len(search.types))
+	search.explore(0, 0, 0, 0, 0, 0)
+
+	if search.best == nil {
+		return FleetPlan{}, fmt.Errorf("no combination of instance types meets the requirement")
+	}
+	return search.plan(), nil
+}
+
+// limit is the most instances of a type worth considering: enough to cover
+// the requirement on its own
+func (fs *fleetSearch) limit(instanceType InstanceType) int {
+	needed := int(math.Ceil(fs.requirement.CPU / instanceType.CPU))
+	if instanceType.Memory > 0 {
+		if byMemory := int(math.Ceil(float64(fs.requirement.Memory) / float64(instanceType.Memory))); byMemory > needed {
+			needed = byMemory
+		}
+	}
+	if instanceType.MaxCount > 0 && instanceType.MaxCount < needed {
+		return instanceType.MaxCount
+	}
+	return needed
+}
+
+// satisfied returns true if the capacity covers the requirement
+func (fs *fleetSearch) satisfied(cpu float64, memory int64, onDemand float64) bool {
+	const epsilon = 1e-9
+	return cpu+epsilon >= fs.requirement.CPU && memory >= fs.requirement.Memory && onDemand+epsilon >= fs.minOnDemand
+}
+
+// explore tries every count of the type at index, then the types after it
+func (fs *fleetSearch) explore(index int, cpu float64, memory int64, onDemand, cost float64, instances int) {
+	if cost > fs.bestCost || (cost == fs.bestCost && instances >= fs.bestCount) {
+		return
+	}
+	if fs.satisfied(cpu, memory, onDemand) {
+		fs.best = append(make([]int, 0, len(fs.counts)), fs.counts...)
+		fs.bestCost, fs.bestCount = cost, instances
+		return
+	}
+	if index == len(fs.types) {
+		return
+	}
+
+	// The remaining types cannot cover the missing CPU for less than the
+	// cheapest of their per-core rates
+	instanceType := fs.types[index]
+	if missing := fs.requirement.CPU - cpu; missing > 0 {
+		if bound := cost + missing*instanceType.CostPerHour/instanceType.CPU; bound > fs.bestCost {
+			return
+		}
+	}
+	for count := fs.limits[index]; count >= 0; count-- {
+		fs.counts[index] = count
+		added := float64(count) * instanceType.CPU
+		addedOnDemand := 0.0
+		if !instanceType.Spot {
+			addedOnDemand = added
+		}
+		fs.explore(index+1,
+			cpu+added,
+			memory+int64(count)*instanceType.Memory,
+			onDemand+addedOnDemand,
+			cost+float64(count)*instanceType.CostPerHour,
+			instances+count)
+	}
+	fs.counts[index] = 0
+}
+
+// plan builds the fleet plan of the best counts found
+func (fs *fleetSearch) plan() FleetPlan {
+	plan := FleetPlan{Allocations: make([]FleetAllocation, 0)}
+	for i, count := range fs.best {
+		if count == 0 {
+			continue
+		}
+		instanceType := fs.types[i]
+		plan.Allocations = append(plan.Allocations, FleetAllocation{InstanceType: instanceType.Name, Count: count})
+		plan.CPU += float64(count) * instanceType.CPU
+		plan.Memory += int64(count) * instanceType.Memory
+		plan.CostPerHour += float64(count) * instanceType.CostPerHour
+		plan.Instances += count
+		if instanceType.StartupTime > plan.StartupTime {
+			plan.StartupTime = instanceType.StartupTime
+		}
+	}
+	sort.Slice(plan.Allocations, func(i, j int) bool { return plan.Allocations[i].InstanceType < plan.Allocations[j].InstanceType })
+	return plan
+}
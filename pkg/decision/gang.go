@@ -0,0 +1,92 @@
+package decision
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// planGang places every member of a gang on the best scoring targets, filling
+// each target's free slots before moving to the next. Returns nil unless the
+// whole gang fits, so gangs are never partially offloaded.
+func (de *DecisionEngine) planGang(
+	process models.Process,
+	targets []models.OffloadTarget,
+	scores map[string]float64,
+) []GangAllocation {
+	candidates := make([]models.OffloadTarget, 0, len(targets))
+	for _, target := range targets {
+		if _, scored := scores[target.ID]; scored && scores[target.ID] >= 0.3 {
+			candidates = append(candidates, target)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].ID] > scores[candidates[j].ID]
+	})
+
+	remaining := process.GangSize
+	gang := make([]GangAllocation, 0)
+	for i := range candidates {
+		if remaining == 0 {
+			break
+		}
+		slots := gangSlots(process, candidates[i])
+		if slots == 0 {
+			continue
+		}
+		if slots > remaining {
+			slots = remaining
+		}
+		target := candidates[i]
+		gang = append(gang, GangAllocation{Target: &target, Slots: slots})
+		remaining -= slots
+	}
+
+	if remaining > 0 {
+		return nil
+	}
+	return gang
+}
+
+// gangSlots returns how many gang members fit on a target at once
+func gangSlots(process models.Process, target models.OffloadTarget) int {
+	slots := math.MaxInt
+	if process.CPURequirement > 0 {
+		slots = int(target.AvailableCapacity / process.CPURequirement)
+	}
+	if process.MemoryRequirement > 0 {
+		if bySlots := int(target.MemoryAvailable / process.MemoryRequirement); bySlots < slots {
+			slots = bySlots
+		}
+	}
+	if slots == math.MaxInt {
+		return process.GangSize
+	}
+	return slots
+}
+
+// applyGang turns an offload decision into a gang decision
+func (de *DecisionEngine) applyGang(decision *OffloadDecision, process models.Process, gang []GangAllocation) {
+	var makespan time.Duration
+	estimatedCost := 0.0
+	now := de.now()
+	for _, allocation := range gang {
+		member := de.transferView(process, *allocation.Target, now)
+		if estimated := allocation.Target.EstimateExecutionTime(member); estimated > makespan {
+			makespan = estimated
+		}
+		estimatedCost += float64(allocation.Slots) * (allocation.Target.GetTotalCost(member) + de.retrievalCost(member))
+		de.catalog.Place(process, allocation.Target.ID, now)
+	}
+
+	decision.Gang = gang
+	decision.Target = gang[0].Target
+	decision.Strategy = GANG
+	decision.EstimatedCost = estimatedCost
+	if process.EstimatedDuration > 0 {
+		timeSavings := float64(process.EstimatedDuration - makespan)
+		decision.ExpectedBenefit = math.Max(0, timeSavings/float64(process.EstimatedDuration))
+	}
+}
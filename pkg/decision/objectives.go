@@ -0,0 +1,166 @@
+package decision
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// ObjectiveMetric is a custom objective scored alongside the built-in
+// factors. Evaluate rates a target for a process from 0 (worst) to 1 (best).
+type ObjectiveMetric interface {
+	Name() string
+	Evaluate(process models.Process, target models.OffloadTarget, state models.SystemState) float64
+}
+
+// objectiveFunc adapts a function to ObjectiveMetric
+type objectiveFunc struct {
+	name     string
+	evaluate func(models.Process, models.OffloadTarget, models.SystemState) float64
+}
+
+func (o objectiveFunc) Name() string { return o.name }
+
+func (o objectiveFunc) Evaluate(process models.Process, target models.OffloadTarget, state models.SystemState) float64 {
+	return o.evaluate(process, target, state)
+}
+
+// NewObjectiveMetric creates an objective metric from an evaluation function
+func NewObjectiveMetric(name string, evaluate func(models.Process, models.OffloadTarget, models.SystemState) float64) ObjectiveMetric {
+	return objectiveFunc{name: name, evaluate: evaluate}
+}
+
+// objectiveRegistry holds the custom objectives configurations can refer to
+var objectiveRegistry = struct {
+	metrics map[string]ObjectiveMetric
+	mu      sync.RWMutex
+}{metrics: make(map[string]ObjectiveMetric)}
+
+// RegisterObjective makes a custom objective available to configurations by
+// its name. Names must be unique and differ from the built-in factors.
+func RegisterObjective(metric ObjectiveMetric) error {
+	name := metric.Name()
+	if name == "" {
+		return fmt.Errorf("objective name cannot be empty")
+	}
+	for _, factor := range attributionFactors {
+		if name == factor {
+			return fmt.Errorf("objective %s is a built-in factor", name)
+		}
+	}
+
+	objectiveRegistry.mu.Lock()
+	defer objectiveRegistry.mu.Unlock()
+
+	if _, exists := objectiveRegistry.metrics[name]; exists {
+		return fmt.Errorf("objective %s is already registered", name)
+	}
+	objectiveRegistry.metrics[name] = metric
+	return nil
+}
+
+// UnregisterObjective removes a custom objective from the registry. Engines
+// already scoring with it keep doing so.
+func UnregisterObjective(name string) {
+	objectiveRegistry.mu.Lock()
+	defer objectiveRegistry.mu.Unlock()
+
+	delete(objectiveRegistry.metrics, name)
+}
+
+// RegisteredObjectives returns the names of the registered custom objectives
+func RegisteredObjectives() []string {
+	objectiveRegistry.mu.RLock()
+	defer objectiveRegistry.mu.RUnlock()
+
+	names := make([]string, 0, len(objectiveRegistry.metrics))
+	for name := range objectiveRegistry.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ObjectiveGoal selects a registered objective and the share of the score it
+// takes. The built-in factors share the remainder by their adaptive weights.
+type ObjectiveGoal struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"` // Share of the score, in (0, 1)
+}
+
+// ValidateObjectiveGoals checks goal weights and names, without requiring
+// the objectives to be registered yet
+func ValidateObjectiveGoals(goals []ObjectiveGoal) error {
+	total := 0.0
+	seen := make(map[string]bool)
+	for i, goal := range goals {
+		switch {
+		case goal.Name == "":
+			return fmt.Errorf("goal %d: name cannot be empty", i)
+		case seen[goal.Name]:
+			return fmt.Errorf("duplicate objective %s", goal.Name)
+		case goal.Weight <= 0 || goal.Weight >= 1:
+			return fmt.Errorf("%s: weight must be between 0 and 1, got %f", goal.Name, goal.Weight)
+		}
+		seen[goal.Name] = true
+		total += goal.Weight
+	}
+	if total >= 1 {
+		return fmt.Errorf("weights must sum to less than 1, got %f", total)
+	}
+	return nil
+}
+
+// weightedObjective is a resolved objective goal
+type weightedObjective struct {
+	metric ObjectiveMetric
+	weight float64
+}
+
+// SetObjectives scores targets on the given registered objectives in addition
+// to the built-in factors. An empty list scores on the built-in factors only.
+func (de *DecisionEngine) SetObjectives(goals []ObjectiveGoal) error {
+	if err := ValidateObjectiveGoals(goals); err != nil {
+		return err
+	}
+
+	objectiveRegistry.mu.RLock()
+	defer objectiveRegistry.mu.RUnlock()
+
+	objectives := make([]weightedObjective, 0, len(goals))
+	for _, goal := range goals {
+		metric, exists := objectiveRegistry.metrics[goal.Name]
+		if !exists {
+			return fmt.Errorf("objective %s is not registered", goal.Name)
+		}
+		objectives = append(objectives, weightedObjective{metric: metric, weight: goal.Weight})
+	}
+	de.objectives = objectives
+	return nil
+}
+
+// evaluateObjectives rates a target on the custom objectives
+func (de *DecisionEngine) evaluateObjectives(
+	process models.Process,
+	target models.OffloadTarget,
+	state models.SystemState,
+	components *ScoreBreakdown,
+) {
+	if len(de.objectives) == 0 {
+		return
+	}
+	components.Objectives = make(map[string]float64, len(de.objectives))
+	components.ObjectiveWeights = make(map[string]float64, len(de.objectives))
+	for _, objective := range de.objectives {
+		name := objective.metric.Name()
+		value := objective.metric.Evaluate(process, target, state)
+		if math.IsNaN(value) {
+			value = 0.0
+		}
+		components.Objectives[name] = math.Max(0.0, math.Min(1.0, value))
+		components.ObjectiveWeights[name] = objective.weight
+	}
+}
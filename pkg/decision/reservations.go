@@ -0,0 +1,295 @@
+package decision
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// ReservationConfig configures reserving target capacity for committed
+// offloads, so decisions made before a target reports its new load do not
+// double-book it
+type ReservationConfig struct {
+	Enabled bool          `json:"enabled"`
+	TTL     time.Duration `json:"ttl"` // Reservations whose outcome never arrives are released after this (0 = held until released)
+}
+
+// Validate checks the reservation configuration
+func (rc ReservationConfig) Validate() error {
+	if rc.TTL < 0 {
+		return fmt.Errorf("ttl must be non-negative")
+	}
+	return nil
+}
+
+// Reservation is the capacity a committed offload holds on one target
+type Reservation struct {
+	ProcessID string    `json:"process_id"`
+	TargetID  string    `json:"target_id"`
+	CPU       float64   `json:"cpu"`
+	Memory    int64     `json:"memory"` // Bytes
+	At        time.Time `json:"at"`
+	Settled   bool      `json:"settled"` // Reflected in capacity the target reported since
+}
+
+// ReservationStats counts the reservations made and how they ended
+type ReservationStats struct {
+	Reserved int64   `json:"reserved"` // Offloads that reserved capacity
+	Released int64   `json:"released"` // On completion, failure or a revoked offload
+	Expired  int64   `json:"expired"`
+	Active   int     `json:"active"` // Offloads holding reservations
+	CPU      float64 `json:"cpu"`    // Unsettled reserved cores across targets
+	Memory   int64   `json:"memory"` // Unsettled reserved bytes across targets
+}
+
+// ReservationLedger holds the capacity committed offloads reserve on their
+// targets until they complete. Targets are offered to decisions with their
+// unsettled reservations taken off their available capacity and memory, so
+// a nearly full target is not picked again before it reports the new load.
+// A reservation is settled once its target reports capacity measured after
+// it was made, and released when the offload completes, fails or is
+// revoked.
+type ReservationLedger struct {
+	config       ReservationConfig
+	reservations map[string][]Reservation // By process ID
+	stats        ReservationStats
+	mu           sync.Mutex
+}
+
+// NewReservationLedger creates an empty reservation ledger
+func NewReservationLedger(config ReservationConfig) *ReservationLedger {
+	return &ReservationLedger{
+		config:       config,
+		reservations: make(map[string][]Reservation),
+	}
+}
+
+// Reserve records the capacity an offload decision commits on each of its
+// targets: the whole process on a single target, each gang allocation's
+// members, or each shard. It replaces any reservations the process held.
+func (rl *ReservationLedger) Reserve(process models.Process, dec OffloadDecision, at time.Time) {
+	reservations := reservationsFor(process, dec, at)
+	if len(reservations) == 0 {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.reservations[process.ID] = reservations
+	rl.stats.Reserved++
+}
+
+// TryReserve reserves capacity like Reserve, but only if every target the
+// decision uses still has room for it once the reservations held now are
+// taken off. Decisions are made from targets with the reservations held when
+// they started taken off, so a concurrent decision may have reserved the
+// same capacity since. Targets are looked up by ID in offered, with capacity
+// as reported. It returns the ID of the first target without room, and
+// reserves nothing then.
+func (rl *ReservationLedger) TryReserve(process models.Process, dec OffloadDecision, offered []models.OffloadTarget, at time.Time) (string, bool) {
+	reservations := reservationsFor(process, dec, at)
+	if len(reservations) == 0 {
+		return "", true
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.expire(at)
+	cpu := make(map[string]float64, len(reservations))
+	memory := make(map[string]int64, len(reservations))
+	for _, reservation := range reservations {
+		cpu[reservation.TargetID] += reservation.CPU
+		memory[reservation.TargetID] += reservation.Memory
+	}
+	for processID, held := range rl.reservations {
+		if processID == process.ID {
+			continue // Replaced by this reservation
+		}
+		for _, reservation := range held {
+			if _, used := cpu[reservation.TargetID]; used && !reservation.Settled {
+				cpu[reservation.TargetID] += reservation.CPU
+				memory[reservation.TargetID] += reservation.Memory
+			}
+		}
+	}
+	for _, reservation := range reservations {
+		for i := range offered {
+			target := &offered[i]
+			if target.ID == reservation.TargetID &&
+				(cpu[target.ID] > target.AvailableCapacity || memory[target.ID] > target.MemoryAvailable) {
+				return target.ID, false
+			}
+		}
+	}
+
+	rl.reservations[process.ID] = reservations
+	rl.stats.Reserved++
+	return "", true
+}
+
+// reservationsFor returns the capacity an offload decision commits on each
+// of its targets, or none when it keeps the process local
+func reservationsFor(process models.Process, dec OffloadDecision, at time.Time) []Reservation {
+	if !dec.ShouldOffload {
+		return nil
+	}
+
+	reservations := make([]Reservation, 0, 1)
+	reserve := func(target *models.OffloadTarget, cpu float64, memory int64) {
+		if target != nil {
+			reservations = append(reservations, Reservation{ProcessID: process.ID, TargetID: target.ID, CPU: cpu, Memory: memory, At: at})
+		}
+	}
+	switch {
+	case len(dec.Gang) > 0:
+		for _, allocation := range dec.Gang {
+			reserve(allocation.Target, process.CPURequirement*float64(allocation.Slots), process.MemoryRequirement*int64(allocation.Slots))
+		}
+	case len(dec.Shards) > 0:
+		for _, shard := range dec.Shards {
+			reserve(shard.Target, shard.Process.CPURequirement, shard.Process.MemoryRequirement)
+		}
+	default:
+		reserve(dec.Target, process.CPURequirement, process.MemoryRequirement)
+	}
+	return reservations
+}
+
+// Release frees the capacity a process reserved, returning false if it held
+// none
+func (rl *ReservationLedger) Release(processID string) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if _, exists := rl.reservations[processID]; !exists {
+		return false
+	}
+	delete(rl.reservations, processID)
+	rl.stats.Released++
+	return true
+}
+
+// Settle marks the reservations made on a target before the given time as
+// reflected in the capacity it reported at that time, so they are no longer
+// taken off it. They are still held until released.
+func (rl *ReservationLedger) Settle(targetID string, at time.Time) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	for _, reservations := range rl.reservations {
+		for i := range reservations {
+			if reservations[i].TargetID == targetID && reservations[i].At.Before(at) {
+				reservations[i].Settled = true
+			}
+		}
+	}
+}
+
+// Reserved returns the unsettled cores and memory reserved on a target
+func (rl *ReservationLedger) Reserved(targetID string) (float64, int64) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	var cpu float64
+	var memory int64
+	for _, reservations := range rl.reservations {
+		for _, reservation := range reservations {
+			if reservation.TargetID == targetID && !reservation.Settled {
+				cpu += reservation.CPU
+				memory += reservation.Memory
+			}
+		}
+	}
+	return cpu, memory
+}
+
+// ApplyTargets returns copies of the targets with their unsettled
+// reservations taken off their available capacity and memory, and their
+// load raised to match. Reservations past their TTL are released first.
+func (rl *ReservationLedger) ApplyTargets(targets []models.OffloadTarget, now time.Time) []models.OffloadTarget {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.expire(now)
+	if len(rl.reservations) == 0 {
+		return targets
+	}
+	cpu := make(map[string]float64)
+	memory := make(map[string]int64)
+	for _, reservations := range rl.reservations {
+		for _, reservation := range reservations {
+			if !reservation.Settled {
+				cpu[reservation.TargetID] += reservation.CPU
+				memory[reservation.TargetID] += reservation.Memory
+			}
+		}
+	}
+
+	applied := make([]models.OffloadTarget, len(targets))
+	for i, target := range targets {
+		if reserved, exists := cpu[target.ID]; exists {
+			target.AvailableCapacity = math.Max(0, target.AvailableCapacity-reserved)
+			target.MemoryAvailable = max(0, target.MemoryAvailable-memory[target.ID])
+			if target.TotalCapacity > 0 {
+				target.CurrentLoad = math.Max(target.CurrentLoad, 1.0-target.AvailableCapacity/target.TotalCapacity)
+			}
+		}
+		applied[i] = target
+	}
+	return applied
+}
+
+// expire releases reservations held longer than the TTL
+func (rl *ReservationLedger) expire(now time.Time) {
+	if rl.config.TTL == 0 {
+		return
+	}
+	for processID, reservations := range rl.reservations {
+		if now.Sub(reservations[0].At) > rl.config.TTL {
+			delete(rl.reservations, processID)
+			rl.stats.Expired++
+		}
+	}
+}
+
+// Reservations returns the held reservations ordered by process and target
+func (rl *ReservationLedger) Reservations() []Reservation {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	held := make([]Reservation, 0, len(rl.reservations))
+	for _, reservations := range rl.reservations {
+		held = append(held, reservations...)
+	}
+	sort.Slice(held, func(i, j int) bool {
+		if held[i].ProcessID != held[j].ProcessID {
+			return held[i].ProcessID < held[j].ProcessID
+		}
+		return held[i].TargetID < held[j].TargetID
+	})
+	return held
+}
+
+// Stats returns the reservation counts and the capacity currently reserved
+func (rl *ReservationLedger) Stats() ReservationStats {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	stats := rl.stats
+	stats.Active = len(rl.reservations)
+	for _, reservations := range rl.reservations {
+		for _, reservation := range reservations {
+			if !reservation.Settled {
+				stats.CPU += reservation.CPU
+				stats.Memory += reservation.Memory
+			}
+		}
+	}
+	return stats
+}
@@ -0,0 +1,80 @@
+package decision
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// DefaultScoringConfig returns the default scoring configuration. Parallel
+// scoring only pays off once the per-goroutine overhead is amortized over
+// many candidates.
+func DefaultScoringConfig() ScoringConfig {
+	return ScoringConfig{
+		Parallel:  true,
+		Threshold: 256,
+		Workers:   0,
+		ChunkSize: 64,
+	}
+}
+
+// scoreTargetsParallel scores targets with a bounded pool of workers. Workers
+// claim chunks in heuristic order, so when the context expires the scored set
+// is still the most promising prefix. The first target is always scored.
+func (de *DecisionEngine) scoreTargetsParallel(
+	ctx context.Context,
+	process models.Process,
+	ordered []models.OffloadTarget,
+	state models.SystemState,
+	weights AdaptiveWeights,
+) (map[string]scoredTarget, bool) {
+	workers := de.scoringConfig.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunkSize := de.scoringConfig.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultScoringConfig().ChunkSize
+	}
+
+	results := make([]scoredTarget, len(ordered))
+	scored := make([]bool, len(ordered))
+	var next atomic.Int64
+	var exhausted atomic.Bool
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				start := int(next.Add(int64(chunkSize))) - chunkSize
+				if start >= len(ordered) {
+					return
+				}
+				end := min(start+chunkSize, len(ordered))
+				for i := start; i < end; i++ {
+					if i > 0 && BudgetExpired(ctx) {
+						exhausted.Store(true)
+						return
+					}
+					results[i] = de.scoreTarget(process, ordered[i], state, weights)
+					scored[i] = true
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	scores := make(map[string]scoredTarget, len(ordered))
+	for i, target := range ordered {
+		if scored[i] {
+			scores[target.ID] = results[i]
+		}
+	}
+
+	return scores, exhausted.Load()
+}
@@ -0,0 +1,210 @@
+package decision
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// DefaultSplitConfig returns the default partial offload configuration
+func DefaultSplitConfig() SplitConfig {
+	return SplitConfig{
+		Enabled:               true,
+		MaxShards:             4,
+		MinShardFraction:      0.1,
+		MergeOverheadPerShard: 500 * time.Millisecond,
+		MinImprovement:        0.1,
+	}
+}
+
+// planSplit divides a parallelizable process across the best scoring targets.
+// Shares are proportional to target processing speed so shards finish at
+// roughly the same time. Returns nil if splitting does not shorten the
+// makespan by at least MinImprovement compared to the best single target.
+func (de *DecisionEngine) planSplit(
+	process models.Process,
+	targets []models.OffloadTarget,
+	scores map[string]float64,
+	best *models.OffloadTarget,
+) ([]WorkloadShard, time.Duration) {
+	maxShards := de.splitConfig.MaxShards
+	if process.MaxShards > 0 && process.MaxShards < maxShards {
+		maxShards = process.MaxShards
+	}
+	if maxShards < 2 || best == nil {
+		return nil, 0
+	}
+
+	// Rank candidates by score
+	candidates := make([]models.OffloadTarget, 0, len(targets))
+	for _, target := range targets {
+		if scores[target.ID] >= 0.3 {
+			candidates = append(candidates, target)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].ID] > scores[candidates[j].ID]
+	})
+	if len(candidates) > maxShards {
+		candidates = candidates[:maxShards]
+	}
+
+	// Assign fractions, dropping targets that would receive too small a share
+	var fractions []float64
+	for len(candidates) >= 2 {
+		fractions = splitFractions(candidates)
+		smallest := 0
+		for i, f := range fractions {
+			if f < fractions[smallest] {
+				smallest = i
+			}
+		}
+		if fractions[smallest] >= de.splitConfig.MinShardFraction {
+			break
+		}
+		candidates = append(candidates[:smallest], candidates[smallest+1:]...)
+	}
+	if len(candidates) < 2 {
+		return nil, 0
+	}
+
+	now := de.now()
+	shards := make([]WorkloadShard, 0, len(candidates))
+	var makespan time.Duration
+	for i := range candidates {
+		target := candidates[i]
+		shardProcess := process.Shard(i, fractions[i])
+		if !target.CanAccommodate(shardProcess) {
+			return nil, 0
+		}
+
+		dataSize := shardProcess.GetDataSize()
+
+		shard := WorkloadShard{
+			Index:         i,
+			Target:        &target,
+			Fraction:      fractions[i],
+			Process:       shardProcess,
+			EstimatedTime: de.estimateExecutionTime(shardProcess, target, now),
+			TransferTime:  de.transferTime(target, dataSize, now),
+			TransferCost:  target.TransferCost(units.DataSize(dataSize)),
+		}
+		if shard.EstimatedTime > makespan {
+			makespan = shard.EstimatedTime
+		}
+		shards = append(shards, shard)
+	}
+
+	mergeCost := time.Duration(len(shards)) * de.splitConfig.MergeOverheadPerShard
+	makespan += mergeCost
+
+	singleTime := best.EstimateExecutionTime(process)
+	if float64(makespan) > float64(singleTime)*(1.0-de.splitConfig.MinImprovement) {
+		return nil, 0
+	}
+
+	return shards, mergeCost
+}
+
+// splitFractions returns workload shares proportional to processing speed
+func splitFractions(targets []models.OffloadTarget) []float64 {
+	fractions := make([]float64, len(targets))
+	total := 0.0
+	for i, target := range targets {
+		speed := target.ProcessingSpeed
+		if speed <= 0 {
+			speed = 1.0
+		}
+		fractions[i] = speed
+		total += speed
+	}
+	for i := range fractions {
+		fractions[i] /= total
+	}
+	return fractions
+}
+
+// applySplit turns an offload decision into a split decision when beneficial
+func (de *DecisionEngine) applySplit(
+	decision *OffloadDecision,
+	process models.Process,
+	targets []models.OffloadTarget,
+	scores map[string]float64,
+) {
+	shards, mergeCost := de.planSplit(process, targets, scores, decision.Target)
+	if len(shards) == 0 {
+		return
+	}
+
+	var makespan time.Duration
+	estimatedCost := 0.0
+	for _, shard := range shards {
+		if shard.EstimatedTime > makespan {
+			makespan = shard.EstimatedTime
+		}
+		estimatedCost += shard.Target.GetTotalCost(shard.Process) + de.retrievalCost(shard.Process)
+	}
+	makespan += mergeCost
+
+	decision.Shards = shards
+	decision.MergeCost = mergeCost
+	decision.Strategy = SPLIT
+	decision.EstimatedCost = estimatedCost
+	if process.EstimatedDuration > 0 {
+		timeSavings := float64(process.EstimatedDuration - makespan)
+		decision.ExpectedBenefit = math.Max(0, timeSavings/float64(process.EstimatedDuration))
+	}
+}
+
+// AggregateShardOutcomes combines the outcomes of a split workload into a
+// single outcome for learning. Latency and attribution are taken from the
+// slowest shard since it determines when the merged result is available.
+func AggregateShardOutcomes(shardOutcomes []OffloadOutcome) OffloadOutcome {
+	if len(shardOutcomes) == 0 {
+		return OffloadOutcome{}
+	}
+
+	slowest := 0
+	for i, outcome := range shardOutcomes {
+		if outcome.ExecutionTime > shardOutcomes[slowest].ExecutionTime {
+			slowest = i
+		}
+	}
+
+	aggregate := shardOutcomes[slowest]
+	aggregate.Success = true
+	aggregate.CompletedOnTime = true
+	aggregate.NetworkCostActual = 0
+	aggregate.EnergyConsumed = 0
+	aggregate.CostActual = 0
+	aggregate.CostSavings = 0
+	aggregate.ViolationType = nil
+
+	for _, outcome := range shardOutcomes {
+		aggregate.Success = aggregate.Success && outcome.Success
+		aggregate.CompletedOnTime = aggregate.CompletedOnTime && outcome.CompletedOnTime
+		aggregate.NetworkCongestion = aggregate.NetworkCongestion || outcome.NetworkCongestion
+		aggregate.TargetOverloaded = aggregate.TargetOverloaded || outcome.TargetOverloaded
+		aggregate.PolicyViolation = aggregate.PolicyViolation || outcome.PolicyViolation
+		aggregate.ViolationType = append(aggregate.ViolationType, outcome.ViolationType...)
+		aggregate.NetworkCostActual += outcome.NetworkCostActual
+		aggregate.EnergyConsumed += outcome.EnergyConsumed
+		aggregate.CostActual += outcome.CostActual
+		aggregate.CostSavings += outcome.CostSavings
+
+		if outcome.StartTime.Before(aggregate.StartTime) {
+			aggregate.StartTime = outcome.StartTime
+		}
+		if outcome.EndTime.After(aggregate.EndTime) {
+			aggregate.EndTime = outcome.EndTime
+		}
+		if !outcome.Success && aggregate.ErrorType == "" {
+			aggregate.ErrorType = outcome.ErrorType
+		}
+	}
+
+	return aggregate
+}
@@ -0,0 +1,272 @@
+package decision
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// TargetQuery selects targets from a registry. Empty fields match any target;
+// multiple values within a field are alternatives, except Capabilities, which
+// must all be present.
+type TargetQuery struct {
+	Types         []models.TargetType `json:"types"`
+	Capabilities  []string            `json:"capabilities"`
+	Jurisdictions []string            `json:"jurisdictions"`
+	Regions       []string            `json:"regions"` // Matched against target Location
+}
+
+// targetSet is a set of target IDs
+type targetSet map[string]struct{}
+
+// TargetRegistry indexes targets by type, capability, jurisdiction and region
+// so large fleets can be narrowed to a candidate set without a linear scan
+type TargetRegistry struct {
+	targets        map[string]models.OffloadTarget
+	byType         map[models.TargetType]targetSet
+	byCapability   map[string]targetSet
+	byJurisdiction map[string]targetSet
+	byRegion       map[string]targetSet
+	jurisdictions  *models.JurisdictionGraph // Permitted data transfers (nil = unrestricted)
+	mu             sync.RWMutex
+}
+
+// NewTargetRegistry creates an empty target registry
+func NewTargetRegistry() *TargetRegistry {
+	return &TargetRegistry{
+		targets:        make(map[string]models.OffloadTarget),
+		byType:         make(map[models.TargetType]targetSet),
+		byCapability:   make(map[string]targetSet),
+		byJurisdiction: make(map[string]targetSet),
+		byRegion:       make(map[string]targetSet),
+	}
+}
+
+// SetJurisdictionGraph restricts process candidates to the targets their
+// data may be transferred to
+func (tr *TargetRegistry) SetJurisdictionGraph(graph *models.JurisdictionGraph) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.jurisdictions = graph
+}
+
+// Upsert adds a target or replaces the existing target with the same ID
+func (tr *TargetRegistry) Upsert(target models.OffloadTarget) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if existing, exists := tr.targets[target.ID]; exists {
+		tr.unindex(existing)
+	}
+	tr.targets[target.ID] = target
+	tr.index(target)
+}
+
+// Remove removes a target from the registry
+func (tr *TargetRegistry) Remove(targetID string) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if existing, exists := tr.targets[targetID]; exists {
+		tr.unindex(existing)
+		delete(tr.targets, targetID)
+	}
+}
+
+// Apply upserts and removes targets in one step, so queries never see part
+// of the change
+func (tr *TargetRegistry) Apply(upserts []models.OffloadTarget, removals []string) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	for _, targetID := range removals {
+		if existing, exists := tr.targets[targetID]; exists {
+			tr.unindex(existing)
+			delete(tr.targets, targetID)
+		}
+	}
+	for _, target := range upserts {
+		if existing, exists := tr.targets[target.ID]; exists {
+			tr.unindex(existing)
+		}
+		tr.targets[target.ID] = target
+		tr.index(target)
+	}
+}
+
+// Get returns the target with the given ID
+func (tr *TargetRegistry) Get(targetID string) (models.OffloadTarget, bool) {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	target, exists := tr.targets[targetID]
+	return target, exists
+}
+
+// Len returns the number of registered targets
+func (tr *TargetRegistry) Len() int {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	return len(tr.targets)
+}
+
+// All returns all registered targets ordered by ID
+func (tr *TargetRegistry) All() []models.OffloadTarget {
+	return tr.Query(TargetQuery{})
+}
+
+// Query returns the targets matching the query ordered by ID
+func (tr *TargetRegistry) Query(query TargetQuery) []models.OffloadTarget {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	// Each constraint yields a set of matching IDs; the result is their intersection
+	sets := make([]targetSet, 0, 4)
+	if len(query.Types) > 0 {
+		sets = append(sets, unionOf(tr.byType, query.Types))
+	}
+	for _, capability := range query.Capabilities {
+		sets = append(sets, tr.byCapability[capability])
+	}
+	if len(query.Jurisdictions) > 0 {
+		sets = append(sets, unionOf(tr.byJurisdiction, query.Jurisdictions))
+	}
+	if len(query.Regions) > 0 {
+		sets = append(sets, unionOf(tr.byRegion, query.Regions))
+	}
+
+	ids := make([]string, 0)
+	if len(sets) == 0 {
+		for id := range tr.targets {
+			ids = append(ids, id)
+		}
+	} else {
+		// Iterate the smallest set and probe the others
+		sort.Slice(sets, func(i, j int) bool { return len(sets[i]) < len(sets[j]) })
+		for id := range sets[0] {
+			matches := true
+			for _, set := range sets[1:] {
+				if _, ok := set[id]; !ok {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	sort.Strings(ids)
+	targets := make([]models.OffloadTarget, 0, len(ids))
+	for _, id := range ids {
+		targets = append(targets, tr.targets[id])
+	}
+	return targets
+}
+
+// CandidatesFor returns the targets that can satisfy the process's hard
+// placement constraints: its target types, the capabilities its requirements
+// AND together, and, with a jurisdiction graph set, the jurisdictions its
+// data may be transferred to
+func (tr *TargetRegistry) CandidatesFor(process models.Process) []models.OffloadTarget {
+	query := QueryForProcess(process)
+	if jurisdictions, restricted := tr.permittedJurisdictions(process); restricted {
+		if len(jurisdictions) == 0 {
+			return []models.OffloadTarget{}
+		}
+		query.Jurisdictions = jurisdictions
+	}
+	return tr.Query(query)
+}
+
+// permittedJurisdictions returns the indexed jurisdictions the process's data
+// may be transferred to, and whether its data is restricted at all
+func (tr *TargetRegistry) permittedJurisdictions(process models.Process) ([]string, bool) {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	if tr.jurisdictions == nil || len(process.DataResidency) == 0 {
+		return nil, false
+	}
+	known := make([]string, 0, len(tr.byJurisdiction))
+	for jurisdiction := range tr.byJurisdiction {
+		known = append(known, jurisdiction)
+	}
+	sort.Strings(known)
+	return tr.jurisdictions.PermittedJurisdictions(process, known), true
+}
+
+// QueryForProcess derives a registry query from a process's placement
+// constraints: its target types and the capabilities its requirements AND
+// together. Requirements that do not parse add no capabilities; the
+// decision engine rejects every target for them.
+func QueryForProcess(process models.Process) TargetQuery {
+	query := TargetQuery{}
+	switch {
+	case process.SafetyCritical:
+		query.Types = []models.TargetType{models.LOCAL}
+	case process.LocalityRequired:
+		query.Types = []models.TargetType{models.LOCAL, models.EDGE}
+	}
+	if requirement, err := models.ParseRequirements(process.Requirements); err == nil {
+		query.Capabilities = models.RequiredCapabilities(requirement)
+	}
+	return query
+}
+
+// index adds a target to the secondary indexes
+func (tr *TargetRegistry) index(target models.OffloadTarget) {
+	addTo(tr.byType, target.Type, target.ID)
+	for _, capability := range target.Capabilities {
+		addTo(tr.byCapability, capability, target.ID)
+	}
+	if target.DataJurisdiction != "" {
+		addTo(tr.byJurisdiction, target.DataJurisdiction, target.ID)
+	}
+	if target.Location != "" {
+		addTo(tr.byRegion, target.Location, target.ID)
+	}
+}
+
+// unindex removes a target from the secondary indexes
+func (tr *TargetRegistry) unindex(target models.OffloadTarget) {
+	removeFrom(tr.byType, target.Type, target.ID)
+	for _, capability := range target.Capabilities {
+		removeFrom(tr.byCapability, capability, target.ID)
+	}
+	removeFrom(tr.byJurisdiction, target.DataJurisdiction, target.ID)
+	removeFrom(tr.byRegion, target.Location, target.ID)
+}
+
+func addTo[K comparable](index map[K]targetSet, key K, id string) {
+	if index[key] == nil {
+		index[key] = make(targetSet)
+	}
+	index[key][id] = struct{}{}
+}
+
+func removeFrom[K comparable](index map[K]targetSet, key K, id string) {
+	if set, exists := index[key]; exists {
+		delete(set, id)
+		if len(set) == 0 {
+			delete(index, key)
+		}
+	}
+}
+
+func unionOf[K comparable](index map[K]targetSet, keys []K) targetSet {
+	if len(keys) == 1 {
+		return index[keys[0]]
+	}
+	union := make(targetSet)
+	for _, key := range keys {
+		for id := range index[key] {
+			union[id] = struct{}{}
+		}
+	}
+	return union
+}
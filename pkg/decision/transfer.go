@@ -0,0 +1,362 @@
+package decision
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// minLinkShare is the smallest fraction of a link's capacity left to
+// transfers however congested the hour, so estimates stay finite
+const minLinkShare = 0.01
+
+// LinkConfig describes a network link shared by transfers to its targets
+type LinkConfig struct {
+	ID            string    `json:"id"`
+	Capacity      float64   `json:"capacity"`       // Bytes/sec
+	MaxConcurrent int       `json:"max_concurrent"` // Transfers beyond this queue (0 = unlimited)
+	Targets       []string  `json:"targets"`        // Target IDs reached over this link
+	HourlyLoad    []float64 `json:"hourly_load"`    // Background utilization by hour of day (empty = TransferConfig.HourlyLoad)
+}
+
+// TransferConfig configures transfer time estimation. Targets not on a
+// configured link get a private link with their own NetworkBandwidth.
+type TransferConfig struct {
+	Enabled    bool         `json:"enabled"`
+	Links      []LinkConfig `json:"links"`
+	HourlyLoad []float64    `json:"hourly_load"` // Default background utilization by hour of day (empty = none)
+
+	// Retrieval characteristics overriding models.DefaultStorageTiers. They
+	// apply whether or not the link model is enabled.
+	StorageTiers map[models.StorageTier]models.StorageTierSpec `json:"storage_tiers"`
+}
+
+// Validate checks the transfer configuration
+func (tc TransferConfig) Validate() error {
+	if err := validateHourlyLoad(tc.HourlyLoad); err != nil {
+		return fmt.Errorf("hourly_load: %w", err)
+	}
+	for tier, spec := range tc.StorageTiers {
+		if !tier.IsValid() {
+			return fmt.Errorf("storage_tiers: unknown tier %q", tier)
+		}
+		if err := spec.Validate(); err != nil {
+			return fmt.Errorf("storage_tiers.%s: %w", tier, err)
+		}
+	}
+	seen := make(map[string]string)
+	for i, lc := range tc.Links {
+		switch {
+		case lc.ID == "":
+			return fmt.Errorf("links[%d]: id cannot be empty", i)
+		case lc.Capacity <= 0:
+			return fmt.Errorf("links[%d]: capacity must be positive", i)
+		case lc.MaxConcurrent < 0:
+			return fmt.Errorf("links[%d]: max_concurrent must be non-negative", i)
+		}
+		if err := validateHourlyLoad(lc.HourlyLoad); err != nil {
+			return fmt.Errorf("links[%d].hourly_load: %w", i, err)
+		}
+		for _, targetID := range lc.Targets {
+			if other, exists := seen[targetID]; exists {
+				return fmt.Errorf("links[%d]: target %s is already on link %s", i, targetID, other)
+			}
+			seen[targetID] = lc.ID
+		}
+	}
+	return nil
+}
+
+func validateHourlyLoad(load []float64) error {
+	if len(load) != 0 && len(load) != 24 {
+		return fmt.Errorf("must have 24 entries, got %d", len(load))
+	}
+	for hour, utilization := range load {
+		if utilization < 0 || utilization > 1 {
+			return fmt.Errorf("hour %d: utilization must be between 0 and 1", hour)
+		}
+	}
+	return nil
+}
+
+// transfer is an in-flight or queued transfer on a link
+type transfer struct {
+	processID string
+	remaining float64 // Bytes
+}
+
+// link is the transfer state of one network link
+type link struct {
+	config   LinkConfig
+	active   []*transfer    // Sharing the capacity equally
+	queued   []*transfer    // Waiting for a free slot, first in first out
+	updated  time.Time      // Time progress was last applied
+	location *time.Location // Time zone of the hourly load (nil = the time's own)
+}
+
+// TransferEstimator estimates transfer times on links shared by concurrent
+// transfers. Transfers progress as a fluid: active transfers share the
+// link's capacity left over by its hourly background load equally, and
+// transfers beyond the link's concurrency limit wait in a queue.
+type TransferEstimator struct {
+	config    TransferConfig
+	links     map[string]*link  // By link ID
+	linkOf    map[string]string // Target ID -> configured link ID
+	byProcess map[string]string // Process ID -> link ID of its transfer
+	location  *time.Location    // Time zone of the hourly load (nil = the time's own)
+	mu        sync.Mutex
+}
+
+// NewTransferEstimator creates a transfer estimator for the configured links
+func NewTransferEstimator(config TransferConfig) (*TransferEstimator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	te := &TransferEstimator{
+		config:    config,
+		links:     make(map[string]*link),
+		linkOf:    make(map[string]string),
+		byProcess: make(map[string]string),
+	}
+	for _, linkConfig := range config.Links {
+		if len(linkConfig.HourlyLoad) == 0 {
+			linkConfig.HourlyLoad = config.HourlyLoad
+		}
+		te.links[linkConfig.ID] = &link{config: linkConfig}
+		for _, targetID := range linkConfig.Targets {
+			te.linkOf[targetID] = linkConfig.ID
+		}
+	}
+	return te, nil
+}
+
+// SetLocation sets the time zone the hours of the hourly load are read in,
+// such as the deployment region's
+func (te *TransferEstimator) SetLocation(location *time.Location) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.location = location
+	for _, l := range te.links {
+		l.location = location
+	}
+}
+
+// Estimate returns how long transferring the given bytes to a target
+// starting at the given time would take, given the transfers already on its
+// link. It returns 0 when the target's bandwidth is unknown.
+func (te *TransferEstimator) Estimate(target models.OffloadTarget, bytes int64, at time.Time) time.Duration {
+	if bytes <= 0 {
+		return 0
+	}
+
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	l := te.linkFor(target)
+	if l == nil {
+		return 0
+	}
+	l.advance(at)
+
+	// Simulate a copy of the link until the new transfer completes
+	probe := &transfer{remaining: float64(bytes)}
+	sim := l.clone()
+	sim.add(probe)
+	elapsed := sim.runUntil(probe)
+	return time.Duration(elapsed * float64(time.Second))
+}
+
+// Start records a transfer to a target so later estimates account for it.
+// A process has at most one transfer; starting another replaces it.
+func (te *TransferEstimator) Start(processID string, target models.OffloadTarget, bytes int64, at time.Time) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	te.complete(processID)
+	l := te.linkFor(target)
+	if l == nil || bytes <= 0 {
+		return
+	}
+	l.advance(at)
+	l.add(&transfer{processID: processID, remaining: float64(bytes)})
+	te.byProcess[processID] = l.config.ID
+}
+
+// Complete removes a process's transfer, if it has not already finished
+func (te *TransferEstimator) Complete(processID string) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	te.complete(processID)
+}
+
+// InFlight returns the number of active and queued transfers on a target's
+// link at the given time
+func (te *TransferEstimator) InFlight(target models.OffloadTarget, at time.Time) (active, queued int) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	l := te.linkFor(target)
+	if l == nil {
+		return 0, 0
+	}
+	l.advance(at)
+	return len(l.active), len(l.queued)
+}
+
+// complete removes a process's transfer from its link
+func (te *TransferEstimator) complete(processID string) {
+	linkID, exists := te.byProcess[processID]
+	if !exists {
+		return
+	}
+	delete(te.byProcess, processID)
+	if l, exists := te.links[linkID]; exists {
+		l.remove(processID)
+	}
+}
+
+// linkFor returns the link a target is reached over, creating a private link
+// from the target's bandwidth if it is not on a configured one
+func (te *TransferEstimator) linkFor(target models.OffloadTarget) *link {
+	if linkID, exists := te.linkOf[target.ID]; exists {
+		return te.links[linkID]
+	}
+	if target.NetworkBandwidth <= 0 {
+		return nil
+	}
+	linkID := "target/" + target.ID
+	l, exists := te.links[linkID]
+	if !exists {
+		l = &link{config: LinkConfig{ID: linkID, HourlyLoad: te.config.HourlyLoad}, location: te.location}
+		te.links[linkID] = l
+	}
+	l.config.Capacity = target.NetworkBandwidth // Follows probed bandwidth
+	return l
+}
+
+// capacity returns the bytes/sec left to transfers at the given time
+func (l *link) capacity(at time.Time) float64 {
+	share := 1.0
+	if len(l.config.HourlyLoad) == 24 {
+		if l.location != nil {
+			at = at.In(l.location)
+		}
+		share = math.Max(minLinkShare, 1-l.config.HourlyLoad[at.Hour()])
+	}
+	return l.config.Capacity * share
+}
+
+// add starts a transfer, or queues it when the link is at its limit
+func (l *link) add(t *transfer) {
+	if l.config.MaxConcurrent > 0 && len(l.active) >= l.config.MaxConcurrent {
+		l.queued = append(l.queued, t)
+		return
+	}
+	l.active = append(l.active, t)
+}
+
+// remove drops a process's transfer and admits queued transfers
+func (l *link) remove(processID string) {
+	for i, t := range l.active {
+		if t.processID == processID {
+			l.active = append(l.active[:i], l.active[i+1:]...)
+			l.admit()
+			return
+		}
+	}
+	for i, t := range l.queued {
+		if t.processID == processID {
+			l.queued = append(l.queued[:i], l.queued[i+1:]...)
+			return
+		}
+	}
+}
+
+// admit moves queued transfers into free slots
+func (l *link) admit() {
+	for len(l.queued) > 0 && (l.config.MaxConcurrent == 0 || len(l.active) < l.config.MaxConcurrent) {
+		l.active = append(l.active, l.queued[0])
+		l.queued = l.queued[1:]
+	}
+}
+
+// advance applies transfer progress up to the given time
+func (l *link) advance(to time.Time) {
+	if !l.updated.IsZero() && to.After(l.updated) {
+		l.step(to.Sub(l.updated).Seconds(), nil)
+	}
+	if l.updated.IsZero() || to.After(l.updated) {
+		l.updated = to // Idle time, or rounding in step
+	}
+}
+
+// runUntil advances the link until the given transfer completes, returning
+// the seconds taken
+func (l *link) runUntil(target *transfer) float64 {
+	return l.step(math.Inf(1), target)
+}
+
+// step progresses active transfers for up to the given seconds, stopping
+// early once the target transfer (if any) completes. Capacity is
+// re-evaluated at every completion and hour boundary.
+func (l *link) step(seconds float64, target *transfer) float64 {
+	elapsed := 0.0
+	for elapsed < seconds && len(l.active) > 0 {
+		capacity := l.capacity(l.updated)
+		if capacity <= 0 {
+			break
+		}
+		rate := capacity / float64(len(l.active))
+
+		next := math.Inf(1)
+		for _, t := range l.active {
+			next = math.Min(next, t.remaining/rate)
+		}
+		hour := l.updated.Truncate(time.Hour).Add(time.Hour)
+		next = math.Min(next, math.Max(hour.Sub(l.updated).Seconds(), 1e-9))
+		next = math.Min(next, seconds-elapsed)
+
+		done := false
+		remaining := l.active[:0]
+		for _, t := range l.active {
+			t.remaining -= rate * next
+			if t.remaining > 1e-6 {
+				remaining = append(remaining, t)
+			} else if t == target {
+				done = true
+			}
+		}
+		l.active = remaining
+		l.admit()
+		elapsed += next
+		l.updated = l.updated.Add(time.Duration(next * float64(time.Second)))
+		if done {
+			break
+		}
+	}
+	return elapsed
+}
+
+// clone copies the link state for simulation
+func (l *link) clone() *link {
+	copyOf := func(transfers []*transfer) []*transfer {
+		copied := make([]*transfer, len(transfers))
+		for i, t := range transfers {
+			c := *t
+			copied[i] = &c
+		}
+		return copied
+	}
+	return &link{
+		config:   l.config,
+		active:   copyOf(l.active),
+		queued:   copyOf(l.queued),
+		updated:  l.updated,
+		location: l.location,
+	}
+}
@@ -1,9 +1,13 @@
 package decision
 
 import (
+	"encoding/json"
+	"math"
+	"sort"
 	"time"
 
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
 )
 
 // AdaptiveWeights represents the adaptive weights for scoring
@@ -57,6 +61,7 @@ type OffloadDecision struct {
 	// Decision reasoning
 	Score           float64              `json:"score"`
 	ScoreComponents ScoreBreakdown       `json:"score_components"`
+	Attribution     map[string]float64   `json:"attribution,omitempty"` // Shapley value of each weight factor relative to the average candidate
 	AppliedPattern  *DiscoveredPattern   `json:"applied_pattern"`
 	PolicyViolations []string            `json:"policy_violations"`
 	
@@ -64,11 +69,72 @@ type OffloadDecision struct {
 	Strategy        ExecutionStrategy    `json:"strategy"`
 	ExpectedBenefit float64              `json:"expected_benefit"`
 	EstimatedCost   float64              `json:"estimated_cost"`
+	EstimatedEnergy units.Energy         `json:"estimated_energy"` // Watt-hours drawn at the target's site (0 without a power profile)
+	DataSize        int64                `json:"data_size"`        // Bytes moved to the target
+	TransferTime    time.Duration        `json:"transfer_time"`    // Estimated time to move the process's data
+	RetrievalTime   time.Duration        `json:"retrieval_time"`   // Estimated time to read the input from its storage tier
+	EncryptionTime  time.Duration        `json:"encryption_time"`  // Estimated time to encrypt the process's data in transit
+	Shards          []WorkloadShard      `json:"shards,omitempty"` // Set when the workload is split across targets
+	MergeCost       time.Duration        `json:"merge_cost"`       // Time to merge shard results
+	Gang            []GangAllocation     `json:"gang,omitempty"`   // Set when a gang is placed; covers every member
+	CachedResult    json.RawMessage      `json:"cached_result,omitempty"` // Result of an identical earlier call; set with the cached strategy, and the process need not run
 	
 	// Metadata
+	DecisionID      string               `json:"decision_id"`
 	DecisionTime    time.Time            `json:"decision_time"`
 	DecisionLatency time.Duration        `json:"decision_latency"`
+	TargetsEvaluated int                 `json:"targets_evaluated"` // Targets scored before selection
+	BudgetExhausted bool                 `json:"budget_exhausted"`  // Latency budget expired before all targets were scored
+	Phases          PhaseTimings         `json:"phases"`
 	AlgorithmVersion string              `json:"algorithm_version"`
+	CatalogVersion  string               `json:"catalog_version,omitempty"` // Executor catalog version the decision was made against
+	Candidates      []CandidateExplanation `json:"-"`                       // How each target given to the engine was evaluated, for explanations
+}
+
+// withPhases returns the decision with its phase timings set
+func (od OffloadDecision) withPhases(phases PhaseTimings) OffloadDecision {
+	od.Phases = phases
+	return od
+}
+
+// withCandidates returns the decision with its candidate explanations set
+func (od OffloadDecision) withCandidates(candidates []CandidateExplanation) OffloadDecision {
+	od.Candidates = candidates
+	return od
+}
+
+// PhaseTimings breaks decision latency down by phase
+type PhaseTimings struct {
+	StateSnapshot     time.Duration `json:"state_snapshot"`     // Input validation and metric smoothing
+	FeasibilityFilter time.Duration `json:"feasibility_filter"` // Safety checks and target filtering
+	PolicyEvaluation  time.Duration `json:"policy_evaluation"`  // Policy rule evaluation
+	Scoring           time.Duration `json:"scoring"`            // Target scoring and selection
+	LearnerUpdate     time.Duration `json:"learner_update"`     // Applying learned patterns
+	Explanation       time.Duration `json:"explanation"`        // Building the decision explanation
+}
+
+// Add returns the phase-wise sum of two timings
+func (pt PhaseTimings) Add(other PhaseTimings) PhaseTimings {
+	return PhaseTimings{
+		StateSnapshot:     pt.StateSnapshot + other.StateSnapshot,
+		FeasibilityFilter: pt.FeasibilityFilter + other.FeasibilityFilter,
+		PolicyEvaluation:  pt.PolicyEvaluation + other.PolicyEvaluation,
+		Scoring:           pt.Scoring + other.Scoring,
+		LearnerUpdate:     pt.LearnerUpdate + other.LearnerUpdate,
+		Explanation:       pt.Explanation + other.Explanation,
+	}
+}
+
+// ByPhase returns the timings keyed by phase name
+func (pt PhaseTimings) ByPhase() map[string]time.Duration {
+	return map[string]time.Duration{
+		"state_snapshot":     pt.StateSnapshot,
+		"feasibility_filter": pt.FeasibilityFilter,
+		"policy_evaluation":  pt.PolicyEvaluation,
+		"scoring":            pt.Scoring,
+		"learner_update":     pt.LearnerUpdate,
+		"explanation":        pt.Explanation,
+	}
 }
 
 // ScoreBreakdown provides transparency into decision factors
@@ -80,6 +146,103 @@ type ScoreBreakdown struct {
 	EnergyImpact  float64         `json:"energy_impact"`
 	PolicyMatch   float64         `json:"policy_match"`
 	WeightsUsed   AdaptiveWeights `json:"weights_used"`
+
+	// Custom objective values and their shares of the score, by objective name
+	Objectives       map[string]float64 `json:"objectives,omitempty"`
+	ObjectiveWeights map[string]float64 `json:"objective_weights,omitempty"`
+}
+
+// GangAllocation is the number of gang members placed on one target
+type GangAllocation struct {
+	Target *models.OffloadTarget `json:"target"`
+	Slots  int                   `json:"slots"`
+}
+
+// WorkloadShard describes one portion of a split (partially offloaded) workload
+type WorkloadShard struct {
+	Index         int                   `json:"index"`
+	Target        *models.OffloadTarget `json:"target"`
+	Fraction      float64               `json:"fraction"`       // Share of the workload (0.0-1.0)
+	Process       models.Process        `json:"process"`        // Shard-sized view of the process
+	EstimatedTime time.Duration         `json:"estimated_time"` // Including transfer time
+	TransferTime  time.Duration         `json:"transfer_time"`
+	TransferCost  float64               `json:"transfer_cost"`
+}
+
+// SplitConfig controls partial offloading of parallelizable workloads
+type SplitConfig struct {
+	Enabled               bool          `json:"enabled"`
+	MaxShards             int           `json:"max_shards"`
+	MinShardFraction      float64       `json:"min_shard_fraction"`       // Smallest share a target may receive
+	MergeOverheadPerShard time.Duration `json:"merge_overhead_per_shard"` // Fixed merge cost per shard
+	MinImprovement        float64       `json:"min_improvement"`          // Required makespan gain over single target
+}
+
+// ScoringConfig controls parallel scoring of large candidate sets
+type ScoringConfig struct {
+	Parallel  bool `json:"parallel"`
+	Threshold int  `json:"threshold"`  // Minimum candidates before scoring in parallel
+	Workers   int  `json:"workers"`    // Scoring goroutines (0 = GOMAXPROCS)
+	ChunkSize int  `json:"chunk_size"` // Targets a worker claims at a time
+}
+
+// WeightedScore returns the weighted sum of the components, clamped to [0.0, 1.0].
+// Components are summed in a fixed order, so equal breakdowns score equally.
+func (sb ScoreBreakdown) WeightedScore() float64 {
+	w := sb.WeightsUsed
+	total := sb.builtinShare() * (w.QueueDepth*sb.QueueImpact +
+		w.ProcessorLoad*sb.LoadBalance +
+		w.NetworkCost*sb.NetworkCost +
+		w.LatencyCost*sb.LatencyImpact +
+		w.EnergyCost*sb.EnergyImpact +
+		w.PolicyCost*sb.PolicyMatch)
+	for _, name := range sortedNames(sb.Objectives) {
+		total += sb.ObjectiveWeights[name] * sb.Objectives[name]
+	}
+	return math.Max(0.0, math.Min(1.0, total))
+}
+
+// Contributions returns each weighted component's contribution to the score,
+// keyed by weight name, and each custom objective's keyed by its name
+func (sb ScoreBreakdown) Contributions() map[string]float64 {
+	w := sb.WeightsUsed
+	share := sb.builtinShare()
+	contributions := map[string]float64{
+		"QueueDepth":    share * w.QueueDepth * sb.QueueImpact,
+		"ProcessorLoad": share * w.ProcessorLoad * sb.LoadBalance,
+		"NetworkCost":   share * w.NetworkCost * sb.NetworkCost,
+		"LatencyCost":   share * w.LatencyCost * sb.LatencyImpact,
+		"EnergyCost":    share * w.EnergyCost * sb.EnergyImpact,
+		"PolicyCost":    share * w.PolicyCost * sb.PolicyMatch,
+	}
+	for name, value := range sb.Objectives {
+		contributions[name] = sb.ObjectiveWeights[name] * value
+	}
+	return contributions
+}
+
+// builtinShare returns the share of the score left to the built-in factors
+// by custom objectives
+func (sb ScoreBreakdown) builtinShare() float64 {
+	share := 1.0
+	for _, name := range sortedNames(sb.ObjectiveWeights) {
+		share -= sb.ObjectiveWeights[name]
+	}
+	return share
+}
+
+// sortedNames returns the keys of a map of objective values in order, so
+// sums over them do not depend on map iteration order
+func sortedNames(values map[string]float64) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // ExecutionStrategy defines how to execute the offload
@@ -90,6 +253,9 @@ const (
 	DELAYED      ExecutionStrategy = "delayed"
 	BATCHED      ExecutionStrategy = "batched"
 	PIPELINED    ExecutionStrategy = "pipelined"
+	SPLIT        ExecutionStrategy = "split"
+	GANG         ExecutionStrategy = "gang"
+	CACHED       ExecutionStrategy = "cached"
 )
 
 // DiscoveredPattern represents learned behavioral patterns
@@ -156,4 +322,5 @@ type OffloadOutcome struct {
 	MeasurementTime    time.Time              `json:"measurement_time"`
 	Reward             float64                `json:"reward"`
 	Attribution        map[string]float64     `json:"attribution"`
+	Result             json.RawMessage        `json:"result,omitempty"` // Function result, cached for idempotent calls
 }
\ No newline at end of file
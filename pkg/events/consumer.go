@@ -0,0 +1,141 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+)
+
+// Consumer reads outcomes observed by other systems from the consumer topic
+// as a member of the consumer group, whose offsets the proxy commits. Record
+// values are outcomes, or outcome events as published by a Producer. It is
+// not safe for concurrent use.
+type Consumer struct {
+	config   Config
+	client   *restClient
+	instance string // Path of the consumer instance, empty until subscribed
+}
+
+// NewConsumer creates a consumer for the configured topic and group
+func NewConsumer(config Config) (*Consumer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config = config.withDefaults()
+	return &Consumer{config: config, client: newRESTClient(config)}, nil
+}
+
+// subscribe creates the consumer instance and subscribes it to the topic.
+// New groups start from the earliest offset, so no outcome published before
+// CAPE first joined is missed.
+func (c *Consumer) subscribe(ctx context.Context) error {
+	group := "/consumers/" + url.PathEscape(c.config.Consumer.Group)
+	request := map[string]string{
+		"format":             "json",
+		"auto.offset.reset":  "earliest",
+		"auto.commit.enable": "true",
+	}
+	if c.config.Consumer.Name != "" {
+		request["name"] = c.config.Consumer.Name
+	}
+	var created struct {
+		InstanceID string `json:"instance_id"`
+	}
+	if err := c.client.do(ctx, http.MethodPost, group, contentTypeV2, contentTypeV2, request, &created); err != nil {
+		return fmt.Errorf("creating consumer: %w", err)
+	}
+	instance := group + "/instances/" + url.PathEscape(created.InstanceID)
+
+	subscription := struct {
+		Topics []string `json:"topics"`
+	}{[]string{c.config.Consumer.Topic}}
+	if err := c.client.do(ctx, http.MethodPost, instance+"/subscription", contentTypeV2, contentTypeV2, subscription, nil); err != nil {
+		c.client.do(ctx, http.MethodDelete, instance, contentTypeV2, contentTypeV2, nil, nil)
+		return fmt.Errorf("subscribing to %s: %w", c.config.Consumer.Topic, err)
+	}
+	c.instance = instance
+	return nil
+}
+
+// Poll returns the outcomes published since the last poll, subscribing first
+// if needed. Malformed records are skipped and reported in the error
+// alongside the outcomes that could be read. A consumer instance the proxy
+// has expired is recreated on the next poll.
+func (c *Consumer) Poll(ctx context.Context) ([]decision.OffloadOutcome, error) {
+	if c.instance == "" {
+		if err := c.subscribe(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var records []struct {
+		Offset int64           `json:"offset"`
+		Value  json.RawMessage `json:"value"`
+	}
+	err := c.client.do(ctx, http.MethodGet, c.instance+"/records", "", contentTypeJSON, nil, &records)
+	var proxyErr *proxyError
+	if errors.As(err, &proxyErr) && proxyErr.status == http.StatusNotFound {
+		c.instance = ""
+	}
+	if err != nil {
+		return nil, fmt.Errorf("polling %s: %w", c.config.Consumer.Topic, err)
+	}
+
+	outcomes := make([]decision.OffloadOutcome, 0, len(records))
+	malformed := 0
+	for _, record := range records {
+		outcome, ok := parseOutcome(record.Value)
+		if !ok {
+			malformed++
+			continue
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	if malformed > 0 {
+		return outcomes, fmt.Errorf("skipped %d malformed records from %s", malformed, c.config.Consumer.Topic)
+	}
+	return outcomes, nil
+}
+
+// parseOutcome reads an outcome event, or a bare outcome, with a process ID
+func parseOutcome(value json.RawMessage) (decision.OffloadOutcome, bool) {
+	var envelope struct {
+		Type Type            `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return decision.OffloadOutcome{}, false
+	}
+	switch envelope.Type {
+	case TypeOutcome:
+		value = envelope.Data
+	case "":
+	default:
+		return decision.OffloadOutcome{}, false
+	}
+
+	var outcome decision.OffloadOutcome
+	if err := json.Unmarshal(value, &outcome); err != nil || outcome.ProcessID == "" {
+		return decision.OffloadOutcome{}, false
+	}
+	return outcome, true
+}
+
+// Close removes the consumer instance from the group within Timeout, so its
+// partitions are reassigned without waiting for the proxy to expire it
+func (c *Consumer) Close() error {
+	if c.instance == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+
+	instance := c.instance
+	c.instance = ""
+	return c.client.do(ctx, http.MethodDelete, instance, contentTypeV2, contentTypeV2, nil, nil)
+}
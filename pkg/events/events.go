@@ -0,0 +1,265 @@
+// Package events connects CAPE to event-driven data platforms over Kafka. A
+// Producer publishes decisions, outcomes and scaling recommendations to
+// topics, and a Consumer reads outcomes observed by other systems from one.
+// Both talk to Kafka through a Kafka REST Proxy (REST API v2).
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Type is the kind of an event
+type Type string
+
+const (
+	TypeDecision Type = "decision" // An offload decision
+	TypeOutcome  Type = "outcome"  // The outcome of a decision
+	TypeScaling  Type = "scaling"  // A size class change recommended for a target
+)
+
+// Event is the value of a published record
+type Event struct {
+	Type Type        `json:"type"`
+	Key  string      `json:"key"` // Record key: the process ID, or the target ID for scaling events
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data"`
+}
+
+// Topics names the topic each event type is published to
+type Topics struct {
+	Decisions string `json:"decisions"` // Default cape.decisions
+	Outcomes  string `json:"outcomes"`  // Default cape.outcomes
+	Scaling   string `json:"scaling"`   // Default cape.scaling
+}
+
+// ConsumerConfig configures consuming externally observed outcomes
+type ConsumerConfig struct {
+	Enabled bool   `json:"enabled"`
+	Topic   string `json:"topic"` // Default cape.outcomes.observed
+	Group   string `json:"group"` // Consumer group (default cape)
+	Name    string `json:"name"`  // Consumer instance name (empty = assigned by the proxy)
+}
+
+// Config configures publishing to and consuming from Kafka
+type Config struct {
+	Enabled       bool           `json:"enabled"`
+	ProxyURL      string         `json:"proxy_url"`    // Kafka REST Proxy, e.g. http://kafka-rest:8082
+	Username      string         `json:"username"`     // Basic auth user (empty = none)
+	PasswordEnv   string         `json:"password_env"` // Environment variable holding the password (default KAFKA_REST_PASSWORD)
+	Topics        Topics         `json:"topics"`
+	BatchSize     int            `json:"batch_size"`     // Records per publish request (default 100)
+	BufferSize    int            `json:"buffer_size"`    // Events held while the proxy is unreachable, oldest dropped first (default 10000)
+	FlushInterval time.Duration  `json:"flush_interval"` // Time between publishes (default 1s)
+	Timeout       time.Duration  `json:"timeout"`        // Per-request timeout (default 10s)
+	Consumer      ConsumerConfig `json:"consumer"`
+}
+
+// Validate checks the event configuration
+func (c Config) Validate() error {
+	if c.BatchSize < 0 || c.BufferSize < 0 {
+		return fmt.Errorf("batch_size and buffer_size must be non-negative")
+	}
+	if c.FlushInterval < 0 || c.Timeout < 0 {
+		return fmt.Errorf("flush_interval and timeout must be non-negative")
+	}
+	if c.ProxyURL != "" {
+		parsed, err := url.Parse(c.ProxyURL)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return fmt.Errorf("invalid proxy_url %q", c.ProxyURL)
+		}
+	}
+	if (c.Enabled || c.Consumer.Enabled) && c.ProxyURL == "" {
+		return fmt.Errorf("proxy_url is required")
+	}
+	return nil
+}
+
+// withDefaults returns the configuration with unset fields defaulted
+func (c Config) withDefaults() Config {
+	if c.PasswordEnv == "" {
+		c.PasswordEnv = "KAFKA_REST_PASSWORD"
+	}
+	if c.Topics.Decisions == "" {
+		c.Topics.Decisions = "cape.decisions"
+	}
+	if c.Topics.Outcomes == "" {
+		c.Topics.Outcomes = "cape.outcomes"
+	}
+	if c.Topics.Scaling == "" {
+		c.Topics.Scaling = "cape.scaling"
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = 100
+	}
+	if c.BufferSize == 0 {
+		c.BufferSize = 10000
+	}
+	if c.FlushInterval == 0 {
+		c.FlushInterval = time.Second
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.Consumer.Topic == "" {
+		c.Consumer.Topic = "cape.outcomes.observed"
+	}
+	if c.Consumer.Group == "" {
+		c.Consumer.Group = "cape"
+	}
+	return c
+}
+
+// topic returns the topic an event type is published to
+func (c Config) topic(eventType Type) string {
+	switch eventType {
+	case TypeDecision:
+		return c.Topics.Decisions
+	case TypeOutcome:
+		return c.Topics.Outcomes
+	}
+	return c.Topics.Scaling
+}
+
+// Stats counts the events a producer has handled
+type Stats struct {
+	Published   int       `json:"published"`
+	Pending     int       `json:"pending"`
+	Dropped     int       `json:"dropped"` // Evicted from a full buffer
+	Failed      int       `json:"failed"`  // Refused by Kafka
+	LastPublish time.Time `json:"last_publish"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// Producer buffers events and publishes them in batches, so decisions never
+// wait on Kafka. Events are kept and retried while the proxy is unreachable.
+type Producer struct {
+	config Config
+	client *restClient
+
+	mu      sync.Mutex
+	pending []Event // Oldest first
+	stats   Stats
+}
+
+// NewProducer creates a producer for the configured proxy
+func NewProducer(config Config) (*Producer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config = config.withDefaults()
+	return &Producer{config: config, client: newRESTClient(config)}, nil
+}
+
+// Emit queues an event for publishing, dropping the oldest queued event when
+// the buffer is full
+func (p *Producer) Emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.pending) >= p.config.BufferSize {
+		p.pending = p.pending[1:]
+		p.stats.Dropped++
+	}
+	p.pending = append(p.pending, event)
+}
+
+// Flush publishes the queued events, a batch per request and topic. Events
+// the proxy could not be reached for stay queued; events Kafka refused are
+// counted as failed.
+func (p *Producer) Flush(ctx context.Context) error {
+	p.mu.Lock()
+	batch := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+
+	byTopic := make(map[string][]Event)
+	order := make([]string, 0)
+	for _, event := range batch {
+		topic := p.config.topic(event.Type)
+		if _, exists := byTopic[topic]; !exists {
+			order = append(order, topic)
+		}
+		byTopic[topic] = append(byTopic[topic], event)
+	}
+
+	var unsent []Event
+	var flushErr error
+	published, failed := 0, 0
+	for _, topic := range order {
+		events := byTopic[topic]
+		for start := 0; start < len(events); start += p.config.BatchSize {
+			end := min(start+p.config.BatchSize, len(events))
+			if flushErr != nil {
+				unsent = append(unsent, events[start:end]...)
+				continue
+			}
+			refused, err := p.client.produce(ctx, topic, events[start:end])
+			if err != nil {
+				flushErr = fmt.Errorf("publishing to %s: %w", topic, err)
+				unsent = append(unsent, events[start:end]...)
+				continue
+			}
+			published += end - start - refused
+			failed += refused
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Requeue ahead of events emitted during the flush, within the buffer
+	p.pending = append(unsent, p.pending...)
+	if excess := len(p.pending) - p.config.BufferSize; excess > 0 {
+		p.pending = p.pending[excess:]
+		p.stats.Dropped += excess
+	}
+	p.stats.Published += published
+	p.stats.Failed += failed
+	if published > 0 {
+		p.stats.LastPublish = time.Now()
+	}
+	if flushErr != nil {
+		p.stats.LastError = flushErr.Error()
+	}
+	return flushErr
+}
+
+// Run publishes queued events every FlushInterval until the context is
+// cancelled, then makes a last flush within Timeout
+func (p *Producer) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.Close()
+			return
+		case <-ticker.C:
+			p.Flush(ctx)
+		}
+	}
+}
+
+// Close publishes the queued events within Timeout
+func (p *Producer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.Timeout)
+	defer cancel()
+	return p.Flush(ctx)
+}
+
+// Stats returns the producer's event counts
+func (p *Producer) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.Pending = len(p.pending)
+	return stats
+}
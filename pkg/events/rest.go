@@ -0,0 +1,125 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Kafka REST Proxy v2 content types
+const (
+	contentTypeJSON = "application/vnd.kafka.json.v2+json" // Records with JSON keys and values
+	contentTypeV2   = "application/vnd.kafka.v2+json"      // Requests and responses without records
+)
+
+// restClient calls a Kafka REST Proxy
+type restClient struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+func newRESTClient(config Config) *restClient {
+	return &restClient{
+		baseURL:    strings.TrimRight(config.ProxyURL, "/"),
+		username:   config.Username,
+		password:   os.Getenv(config.PasswordEnv),
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// produceRecord is a record in a produce request
+type produceRecord struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
+
+// produce publishes events to a topic, returning how many Kafka refused
+func (rc *restClient) produce(ctx context.Context, topic string, events []Event) (int, error) {
+	records := make([]produceRecord, len(events))
+	for i, event := range events {
+		records[i] = produceRecord{Key: event.Key, Value: event}
+	}
+	var response struct {
+		Offsets []struct {
+			ErrorCode *int   `json:"error_code"`
+			Error     string `json:"error"`
+		} `json:"offsets"`
+	}
+	err := rc.do(ctx, http.MethodPost, "/topics/"+url.PathEscape(topic), contentTypeJSON, contentTypeV2,
+		struct {
+			Records []produceRecord `json:"records"`
+		}{records}, &response)
+	if err != nil {
+		return 0, err
+	}
+	refused := 0
+	for _, offset := range response.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			refused++
+		}
+	}
+	return refused, nil
+}
+
+// do sends a request with a JSON body and decodes a JSON response into out,
+// when both are non-nil
+func (rc *restClient) do(ctx context.Context, method, path, contentType, accept string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, rc.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		request.Header.Set("Content-Type", contentType)
+	}
+	request.Header.Set("Accept", accept)
+	if rc.username != "" {
+		request.SetBasicAuth(rc.username, rc.password)
+	}
+
+	response, err := rc.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		var proxyErr struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(response.Body).Decode(&proxyErr)
+		return &proxyError{status: response.StatusCode, message: proxyErr.Message}
+	}
+	if out == nil || response.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(response.Body).Decode(out)
+}
+
+// proxyError is an error response from the proxy
+type proxyError struct {
+	status  int
+	message string
+}
+
+func (pe *proxyError) Error() string {
+	if pe.message == "" {
+		return fmt.Sprintf("REST proxy returned %d %s", pe.status, http.StatusText(pe.status))
+	}
+	return fmt.Sprintf("REST proxy returned %d: %s", pe.status, pe.message)
+}
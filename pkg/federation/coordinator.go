@@ -0,0 +1,258 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// SummarySource exports a colony's current summary. Local CAPE instances
+// implement it directly; remote colonies implement it over their transport.
+type SummarySource interface {
+	Summary(ctx context.Context) (ColonySummary, error)
+}
+
+// SummarySourceFunc adapts a function to the SummarySource interface
+type SummarySourceFunc func(ctx context.Context) (ColonySummary, error)
+
+// Summary calls f(ctx)
+func (f SummarySourceFunc) Summary(ctx context.Context) (ColonySummary, error) {
+	return f(ctx)
+}
+
+// LocalSource exports the summary of a local CAPE instance, built from the
+// targets in its registry and the state reported by stateFn
+func LocalSource(
+	colonyID string,
+	alg *algorithm.Algorithm,
+	stateFn func() models.SystemState,
+	horizon time.Duration,
+) SummarySource {
+	return SummarySourceFunc(func(ctx context.Context) (ColonySummary, error) {
+		if err := ctx.Err(); err != nil {
+			return ColonySummary{}, err
+		}
+		return Summarize(colonyID, stateFn(), alg.TargetRegistry().All(), horizon), nil
+	})
+}
+
+// CoordinatorConfig configures the federation coordinator
+type CoordinatorConfig struct {
+	Weights      decision.AdaptiveWeights `json:"weights"`
+	StaleAfter   time.Duration            `json:"stale_after"`   // Summaries older than this are ignored
+	PollInterval time.Duration            `json:"poll_interval"` // Interval used by Run
+}
+
+// DefaultCoordinatorConfig returns the default coordinator configuration
+func DefaultCoordinatorConfig() CoordinatorConfig {
+	return CoordinatorConfig{
+		Weights: decision.AdaptiveWeights{
+			QueueDepth:    0.2,
+			ProcessorLoad: 0.2,
+			NetworkCost:   0.2,
+			LatencyCost:   0.2,
+			EnergyCost:    0.1,
+			PolicyCost:    0.1,
+		},
+		StaleAfter:   60 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+}
+
+// CrossColonyDecision is the coordinator's decision for a process that its
+// origin colony could not place locally
+type CrossColonyDecision struct {
+	ProcessID      string                   `json:"process_id"`
+	OriginColony   string                   `json:"origin_colony"`
+	ShouldOffload  bool                     `json:"should_offload"`
+	TargetColony   string                   `json:"target_colony,omitempty"`
+	Decision       decision.OffloadDecision `json:"decision"`
+	ColoniesViewed int                      `json:"colonies_viewed"`
+}
+
+// colonyMember is a registered colony and its latest known summary
+type colonyMember struct {
+	source  SummarySource
+	latency time.Duration // Latency from other colonies to this one
+	summary *ColonySummary
+}
+
+// Coordinator is a global CAPE instance that decides cross-colony offloads
+// from summaries exported by local instances. Summaries arrive by polling
+// registered sources or by being pushed with Update.
+type Coordinator struct {
+	config  CoordinatorConfig
+	engine  *decision.DecisionEngine
+	members map[string]*colonyMember
+	mu      sync.RWMutex
+}
+
+// NewCoordinator creates a new federation coordinator
+func NewCoordinator(config CoordinatorConfig) *Coordinator {
+	if config.StaleAfter <= 0 {
+		config.StaleAfter = DefaultCoordinatorConfig().StaleAfter
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultCoordinatorConfig().PollInterval
+	}
+	return &Coordinator{
+		config:  config,
+		engine:  decision.NewDecisionEngine(config.Weights),
+		members: make(map[string]*colonyMember),
+	}
+}
+
+// Register adds a colony. The source may be nil for colonies that push
+// summaries with Update instead of being polled.
+func (c *Coordinator) Register(colonyID string, source SummarySource, latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	member, exists := c.members[colonyID]
+	if !exists {
+		member = &colonyMember{}
+		c.members[colonyID] = member
+	}
+	member.source = source
+	member.latency = latency
+}
+
+// Unregister removes a colony from the federation
+func (c *Coordinator) Unregister(colonyID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.members, colonyID)
+}
+
+// Update records a summary pushed by a colony. Older summaries than the one
+// already held are ignored, so gossip can deliver them in any order.
+func (c *Coordinator) Update(summary ColonySummary) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	member, exists := c.members[summary.ColonyID]
+	if !exists {
+		return fmt.Errorf("unknown colony %s", summary.ColonyID)
+	}
+	if member.summary != nil && summary.Timestamp.Before(member.summary.Timestamp) {
+		return nil
+	}
+	member.summary = &summary
+	return nil
+}
+
+// Poll fetches a fresh summary from every registered source. Colonies that
+// fail to respond keep their previous summary until it goes stale.
+func (c *Coordinator) Poll(ctx context.Context) error {
+	c.mu.RLock()
+	sources := make(map[string]SummarySource)
+	for colonyID, member := range c.members {
+		if member.source != nil {
+			sources[colonyID] = member.source
+		}
+	}
+	c.mu.RUnlock()
+
+	var failed []string
+	for colonyID, source := range sources {
+		summary, err := source.Summary(ctx)
+		if err != nil || summary.ColonyID != colonyID {
+			failed = append(failed, colonyID)
+			continue
+		}
+		if err := c.Update(summary); err != nil {
+			failed = append(failed, colonyID)
+		}
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("failed to poll colonies: %v", failed)
+	}
+	return nil
+}
+
+// Run polls all sources every PollInterval until the context is cancelled
+func (c *Coordinator) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		c.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Summaries returns the fresh summaries known to the coordinator, by colony ID
+func (c *Coordinator) Summaries() map[string]ColonySummary {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	summaries := make(map[string]ColonySummary)
+	for colonyID, member := range c.members {
+		if c.isFresh(member) {
+			summaries[colonyID] = *member.summary
+		}
+	}
+	return summaries
+}
+
+// Decide chooses a remote colony for a process that its origin colony could
+// not place. Each fresh remote colony is scored as a single target; the
+// origin's own state drives the decision to offload at all.
+func (c *Coordinator) Decide(
+	process models.Process,
+	originColony string,
+	originState models.SystemState,
+) (CrossColonyDecision, error) {
+	c.mu.RLock()
+	colonyIDs := make([]string, 0, len(c.members))
+	for colonyID := range c.members {
+		colonyIDs = append(colonyIDs, colonyID)
+	}
+	sort.Strings(colonyIDs)
+
+	targets := make([]models.OffloadTarget, 0, len(colonyIDs))
+	for _, colonyID := range colonyIDs {
+		member := c.members[colonyID]
+		if colonyID == originColony || !c.isFresh(member) {
+			continue
+		}
+		targets = append(targets, member.summary.AsTarget(member.latency))
+	}
+	c.mu.RUnlock()
+
+	result := CrossColonyDecision{
+		ProcessID:      process.ID,
+		OriginColony:   originColony,
+		ColoniesViewed: len(targets),
+	}
+
+	dec, err := c.engine.MakeDecision(process, targets, originState)
+	if err != nil {
+		return result, fmt.Errorf("coordinator decision failed: %w", err)
+	}
+	result.Decision = dec
+
+	if dec.ShouldOffload && dec.Target != nil {
+		result.ShouldOffload = true
+		result.TargetColony = dec.Target.Location
+	}
+	return result, nil
+}
+
+// isFresh returns true if the member has a summary newer than StaleAfter
+func (c *Coordinator) isFresh(member *colonyMember) bool {
+	return member.summary != nil && time.Since(member.summary.Timestamp) <= c.config.StaleAfter
+}
@@ -0,0 +1,144 @@
+package federation
+
+import (
+	"math"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// ColonySummary is the capacity state a colony exports to the coordinator.
+// It aggregates the colony's targets so the coordinator never sees
+// individual executors.
+type ColonySummary struct {
+	ColonyID  string    `json:"colony_id"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Capacity
+	TargetCount         int      `json:"target_count"`
+	HealthyTargets      int      `json:"healthy_targets"`
+	TotalCapacity       float64  `json:"total_capacity"`
+	AvailableCapacity   float64  `json:"available_capacity"`
+	LargestAvailableCPU float64  `json:"largest_available_cpu"`    // Most CPU a single target can offer
+	LargestAvailableMem int64    `json:"largest_available_memory"` // Most memory a single target can offer
+	TotalMemory         int64    `json:"total_memory"`
+	AverageLoad         float64  `json:"average_load"`
+	AverageSpeed        float64  `json:"average_speed"`
+	AverageReliability  float64  `json:"average_reliability"`
+	AverageComputeCost  float64  `json:"average_compute_cost"`
+	AverageEnergyCost   float64  `json:"average_energy_cost"`
+	MaxSecurityLevel    int      `json:"max_security_level"`
+	MinNetworkBandwidth float64  `json:"min_network_bandwidth"`
+	Jurisdictions       []string `json:"jurisdictions"`
+
+	// Queue state and short-term forecast
+	QueueDepth         int           `json:"queue_depth"`
+	QueueThreshold     int           `json:"queue_threshold"`
+	QueueThroughput    float64       `json:"queue_throughput"`     // Processes per second
+	ForecastQueueDepth int           `json:"forecast_queue_depth"` // Expected depth after the forecast horizon
+	ForecastHorizon    time.Duration `json:"forecast_horizon"`
+	EstimatedWaitTime  time.Duration `json:"estimated_wait_time"`
+}
+
+// Summarize builds a colony summary from the colony's system state and
+// targets. The queue forecast assumes current throughput is sustained and
+// arrivals match the recent queue growth rate.
+func Summarize(
+	colonyID string,
+	state models.SystemState,
+	targets []models.OffloadTarget,
+	horizon time.Duration,
+) ColonySummary {
+	summary := ColonySummary{
+		ColonyID:          colonyID,
+		Timestamp:         time.Now(),
+		TargetCount:       len(targets),
+		QueueDepth:        state.QueueDepth,
+		QueueThreshold:    state.QueueThreshold,
+		QueueThroughput:   state.QueueThroughput,
+		ForecastHorizon:   horizon,
+		EstimatedWaitTime: state.QueueWaitTime,
+		Jurisdictions:     make([]string, 0),
+	}
+
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		if !target.IsHealthy() {
+			continue
+		}
+		summary.HealthyTargets++
+		summary.TotalCapacity += target.TotalCapacity
+		summary.AvailableCapacity += target.AvailableCapacity
+		summary.TotalMemory += target.MemoryTotal
+		summary.LargestAvailableCPU = math.Max(summary.LargestAvailableCPU, target.AvailableCapacity)
+		if target.MemoryAvailable > summary.LargestAvailableMem {
+			summary.LargestAvailableMem = target.MemoryAvailable
+		}
+		summary.AverageLoad += target.CurrentLoad
+		summary.AverageSpeed += target.ProcessingSpeed
+		summary.AverageReliability += target.Reliability
+		summary.AverageComputeCost += target.ComputeCost
+		summary.AverageEnergyCost += target.EnergyCost
+		if target.SecurityLevel > summary.MaxSecurityLevel {
+			summary.MaxSecurityLevel = target.SecurityLevel
+		}
+		if target.NetworkBandwidth > 0 &&
+			(summary.MinNetworkBandwidth == 0 || target.NetworkBandwidth < summary.MinNetworkBandwidth) {
+			summary.MinNetworkBandwidth = target.NetworkBandwidth
+		}
+		if target.DataJurisdiction != "" && !seen[target.DataJurisdiction] {
+			seen[target.DataJurisdiction] = true
+			summary.Jurisdictions = append(summary.Jurisdictions, target.DataJurisdiction)
+		}
+	}
+
+	if summary.HealthyTargets > 0 {
+		n := float64(summary.HealthyTargets)
+		summary.AverageLoad /= n
+		summary.AverageSpeed /= n
+		summary.AverageReliability /= n
+		summary.AverageComputeCost /= n
+		summary.AverageEnergyCost /= n
+	}
+
+	// Forecast: the queue drains at the observed throughput
+	drained := int(state.QueueThroughput * horizon.Seconds())
+	summary.ForecastQueueDepth = max(0, state.QueueDepth-drained)
+
+	return summary
+}
+
+// AsTarget represents a remote colony as a single offload target for the
+// coordinator's decision engine. Available capacity is the largest single
+// target's, since a process must fit on one executor.
+func (cs ColonySummary) AsTarget(interColonyLatency time.Duration) models.OffloadTarget {
+	target := models.OffloadTarget{
+		ID:                ColonyTargetID(cs.ColonyID),
+		Type:              models.HYBRID_CLOUD,
+		Location:          cs.ColonyID,
+		TotalCapacity:     cs.TotalCapacity,
+		AvailableCapacity: cs.LargestAvailableCPU,
+		MemoryTotal:       cs.TotalMemory,
+		MemoryAvailable:   cs.LargestAvailableMem,
+		NetworkLatency:    interColonyLatency,
+		NetworkBandwidth:  cs.MinNetworkBandwidth,
+		NetworkStability:  cs.AverageReliability,
+		ProcessingSpeed:   cs.AverageSpeed,
+		Reliability:       cs.AverageReliability,
+		ComputeCost:       cs.AverageComputeCost,
+		EnergyCost:        cs.AverageEnergyCost,
+		SecurityLevel:     cs.MaxSecurityLevel,
+		CurrentLoad:       cs.AverageLoad,
+		EstimatedWaitTime: cs.EstimatedWaitTime,
+		LastSeen:          cs.Timestamp,
+	}
+	if len(cs.Jurisdictions) == 1 {
+		target.DataJurisdiction = cs.Jurisdictions[0]
+	}
+	return target
+}
+
+// ColonyTargetID returns the target ID used for a colony in coordinator decisions
+func ColonyTargetID(colonyID string) string {
+	return "colony:" + colonyID
+}
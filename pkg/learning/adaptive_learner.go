@@ -1,11 +1,13 @@
 package learning
 
 import (
+	"log/slog"
 	"math"
 	"sort"
 	"time"
 
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
 )
 
@@ -18,11 +20,24 @@ type AdaptiveLearner struct {
 	baseline          *PerformanceBaseline
 	progress          *LearningProgress
 	objectives        []LearningObjective
+	convergence       *ConvergenceMonitor
+	drift             *DriftDetector
+	logger            *slog.Logger
+	now               func() time.Time // Time source of pattern timestamps
 }
 
 // NewAdaptiveLearner creates a new adaptive learner
 func NewAdaptiveLearner(config LearningConfig) *AdaptiveLearner {
-	return &AdaptiveLearner{
+	var drift *DriftDetector
+	if config.Drift.Enabled {
+		drift = NewDriftDetector(config.Drift)
+	}
+
+	if config.HistorySize == 0 {
+		config.HistorySize = DefaultHistorySize
+	}
+
+	al := &AdaptiveLearner{
 		config: config,
 		weightAdapter: &WeightAdapter{
 			learningRate:    config.LearningRate,
@@ -52,8 +67,19 @@ func NewAdaptiveLearner(config LearningConfig) *AdaptiveLearner {
 		progress: &LearningProgress{
 			WeightHistory: make([]decision.AdaptiveWeights, 0),
 		},
-		objectives: initializeLearningObjectives(),
+		objectives:  initializeLearningObjectives(),
+		convergence: NewConvergenceMonitor(config.Convergence),
+		drift:       drift,
+		logger:      logging.Discard(),
+		now:         time.Now,
 	}
+
+	al.convergence.OnEvent(func(event ConvergenceEvent) {
+		al.logger.Info("convergence event",
+			"type", event.Type, "decision_count", event.DecisionCount, "reason", event.Reason)
+	})
+
+	return al
 }
 
 // initializeLearningObjectives creates the default learning objectives
@@ -73,19 +99,39 @@ func (al *AdaptiveLearner) UpdateWeights(weights *decision.AdaptiveWeights, outc
 	// Add outcome to window
 	al.outcomeWindow.Add(outcome)
 	al.progress.DecisionCount++
+	// An outcome implies its decision, even one the learner was not told of
+	al.weightAdapter.decisions = max(al.weightAdapter.decisions, al.progress.DecisionCount)
+	previous := *weights
+	
+	// Frozen weights are kept as-is until the convergence monitor unfreezes them
+	if !al.convergence.IsFrozen() {
+		// Calculate weight adjustments based on attribution and reward
+		adjustments := al.calculateWeightAdjustments(weights, outcome)
+		
+		// Apply adjustments with learning rate
+		al.applyWeightAdjustments(weights, adjustments)
+		
+		// Ensure weights are normalized
+		weights.Normalize()
+	}
+	al.convergence.Observe(previous, *weights, outcome.Reward)
 	
-	// Calculate weight adjustments based on attribution and reward
-	adjustments := al.calculateWeightAdjustments(weights, outcome)
-	
-	// Apply adjustments with learning rate
-	al.applyWeightAdjustments(weights, adjustments)
-	
-	// Ensure weights are normalized
-	weights.Normalize()
+	// Reset learned state if the reward distribution has drifted
+	if al.drift != nil {
+		al.handleDrift(weights, outcome.Reward)
+	}
 	
 	// Track weight history
 	al.weightAdapter.weightHistory = append(al.weightAdapter.weightHistory, *weights)
+	al.weightAdapter.decisionCounts = append(al.weightAdapter.decisionCounts, al.weightAdapter.decisions)
 	al.progress.WeightHistory = append(al.progress.WeightHistory, *weights)
+	if excess := len(al.weightAdapter.weightHistory) - al.config.HistorySize; excess > 0 {
+		al.weightAdapter.weightHistory = al.weightAdapter.weightHistory[excess:]
+		al.weightAdapter.decisionCounts = al.weightAdapter.decisionCounts[excess:]
+	}
+	if excess := len(al.progress.WeightHistory) - al.config.HistorySize; excess > 0 {
+		al.progress.WeightHistory = al.progress.WeightHistory[excess:]
+	}
 	al.progress.WeightUpdates++
 	
 	// Check for convergence
@@ -122,16 +168,69 @@ func (al *AdaptiveLearner) calculateWeightAdjustments(
 	}
 	
 	// Add exploration noise
-	if al.config.ExplorationRate > 0 {
+	explorationRate := al.explorationRate()
+	if explorationRate > 0 {
 		for factor := range adjustments {
-			noise := (math.Sin(float64(al.progress.DecisionCount)) * 0.5 + 0.5) * al.config.ExplorationRate * 0.01
-			adjustments[factor] += noise - al.config.ExplorationRate*0.005
+			noise := (math.Sin(float64(al.progress.DecisionCount)) * 0.5 + 0.5) * explorationRate * 0.01
+			adjustments[factor] += noise - explorationRate*0.005
 		}
 	}
 	
 	return adjustments
 }
 
+// explorationRate returns the current exploration rate, boosted after a drift reset
+func (al *AdaptiveLearner) explorationRate() float64 {
+	if al.drift != nil && al.drift.IsReexploring() {
+		return math.Max(al.config.ExplorationRate, al.drift.config.ReexplorationRate)
+	}
+	return al.config.ExplorationRate
+}
+
+// handleDrift feeds the reward to the drift detector and applies the reset
+// policy to the weights when drift is detected
+func (al *AdaptiveLearner) handleDrift(weights *decision.AdaptiveWeights, reward float64) {
+	detected, direction, statistic, meanBefore := al.drift.Observe(reward)
+	if !detected {
+		return
+	}
+
+	event := DriftEvent{
+		DecisionCount: al.progress.DecisionCount,
+		Direction:     direction,
+		Statistic:     statistic,
+		MeanBefore:    meanBefore,
+		Policy:        al.drift.config.ResetPolicy,
+		WeightsBefore: *weights,
+	}
+
+	switch al.drift.config.ResetPolicy {
+	case RESET_FULL:
+		*weights = al.baseline.StaticWeights
+		al.outcomeWindow.outcomes = make([]decision.OffloadOutcome, 0)
+		al.patternRecognizer.outcomeHistory = make([]decision.OffloadOutcome, 0)
+		al.patternRecognizer.patterns = make([]*decision.DiscoveredPattern, 0)
+		al.resetConvergence(*weights)
+	case RESET_PARTIAL:
+		*weights = blendWeights(*weights, al.baseline.StaticWeights, al.drift.config.PartialResetFactor)
+		al.resetConvergence(*weights)
+	}
+
+	event.WeightsAfter = *weights
+	al.drift.record(event)
+
+	al.logger.Warn("concept drift detected",
+		"direction", direction, "statistic", statistic, "mean_before", meanBefore, "policy", event.Policy)
+}
+
+// resetConvergence clears convergence state so weights can adapt again
+func (al *AdaptiveLearner) resetConvergence(weights decision.AdaptiveWeights) {
+	al.progress.IsConverged = false
+	al.progress.ConvergenceTime = 0
+	al.weightAdapter.convergenceTime = 0
+	al.convergence.Unfreeze(weights, "concept drift detected")
+}
+
 // applyWeightAdjustments applies calculated adjustments to weights
 func (al *AdaptiveLearner) applyWeightAdjustments(
 	weights *decision.AdaptiveWeights,
@@ -161,15 +260,15 @@ func (al *AdaptiveLearner) applyWeightAdjustments(
 // checkConvergence checks if weights have converged
 func (al *AdaptiveLearner) checkConvergence() {
 	history := al.weightAdapter.weightHistory
-	if len(history) < 20 {
+	if len(history) < convergenceWindow {
 		return
 	}
 	
 	// Check if recent weights are stable
-	recent := history[len(history)-20:]
+	recent := history[len(history)-convergenceWindow:]
 	variance := al.calculateWeightVariance(recent)
 	
-	if variance < 0.01 { // Low variance indicates convergence
+	if variance < al.convergenceThreshold() { // Low variance indicates convergence
 		if !al.progress.IsConverged {
 			al.progress.IsConverged = true
 			al.progress.ConvergenceTime = al.progress.DecisionCount
@@ -178,6 +277,14 @@ func (al *AdaptiveLearner) checkConvergence() {
 	}
 }
 
+// convergenceThreshold returns the weight variance below which weights are converged
+func (al *AdaptiveLearner) convergenceThreshold() float64 {
+	if al.config.ConvergenceThreshold > 0 {
+		return al.config.ConvergenceThreshold
+	}
+	return 0.01
+}
+
 // calculateWeightVariance calculates variance in weight history
 func (al *AdaptiveLearner) calculateWeightVariance(weights []decision.AdaptiveWeights) float64 {
 	if len(weights) == 0 {
@@ -224,6 +331,9 @@ func (al *AdaptiveLearner) DiscoverPatterns(
 	outcome decision.OffloadOutcome,
 ) []*decision.DiscoveredPattern {
 	al.patternRecognizer.outcomeHistory = append(al.patternRecognizer.outcomeHistory, outcome)
+	if excess := len(al.patternRecognizer.outcomeHistory) - al.config.HistorySize; excess > 0 {
+		al.patternRecognizer.outcomeHistory = al.patternRecognizer.outcomeHistory[excess:]
+	}
 	
 	// Need minimum samples before pattern discovery
 	if len(al.patternRecognizer.outcomeHistory) < al.config.MinSamples {
@@ -293,8 +403,8 @@ func (al *AdaptiveLearner) findPatternsInOutcomes() []*decision.DiscoveredPatter
 			RecommendedAction: models.OFFLOAD_TO,
 			ApplicationCount:  0,
 			SuccessRate:       float64(len(successful)) / float64(len(successful)+len(failed)),
-			CreatedTime:       time.Now(),
-			LastUpdated:       time.Now(),
+			CreatedTime:       al.now(),
+			LastUpdated:       al.now(),
 			MinSamples:        al.config.MinSamples,
 			ValidationStatus:  decision.DISCOVERING,
 		}
@@ -323,8 +433,8 @@ func (al *AdaptiveLearner) findPatternsInOutcomes() []*decision.DiscoveredPatter
 			RecommendedAction: models.KEEP_LOCAL,
 			ApplicationCount:  0,
 			SuccessRate:       0.9,
-			CreatedTime:       time.Now(),
-			LastUpdated:       time.Now(),
+			CreatedTime:       al.now(),
+			LastUpdated:       al.now(),
 			MinSamples:        al.config.MinSamples,
 			ValidationStatus:  decision.DISCOVERING,
 		}
@@ -341,7 +451,7 @@ func (al *AdaptiveLearner) addPattern(pattern *decision.DiscoveredPattern) {
 		if existing.ID == pattern.ID {
 			// Update existing pattern
 			existing.ApplicationCount++
-			existing.LastUpdated = time.Now()
+			existing.LastUpdated = al.now()
 			return
 		}
 	}
@@ -427,6 +537,102 @@ func (al *AdaptiveLearner) IsConverged() bool {
 	return al.progress.IsConverged
 }
 
+// ObserveDecision counts a decision made with the current weights, so the
+// weight evolution is indexed by decisions rather than by the outcomes
+// learned from. Decisions whose outcomes never arrive still advance it.
+func (al *AdaptiveLearner) ObserveDecision() {
+	al.weightAdapter.decisions++
+}
+
+// GetWeightEvolution returns the weight trajectory indexed by decision count,
+// sampled every step decisions, so runs of different length can be compared.
+// Each point holds the weights in effect once that many decisions were made.
+// The convergence point is the decision count at which the trailing window
+// of weights first had a variance below the convergence threshold. Only the
+// last HistorySize weight vectors are kept, so a long run's trajectory starts
+// after the decisions that were dropped.
+func (al *AdaptiveLearner) GetWeightEvolution(step int) WeightEvolution {
+	if step < 1 {
+		step = 1
+	}
+
+	history := al.weightAdapter.weightHistory
+	decisionCounts := al.weightAdapter.decisionCounts
+	evolution := WeightEvolution{
+		Points: make([]WeightEvolutionPoint, 0, len(history)/step+1),
+		Step:   step,
+	}
+
+	last := al.weightAdapter.decisions
+	for i := range history {
+		// The weights are in effect until the next weight vector is learned
+		until := last
+		if i+1 < len(history) {
+			until = decisionCounts[i+1] - 1
+		}
+		first := (decisionCounts[i] + step - 1) / step * step
+		for decisionCount := first; decisionCount <= until; decisionCount += step {
+			evolution.Points = append(evolution.Points, WeightEvolutionPoint{
+				DecisionCount: decisionCount,
+				Weights:       history[i],
+			})
+		}
+
+		if evolution.ConvergencePoint == 0 && i+1 >= convergenceWindow {
+			window := history[i+1-convergenceWindow : i+1]
+			if al.calculateWeightVariance(window) < al.convergenceThreshold() {
+				evolution.ConvergencePoint = decisionCounts[i]
+				evolution.IsConverged = true
+			}
+		}
+	}
+
+	if len(history) > 0 {
+		evolution.FinalWeights = history[len(history)-1]
+		if last%step != 0 {
+			evolution.Points = append(evolution.Points, WeightEvolutionPoint{
+				DecisionCount: last,
+				Weights:       evolution.FinalWeights,
+			})
+		}
+	}
+
+	return evolution
+}
+
+// SetLogger sets the structured logger; records are discarded until one is set
+func (al *AdaptiveLearner) SetLogger(logger *slog.Logger) {
+	al.logger = logger
+}
+
+// SetClock replaces the time source, for tests and simulations
+func (al *AdaptiveLearner) SetClock(now func() time.Time) {
+	al.now = now
+}
+
+// IsFrozen returns whether weight adaptation is frozen after convergence
+func (al *AdaptiveLearner) IsFrozen() bool {
+	return al.convergence.IsFrozen()
+}
+
+// OnConvergenceEvent registers a handler for convergence events
+func (al *AdaptiveLearner) OnConvergenceEvent(handler func(ConvergenceEvent)) {
+	al.convergence.OnEvent(handler)
+}
+
+// GetConvergenceEvents returns all convergence events emitted so far
+func (al *AdaptiveLearner) GetConvergenceEvents() []ConvergenceEvent {
+	return al.convergence.GetEvents()
+}
+
+// GetDriftEvents returns all detected concept drift events
+func (al *AdaptiveLearner) GetDriftEvents() []DriftEvent {
+	if al.drift == nil {
+		return nil
+	}
+	return al.drift.GetEvents()
+}
+
 // GetConvergenceTime returns the number of decisions until convergence
 func (al *AdaptiveLearner) GetConvergenceTime() int {
 	return al.weightAdapter.convergenceTime
@@ -0,0 +1,214 @@
+package learning
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+)
+
+// CanaryConfig configures staged rollout of learned weights. Adapted weights
+// are first used for a fraction of decisions and only promoted once that
+// cohort performs no worse than the stable weights.
+type CanaryConfig struct {
+	Enabled           bool    `json:"enabled"`
+	Fraction          float64 `json:"fraction"`            // Share of decisions using candidate weights (default 0.1)
+	Window            int     `json:"window"`              // Outcomes per cohort before evaluating (default 50)
+	MaxSLARegression  float64 `json:"max_sla_regression"`  // Tolerated drop in SLA attainment (default 0.05)
+	MaxCostRegression float64 `json:"max_cost_regression"` // Tolerated relative increase in mean cost (default 0.1)
+}
+
+// CanaryAction is how a canary trial ended
+type CanaryAction string
+
+const (
+	CANARY_PROMOTED    CanaryAction = "promoted"
+	CANARY_ROLLED_BACK CanaryAction = "rolled_back"
+)
+
+// CanaryEvent records the end of a canary trial
+type CanaryEvent struct {
+	Timestamp        time.Time                `json:"timestamp"`
+	Action           CanaryAction             `json:"action"`
+	StableWeights    decision.AdaptiveWeights `json:"stable_weights"`
+	CandidateWeights decision.AdaptiveWeights `json:"candidate_weights"`
+	StableSLA        float64                  `json:"stable_sla"`
+	CanarySLA        float64                  `json:"canary_sla"`
+	StableMeanCost   float64                  `json:"stable_mean_cost"`
+	CanaryMeanCost   float64                  `json:"canary_mean_cost"`
+}
+
+// cohortStats accumulates outcomes of one cohort during a trial
+type cohortStats struct {
+	outcomes int
+	slaMet   int
+	cost     float64
+}
+
+func (cs cohortStats) sla() float64 {
+	if cs.outcomes == 0 {
+		return 0
+	}
+	return float64(cs.slaMet) / float64(cs.outcomes)
+}
+
+func (cs cohortStats) meanCost() float64 {
+	if cs.outcomes == 0 {
+		return 0
+	}
+	return cs.cost / float64(cs.outcomes)
+}
+
+// assignment records the trial and cohort a process was decided in
+type assignment struct {
+	trial  int
+	canary bool
+}
+
+// CanaryController splits decisions between stable and candidate weights and
+// promotes or rolls back the candidate based on cohort outcomes
+type CanaryController struct {
+	config      CanaryConfig
+	stable      decision.AdaptiveWeights
+	candidate   decision.AdaptiveWeights
+	trial       int // Increments whenever a trial starts
+	active      bool
+	assignments map[string]assignment // By process ID
+	stableStats cohortStats
+	canaryStats cohortStats
+	events      []CanaryEvent
+}
+
+// NewCanaryController creates a canary controller starting from the stable weights
+func NewCanaryController(config CanaryConfig, stable decision.AdaptiveWeights) *CanaryController {
+	if config.Fraction <= 0 || config.Fraction >= 1 {
+		config.Fraction = 0.1
+	}
+	if config.Window <= 0 {
+		config.Window = 50
+	}
+	if config.MaxSLARegression <= 0 {
+		config.MaxSLARegression = 0.05
+	}
+	if config.MaxCostRegression <= 0 {
+		config.MaxCostRegression = 0.1
+	}
+
+	return &CanaryController{
+		config:      config,
+		stable:      stable,
+		candidate:   stable,
+		assignments: make(map[string]assignment),
+		events:      make([]CanaryEvent, 0),
+	}
+}
+
+// Weights assigns a process to a cohort and returns the weights to decide it with
+func (cc *CanaryController) Weights(processID string) decision.AdaptiveWeights {
+	if !cc.active {
+		return cc.stable
+	}
+
+	canary := inCanary(processID, cc.config.Fraction)
+	cc.assignments[processID] = assignment{trial: cc.trial, canary: canary}
+	if canary {
+		return cc.candidate
+	}
+	return cc.stable
+}
+
+// Stable returns the weights used outside the canary cohort
+func (cc *CanaryController) Stable() decision.AdaptiveWeights {
+	return cc.stable
+}
+
+// Candidate returns the weights being trialled, or the stable weights when
+// no trial is running
+func (cc *CanaryController) Candidate() decision.AdaptiveWeights {
+	return cc.candidate
+}
+
+// IsActive reports whether a candidate is being trialled
+func (cc *CanaryController) IsActive() bool {
+	return cc.active
+}
+
+// Propose replaces the candidate with newly learned weights, starting a trial
+// if none is running
+func (cc *CanaryController) Propose(weights decision.AdaptiveWeights) {
+	cc.candidate = weights
+	if !cc.active && weights != cc.stable {
+		cc.active = true
+		cc.trial++
+		cc.stableStats = cohortStats{}
+		cc.canaryStats = cohortStats{}
+	}
+}
+
+// Observe records an outcome to its cohort. Once both cohorts have a full
+// window, the trial ends and the returned event says whether the candidate
+// was promoted or rolled back.
+func (cc *CanaryController) Observe(outcome decision.OffloadOutcome) *CanaryEvent {
+	assigned, exists := cc.assignments[outcome.ProcessID]
+	if !exists {
+		return nil
+	}
+	delete(cc.assignments, outcome.ProcessID)
+	if !cc.active || assigned.trial != cc.trial {
+		return nil
+	}
+
+	stats := &cc.stableStats
+	if assigned.canary {
+		stats = &cc.canaryStats
+	}
+	stats.outcomes++
+	if outcome.Success && outcome.CompletedOnTime {
+		stats.slaMet++
+	}
+	stats.cost += outcome.CostActual
+
+	if cc.stableStats.outcomes < cc.config.Window || cc.canaryStats.outcomes < cc.config.Window {
+		return nil
+	}
+	return cc.resolve()
+}
+
+// Events returns the trial outcomes so far
+func (cc *CanaryController) Events() []CanaryEvent {
+	return append([]CanaryEvent(nil), cc.events...)
+}
+
+// resolve ends the trial, promoting the candidate unless its cohort regressed
+func (cc *CanaryController) resolve() *CanaryEvent {
+	event := CanaryEvent{
+		Timestamp:        time.Now(),
+		Action:           CANARY_PROMOTED,
+		StableWeights:    cc.stable,
+		CandidateWeights: cc.candidate,
+		StableSLA:        cc.stableStats.sla(),
+		CanarySLA:        cc.canaryStats.sla(),
+		StableMeanCost:   cc.stableStats.meanCost(),
+		CanaryMeanCost:   cc.canaryStats.meanCost(),
+	}
+
+	slaRegressed := event.CanarySLA < event.StableSLA-cc.config.MaxSLARegression
+	costRegressed := event.CanaryMeanCost > event.StableMeanCost*(1.0+cc.config.MaxCostRegression)
+	if slaRegressed || costRegressed {
+		event.Action = CANARY_ROLLED_BACK
+		cc.candidate = cc.stable
+	} else {
+		cc.stable = cc.candidate
+	}
+
+	cc.active = false
+	cc.events = append(cc.events, event)
+	return &event
+}
+
+// inCanary deterministically places a fraction of processes in the canary cohort
+func inCanary(processID string, fraction float64) bool {
+	h := fnv.New32a()
+	h.Write([]byte(processID))
+	return float64(h.Sum32()%10000) < fraction*10000
+}
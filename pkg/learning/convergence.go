@@ -0,0 +1,191 @@
+package learning
+
+import (
+	"math"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+)
+
+// ConvergenceConfig configures weight convergence detection and auto-freeze
+type ConvergenceConfig struct {
+	Window              int     `json:"window"`                // Decisions the weight delta must stay below epsilon
+	Epsilon             float64 `json:"epsilon"`               // Maximum L1 weight delta per decision
+	AutoFreeze          bool    `json:"auto_freeze"`           // Stop adapting weights once converged
+	UnfreezeRewardShift float64 `json:"unfreeze_reward_shift"` // Reward mean shift that unfreezes learning (0 disables)
+	Timeout             int     `json:"timeout"`               // Decisions without convergence before a timeout event (0 disables)
+}
+
+// DefaultConvergenceConfig returns the default convergence configuration
+func DefaultConvergenceConfig() ConvergenceConfig {
+	return ConvergenceConfig{
+		Window:              convergenceWindow,
+		Epsilon:             0.001,
+		AutoFreeze:          false,
+		UnfreezeRewardShift: 0.3,
+		Timeout:             0,
+	}
+}
+
+// ConvergenceEventType identifies convergence monitor events
+type ConvergenceEventType string
+
+const (
+	CONVERGED           ConvergenceEventType = "converged"
+	UNFROZEN            ConvergenceEventType = "unfrozen"
+	CONVERGENCE_TIMEOUT ConvergenceEventType = "convergence_timeout"
+)
+
+// ConvergenceEvent is emitted when the convergence state changes
+type ConvergenceEvent struct {
+	Type          ConvergenceEventType     `json:"type"`
+	DecisionCount int                      `json:"decision_count"`
+	Weights       decision.AdaptiveWeights `json:"weights"`
+	RewardMean    float64                  `json:"reward_mean"`
+	Reason        string                   `json:"reason"`
+}
+
+// ConvergenceMonitor detects when weights stop changing and optionally freezes
+// learning until the reward distribution shifts
+type ConvergenceMonitor struct {
+	config        ConvergenceConfig
+	stableCount   int
+	frozen        bool
+	converged     bool
+	timedOut      bool
+	frozenReward  float64
+	recentRewards []float64
+	decisionCount int
+	handlers      []func(ConvergenceEvent)
+	events        []ConvergenceEvent
+}
+
+// NewConvergenceMonitor creates a new convergence monitor
+func NewConvergenceMonitor(config ConvergenceConfig) *ConvergenceMonitor {
+	defaults := DefaultConvergenceConfig()
+	if config.Window <= 0 {
+		config.Window = defaults.Window
+	}
+	if config.Epsilon <= 0 {
+		config.Epsilon = defaults.Epsilon
+	}
+	return &ConvergenceMonitor{
+		config:        config,
+		recentRewards: make([]float64, 0, config.Window),
+		events:        make([]ConvergenceEvent, 0),
+	}
+}
+
+// OnEvent registers a handler called for every convergence event
+func (cm *ConvergenceMonitor) OnEvent(handler func(ConvergenceEvent)) {
+	cm.handlers = append(cm.handlers, handler)
+}
+
+// Observe records a weight update and its reward
+func (cm *ConvergenceMonitor) Observe(previous, current decision.AdaptiveWeights, reward float64) {
+	cm.decisionCount++
+
+	cm.recentRewards = append(cm.recentRewards, reward)
+	if len(cm.recentRewards) > cm.config.Window {
+		cm.recentRewards = cm.recentRewards[1:]
+	}
+
+	// While frozen, watch for a shift in the reward distribution
+	if cm.frozen {
+		if cm.config.UnfreezeRewardShift > 0 && len(cm.recentRewards) >= cm.config.Window {
+			shift := math.Abs(cm.rewardMean() - cm.frozenReward)
+			if shift > cm.config.UnfreezeRewardShift {
+				cm.frozen = false
+				cm.converged = false
+				cm.stableCount = 0
+				cm.emit(UNFROZEN, current, "reward distribution shifted")
+			}
+		}
+		return
+	}
+
+	if weightDelta(previous, current) < cm.config.Epsilon {
+		cm.stableCount++
+	} else {
+		cm.stableCount = 0
+		cm.converged = false
+	}
+
+	if !cm.converged && cm.stableCount >= cm.config.Window {
+		cm.converged = true
+		cm.frozenReward = cm.rewardMean()
+		if cm.config.AutoFreeze {
+			cm.frozen = true
+		}
+		cm.emit(CONVERGED, current, "weight delta below epsilon for full window")
+	}
+
+	if !cm.converged && !cm.timedOut && cm.config.Timeout > 0 && cm.decisionCount >= cm.config.Timeout {
+		cm.timedOut = true
+		cm.emit(CONVERGENCE_TIMEOUT, current, "weights did not converge within timeout")
+	}
+}
+
+// IsFrozen returns true if learning is frozen
+func (cm *ConvergenceMonitor) IsFrozen() bool {
+	return cm.frozen
+}
+
+// IsConverged returns true if weights are currently converged
+func (cm *ConvergenceMonitor) IsConverged() bool {
+	return cm.converged
+}
+
+// Unfreeze resumes learning regardless of reward shift
+func (cm *ConvergenceMonitor) Unfreeze(weights decision.AdaptiveWeights, reason string) {
+	if !cm.frozen {
+		return
+	}
+	cm.frozen = false
+	cm.converged = false
+	cm.stableCount = 0
+	cm.emit(UNFROZEN, weights, reason)
+}
+
+// GetEvents returns all emitted convergence events
+func (cm *ConvergenceMonitor) GetEvents() []ConvergenceEvent {
+	events := make([]ConvergenceEvent, len(cm.events))
+	copy(events, cm.events)
+	return events
+}
+
+// emit records an event and notifies handlers
+func (cm *ConvergenceMonitor) emit(eventType ConvergenceEventType, weights decision.AdaptiveWeights, reason string) {
+	event := ConvergenceEvent{
+		Type:          eventType,
+		DecisionCount: cm.decisionCount,
+		Weights:       weights,
+		RewardMean:    cm.rewardMean(),
+		Reason:        reason,
+	}
+	cm.events = append(cm.events, event)
+	for _, handler := range cm.handlers {
+		handler(event)
+	}
+}
+
+// rewardMean returns the mean of recent rewards
+func (cm *ConvergenceMonitor) rewardMean() float64 {
+	if len(cm.recentRewards) == 0 {
+		return 0.0
+	}
+	total := 0.0
+	for _, r := range cm.recentRewards {
+		total += r
+	}
+	return total / float64(len(cm.recentRewards))
+}
+
+// weightDelta returns the L1 distance between two weight vectors
+func weightDelta(a, b decision.AdaptiveWeights) float64 {
+	return math.Abs(a.QueueDepth-b.QueueDepth) +
+		math.Abs(a.ProcessorLoad-b.ProcessorLoad) +
+		math.Abs(a.NetworkCost-b.NetworkCost) +
+		math.Abs(a.LatencyCost-b.LatencyCost) +
+		math.Abs(a.EnergyCost-b.EnergyCost) +
+		math.Abs(a.PolicyCost-b.PolicyCost)
+}
@@ -0,0 +1,240 @@
+package learning
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+)
+
+// ResetPolicy defines how the learner reacts to detected concept drift
+type ResetPolicy string
+
+const (
+	RESET_NONE    ResetPolicy = "none"    // Only record the drift event
+	RESET_PARTIAL ResetPolicy = "partial" // Blend weights toward the baseline and re-explore
+	RESET_FULL    ResetPolicy = "full"    // Restore baseline weights and forget learned state
+)
+
+// maxDriftEvents is the number of drift events kept, oldest dropped first
+const maxDriftEvents = 100
+
+// DriftConfig configures concept drift detection over the reward stream.
+// Unset fields take their DefaultDriftConfig values.
+type DriftConfig struct {
+	Enabled                bool        `json:"enabled"`
+	Delta                  float64     `json:"delta"`                   // Tolerated change magnitude
+	Threshold              float64     `json:"threshold"`               // Page-Hinkley alarm threshold (lambda)
+	MinSamples             int         `json:"min_samples"`             // Samples before drift can be signalled
+	ResetPolicy            ResetPolicy `json:"reset_policy"`            // What a detected drift resets
+	PartialResetFactor     float64     `json:"partial_reset_factor"`    // Share of baseline blended in on partial reset
+	ReexplorationRate      float64     `json:"reexploration_rate"`      // Exploration rate after a reset
+	ReexplorationDecisions int         `json:"reexploration_decisions"` // Decisions to keep the boosted exploration
+}
+
+// DefaultDriftConfig returns the default drift configuration
+func DefaultDriftConfig() DriftConfig {
+	return DriftConfig{
+		Enabled:                true,
+		Delta:                  0.05,
+		Threshold:              5.0,
+		MinSamples:             30,
+		ResetPolicy:            RESET_PARTIAL,
+		PartialResetFactor:     0.5,
+		ReexplorationRate:      0.3,
+		ReexplorationDecisions: 50,
+	}
+}
+
+// Validate checks the drift configuration
+func (dc DriftConfig) Validate() error {
+	switch dc.ResetPolicy {
+	case "", RESET_NONE, RESET_PARTIAL, RESET_FULL:
+	default:
+		return fmt.Errorf("unknown reset policy %q", dc.ResetPolicy)
+	}
+	switch {
+	case dc.Delta < 0:
+		return fmt.Errorf("delta must be non-negative")
+	case dc.Threshold < 0:
+		return fmt.Errorf("threshold must be non-negative")
+	case dc.MinSamples < 0:
+		return fmt.Errorf("min_samples must be non-negative")
+	case dc.PartialResetFactor < 0 || dc.PartialResetFactor > 1:
+		return fmt.Errorf("partial_reset_factor must be between 0 and 1, got %f", dc.PartialResetFactor)
+	case dc.ReexplorationRate < 0 || dc.ReexplorationRate > 1:
+		return fmt.Errorf("reexploration_rate must be between 0 and 1, got %f", dc.ReexplorationRate)
+	case dc.ReexplorationDecisions < 0:
+		return fmt.Errorf("reexploration_decisions must be non-negative")
+	}
+	return nil
+}
+
+// DriftDirection indicates whether rewards shifted up or down
+type DriftDirection string
+
+const (
+	DRIFT_UP   DriftDirection = "up"
+	DRIFT_DOWN DriftDirection = "down"
+)
+
+// DriftEvent records a detected concept drift
+type DriftEvent struct {
+	DecisionCount int                      `json:"decision_count"`
+	Direction     DriftDirection           `json:"direction"`
+	Statistic     float64                  `json:"statistic"`
+	MeanBefore    float64                  `json:"mean_before"`
+	Policy        ResetPolicy              `json:"policy"`
+	WeightsBefore decision.AdaptiveWeights `json:"weights_before"`
+	WeightsAfter  decision.AdaptiveWeights `json:"weights_after"`
+}
+
+// PageHinkley is a two-sided Page-Hinkley test for changes in a stream's mean
+type PageHinkley struct {
+	delta     float64
+	threshold float64
+	minSample int
+	count     int
+	mean      float64
+	sumUp     float64
+	minUp     float64
+	sumDown   float64
+	minDown   float64
+}
+
+// NewPageHinkley creates a new Page-Hinkley change detector
+func NewPageHinkley(delta, threshold float64, minSamples int) *PageHinkley {
+	return &PageHinkley{
+		delta:     delta,
+		threshold: threshold,
+		minSample: minSamples,
+	}
+}
+
+// Add adds an observation and reports whether a change was detected
+func (ph *PageHinkley) Add(x float64) (bool, DriftDirection, float64) {
+	ph.count++
+	ph.mean += (x - ph.mean) / float64(ph.count)
+
+	ph.sumUp += x - ph.mean - ph.delta
+	ph.minUp = math.Min(ph.minUp, ph.sumUp)
+	ph.sumDown += ph.mean - x - ph.delta
+	ph.minDown = math.Min(ph.minDown, ph.sumDown)
+
+	if ph.count < ph.minSample {
+		return false, "", 0
+	}
+
+	up := ph.sumUp - ph.minUp
+	down := ph.sumDown - ph.minDown
+	if down > ph.threshold && down >= up {
+		return true, DRIFT_DOWN, down
+	}
+	if up > ph.threshold {
+		return true, DRIFT_UP, up
+	}
+	return false, "", math.Max(up, down)
+}
+
+// Mean returns the running mean of observations since the last reset
+func (ph *PageHinkley) Mean() float64 {
+	return ph.mean
+}
+
+// Reset clears the detector state
+func (ph *PageHinkley) Reset() {
+	*ph = PageHinkley{
+		delta:     ph.delta,
+		threshold: ph.threshold,
+		minSample: ph.minSample,
+	}
+}
+
+// DriftDetector watches the reward stream and applies the reset policy
+type DriftDetector struct {
+	config      DriftConfig
+	detector    *PageHinkley
+	events      []DriftEvent
+	reexploring int
+}
+
+// NewDriftDetector creates a new drift detector
+func NewDriftDetector(config DriftConfig) *DriftDetector {
+	defaults := DefaultDriftConfig()
+	if config.Delta <= 0 {
+		config.Delta = defaults.Delta
+	}
+	if config.Threshold <= 0 {
+		config.Threshold = defaults.Threshold
+	}
+	if config.MinSamples <= 0 {
+		config.MinSamples = defaults.MinSamples
+	}
+	if config.ResetPolicy == "" {
+		config.ResetPolicy = defaults.ResetPolicy
+	}
+	if config.PartialResetFactor <= 0 {
+		config.PartialResetFactor = defaults.PartialResetFactor
+	}
+	if config.ReexplorationRate <= 0 {
+		config.ReexplorationRate = defaults.ReexplorationRate
+	}
+	if config.ReexplorationDecisions <= 0 {
+		config.ReexplorationDecisions = defaults.ReexplorationDecisions
+	}
+	return &DriftDetector{
+		config:   config,
+		detector: NewPageHinkley(config.Delta, config.Threshold, config.MinSamples),
+		events:   make([]DriftEvent, 0),
+	}
+}
+
+// Observe feeds a reward to the detector. It returns true if drift was detected.
+func (dd *DriftDetector) Observe(reward float64) (bool, DriftDirection, float64, float64) {
+	if dd.reexploring > 0 {
+		dd.reexploring--
+	}
+
+	meanBefore := dd.detector.Mean()
+	detected, direction, statistic := dd.detector.Add(reward)
+	if detected {
+		dd.detector.Reset()
+		dd.reexploring = dd.config.ReexplorationDecisions
+	}
+	return detected, direction, statistic, meanBefore
+}
+
+// IsReexploring returns true while the boosted post-reset exploration applies
+func (dd *DriftDetector) IsReexploring() bool {
+	return dd.reexploring > 0 && dd.config.ResetPolicy != RESET_NONE
+}
+
+// record adds a drift event, dropping the oldest beyond maxDriftEvents
+func (dd *DriftDetector) record(event DriftEvent) {
+	dd.events = append(dd.events, event)
+	if excess := len(dd.events) - maxDriftEvents; excess > 0 {
+		dd.events = dd.events[excess:]
+	}
+}
+
+// GetEvents returns the most recent detected drift events
+func (dd *DriftDetector) GetEvents() []DriftEvent {
+	events := make([]DriftEvent, len(dd.events))
+	copy(events, dd.events)
+	return events
+}
+
+// blendWeights moves weights toward the baseline by factor (0 = keep, 1 = baseline)
+func blendWeights(current, baseline decision.AdaptiveWeights, factor float64) decision.AdaptiveWeights {
+	factor = math.Max(0.0, math.Min(1.0, factor))
+	blended := decision.AdaptiveWeights{
+		QueueDepth:    current.QueueDepth*(1-factor) + baseline.QueueDepth*factor,
+		ProcessorLoad: current.ProcessorLoad*(1-factor) + baseline.ProcessorLoad*factor,
+		NetworkCost:   current.NetworkCost*(1-factor) + baseline.NetworkCost*factor,
+		LatencyCost:   current.LatencyCost*(1-factor) + baseline.LatencyCost*factor,
+		EnergyCost:    current.EnergyCost*(1-factor) + baseline.EnergyCost*factor,
+		PolicyCost:    current.PolicyCost*(1-factor) + baseline.PolicyCost*factor,
+	}
+	blended.Normalize()
+	return blended
+}
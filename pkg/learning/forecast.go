@@ -0,0 +1,356 @@
+package learning
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Predictor forecasts the next value of a series from the values seen so far
+type Predictor interface {
+	Observe(value float64)
+	Predict() (float64, bool) // False until the predictor has seen enough values
+}
+
+// PredictorFactory creates a predictor for a new series
+type PredictorFactory func() Predictor
+
+// Built-in predictor names
+const (
+	PredictorEWMA     = "ewma"
+	PredictorKalman   = "kalman"
+	PredictorNaive    = "naive"
+	PredictorSeasonal = "seasonal" // Available when a seasonal period is configured
+)
+
+// ForecastMetric is the error measure predictors are ranked by
+type ForecastMetric string
+
+const (
+	RMSE ForecastMetric = "rmse"
+	MAPE ForecastMetric = "mape"
+)
+
+// ForecastConfig configures load forecasting from executor heartbeats. Every
+// predictor forecasts every target; their errors are tracked per executor
+// type, and forecasts come from the most accurate predictor for the target's
+// type unless a fixed predictor is configured.
+type ForecastConfig struct {
+	Enabled    bool                        `json:"enabled"`
+	Window     int                         `json:"window"`      // Forecast errors kept per executor type and predictor (default 100)
+	Metric     ForecastMetric              `json:"metric"`      // Error measure predictors are ranked by (default RMSE)
+	MinSamples int                         `json:"min_samples"` // Errors a predictor needs before it can be selected (default 10)
+	Predictor  string                      `json:"predictor"`   // Predictor to always use ("" = auto-select)
+	Seasonal   SeasonalConfig              `json:"seasonal"`    // Decomposes cyclic load for the seasonal predictor (period 0 disables)
+	Predictors map[string]PredictorFactory `json:"-"`           // Additional predictors, by name
+}
+
+// Validate checks the forecast configuration
+func (fc ForecastConfig) Validate() error {
+	switch {
+	case fc.Window < 0 || fc.MinSamples < 0:
+		return fmt.Errorf("window and min_samples must be non-negative")
+	case fc.Metric != "" && fc.Metric != RMSE && fc.Metric != MAPE:
+		return fmt.Errorf("unknown metric %s", fc.Metric)
+	}
+	if fc.Seasonal.Period != 0 {
+		if err := fc.Seasonal.Validate(); err != nil {
+			return fmt.Errorf("seasonal: %w", err)
+		}
+	}
+	for name, factory := range fc.Predictors {
+		if _, builtin := builtinPredictors[name]; builtin || name == "" || name == PredictorSeasonal {
+			return fmt.Errorf("predictors: invalid or built-in name %q", name)
+		}
+		if factory == nil {
+			return fmt.Errorf("predictors: %s has no factory", name)
+		}
+	}
+	if fc.Predictor != "" {
+		_, builtin := builtinPredictors[fc.Predictor]
+		builtin = builtin || (fc.Predictor == PredictorSeasonal && fc.Seasonal.Period != 0)
+		if _, registered := fc.Predictors[fc.Predictor]; !builtin && !registered {
+			return fmt.Errorf("unknown predictor %s", fc.Predictor)
+		}
+	}
+	return nil
+}
+
+// Forecast is a predicted next value of a series
+type Forecast struct {
+	Value     float64 `json:"value"`
+	Predictor string  `json:"predictor"` // Predictor the forecast came from
+}
+
+// ForecastAccuracy is a predictor's recent error for one executor type
+type ForecastAccuracy struct {
+	ExecutorType string  `json:"executor_type"`
+	Predictor    string  `json:"predictor"`
+	Samples      int     `json:"samples"`
+	RMSE         float64 `json:"rmse"`
+	MAPE         float64 `json:"mape"`     // Over non-zero actual values, as a fraction
+	Selected     bool    `json:"selected"` // Forecasts for the executor type use this predictor
+}
+
+// forecastError is one prediction compared with the value that followed
+type forecastError struct {
+	squared    float64
+	percentage float64
+	hasPercent bool // False when the actual value was zero
+}
+
+// Forecaster tracks predictor accuracy and routes forecasts to the best
+// predictor per executor type
+type Forecaster struct {
+	config     ForecastConfig
+	names      []string // Built-ins first, then additional predictors by name
+	factories  map[string]PredictorFactory
+	series     map[string]map[string]Predictor // Series key -> predictor name -> predictor
+	seriesType map[string]string               // Series key -> executor type
+	errors     map[string]map[string][]forecastError
+	mu         sync.Mutex
+}
+
+// builtinPredictors are available to every forecaster, by name. EWMA is used
+// until a predictor has enough samples to be selected.
+var builtinPredictors = map[string]PredictorFactory{
+	PredictorEWMA:   func() Predictor { return &ewmaPredictor{alpha: 0.3} },
+	PredictorKalman: func() Predictor { return &kalmanPredictor{filter: NewKalmanFilter(0.01, 0.05)} },
+	PredictorNaive:  func() Predictor { return &naivePredictor{} },
+}
+
+// NewForecaster creates a forecaster, applying defaults
+func NewForecaster(config ForecastConfig) *Forecaster {
+	if config.Window == 0 {
+		config.Window = 100
+	}
+	if config.Metric == "" {
+		config.Metric = RMSE
+	}
+	if config.MinSamples == 0 {
+		config.MinSamples = 10
+	}
+
+	factories := make(map[string]PredictorFactory, len(builtinPredictors)+len(config.Predictors))
+	names := []string{PredictorEWMA, PredictorKalman, PredictorNaive}
+	for name, factory := range builtinPredictors {
+		factories[name] = factory
+	}
+	if config.Seasonal.Period != 0 {
+		seasonal := config.Seasonal
+		factories[PredictorSeasonal] = func() Predictor { return NewCompositePredictor(seasonal) }
+		names = append(names, PredictorSeasonal)
+	}
+	extra := make([]string, 0, len(config.Predictors))
+	for name, factory := range config.Predictors {
+		factories[name] = factory
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+
+	return &Forecaster{
+		config:     config,
+		names:      append(names, extra...),
+		factories:  factories,
+		series:     make(map[string]map[string]Predictor),
+		seriesType: make(map[string]string),
+		errors:     make(map[string]map[string][]forecastError),
+	}
+}
+
+// Observe scores every predictor's forecast for a series against the value
+// that arrived, then feeds them the value
+func (f *Forecaster) Observe(key, executorType string, value float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	predictors, exists := f.series[key]
+	if !exists {
+		predictors = make(map[string]Predictor, len(f.names))
+		for _, name := range f.names {
+			predictors[name] = f.factories[name]()
+		}
+		f.series[key] = predictors
+	}
+	f.seriesType[key] = executorType
+
+	errors, exists := f.errors[executorType]
+	if !exists {
+		errors = make(map[string][]forecastError)
+		f.errors[executorType] = errors
+	}
+	for _, name := range f.names {
+		if predicted, ok := predictors[name].Predict(); ok {
+			sample := forecastError{squared: (predicted - value) * (predicted - value)}
+			if value != 0 {
+				sample.percentage = math.Abs((value - predicted) / value)
+				sample.hasPercent = true
+			}
+			window := append(errors[name], sample)
+			if len(window) > f.config.Window {
+				window = window[len(window)-f.config.Window:]
+			}
+			errors[name] = window
+		}
+		predictors[name].Observe(value)
+	}
+}
+
+// Forecast predicts the next value of a series
+func (f *Forecaster) Forecast(key string) (Forecast, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	predictors, exists := f.series[key]
+	if !exists {
+		return Forecast{}, false
+	}
+	name := f.selected(f.seriesType[key])
+	value, ok := predictors[name].Predict()
+	return Forecast{Value: value, Predictor: name}, ok
+}
+
+// Accuracy returns the recent error of every predictor, ordered by executor
+// type and then predictor
+func (f *Forecaster) Accuracy() []ForecastAccuracy {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	types := make([]string, 0, len(f.errors))
+	for executorType := range f.errors {
+		types = append(types, executorType)
+	}
+	sort.Strings(types)
+
+	accuracy := make([]ForecastAccuracy, 0, len(types)*len(f.names))
+	for _, executorType := range types {
+		selected := f.selected(executorType)
+		for _, name := range f.names {
+			entry := f.accuracy(executorType, name)
+			entry.Selected = name == selected
+			accuracy = append(accuracy, entry)
+		}
+	}
+	return accuracy
+}
+
+// Forget drops a series, e.g. when its target is removed. Accuracy already
+// recorded for its executor type is kept.
+func (f *Forecaster) Forget(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.series, key)
+	delete(f.seriesType, key)
+}
+
+// selected returns the predictor forecasts for an executor type use: the
+// configured one, or the one with the lowest error among those with enough
+// samples, falling back to the first predictor
+func (f *Forecaster) selected(executorType string) string {
+	if f.config.Predictor != "" {
+		return f.config.Predictor
+	}
+	best, bestError := f.names[0], math.Inf(1)
+	for _, name := range f.names {
+		entry := f.accuracy(executorType, name)
+		if entry.Samples < f.config.MinSamples {
+			continue
+		}
+		measure := entry.RMSE
+		if f.config.Metric == MAPE {
+			measure = entry.MAPE
+			if !f.hasPercent(executorType, name) {
+				continue
+			}
+		}
+		if measure < bestError {
+			best, bestError = name, measure
+		}
+	}
+	return best
+}
+
+// accuracy summarizes a predictor's error window for an executor type
+func (f *Forecaster) accuracy(executorType, name string) ForecastAccuracy {
+	window := f.errors[executorType][name]
+	entry := ForecastAccuracy{ExecutorType: executorType, Predictor: name, Samples: len(window)}
+	if len(window) == 0 {
+		return entry
+	}
+	squared, percentage, percentSamples := 0.0, 0.0, 0
+	for _, sample := range window {
+		squared += sample.squared
+		if sample.hasPercent {
+			percentage += sample.percentage
+			percentSamples++
+		}
+	}
+	entry.RMSE = math.Sqrt(squared / float64(len(window)))
+	if percentSamples > 0 {
+		entry.MAPE = percentage / float64(percentSamples)
+	}
+	return entry
+}
+
+// hasPercent returns true if a predictor has any error measured against a
+// non-zero value
+func (f *Forecaster) hasPercent(executorType, name string) bool {
+	for _, sample := range f.errors[executorType][name] {
+		if sample.hasPercent {
+			return true
+		}
+	}
+	return false
+}
+
+// naivePredictor forecasts the last value seen
+type naivePredictor struct {
+	last float64
+	seen bool
+}
+
+func (np *naivePredictor) Observe(value float64) {
+	np.last, np.seen = value, true
+}
+
+func (np *naivePredictor) Predict() (float64, bool) {
+	return np.last, np.seen
+}
+
+// ewmaPredictor forecasts an exponentially weighted moving average
+type ewmaPredictor struct {
+	alpha   float64
+	average float64
+	seen    bool
+}
+
+func (ep *ewmaPredictor) Observe(value float64) {
+	if !ep.seen {
+		ep.average, ep.seen = value, true
+		return
+	}
+	ep.average += ep.alpha * (value - ep.average)
+}
+
+func (ep *ewmaPredictor) Predict() (float64, bool) {
+	return ep.average, ep.seen
+}
+
+// kalmanPredictor forecasts the Kalman filter estimate
+type kalmanPredictor struct {
+	filter *KalmanFilter
+}
+
+func (kp *kalmanPredictor) Observe(value float64) {
+	kp.filter.Update(value)
+}
+
+func (kp *kalmanPredictor) Predict() (float64, bool) {
+	return kp.filter.Estimate(), kp.filter.initialized
+}
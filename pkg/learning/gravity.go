@@ -0,0 +1,236 @@
+package learning
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// bytesPerGB converts transferred bytes to the gigabytes gravity is fitted in
+const bytesPerGB = float64(units.GiB)
+
+// Gravity factors are clamped so a few outliers cannot dominate scoring
+const (
+	minGravityFactor = 0.1
+	maxGravityFactor = 10.0
+)
+
+// GravityConfig configures online learning of data gravity: how much moving
+// a process's data to a target adds to its end-to-end latency and cost
+type GravityConfig struct {
+	Enabled           bool    `json:"enabled"`
+	PriorSecondsPerGB float64 `json:"prior_seconds_per_gb"` // Latency per GB moved that scoring assumes (default 8)
+	MinSamples        int     `json:"min_samples"`          // Observations before a location's estimate is used (default 10)
+	Decay             float64 `json:"decay"`                // Weight older observations keep per new one (default 0.99)
+	Confidence        float64 `json:"confidence"`           // Width of confidence bounds in standard errors (default 1.96)
+}
+
+// Validate checks the gravity configuration
+func (gc GravityConfig) Validate() error {
+	switch {
+	case gc.PriorSecondsPerGB < 0:
+		return fmt.Errorf("prior_seconds_per_gb must be non-negative")
+	case gc.MinSamples < 0:
+		return fmt.Errorf("min_samples must be non-negative")
+	case gc.Decay < 0 || gc.Decay > 1:
+		return fmt.Errorf("decay must be between 0 and 1, got %f", gc.Decay)
+	case gc.Confidence < 0:
+		return fmt.Errorf("confidence must be non-negative")
+	}
+	return nil
+}
+
+// GravityObservation is the data movement and realized outcome of one offload
+type GravityObservation struct {
+	Location string        `json:"location"`  // Target location
+	DataSize int64         `json:"data_size"` // Bytes moved to the target
+	Latency  time.Duration `json:"latency"`   // Realized end-to-end latency
+	Cost     float64       `json:"cost"`      // Realized cost
+}
+
+// GravityEstimate is the fitted data gravity of a location, or of all
+// locations when Location is empty
+type GravityEstimate struct {
+	Location     string  `json:"location"`
+	Samples      int     `json:"samples"`
+	SecondsPerGB float64 `json:"seconds_per_gb"` // Latency slope
+	SecondsLower float64 `json:"seconds_lower"`
+	SecondsUpper float64 `json:"seconds_upper"`
+	CostPerGB    float64 `json:"cost_per_gb"` // Cost slope
+	CostLower    float64 `json:"cost_lower"`
+	CostUpper    float64 `json:"cost_upper"`
+	Factor       float64 `json:"factor"` // Data size multiplier for scoring (1 = prior)
+	Fitted       bool    `json:"fitted"` // Enough varied observations for a regression
+}
+
+// regression is an exponentially weighted least squares fit of y = a + b*x
+type regression struct {
+	w, sx, sy, sxx, sxy, syy float64
+}
+
+// add weights the existing observations by decay and adds a new one
+func (r *regression) add(x, y, decay float64) {
+	r.w = r.w*decay + 1
+	r.sx = r.sx*decay + x
+	r.sy = r.sy*decay + y
+	r.sxx = r.sxx*decay + x*x
+	r.sxy = r.sxy*decay + x*y
+	r.syy = r.syy*decay + y*y
+}
+
+// slope returns the fitted slope and its standard error, and false when the
+// observations cannot support a fit
+func (r *regression) slope() (float64, float64, bool) {
+	if r.w <= 2 {
+		return 0, 0, false
+	}
+	varX := r.sxx - r.sx*r.sx/r.w
+	if varX <= 1e-12 {
+		return 0, 0, false
+	}
+	covXY := r.sxy - r.sx*r.sy/r.w
+	b := covXY / varX
+	residual := math.Max(0, r.syy-r.sy*r.sy/r.w-b*covXY)
+	return b, math.Sqrt(residual / (r.w - 2) / varX), true
+}
+
+// locationGravity holds the regressions for one location
+type locationGravity struct {
+	latency regression
+	cost    regression
+	samples int
+}
+
+// GravityLearner regresses realized latency and cost against the data moved
+// by each offload, per target location and overall. The learned factor
+// scales data size in network cost scoring, moving from the prior only as
+// far as the confidence bounds require.
+type GravityLearner struct {
+	config    GravityConfig
+	locations map[string]*locationGravity // "" = all locations
+	mu        sync.RWMutex
+}
+
+// NewGravityLearner creates a gravity learner, applying defaults
+func NewGravityLearner(config GravityConfig) *GravityLearner {
+	if config.PriorSecondsPerGB == 0 {
+		config.PriorSecondsPerGB = 8
+	}
+	if config.MinSamples == 0 {
+		config.MinSamples = 10
+	}
+	if config.Decay == 0 {
+		config.Decay = 0.99
+	}
+	if config.Confidence == 0 {
+		config.Confidence = 1.96
+	}
+	return &GravityLearner{
+		config:    config,
+		locations: make(map[string]*locationGravity),
+	}
+}
+
+// Observe adds an offload outcome to the location's and the overall fit
+func (gl *GravityLearner) Observe(observation GravityObservation) {
+	if observation.DataSize < 0 || observation.Latency <= 0 {
+		return // Nothing was measured
+	}
+
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	x := float64(observation.DataSize) / bytesPerGB
+	keys := []string{""}
+	if observation.Location != "" {
+		keys = append(keys, observation.Location)
+	}
+	for _, key := range keys {
+		location, exists := gl.locations[key]
+		if !exists {
+			location = &locationGravity{}
+			gl.locations[key] = location
+		}
+		location.latency.add(x, observation.Latency.Seconds(), gl.config.Decay)
+		location.cost.add(x, observation.Cost, gl.config.Decay)
+		location.samples++
+	}
+}
+
+// Estimate returns the fitted gravity of a location ("" for all locations)
+func (gl *GravityLearner) Estimate(location string) GravityEstimate {
+	gl.mu.RLock()
+	defer gl.mu.RUnlock()
+
+	return gl.estimate(location)
+}
+
+// Estimates returns the overall estimate followed by each location's,
+// ordered by location
+func (gl *GravityLearner) Estimates() []GravityEstimate {
+	gl.mu.RLock()
+	defer gl.mu.RUnlock()
+
+	keys := make([]string, 0, len(gl.locations))
+	for key := range gl.locations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	estimates := make([]GravityEstimate, 0, len(keys))
+	for _, key := range keys {
+		estimates = append(estimates, gl.estimate(key))
+	}
+	return estimates
+}
+
+// Factors returns the scoring factor of every location with a usable fit,
+// keyed by location, with the overall factor under ""
+func (gl *GravityLearner) Factors() map[string]float64 {
+	gl.mu.RLock()
+	defer gl.mu.RUnlock()
+
+	factors := make(map[string]float64)
+	for key := range gl.locations {
+		if estimate := gl.estimate(key); estimate.Fitted && estimate.Samples >= gl.config.MinSamples {
+			factors[key] = estimate.Factor
+		}
+	}
+	return factors
+}
+
+// estimate fits a location's regressions
+func (gl *GravityLearner) estimate(key string) GravityEstimate {
+	estimate := GravityEstimate{Location: key, Factor: 1.0}
+	location, exists := gl.locations[key]
+	if !exists {
+		return estimate
+	}
+	estimate.Samples = location.samples
+
+	seconds, secondsErr, ok := location.latency.slope()
+	if !ok {
+		return estimate
+	}
+	cost, costErr, _ := location.cost.slope()
+	z := gl.config.Confidence
+
+	estimate.Fitted = true
+	estimate.SecondsPerGB = seconds
+	estimate.SecondsLower = seconds - z*secondsErr
+	estimate.SecondsUpper = seconds + z*secondsErr
+	estimate.CostPerGB = cost
+	estimate.CostLower = cost - z*costErr
+	estimate.CostUpper = cost + z*costErr
+
+	// Use the plausible slope closest to the prior, so the factor only moves
+	// as far as the evidence supports
+	prior := gl.config.PriorSecondsPerGB
+	plausible := math.Max(estimate.SecondsLower, math.Min(estimate.SecondsUpper, prior))
+	estimate.Factor = math.Max(minGravityFactor, math.Min(maxGravityFactor, plausible/prior))
+	return estimate
+}
@@ -0,0 +1,223 @@
+package learning
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// HealthConfig configures target health scoring from executor heartbeats
+type HealthConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`  // Expected heartbeat interval (default 10s)
+	HalfLife time.Duration `json:"half_life"` // Time for old observations to lose half their weight (default 60s)
+}
+
+// Validate checks the health configuration
+func (hc HealthConfig) Validate() error {
+	if hc.Interval < 0 || hc.HalfLife < 0 {
+		return fmt.Errorf("health intervals must be non-negative")
+	}
+	return nil
+}
+
+// Heartbeat is one report from an executor's heartbeat or metrics stream
+type Heartbeat struct {
+	TargetID     string        `json:"target_id"`
+	Timestamp    time.Time     `json:"timestamp"`
+	Latency      time.Duration `json:"latency"`       // Heartbeat round-trip latency
+	Requests     int           `json:"requests"`      // Requests served since the previous heartbeat
+	Errors       int           `json:"errors"`        // Requests failed since the previous heartbeat
+	ReportedLoad float64       `json:"reported_load"` // Load the executor advertised (0.0-1.0)
+	ActualLoad   float64       `json:"actual_load"`   // Load observed from its queue (0.0-1.0)
+}
+
+// HealthScore summarizes a target's decayed heartbeat history
+type HealthScore struct {
+	TargetID      string    `json:"target_id"`
+	Score         float64   `json:"score"`         // Overall health (0.0-1.0)
+	Jitter        float64   `json:"jitter"`        // Latency coefficient of variation
+	ErrorRate     float64   `json:"error_rate"`    // Failed fraction of requests
+	LoadAccuracy  float64   `json:"load_accuracy"` // 1 - mean |reported - actual| load
+	Freshness     float64   `json:"freshness"`     // Decays once heartbeats stop arriving
+	LastHeartbeat time.Time `json:"last_heartbeat"`
+	Heartbeats    int       `json:"heartbeats"`
+}
+
+// targetHealth holds the exponentially decayed statistics for one target
+type targetHealth struct {
+	lastHeartbeat time.Time
+	latencyMean   float64
+	latencyVar    float64
+	errorRate     float64
+	loadError     float64
+	heartbeats    int
+}
+
+// HealthMonitor scores targets from their heartbeat streams. Observations
+// decay with age, so a target recovers as errors and jitter stop, and loses
+// health when heartbeats stop arriving.
+type HealthMonitor struct {
+	config  HealthConfig
+	targets map[string]*targetHealth
+	mu      sync.RWMutex
+}
+
+// NewHealthMonitor creates a health monitor, applying default intervals
+func NewHealthMonitor(config HealthConfig) *HealthMonitor {
+	if config.Interval == 0 {
+		config.Interval = 10 * time.Second
+	}
+	if config.HalfLife == 0 {
+		config.HalfLife = 60 * time.Second
+	}
+	return &HealthMonitor{
+		config:  config,
+		targets: make(map[string]*targetHealth),
+	}
+}
+
+// Observe ingests a heartbeat. Heartbeats older than the latest one seen for
+// the target are ignored.
+func (hm *HealthMonitor) Observe(heartbeat Heartbeat) error {
+	if heartbeat.TargetID == "" {
+		return fmt.Errorf("heartbeat has no target ID")
+	}
+	if heartbeat.Requests < 0 || heartbeat.Errors < 0 || heartbeat.Latency < 0 {
+		return fmt.Errorf("heartbeat from %s has negative counters", heartbeat.TargetID)
+	}
+	if heartbeat.Timestamp.IsZero() {
+		heartbeat.Timestamp = time.Now()
+	}
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	latency := float64(heartbeat.Latency)
+	loadError := math.Min(1.0, math.Abs(heartbeat.ReportedLoad-heartbeat.ActualLoad))
+	errorRate, hasRequests := 0.0, heartbeat.Requests > 0
+	if hasRequests {
+		errorRate = math.Min(1.0, float64(heartbeat.Errors)/float64(heartbeat.Requests))
+	}
+
+	health, exists := hm.targets[heartbeat.TargetID]
+	if !exists {
+		hm.targets[heartbeat.TargetID] = &targetHealth{
+			lastHeartbeat: heartbeat.Timestamp,
+			latencyMean:   latency,
+			errorRate:     errorRate,
+			loadError:     loadError,
+			heartbeats:    1,
+		}
+		return nil
+	}
+	if heartbeat.Timestamp.Before(health.lastHeartbeat) {
+		return nil
+	}
+
+	// Weight the new observation by the time it covers, so bursts of
+	// heartbeats do not outweigh steady history
+	elapsed := heartbeat.Timestamp.Sub(health.lastHeartbeat)
+	if elapsed < hm.config.Interval {
+		elapsed = hm.config.Interval
+	}
+	alpha := 1 - hm.decay(elapsed)
+
+	diff := latency - health.latencyMean
+	increment := alpha * diff
+	health.latencyMean += increment
+	health.latencyVar = (1 - alpha) * (health.latencyVar + diff*increment)
+	if hasRequests {
+		health.errorRate += alpha * (errorRate - health.errorRate)
+	}
+	health.loadError += alpha * (loadError - health.loadError)
+	health.lastHeartbeat = heartbeat.Timestamp
+	health.heartbeats++
+	return nil
+}
+
+// Score returns the health of a target at the given time, and false if no
+// heartbeat has been received from it
+func (hm *HealthMonitor) Score(targetID string, now time.Time) (HealthScore, bool) {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	health, exists := hm.targets[targetID]
+	if !exists {
+		return HealthScore{}, false
+	}
+	return hm.score(targetID, health, now), true
+}
+
+// Scores returns the health of every target that has sent a heartbeat,
+// ordered by target ID
+func (hm *HealthMonitor) Scores(now time.Time) []HealthScore {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	scores := make([]HealthScore, 0, len(hm.targets))
+	for targetID, health := range hm.targets {
+		scores = append(scores, hm.score(targetID, health, now))
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].TargetID < scores[j].TargetID })
+	return scores
+}
+
+// ApplyTargets returns the targets with their reliability scaled by their
+// health. Targets without heartbeats keep their reported reliability.
+func (hm *HealthMonitor) ApplyTargets(targets []models.OffloadTarget, now time.Time) []models.OffloadTarget {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	applied := make([]models.OffloadTarget, len(targets))
+	for i, target := range targets {
+		if health, exists := hm.targets[target.ID]; exists {
+			target.Reliability *= hm.score(target.ID, health, now).Score
+		}
+		applied[i] = target
+	}
+	return applied
+}
+
+// Forget drops a target's heartbeat history
+func (hm *HealthMonitor) Forget(targetID string) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	delete(hm.targets, targetID)
+}
+
+// score combines a target's statistics into a health score
+func (hm *HealthMonitor) score(targetID string, health *targetHealth, now time.Time) HealthScore {
+	jitter := 0.0
+	if health.latencyMean > 0 {
+		jitter = math.Sqrt(health.latencyVar) / health.latencyMean
+	}
+
+	// Missing a few heartbeats is tolerated before health starts to decay
+	freshness := 1.0
+	if silence := now.Sub(health.lastHeartbeat) - 3*hm.config.Interval; silence > 0 {
+		freshness = hm.decay(silence)
+	}
+
+	loadAccuracy := 1 - health.loadError
+	return HealthScore{
+		TargetID:      targetID,
+		Score:         (1 - health.errorRate) * loadAccuracy * freshness / (1 + jitter),
+		Jitter:        jitter,
+		ErrorRate:     health.errorRate,
+		LoadAccuracy:  loadAccuracy,
+		Freshness:     freshness,
+		LastHeartbeat: health.lastHeartbeat,
+		Heartbeats:    health.heartbeats,
+	}
+}
+
+// decay returns the weight remaining after the given time
+func (hm *HealthMonitor) decay(elapsed time.Duration) float64 {
+	return math.Exp(-math.Ln2 * float64(elapsed) / float64(hm.config.HalfLife))
+}
@@ -0,0 +1,211 @@
+package learning
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Smoothable metric names
+const (
+	MetricQueueDepth      = "queue_depth"
+	MetricQueueWaitTime   = "queue_wait_time"
+	MetricQueueThroughput = "queue_throughput"
+	MetricComputeUsage    = "compute_usage"
+	MetricMemoryUsage     = "memory_usage"
+	MetricDiskUsage       = "disk_usage"
+	MetricNetworkUsage    = "network_usage"
+	MetricMasterUsage     = "master_usage"
+	MetricNetworkLatency  = "network_latency" // Per-target, keyed by target ID
+	MetricCurrentLoad     = "current_load"    // Per-target, keyed by target ID
+)
+
+// KalmanFilter is a one-dimensional Kalman filter for smoothing noisy metrics
+type KalmanFilter struct {
+	processNoise     float64 // Q: how fast the true value is expected to change
+	measurementNoise float64 // R: how noisy measurements are
+	estimate         float64
+	errorCovariance  float64
+	initialized      bool
+}
+
+// NewKalmanFilter creates a new Kalman filter
+func NewKalmanFilter(processNoise, measurementNoise float64) *KalmanFilter {
+	return &KalmanFilter{
+		processNoise:     processNoise,
+		measurementNoise: measurementNoise,
+		errorCovariance:  1.0,
+	}
+}
+
+// Update incorporates a measurement and returns the smoothed estimate
+func (kf *KalmanFilter) Update(measurement float64) float64 {
+	if math.IsNaN(measurement) || math.IsInf(measurement, 0) {
+		return kf.estimate
+	}
+
+	if !kf.initialized {
+		kf.estimate = measurement
+		kf.initialized = true
+		return kf.estimate
+	}
+
+	// Predict
+	predictedCovariance := kf.errorCovariance + kf.processNoise
+
+	// Correct
+	gain := predictedCovariance / (predictedCovariance + kf.measurementNoise)
+	kf.estimate += gain * (measurement - kf.estimate)
+	kf.errorCovariance = (1 - gain) * predictedCovariance
+
+	return kf.estimate
+}
+
+// Estimate returns the current smoothed estimate
+func (kf *KalmanFilter) Estimate() float64 {
+	return kf.estimate
+}
+
+// Reset clears the filter state
+func (kf *KalmanFilter) Reset() {
+	kf.estimate = 0
+	kf.errorCovariance = 1.0
+	kf.initialized = false
+}
+
+// KalmanConfig configures smoothing for a single metric
+type KalmanConfig struct {
+	ProcessNoise     float64 `json:"process_noise"`
+	MeasurementNoise float64 `json:"measurement_noise"`
+}
+
+// SmoothingConfig configures the optional metric smoothing stage
+type SmoothingConfig struct {
+	Enabled bool                    `json:"enabled"`
+	Metrics map[string]KalmanConfig `json:"metrics"` // Metric name -> filter parameters
+}
+
+// DefaultSmoothingConfig returns a smoothing configuration for the jittery
+// utilization and latency metrics
+func DefaultSmoothingConfig() SmoothingConfig {
+	return SmoothingConfig{
+		Enabled: true,
+		Metrics: map[string]KalmanConfig{
+			MetricComputeUsage:   {ProcessNoise: 0.01, MeasurementNoise: 0.05},
+			MetricMemoryUsage:    {ProcessNoise: 0.01, MeasurementNoise: 0.05},
+			MetricNetworkUsage:   {ProcessNoise: 0.01, MeasurementNoise: 0.1},
+			MetricNetworkLatency: {ProcessNoise: 0.05, MeasurementNoise: 0.5},
+		},
+	}
+}
+
+// MetricSmoother applies per-metric Kalman filters to system and target state
+type MetricSmoother struct {
+	config  SmoothingConfig
+	filters map[string]*KalmanFilter
+	mu      sync.Mutex
+}
+
+// NewMetricSmoother creates a new metric smoother
+func NewMetricSmoother(config SmoothingConfig) *MetricSmoother {
+	return &MetricSmoother{
+		config:  config,
+		filters: make(map[string]*KalmanFilter),
+	}
+}
+
+// SmoothState returns the system state with configured metrics smoothed
+func (ms *MetricSmoother) SmoothState(state models.SystemState) models.SystemState {
+	if !ms.config.Enabled {
+		return state
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if v, ok := ms.smooth(MetricQueueDepth, "", float64(state.QueueDepth)); ok {
+		state.QueueDepth = int(math.Round(math.Max(0, v)))
+	}
+	if v, ok := ms.smooth(MetricQueueWaitTime, "", float64(state.QueueWaitTime)); ok {
+		state.QueueWaitTime = time.Duration(math.Max(0, v))
+	}
+	if v, ok := ms.smooth(MetricQueueThroughput, "", state.QueueThroughput); ok {
+		state.QueueThroughput = math.Max(0, v)
+	}
+	if v, ok := ms.smooth(MetricComputeUsage, "", float64(state.ComputeUsage)); ok {
+		state.ComputeUsage = clampUtilization(v)
+	}
+	if v, ok := ms.smooth(MetricMemoryUsage, "", float64(state.MemoryUsage)); ok {
+		state.MemoryUsage = clampUtilization(v)
+	}
+	if v, ok := ms.smooth(MetricDiskUsage, "", float64(state.DiskUsage)); ok {
+		state.DiskUsage = clampUtilization(v)
+	}
+	if v, ok := ms.smooth(MetricNetworkUsage, "", float64(state.NetworkUsage)); ok {
+		state.NetworkUsage = clampUtilization(v)
+	}
+	if v, ok := ms.smooth(MetricMasterUsage, "", float64(state.MasterUsage)); ok {
+		state.MasterUsage = clampUtilization(v)
+	}
+
+	return state
+}
+
+// SmoothTargets returns the targets with configured per-target metrics smoothed
+func (ms *MetricSmoother) SmoothTargets(targets []models.OffloadTarget) []models.OffloadTarget {
+	if !ms.config.Enabled {
+		return targets
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	smoothed := make([]models.OffloadTarget, len(targets))
+	for i, target := range targets {
+		if v, ok := ms.smooth(MetricNetworkLatency, target.ID, float64(target.NetworkLatency)); ok {
+			target.NetworkLatency = time.Duration(math.Max(0, v))
+		}
+		if v, ok := ms.smooth(MetricCurrentLoad, target.ID, target.CurrentLoad); ok {
+			target.CurrentLoad = math.Max(0.0, math.Min(1.0, v))
+		}
+		smoothed[i] = target
+	}
+
+	return smoothed
+}
+
+// Reset clears all filter state
+func (ms *MetricSmoother) Reset() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.filters = make(map[string]*KalmanFilter)
+}
+
+// smooth feeds a measurement to the filter for a metric if it is configured
+func (ms *MetricSmoother) smooth(metric, key string, measurement float64) (float64, bool) {
+	params, ok := ms.config.Metrics[metric]
+	if !ok {
+		return measurement, false
+	}
+
+	filterKey := metric
+	if key != "" {
+		filterKey = metric + "/" + key
+	}
+
+	filter, exists := ms.filters[filterKey]
+	if !exists {
+		filter = NewKalmanFilter(params.ProcessNoise, params.MeasurementNoise)
+		ms.filters[filterKey] = filter
+	}
+
+	return filter.Update(measurement), true
+}
+
+// clampUtilization clamps a smoothed value to the valid utilization range
+func clampUtilization(v float64) models.Utilization {
+	return models.Utilization(math.Max(0.0, math.Min(1.0, v)))
+}
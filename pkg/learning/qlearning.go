@@ -0,0 +1,147 @@
+package learning
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// QLearningConfig configures tabular Q-learning with epsilon-greedy
+// exploration. Epsilon decays multiplicatively after every update until it
+// reaches MinEpsilon; an initial epsilon below the floor is kept.
+type QLearningConfig struct {
+	LearningRate float64 `json:"learning_rate"` // Step size of value updates (default 0.1)
+	Discount     float64 `json:"discount"`      // Weight of future rewards (default 0.9)
+	Epsilon      float64 `json:"epsilon"`       // Initial exploration rate (default 1.0)
+	EpsilonDecay float64 `json:"epsilon_decay"` // Epsilon multiplier per update (default 0.99)
+	MinEpsilon   float64 `json:"min_epsilon"`   // Exploration rate floor (default 0.05)
+	Seed         int64   `json:"seed"`          // Exploration seed (0 = time-based)
+}
+
+// Validate checks the Q-learning configuration
+func (qc QLearningConfig) Validate() error {
+	for _, value := range []float64{qc.LearningRate, qc.Discount, qc.Epsilon, qc.EpsilonDecay, qc.MinEpsilon} {
+		if value < 0 || value > 1 {
+			return fmt.Errorf("learning_rate, discount, epsilon, epsilon_decay and min_epsilon must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+// QLearner learns the value of a fixed set of actions in discrete states
+type QLearner struct {
+	config  QLearningConfig
+	actions int
+	table   map[string][]float64
+	epsilon float64
+	updates int
+	rng     *rand.Rand
+	mu      sync.Mutex
+}
+
+// NewQLearner creates a Q-learner over the given number of actions, applying
+// default rates
+func NewQLearner(config QLearningConfig, actions int) *QLearner {
+	if config.LearningRate == 0 {
+		config.LearningRate = 0.1
+	}
+	if config.Discount == 0 {
+		config.Discount = 0.9
+	}
+	if config.Epsilon == 0 {
+		config.Epsilon = 1.0
+	}
+	if config.EpsilonDecay == 0 {
+		config.EpsilonDecay = 0.99
+	}
+	if config.MinEpsilon == 0 {
+		config.MinEpsilon = 0.05
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &QLearner{
+		config:  config,
+		actions: actions,
+		table:   make(map[string][]float64),
+		epsilon: config.Epsilon,
+		rng:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Choose picks an action for a state: a random one with probability epsilon,
+// otherwise the highest valued, preferring lower actions on ties
+func (ql *QLearner) Choose(state string) int {
+	ql.mu.Lock()
+	defer ql.mu.Unlock()
+
+	if ql.rng.Float64() < ql.epsilon {
+		return ql.rng.Intn(ql.actions)
+	}
+	return ql.best(state)
+}
+
+// Update moves the value of taking an action in a state towards the reward
+// plus the discounted value of the state it led to, then decays epsilon
+func (ql *QLearner) Update(state string, action int, reward float64, next string) {
+	ql.mu.Lock()
+	defer ql.mu.Unlock()
+
+	values := ql.values(state)
+	target := reward + ql.config.Discount*ql.values(next)[ql.best(next)]
+	values[action] += ql.config.LearningRate * (target - values[action])
+
+	ql.updates++
+	if ql.epsilon > ql.config.MinEpsilon {
+		ql.epsilon = math.Max(ql.epsilon*ql.config.EpsilonDecay, ql.config.MinEpsilon)
+	}
+}
+
+// Values returns the learned action values of a state
+func (ql *QLearner) Values(state string) []float64 {
+	ql.mu.Lock()
+	defer ql.mu.Unlock()
+
+	return append([]float64(nil), ql.values(state)...)
+}
+
+// Epsilon returns the current exploration rate
+func (ql *QLearner) Epsilon() float64 {
+	ql.mu.Lock()
+	defer ql.mu.Unlock()
+
+	return ql.epsilon
+}
+
+// Updates returns the number of value updates made
+func (ql *QLearner) Updates() int {
+	ql.mu.Lock()
+	defer ql.mu.Unlock()
+
+	return ql.updates
+}
+
+// values returns a state's action values, creating them at zero
+func (ql *QLearner) values(state string) []float64 {
+	values, exists := ql.table[state]
+	if !exists {
+		values = make([]float64, ql.actions)
+		ql.table[state] = values
+	}
+	return values
+}
+
+// best returns the highest valued action of a state
+func (ql *QLearner) best(state string) int {
+	values := ql.values(state)
+	best := 0
+	for action, value := range values {
+		if value > values[best] {
+			best = action
+		}
+	}
+	return best
+}
@@ -0,0 +1,474 @@
+package learning
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// SizeClass is one step of the CPU and memory classes targets can be resized to
+type SizeClass struct {
+	Name        string  `json:"name"`
+	CPU         float64 `json:"cpu"`           // Cores
+	Memory      int64   `json:"memory"`        // Bytes
+	CostPerHour float64 `json:"cost_per_hour"` // Running cost of the class
+}
+
+// ResizeMode selects how resizes are chosen
+type ResizeMode string
+
+const (
+	RULE_BASED    ResizeMode = "rule_based"    // Load thresholds sustained over time
+	REINFORCEMENT ResizeMode = "reinforcement" // Q-learning over class and load
+)
+
+// ResizeConfig configures resize recommendations from executor heartbeats.
+// In rule-based mode, a target whose load stays above HighLoad (or below
+// LowLoad) for Sustain is recommended the next larger (or smaller) class on
+// the ladder. In reinforcement mode, a Q-learner picks the class change
+// from the target's class and load band, rewarded by SLA compliance and cost.
+type ResizeConfig struct {
+	Enabled    bool            `json:"enabled"`
+	Mode       ResizeMode      `json:"mode"`        // Default RULE_BASED
+	Ladder     []SizeClass     `json:"ladder"`      // Ordered from smallest to largest
+	HighLoad   float64         `json:"high_load"`   // Load that calls for a larger class (default 0.8)
+	LowLoad    float64         `json:"low_load"`    // Load that calls for a smaller class (default 0.3)
+	Sustain    time.Duration   `json:"sustain"`     // How long load must stay beyond a threshold (default 10m)
+	Downtime   time.Duration   `json:"downtime"`    // Time a target is unavailable while resized
+	ResizeCost float64         `json:"resize_cost"` // One-off cost of a resize
+	SLAWeight  float64         `json:"sla_weight"`  // Reward per unit of SLA compliance (default 1)
+	CostWeight float64         `json:"cost_weight"` // Reward lost per unit of cost (default 1)
+	QLearning  QLearningConfig `json:"q_learning"`  // Learner settings in reinforcement mode
+}
+
+// Validate checks the resize configuration
+func (rc ResizeConfig) Validate() error {
+	switch {
+	case rc.HighLoad < 0 || rc.HighLoad > 1 || rc.LowLoad < 0 || rc.LowLoad > 1:
+		return fmt.Errorf("high_load and low_load must be between 0 and 1")
+	case rc.HighLoad > 0 && rc.LowLoad >= rc.HighLoad:
+		return fmt.Errorf("low_load must be below high_load")
+	case rc.Sustain < 0 || rc.Downtime < 0:
+		return fmt.Errorf("sustain and downtime must be non-negative")
+	case rc.ResizeCost < 0:
+		return fmt.Errorf("resize_cost must be non-negative")
+	case rc.SLAWeight < 0 || rc.CostWeight < 0:
+		return fmt.Errorf("sla_weight and cost_weight must be non-negative")
+	case rc.Mode != "" && rc.Mode != RULE_BASED && rc.Mode != REINFORCEMENT:
+		return fmt.Errorf("unknown mode %s", rc.Mode)
+	case rc.Enabled && len(rc.Ladder) < 2:
+		return fmt.Errorf("ladder needs at least two classes when enabled")
+	}
+	if err := rc.QLearning.Validate(); err != nil {
+		return fmt.Errorf("q_learning: %w", err)
+	}
+	seen := make(map[string]bool)
+	for i, class := range rc.Ladder {
+		switch {
+		case class.Name == "":
+			return fmt.Errorf("ladder[%d]: name cannot be empty", i)
+		case seen[class.Name]:
+			return fmt.Errorf("ladder[%d]: duplicate class %s", i, class.Name)
+		case class.CPU <= 0 || class.Memory <= 0:
+			return fmt.Errorf("ladder[%d]: cpu and memory must be positive", i)
+		case class.CostPerHour < 0:
+			return fmt.Errorf("ladder[%d]: cost_per_hour must be non-negative", i)
+		case i > 0 && class.CPU <= rc.Ladder[i-1].CPU:
+			return fmt.Errorf("ladder[%d]: classes must be ordered by increasing cpu", i)
+		}
+		seen[class.Name] = true
+	}
+	return nil
+}
+
+// ResizeDirection is the way a target is recommended to be resized
+type ResizeDirection string
+
+const (
+	SCALE_UP   ResizeDirection = "scale_up"
+	SCALE_DOWN ResizeDirection = "scale_down"
+)
+
+// ResizeRecommendation recommends moving a target to another size class
+type ResizeRecommendation struct {
+	TargetID        string          `json:"target_id"`
+	Direction       ResizeDirection `json:"direction"`
+	From            string          `json:"from"`
+	To              string          `json:"to"`
+	Load            float64         `json:"load"`              // Mean load while beyond the threshold
+	ProjectedLoad   float64         `json:"projected_load"`    // Expected load after the resize, including Reserved
+	Reserved        float64         `json:"reserved"`          // Share of capacity reserved by offloads not yet reflected in heartbeats
+	Sustained       time.Duration   `json:"sustained"`         // How long load has been beyond the threshold
+	HourlyCostDelta float64         `json:"hourly_cost_delta"` // Change in running cost
+	ResizeCost      float64         `json:"resize_cost"`
+	Downtime        time.Duration   `json:"downtime"`
+	NotBefore       time.Time       `json:"not_before"` // Downsizing waits until the paid lease time is used
+}
+
+// ResizeStats summarizes the rewards earned by resize decisions, for
+// comparing modes
+type ResizeStats struct {
+	Mode       ResizeMode `json:"mode"`
+	Steps      int        `json:"steps"`       // Decision periods rewarded
+	MeanReward float64    `json:"mean_reward"` // Mean reward per period
+	Epsilon    float64    `json:"epsilon"`     // Exploration rate (reinforcement mode only)
+}
+
+// Resize actions of the Q-learner
+const (
+	resizeHold = iota
+	resizeUp
+	resizeDown
+)
+
+// loadStreak is a run of heartbeats beyond one load threshold
+type loadStreak struct {
+	direction ResizeDirection
+	since     time.Time
+	loadSum   float64
+	samples   int
+}
+
+// resizeStep is the decision period of a target since its last
+// recommendation: the load and outcomes it saw, and the action that led to it
+type resizeStep struct {
+	state    string // Learner state at the start of the period ("" before the first)
+	action   int
+	start    time.Time
+	loadSum  float64
+	samples  int
+	outcomes int
+	slaMet   int
+	cost     float64
+}
+
+// ResizeAdvisor recommends resizing targets whose heartbeats report
+// persistently high or low load
+type ResizeAdvisor struct {
+	config        ResizeConfig
+	streaks       map[string]*loadStreak
+	lastHeartbeat map[string]time.Time
+	steps         map[string]*resizeStep
+	learner       *QLearner                   // nil in rule-based mode
+	reservations  *decision.ReservationLedger // nil = heartbeat load only
+	rewardSum     float64
+	rewardSteps   int
+	mu            sync.Mutex
+}
+
+// NewResizeAdvisor creates a resize advisor, applying default thresholds
+func NewResizeAdvisor(config ResizeConfig) *ResizeAdvisor {
+	if config.HighLoad == 0 {
+		config.HighLoad = 0.8
+	}
+	if config.LowLoad == 0 {
+		config.LowLoad = 0.3
+	}
+	if config.Sustain == 0 {
+		config.Sustain = 10 * time.Minute
+	}
+	if config.Mode == "" {
+		config.Mode = RULE_BASED
+	}
+	if config.SLAWeight == 0 {
+		config.SLAWeight = 1.0
+	}
+	if config.CostWeight == 0 {
+		config.CostWeight = 1.0
+	}
+	advisor := &ResizeAdvisor{
+		config:        config,
+		streaks:       make(map[string]*loadStreak),
+		lastHeartbeat: make(map[string]time.Time),
+		steps:         make(map[string]*resizeStep),
+	}
+	if config.Mode == REINFORCEMENT {
+		advisor.learner = NewQLearner(config.QLearning, 3)
+	}
+	return advisor
+}
+
+// Observe ingests a heartbeat's observed load. Heartbeats older than the
+// latest one seen for the target are ignored.
+func (ra *ResizeAdvisor) Observe(heartbeat Heartbeat) error {
+	if heartbeat.TargetID == "" {
+		return fmt.Errorf("heartbeat has no target ID")
+	}
+	if heartbeat.Timestamp.IsZero() {
+		heartbeat.Timestamp = time.Now()
+	}
+
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	if heartbeat.Timestamp.Before(ra.lastHeartbeat[heartbeat.TargetID]) {
+		return nil
+	}
+	ra.lastHeartbeat[heartbeat.TargetID] = heartbeat.Timestamp
+	step := ra.step(heartbeat.TargetID, heartbeat.Timestamp)
+	step.loadSum += heartbeat.ActualLoad
+	step.samples++
+
+	var direction ResizeDirection
+	switch {
+	case heartbeat.ActualLoad >= ra.config.HighLoad:
+		direction = SCALE_UP
+	case heartbeat.ActualLoad <= ra.config.LowLoad:
+		direction = SCALE_DOWN
+	default:
+		delete(ra.streaks, heartbeat.TargetID)
+		return nil
+	}
+
+	streak, exists := ra.streaks[heartbeat.TargetID]
+	if !exists || streak.direction != direction {
+		streak = &loadStreak{direction: direction, since: heartbeat.Timestamp}
+		ra.streaks[heartbeat.TargetID] = streak
+	}
+	streak.loadSum += heartbeat.ActualLoad
+	streak.samples++
+	return nil
+}
+
+// ObserveOutcome credits a process outcome to the decision period of the
+// target it ran on. Processes that succeed on time meet their SLA.
+func (ra *ResizeAdvisor) ObserveOutcome(outcome decision.OffloadOutcome) {
+	if outcome.TargetID == "" {
+		return
+	}
+	at := outcome.EndTime
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	step := ra.step(outcome.TargetID, at)
+	step.outcomes++
+	if outcome.Success && outcome.CompletedOnTime {
+		step.slaMet++
+	}
+	step.cost += outcome.CostActual
+}
+
+// Recommend returns the resizes due for the given targets at the given time,
+// ordered by target ID. A target's current class is the ladder class closest
+// to its total capacity. Downsizing is timed for when the time already paid
+// on the target's lease runs out.
+//
+// Each call ends the decision period of targets that sent heartbeats since
+// the last call and rewards the action that started it. In rule-based mode,
+// downsizing is only recommended when the projected load stays below the
+// high load threshold. In reinforcement mode, the learner is updated with
+// the reward and picks the next action from the period's mean load; targets
+// without new heartbeats get no recommendation.
+func (ra *ResizeAdvisor) Recommend(targets []models.OffloadTarget, now time.Time) []ResizeRecommendation {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	recommendations := make([]ResizeRecommendation, 0)
+	for _, target := range targets {
+		current := ra.classOf(target)
+		step := ra.steps[target.ID]
+		stepped := step != nil && step.samples > 0
+		var state string
+		var load float64
+		if stepped {
+			load = step.loadSum / float64(step.samples)
+			state = fmt.Sprintf("%d/%d", current, ra.loadBand(load))
+			if step.state != "" {
+				reward := ra.reward(step, ra.config.Ladder[current], now)
+				ra.rewardSum += reward
+				ra.rewardSteps++
+				if ra.learner != nil {
+					ra.learner.Update(step.state, step.action, reward, state)
+				}
+			}
+		}
+
+		var rec ResizeRecommendation
+		var ok bool
+		if ra.learner != nil {
+			if !stepped {
+				continue
+			}
+			action := ra.learner.Choose(state)
+			ra.steps[target.ID] = &resizeStep{state: state, action: action, start: now}
+			rec, ok = ra.learnedRecommendation(target, current, action, load, now.Sub(step.start), now)
+		} else {
+			rec, ok = ra.ruleRecommendation(target, current, now)
+			if stepped {
+				action := resizeHold
+				switch {
+				case ok && rec.Direction == SCALE_UP:
+					action = resizeUp
+				case ok:
+					action = resizeDown
+				}
+				ra.steps[target.ID] = &resizeStep{state: state, action: action, start: now}
+			}
+		}
+		if ok {
+			recommendations = append(recommendations, rec)
+		}
+	}
+	sort.Slice(recommendations, func(i, j int) bool { return recommendations[i].TargetID < recommendations[j].TargetID })
+	return recommendations
+}
+
+// Stats returns the rewards earned by resize decisions so far
+func (ra *ResizeAdvisor) Stats() ResizeStats {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	stats := ResizeStats{Mode: ra.config.Mode, Steps: ra.rewardSteps}
+	if ra.rewardSteps > 0 {
+		stats.MeanReward = ra.rewardSum / float64(ra.rewardSteps)
+	}
+	if ra.learner != nil {
+		stats.Epsilon = ra.learner.Epsilon()
+	}
+	return stats
+}
+
+// ruleRecommendation recommends a resize for a target whose load streak has
+// been sustained
+func (ra *ResizeAdvisor) ruleRecommendation(target models.OffloadTarget, current int, now time.Time) (ResizeRecommendation, bool) {
+	streak, exists := ra.streaks[target.ID]
+	if !exists || now.Sub(streak.since) < ra.config.Sustain {
+		return ResizeRecommendation{}, false
+	}
+
+	next := current + 1
+	if streak.direction == SCALE_DOWN {
+		next = current - 1
+	}
+	if next < 0 || next >= len(ra.config.Ladder) {
+		return ResizeRecommendation{}, false
+	}
+
+	rec := ra.recommendation(target, streak.direction, current, next, streak.loadSum/float64(streak.samples), now.Sub(streak.since), now)
+	if streak.direction == SCALE_DOWN && rec.ProjectedLoad >= ra.config.HighLoad {
+		return ResizeRecommendation{}, false
+	}
+	return rec, true
+}
+
+// learnedRecommendation turns a learner action into a recommendation. Holding,
+// or moving past either end of the ladder, recommends nothing.
+func (ra *ResizeAdvisor) learnedRecommendation(target models.OffloadTarget, current, action int, load float64, sustained time.Duration, now time.Time) (ResizeRecommendation, bool) {
+	direction, next := SCALE_UP, current+1
+	switch action {
+	case resizeHold:
+		return ResizeRecommendation{}, false
+	case resizeDown:
+		direction, next = SCALE_DOWN, current-1
+	}
+	if next < 0 || next >= len(ra.config.Ladder) {
+		return ResizeRecommendation{}, false
+	}
+	return ra.recommendation(target, direction, current, next, load, sustained, now), true
+}
+
+// recommendation describes moving a target between two ladder classes
+func (ra *ResizeAdvisor) recommendation(target models.OffloadTarget, direction ResizeDirection, current, next int, load float64, sustained time.Duration, now time.Time) ResizeRecommendation {
+	from, to := ra.config.Ladder[current], ra.config.Ladder[next]
+	var reserved float64
+	if ra.reservations != nil && target.TotalCapacity > 0 {
+		cpu, _ := ra.reservations.Reserved(target.ID)
+		reserved = math.Min(1.0, cpu/target.TotalCapacity)
+	}
+	var notBefore time.Time
+	if direction == SCALE_DOWN {
+		notBefore = target.PaidUntil(now)
+	}
+	return ResizeRecommendation{
+		TargetID:        target.ID,
+		Direction:       direction,
+		From:            from.Name,
+		To:              to.Name,
+		Load:            load,
+		ProjectedLoad:   math.Min(1.0, (load+reserved)*from.CPU/to.CPU),
+		Reserved:        reserved,
+		Sustained:       sustained,
+		HourlyCostDelta: to.CostPerHour - from.CostPerHour,
+		ResizeCost:      ra.config.ResizeCost,
+		Downtime:        ra.config.Downtime,
+		NotBefore:       notBefore,
+	}
+}
+
+// reward scores a decision period: SLA compliance of its outcomes (full when
+// there were none) less the cost of its outcomes, of running the class and
+// of any resize that started it
+func (ra *ResizeAdvisor) reward(step *resizeStep, class SizeClass, now time.Time) float64 {
+	compliance := 1.0
+	if step.outcomes > 0 {
+		compliance = float64(step.slaMet) / float64(step.outcomes)
+	}
+	cost := step.cost + class.CostPerHour*now.Sub(step.start).Hours()
+	if step.action != resizeHold {
+		cost += ra.config.ResizeCost
+	}
+	return ra.config.SLAWeight*compliance - ra.config.CostWeight*cost
+}
+
+// loadBand buckets load into low (0), normal (1) and high (2) by the thresholds
+func (ra *ResizeAdvisor) loadBand(load float64) int {
+	switch {
+	case load >= ra.config.HighLoad:
+		return 2
+	case load <= ra.config.LowLoad:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// step returns a target's current decision period, starting one if needed
+func (ra *ResizeAdvisor) step(targetID string, at time.Time) *resizeStep {
+	step, exists := ra.steps[targetID]
+	if !exists {
+		step = &resizeStep{start: at}
+		ra.steps[targetID] = step
+	}
+	return step
+}
+
+// SetReservations counts the capacity held in a reservation ledger toward
+// the projected load of resized targets, so a target is not downsized from
+// under offloads committed to it
+func (ra *ResizeAdvisor) SetReservations(ledger *decision.ReservationLedger) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	ra.reservations = ledger
+}
+
+// Forget drops a target's load history and open decision period, e.g. after
+// it has been resized. What the learner has learned is kept.
+func (ra *ResizeAdvisor) Forget(targetID string) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	delete(ra.streaks, targetID)
+	delete(ra.lastHeartbeat, targetID)
+	delete(ra.steps, targetID)
+}
+
+// classOf returns the index of the ladder class closest to a target's capacity
+func (ra *ResizeAdvisor) classOf(target models.OffloadTarget) int {
+	closest := 0
+	for i, class := range ra.config.Ladder {
+		if math.Abs(class.CPU-target.TotalCapacity) < math.Abs(ra.config.Ladder[closest].CPU-target.TotalCapacity) {
+			closest = i
+		}
+	}
+	return closest
+}
@@ -0,0 +1,85 @@
+package learning
+
+import (
+	"math"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+)
+
+// SLAPolicy describes the service level expectations used when shaping rewards
+type SLAPolicy struct {
+	MaxLatency             time.Duration `json:"max_latency"`              // Latency above this is an SLA violation (0 disables)
+	DeadlineMissPenalty    float64       `json:"deadline_miss_penalty"`    // Subtracted when a process misses its deadline
+	PolicyViolationPenalty float64       `json:"policy_violation_penalty"` // Subtracted when an outcome violated a policy
+}
+
+// DefaultSLAPolicy returns the SLA policy used when none is configured
+func DefaultSLAPolicy() SLAPolicy {
+	return SLAPolicy{
+		MaxLatency:             0,
+		DeadlineMissPenalty:    0.2,
+		PolicyViolationPenalty: 0.5,
+	}
+}
+
+// RewardFunction computes the learning reward for a decision and its outcome
+type RewardFunction interface {
+	Evaluate(dec decision.OffloadDecision, outcome decision.OffloadOutcome, sla SLAPolicy) float64
+}
+
+// RewardFunc adapts an ordinary function to the RewardFunction interface
+type RewardFunc func(dec decision.OffloadDecision, outcome decision.OffloadOutcome, sla SLAPolicy) float64
+
+// Evaluate calls f(dec, outcome, sla)
+func (f RewardFunc) Evaluate(dec decision.OffloadDecision, outcome decision.OffloadOutcome, sla SLAPolicy) float64 {
+	return f(dec, outcome, sla)
+}
+
+// DefaultRewardFunction is the built-in reward shaping used by the algorithm
+type DefaultRewardFunction struct {
+	OffloadReward          float64 `json:"offload_reward"`           // Reward for a successful offload
+	LocalReward            float64 `json:"local_reward"`             // Reward for a successful local execution
+	FailureReward          float64 `json:"failure_reward"`           // Reward for a failed execution
+	LatencyViolationReward float64 `json:"latency_violation_reward"` // Reward when the SLA latency is exceeded
+}
+
+// NewDefaultRewardFunction creates the default reward function
+func NewDefaultRewardFunction() *DefaultRewardFunction {
+	return &DefaultRewardFunction{
+		OffloadReward:          1.0,
+		LocalReward:            0.3,
+		FailureReward:          -1.0,
+		LatencyViolationReward: -0.5,
+	}
+}
+
+// Evaluate computes a reward in the range [-1.0, 1.0]
+func (rf *DefaultRewardFunction) Evaluate(
+	dec decision.OffloadDecision,
+	outcome decision.OffloadOutcome,
+	sla SLAPolicy,
+) float64 {
+	if !outcome.Success {
+		return rf.FailureReward
+	}
+
+	reward := rf.LocalReward
+	if dec.ShouldOffload {
+		reward = rf.OffloadReward
+	}
+
+	// Latency SLA violations override the base reward
+	if sla.MaxLatency > 0 && outcome.LatencyActual > sla.MaxLatency {
+		reward = rf.LatencyViolationReward
+	}
+
+	if !outcome.CompletedOnTime {
+		reward -= sla.DeadlineMissPenalty
+	}
+	if outcome.PolicyViolation {
+		reward -= sla.PolicyViolationPenalty
+	}
+
+	return math.Max(-1.0, math.Min(1.0, reward))
+}
@@ -0,0 +1,159 @@
+package learning
+
+import "fmt"
+
+// SeasonalConfig configures seasonal decomposition of a demand series whose
+// samples are evenly spaced
+type SeasonalConfig struct {
+	Period int     `json:"period"` // Samples per seasonal cycle, e.g. 24 for hourly samples of a daily cycle
+	Cycles int     `json:"cycles"` // Cycles of history decomposed (default 4)
+	Alpha  float64 `json:"alpha"`  // Smoothing of the residual forecast (default 0.3)
+}
+
+// Validate checks the seasonal configuration
+func (sc SeasonalConfig) Validate() error {
+	switch {
+	case sc.Period < 2:
+		return fmt.Errorf("period must be at least 2, got %d", sc.Period)
+	case sc.Cycles < 0:
+		return fmt.Errorf("cycles must be non-negative")
+	case sc.Cycles == 1:
+		return fmt.Errorf("cycles must be at least 2 to separate trend from season")
+	case sc.Alpha < 0 || sc.Alpha > 1:
+		return fmt.Errorf("alpha must be between 0 and 1, got %f", sc.Alpha)
+	}
+	return nil
+}
+
+// Decomposition splits a series into additive components:
+// value = trend + seasonal + residual
+type Decomposition struct {
+	Trend    []float64 `json:"trend"`
+	Seasonal []float64 `json:"seasonal"`
+	Residual []float64 `json:"residual"`
+	Season   []float64 `json:"season"` // Seasonal component by phase, from the phase of the first value
+}
+
+// CompositePredictor forecasts a series with a strong cycle by decomposing
+// its recent whole cycles into trend, seasonal and residual components,
+// forecasting each separately and recombining them. The trend is a line
+// fitted jointly with the season and is extrapolated; the season is the
+// level of each phase of the cycle above the trend and repeats; the residual
+// is smoothed exponentially. Until two cycles have been seen it forecasts
+// the mean.
+type CompositePredictor struct {
+	config   SeasonalConfig
+	history  []float64
+	observed int // Values observed, for the phase of the next one
+}
+
+// NewCompositePredictor creates a seasonal predictor, applying defaults
+func NewCompositePredictor(config SeasonalConfig) *CompositePredictor {
+	if config.Cycles == 0 {
+		config.Cycles = 4
+	}
+	if config.Alpha == 0 {
+		config.Alpha = 0.3
+	}
+	return &CompositePredictor{config: config}
+}
+
+// Observe appends a value, keeping the configured number of cycles
+func (cp *CompositePredictor) Observe(value float64) {
+	cp.history = append(cp.history, value)
+	cp.observed++
+	if limit := cp.config.Period * cp.config.Cycles; len(cp.history) > limit {
+		cp.history = cp.history[len(cp.history)-limit:]
+	}
+}
+
+// Predict forecasts the next value
+func (cp *CompositePredictor) Predict() (float64, bool) {
+	if len(cp.history) == 0 {
+		return 0, false
+	}
+	decomposition, ok := cp.Decompose()
+	if !ok {
+		sum := 0.0
+		for _, value := range cp.history {
+			sum += value
+		}
+		return sum / float64(len(cp.history)), true
+	}
+
+	n := len(decomposition.Trend)
+	slope := 0.0
+	if n > 1 {
+		slope = decomposition.Trend[1] - decomposition.Trend[0]
+	}
+	trend := decomposition.Trend[n-1] + slope
+	season := decomposition.Season[n%cp.config.Period]
+
+	residual := decomposition.Residual[0]
+	for _, value := range decomposition.Residual[1:] {
+		residual += cp.config.Alpha * (value - residual)
+	}
+	return trend + season + residual, true
+}
+
+// Decompose splits the most recent whole cycles of history into components.
+// Returns false until two cycles have been seen.
+func (cp *CompositePredictor) Decompose() (Decomposition, bool) {
+	period := cp.config.Period
+	cycles := len(cp.history) / period
+	if cycles < 2 {
+		return Decomposition{}, false
+	}
+	values := cp.history[len(cp.history)-cycles*period:]
+	n := len(values)
+	// Phase of values[0] within the cycle, so phases stay fixed as history slides
+	offset := (cp.observed - n) % period
+
+	// Fit trend and season jointly: the slope is estimated from how values
+	// change within each phase, so the season does not bias it, and each
+	// phase's level above the trend line is its seasonal component
+	meanX, meanY := make([]float64, period), make([]float64, period)
+	for i, value := range values {
+		phase := (offset + i) % period
+		meanX[phase] += float64(i) / float64(cycles)
+		meanY[phase] += value / float64(cycles)
+	}
+	covariance, variance := 0.0, 0.0
+	for i, value := range values {
+		phase := (offset + i) % period
+		covariance += (float64(i) - meanX[phase]) * (value - meanY[phase])
+		variance += (float64(i) - meanX[phase]) * (float64(i) - meanX[phase])
+	}
+	slope := covariance / variance
+
+	season := make([]float64, period)
+	intercept := 0.0
+	for phase := range season {
+		season[phase] = meanY[phase] - slope*meanX[phase]
+		intercept += season[phase] / float64(period)
+	}
+	for phase := range season {
+		season[phase] -= intercept
+	}
+	trend := make([]float64, n)
+	for i := range trend {
+		trend[i] = intercept + slope*float64(i)
+	}
+
+	decomposition := Decomposition{
+		Trend:    trend,
+		Seasonal: make([]float64, n),
+		Residual: make([]float64, n),
+		Season:   make([]float64, period),
+	}
+	for i, value := range values {
+		decomposition.Seasonal[i] = season[(offset+i)%period]
+		decomposition.Residual[i] = value - trend[i] - decomposition.Seasonal[i]
+	}
+	// Index the season from the first value, so Season[i%period] lines up
+	// with values[i] and Season[n%period] is the next value's phase
+	for i := range decomposition.Season {
+		decomposition.Season[i] = season[(offset+i)%period]
+	}
+	return decomposition, true
+}
@@ -0,0 +1,267 @@
+package learning
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// StrategyProfile is a named weight profile decisions can be made with, such
+// as "night-batch" or "interactive-first"
+type StrategyProfile struct {
+	Name         string                   `json:"name"`
+	Weights      decision.AdaptiveWeights `json:"weights"`
+	Hours        []int                    `json:"hours"`         // Hours of day the strategy applies, in the calendar's time zone (empty = all)
+	BusinessDays *bool                    `json:"business_days"` // Only business days (true) or weekends and holidays (false) (nil = both)
+	ProcessTypes []string                 `json:"process_types"` // Process types the strategy applies to (empty = all)
+	RealTime     *bool                    `json:"real_time"`     // Only real-time (true) or non-real-time (false) processes (nil = both)
+}
+
+// appliesTo returns true if the strategy may decide the process at the
+// given time, read in the calendar
+func (sp StrategyProfile) appliesTo(process models.Process, at time.Time, calendar *models.Calendar) bool {
+	at = calendar.In(at)
+	if sp.RealTime != nil && *sp.RealTime != process.RealTime {
+		return false
+	}
+	if len(sp.Hours) > 0 && !containsInt(sp.Hours, at.Hour()) {
+		return false
+	}
+	if sp.BusinessDays != nil && *sp.BusinessDays != calendar.IsBusinessDay(at) {
+		return false
+	}
+	if len(sp.ProcessTypes) > 0 && !containsString(sp.ProcessTypes, process.Type) {
+		return false
+	}
+	return true
+}
+
+// StrategyConfig configures Thompson sampling over strategy profiles. Each
+// decision samples every applicable strategy's success rate and uses the
+// weights of the highest sample; outcomes update the chosen strategy.
+type StrategyConfig struct {
+	Enabled    bool              `json:"enabled"`
+	Strategies []StrategyProfile `json:"strategies"`
+	PriorAlpha float64           `json:"prior_alpha"` // Prior successes per strategy (default 1)
+	PriorBeta  float64           `json:"prior_beta"`  // Prior failures per strategy (default 1)
+	Seed       int64             `json:"seed"`        // Sampling seed (0 = time-based)
+}
+
+// Validate checks the strategy configuration
+func (sc StrategyConfig) Validate() error {
+	if sc.PriorAlpha < 0 || sc.PriorBeta < 0 {
+		return fmt.Errorf("prior_alpha and prior_beta must be non-negative")
+	}
+	seen := make(map[string]bool)
+	for i, strategy := range sc.Strategies {
+		switch {
+		case strategy.Name == "":
+			return fmt.Errorf("strategies[%d]: name cannot be empty", i)
+		case seen[strategy.Name]:
+			return fmt.Errorf("strategies[%d]: duplicate strategy %s", i, strategy.Name)
+		}
+		seen[strategy.Name] = true
+		if sum := strategy.Weights.Sum(); sum <= 0 {
+			return fmt.Errorf("strategies[%d]: weights must have a positive sum", i)
+		}
+		for _, hour := range strategy.Hours {
+			if hour < 0 || hour > 23 {
+				return fmt.Errorf("strategies[%d]: hour %d is outside 0-23", i, hour)
+			}
+		}
+	}
+	if sc.Enabled && len(sc.Strategies) == 0 {
+		return fmt.Errorf("at least one strategy is required when enabled")
+	}
+	return nil
+}
+
+// StrategyStats summarizes a strategy's outcomes
+type StrategyStats struct {
+	Name       string  `json:"name"`
+	Selections int     `json:"selections"`
+	Successes  int     `json:"successes"`
+	Failures   int     `json:"failures"`
+	Mean       float64 `json:"mean"` // Posterior mean success rate
+}
+
+// strategyArm is a strategy and its Beta posterior
+type strategyArm struct {
+	profile     StrategyProfile
+	alpha, beta float64
+	stats       StrategyStats
+}
+
+// ThompsonSampler chooses among strategy profiles by Thompson sampling on
+// whether their decisions succeed on time
+type ThompsonSampler struct {
+	arms     []*strategyArm
+	byName   map[string]*strategyArm
+	assigned map[string]string // Process ID -> strategy name
+	calendar *models.Calendar  // Time zone and business days of strategies
+	rng      *rand.Rand
+	mu       sync.Mutex
+}
+
+// NewThompsonSampler creates a sampler for the configured strategies
+func NewThompsonSampler(config StrategyConfig) (*ThompsonSampler, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.PriorAlpha == 0 {
+		config.PriorAlpha = 1
+	}
+	if config.PriorBeta == 0 {
+		config.PriorBeta = 1
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	ts := &ThompsonSampler{
+		byName:   make(map[string]*strategyArm),
+		assigned: make(map[string]string),
+		calendar: models.DefaultCalendar(),
+		rng:      rand.New(rand.NewSource(seed)),
+	}
+	for _, profile := range config.Strategies {
+		profile.Weights.Normalize()
+		arm := &strategyArm{profile: profile, alpha: config.PriorAlpha, beta: config.PriorBeta}
+		arm.stats.Name = profile.Name
+		ts.arms = append(ts.arms, arm)
+		ts.byName[profile.Name] = arm
+	}
+	return ts, nil
+}
+
+// SetCalendar sets the calendar strategy hours and business days are read in
+func (ts *ThompsonSampler) SetCalendar(calendar *models.Calendar) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.calendar = calendar
+}
+
+// Select chooses the strategy a process is decided with, and false if no
+// strategy applies to it
+func (ts *ThompsonSampler) Select(process models.Process, at time.Time) (StrategyProfile, bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var best *strategyArm
+	bestSample := -1.0
+	for _, arm := range ts.arms {
+		if !arm.profile.appliesTo(process, at, ts.calendar) {
+			continue
+		}
+		if sample := ts.sampleBeta(arm.alpha, arm.beta); sample > bestSample {
+			best, bestSample = arm, sample
+		}
+	}
+	if best == nil {
+		return StrategyProfile{}, false
+	}
+	best.stats.Selections++
+	ts.assigned[process.ID] = best.profile.Name
+	return best.profile, true
+}
+
+// Observe updates the posterior of the strategy that decided the outcome's
+// process. Outcomes of processes decided without a strategy are ignored.
+func (ts *ThompsonSampler) Observe(outcome decision.OffloadOutcome) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	name, exists := ts.assigned[outcome.ProcessID]
+	if !exists {
+		return
+	}
+	delete(ts.assigned, outcome.ProcessID)
+	arm := ts.byName[name]
+	if outcome.Success && outcome.CompletedOnTime {
+		arm.alpha++
+		arm.stats.Successes++
+	} else {
+		arm.beta++
+		arm.stats.Failures++
+	}
+}
+
+// Forget drops a process's strategy assignment without an outcome
+func (ts *ThompsonSampler) Forget(processID string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	delete(ts.assigned, processID)
+}
+
+// Stats returns each strategy's outcomes ordered by name
+func (ts *ThompsonSampler) Stats() []StrategyStats {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	stats := make([]StrategyStats, 0, len(ts.arms))
+	for _, arm := range ts.arms {
+		s := arm.stats
+		s.Mean = arm.alpha / (arm.alpha + arm.beta)
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// sampleBeta draws from Beta(alpha, beta) as a ratio of gamma variates
+func (ts *ThompsonSampler) sampleBeta(alpha, beta float64) float64 {
+	x := ts.sampleGamma(alpha)
+	y := ts.sampleGamma(beta)
+	if x+y == 0 {
+		return 0.5
+	}
+	return x / (x + y)
+}
+
+// sampleGamma draws from Gamma(shape, 1) by Marsaglia and Tsang's method
+func (ts *ThompsonSampler) sampleGamma(shape float64) float64 {
+	if shape < 1 {
+		// Boost the shape and scale back down
+		return ts.sampleGamma(shape+1) * math.Pow(ts.rng.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3.0
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := ts.rng.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := ts.rng.Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
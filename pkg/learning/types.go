@@ -14,8 +14,16 @@ type LearningConfig struct {
 	MinSamples       int     `json:"min_samples"`       // Minimum samples for pattern detection
 	ConvergenceThreshold float64 `json:"convergence_threshold"` // Threshold for weight convergence
 	MaxPatterns      int     `json:"max_patterns"`      // Maximum patterns to maintain
+	Convergence      ConvergenceConfig `json:"convergence"`  // Convergence detection and auto-freeze
+	Drift            DriftConfig       `json:"drift"`        // Concept drift detection and reset policy
+	Canary           CanaryConfig      `json:"canary"`       // Staged rollout of learned weights
+	HistorySize      int               `json:"history_size"` // Weight vectors and outcomes kept for convergence and pattern discovery (0 = 10000)
 }
 
+// DefaultHistorySize is the number of weight vectors and outcomes kept when
+// LearningConfig.HistorySize is not set
+const DefaultHistorySize = 10000
+
 // LearningObjective defines what the algorithm learns to optimize
 type LearningObjective struct {
 	Name         string         `json:"name"`
@@ -49,6 +57,24 @@ type LearningProgress struct {
 	ConvergenceTime    int                      `json:"convergence_time"`
 }
 
+// convergenceWindow is the number of recent weight vectors checked for convergence
+const convergenceWindow = 20
+
+// WeightEvolution is a weight trajectory indexed by decision count
+type WeightEvolution struct {
+	Points           []WeightEvolutionPoint   `json:"points"`
+	Step             int                      `json:"step"`              // Decisions between points
+	ConvergencePoint int                      `json:"convergence_point"` // Decision count at convergence (0 if not converged)
+	IsConverged      bool                     `json:"is_converged"`
+	FinalWeights     decision.AdaptiveWeights `json:"final_weights"`
+}
+
+// WeightEvolutionPoint is the weight vector after a given number of decisions
+type WeightEvolutionPoint struct {
+	DecisionCount int                      `json:"decision_count"`
+	Weights       decision.AdaptiveWeights `json:"weights"`
+}
+
 // PatternRecognizer identifies patterns in decision outcomes
 type PatternRecognizer struct {
 	patterns       []*decision.DiscoveredPattern
@@ -62,6 +88,8 @@ type WeightAdapter struct {
 	learningRate    float64
 	explorationRate float64
 	weightHistory   []decision.AdaptiveWeights
+	decisionCounts  []int // Decisions made when each weight vector in weightHistory was learned
+	decisions       int   // Decisions made so far
 	convergenceTime int
 }
 
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"log/syslog"
+	"os"
+	"strings"
+)
+
+// Output selects where log records are written
+type Output string
+
+const (
+	CONSOLE Output = "console"
+	FILE    Output = "file"
+	SYSLOG  Output = "syslog"
+)
+
+// Format selects how log records are encoded
+type Format string
+
+const (
+	TEXT Format = "text"
+	JSON Format = "json"
+)
+
+// Config configures the structured logger
+type Config struct {
+	Level    string `json:"level"`     // debug, info, warn or error
+	Format   Format `json:"format"`    // text or json
+	Output   Output `json:"output"`    // console, file or syslog
+	FilePath string `json:"file_path"` // Log file path when Output is file
+	Tag      string `json:"tag"`       // Syslog tag
+}
+
+// DefaultConfig returns a console text logger at info level
+func DefaultConfig() Config {
+	return Config{
+		Level:  "info",
+		Format: TEXT,
+		Output: CONSOLE,
+		Tag:    "colony-offloader",
+	}
+}
+
+// New creates a logger from the configuration. The returned closer releases
+// the underlying sink and must be called when the logger is no longer used.
+func New(config Config) (*slog.Logger, io.Closer, error) {
+	level, err := ParseLevel(config.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		writer io.Writer
+		closer io.Closer = nopCloser{}
+	)
+
+	switch config.Output {
+	case CONSOLE, "":
+		writer = os.Stderr
+	case FILE:
+		if config.FilePath == "" {
+			return nil, nil, fmt.Errorf("file output requires a file path")
+		}
+		file, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		writer, closer = file, file
+	case SYSLOG:
+		tag := config.Tag
+		if tag == "" {
+			tag = DefaultConfig().Tag
+		}
+		sysWriter, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		writer, closer = sysWriter, sysWriter
+	default:
+		return nil, nil, fmt.Errorf("unknown log output %q", config.Output)
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch config.Format {
+	case TEXT, "":
+		handler = slog.NewTextHandler(writer, options)
+	case JSON:
+		handler = slog.NewJSONHandler(writer, options)
+	default:
+		closer.Close()
+		return nil, nil, fmt.Errorf("unknown log format %q", config.Format)
+	}
+
+	return slog.New(handler), closer, nil
+}
+
+// ParseLevel converts a level name to a slog level. An empty name is info.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+// Discard returns a logger that drops all records
+func Discard() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// nopCloser is the closer for sinks that must not be closed
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
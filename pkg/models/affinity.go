@@ -0,0 +1,41 @@
+package models
+
+import "fmt"
+
+// Affinity constrains where a process runs relative to other processes and
+// targets, analogous to Kubernetes pod affinity
+type Affinity struct {
+	Groups         []string `json:"groups"`          // Declared affinity groups the process belongs to
+	CoLocateWith   []string `json:"colocate_with"`   // Process IDs whose target this process must share
+	AvoidProcesses []string `json:"avoid_processes"` // Process IDs this process must not share a target with
+	AvoidTargets   []string `json:"avoid_targets"`   // Target IDs this process must not run on
+}
+
+// IsEmpty returns true if the affinity places no constraints
+func (a Affinity) IsEmpty() bool {
+	return len(a.Groups) == 0 && len(a.CoLocateWith) == 0 &&
+		len(a.AvoidProcesses) == 0 && len(a.AvoidTargets) == 0
+}
+
+// AffinityGroup is a named rule applied between all processes in the group
+type AffinityGroup struct {
+	ID            string `json:"id"`
+	CoLocate      bool   `json:"colocate"`       // Members share one target
+	AntiAffinity  bool   `json:"anti_affinity"`  // Members never share a target
+	SpreadRegions bool   `json:"spread_regions"` // Members run in distinct target locations
+	Preferred     bool   `json:"preferred"`      // Violations lower the score instead of excluding the target
+}
+
+// Validate checks that the group is well-formed
+func (g AffinityGroup) Validate() error {
+	if g.ID == "" {
+		return fmt.Errorf("affinity group ID cannot be empty")
+	}
+	if g.CoLocate && (g.AntiAffinity || g.SpreadRegions) {
+		return fmt.Errorf("affinity group %s cannot both co-locate and spread members", g.ID)
+	}
+	if !g.CoLocate && !g.AntiAffinity && !g.SpreadRegions {
+		return fmt.Errorf("affinity group %s declares no rule", g.ID)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// BatteryState is the charge and duty cycle of a battery-powered target
+type BatteryState struct {
+	Level          float64 `json:"level"`            // State of charge (0.0-1.0)
+	CapacityWh     float64 `json:"capacity_wh"`      // Full charge, in watt-hours (0 = unknown)
+	Charging       bool    `json:"charging"`         // On external power
+	DutyCycleLimit float64 `json:"duty_cycle_limit"` // Largest share of time the device may be busy (0 = unlimited)
+	DutyCycleUsed  float64 `json:"duty_cycle_used"`  // Share of the current duty cycle window spent busy
+}
+
+// Validate checks the battery state
+func (bs BatteryState) Validate() error {
+	if bs.Level < 0 || bs.Level > 1 {
+		return fmt.Errorf("level must be between 0 and 1, got %v", bs.Level)
+	}
+	if bs.CapacityWh < 0 {
+		return fmt.Errorf("capacity_wh must be non-negative, got %v", bs.CapacityWh)
+	}
+	if bs.DutyCycleLimit < 0 || bs.DutyCycleLimit > 1 || bs.DutyCycleUsed < 0 || bs.DutyCycleUsed > 1 {
+		return fmt.Errorf("duty_cycle_limit and duty_cycle_used must be between 0 and 1")
+	}
+	return nil
+}
+
+// BatteryPolicy preserves the uptime of battery-powered targets. Processes
+// are not offloaded to devices whose charge is below the minimum level, or
+// that have used up their duty cycle, and devices are scored by how much of
+// their energy budget above the minimum a process would use.
+type BatteryPolicy struct {
+	Enabled  bool    `json:"enabled"`
+	MinLevel float64 `json:"min_level"` // Charge below which devices take no offloads (0 = 0.2)
+}
+
+// defaultMinBatteryLevel keeps a fifth of the charge for the device's own
+// work
+const defaultMinBatteryLevel = 0.2
+
+// Validate checks the policy
+func (bp BatteryPolicy) Validate() error {
+	if bp.MinLevel < 0 || bp.MinLevel >= 1 {
+		return fmt.Errorf("min_level must be in range [0,1), got %v", bp.MinLevel)
+	}
+	return nil
+}
+
+func (bp BatteryPolicy) minLevel() float64 {
+	if bp.MinLevel == 0 {
+		return defaultMinBatteryLevel
+	}
+	return bp.MinLevel
+}
+
+// Refusal returns why a target's battery cannot take the process, or an
+// empty string if it can. Mains-powered targets and charging devices are
+// never refused for their charge.
+func (bp BatteryPolicy) Refusal(process Process, target OffloadTarget) string {
+	battery := target.Battery
+	if !bp.Enabled || battery == nil {
+		return ""
+	}
+	if battery.DutyCycleLimit > 0 && battery.DutyCycleUsed >= battery.DutyCycleLimit {
+		return fmt.Sprintf("duty cycle of %.0f%% used up", battery.DutyCycleLimit*100)
+	}
+	if battery.Charging {
+		return ""
+	}
+	if battery.Level < bp.minLevel() {
+		return fmt.Sprintf("battery at %.0f%%, below minimum %.0f%%", battery.Level*100, bp.minLevel()*100)
+	}
+	if battery.CapacityWh > 0 && target.EstimateEnergy(process, target.EstimateExecutionTime(process)) > bp.EnergyBudget(target) {
+		return "process would drain the battery below the minimum level"
+	}
+	return ""
+}
+
+// EnergyBudget returns the energy a device can spend on offloads before
+// reaching the minimum level. It is zero for mains-powered and charging
+// targets, and those of unknown capacity.
+func (bp BatteryPolicy) EnergyBudget(target OffloadTarget) units.Energy {
+	battery := target.Battery
+	if battery == nil || battery.Charging || battery.CapacityWh <= 0 {
+		return 0
+	}
+	return units.Energy(math.Max(0, battery.Level-bp.minLevel()) * battery.CapacityWh)
+}
+
+// BudgetHeadroom rates a target by the share of its energy budget left after
+// running a process for a duration, from 1 for targets without a budget to
+// 0 when the process uses all of it
+func (bp BatteryPolicy) BudgetHeadroom(process Process, target OffloadTarget, duration time.Duration) float64 {
+	if !bp.Enabled || target.Battery == nil {
+		return 1
+	}
+	if target.Battery.Charging || target.Battery.CapacityWh <= 0 {
+		return 1
+	}
+	budget := bp.EnergyBudget(target)
+	if budget <= 0 {
+		return 0
+	}
+	return math.Max(0, 1-float64(target.EstimateEnergy(process, duration)/budget))
+}
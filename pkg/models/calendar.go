@@ -0,0 +1,140 @@
+package models
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// dateLayout is the format of calendar dates
+const dateLayout = "2006-01-02"
+
+// CalendarConfig places schedule logic in a deployment region's time zone
+// and business calendar
+type CalendarConfig struct {
+	TimeZone string         `json:"time_zone"` // IANA name such as "Europe/Stockholm" (empty = each time's own zone)
+	Weekend  []time.Weekday `json:"weekend"`   // Non-business days of the week (empty = Saturday and Sunday)
+	Holidays []string       `json:"holidays"`  // Non-business dates, as YYYY-MM-DD
+}
+
+// Validate checks the time zone and holiday dates
+func (cc CalendarConfig) Validate() error {
+	if cc.TimeZone != "" {
+		if _, err := time.LoadLocation(cc.TimeZone); err != nil {
+			return fmt.Errorf("time_zone: %w", err)
+		}
+	}
+	for _, day := range cc.Weekend {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("weekend: %d is not a day of the week", day)
+		}
+	}
+	for _, date := range cc.Holidays {
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			return fmt.Errorf("holidays: %q is not a YYYY-MM-DD date", date)
+		}
+	}
+	return nil
+}
+
+// HolidayProvider reports the holidays of a region, such as a public
+// holiday API or a company's business calendar
+type HolidayProvider interface {
+	// IsHoliday reports whether the calendar date of t, in t's location, is
+	// a holiday
+	IsHoliday(t time.Time) bool
+}
+
+// HolidayDates is a HolidayProvider for a fixed set of dates
+type HolidayDates map[string]bool
+
+// IsHoliday reports whether t falls on one of the dates
+func (hd HolidayDates) IsHoliday(t time.Time) bool {
+	return hd[t.Format(dateLayout)]
+}
+
+// Calendar interprets times in a deployment region: hours and days of the
+// week in its time zone, and business days net of weekends and holidays
+type Calendar struct {
+	location  *time.Location // nil when times are read in their own zone
+	weekend   [7]bool
+	providers []HolidayProvider
+	mu        sync.RWMutex
+}
+
+// NewCalendar creates a calendar. Configured holidays are the first holiday
+// provider; more can be added with AddHolidayProvider.
+func NewCalendar(config CalendarConfig) (*Calendar, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	calendar := &Calendar{}
+	if config.TimeZone != "" {
+		calendar.location, _ = time.LoadLocation(config.TimeZone) // Validated above
+	}
+
+	weekend := config.Weekend
+	if len(weekend) == 0 {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	for _, day := range weekend {
+		calendar.weekend[day] = true
+	}
+
+	if len(config.Holidays) > 0 {
+		dates := make(HolidayDates, len(config.Holidays))
+		for _, date := range config.Holidays {
+			dates[date] = true
+		}
+		calendar.providers = append(calendar.providers, dates)
+	}
+	return calendar, nil
+}
+
+// DefaultCalendar returns a calendar that reads times in their own zone, with
+// Saturday and Sunday weekends and no holidays
+func DefaultCalendar() *Calendar {
+	calendar, _ := NewCalendar(CalendarConfig{})
+	return calendar
+}
+
+// AddHolidayProvider adds a source of holidays
+func (c *Calendar) AddHolidayProvider(provider HolidayProvider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.providers = append(c.providers, provider)
+}
+
+// Location returns the calendar's time zone, or nil when times are read in
+// their own zone
+func (c *Calendar) Location() *time.Location {
+	return c.location
+}
+
+// In returns t in the calendar's time zone
+func (c *Calendar) In(t time.Time) time.Time {
+	if c.location == nil {
+		return t
+	}
+	return t.In(c.location)
+}
+
+// IsHoliday reports whether t falls on a holiday in the calendar's time zone
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	t = c.In(t)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, provider := range c.providers {
+		if provider.IsHoliday(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBusinessDay reports whether t falls on a day that is neither a weekend
+// day nor a holiday in the calendar's time zone
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	t = c.In(t)
+	return !c.weekend[t.Weekday()] && !c.IsHoliday(t)
+}
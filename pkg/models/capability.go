@@ -0,0 +1,315 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Capability is a feature a target offers. It is either a flag such as
+// gpu_accelerated or a namespaced value such as region:eu.
+type Capability string
+
+// Capability flags
+const (
+	GPU_ACCELERATED  Capability = "gpu_accelerated"
+	TPU_ACCELERATED  Capability = "tpu_accelerated"
+	FPGA_ACCELERATED Capability = "fpga_accelerated"
+	LOW_LATENCY      Capability = "low_latency"
+	HIGH_MEMORY      Capability = "high_memory"
+	SSD_STORAGE      Capability = "ssd_storage"
+	TRUSTED_ENCLAVE  Capability = "trusted_enclave"
+	PERSISTENT_STATE Capability = "persistent_state"
+)
+
+// ValidCapabilityFlags returns all capability flags of the taxonomy
+func ValidCapabilityFlags() []Capability {
+	return []Capability{
+		GPU_ACCELERATED, TPU_ACCELERATED, FPGA_ACCELERATED, LOW_LATENCY,
+		HIGH_MEMORY, SSD_STORAGE, TRUSTED_ENCLAVE, PERSISTENT_STATE,
+	}
+}
+
+// ValidCapabilityNamespaces returns the namespaces of valued capabilities,
+// such as region:eu or arch:arm64
+func ValidCapabilityNamespaces() []string {
+	return []string{"region", "zone", "arch", "os", "accelerator", "runtime"}
+}
+
+// IsValid checks that the capability is a known flag or a non-empty value in
+// a known namespace
+func (c Capability) IsValid() bool {
+	namespace, value, valued := strings.Cut(string(c), ":")
+	if !valued {
+		for _, flag := range ValidCapabilityFlags() {
+			if c == flag {
+				return true
+			}
+		}
+		return false
+	}
+	if !isCapabilityWord(value) {
+		return false
+	}
+	for _, valid := range ValidCapabilityNamespaces() {
+		if namespace == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// isCapabilityWord checks that s is a non-empty run of lower-case letters,
+// digits, underscores, hyphens and dots
+func isCapabilityWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLower(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// Requirement is a parsed capability requirements expression
+type Requirement interface {
+	// Matches reports whether a set of capabilities satisfies the requirement
+	Matches(capabilities []string) bool
+	String() string
+}
+
+// ParseRequirements parses a capability requirements expression such as
+// "gpu_accelerated AND (region:eu OR low_latency)". AND binds tighter than
+// OR, NOT negates, and parentheses group. Keywords are case-insensitive;
+// every capability must be in the taxonomy. An empty expression is
+// satisfied by every target.
+func ParseRequirements(expression string) (Requirement, error) {
+	p := &requirementParser{tokens: tokenizeRequirements(expression)}
+	if len(p.tokens) == 0 {
+		return requireAll{}, nil
+	}
+	requirement, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in requirements", p.tokens[p.pos])
+	}
+	return requirement, nil
+}
+
+// RequiredCapabilities returns the capabilities every target satisfying the
+// requirement must offer: those ANDed at its top level. Capabilities under
+// OR or NOT are not required on their own.
+func RequiredCapabilities(requirement Requirement) []string {
+	switch r := requirement.(type) {
+	case requireCapability:
+		return []string{string(r)}
+	case requireAll:
+		var required []string
+		for _, term := range r {
+			required = append(required, RequiredCapabilities(term)...)
+		}
+		return required
+	}
+	return nil
+}
+
+// tokenizeRequirements splits an expression into parentheses and words
+func tokenizeRequirements(expression string) []string {
+	var tokens []string
+	word := strings.Builder{}
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range expression {
+		switch {
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// requirementParser is a recursive descent parser over requirement tokens
+type requirementParser struct {
+	tokens []string
+	pos    int
+}
+
+// keyword consumes the current token if it is the keyword
+func (p *requirementParser) keyword(keyword string) bool {
+	if p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *requirementParser) parseOr() (Requirement, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	terms := []Requirement{left}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, right)
+	}
+	if len(terms) == 1 {
+		return left, nil
+	}
+	return requireAny(terms), nil
+}
+
+func (p *requirementParser) parseAnd() (Requirement, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	terms := requireAll{left}
+	for p.keyword("AND") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, right)
+	}
+	if len(terms) == 1 {
+		return left, nil
+	}
+	return terms, nil
+}
+
+func (p *requirementParser) parseUnary() (Requirement, error) {
+	if p.keyword("NOT") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return requireNot{operand}, nil
+	}
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of requirements")
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+	switch {
+	case token == "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword(")") {
+			return nil, fmt.Errorf("missing closing parenthesis in requirements")
+		}
+		return inner, nil
+	case token == ")" || strings.EqualFold(token, "AND") || strings.EqualFold(token, "OR"):
+		return nil, fmt.Errorf("unexpected %q in requirements", token)
+	}
+
+	capability := Capability(token)
+	if !capability.IsValid() {
+		return nil, fmt.Errorf("unknown capability %q", token)
+	}
+	return requireCapability(capability), nil
+}
+
+// requireCapability is satisfied by targets offering the capability
+type requireCapability Capability
+
+func (r requireCapability) Matches(capabilities []string) bool {
+	for _, capability := range capabilities {
+		if capability == string(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r requireCapability) String() string {
+	return string(r)
+}
+
+// requireAll is satisfied when every term is
+type requireAll []Requirement
+
+func (r requireAll) Matches(capabilities []string) bool {
+	for _, term := range r {
+		if !term.Matches(capabilities) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r requireAll) String() string {
+	return joinRequirements(r, " AND ")
+}
+
+// requireAny is satisfied when at least one term is
+type requireAny []Requirement
+
+func (r requireAny) Matches(capabilities []string) bool {
+	for _, term := range r {
+		if term.Matches(capabilities) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r requireAny) String() string {
+	return joinRequirements(r, " OR ")
+}
+
+// requireNot is satisfied when its operand is not
+type requireNot struct {
+	operand Requirement
+}
+
+func (r requireNot) Matches(capabilities []string) bool {
+	return !r.operand.Matches(capabilities)
+}
+
+func (r requireNot) String() string {
+	return "NOT " + groupRequirement(r.operand)
+}
+
+// joinRequirements formats terms with a separator, parenthesizing compound
+// terms
+func joinRequirements(terms []Requirement, separator string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = groupRequirement(term)
+	}
+	return strings.Join(parts, separator)
+}
+
+// groupRequirement parenthesizes compound requirements
+func groupRequirement(r Requirement) string {
+	switch r := r.(type) {
+	case requireAll:
+		if len(r) > 1 {
+			return "(" + r.String() + ")"
+		}
+	case requireAny:
+		return "(" + r.String() + ")"
+	}
+	return r.String()
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// PowerProfile is the power a target draws as its utilization rises
+type PowerProfile struct {
+	IdleWatts float64   `json:"idle_watts"`      // Drawn at zero utilization
+	PeakWatts float64   `json:"peak_watts"`      // Drawn at full utilization
+	Curve     []float64 `json:"curve,omitempty"` // Share of the idle-to-peak range drawn at evenly spaced utilizations from 0 to 1 (empty = linear)
+	PUE       float64   `json:"pue,omitempty"`   // Power usage effectiveness of the target's site (0 = the site's configured PUE, or 1)
+}
+
+// Validate checks the power draw is non-negative and rises to the peak
+func (pp PowerProfile) Validate() error {
+	if pp.IdleWatts < 0 || pp.PeakWatts < pp.IdleWatts {
+		return fmt.Errorf("power must satisfy 0 <= idle_watts <= peak_watts, got %v and %v", pp.IdleWatts, pp.PeakWatts)
+	}
+	if len(pp.Curve) == 1 {
+		return fmt.Errorf("curve needs a point at both 0 and full utilization")
+	}
+	for i, share := range pp.Curve {
+		if share < 0 || share > 1 {
+			return fmt.Errorf("curve[%d] must be between 0 and 1, got %v", i, share)
+		}
+	}
+	if pp.PUE != 0 && pp.PUE < 1 {
+		return fmt.Errorf("pue must be at least 1, got %v", pp.PUE)
+	}
+	return nil
+}
+
+// Draw returns the power drawn at a utilization (0.0-1.0), interpolated
+// along the curve
+func (pp PowerProfile) Draw(utilization float64) units.Power {
+	utilization = math.Max(0, math.Min(1, utilization))
+	share := utilization
+	if len(pp.Curve) >= 2 {
+		position := utilization * float64(len(pp.Curve)-1)
+		lower := int(position)
+		if lower >= len(pp.Curve)-1 {
+			share = pp.Curve[len(pp.Curve)-1]
+		} else {
+			fraction := position - float64(lower)
+			share = pp.Curve[lower] + fraction*(pp.Curve[lower+1]-pp.Curve[lower])
+		}
+	}
+	return units.Power(pp.IdleWatts + share*(pp.PeakWatts-pp.IdleWatts))
+}
+
+// EnergyConfig sets the power usage effectiveness of the sites targets run
+// at, applied to targets whose power profile has none
+type EnergyConfig struct {
+	SitePUE map[string]float64 `json:"site_pue"` // By target location
+}
+
+// Validate checks every site's PUE is at least 1
+func (ec EnergyConfig) Validate() error {
+	for site, pue := range ec.SitePUE {
+		if pue < 1 {
+			return fmt.Errorf("site_pue: %s must be at least 1, got %v", site, pue)
+		}
+	}
+	return nil
+}
+
+// ApplyTarget returns the target with its site's PUE set on its power
+// profile, unless the profile has its own
+func (ec EnergyConfig) ApplyTarget(target OffloadTarget) OffloadTarget {
+	if target.Power == nil || target.Power.PUE != 0 {
+		return target
+	}
+	pue, exists := ec.SitePUE[target.Location]
+	if !exists {
+		return target
+	}
+	profile := *target.Power
+	profile.PUE = pue
+	target.Power = &profile
+	return target
+}
+
+// EstimateEnergy estimates the energy a process draws running on the target
+// for a duration: the extra power its load draws over the target's current
+// load, times the site's PUE. It is zero for targets without a power
+// profile.
+func (ot OffloadTarget) EstimateEnergy(process Process, duration time.Duration) units.Energy {
+	if ot.Power == nil {
+		return 0
+	}
+	added := 1.0
+	if ot.TotalCapacity > 0 {
+		added = process.CPURequirement / ot.TotalCapacity
+	}
+	before := ot.Power.Draw(ot.CurrentLoad)
+	after := ot.Power.Draw(ot.CurrentLoad + added)
+
+	pue := ot.Power.PUE
+	if pue == 0 {
+		pue = 1
+	}
+	return units.Energy(float64((after - before).Over(duration)) * pue)
+}
+
+// EnergyCostOver returns the cost of the energy a process draws running on
+// the target for a duration: the energy at the target's energy price with a
+// power profile, otherwise the energy cost factor per hour
+func (ot OffloadTarget) EnergyCostOver(process Process, duration time.Duration) float64 {
+	if ot.Power == nil {
+		return ot.EnergyCost * duration.Hours()
+	}
+	return ot.EstimateEnergy(process, duration).KilowattHours() * ot.EnergyPrice
+}
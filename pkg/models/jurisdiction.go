@@ -0,0 +1,148 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TransferEdge allows data held in one jurisdiction to move to another
+type TransferEdge struct {
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+	RequiresSCC bool    `json:"requires_scc"` // Only allowed for processes with standard contractual clauses
+	CostFactor  float64 `json:"cost_factor"`  // Transfer cost multiplier (0 = 1.0)
+}
+
+// JurisdictionGraph models which cross-jurisdiction data transfers are
+// permitted. Transfers within a jurisdiction are always allowed; any other
+// transfer needs an explicit edge. Jurisdiction names are case-insensitive.
+type JurisdictionGraph struct {
+	edges map[string]map[string]TransferEdge
+	mu    sync.RWMutex
+}
+
+// NewJurisdictionGraph creates a graph from a set of allowed transfers
+func NewJurisdictionGraph(edges ...TransferEdge) (*JurisdictionGraph, error) {
+	graph := &JurisdictionGraph{edges: make(map[string]map[string]TransferEdge)}
+	for _, edge := range edges {
+		if err := graph.Allow(edge); err != nil {
+			return nil, err
+		}
+	}
+	return graph, nil
+}
+
+// Allow adds or replaces an allowed transfer
+func (jg *JurisdictionGraph) Allow(edge TransferEdge) error {
+	from, to := normalizeJurisdiction(edge.From), normalizeJurisdiction(edge.To)
+	if from == "" || to == "" {
+		return fmt.Errorf("transfer edge requires both jurisdictions, got %q -> %q", edge.From, edge.To)
+	}
+	if edge.CostFactor < 0 {
+		return fmt.Errorf("transfer edge %s -> %s: cost factor must be non-negative", edge.From, edge.To)
+	}
+
+	jg.mu.Lock()
+	defer jg.mu.Unlock()
+
+	if jg.edges[from] == nil {
+		jg.edges[from] = make(map[string]TransferEdge)
+	}
+	jg.edges[from][to] = edge
+	return nil
+}
+
+// CanTransfer reports whether data may move between jurisdictions, and why
+// not if it may not
+func (jg *JurisdictionGraph) CanTransfer(from, to string, scc bool) (bool, string) {
+	from, to = normalizeJurisdiction(from), normalizeJurisdiction(to)
+	if from == to {
+		return true, ""
+	}
+	if to == "" {
+		return false, fmt.Sprintf("target jurisdiction unknown for %s data", from)
+	}
+
+	jg.mu.RLock()
+	edge, exists := jg.edges[from][to]
+	jg.mu.RUnlock()
+
+	if !exists {
+		return false, fmt.Sprintf("transfer %s -> %s not permitted", from, to)
+	}
+	if edge.RequiresSCC && !scc {
+		return false, fmt.Sprintf("transfer %s -> %s requires standard contractual clauses", from, to)
+	}
+	return true, ""
+}
+
+// CheckPlacement reports whether every jurisdiction the process's data resides
+// in may transfer to the target's jurisdiction
+func (jg *JurisdictionGraph) CheckPlacement(process Process, target OffloadTarget) (bool, string) {
+	for _, residency := range process.DataResidency {
+		if ok, reason := jg.CanTransfer(residency, target.DataJurisdiction, process.SCCApproved); !ok {
+			return false, reason
+		}
+	}
+	return true, ""
+}
+
+// PermittedJurisdictions returns the jurisdictions, out of those given, that
+// every jurisdiction the process's data resides in may transfer to
+func (jg *JurisdictionGraph) PermittedJurisdictions(process Process, jurisdictions []string) []string {
+	permitted := make([]string, 0, len(jurisdictions))
+	for _, jurisdiction := range jurisdictions {
+		if ok, _ := jg.CheckPlacement(process, OffloadTarget{DataJurisdiction: jurisdiction}); ok {
+			permitted = append(permitted, jurisdiction)
+		}
+	}
+	return permitted
+}
+
+// TransferCostFactor returns the largest cost multiplier over the transfers
+// needed to place the process on the target, or 1.0 if none applies
+func (jg *JurisdictionGraph) TransferCostFactor(process Process, target OffloadTarget) float64 {
+	to := normalizeJurisdiction(target.DataJurisdiction)
+	factor := 1.0
+
+	jg.mu.RLock()
+	defer jg.mu.RUnlock()
+
+	for _, residency := range process.DataResidency {
+		from := normalizeJurisdiction(residency)
+		if from == to {
+			continue
+		}
+		if edge, exists := jg.edges[from][to]; exists && edge.CostFactor > factor {
+			factor = edge.CostFactor
+		}
+	}
+	return factor
+}
+
+// Edges returns all allowed transfers ordered by source and destination
+func (jg *JurisdictionGraph) Edges() []TransferEdge {
+	jg.mu.RLock()
+	defer jg.mu.RUnlock()
+
+	edges := make([]TransferEdge, 0)
+	for _, targets := range jg.edges {
+		for _, edge := range targets {
+			edges = append(edges, edge)
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		fromI, fromJ := normalizeJurisdiction(edges[i].From), normalizeJurisdiction(edges[j].From)
+		if fromI != fromJ {
+			return fromI < fromJ
+		}
+		return normalizeJurisdiction(edges[i].To) < normalizeJurisdiction(edges[j].To)
+	})
+	return edges
+}
+
+func normalizeJurisdiction(jurisdiction string) string {
+	return strings.ToLower(strings.TrimSpace(jurisdiction))
+}
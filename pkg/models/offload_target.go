@@ -1,8 +1,11 @@
 package models
 
 import (
+	"fmt"
 	"strings"
 	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
 )
 
 // OffloadTarget represents a potential execution destination
@@ -22,7 +25,7 @@ type OffloadTarget struct {
 	NetworkLatency   time.Duration `json:"network_latency"`   // Round-trip latency
 	NetworkBandwidth float64       `json:"network_bandwidth"` // Available bandwidth (bytes/sec)
 	NetworkStability float64       `json:"network_stability"` // Stability score (0.0-1.0)
-	NetworkCost      float64       `json:"network_cost"`      // Cost per byte transferred
+	NetworkCost      float64       `json:"network_cost"`      // Cost per MiB transferred
 
 	// Performance characteristics
 	ProcessingSpeed float64 `json:"processing_speed"` // Relative speed multiplier
@@ -30,23 +33,37 @@ type OffloadTarget struct {
 
 	// Economic factors
 	ComputeCost float64 `json:"compute_cost"` // Cost per compute unit
-	EnergyCost  float64 `json:"energy_cost"`  // Energy cost factor
+	EnergyCost  float64 `json:"energy_cost"`  // Energy cost per hour, without a power profile
+
+	// Energy
+	Power       *PowerProfile `json:"power,omitempty"`        // Power drawn by utilization (nil = energy is priced by EnergyCost)
+	EnergyPrice float64       `json:"energy_price,omitempty"` // Cost per kWh drawn, with a power profile
+	Battery     *BatteryState `json:"battery,omitempty"`      // Charge and duty cycle of battery-powered devices (nil = mains powered)
+
+	// Currency of the compute, network and energy costs (empty = USD)
+	Currency units.Currency `json:"currency,omitempty"`
+
+	// Billing
+	BillingIncrement time.Duration `json:"billing_increment"` // Compute is billed in whole increments (0 = continuous)
+	MinimumLease     time.Duration `json:"minimum_lease"`     // Shortest billed lease
+	LeaseStart       time.Time     `json:"lease_start"`       // When the target's current lease began (zero = not leased)
 
 	// Policy compliance
-	SecurityLevel     int      `json:"security_level"`      // Available security level (0-5)
-	DataJurisdiction  string   `json:"data_jurisdiction"`   // Legal jurisdiction
-	ComplianceFlags   []string `json:"compliance_flags"`    // Compliance certifications
-	EnergySource      string   `json:"energy_source"`       // Energy source type
-	Capabilities      []string `json:"capabilities"`        // Target capabilities
+	SecurityLevel    int      `json:"security_level"`    // Available security level (0-5)
+	DataJurisdiction string   `json:"data_jurisdiction"` // Legal jurisdiction
+	ComplianceFlags  []string `json:"compliance_flags"`  // Compliance certifications
+	EnergySource     string   `json:"energy_source"`     // Energy source type
+	Capabilities     []string `json:"capabilities"`      // Target capabilities
 
 	// Runtime state
-	CurrentLoad       float64       `json:"current_load"`        // Current utilization (0.0-1.0)
-	EstimatedWaitTime time.Duration `json:"estimated_wait_time"` // Expected queue wait
-	LastSeen          time.Time     `json:"last_seen"`           // Last health check
+	CurrentLoad       float64       `json:"current_load"`          // Current utilization (0.0-1.0)
+	EstimatedWaitTime time.Duration `json:"estimated_wait_time"`   // Expected queue wait
+	LastSeen          time.Time     `json:"last_seen"`             // Last health check
+	Temperature       float64       `json:"temperature,omitempty"` // °C (0 = not reported)
 
 	// Learning state (updated by algorithm)
-	PolicyBonus       float64 `json:"policy_bonus"`        // Policy-derived score modifier
-	HistoricalSuccess float64 `json:"historical_success"`  // Success rate with this target
+	PolicyBonus       float64 `json:"policy_bonus"`       // Policy-derived score modifier
+	HistoricalSuccess float64 `json:"historical_success"` // Success rate with this target
 }
 
 // Validate validates the offload target
@@ -74,13 +91,13 @@ func (ot OffloadTarget) Validate() error {
 		"NetworkBandwidth must be non-negative")
 
 	// Validate scores are in [0.0, 1.0] range
-	errors.AddIf(ot.NetworkStability < 0.0 || ot.NetworkStability > 1.0, 
+	errors.AddIf(ot.NetworkStability < 0.0 || ot.NetworkStability > 1.0,
 		"NetworkStability", ot.NetworkStability,
 		"NetworkStability must be in range [0.0, 1.0]")
-	errors.AddIf(ot.Reliability < 0.0 || ot.Reliability > 1.0, 
+	errors.AddIf(ot.Reliability < 0.0 || ot.Reliability > 1.0,
 		"Reliability", ot.Reliability,
 		"Reliability must be in range [0.0, 1.0]")
-	errors.AddIf(ot.HistoricalSuccess < 0.0 || ot.HistoricalSuccess > 1.0, 
+	errors.AddIf(ot.HistoricalSuccess < 0.0 || ot.HistoricalSuccess > 1.0,
 		"HistoricalSuccess", ot.HistoricalSuccess,
 		"HistoricalSuccess must be in range [0.0, 1.0]")
 
@@ -91,9 +108,30 @@ func (ot OffloadTarget) Validate() error {
 		"EnergyCost must be non-negative")
 	errors.AddIf(ot.NetworkCost < 0, "NetworkCost", ot.NetworkCost,
 		"NetworkCost must be non-negative")
+	errors.AddIf(ot.EnergyPrice < 0, "EnergyPrice", ot.EnergyPrice,
+		"EnergyPrice must be non-negative")
+	if ot.Power != nil {
+		if err := ot.Power.Validate(); err != nil {
+			errors.Add("Power", *ot.Power, err.Error())
+		}
+	}
+	if ot.Battery != nil {
+		if err := ot.Battery.Validate(); err != nil {
+			errors.Add("Battery", *ot.Battery, err.Error())
+		}
+	}
+	if ot.Currency != "" {
+		if err := ot.Currency.Validate(); err != nil {
+			errors.Add("Currency", ot.Currency, err.Error())
+		}
+	}
+	errors.AddIf(ot.BillingIncrement < 0, "BillingIncrement", ot.BillingIncrement,
+		"BillingIncrement must be non-negative")
+	errors.AddIf(ot.MinimumLease < 0, "MinimumLease", ot.MinimumLease,
+		"MinimumLease must be non-negative")
 
 	// Validate security level
-	errors.AddIf(ot.SecurityLevel < 0 || ot.SecurityLevel > 5, 
+	errors.AddIf(ot.SecurityLevel < 0 || ot.SecurityLevel > 5,
 		"SecurityLevel", ot.SecurityLevel,
 		"SecurityLevel must be in range [0,5]")
 
@@ -106,7 +144,7 @@ func (ot OffloadTarget) Validate() error {
 			break
 		}
 	}
-	errors.AddIf(!validJurisdiction && ot.DataJurisdiction != "", 
+	errors.AddIf(!validJurisdiction && ot.DataJurisdiction != "",
 		"DataJurisdiction", ot.DataJurisdiction,
 		"Invalid data jurisdiction")
 
@@ -163,6 +201,14 @@ func (ot OffloadTarget) GetWarnings() []string {
 		warnings = append(warnings, "high cost target")
 	}
 
+	// Warn about capabilities that requirements cannot name
+	for _, capability := range ot.Capabilities {
+		if !Capability(capability).IsValid() {
+			warnings = append(warnings, fmt.Sprintf("capability %q is outside the taxonomy", capability))
+			break
+		}
+	}
+
 	// Check for duplicate compliance flags
 	flagSet := make(map[string]bool)
 	for _, flag := range ot.ComplianceFlags {
@@ -206,6 +252,16 @@ func (ot OffloadTarget) HasAnyCapability(options []string) bool {
 	return false
 }
 
+// SatisfiesRequirements checks the target's capabilities against a process's
+// requirements expression
+func (ot OffloadTarget) SatisfiesRequirements(expression string) (bool, error) {
+	requirement, err := ParseRequirements(expression)
+	if err != nil {
+		return false, err
+	}
+	return requirement.Matches(ot.Capabilities), nil
+}
+
 // IsAvailable returns true if the target has available capacity
 func (ot OffloadTarget) IsAvailable() bool {
 	return ot.AvailableCapacity > 0 && ot.MemoryAvailable > 0
@@ -287,18 +343,75 @@ func (ot OffloadTarget) EstimateExecutionTime(process Process) time.Duration {
 
 // GetTotalCost estimates the total cost of running a process on this target
 func (ot OffloadTarget) GetTotalCost(process Process) float64 {
-	// Compute cost based on estimated duration
+	// Compute cost based on estimated duration, as billed
 	executionTime := ot.EstimateExecutionTime(process)
-	computeCost := ot.ComputeCost * executionTime.Hours()
+	computeCost := ot.ComputeCost * ot.BilledTime(executionTime).Hours()
 
 	// Network cost based on data transfer
-	dataSize := process.InputSize + process.OutputSize
-	networkCost := ot.NetworkCost * float64(dataSize)/(1024*1024) // Cost per MB
+	networkCost := ot.TransferCost(units.DataSize(process.InputSize + process.OutputSize))
 
-	// Energy cost
-	energyCost := ot.EnergyCost * executionTime.Hours()
+	return computeCost + networkCost + ot.GetEnergyCost(process)
+}
 
-	return computeCost + networkCost + energyCost
+// TransferCost returns the network cost of moving data to or from this target
+func (ot OffloadTarget) TransferCost(size units.DataSize) float64 {
+	return ot.NetworkCost * size.MiB()
+}
+
+// InCurrency returns the target with its compute, network and energy costs
+// and energy price converted to a currency
+func (ot OffloadTarget) InCurrency(rates units.Rates, currency units.Currency) (OffloadTarget, error) {
+	from := ot.Currency.OrBase()
+	if from == currency.OrBase() {
+		ot.Currency = currency
+		return ot, nil
+	}
+	for _, cost := range []*float64{&ot.ComputeCost, &ot.NetworkCost, &ot.EnergyCost, &ot.EnergyPrice} {
+		converted, err := rates.Convert(units.Money{Amount: *cost, Currency: from}, currency)
+		if err != nil {
+			return ot, err
+		}
+		*cost = converted.Amount
+	}
+	ot.Currency = currency
+	return ot, nil
+}
+
+// GetEnergyCost estimates the cost of the energy used running a process on
+// this target, over its estimated execution time
+func (ot OffloadTarget) GetEnergyCost(process Process) float64 {
+	return ot.EnergyCostOver(process, ot.EstimateExecutionTime(process))
+}
+
+// BilledTime returns the time billed for using the target for a duration:
+// at least the minimum lease, rounded up to whole billing increments
+func (ot OffloadTarget) BilledTime(duration time.Duration) time.Duration {
+	if duration < ot.MinimumLease {
+		duration = ot.MinimumLease
+	}
+	if ot.BillingIncrement > 0 && duration%ot.BillingIncrement != 0 {
+		duration += ot.BillingIncrement - duration%ot.BillingIncrement
+	}
+	return duration
+}
+
+// PaidUntil returns when the time already billed for the target's current
+// lease runs out, or the zero time if it is not leased
+func (ot OffloadTarget) PaidUntil(now time.Time) time.Time {
+	if ot.LeaseStart.IsZero() {
+		return time.Time{}
+	}
+	elapsed := now.Sub(ot.LeaseStart)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	// The increment in progress is already billed
+	paid := elapsed
+	if ot.BillingIncrement > 0 {
+		paid = (elapsed/ot.BillingIncrement + 1) * ot.BillingIncrement
+	}
+	return ot.LeaseStart.Add(ot.BilledTime(paid))
 }
 
 // GetCompatibilityScore returns a compatibility score for a process (0.0-1.0)
@@ -382,4 +495,4 @@ func abs(x float64) float64 {
 		return -x
 	}
 	return x
-}
\ No newline at end of file
+}
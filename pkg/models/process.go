@@ -1,43 +1,65 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
 )
 
 // Process represents a workload candidate for offloading
 type Process struct {
 	// Identity
-	ID   string `json:"id"`
-	Type string `json:"type"`
-	Priority int `json:"priority"` // Priority level (1-10, 10=highest)
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	TenantID  string `json:"tenant_id"`  // Owning tenant (empty = untracked)
+	ProjectID string `json:"project_id"` // Project within the tenant costs are attributed to (empty = none)
+	Priority  int    `json:"priority"`   // Priority level (1-10, 10=highest)
 
 	// Resource requirements
-	CPURequirement    float64 `json:"cpu_requirement"`     // CPU cores needed
-	MemoryRequirement int64   `json:"memory_requirement"`  // Memory bytes needed
-	DiskRequirement   int64   `json:"disk_requirement"`    // Storage bytes needed
+	CPURequirement     float64 `json:"cpu_requirement"`     // CPU cores needed
+	MemoryRequirement  int64   `json:"memory_requirement"`  // Memory bytes needed
+	DiskRequirement    int64   `json:"disk_requirement"`    // Storage bytes needed
 	NetworkRequirement float64 `json:"network_requirement"` // Network bandwidth needed
 
 	// Data characteristics
-	InputSize       int64 `json:"input_size"`        // Input data bytes
-	OutputSize      int64 `json:"output_size"`       // Expected output data bytes
-	DataSensitivity int   `json:"data_sensitivity"`  // Sensitivity level (0-5)
+	InputSize        int64       `json:"input_size"`         // Input data bytes
+	OutputSize       int64       `json:"output_size"`        // Expected output data bytes
+	InputDatasetID   string      `json:"input_dataset_id"`   // Shared input dataset (empty if input is unique)
+	OutputDatasetID  string      `json:"output_dataset_id"`  // Dataset the output materializes as (empty if not reused)
+	InputStorageTier StorageTier `json:"input_storage_tier"` // Tier the input is read from (empty if not modeled)
+	DataSensitivity  int         `json:"data_sensitivity"`   // Sensitivity level (0-5)
 
 	// Execution characteristics
 	EstimatedDuration time.Duration `json:"estimated_duration"` // Expected runtime
 	MaxDuration       time.Duration `json:"max_duration"`       // SLA deadline
 	RealTime          bool          `json:"real_time"`          // Real-time processing required
 	SafetyCritical    bool          `json:"safety_critical"`    // Safety implications
+	Parallelizable    bool          `json:"parallelizable"`     // Workload can be split across targets
+	MaxShards         int           `json:"max_shards"`         // Upper bound on shards (0 = engine default)
+	GangSize          int           `json:"gang_size"`          // Members offloaded all-or-nothing, each with the full requirements (0 = not a gang)
+
+	// Function call
+	FuncName   string   `json:"func_name"`  // Function the process executes (empty if not a function call)
+	Args       []string `json:"args"`       // Function arguments
+	InputHash  string   `json:"input_hash"` // Content hash of the input data (empty if none)
+	Idempotent bool     `json:"idempotent"` // Identical calls return identical results
 
 	// Dependencies
-	HasDAG       bool     `json:"has_dag"`       // Is part of processing pipeline
-	DAG          *DAG     `json:"dag"`           // Pipeline structure if applicable
-	Dependencies []string `json:"dependencies"`  // Process dependencies
+	HasDAG       bool     `json:"has_dag"`      // Is part of processing pipeline
+	DAG          *DAG     `json:"dag"`          // Pipeline structure if applicable
+	Dependencies []string `json:"dependencies"` // Process dependencies
 
 	// Policy attributes
-	LocalityRequired bool `json:"locality_required"` // Must stay in jurisdiction
-	SecurityLevel    int  `json:"security_level"`    // Required security level (0-5)
+	LocalityRequired bool     `json:"locality_required"` // Must stay in jurisdiction
+	SecurityLevel    int      `json:"security_level"`    // Required security level (0-5)
+	DataResidency    []string `json:"data_residency"`    // Jurisdictions the input data resides in
+	SCCApproved      bool     `json:"scc_approved"`      // Standard contractual clauses cover cross-border transfer
+	Affinity         Affinity `json:"affinity"`          // Placement relative to other processes and targets
+	Requirements     string   `json:"requirements"`      // Capability requirements expression, e.g. "gpu_accelerated AND (region:eu OR low_latency)"
 
 	// State
 	SubmissionTime time.Time     `json:"submission_time"` // When submitted
@@ -53,12 +75,12 @@ type DAG struct {
 
 // Stage represents a stage in a processing pipeline
 type Stage struct {
-	ID           string    `json:"id"`
-	Name         string    `json:"name"`
-	InputSize    int64     `json:"input_size"`
-	OutputSize   int64     `json:"output_size"`
-	Dependencies []string  `json:"dependencies"`
-	Depth        int       `json:"depth"`        // Depth in the DAG
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	InputSize    int64    `json:"input_size"`
+	OutputSize   int64    `json:"output_size"`
+	Dependencies []string `json:"dependencies"`
+	Depth        int      `json:"depth"` // Depth in the DAG
 }
 
 // Validate validates the process
@@ -69,39 +91,54 @@ func (p Process) Validate() error {
 	errors.AddIf(p.ID == "", "ID", p.ID, "ID cannot be empty")
 
 	// Validate priority range [1,10]
-	errors.AddIf(p.Priority < 1 || p.Priority > 10, "Priority", p.Priority, 
+	errors.AddIf(p.Priority < 1 || p.Priority > 10, "Priority", p.Priority,
 		"Priority must be in range [1,10]")
 
 	// Validate resource requirements are non-negative
-	errors.AddIf(p.CPURequirement < 0, "CPURequirement", p.CPURequirement, 
+	errors.AddIf(p.CPURequirement < 0, "CPURequirement", p.CPURequirement,
 		"CPURequirement must be non-negative")
-	errors.AddIf(p.MemoryRequirement < 0, "MemoryRequirement", p.MemoryRequirement, 
+	errors.AddIf(p.MemoryRequirement < 0, "MemoryRequirement", p.MemoryRequirement,
 		"MemoryRequirement must be non-negative")
-	errors.AddIf(p.DiskRequirement < 0, "DiskRequirement", p.DiskRequirement, 
+	errors.AddIf(p.DiskRequirement < 0, "DiskRequirement", p.DiskRequirement,
 		"DiskRequirement must be non-negative")
-	errors.AddIf(p.NetworkRequirement < 0, "NetworkRequirement", p.NetworkRequirement, 
+	errors.AddIf(p.NetworkRequirement < 0, "NetworkRequirement", p.NetworkRequirement,
 		"NetworkRequirement must be non-negative")
 
 	// Validate data sizes are non-negative
-	errors.AddIf(p.InputSize < 0, "InputSize", p.InputSize, 
+	errors.AddIf(p.InputSize < 0, "InputSize", p.InputSize,
 		"InputSize must be non-negative")
-	errors.AddIf(p.OutputSize < 0, "OutputSize", p.OutputSize, 
+	errors.AddIf(p.OutputSize < 0, "OutputSize", p.OutputSize,
 		"OutputSize must be non-negative")
+	errors.AddIf(p.InputStorageTier != "" && !p.InputStorageTier.IsValid(), "InputStorageTier", p.InputStorageTier,
+		"InputStorageTier must be hot, warm, cold or archive")
 
 	// Validate EstimatedDuration > 0
-	errors.AddIf(p.EstimatedDuration <= 0, "EstimatedDuration", p.EstimatedDuration, 
+	errors.AddIf(p.EstimatedDuration <= 0, "EstimatedDuration", p.EstimatedDuration,
 		"EstimatedDuration must be > 0 for valid processes")
 
 	// Validate MaxDuration is non-negative
-	errors.AddIf(p.MaxDuration < 0, "MaxDuration", p.MaxDuration, 
+	errors.AddIf(p.MaxDuration < 0, "MaxDuration", p.MaxDuration,
 		"MaxDuration must be non-negative")
 
+	// Validate GangSize is non-negative
+	errors.AddIf(p.GangSize < 0, "GangSize", p.GangSize,
+		"GangSize must be non-negative")
+
+	// Validate shard limit is non-negative
+	errors.AddIf(p.MaxShards < 0, "MaxShards", p.MaxShards,
+		"MaxShards must be non-negative")
+
 	// Validate security and sensitivity levels
-	errors.AddIf(p.DataSensitivity < 0 || p.DataSensitivity > 5, "DataSensitivity", p.DataSensitivity, 
+	errors.AddIf(p.DataSensitivity < 0 || p.DataSensitivity > 5, "DataSensitivity", p.DataSensitivity,
 		"DataSensitivity must be in range [0,5]")
-	errors.AddIf(p.SecurityLevel < 0 || p.SecurityLevel > 5, "SecurityLevel", p.SecurityLevel, 
+	errors.AddIf(p.SecurityLevel < 0 || p.SecurityLevel > 5, "SecurityLevel", p.SecurityLevel,
 		"SecurityLevel must be in range [0,5]")
 
+	// Validate capability requirements parse against the taxonomy
+	if _, err := ParseRequirements(p.Requirements); err != nil {
+		errors.Add("Requirements", p.Requirements, err.Error())
+	}
+
 	// Validate DAG consistency
 	if p.HasDAG && p.DAG == nil {
 		errors.Add("DAG", p.DAG, "Process marked as having DAG but DAG is nil")
@@ -141,20 +178,20 @@ func (p Process) GetWarnings() []string {
 
 	// Warn if deadline is before estimated duration
 	if p.MaxDuration > 0 && p.MaxDuration < p.EstimatedDuration {
-		warnings = append(warnings, 
-			fmt.Sprintf("deadline (%v) is before estimated duration (%v)", 
+		warnings = append(warnings,
+			fmt.Sprintf("deadline (%v) is before estimated duration (%v)",
 				p.MaxDuration, p.EstimatedDuration))
 	}
 
 	// Warn if real-time process has low priority
 	if p.RealTime && p.Priority < 7 {
-		warnings = append(warnings, 
+		warnings = append(warnings,
 			"real-time process should have high priority (≥7)")
 	}
 
 	// Warn if safety-critical process has low priority
 	if p.SafetyCritical && p.Priority < 9 {
-		warnings = append(warnings, 
+		warnings = append(warnings,
 			"safety-critical process should have maximum priority (≥9)")
 	}
 
@@ -189,7 +226,7 @@ func (p Process) GetDataSize() int64 {
 
 // IsDataIntensive returns true if the process has large data requirements
 func (p Process) IsDataIntensive() bool {
-	const dataThreshold = 10 * 1024 * 1024 // 10MB
+	const dataThreshold = int64(10 * units.MiB)
 	return p.GetDataSize() > dataThreshold
 }
 
@@ -200,7 +237,7 @@ func (p Process) IsCPUIntensive() bool {
 
 // IsMemoryIntensive returns true if the process has high memory requirements
 func (p Process) IsMemoryIntensive() bool {
-	const memoryThreshold = 8 * 1024 * 1024 * 1024 // 8GB
+	const memoryThreshold = int64(8 * units.GiB)
 	return p.MemoryRequirement > memoryThreshold
 }
 
@@ -231,16 +268,30 @@ func (p Process) GetResourceProfile() string {
 	return strings.Join(profile, ",")
 }
 
+// CallKey identifies a function call by its function, arguments and input
+// hash, so identical calls share a key. It is empty when the process is not
+// a function call.
+func (p Process) CallKey() string {
+	if p.FuncName == "" {
+		return ""
+	}
+	hash := sha256.New()
+	for _, part := range append([]string{p.FuncName, p.InputHash}, p.Args...) {
+		fmt.Fprintf(hash, "%d:%s", len(part), part)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 // GetSLABuffer returns the time buffer between estimated and max duration
 func (p Process) GetSLABuffer() time.Duration {
 	if p.MaxDuration <= 0 {
 		return 0 // No SLA deadline
 	}
-	
+
 	if p.MaxDuration > p.EstimatedDuration {
 		return p.MaxDuration - p.EstimatedDuration
 	}
-	
+
 	return 0
 }
 
@@ -249,11 +300,29 @@ func (p Process) GetBufferRatio() float64 {
 	if p.EstimatedDuration <= 0 {
 		return 0
 	}
-	
+
 	buffer := p.GetSLABuffer()
 	return float64(buffer) / float64(p.EstimatedDuration)
 }
 
+// Shard returns the portion of the process covering the given fraction of its
+// work and data (for split offloading)
+func (p Process) Shard(index int, fraction float64) Process {
+	shard := p
+	shard.ID = fmt.Sprintf("%s-shard-%d", p.ID, index)
+	shard.CPURequirement = p.CPURequirement * fraction
+	shard.MemoryRequirement = int64(float64(p.MemoryRequirement) * fraction)
+	shard.InputSize = int64(float64(p.InputSize) * fraction)
+	shard.OutputSize = int64(float64(p.OutputSize) * fraction)
+	shard.EstimatedDuration = time.Duration(float64(p.EstimatedDuration) * fraction)
+	if shard.EstimatedDuration <= 0 {
+		shard.EstimatedDuration = time.Millisecond
+	}
+	shard.Parallelizable = false
+	shard.MaxShards = 0
+	return shard
+}
+
 // ToProcess converts a Stage to a Process (for DAG processing)
 func (s Stage) ToProcess() Process {
 	return Process{
@@ -263,7 +332,7 @@ func (s Stage) ToProcess() Process {
 		OutputSize:        s.OutputSize,
 		Dependencies:      s.Dependencies,
 		EstimatedDuration: 30 * time.Second, // Default for stages
-		Priority:          5,                 // Default priority
+		Priority:          5,                // Default priority
 		Status:            QUEUED,
 	}
 }
@@ -378,4 +447,4 @@ func (dag *DAG) GetDepth() map[string]int {
 	}
 
 	return depths
-}
\ No newline at end of file
+}
@@ -0,0 +1,239 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit bounds the search for the next run of a schedule that can
+// never fire (e.g. February 30th)
+const cronSearchLimit = 5 * 365 * 24 * time.Hour
+
+// cronDescriptors map shorthand schedules to their five-field equivalents
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronSchedule is a parsed five-field cron expression: minute, hour, day of
+// month, month and day of week. Times are matched in their own location.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64 // Bit sets of matching values
+	domAny, dowAny                bool   // Field was "*"
+}
+
+// ParseCron parses a cron expression. Fields accept "*", values, ranges
+// ("1-5"), lists ("1,15") and steps ("*/15", "0-30/10"); day of week 7 is
+// Sunday. The descriptors @hourly, @daily, @weekly, @monthly and @yearly
+// are also accepted.
+func ParseCron(expr string) (CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if descriptor, exists := cronDescriptors[expr]; exists {
+		expr = descriptor
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	var schedule CronSchedule
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return CronSchedule{}, fmt.Errorf("minute: %w", err)
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return CronSchedule{}, fmt.Errorf("hour: %w", err)
+	}
+	if schedule.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return CronSchedule{}, fmt.Errorf("day of month: %w", err)
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return CronSchedule{}, fmt.Errorf("month: %w", err)
+	}
+	if schedule.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return CronSchedule{}, fmt.Errorf("day of week: %w", err)
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1 // 7 is Sunday
+	}
+	schedule.domAny = fields[2] == "*"
+	schedule.dowAny = fields[4] == "*"
+	return schedule, nil
+}
+
+// parseCronField parses one comma-separated cron field into a bit set
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if slash := strings.Index(part, "/"); slash >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[slash+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart = part[:slash]
+		}
+
+		low, high := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var errLow, errHigh error
+			low, errLow = strconv.Atoi(bounds[0])
+			high, errHigh = strconv.Atoi(bounds[1])
+			if errLow != nil || errHigh != nil {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			low, high = value, value
+			if step > 1 {
+				high = max // "5/15" runs from 5 to the end of the range
+			}
+		}
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// Next returns the first time after the given time that the schedule fires,
+// or the zero time if it never does
+func (cs CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(cronSearchLimit)
+	for t.Before(limit) {
+		switch {
+		case cs.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !cs.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case cs.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case cs.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Between returns the times the schedule fires in (from, to], in order
+func (cs CronSchedule) Between(from, to time.Time) []time.Time {
+	times := make([]time.Time, 0)
+	for t := cs.Next(from); !t.IsZero() && !t.After(to); t = cs.Next(t) {
+		times = append(times, t)
+	}
+	return times
+}
+
+// dayMatches applies cron's day rule: when both day of month and day of week
+// are restricted, either may match
+func (cs CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := cs.dom&(1<<uint(t.Day())) != 0
+	dowMatch := cs.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case cs.domAny && cs.dowAny:
+		return true
+	case cs.domAny:
+		return dowMatch
+	case cs.dowAny:
+		return domMatch
+	}
+	return domMatch || dowMatch
+}
+
+// RecurringProcess is a process submitted on a cron schedule, such as a
+// ColonyOS cron workflow
+type RecurringProcess struct {
+	ID           string    `json:"id"`
+	Schedule     string    `json:"schedule"`      // Cron expression, see ParseCron
+	Template     Process   `json:"template"`      // Submitted at each run; its ID is derived from the run time
+	Until        time.Time `json:"until"`         // No runs after this time (zero = no end)
+	TimeZone     string    `json:"time_zone"`     // IANA time zone the schedule is matched in (empty = the calendar's)
+	BusinessDays bool      `json:"business_days"` // Skip runs on the calendar's weekends and holidays
+}
+
+// Validate checks the definition's schedule and process template
+func (rp RecurringProcess) Validate() error {
+	if rp.ID == "" {
+		return fmt.Errorf("recurring process ID cannot be empty")
+	}
+	if _, err := ParseCron(rp.Schedule); err != nil {
+		return fmt.Errorf("schedule: %w", err)
+	}
+	if rp.TimeZone != "" {
+		if _, err := time.LoadLocation(rp.TimeZone); err != nil {
+			return fmt.Errorf("time_zone: %w", err)
+		}
+	}
+	template := rp.Template
+	template.ID = rp.ID
+	if err := template.Validate(); err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+	return nil
+}
+
+// Runs returns the times the definition is submitted in (from, to]. The
+// schedule is matched in the definition's time zone, or else the calendar's,
+// or else from's location. Business days are those of the calendar, or
+// DefaultCalendar when it is nil.
+func (rp RecurringProcess) Runs(from, to time.Time, calendar *Calendar) ([]time.Time, error) {
+	schedule, err := ParseCron(rp.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if calendar == nil {
+		calendar = DefaultCalendar()
+	}
+	from = calendar.In(from)
+	if rp.TimeZone != "" {
+		location, err := time.LoadLocation(rp.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		from = from.In(location)
+	}
+	if !rp.Until.IsZero() && rp.Until.Before(to) {
+		to = rp.Until
+	}
+
+	runs := schedule.Between(from, to)
+	if !rp.BusinessDays {
+		return runs, nil
+	}
+	businessRuns := runs[:0]
+	for _, at := range runs {
+		if calendar.IsBusinessDay(at) {
+			businessRuns = append(businessRuns, at)
+		}
+	}
+	return businessRuns, nil
+}
+
+// Instance returns the process submitted by the run at the given time
+func (rp RecurringProcess) Instance(at time.Time) Process {
+	process := rp.Template
+	process.ID = fmt.Sprintf("%s@%s", rp.ID, at.UTC().Format("20060102T1504Z"))
+	process.SubmissionTime = at
+	process.StartTime = time.Time{}
+	process.Status = QUEUED
+	return process
+}
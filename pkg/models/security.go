@@ -0,0 +1,80 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// SecurityPolicy negotiates the protection a process's data sensitivity
+// demands: the minimum security level of the target it runs on, and whether
+// its data must be encrypted in transit. Encryption is not free; it costs
+// target CPU time proportional to the bytes moved plus a handshake.
+type SecurityPolicy struct {
+	Enabled              bool          `json:"enabled"`
+	RequiredLevels       []int         `json:"required_levels"`       // Minimum target security level by data sensitivity 0-5 (empty = the sensitivity itself)
+	EncryptionThreshold  int           `json:"encryption_threshold"`  // Lowest data sensitivity whose transfers are encrypted (0 = all transfers)
+	EncryptionThroughput float64       `json:"encryption_throughput"` // Bytes/sec one core encrypts (0 = 200 MiB/s)
+	HandshakeLatency     time.Duration `json:"handshake_latency"`     // Added once per encrypted transfer
+}
+
+// defaultEncryptionThroughput is a conservative AES-GCM rate for one core
+const defaultEncryptionThroughput = 200 * 1024 * 1024
+
+// Validate checks the policy
+func (sp SecurityPolicy) Validate() error {
+	if len(sp.RequiredLevels) > 0 && len(sp.RequiredLevels) != 6 {
+		return fmt.Errorf("required_levels must give a level for each data sensitivity 0-5, got %d", len(sp.RequiredLevels))
+	}
+	for sensitivity, level := range sp.RequiredLevels {
+		if level < 0 || level > 5 {
+			return fmt.Errorf("required_levels[%d]: security level must be in range [0,5], got %d", sensitivity, level)
+		}
+		if sensitivity > 0 && level < sp.RequiredLevels[sensitivity-1] {
+			return fmt.Errorf("required_levels[%d]: more sensitive data cannot require a lower security level", sensitivity)
+		}
+	}
+	if sp.EncryptionThreshold < 0 || sp.EncryptionThreshold > 6 {
+		return fmt.Errorf("encryption_threshold must be in range [0,6], got %d", sp.EncryptionThreshold)
+	}
+	if sp.EncryptionThroughput < 0 || sp.HandshakeLatency < 0 {
+		return fmt.Errorf("encryption_throughput and handshake_latency must be non-negative")
+	}
+	return nil
+}
+
+// RequiredLevel returns the minimum security level of a target for the
+// process: the higher of its own security level and the level its data
+// sensitivity requires
+func (sp SecurityPolicy) RequiredLevel(process Process) int {
+	required := process.SecurityLevel
+	if !sp.Enabled {
+		return required
+	}
+	sensitivityLevel := process.DataSensitivity
+	if process.DataSensitivity >= 0 && process.DataSensitivity < len(sp.RequiredLevels) {
+		sensitivityLevel = sp.RequiredLevels[process.DataSensitivity]
+	}
+	return max(required, sensitivityLevel)
+}
+
+// RequiresEncryption reports whether the process's transfers must be
+// encrypted in transit
+func (sp SecurityPolicy) RequiresEncryption(process Process) bool {
+	return sp.Enabled && process.DataSensitivity >= sp.EncryptionThreshold
+}
+
+// EncryptionOverhead returns the time encrypting the process's transfers
+// adds: one core's worth of CPU time over the bytes moved, plus the
+// handshake. It is zero when the process needs no encryption or moves no
+// data.
+func (sp SecurityPolicy) EncryptionOverhead(process Process) time.Duration {
+	bytes := process.InputSize + process.OutputSize
+	if !sp.RequiresEncryption(process) || bytes <= 0 {
+		return 0
+	}
+	throughput := sp.EncryptionThroughput
+	if throughput <= 0 {
+		throughput = defaultEncryptionThroughput
+	}
+	return sp.HandshakeLatency + time.Duration(float64(bytes)/throughput*float64(time.Second))
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// StorageTier is the class of storage a dataset is retrieved from
+type StorageTier string
+
+const (
+	HOT_STORAGE     StorageTier = "hot"     // Local NVMe, in-memory caches
+	WARM_STORAGE    StorageTier = "warm"    // NFS, S3 Standard
+	COLD_STORAGE    StorageTier = "cold"    // S3 Infrequent Access, Glacier Instant Retrieval
+	ARCHIVE_STORAGE StorageTier = "archive" // Glacier Flexible Retrieval, tape
+)
+
+// StorageTierSpec describes the cost of retrieving data from a storage tier
+// before it can be transferred
+type StorageTierSpec struct {
+	RetrievalLatency time.Duration `json:"retrieval_latency"` // Time to first byte
+	Throughput       float64       `json:"throughput"`        // Bytes/sec (0 = not limiting)
+	CostPerGB        float64       `json:"cost_per_gb"`       // Retrieval cost
+}
+
+// Validate checks the tier specification
+func (s StorageTierSpec) Validate() error {
+	if s.RetrievalLatency < 0 || s.Throughput < 0 || s.CostPerGB < 0 {
+		return fmt.Errorf("storage tier retrieval latency, throughput and cost must be non-negative")
+	}
+	return nil
+}
+
+// RetrievalTime returns how long reading the given bytes from the tier takes
+func (s StorageTierSpec) RetrievalTime(bytes int64) time.Duration {
+	if bytes <= 0 {
+		return 0
+	}
+	retrieval := s.RetrievalLatency
+	if s.Throughput > 0 {
+		retrieval += time.Duration(float64(bytes) / s.Throughput * float64(time.Second))
+	}
+	return retrieval
+}
+
+// RetrievalCost returns the cost of reading the given bytes from the tier
+func (s StorageTierSpec) RetrievalCost(bytes int64) float64 {
+	return s.CostPerGB * units.DataSize(bytes).GiB()
+}
+
+// IsValid returns true if the tier is a known storage tier
+func (t StorageTier) IsValid() bool {
+	switch t {
+	case HOT_STORAGE, WARM_STORAGE, COLD_STORAGE, ARCHIVE_STORAGE:
+		return true
+	}
+	return false
+}
+
+// DefaultStorageTiers returns typical retrieval characteristics for each tier
+func DefaultStorageTiers() map[StorageTier]StorageTierSpec {
+	return map[StorageTier]StorageTierSpec{
+		HOT_STORAGE:     {RetrievalLatency: 100 * time.Microsecond, Throughput: float64(2 * units.GiB)},
+		WARM_STORAGE:    {RetrievalLatency: 20 * time.Millisecond, Throughput: float64(200 * units.MiB), CostPerGB: 0.0004},
+		COLD_STORAGE:    {RetrievalLatency: 100 * time.Millisecond, Throughput: float64(100 * units.MiB), CostPerGB: 0.01},
+		ARCHIVE_STORAGE: {RetrievalLatency: 4 * time.Hour, Throughput: float64(50 * units.MiB), CostPerGB: 0.02},
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ThermalPolicy keeps heavy processes off hot targets. Targets above the
+// throttle temperature slow down as their clocks are throttled, and heavy
+// processes are not placed on targets at or above the maximum temperature.
+type ThermalPolicy struct {
+	Enabled             bool    `json:"enabled"`
+	ThrottleTemperature float64 `json:"throttle_temperature"` // °C above which targets slow down (0 = 80)
+	MaxTemperature      float64 `json:"max_temperature"`      // °C from which heavy processes are refused (0 = 95)
+	ThrottlePerDegree   float64 `json:"throttle_per_degree"`  // Fraction of speed lost per degree above the throttle temperature (0 = 0.03)
+	HeavyCPU            float64 `json:"heavy_cpu"`            // CPU requirement from which a process is heavy (0 = 4 cores)
+}
+
+// Thermal defaults, typical of server CPUs
+const (
+	defaultThrottleTemperature = 80
+	defaultMaxTemperature      = 95
+	defaultThrottlePerDegree   = 0.03
+	defaultHeavyCPU            = 4
+	minThrottledSpeed          = 0.2 // Throttling never slows a target below this share of its speed
+)
+
+// Validate checks the policy
+func (tp ThermalPolicy) Validate() error {
+	if tp.ThrottleTemperature < 0 || tp.MaxTemperature < 0 || tp.ThrottlePerDegree < 0 || tp.HeavyCPU < 0 {
+		return fmt.Errorf("throttle_temperature, max_temperature, throttle_per_degree and heavy_cpu must be non-negative")
+	}
+	if tp.ThrottlePerDegree >= 1 {
+		return fmt.Errorf("throttle_per_degree must be below 1, got %v", tp.ThrottlePerDegree)
+	}
+	if tp.throttleTemperature() >= tp.maxTemperature() {
+		return fmt.Errorf("throttle_temperature must be below max_temperature, got %v and %v", tp.throttleTemperature(), tp.maxTemperature())
+	}
+	return nil
+}
+
+func (tp ThermalPolicy) throttleTemperature() float64 {
+	if tp.ThrottleTemperature == 0 {
+		return defaultThrottleTemperature
+	}
+	return tp.ThrottleTemperature
+}
+
+func (tp ThermalPolicy) maxTemperature() float64 {
+	if tp.MaxTemperature == 0 {
+		return defaultMaxTemperature
+	}
+	return tp.MaxTemperature
+}
+
+// IsHeavy reports whether a process is heavy enough to be kept off hot
+// targets
+func (tp ThermalPolicy) IsHeavy(process Process) bool {
+	heavy := tp.HeavyCPU
+	if heavy == 0 {
+		heavy = defaultHeavyCPU
+	}
+	return process.CPURequirement >= heavy
+}
+
+// TooHot reports whether a target is too hot for the process. Targets that
+// report no temperature never are.
+func (tp ThermalPolicy) TooHot(process Process, target OffloadTarget) bool {
+	return tp.Enabled && target.Temperature > 0 &&
+		target.Temperature >= tp.maxTemperature() && tp.IsHeavy(process)
+}
+
+// SpeedFactor returns the share of its speed a target runs at, at a
+// temperature: 1 up to the throttle temperature, then falling by the
+// throttle rate per degree
+func (tp ThermalPolicy) SpeedFactor(temperature float64) float64 {
+	excess := temperature - tp.throttleTemperature()
+	if excess <= 0 {
+		return 1
+	}
+	rate := tp.ThrottlePerDegree
+	if rate == 0 {
+		rate = defaultThrottlePerDegree
+	}
+	return math.Max(minThrottledSpeed, 1-excess*rate)
+}
+
+// Throttled returns how long a duration of work takes on the target at its
+// current temperature
+func (tp ThermalPolicy) Throttled(duration time.Duration, target OffloadTarget) time.Duration {
+	if !tp.Enabled {
+		return duration
+	}
+	return time.Duration(float64(duration) / tp.SpeedFactor(target.Temperature))
+}
+
+// Headroom rates how far below the maximum temperature a target runs, from
+// 1 at the throttle temperature or below to 0 at the maximum, for heavy
+// processes. Light processes always have full headroom.
+func (tp ThermalPolicy) Headroom(process Process, target OffloadTarget) float64 {
+	if !tp.Enabled || !tp.IsHeavy(process) {
+		return 1
+	}
+	throttle, maximum := tp.throttleTemperature(), tp.maxTemperature()
+	return math.Max(0, math.Min(1, (maximum-target.Temperature)/(maximum-throttle)))
+}
@@ -8,24 +8,24 @@ import (
 type TargetType string
 
 const (
-	LOCAL        TargetType = "local"
-	EDGE         TargetType = "edge"
+	LOCAL         TargetType = "local"
+	EDGE          TargetType = "edge"
 	PRIVATE_CLOUD TargetType = "private_cloud"
-	PUBLIC_CLOUD TargetType = "public_cloud"
-	HYBRID_CLOUD TargetType = "hybrid_cloud"
-	FOG          TargetType = "fog"
+	PUBLIC_CLOUD  TargetType = "public_cloud"
+	HYBRID_CLOUD  TargetType = "hybrid_cloud"
+	FOG           TargetType = "fog"
 )
 
 // ProcessStatus represents the current state of a process
 type ProcessStatus string
 
 const (
-	QUEUED     ProcessStatus = "queued"
-	ASSIGNED   ProcessStatus = "assigned"
-	EXECUTING  ProcessStatus = "executing"
-	COMPLETED  ProcessStatus = "completed"
-	FAILED     ProcessStatus = "failed"
-	CANCELLED  ProcessStatus = "cancelled"
+	QUEUED    ProcessStatus = "queued"
+	ASSIGNED  ProcessStatus = "assigned"
+	EXECUTING ProcessStatus = "executing"
+	COMPLETED ProcessStatus = "completed"
+	FAILED    ProcessStatus = "failed"
+	CANCELLED ProcessStatus = "cancelled"
 )
 
 // PolicyType represents whether a policy rule is hard or soft
@@ -40,25 +40,27 @@ const (
 type Operator string
 
 const (
-	EQUAL_TO        Operator = "eq"
-	NOT_EQUAL_TO    Operator = "ne"
-	GREATER_THAN    Operator = "gt"
-	LESS_THAN       Operator = "lt"
-	GREATER_EQUAL   Operator = "ge"
-	LESS_EQUAL      Operator = "le"
-	BETWEEN         Operator = "between"
-	NOT_BETWEEN     Operator = "not_between"
-	CONTAINS        Operator = "contains"
-	NOT_CONTAINS    Operator = "not_contains"
+	EQUAL_TO      Operator = "eq"
+	NOT_EQUAL_TO  Operator = "ne"
+	GREATER_THAN  Operator = "gt"
+	LESS_THAN     Operator = "lt"
+	GREATER_EQUAL Operator = "ge"
+	LESS_EQUAL    Operator = "le"
+	BETWEEN       Operator = "between"
+	NOT_BETWEEN   Operator = "not_between"
+	CONTAINS      Operator = "contains"
+	NOT_CONTAINS  Operator = "not_contains"
+	IN            Operator = "in"
+	NOT_IN        Operator = "not_in"
 )
 
 // ActionType represents recommended actions for discovered patterns
 type ActionType string
 
 const (
-	OFFLOAD_TO   ActionType = "OFFLOAD_TO"
-	KEEP_LOCAL   ActionType = "KEEP_LOCAL"
-	DELAY        ActionType = "DELAY_EXECUTION"
+	OFFLOAD_TO ActionType = "OFFLOAD_TO"
+	KEEP_LOCAL ActionType = "KEEP_LOCAL"
+	DELAY      ActionType = "DELAY_EXECUTION"
 )
 
 // ValidTargetTypes returns all valid target types
@@ -106,18 +108,18 @@ func (ps ProcessStatus) CanTransitionTo(target ProcessStatus) bool {
 		FAILED:    {}, // Terminal state
 		CANCELLED: {}, // Terminal state
 	}
-	
+
 	allowedTransitions, exists := transitions[ps]
 	if !exists {
 		return false
 	}
-	
+
 	for _, allowed := range allowedTransitions {
 		if target == allowed {
 			return true
 		}
 	}
-	
+
 	return false
 }
 
@@ -213,7 +215,7 @@ type ValidationError struct {
 }
 
 func (ve ValidationError) Error() string {
-	return fmt.Sprintf("validation error for field '%s' (value: %v): %s", 
+	return fmt.Sprintf("validation error for field '%s' (value: %v): %s",
 		ve.Field, ve.Value, ve.Message)
 }
 
@@ -249,4 +251,4 @@ func (ve *ValidationErrors) AddIf(condition bool, field string, value interface{
 	if condition {
 		ve.Add(field, value, message)
 	}
-}
\ No newline at end of file
+}
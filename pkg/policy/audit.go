@@ -0,0 +1,326 @@
+package policy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// AuditConfig configures the append-only audit trail on disk
+type AuditConfig struct {
+	Dir           string `json:"dir"`             // Directory of audit files (empty disables)
+	MaxFileBytes  int64  `json:"max_file_bytes"`  // Rotate to a new file beyond this size (0 = 10 MiB)
+	SigningKeyEnv string `json:"signing_key_env"` // Environment variable holding the HMAC key (empty = unsigned)
+}
+
+// defaultAuditFileBytes is the rotation size when none is configured
+const defaultAuditFileBytes = 10 * 1024 * 1024
+
+// Validate checks the audit configuration
+func (ac AuditConfig) Validate() error {
+	if ac.MaxFileBytes < 0 {
+		return fmt.Errorf("max_file_bytes must be non-negative")
+	}
+	if ac.SigningKeyEnv != "" && ac.Dir == "" {
+		return fmt.Errorf("signing_key_env requires dir")
+	}
+	return nil
+}
+
+// SigningKey reads the HMAC key from the configured environment variable
+func (ac AuditConfig) SigningKey() ([]byte, error) {
+	if ac.SigningKeyEnv == "" {
+		return nil, nil
+	}
+	key := os.Getenv(ac.SigningKeyEnv)
+	if key == "" {
+		return nil, fmt.Errorf("audit signing key variable %s is not set", ac.SigningKeyEnv)
+	}
+	return []byte(key), nil
+}
+
+// AuditRecord is one line of an audit file. Each record's hash covers its
+// sequence number, the previous record's hash and the entry, so removing,
+// reordering or editing records breaks the chain. With a signing key the
+// hash is also signed, so the chain cannot be rebuilt without the key.
+type AuditRecord struct {
+	Seq       int64           `json:"seq"`
+	PrevHash  string          `json:"prev_hash"`
+	Entry     json.RawMessage `json:"entry"`
+	Hash      string          `json:"hash"`
+	Signature string          `json:"signature,omitempty"`
+}
+
+// auditHash computes a record's chained hash
+func auditHash(seq int64, prevHash string, entry []byte) string {
+	h := sha256.New()
+	h.Write([]byte(strconv.FormatInt(seq, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(prevHash))
+	h.Write([]byte{0})
+	h.Write(entry)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// auditSignature signs a record hash
+func auditSignature(key []byte, hash string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(hash))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// AuditWriter appends hash-chained audit records to rotating files named
+// audit-NNNNNN.jsonl. Reopening a directory continues its chain.
+type AuditWriter struct {
+	dir      string
+	maxBytes int64
+	key      []byte
+
+	mu       sync.Mutex
+	file     *os.File
+	index    int   // Number of the current file
+	size     int64 // Bytes written to the current file
+	seq      int64 // Sequence number of the last record
+	lastHash string
+}
+
+// NewAuditWriter opens the audit directory, creating it if needed, and
+// resumes the chain from its last record
+func NewAuditWriter(config AuditConfig) (*AuditWriter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	key, err := config.SigningKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(config.Dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit directory: %w", err)
+	}
+
+	w := &AuditWriter{dir: config.Dir, maxBytes: config.MaxFileBytes, key: key, index: 1}
+	if w.maxBytes == 0 {
+		w.maxBytes = defaultAuditFileBytes
+	}
+
+	files, err := auditFiles(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > 0 {
+		w.index = files[len(files)-1].index
+	}
+	// A rotation may have left the newest file empty
+	for i := len(files) - 1; i >= 0; i-- {
+		record, err := lastAuditRecord(files[i].path)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			w.seq, w.lastHash = record.Seq, record.Hash
+			break
+		}
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// open opens the current audit file for appending
+func (w *AuditWriter) open() error {
+	file, err := os.OpenFile(auditFileName(w.dir, w.index), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to open audit file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	w.file, w.size = file, info.Size()
+	return nil
+}
+
+// Append chains and writes an audit log entry, rotating the file first if
+// it is full. The record is synced before Append returns.
+func (w *AuditWriter) Append(entry AuditLog) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return fmt.Errorf("audit writer is closed")
+	}
+
+	record := AuditRecord{Seq: w.seq + 1, PrevHash: w.lastHash, Entry: data}
+	record.Hash = auditHash(record.Seq, record.PrevHash, record.Entry)
+	if w.key != nil {
+		record.Signature = auditSignature(w.key, record.Hash)
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	if w.size > 0 && w.size+int64(len(line)) > w.maxBytes {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.index++
+		if err := w.open(); err != nil {
+			w.file = nil
+			return err
+		}
+	}
+	if _, err := w.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit file: %w", err)
+	}
+	w.size += int64(len(line))
+	w.seq, w.lastHash = record.Seq, record.Hash
+	return nil
+}
+
+// Close closes the current audit file
+func (w *AuditWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// AuditVerification is the result of verifying an audit directory
+type AuditVerification struct {
+	Files   int   `json:"files"`
+	Records int64 `json:"records"`
+	Signed  bool  `json:"signed"` // Every record carried a valid signature
+}
+
+// VerifyAuditTrail checks the hash chain of every record in an audit
+// directory, in file order. With a key, every record must also carry a
+// valid signature. The error locates the first broken record.
+func VerifyAuditTrail(dir string, key []byte) (AuditVerification, error) {
+	result := AuditVerification{Signed: key != nil}
+	files, err := auditFiles(dir)
+	if err != nil {
+		return result, err
+	}
+	if len(files) == 0 {
+		return result, fmt.Errorf("no audit files in %s", dir)
+	}
+
+	prevHash := ""
+	for i, file := range files {
+		if file.index != files[0].index+i {
+			return result, fmt.Errorf("audit file %s is missing", filepath.Base(auditFileName(dir, files[0].index+i)))
+		}
+		err := scanAuditFile(file.path, func(line int, record AuditRecord) error {
+			where := fmt.Sprintf("%s:%d", filepath.Base(file.path), line)
+			if record.Seq != result.Records+1 {
+				return fmt.Errorf("%s: expected sequence %d, found %d", where, result.Records+1, record.Seq)
+			}
+			if record.PrevHash != prevHash {
+				return fmt.Errorf("%s: chain broken, previous hash does not match", where)
+			}
+			if auditHash(record.Seq, record.PrevHash, record.Entry) != record.Hash {
+				return fmt.Errorf("%s: record hash does not match its contents", where)
+			}
+			if key != nil && !hmac.Equal([]byte(auditSignature(key, record.Hash)), []byte(record.Signature)) {
+				return fmt.Errorf("%s: invalid signature", where)
+			}
+			result.Records++
+			prevHash = record.Hash
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Files++
+	}
+	return result, nil
+}
+
+// auditFile is an audit file and its number
+type auditFile struct {
+	path  string
+	index int
+}
+
+// auditFileName returns the path of a numbered audit file
+func auditFileName(dir string, index int) string {
+	return filepath.Join(dir, fmt.Sprintf("audit-%06d.jsonl", index))
+}
+
+// auditFiles lists a directory's audit files in order
+func auditFiles(dir string) ([]auditFile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "audit-*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	files := make([]auditFile, 0, len(paths))
+	for _, path := range paths {
+		var index int
+		if _, err := fmt.Sscanf(filepath.Base(path), "audit-%06d.jsonl", &index); err != nil {
+			continue
+		}
+		files = append(files, auditFile{path: path, index: index})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].index < files[j].index })
+	return files, nil
+}
+
+// scanAuditFile decodes each record of an audit file
+func scanAuditFile(path string, visit func(line int, record AuditRecord) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("%s:%d: %w", filepath.Base(path), line, err)
+		}
+		if err := visit(line, record); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// lastAuditRecord returns the last record of an audit file, or nil if it is
+// empty
+func lastAuditRecord(path string) (*AuditRecord, error) {
+	var last *AuditRecord
+	err := scanAuditFile(path, func(_ int, record AuditRecord) error {
+		last = &record
+		return nil
+	})
+	return last, err
+}
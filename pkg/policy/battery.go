@@ -0,0 +1,21 @@
+package policy
+
+import "github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+
+// BatteryRuleID identifies the rule preserving the charge of battery-powered
+// targets
+const BatteryRuleID = "battery-minimum-level"
+
+// BatteryRule returns a hard rule blocking battery-powered targets whose
+// charge or duty cycle the battery policy refuses the process on
+func BatteryRule(battery models.BatteryPolicy) PolicyRule {
+	return PolicyRule{
+		ID:       BatteryRuleID,
+		Type:     models.HARD,
+		Priority: 1,
+		Condition: func(p models.Process, t models.OffloadTarget) bool {
+			return battery.Refusal(p, t) == ""
+		},
+		Description: "Battery-powered targets must keep their minimum charge and duty cycle",
+	}
+}
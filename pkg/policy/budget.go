@@ -0,0 +1,153 @@
+package policy
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// BudgetConfig limits offloading spend over rolling windows. A zero limit
+// disables that window.
+type BudgetConfig struct {
+	MaxCostPerHour float64 `json:"max_cost_per_hour"`
+	MaxCostPerDay  float64 `json:"max_cost_per_day"`
+	SoftThreshold  float64 `json:"soft_threshold"` // Fraction of a budget spent before cost pressure starts (default 0.5)
+}
+
+// IsEnabled returns true if any budget limit is set
+func (c BudgetConfig) IsEnabled() bool {
+	return c.MaxCostPerHour > 0 || c.MaxCostPerDay > 0
+}
+
+// Validate checks the budget configuration
+func (c BudgetConfig) Validate() error {
+	if c.MaxCostPerHour < 0 || c.MaxCostPerDay < 0 {
+		return fmt.Errorf("budget limits must be non-negative")
+	}
+	if c.SoftThreshold < 0 || c.SoftThreshold >= 1 {
+		return fmt.Errorf("budget soft threshold must be in [0, 1), got %f", c.SoftThreshold)
+	}
+	return nil
+}
+
+// spend is a cost charged against the budget
+type spend struct {
+	at     time.Time
+	amount float64
+}
+
+// BudgetManager tracks rolling hourly and daily offloading spend
+type BudgetManager struct {
+	config BudgetConfig
+	spends []spend // Ordered by time, pruned to the daily window
+	mu     sync.Mutex
+	now    func() time.Time
+}
+
+// NewBudgetManager creates a budget manager
+func NewBudgetManager(config BudgetConfig) (*BudgetManager, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.SoftThreshold == 0 {
+		config.SoftThreshold = 0.5
+	}
+
+	return &BudgetManager{
+		config: config,
+		spends: make([]spend, 0),
+		now:    time.Now,
+	}, nil
+}
+
+// SetClock replaces the time source, for tests and simulations
+func (bm *BudgetManager) SetClock(now func() time.Time) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	bm.now = now
+}
+
+// Record charges a cost against the budget. Negative amounts correct earlier
+// estimates.
+func (bm *BudgetManager) Record(amount float64) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	bm.spends = append(bm.spends, spend{at: bm.now(), amount: amount})
+	bm.prune()
+}
+
+// Spent returns the spend within the window ending now
+func (bm *BudgetManager) Spent(window time.Duration) float64 {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	return bm.spentWithin(window)
+}
+
+// Remaining returns the budget left in the tightest window, or +Inf if no
+// limit is set
+func (bm *BudgetManager) Remaining() float64 {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	remaining := math.Inf(1)
+	if bm.config.MaxCostPerHour > 0 {
+		remaining = math.Min(remaining, bm.config.MaxCostPerHour-bm.spentWithin(time.Hour))
+	}
+	if bm.config.MaxCostPerDay > 0 {
+		remaining = math.Min(remaining, bm.config.MaxCostPerDay-bm.spentWithin(24*time.Hour))
+	}
+	return math.Max(0.0, remaining)
+}
+
+// Allows reports whether a cost fits within every budget window
+func (bm *BudgetManager) Allows(cost float64) bool {
+	return cost <= bm.Remaining()
+}
+
+// Pressure returns how strongly costs should be discouraged, from 0 below the
+// soft threshold to 1 when a budget is exhausted
+func (bm *BudgetManager) Pressure() float64 {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	pressure := 0.0
+	for _, window := range []struct {
+		limit  float64
+		length time.Duration
+	}{
+		{bm.config.MaxCostPerHour, time.Hour},
+		{bm.config.MaxCostPerDay, 24 * time.Hour},
+	} {
+		if window.limit <= 0 {
+			continue
+		}
+		used := bm.spentWithin(window.length) / window.limit
+		windowPressure := (used - bm.config.SoftThreshold) / (1.0 - bm.config.SoftThreshold)
+		pressure = math.Max(pressure, math.Min(1.0, windowPressure))
+	}
+	return pressure
+}
+
+// spentWithin sums the spend within the window; callers hold the lock
+func (bm *BudgetManager) spentWithin(window time.Duration) float64 {
+	cutoff := bm.now().Add(-window)
+	total := 0.0
+	for i := len(bm.spends) - 1; i >= 0 && bm.spends[i].at.After(cutoff); i-- {
+		total += bm.spends[i].amount
+	}
+	return total
+}
+
+// prune drops spend older than the daily window; callers hold the lock
+func (bm *BudgetManager) prune() {
+	cutoff := bm.now().Add(-24 * time.Hour)
+	keep := 0
+	for keep < len(bm.spends) && !bm.spends[keep].at.After(cutoff) {
+		keep++
+	}
+	bm.spends = bm.spends[keep:]
+}
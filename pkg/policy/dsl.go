@@ -0,0 +1,399 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// RuleFile is the declarative rule format loaded from JSON or YAML
+type RuleFile struct {
+	Rules []RuleSpec `json:"rules" yaml:"rules"`
+}
+
+// RuleSpec declares a single policy rule. The condition describes when the
+// rule is satisfied; a process-target pair that does not satisfy it violates
+// the rule.
+type RuleSpec struct {
+	ID          string            `json:"id" yaml:"id"`
+	Description string            `json:"description" yaml:"description"`
+	Type        models.PolicyType `json:"type" yaml:"type"`
+	Priority    int               `json:"priority" yaml:"priority"`
+	Penalty     float64           `json:"penalty" yaml:"penalty"` // Score penalty for soft violations (0 = default)
+	Disabled    bool              `json:"disabled" yaml:"disabled"`
+	Condition   ConditionSpec     `json:"condition" yaml:"condition"`
+}
+
+// ConditionSpec is either a comparison (Field, Op and Value or ValueField) or
+// a combination of nested conditions (All, Any or Not)
+type ConditionSpec struct {
+	Field      string          `json:"field,omitempty" yaml:"field,omitempty"`
+	Op         models.Operator `json:"op,omitempty" yaml:"op,omitempty"`
+	Value      interface{}     `json:"value,omitempty" yaml:"value,omitempty"`
+	ValueField string          `json:"value_field,omitempty" yaml:"value_field,omitempty"` // Compare against another field
+
+	All []ConditionSpec `json:"all,omitempty" yaml:"all,omitempty"`
+	Any []ConditionSpec `json:"any,omitempty" yaml:"any,omitempty"`
+	Not *ConditionSpec  `json:"not,omitempty" yaml:"not,omitempty"`
+}
+
+// fieldResolver extracts a field value from a process-target pair
+type fieldResolver func(p models.Process, t models.OffloadTarget) interface{}
+
+// ruleFields lists the fields available to rule conditions. Durations are in
+// milliseconds and sizes in bytes.
+var ruleFields = map[string]fieldResolver{
+	"process.id":                 func(p models.Process, t models.OffloadTarget) interface{} { return p.ID },
+	"process.type":               func(p models.Process, t models.OffloadTarget) interface{} { return p.Type },
+	"process.priority":           func(p models.Process, t models.OffloadTarget) interface{} { return float64(p.Priority) },
+	"process.cpu_requirement":    func(p models.Process, t models.OffloadTarget) interface{} { return p.CPURequirement },
+	"process.memory_requirement": func(p models.Process, t models.OffloadTarget) interface{} { return float64(p.MemoryRequirement) },
+	"process.input_size":         func(p models.Process, t models.OffloadTarget) interface{} { return float64(p.InputSize) },
+	"process.output_size":        func(p models.Process, t models.OffloadTarget) interface{} { return float64(p.OutputSize) },
+	"process.data_sensitivity":   func(p models.Process, t models.OffloadTarget) interface{} { return float64(p.DataSensitivity) },
+	"process.security_level":     func(p models.Process, t models.OffloadTarget) interface{} { return float64(p.SecurityLevel) },
+	"process.estimated_duration": func(p models.Process, t models.OffloadTarget) interface{} { return milliseconds(p.EstimatedDuration) },
+	"process.max_duration":       func(p models.Process, t models.OffloadTarget) interface{} { return milliseconds(p.MaxDuration) },
+	"process.real_time":          func(p models.Process, t models.OffloadTarget) interface{} { return p.RealTime },
+	"process.safety_critical":    func(p models.Process, t models.OffloadTarget) interface{} { return p.SafetyCritical },
+	"process.locality_required":  func(p models.Process, t models.OffloadTarget) interface{} { return p.LocalityRequired },
+	"process.data_residency":     func(p models.Process, t models.OffloadTarget) interface{} { return p.DataResidency },
+	"process.scc_approved":       func(p models.Process, t models.OffloadTarget) interface{} { return p.SCCApproved },
+	"target.id":                  func(p models.Process, t models.OffloadTarget) interface{} { return t.ID },
+	"target.type":                func(p models.Process, t models.OffloadTarget) interface{} { return string(t.Type) },
+	"target.location":            func(p models.Process, t models.OffloadTarget) interface{} { return t.Location },
+	"target.data_jurisdiction":   func(p models.Process, t models.OffloadTarget) interface{} { return t.DataJurisdiction },
+	"target.energy_source":       func(p models.Process, t models.OffloadTarget) interface{} { return t.EnergySource },
+	"target.security_level":      func(p models.Process, t models.OffloadTarget) interface{} { return float64(t.SecurityLevel) },
+	"target.reliability":         func(p models.Process, t models.OffloadTarget) interface{} { return t.Reliability },
+	"target.compute_cost":        func(p models.Process, t models.OffloadTarget) interface{} { return t.ComputeCost },
+	"target.energy_cost":         func(p models.Process, t models.OffloadTarget) interface{} { return t.EnergyCost },
+	"target.network_cost":        func(p models.Process, t models.OffloadTarget) interface{} { return t.NetworkCost },
+	"target.network_latency":     func(p models.Process, t models.OffloadTarget) interface{} { return milliseconds(t.NetworkLatency) },
+	"target.current_load":        func(p models.Process, t models.OffloadTarget) interface{} { return t.CurrentLoad },
+	"target.processing_speed":    func(p models.Process, t models.OffloadTarget) interface{} { return t.ProcessingSpeed },
+	"target.temperature":         func(p models.Process, t models.OffloadTarget) interface{} { return t.Temperature },
+	"target.battery_level":       func(p models.Process, t models.OffloadTarget) interface{} { return batteryLevel(t) },
+	"target.capabilities":        func(p models.Process, t models.OffloadTarget) interface{} { return t.Capabilities },
+	"target.compliance_flags":    func(p models.Process, t models.OffloadTarget) interface{} { return t.ComplianceFlags },
+}
+
+// ParseRules parses a rule file. The format is chosen by extension (.json,
+// .yaml or .yml).
+func ParseRules(data []byte, format string) ([]PolicyRule, error) {
+	var file RuleFile
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "json":
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("invalid JSON rule file: %w", err)
+		}
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("invalid YAML rule file: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported rule file format %q", format)
+	}
+	return CompileRules(file.Rules)
+}
+
+// LoadRules reads and compiles a JSON or YAML rule file
+func LoadRules(path string) ([]PolicyRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule file: %w", err)
+	}
+	return ParseRules(data, filepath.Ext(path))
+}
+
+// CompileRules converts declarative rule specs into policy rules
+func CompileRules(specs []RuleSpec) ([]PolicyRule, error) {
+	rules := make([]PolicyRule, 0, len(specs))
+	seen := make(map[string]bool)
+
+	for i, spec := range specs {
+		if spec.ID == "" {
+			return nil, fmt.Errorf("rule %d: id is required", i)
+		}
+		if seen[spec.ID] {
+			return nil, fmt.Errorf("rule %s: duplicate id", spec.ID)
+		}
+		seen[spec.ID] = true
+
+		if spec.Type != models.HARD && spec.Type != models.SOFT {
+			return nil, fmt.Errorf("rule %s: type must be %q or %q", spec.ID, models.HARD, models.SOFT)
+		}
+		if spec.Penalty < 0 || spec.Penalty > 1 {
+			return nil, fmt.Errorf("rule %s: penalty must be between 0 and 1", spec.ID)
+		}
+
+		condition, err := compileCondition(spec.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", spec.ID, err)
+		}
+
+		rules = append(rules, PolicyRule{
+			ID:          spec.ID,
+			Type:        spec.Type,
+			Priority:    spec.Priority,
+			Penalty:     spec.Penalty,
+			Condition:   condition,
+			Description: spec.Description,
+			Enabled:     !spec.Disabled,
+		})
+	}
+
+	return rules, nil
+}
+
+// compileCondition validates a condition and compiles it into a predicate
+func compileCondition(spec ConditionSpec) (func(models.Process, models.OffloadTarget) bool, error) {
+	switch {
+	case len(spec.All) > 0:
+		parts, err := compileConditions(spec.All)
+		if err != nil {
+			return nil, err
+		}
+		return func(p models.Process, t models.OffloadTarget) bool {
+			for _, part := range parts {
+				if !part(p, t) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case len(spec.Any) > 0:
+		parts, err := compileConditions(spec.Any)
+		if err != nil {
+			return nil, err
+		}
+		return func(p models.Process, t models.OffloadTarget) bool {
+			for _, part := range parts {
+				if part(p, t) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case spec.Not != nil:
+		inner, err := compileCondition(*spec.Not)
+		if err != nil {
+			return nil, err
+		}
+		return func(p models.Process, t models.OffloadTarget) bool {
+			return !inner(p, t)
+		}, nil
+	}
+
+	field, ok := ruleFields[spec.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", spec.Field)
+	}
+
+	value := func(models.Process, models.OffloadTarget) interface{} { return spec.Value }
+	if spec.ValueField != "" {
+		other, ok := ruleFields[spec.ValueField]
+		if !ok {
+			return nil, fmt.Errorf("unknown value field %q", spec.ValueField)
+		}
+		if err := validateFieldOperator(spec.Op); err != nil {
+			return nil, fmt.Errorf("field %s: %w", spec.Field, err)
+		}
+		value = other
+	} else if err := validateOperand(spec.Op, spec.Value); err != nil {
+		return nil, fmt.Errorf("field %s: %w", spec.Field, err)
+	}
+
+	op := spec.Op
+	return func(p models.Process, t models.OffloadTarget) bool {
+		return compareOperands(field(p, t), op, value(p, t))
+	}, nil
+}
+
+// compileConditions compiles a list of nested conditions
+func compileConditions(specs []ConditionSpec) ([]func(models.Process, models.OffloadTarget) bool, error) {
+	parts := make([]func(models.Process, models.OffloadTarget) bool, 0, len(specs))
+	for _, spec := range specs {
+		part, err := compileCondition(spec)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// validateFieldOperator checks that an operator can compare against another
+// field's value
+func validateFieldOperator(op models.Operator) error {
+	switch op {
+	case models.EQUAL_TO, models.NOT_EQUAL_TO, models.CONTAINS, models.NOT_CONTAINS,
+		models.GREATER_THAN, models.LESS_THAN, models.GREATER_EQUAL, models.LESS_EQUAL,
+		models.IN, models.NOT_IN:
+		return nil
+	case models.BETWEEN, models.NOT_BETWEEN:
+		return fmt.Errorf("operator %s requires a literal [min, max] value", op)
+	}
+	return fmt.Errorf("unknown operator %q", op)
+}
+
+// validateOperand checks that a literal value has the shape the operator needs
+func validateOperand(op models.Operator, value interface{}) error {
+	switch op {
+	case models.EQUAL_TO, models.NOT_EQUAL_TO, models.CONTAINS, models.NOT_CONTAINS:
+		if value == nil {
+			return fmt.Errorf("operator %s requires a value", op)
+		}
+	case models.GREATER_THAN, models.LESS_THAN, models.GREATER_EQUAL, models.LESS_EQUAL:
+		if _, ok := toNumber(value); !ok {
+			return fmt.Errorf("operator %s requires a numeric value", op)
+		}
+	case models.BETWEEN, models.NOT_BETWEEN:
+		bounds, ok := value.([]interface{})
+		if !ok || len(bounds) != 2 {
+			return fmt.Errorf("operator %s requires a [min, max] value", op)
+		}
+		for _, bound := range bounds {
+			if _, ok := toNumber(bound); !ok {
+				return fmt.Errorf("operator %s requires numeric bounds", op)
+			}
+		}
+	case models.IN, models.NOT_IN:
+		if _, ok := value.([]interface{}); !ok {
+			return fmt.Errorf("operator %s requires a list value", op)
+		}
+	default:
+		return fmt.Errorf("unknown operator %q", op)
+	}
+	return nil
+}
+
+// compareOperands applies an operator to a field value and operand
+func compareOperands(actual interface{}, op models.Operator, expected interface{}) bool {
+	switch op {
+	case models.EQUAL_TO:
+		return equalOperands(actual, expected)
+	case models.NOT_EQUAL_TO:
+		return !equalOperands(actual, expected)
+	case models.GREATER_THAN, models.LESS_THAN, models.GREATER_EQUAL, models.LESS_EQUAL:
+		a, okA := toNumber(actual)
+		b, okB := toNumber(expected)
+		if !okA || !okB {
+			return false
+		}
+		switch op {
+		case models.GREATER_THAN:
+			return a > b
+		case models.LESS_THAN:
+			return a < b
+		case models.GREATER_EQUAL:
+			return a >= b
+		default:
+			return a <= b
+		}
+	case models.BETWEEN, models.NOT_BETWEEN:
+		bounds, _ := expected.([]interface{})
+		a, okA := toNumber(actual)
+		if !okA || len(bounds) != 2 {
+			return false
+		}
+		low, _ := toNumber(bounds[0])
+		high, _ := toNumber(bounds[1])
+		inside := a >= low && a <= high
+		return inside == (op == models.BETWEEN)
+	case models.CONTAINS:
+		return containsOperand(actual, expected)
+	case models.NOT_CONTAINS:
+		return !containsOperand(actual, expected)
+	case models.IN, models.NOT_IN:
+		found := false
+		for _, option := range operandList(expected) {
+			if equalOperands(actual, option) {
+				found = true
+				break
+			}
+		}
+		return found == (op == models.IN)
+	}
+	return false
+}
+
+// equalOperands compares numerically when both sides are numbers
+func equalOperands(a, b interface{}) bool {
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			return x == y
+		}
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// containsOperand checks list membership or substring containment
+func containsOperand(container, item interface{}) bool {
+	switch c := container.(type) {
+	case []string:
+		for _, element := range c {
+			if equalOperands(element, item) {
+				return true
+			}
+		}
+		return false
+	case string:
+		return strings.Contains(c, fmt.Sprint(item))
+	}
+	return false
+}
+
+// operandList returns the elements of a literal list or of a list field
+// such as target.capabilities
+func operandList(v interface{}) []interface{} {
+	switch list := v.(type) {
+	case []interface{}:
+		return list
+	case []string:
+		elements := make([]interface{}, len(list))
+		for i, element := range list {
+			elements[i] = element
+		}
+		return elements
+	}
+	return nil
+}
+
+// toNumber converts decoded JSON/YAML numbers to float64
+func toNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// batteryLevel returns a target's state of charge, full for mains-powered
+// targets
+func batteryLevel(t models.OffloadTarget) float64 {
+	if t.Battery == nil {
+		return 1
+	}
+	return t.Battery.Level
+}
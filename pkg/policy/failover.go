@@ -0,0 +1,187 @@
+package policy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// FailoverConfig ranks the regions (target locations) processes may be
+// offloaded to. Offloads go to the most preferred region with a usable
+// target, falling back to the next region when every target in it is
+// unhealthy, saturated or ruled out by policy.
+type FailoverConfig struct {
+	Enabled   bool     `json:"enabled"`
+	Regions   []string `json:"regions"`    // Target locations, most preferred first
+	MaxLoad   float64  `json:"max_load"`   // Load at which a target is saturated (default 0.9)
+	MaxEvents int      `json:"max_events"` // Failover events kept (default 1000)
+}
+
+// Validate checks the failover configuration
+func (fc FailoverConfig) Validate() error {
+	if fc.MaxLoad < 0 || fc.MaxLoad > 1 {
+		return fmt.Errorf("max_load must be between 0 and 1, got %f", fc.MaxLoad)
+	}
+	if fc.MaxEvents < 0 {
+		return fmt.Errorf("max_events must be non-negative")
+	}
+	if fc.Enabled && len(fc.Regions) == 0 {
+		return fmt.Errorf("at least one region is required when enabled")
+	}
+	seen := make(map[string]bool)
+	for i, region := range fc.Regions {
+		switch {
+		case region == "":
+			return fmt.Errorf("regions[%d]: region cannot be empty", i)
+		case seen[region]:
+			return fmt.Errorf("regions[%d]: duplicate region %s", i, region)
+		}
+		seen[region] = true
+	}
+	return nil
+}
+
+// FailoverEvent records a process placed outside the preferred region, or
+// kept local because no region had a usable target
+type FailoverEvent struct {
+	ProcessID       string    `json:"process_id"`
+	PreferredRegion string    `json:"preferred_region"`
+	Region          string    `json:"region"` // Empty when no region was usable
+	Reason          string    `json:"reason"` // Why the preferred region was passed over
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// FailoverStats counts region selections
+type FailoverStats struct {
+	Selections int            `json:"selections"`
+	Failovers  int            `json:"failovers"`
+	ByRegion   map[string]int `json:"by_region"` // Failovers by region failed over to
+	Exhausted  int            `json:"exhausted"` // Selections with no usable region
+}
+
+// FailoverPolicy selects the region each offload is placed in
+type FailoverPolicy struct {
+	config FailoverConfig
+	events []FailoverEvent
+	stats  FailoverStats
+	mu     sync.Mutex
+}
+
+// NewFailoverPolicy creates a failover policy
+func NewFailoverPolicy(config FailoverConfig) (*FailoverPolicy, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.MaxLoad == 0 {
+		config.MaxLoad = 0.9
+	}
+	if config.MaxEvents == 0 {
+		config.MaxEvents = 1000
+	}
+	return &FailoverPolicy{
+		config: config,
+		events: make([]FailoverEvent, 0),
+		stats:  FailoverStats{ByRegion: make(map[string]int)},
+	}, nil
+}
+
+// Select returns the usable targets of the most preferred region that has
+// any, from targets that already passed policy evaluation. The event is set
+// when the preferred region was passed over, and false is returned when no
+// region has a usable target.
+func (fp *FailoverPolicy) Select(processID string, targets []models.OffloadTarget, at time.Time) ([]models.OffloadTarget, *FailoverEvent, bool) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	fp.stats.Selections++
+	preferred := fp.config.Regions[0]
+	for rank, region := range fp.config.Regions {
+		usable := make([]models.OffloadTarget, 0)
+		for _, target := range targets {
+			if target.Location == region && fp.usable(target) {
+				usable = append(usable, target)
+			}
+		}
+		if len(usable) == 0 {
+			continue
+		}
+		if rank == 0 {
+			return usable, nil, true
+		}
+
+		fp.stats.Failovers++
+		fp.stats.ByRegion[region]++
+		event := fp.record(processID, region, fp.unusableReason(preferred, targets), at)
+		return usable, &event, true
+	}
+
+	fp.stats.Exhausted++
+	event := fp.record(processID, "", fp.unusableReason(preferred, targets), at)
+	return nil, &event, false
+}
+
+// Events returns the recorded failover events, oldest first
+func (fp *FailoverPolicy) Events() []FailoverEvent {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return append([]FailoverEvent(nil), fp.events...)
+}
+
+// Stats returns the region selection counts
+func (fp *FailoverPolicy) Stats() FailoverStats {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	stats := fp.stats
+	stats.ByRegion = make(map[string]int, len(fp.stats.ByRegion))
+	for region, count := range fp.stats.ByRegion {
+		stats.ByRegion[region] = count
+	}
+	return stats
+}
+
+// usable returns true if a target is healthy and has capacity to spare
+func (fp *FailoverPolicy) usable(target models.OffloadTarget) bool {
+	return target.IsHealthy() && target.IsAvailable() && target.CurrentLoad < fp.config.MaxLoad
+}
+
+// unusableReason explains why a region has no usable target
+func (fp *FailoverPolicy) unusableReason(region string, targets []models.OffloadTarget) string {
+	healthy, present := 0, 0
+	for _, target := range targets {
+		if target.Location != region {
+			continue
+		}
+		present++
+		if target.IsHealthy() {
+			healthy++
+		}
+	}
+	switch {
+	case present == 0:
+		return "no policy-compliant targets"
+	case healthy == 0:
+		return "all targets unhealthy"
+	default:
+		return "all healthy targets saturated"
+	}
+}
+
+// record appends a failover event, dropping the oldest beyond MaxEvents
+func (fp *FailoverPolicy) record(processID, region, reason string, at time.Time) FailoverEvent {
+	event := FailoverEvent{
+		ProcessID:       processID,
+		PreferredRegion: fp.config.Regions[0],
+		Region:          region,
+		Reason:          reason,
+		Timestamp:       at,
+	}
+	fp.events = append(fp.events, event)
+	if len(fp.events) > fp.config.MaxEvents {
+		fp.events = fp.events[len(fp.events)-fp.config.MaxEvents:]
+	}
+	return event
+}
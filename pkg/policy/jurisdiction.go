@@ -0,0 +1,21 @@
+package policy
+
+import "github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+
+// JurisdictionRuleID identifies the rule enforcing permitted data transfers
+const JurisdictionRuleID = "data-residency-transfer"
+
+// JurisdictionRule returns a hard rule blocking targets the process's data may
+// not be transferred to under the jurisdiction graph
+func JurisdictionRule(graph *models.JurisdictionGraph) PolicyRule {
+	return PolicyRule{
+		ID:       JurisdictionRuleID,
+		Type:     models.HARD,
+		Priority: 1,
+		Condition: func(p models.Process, t models.OffloadTarget) bool {
+			allowed, _ := graph.CheckPlacement(p, t)
+			return allowed
+		},
+		Description: "Process data may only move along permitted jurisdiction transfers",
+	}
+}
@@ -0,0 +1,167 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// OPASource is the rule source used for rules backed by Open Policy Agent
+const OPASource = "opa"
+
+// OPAConfig configures delegation of placement policy to an Open Policy Agent
+// server through its Data API. An empty URL disables OPA.
+type OPAConfig struct {
+	URL      string            `json:"url"`       // e.g. http://localhost:8181
+	Path     string            `json:"path"`      // Policy decision path, e.g. cape/placement
+	Type     models.PolicyType `json:"type"`      // HARD (default) blocks denied targets, SOFT penalizes them
+	Penalty  float64           `json:"penalty"`   // Soft violation score penalty (0 = default)
+	Timeout  time.Duration     `json:"timeout"`   // Per-query timeout (default 200ms)
+	FailOpen bool              `json:"fail_open"` // Allow targets when OPA is unreachable
+}
+
+// OPAInput is the input document sent to OPA for a process-target pair
+type OPAInput struct {
+	Process models.Process       `json:"process"`
+	Target  models.OffloadTarget `json:"target"`
+}
+
+// OPADecision is the policy decision returned by OPA. The policy may return
+// either a plain boolean or an object with allow and deny fields.
+type OPADecision struct {
+	Allow bool     `json:"allow"`
+	Deny  []string `json:"deny"` // Reasons; any reason denies the target
+}
+
+// OPAClient queries an OPA server for placement decisions
+type OPAClient struct {
+	config     OPAConfig
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewOPAClient creates a client for the configured OPA server
+func NewOPAClient(config OPAConfig) (*OPAClient, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("OPA url cannot be empty")
+	}
+	if config.Path == "" {
+		return nil, fmt.Errorf("OPA decision path cannot be empty")
+	}
+	if config.Type == "" {
+		config.Type = models.HARD
+	}
+	if config.Type != models.HARD && config.Type != models.SOFT {
+		return nil, fmt.Errorf("invalid OPA rule type %q", config.Type)
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 200 * time.Millisecond
+	}
+
+	return &OPAClient{
+		config:     config,
+		httpClient: &http.Client{},
+		logger:     logging.Discard(),
+	}, nil
+}
+
+// SetLogger sets the logger used to report failed queries, which are not
+// logged until one is set
+func (c *OPAClient) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
+
+// Query asks OPA whether the process may be placed on the target
+func (c *OPAClient) Query(ctx context.Context, process models.Process, target models.OffloadTarget) (OPADecision, error) {
+	body, err := json.Marshal(struct {
+		Input OPAInput `json:"input"`
+	}{OPAInput{Process: process, Target: target}})
+	if err != nil {
+		return OPADecision{}, fmt.Errorf("failed to encode OPA input: %w", err)
+	}
+
+	url := strings.TrimRight(c.config.URL, "/") + "/v1/data/" + strings.Trim(c.config.Path, "/")
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return OPADecision{}, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return OPADecision{}, fmt.Errorf("OPA query failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return OPADecision{}, fmt.Errorf("OPA query failed: %s", response.Status)
+	}
+
+	var result struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return OPADecision{}, fmt.Errorf("failed to decode OPA response: %w", err)
+	}
+	return parseOPAResult(result.Result)
+}
+
+// Rule returns a policy rule that delegates its condition to OPA
+func (c *OPAClient) Rule() PolicyRule {
+	return PolicyRule{
+		ID:          "opa:" + strings.Trim(c.config.Path, "/"),
+		Type:        c.config.Type,
+		Description: fmt.Sprintf("OPA policy %s", c.config.Path),
+		Penalty:     c.config.Penalty,
+		Enabled:     true,
+		Condition:   c.allows,
+	}
+}
+
+// allows is the rule condition; query failures resolve to FailOpen
+func (c *OPAClient) allows(process models.Process, target models.OffloadTarget) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+
+	decision, err := c.Query(ctx, process, target)
+	if err != nil {
+		c.logger.Warn("OPA policy evaluation failed",
+			"path", c.config.Path, "process_id", process.ID, "target_id", target.ID,
+			"fail_open", c.config.FailOpen, "error", err)
+		return c.config.FailOpen
+	}
+	if !decision.Allow {
+		c.logger.Debug("OPA denied target",
+			"process_id", process.ID, "target_id", target.ID, "reasons", decision.Deny)
+	}
+	return decision.Allow
+}
+
+// parseOPAResult decodes a boolean or object policy result
+func parseOPAResult(raw json.RawMessage) (OPADecision, error) {
+	if len(raw) == 0 {
+		return OPADecision{}, fmt.Errorf("OPA policy is undefined")
+	}
+
+	var allow bool
+	if err := json.Unmarshal(raw, &allow); err == nil {
+		return OPADecision{Allow: allow}, nil
+	}
+
+	var decision OPADecision
+	if err := json.Unmarshal(raw, &decision); err != nil {
+		return OPADecision{}, fmt.Errorf("unexpected OPA result: %s", raw)
+	}
+	if len(decision.Deny) > 0 {
+		decision.Allow = false
+	}
+	return decision, nil
+}
@@ -2,9 +2,11 @@ package policy
 
 import (
 	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
 )
 
@@ -13,10 +15,13 @@ type PolicyEngine struct {
 	rules             []PolicyRule
 	safetyConstraints SafetyConstraints
 	auditLogs         []AuditLog
+	auditWriter       *AuditWriter // Persists the audit trail (nil = in memory only)
 	violations        []PolicyViolation
 	stats             PolicyStats
 	mu                sync.RWMutex
 	immutable         bool
+	logger            *slog.Logger
+	now               func() time.Time // Time source of rule and audit timestamps
 }
 
 // NewPolicyEngine creates a new policy engine
@@ -42,6 +47,8 @@ func NewPolicyEngine() *PolicyEngine {
 			BackoffStrategy:       EXPONENTIAL,
 		},
 		immutable: false,
+		logger:    logging.Discard(),
+		now:       time.Now,
 	}
 }
 
@@ -61,9 +68,9 @@ func (pe *PolicyEngine) AddRule(rule PolicyRule) error {
 
 	// Set timestamps
 	if rule.CreatedAt.IsZero() {
-		rule.CreatedAt = time.Now()
+		rule.CreatedAt = pe.now()
 	}
-	rule.UpdatedAt = time.Now()
+	rule.UpdatedAt = pe.now()
 	rule.Enabled = true
 
 	// Generate ID if not provided
@@ -75,6 +82,50 @@ func (pe *PolicyEngine) AddRule(rule PolicyRule) error {
 	return nil
 }
 
+// ReplaceRules atomically replaces all rules loaded from a source with a new
+// set, leaving rules from other sources untouched
+func (pe *PolicyEngine) ReplaceRules(source string, rules []PolicyRule) error {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	if pe.immutable {
+		return fmt.Errorf("policy engine is immutable during execution")
+	}
+	if source == "" {
+		return fmt.Errorf("rule source cannot be empty")
+	}
+
+	kept := make([]PolicyRule, 0, len(pe.rules)+len(rules))
+	ids := make(map[string]bool)
+	for _, rule := range pe.rules {
+		if rule.Source != source {
+			kept = append(kept, rule)
+			ids[rule.ID] = true
+		}
+	}
+
+	now := pe.now()
+	for _, rule := range rules {
+		if rule.Condition == nil {
+			return fmt.Errorf("rule %s: condition cannot be nil", rule.ID)
+		}
+		if ids[rule.ID] {
+			return fmt.Errorf("rule %s: id already used by another source", rule.ID)
+		}
+		ids[rule.ID] = true
+
+		rule.Source = source
+		if rule.CreatedAt.IsZero() {
+			rule.CreatedAt = now
+		}
+		rule.UpdatedAt = now
+		kept = append(kept, rule)
+	}
+
+	pe.rules = kept
+	return nil
+}
+
 // EvaluatePolicy evaluates all policy rules for a process-target pair
 func (pe *PolicyEngine) EvaluatePolicy(
 	process models.Process,
@@ -118,7 +169,7 @@ func (pe *PolicyEngine) EvaluatePolicy(
 				pe.logViolation(rule, process, target, CRITICAL)
 			} else {
 				// Soft constraints affect scoring
-				evaluation.ScoreAdjustment -= 0.2 // Penalty for soft violation
+				evaluation.ScoreAdjustment -= rule.softPenalty()
 				pe.stats.SoftViolations++
 				pe.stats.ViolationsByRule[rule.ID]++
 				
@@ -137,12 +188,8 @@ func (pe *PolicyEngine) EvaluatePolicy(
 
 	evaluation.EvaluationTime = time.Since(startTime)
 	
-	// Update average evaluation time
-	if pe.stats.AverageEvalTime == 0 {
-		pe.stats.AverageEvalTime = evaluation.EvaluationTime
-	} else {
-		pe.stats.AverageEvalTime = (pe.stats.AverageEvalTime + evaluation.EvaluationTime) / 2
-	}
+	// Update the mean evaluation time, weighting every evaluation equally
+	pe.stats.AverageEvalTime += (evaluation.EvaluationTime - pe.stats.AverageEvalTime) / time.Duration(pe.stats.TotalEvaluations)
 
 	// Audit log
 	pe.logEvaluation(evaluation)
@@ -150,6 +197,43 @@ func (pe *PolicyEngine) EvaluatePolicy(
 	return evaluation
 }
 
+// PreviewPolicy evaluates all policy rules for a process-target pair like
+// EvaluatePolicy, without recording statistics, violations or audit events
+func (pe *PolicyEngine) PreviewPolicy(
+	process models.Process,
+	target models.OffloadTarget,
+) PolicyEvaluation {
+	startTime := time.Now()
+
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	evaluation := PolicyEvaluation{
+		Process:       process,
+		Target:        target,
+		Allowed:       true,
+		ViolatedRules: make([]PolicyRule, 0),
+		AppliedRules:  make([]PolicyRule, 0),
+	}
+	for _, rule := range pe.rules {
+		if !rule.Enabled {
+			continue
+		}
+		evaluation.AppliedRules = append(evaluation.AppliedRules, rule)
+		if !rule.Condition(process, target) {
+			evaluation.ViolatedRules = append(evaluation.ViolatedRules, rule)
+			if rule.Type == models.HARD {
+				evaluation.Allowed = false
+			} else {
+				evaluation.ScoreAdjustment -= rule.softPenalty()
+			}
+		}
+	}
+	evaluation.EvaluationTime = time.Since(startTime)
+
+	return evaluation
+}
+
 // FilterTargetsByPolicy filters targets based on hard policy constraints
 func (pe *PolicyEngine) FilterTargetsByPolicy(
 	process models.Process,
@@ -207,7 +291,7 @@ func (pe *PolicyEngine) logViolation(
 		ProcessID:   process.ID,
 		TargetID:    target.ID,
 		Description: rule.Description,
-		Timestamp:   time.Now(),
+		Timestamp:   pe.now(),
 		Severity:    severity,
 		Action:      BLOCKED,
 	}
@@ -218,6 +302,9 @@ func (pe *PolicyEngine) logViolation(
 
 	pe.violations = append(pe.violations, violation)
 
+	pe.logger.Debug("policy violation",
+		"rule_id", rule.ID, "process_id", process.ID, "target_id", target.ID, "action", violation.Action)
+
 	// Map to violation type
 	violationType := COMPLIANCE_VIOLATION
 	if process.SafetyCritical {
@@ -233,7 +320,7 @@ func (pe *PolicyEngine) logViolation(
 	// Create audit log
 	auditLog := AuditLog{
 		ID:        fmt.Sprintf("audit_%d", len(pe.auditLogs)+1),
-		Timestamp: time.Now(),
+		Timestamp: pe.now(),
 		EventType: "policy_violation",
 		ProcessID: process.ID,
 		TargetID:  target.ID,
@@ -246,7 +333,7 @@ func (pe *PolicyEngine) logViolation(
 		},
 	}
 
-	pe.auditLogs = append(pe.auditLogs, auditLog)
+	pe.appendAudit(auditLog)
 }
 
 // logEvaluation logs a policy evaluation
@@ -258,7 +345,7 @@ func (pe *PolicyEngine) logEvaluation(evaluation PolicyEvaluation) {
 
 	auditLog := AuditLog{
 		ID:        fmt.Sprintf("audit_%d", len(pe.auditLogs)+1),
-		Timestamp: time.Now(),
+		Timestamp: pe.now(),
 		EventType: "policy_evaluation",
 		ProcessID: evaluation.Process.ID,
 		TargetID:  evaluation.Target.ID,
@@ -271,7 +358,51 @@ func (pe *PolicyEngine) logEvaluation(evaluation PolicyEvaluation) {
 		},
 	}
 
+	pe.appendAudit(auditLog)
+}
+
+// RecordAuditEvent appends an externally produced event to the audit log
+func (pe *PolicyEngine) RecordAuditEvent(
+	eventType string,
+	processID string,
+	targetID string,
+	decision string,
+	details map[string]interface{},
+) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	auditLog := AuditLog{
+		ID:        fmt.Sprintf("audit_%d", len(pe.auditLogs)+1),
+		Timestamp: pe.now(),
+		EventType: eventType,
+		ProcessID: processID,
+		TargetID:  targetID,
+		Decision:  decision,
+		Details:   details,
+	}
+
+	pe.appendAudit(auditLog)
+}
+
+// appendAudit records an audit log entry, persisting it when an audit
+// writer is set. The caller holds the lock.
+func (pe *PolicyEngine) appendAudit(auditLog AuditLog) {
 	pe.auditLogs = append(pe.auditLogs, auditLog)
+	if pe.auditWriter != nil {
+		if err := pe.auditWriter.Append(auditLog); err != nil {
+			pe.logger.Error("Failed to persist audit record", "audit_id", auditLog.ID, "error", err)
+		}
+	}
+}
+
+// SetAuditWriter persists every subsequent audit log entry through the
+// writer
+func (pe *PolicyEngine) SetAuditWriter(writer *AuditWriter) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	pe.auditWriter = writer
 }
 
 // GetViolations returns policy violations
@@ -302,6 +433,22 @@ func (pe *PolicyEngine) GetStats() PolicyStats {
 	return pe.stats
 }
 
+// SetLogger sets the structured logger; records are discarded until one is set
+func (pe *PolicyEngine) SetLogger(logger *slog.Logger) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	pe.logger = logger
+}
+
+// SetClock replaces the time source, for tests and simulations
+func (pe *PolicyEngine) SetClock(now func() time.Time) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	pe.now = now
+}
+
 // SetImmutable makes the policy engine immutable (for execution phase)
 func (pe *PolicyEngine) SetImmutable(immutable bool) {
 	pe.mu.Lock()
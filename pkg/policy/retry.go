@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryPolicy configures how failed executions are retried
+type RetryPolicy struct {
+	MaxRetries       int           `json:"max_retries"`
+	Backoff          BackoffType   `json:"backoff"`
+	BaseDelay        time.Duration `json:"base_delay"`         // Delay before the first retry (default 100ms)
+	MaxDelay         time.Duration `json:"max_delay"`          // Backoff cap (default 10s)
+	AvoidFailedClass bool          `json:"avoid_failed_class"` // Retry on a different target type, not just a different target
+	LocalFallback    bool          `json:"local_fallback"`     // Run locally once every offload target has failed
+}
+
+// RetryPolicyFor derives a retry policy from the safety constraints
+func RetryPolicyFor(constraints SafetyConstraints) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:    constraints.MaxRetries,
+		Backoff:       constraints.BackoffStrategy,
+		LocalFallback: constraints.LocalFallback,
+	}
+}
+
+// Validate checks the retry policy
+func (rp RetryPolicy) Validate() error {
+	if rp.MaxRetries < 0 {
+		return fmt.Errorf("max retries must be non-negative")
+	}
+	if rp.BaseDelay < 0 || rp.MaxDelay < 0 {
+		return fmt.Errorf("retry delays must be non-negative")
+	}
+	switch rp.Backoff {
+	case "", EXPONENTIAL, LINEAR, FIXED:
+		return nil
+	}
+	return fmt.Errorf("unknown backoff strategy: %s", rp.Backoff)
+}
+
+// Delay returns how long to wait before the given retry (1 = first retry)
+func (rp RetryPolicy) Delay(retry int) time.Duration {
+	base := rp.BaseDelay
+	if base == 0 {
+		base = 100 * time.Millisecond
+	}
+	maxDelay := rp.MaxDelay
+	if maxDelay == 0 {
+		maxDelay = 10 * time.Second
+	}
+	if retry < 1 {
+		retry = 1
+	}
+
+	delay := base
+	switch rp.Backoff {
+	case LINEAR:
+		delay = base * time.Duration(retry)
+	case FIXED:
+	default: // EXPONENTIAL
+		for i := 1; i < retry && delay < maxDelay; i++ {
+			delay *= 2
+		}
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
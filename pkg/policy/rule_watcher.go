@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// RuleWatcher loads a rule file into a policy engine and reloads it when the
+// file changes. A file that fails to load leaves the previous rules in place.
+type RuleWatcher struct {
+	engine   *PolicyEngine
+	path     string
+	interval time.Duration
+	modTime  time.Time
+	onReload func(rules []PolicyRule, err error)
+}
+
+// NewRuleWatcher creates a watcher for a JSON or YAML rule file
+func NewRuleWatcher(engine *PolicyEngine, path string, interval time.Duration) *RuleWatcher {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	return &RuleWatcher{
+		engine:   engine,
+		path:     path,
+		interval: interval,
+	}
+}
+
+// OnReload registers a callback invoked after every reload attempt
+func (rw *RuleWatcher) OnReload(callback func(rules []PolicyRule, err error)) {
+	rw.onReload = callback
+}
+
+// Load reads the rule file and replaces the rules previously loaded from it
+func (rw *RuleWatcher) Load() error {
+	info, err := os.Stat(rw.path)
+	if err != nil {
+		return rw.report(nil, fmt.Errorf("failed to stat rule file: %w", err))
+	}
+	rw.modTime = info.ModTime() // A broken file is reported once, not on every poll
+
+	rules, err := LoadRules(rw.path)
+	if err != nil {
+		return rw.report(nil, err)
+	}
+	if err := rw.engine.ReplaceRules(rw.path, rules); err != nil {
+		return rw.report(nil, err)
+	}
+
+	return rw.report(rules, nil)
+}
+
+// Run reloads the rule file whenever its modification time changes, until
+// the context is cancelled
+func (rw *RuleWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(rw.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if rw.changed() {
+				rw.Load()
+			}
+		}
+	}
+}
+
+// changed returns true if the file was modified since the last load
+func (rw *RuleWatcher) changed() bool {
+	info, err := os.Stat(rw.path)
+	return err == nil && !info.ModTime().Equal(rw.modTime)
+}
+
+// report invokes the reload callback and passes the error through
+func (rw *RuleWatcher) report(rules []PolicyRule, err error) error {
+	if rw.onReload != nil {
+		rw.onReload(rules, err)
+	}
+	return err
+}
@@ -0,0 +1,20 @@
+package policy
+
+import "github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+
+// SecurityRuleID identifies the rule enforcing negotiated security levels
+const SecurityRuleID = "data-sensitivity-security-level"
+
+// SecurityRule returns a hard rule blocking targets below the security level
+// the process's data sensitivity requires under the security policy
+func SecurityRule(security models.SecurityPolicy) PolicyRule {
+	return PolicyRule{
+		ID:       SecurityRuleID,
+		Type:     models.HARD,
+		Priority: 1,
+		Condition: func(p models.Process, t models.OffloadTarget) bool {
+			return security.RequiredLevel(p) <= t.SecurityLevel
+		},
+		Description: "Target must meet the security level the process's data sensitivity requires",
+	}
+}
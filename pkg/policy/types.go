@@ -16,6 +16,20 @@ type PolicyRule struct {
 	CreatedAt   time.Time                                                    `json:"created_at"`
 	UpdatedAt   time.Time                                                    `json:"updated_at"`
 	Enabled     bool                                                         `json:"enabled"`
+	Penalty     float64                                                      `json:"penalty"` // Soft violation score penalty (0 = default)
+	Source      string                                                       `json:"source"`  // Rule file the rule was loaded from (empty for built-in rules)
+}
+
+// defaultSoftPenalty is the score penalty for a soft violation when the rule
+// does not set its own
+const defaultSoftPenalty = 0.2
+
+// softPenalty returns the score penalty applied when the rule is violated
+func (r PolicyRule) softPenalty() float64 {
+	if r.Penalty > 0 {
+		return r.Penalty
+	}
+	return defaultSoftPenalty
 }
 
 // PolicyViolation represents a policy violation event
@@ -0,0 +1,322 @@
+package pricing
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// AWSConfig configures prices from the AWS Pricing API, with spot prices
+// from EC2 spot price history. Requests are signed with the credentials in
+// the named environment variables.
+type AWSConfig struct {
+	Enabled         bool   `json:"enabled"`
+	Spot            bool   `json:"spot"`              // Also quote current spot prices
+	PricingEndpoint string `json:"pricing_endpoint"`  // Default https://api.pricing.us-east-1.amazonaws.com
+	EC2Endpoint     string `json:"ec2_endpoint"`      // "{region}" is replaced (default https://ec2.{region}.amazonaws.com)
+	AccessKeyEnv    string `json:"access_key_env"`    // Default AWS_ACCESS_KEY_ID
+	SecretKeyEnv    string `json:"secret_key_env"`    // Default AWS_SECRET_ACCESS_KEY
+	SessionTokenEnv string `json:"session_token_env"` // Default AWS_SESSION_TOKEN (unset = no session)
+}
+
+// Validate checks the endpoints
+func (ac AWSConfig) Validate() error {
+	for _, endpoint := range []string{ac.PricingEndpoint, ac.EC2Endpoint} {
+		if endpoint == "" {
+			continue
+		}
+		if _, err := url.Parse(strings.ReplaceAll(endpoint, "{region}", "region")); err != nil {
+			return fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+		}
+	}
+	return nil
+}
+
+// awsPricingRegion is the region the Pricing API is served from and signed for
+const awsPricingRegion = "us-east-1"
+
+// AWSProvider quotes Linux on-demand prices of shared-tenancy EC2 instances
+// from the AWS Pricing API and, when enabled, the cheapest current spot
+// price across a region's availability zones
+type AWSProvider struct {
+	config     AWSConfig
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewAWSProvider creates an AWS price provider
+func NewAWSProvider(config AWSConfig) *AWSProvider {
+	if config.PricingEndpoint == "" {
+		config.PricingEndpoint = "https://api.pricing." + awsPricingRegion + ".amazonaws.com"
+	}
+	if config.EC2Endpoint == "" {
+		config.EC2Endpoint = "https://ec2.{region}.amazonaws.com"
+	}
+	if config.AccessKeyEnv == "" {
+		config.AccessKeyEnv = "AWS_ACCESS_KEY_ID"
+	}
+	if config.SecretKeyEnv == "" {
+		config.SecretKeyEnv = "AWS_SECRET_ACCESS_KEY"
+	}
+	if config.SessionTokenEnv == "" {
+		config.SessionTokenEnv = "AWS_SESSION_TOKEN"
+	}
+	return &AWSProvider{config: config, httpClient: &http.Client{}, now: time.Now}
+}
+
+// Name identifies the provider
+func (ap *AWSProvider) Name() string {
+	return "aws"
+}
+
+// awsCredentials sign requests
+type awsCredentials struct {
+	accessKey    string
+	secretKey    string
+	sessionToken string
+}
+
+// credentials reads the credentials from the environment
+func (ap *AWSProvider) credentials() (awsCredentials, error) {
+	creds := awsCredentials{
+		accessKey:    os.Getenv(ap.config.AccessKeyEnv),
+		secretKey:    os.Getenv(ap.config.SecretKeyEnv),
+		sessionToken: os.Getenv(ap.config.SessionTokenEnv),
+	}
+	if creds.accessKey == "" || creds.secretKey == "" {
+		return creds, fmt.Errorf("%s and %s must be set", ap.config.AccessKeyEnv, ap.config.SecretKeyEnv)
+	}
+	return creds, nil
+}
+
+// Quotes returns the on-demand, and optionally spot, price of every wanted
+// instance type
+func (ap *AWSProvider) Quotes(ctx context.Context, wanted []Key) ([]Quote, error) {
+	creds, err := ap.credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := make([]Quote, 0, len(wanted))
+	for _, key := range wanted {
+		quote := Quote{InstanceType: key.InstanceType, Region: key.Region, Currency: units.BaseCurrency, UpdatedAt: ap.now()}
+		if quote.OnDemand, err = ap.onDemand(ctx, creds, key); err != nil {
+			return nil, fmt.Errorf("%s in %s: %w", key.InstanceType, key.Region, err)
+		}
+		if ap.config.Spot {
+			if quote.Spot, err = ap.spot(ctx, creds, key); err != nil {
+				return nil, fmt.Errorf("%s spot in %s: %w", key.InstanceType, key.Region, err)
+			}
+		}
+		if quote.OnDemand > 0 || quote.Spot > 0 {
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes, nil
+}
+
+// awsFilter is a GetProducts term filter
+type awsFilter struct {
+	Type  string `json:"Type"`
+	Field string `json:"Field"`
+	Value string `json:"Value"`
+}
+
+// awsPriceItem is the part of a Pricing API price list item read for
+// on-demand prices
+type awsPriceItem struct {
+	Terms struct {
+		OnDemand map[string]struct {
+			PriceDimensions map[string]struct {
+				Unit         string            `json:"unit"`
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+// onDemand returns the hourly on-demand price of an instance type, or zero
+// when the Pricing API lists none
+func (ap *AWSProvider) onDemand(ctx context.Context, creds awsCredentials, key Key) (float64, error) {
+	filters := []awsFilter{
+		{"TERM_MATCH", "instanceType", key.InstanceType},
+		{"TERM_MATCH", "regionCode", key.Region},
+		{"TERM_MATCH", "operatingSystem", "Linux"},
+		{"TERM_MATCH", "tenancy", "Shared"},
+		{"TERM_MATCH", "preInstalledSw", "NA"},
+		{"TERM_MATCH", "capacitystatus", "Used"},
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"ServiceCode":   "AmazonEC2",
+		"FormatVersion": "aws_v1",
+		"Filters":       filters,
+		"MaxResults":    100,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, ap.config.PricingEndpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	request.Header.Set("X-Amz-Target", "AWSPriceListService.GetProducts")
+	signV4(request, body, "pricing", awsPricingRegion, creds, ap.now())
+
+	var response struct {
+		PriceList []string `json:"PriceList"`
+	}
+	if err := ap.do(request, func(r io.Reader) error { return json.NewDecoder(r).Decode(&response) }); err != nil {
+		return 0, err
+	}
+
+	for _, document := range response.PriceList {
+		var item awsPriceItem
+		if err := json.Unmarshal([]byte(document), &item); err != nil {
+			return 0, fmt.Errorf("invalid price list item: %w", err)
+		}
+		for _, term := range item.Terms.OnDemand {
+			for _, dimension := range term.PriceDimensions {
+				if dimension.Unit != "Hrs" {
+					continue
+				}
+				if price, err := strconv.ParseFloat(dimension.PricePerUnit[string(units.BaseCurrency)], 64); err == nil && price > 0 {
+					return price, nil
+				}
+			}
+		}
+	}
+	return 0, nil
+}
+
+// spot returns the cheapest current spot price of an instance type across
+// the region's availability zones, or zero when none is offered
+func (ap *AWSProvider) spot(ctx context.Context, creds awsCredentials, key Key) (float64, error) {
+	query := url.Values{
+		"Action":               {"DescribeSpotPriceHistory"},
+		"Version":              {"2016-11-15"},
+		"InstanceType.1":       {key.InstanceType},
+		"ProductDescription.1": {"Linux/UNIX"},
+		"StartTime":            {ap.now().UTC().Format(time.RFC3339)},
+	}
+	endpoint := strings.ReplaceAll(ap.config.EC2Endpoint, "{region}", key.Region)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/?"+awsQuery(query), nil)
+	if err != nil {
+		return 0, err
+	}
+	signV4(request, nil, "ec2", key.Region, creds, ap.now())
+
+	var response struct {
+		Items []struct {
+			SpotPrice string `xml:"spotPrice"`
+		} `xml:"spotPriceHistorySet>item"`
+	}
+	if err := ap.do(request, func(r io.Reader) error { return xml.NewDecoder(r).Decode(&response) }); err != nil {
+		return 0, err
+	}
+
+	cheapest := 0.0
+	for _, item := range response.Items {
+		if price, err := strconv.ParseFloat(item.SpotPrice, 64); err == nil && price > 0 && (cheapest == 0 || price < cheapest) {
+			cheapest = price
+		}
+	}
+	return cheapest, nil
+}
+
+// do sends a request and decodes a successful response
+func (ap *AWSProvider) do(request *http.Request, decode func(io.Reader) error) error {
+	response, err := ap.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("%s returned %s: %s", request.URL.Host, response.Status, strings.TrimSpace(string(message)))
+	}
+	if err := decode(response.Body); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", request.URL.Host, err)
+	}
+	return nil
+}
+
+// awsQuery encodes query parameters sorted by key, with spaces as %20 as
+// signature version 4 requires
+func awsQuery(values url.Values) string {
+	return strings.ReplaceAll(values.Encode(), "+", "%20")
+}
+
+// signV4 signs a request with AWS signature version 4
+func signV4(request *http.Request, body []byte, service, region string, creds awsCredentials, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	request.Header.Set("X-Amz-Date", amzDate)
+	if creds.sessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := request.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		path,
+		awsQuery(request.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + creds.secretKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
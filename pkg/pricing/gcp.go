@@ -0,0 +1,285 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// gcpComputeService is the Cloud Billing Catalog ID of Compute Engine
+const gcpComputeService = "6F81-5844-456A"
+
+// MachineShape is the vCPUs and memory a machine type is billed for
+type MachineShape struct {
+	Family    string  `json:"family"` // SKU family, e.g. "N2"
+	CPU       float64 `json:"cpu"`
+	MemoryGiB float64 `json:"memory_gib"`
+}
+
+// GCPConfig configures prices from the GCP Cloud Billing Catalog. Machine
+// types are priced as their vCPUs and memory at the family's per-core and
+// per-GiB rates; predefined n1, n2, n2d, c2 and e2 standard, highmem and
+// highcpu types are recognized by name.
+type GCPConfig struct {
+	Enabled      bool                    `json:"enabled"`
+	Endpoint     string                  `json:"endpoint"`      // Default https://cloudbilling.googleapis.com
+	APIKeyEnv    string                  `json:"api_key_env"`   // Default GOOGLE_API_KEY
+	MachineTypes map[string]MachineShape `json:"machine_types"` // Shapes of other machine types, by name
+}
+
+// Validate checks the endpoint and machine shapes
+func (gc GCPConfig) Validate() error {
+	if gc.Endpoint != "" {
+		if _, err := url.Parse(gc.Endpoint); err != nil {
+			return fmt.Errorf("invalid endpoint %q: %w", gc.Endpoint, err)
+		}
+	}
+	for name, shape := range gc.MachineTypes {
+		if shape.Family == "" || shape.CPU <= 0 || shape.MemoryGiB < 0 {
+			return fmt.Errorf("machine_types.%s: family and a positive CPU count are required", name)
+		}
+	}
+	return nil
+}
+
+// gcpMemoryPerCPU is the GiB per vCPU of predefined machine classes
+var gcpMemoryPerCPU = map[string]float64{"standard": 4, "highmem": 8, "highcpu": 1}
+
+// gcpN1MemoryPerCPU is the GiB per vCPU of N1 machine classes
+var gcpN1MemoryPerCPU = map[string]float64{"standard": 3.75, "highmem": 6.5, "highcpu": 0.9}
+
+// GCPProvider quotes on-demand and spot prices of Compute Engine machine
+// types from the Cloud Billing Catalog
+type GCPProvider struct {
+	config     GCPConfig
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewGCPProvider creates a GCP price provider
+func NewGCPProvider(config GCPConfig) *GCPProvider {
+	if config.Endpoint == "" {
+		config.Endpoint = "https://cloudbilling.googleapis.com"
+	}
+	if config.APIKeyEnv == "" {
+		config.APIKeyEnv = "GOOGLE_API_KEY"
+	}
+	return &GCPProvider{config: config, httpClient: &http.Client{}, now: time.Now}
+}
+
+// Name identifies the provider
+func (gp *GCPProvider) Name() string {
+	return "gcp"
+}
+
+// Shape returns the vCPUs and memory a machine type is billed for
+func (gp *GCPProvider) Shape(machineType string) (MachineShape, bool) {
+	if shape, exists := gp.config.MachineTypes[machineType]; exists {
+		return shape, true
+	}
+	parts := strings.Split(machineType, "-")
+	if len(parts) != 3 {
+		return MachineShape{}, false
+	}
+	cpu, err := strconv.Atoi(parts[2])
+	if err != nil || cpu <= 0 {
+		return MachineShape{}, false
+	}
+	perCPU := gcpMemoryPerCPU
+	if parts[0] == "n1" {
+		perCPU = gcpN1MemoryPerCPU
+	}
+	memory, exists := perCPU[parts[1]]
+	if !exists {
+		return MachineShape{}, false
+	}
+	return MachineShape{Family: strings.ToUpper(parts[0]), CPU: float64(cpu), MemoryGiB: memory * float64(cpu)}, true
+}
+
+// gcpSKU is the part of a Cloud Billing Catalog SKU read for prices
+type gcpSKU struct {
+	Description string `json:"description"`
+	Category    struct {
+		ResourceFamily string `json:"resourceFamily"`
+		UsageType      string `json:"usageType"`
+	} `json:"category"`
+	ServiceRegions []string `json:"serviceRegions"`
+	PricingInfo    []struct {
+		PricingExpression struct {
+			TieredRates []struct {
+				UnitPrice struct {
+					Units string `json:"units"`
+					Nanos int64  `json:"nanos"`
+				} `json:"unitPrice"`
+			} `json:"tieredRates"`
+		} `json:"pricingExpression"`
+	} `json:"pricingInfo"`
+}
+
+// gcpRate identifies a per-core or per-GiB rate
+type gcpRate struct {
+	family string
+	region string
+	kind   string // "Core" or "Ram"
+	spot   bool
+}
+
+// Quotes returns the on-demand and spot price of every wanted machine type
+// with a known shape
+func (gp *GCPProvider) Quotes(ctx context.Context, wanted []Key) ([]Quote, error) {
+	apiKey := os.Getenv(gp.config.APIKeyEnv)
+	if apiKey == "" {
+		return nil, fmt.Errorf("%s is not set", gp.config.APIKeyEnv)
+	}
+	rates, err := gp.rates(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := make([]Quote, 0, len(wanted))
+	for _, key := range wanted {
+		shape, ok := gp.Shape(key.InstanceType)
+		if !ok {
+			continue
+		}
+		price := func(spot bool) float64 {
+			core := rates[gcpRate{shape.Family, key.Region, "Core", spot}]
+			ram := rates[gcpRate{shape.Family, key.Region, "Ram", spot}]
+			if core == 0 {
+				return 0
+			}
+			return shape.CPU*core + shape.MemoryGiB*ram
+		}
+		quote := Quote{
+			InstanceType: key.InstanceType,
+			Region:       key.Region,
+			OnDemand:     price(false),
+			Spot:         price(true),
+			Currency:     units.BaseCurrency,
+			UpdatedAt:    gp.now(),
+		}
+		if quote.OnDemand > 0 || quote.Spot > 0 {
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes, nil
+}
+
+// rates reads the per-core and per-GiB hourly rates of every machine family
+// and region from the Compute Engine SKUs
+func (gp *GCPProvider) rates(ctx context.Context, apiKey string) (map[gcpRate]float64, error) {
+	rates := make(map[gcpRate]float64)
+	pageToken := ""
+	for {
+		query := url.Values{"key": {apiKey}, "currencyCode": {string(units.BaseCurrency)}, "pageSize": {"5000"}}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		endpoint := fmt.Sprintf("%s/v1/services/%s/skus?%s", gp.config.Endpoint, gcpComputeService, query.Encode())
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			SKUs          []gcpSKU `json:"skus"`
+			NextPageToken string   `json:"nextPageToken"`
+		}
+		if err := gp.do(request, &page); err != nil {
+			return nil, err
+		}
+		for _, sku := range page.SKUs {
+			rate, ok := parseGCPRate(sku)
+			if !ok {
+				continue
+			}
+			price := skuPrice(sku)
+			if price <= 0 {
+				continue
+			}
+			for _, region := range sku.ServiceRegions {
+				rate.region = region
+				rates[rate] = price
+			}
+		}
+
+		if page.NextPageToken == "" {
+			return rates, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// do sends a request and decodes a successful JSON response
+func (gp *GCPProvider) do(request *http.Request, value interface{}) error {
+	response, err := gp.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("%s returned %s: %s", request.URL.Host, response.Status, strings.TrimSpace(string(message)))
+	}
+	if err := json.NewDecoder(response.Body).Decode(value); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", request.URL.Host, err)
+	}
+	return nil
+}
+
+// parseGCPRate identifies the per-core or per-GiB rate a SKU prices, from
+// descriptions such as "Spot Preemptible N2 Instance Core running in
+// Americas". Custom, sole-tenant and committed use SKUs are skipped.
+func parseGCPRate(sku gcpSKU) (gcpRate, bool) {
+	if sku.Category.ResourceFamily != "Compute" {
+		return gcpRate{}, false
+	}
+	var rate gcpRate
+	switch sku.Category.UsageType {
+	case "OnDemand":
+	case "Preemptible":
+		rate.spot = true
+	default:
+		return gcpRate{}, false
+	}
+
+	description := sku.Description
+	for _, skip := range []string{"Custom", "Sole Tenancy", "Commit", "Extended"} {
+		if strings.Contains(description, skip) {
+			return gcpRate{}, false
+		}
+	}
+	description = strings.TrimPrefix(description, "Spot Preemptible ")
+	description = strings.TrimPrefix(description, "Preemptible ")
+	for _, kind := range []string{"Core", "Ram"} {
+		if index := strings.Index(description, " Instance "+kind); index > 0 {
+			rate.family = strings.Fields(description[:index])[0]
+			rate.kind = kind
+			return rate, true
+		}
+	}
+	return gcpRate{}, false
+}
+
+// skuPrice returns the first non-zero tiered unit price of a SKU
+func skuPrice(sku gcpSKU) float64 {
+	for _, info := range sku.PricingInfo {
+		for _, tier := range info.PricingExpression.TieredRates {
+			units, _ := strconv.ParseFloat(tier.UnitPrice.Units, 64)
+			if price := units + float64(tier.UnitPrice.Nanos)/1e9; price > 0 {
+				return price
+			}
+		}
+	}
+	return 0
+}
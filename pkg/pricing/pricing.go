@@ -0,0 +1,344 @@
+// Package pricing keeps target compute prices current from price sources
+// such as a static catalog, the AWS Pricing API and the GCP Cloud Billing
+// Catalog, so cost-based decisions do not run on stale configured prices.
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// Key identifies an instance type in a region
+type Key struct {
+	InstanceType string `json:"instance_type"`
+	Region       string `json:"region"`
+}
+
+// Quote is the hourly price of an instance type in a region
+type Quote struct {
+	InstanceType string         `json:"instance_type"`
+	Region       string         `json:"region"`
+	OnDemand     float64        `json:"on_demand"` // Per hour (0 = not quoted)
+	Spot         float64        `json:"spot"`      // Per hour, preemptible capacity (0 = not quoted)
+	Currency     units.Currency `json:"currency"`  // Empty = USD
+	Provider     string         `json:"provider"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// Key returns the instance type and region the quote is for
+func (q Quote) Key() Key {
+	return Key{InstanceType: q.InstanceType, Region: q.Region}
+}
+
+// Provider is a source of instance prices
+type Provider interface {
+	// Name identifies the provider in quotes and errors
+	Name() string
+	// Quotes returns current prices for the wanted instance types. Providers
+	// may return more, and leave out those they have no price for.
+	Quotes(ctx context.Context, wanted []Key) ([]Quote, error)
+}
+
+// TargetPricing maps a target to the instance it is billed as
+type TargetPricing struct {
+	InstanceType string `json:"instance_type"`
+	Region       string `json:"region"` // Empty = the target's location, once it has been priced
+	Spot         bool   `json:"spot"`   // Billed at the spot price when one is quoted
+}
+
+// Config configures scheduled refreshing of target prices. Providers are
+// refreshed in the order static, AWS, GCP, with later quotes for the same
+// instance type and region taking precedence.
+type Config struct {
+	Enabled  bool                     `json:"enabled"`
+	Interval time.Duration            `json:"interval"` // Time between refreshes (default 1h)
+	Timeout  time.Duration            `json:"timeout"`  // Per-provider refresh timeout (default 30s)
+	Targets  map[string]TargetPricing `json:"targets"`  // Target ID -> instance it is billed as
+	Static   []Quote                  `json:"static"`   // Fixed catalog prices
+	AWS      AWSConfig                `json:"aws"`
+	GCP      GCPConfig                `json:"gcp"`
+}
+
+// Validate checks the pricing configuration
+func (c Config) Validate() error {
+	if c.Interval < 0 || c.Timeout < 0 {
+		return fmt.Errorf("pricing intervals must be non-negative")
+	}
+	for targetID, target := range c.Targets {
+		if target.InstanceType == "" {
+			return fmt.Errorf("targets.%s: instance type cannot be empty", targetID)
+		}
+	}
+	for i, quote := range c.Static {
+		if quote.InstanceType == "" {
+			return fmt.Errorf("static[%d]: instance type cannot be empty", i)
+		}
+		if quote.OnDemand < 0 || quote.Spot < 0 {
+			return fmt.Errorf("static[%d]: prices must be non-negative", i)
+		}
+		if quote.Currency != "" {
+			if err := quote.Currency.Validate(); err != nil {
+				return fmt.Errorf("static[%d]: %w", i, err)
+			}
+		}
+	}
+	if err := c.AWS.Validate(); err != nil {
+		return fmt.Errorf("aws: %w", err)
+	}
+	if err := c.GCP.Validate(); err != nil {
+		return fmt.Errorf("gcp: %w", err)
+	}
+	return nil
+}
+
+// ProviderStatus is the outcome of a provider's last refresh
+type ProviderStatus struct {
+	Name        string    `json:"name"`
+	Quotes      int       `json:"quotes"`
+	LastRefresh time.Time `json:"last_refresh"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// providerState is a provider and the quotes of its last successful refresh
+type providerState struct {
+	provider Provider
+	quotes   map[Key]Quote
+	status   ProviderStatus
+}
+
+// Catalog refreshes instance prices from its providers on a schedule and
+// reprices targets with them. A provider whose refresh fails keeps its
+// previous quotes.
+type Catalog struct {
+	config    Config
+	providers []*providerState
+	locations map[string]string // Locations of priced targets, by ID
+	rates     units.Rates       // Converts quotes to the currency of targets
+	mu        sync.RWMutex
+}
+
+// NewCatalog creates a catalog with the configured providers
+func NewCatalog(config Config) (*Catalog, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Interval == 0 {
+		config.Interval = time.Hour
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	c := &Catalog{config: config, locations: make(map[string]string)}
+	if len(config.Static) > 0 {
+		c.Register(NewStaticProvider(config.Static))
+	}
+	if config.AWS.Enabled {
+		c.Register(NewAWSProvider(config.AWS))
+	}
+	if config.GCP.Enabled {
+		c.Register(NewGCPProvider(config.GCP))
+	}
+	return c, nil
+}
+
+// Register adds a provider, taking precedence over those registered before it
+func (c *Catalog) Register(provider Provider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.providers = append(c.providers, &providerState{
+		provider: provider,
+		quotes:   make(map[Key]Quote),
+		status:   ProviderStatus{Name: provider.Name()},
+	})
+}
+
+// SetRates sets the exchange rates quotes are converted to the currency of
+// targets with
+func (c *Catalog) SetRates(rates units.Rates) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rates = rates
+}
+
+// wanted returns the instance types and regions the configured targets are
+// billed as, with the locations of priced targets for regions left empty
+func (c *Catalog) wanted() []Key {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	seen := make(map[Key]bool)
+	keys := make([]Key, 0, len(c.config.Targets))
+	for targetID, target := range c.config.Targets {
+		key := Key{InstanceType: target.InstanceType, Region: target.Region}
+		if key.Region == "" {
+			key.Region = c.locations[targetID]
+		}
+		if key.Region != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].InstanceType != keys[j].InstanceType {
+			return keys[i].InstanceType < keys[j].InstanceType
+		}
+		return keys[i].Region < keys[j].Region
+	})
+	return keys
+}
+
+// RefreshAll refreshes every provider in turn, returning an error listing
+// the providers whose refresh failed
+func (c *Catalog) RefreshAll(ctx context.Context) error {
+	c.mu.RLock()
+	providers := append([]*providerState(nil), c.providers...)
+	c.mu.RUnlock()
+
+	wanted := c.wanted()
+	failed := make([]string, 0)
+	for _, state := range providers {
+		refreshCtx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+		quotes, err := state.provider.Quotes(refreshCtx, wanted)
+		cancel()
+
+		now := time.Now()
+		c.mu.Lock()
+		state.status.LastRefresh = now
+		if err != nil {
+			state.status.LastError = err.Error()
+			failed = append(failed, fmt.Sprintf("%s: %v", state.status.Name, err))
+		} else {
+			state.status.LastError = ""
+			state.quotes = make(map[Key]Quote, len(quotes))
+			for _, quote := range quotes {
+				quote.Provider = state.status.Name
+				if quote.UpdatedAt.IsZero() {
+					quote.UpdatedAt = now
+				}
+				state.quotes[quote.Key()] = quote
+			}
+			state.status.Quotes = len(state.quotes)
+		}
+		c.mu.Unlock()
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("price refresh failed for %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// Run refreshes prices every Interval until the context is cancelled
+func (c *Catalog) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.RefreshAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Quote returns the current price of an instance type in a region from the
+// provider with the highest precedence that quotes it
+func (c *Catalog) Quote(instanceType, region string) (Quote, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.quote(Key{InstanceType: instanceType, Region: region})
+}
+
+// quote looks up a price; callers hold the lock
+func (c *Catalog) quote(key Key) (Quote, bool) {
+	for i := len(c.providers) - 1; i >= 0; i-- {
+		if quote, exists := c.providers[i].quotes[key]; exists {
+			return quote, true
+		}
+	}
+	return Quote{}, false
+}
+
+// Quotes returns every current price, by instance type and region
+func (c *Catalog) Quotes() []Quote {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	merged := make(map[Key]Quote)
+	for _, state := range c.providers {
+		for key, quote := range state.quotes {
+			merged[key] = quote
+		}
+	}
+	quotes := make([]Quote, 0, len(merged))
+	for _, quote := range merged {
+		quotes = append(quotes, quote)
+	}
+	sort.Slice(quotes, func(i, j int) bool {
+		if quotes[i].InstanceType != quotes[j].InstanceType {
+			return quotes[i].InstanceType < quotes[j].InstanceType
+		}
+		return quotes[i].Region < quotes[j].Region
+	})
+	return quotes
+}
+
+// Status returns the outcome of every provider's last refresh, in precedence
+// order
+func (c *Catalog) Status() []ProviderStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	statuses := make([]ProviderStatus, 0, len(c.providers))
+	for _, state := range c.providers {
+		statuses = append(statuses, state.status)
+	}
+	return statuses
+}
+
+// ApplyTargets returns the targets with their compute cost replaced by the
+// current hourly price of the instance they are billed as: the spot price
+// for spot targets when one is quoted, otherwise the on-demand price,
+// converted to the target's currency. Unmapped and unquoted targets, and
+// those whose currency the quote cannot be converted to, keep their
+// configured cost.
+func (c *Catalog) ApplyTargets(targets []models.OffloadTarget) []models.OffloadTarget {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	applied := make([]models.OffloadTarget, len(targets))
+	for i, target := range targets {
+		if mapping, exists := c.config.Targets[target.ID]; exists {
+			region := mapping.Region
+			if region == "" {
+				region = target.Location
+				c.locations[target.ID] = region
+			}
+			if quote, ok := c.quote(Key{InstanceType: mapping.InstanceType, Region: region}); ok {
+				price := quote.OnDemand
+				if mapping.Spot && quote.Spot > 0 {
+					price = quote.Spot
+				}
+				converted, err := c.rates.Convert(units.Money{Amount: price, Currency: quote.Currency}, target.Currency)
+				if price > 0 && err == nil {
+					target.ComputeCost = converted.Amount
+				}
+			}
+		}
+		applied[i] = target
+	}
+	return applied
+}
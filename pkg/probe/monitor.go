@@ -0,0 +1,277 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Config configures scheduled probing of target endpoints
+type Config struct {
+	Enabled   bool              `json:"enabled"`
+	Interval  time.Duration     `json:"interval"`  // Time between probe rounds (default 10s)
+	Timeout   time.Duration     `json:"timeout"`   // Per-probe timeout (default 1s)
+	Window    int               `json:"window"`    // Probes kept per target (default 10)
+	Endpoints map[string]string `json:"endpoints"` // Target ID -> endpoint URL, see NewProber
+}
+
+// Validate checks the probe configuration
+func (c Config) Validate() error {
+	if c.Interval < 0 || c.Timeout < 0 {
+		return fmt.Errorf("probe intervals must be non-negative")
+	}
+	if c.Window < 0 {
+		return fmt.Errorf("probe window must be non-negative")
+	}
+	for targetID, endpoint := range c.Endpoints {
+		if _, err := NewProber(endpoint); err != nil {
+			return fmt.Errorf("endpoints.%s: %w", targetID, err)
+		}
+	}
+	return nil
+}
+
+// Estimate is the rolling network estimate for a target
+type Estimate struct {
+	TargetID  string        `json:"target_id"`
+	Latency   time.Duration `json:"latency"`   // Mean latency of successful probes
+	Jitter    time.Duration `json:"jitter"`    // Standard deviation of latency
+	Bandwidth float64       `json:"bandwidth"` // Mean measured bandwidth (0 = not measured)
+	Stability float64       `json:"stability"` // Fraction of probes that succeeded
+	Samples   int           `json:"samples"`
+	LastProbe time.Time     `json:"last_probe"`
+	LastError string        `json:"last_error,omitempty"`
+}
+
+// sample is one probe attempt
+type sample struct {
+	result Result
+	failed bool
+}
+
+// targetProbe is a probed target and its recent samples
+type targetProbe struct {
+	prober    Prober
+	samples   []sample // Oldest first, at most Window
+	lastProbe time.Time
+	lastError error
+}
+
+// Monitor probes registered targets on a schedule and keeps rolling latency,
+// bandwidth and stability estimates for them
+type Monitor struct {
+	config  Config
+	targets map[string]*targetProbe
+	mu      sync.RWMutex
+}
+
+// NewMonitor creates a monitor for the configured endpoints
+func NewMonitor(config Config) (*Monitor, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Interval == 0 {
+		config.Interval = 10 * time.Second
+	}
+	if config.Timeout == 0 {
+		config.Timeout = time.Second
+	}
+	if config.Window == 0 {
+		config.Window = 10
+	}
+
+	m := &Monitor{
+		config:  config,
+		targets: make(map[string]*targetProbe),
+	}
+	for targetID, endpoint := range config.Endpoints {
+		prober, _ := NewProber(endpoint) // Validated above
+		m.Register(targetID, prober)
+	}
+	return m, nil
+}
+
+// Register adds or replaces the prober for a target, discarding its samples
+func (m *Monitor) Register(targetID string, prober Prober) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.targets[targetID] = &targetProbe{prober: prober}
+}
+
+// Unregister stops probing a target
+func (m *Monitor) Unregister(targetID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.targets, targetID)
+}
+
+// ProbeAll probes every registered target concurrently, returning an error
+// listing the targets whose probe failed
+func (m *Monitor) ProbeAll(ctx context.Context) error {
+	m.mu.RLock()
+	probers := make(map[string]Prober, len(m.targets))
+	for targetID, target := range m.targets {
+		probers[targetID] = target.prober
+	}
+	m.mu.RUnlock()
+
+	type probeResult struct {
+		targetID string
+		result   Result
+		err      error
+	}
+	results := make(chan probeResult, len(probers))
+	for targetID, prober := range probers {
+		go func(targetID string, prober Prober) {
+			probeCtx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+			defer cancel()
+			result, err := prober.Probe(probeCtx)
+			results <- probeResult{targetID: targetID, result: result, err: err}
+		}(targetID, prober)
+	}
+
+	now := time.Now()
+	failed := make([]string, 0)
+	for range probers {
+		r := <-results
+		if r.err != nil {
+			failed = append(failed, r.targetID)
+		}
+		m.record(r.targetID, r.result, r.err, now)
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("probes failed for %v", failed)
+	}
+	return nil
+}
+
+// Run probes all targets every Interval until the context is cancelled
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.ProbeAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Estimate returns the rolling estimate for a target, and false if it has
+// not been probed
+func (m *Monitor) Estimate(targetID string) (Estimate, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	target, exists := m.targets[targetID]
+	if !exists || len(target.samples) == 0 {
+		return Estimate{}, false
+	}
+	return estimate(targetID, target), true
+}
+
+// Estimates returns the estimates of every probed target ordered by ID
+func (m *Monitor) Estimates() []Estimate {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	estimates := make([]Estimate, 0, len(m.targets))
+	for targetID, target := range m.targets {
+		if len(target.samples) > 0 {
+			estimates = append(estimates, estimate(targetID, target))
+		}
+	}
+	sort.Slice(estimates, func(i, j int) bool { return estimates[i].TargetID < estimates[j].TargetID })
+	return estimates
+}
+
+// ApplyTargets returns the targets with their network latency, bandwidth and
+// stability replaced by the probe estimates. Unprobed targets and fields no
+// probe has measured keep their configured values.
+func (m *Monitor) ApplyTargets(targets []models.OffloadTarget) []models.OffloadTarget {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	applied := make([]models.OffloadTarget, len(targets))
+	for i, target := range targets {
+		if probe, exists := m.targets[target.ID]; exists && len(probe.samples) > 0 {
+			e := estimate(target.ID, probe)
+			if e.Stability > 0 {
+				target.NetworkLatency = e.Latency
+			}
+			if e.Bandwidth > 0 {
+				target.NetworkBandwidth = e.Bandwidth
+			}
+			target.NetworkStability = e.Stability
+		}
+		applied[i] = target
+	}
+	return applied
+}
+
+// record appends a probe sample to a target's window
+func (m *Monitor) record(targetID string, result Result, err error, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	target, exists := m.targets[targetID]
+	if !exists {
+		return // Unregistered while probing
+	}
+	target.samples = append(target.samples, sample{result: result, failed: err != nil})
+	if len(target.samples) > m.config.Window {
+		target.samples = target.samples[len(target.samples)-m.config.Window:]
+	}
+	target.lastProbe = at
+	target.lastError = err
+}
+
+// estimate summarizes a target's sample window
+func estimate(targetID string, target *targetProbe) Estimate {
+	e := Estimate{
+		TargetID:  targetID,
+		Samples:   len(target.samples),
+		LastProbe: target.lastProbe,
+	}
+	if target.lastError != nil {
+		e.LastError = target.lastError.Error()
+	}
+
+	var latencySum, latencySquares, bandwidthSum float64
+	succeeded, measured := 0, 0
+	for _, s := range target.samples {
+		if s.failed {
+			continue
+		}
+		latency := float64(s.result.Latency)
+		latencySum += latency
+		latencySquares += latency * latency
+		succeeded++
+		if s.result.Bandwidth > 0 {
+			bandwidthSum += s.result.Bandwidth
+			measured++
+		}
+	}
+	e.Stability = float64(succeeded) / float64(len(target.samples))
+	if succeeded > 0 {
+		mean := latencySum / float64(succeeded)
+		e.Latency = time.Duration(mean)
+		e.Jitter = time.Duration(math.Sqrt(math.Max(0, latencySquares/float64(succeeded)-mean*mean)))
+	}
+	if measured > 0 {
+		e.Bandwidth = bandwidthSum / float64(measured)
+	}
+	return e
+}
@@ -0,0 +1,119 @@
+// Package probe actively measures the network path to executor endpoints so
+// target latency and bandwidth reflect current conditions rather than the
+// values they were configured with.
+package probe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// minBandwidthBytes is the smallest response body bandwidth is estimated from;
+// smaller transfers are dominated by latency
+const minBandwidthBytes = 64 * 1024
+
+// Result is the measurement from one probe
+type Result struct {
+	Latency   time.Duration `json:"latency"`   // Round-trip or connect latency
+	Bandwidth float64       `json:"bandwidth"` // Bytes/sec (0 = not measured)
+}
+
+// Prober measures the network path to one endpoint
+type Prober interface {
+	Probe(ctx context.Context) (Result, error)
+}
+
+// ProberFunc adapts a function to the Prober interface
+type ProberFunc func(ctx context.Context) (Result, error)
+
+// Probe calls f(ctx)
+func (f ProberFunc) Probe(ctx context.Context) (Result, error) {
+	return f(ctx)
+}
+
+// NewProber creates a prober for an endpoint URL. tcp://host:port measures
+// connect latency; http:// and https:// URLs measure time to first byte and,
+// for large enough responses, bandwidth. ICMP is not supported since it needs
+// raw socket privileges; use a TCP endpoint instead.
+func NewProber(endpoint string) (Prober, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid probe endpoint %q: %w", endpoint, err)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("probe endpoint %q has no host", endpoint)
+	}
+
+	switch parsed.Scheme {
+	case "tcp":
+		return TCPProber{Address: parsed.Host}, nil
+	case "http", "https":
+		return HTTPProber{URL: endpoint}, nil
+	}
+	return nil, fmt.Errorf("unsupported probe scheme %q", parsed.Scheme)
+}
+
+// TCPProber measures the time to establish a TCP connection
+type TCPProber struct {
+	Address string // host:port
+}
+
+// Probe dials the address and closes the connection
+func (p TCPProber) Probe(ctx context.Context) (Result, error) {
+	var dialer net.Dialer
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", p.Address)
+	if err != nil {
+		return Result{}, err
+	}
+	latency := time.Since(start)
+	conn.Close()
+	return Result{Latency: latency}, nil
+}
+
+// HTTPProber measures HTTP time to first byte, and bandwidth from the rest of
+// the response body
+type HTTPProber struct {
+	URL    string
+	Client *http.Client // nil = http.DefaultClient
+}
+
+// Probe issues a GET request and reads the response body
+func (p HTTPProber) Probe(ctx context.Context) (Result, error) {
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return Result{}, err
+	}
+
+	start := time.Now()
+	response, err := client.Do(request)
+	if err != nil {
+		return Result{}, err
+	}
+	defer response.Body.Close()
+	latency := time.Since(start)
+	if response.StatusCode >= 500 {
+		return Result{}, fmt.Errorf("probe returned %s", response.Status)
+	}
+
+	bodyStart := time.Now()
+	n, err := io.Copy(io.Discard, response.Body)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Latency: latency}
+	if transfer := time.Since(bodyStart); n >= minBandwidthBytes && transfer > 0 {
+		result.Bandwidth = float64(n) / transfer.Seconds()
+	}
+	return result, nil
+}
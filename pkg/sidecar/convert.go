@@ -0,0 +1,278 @@
+package sidecar
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	capev1 "github.com/casperlundberg/colony-process-offloader-algorithm/api/proto/cape/v1"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/tenancy"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// fromDecideRequest converts a decide request. Missing messages convert to
+// zero values, which validation rejects.
+func fromDecideRequest(request *capev1.DecideRequest) DecideRequest {
+	targets := make([]models.OffloadTarget, 0, len(request.GetTargets()))
+	for _, target := range request.GetTargets() {
+		targets = append(targets, fromOffloadTarget(target))
+	}
+	return DecideRequest{
+		Process: fromProcess(request.GetProcess()),
+		Targets: targets,
+		State:   fromSystemState(request.GetState()),
+		Timeout: request.GetTimeout().AsDuration(),
+	}
+}
+
+// fromProcess converts a process awaiting placement, so it is queued
+func fromProcess(process *capev1.Process) models.Process {
+	return models.Process{
+		ID:                 process.GetId(),
+		Type:               process.GetType(),
+		TenantID:           process.GetTenantId(),
+		ProjectID:          process.GetProjectId(),
+		Priority:           int(process.GetPriority()),
+		CPURequirement:     process.GetCpuRequirement(),
+		MemoryRequirement:  process.GetMemoryRequirement(),
+		DiskRequirement:    process.GetDiskRequirement(),
+		NetworkRequirement: process.GetNetworkRequirement(),
+		InputSize:          process.GetInputSize(),
+		OutputSize:         process.GetOutputSize(),
+		InputDatasetID:     process.GetInputDatasetId(),
+		OutputDatasetID:    process.GetOutputDatasetId(),
+		InputStorageTier:   models.StorageTier(process.GetInputStorageTier()),
+		DataSensitivity:    int(process.GetDataSensitivity()),
+		EstimatedDuration:  process.GetEstimatedDuration().AsDuration(),
+		MaxDuration:        process.GetMaxDuration().AsDuration(),
+		RealTime:           process.GetRealTime(),
+		SafetyCritical:     process.GetSafetyCritical(),
+		Parallelizable:     process.GetParallelizable(),
+		MaxShards:          int(process.GetMaxShards()),
+		GangSize:           int(process.GetGangSize()),
+		Dependencies:       process.GetDependencies(),
+		LocalityRequired:   process.GetLocalityRequired(),
+		SecurityLevel:      int(process.GetSecurityLevel()),
+		DataResidency:      process.GetDataResidency(),
+		SCCApproved:        process.GetSccApproved(),
+		SubmissionTime:     fromTimestamp(process.GetSubmissionTime()),
+		Status:             models.QUEUED,
+	}
+}
+
+func fromOffloadTarget(target *capev1.OffloadTarget) models.OffloadTarget {
+	converted := models.OffloadTarget{
+		ID:                target.GetId(),
+		Type:              models.TargetType(target.GetType()),
+		Location:          target.GetLocation(),
+		TotalCapacity:     target.GetTotalCapacity(),
+		AvailableCapacity: target.GetAvailableCapacity(),
+		MemoryTotal:       target.GetMemoryTotal(),
+		MemoryAvailable:   target.GetMemoryAvailable(),
+		NetworkLatency:    target.GetNetworkLatency().AsDuration(),
+		NetworkBandwidth:  target.GetNetworkBandwidth(),
+		NetworkStability:  target.GetNetworkStability(),
+		NetworkCost:       target.GetNetworkCost(),
+		ProcessingSpeed:   target.GetProcessingSpeed(),
+		Reliability:       target.GetReliability(),
+		ComputeCost:       target.GetComputeCost(),
+		EnergyCost:        target.GetEnergyCost(),
+		EnergyPrice:       target.GetEnergyPrice(),
+		SecurityLevel:     int(target.GetSecurityLevel()),
+		DataJurisdiction:  target.GetDataJurisdiction(),
+		ComplianceFlags:   target.GetComplianceFlags(),
+		EnergySource:      target.GetEnergySource(),
+		Capabilities:      target.GetCapabilities(),
+		CurrentLoad:       target.GetCurrentLoad(),
+		EstimatedWaitTime: target.GetEstimatedWaitTime().AsDuration(),
+		LastSeen:          fromTimestamp(target.GetLastSeen()),
+		Temperature:       target.GetTemperature(),
+	}
+	if power := target.GetPower(); power != nil {
+		converted.Power = &models.PowerProfile{
+			IdleWatts: power.GetIdleWatts(),
+			PeakWatts: power.GetPeakWatts(),
+			Curve:     power.GetCurve(),
+			PUE:       power.GetPue(),
+		}
+	}
+	if battery := target.GetBattery(); battery != nil {
+		converted.Battery = &models.BatteryState{
+			Level:          battery.GetLevel(),
+			CapacityWh:     battery.GetCapacityWh(),
+			Charging:       battery.GetCharging(),
+			DutyCycleLimit: battery.GetDutyCycleLimit(),
+			DutyCycleUsed:  battery.GetDutyCycleUsed(),
+		}
+	}
+	return converted
+}
+
+// fromSystemState converts a system state, reading its hour and day from its
+// timestamp
+func fromSystemState(state *capev1.SystemState) models.SystemState {
+	converted := models.SystemState{
+		QueueDepth:        int(state.GetQueueDepth()),
+		QueueThreshold:    int(state.GetQueueThreshold()),
+		QueueWaitTime:     state.GetQueueWaitTime().AsDuration(),
+		QueueThroughput:   state.GetQueueThroughput(),
+		ComputeUsage:      models.Utilization(state.GetComputeUsage()),
+		MemoryUsage:       models.Utilization(state.GetMemoryUsage()),
+		DiskUsage:         models.Utilization(state.GetDiskUsage()),
+		NetworkUsage:      models.Utilization(state.GetNetworkUsage()),
+		MasterUsage:       models.Utilization(state.GetMasterUsage()),
+		ActiveConnections: int(state.GetActiveConnections()),
+		Timestamp:         fromTimestamp(state.GetTimestamp()),
+	}
+	if !converted.Timestamp.IsZero() {
+		converted.TimeSlot = converted.Timestamp.Hour()
+		converted.DayOfWeek = int(converted.Timestamp.Weekday())
+	}
+	return converted
+}
+
+func fromOutcome(outcome *capev1.Outcome) decision.OffloadOutcome {
+	return decision.OffloadOutcome{
+		DecisionID:        outcome.GetDecisionId(),
+		ProcessID:         outcome.GetProcessId(),
+		TargetID:          outcome.GetTargetId(),
+		Success:           outcome.GetSuccess(),
+		CompletedOnTime:   outcome.GetCompletedOnTime(),
+		ErrorType:         outcome.GetErrorType(),
+		ExecutionTime:     outcome.GetExecutionTime().AsDuration(),
+		LatencyActual:     outcome.GetLatencyActual().AsDuration(),
+		CostActual:        outcome.GetCostActual(),
+		EnergyConsumed:    outcome.GetEnergyConsumed(),
+		NetworkCongestion: outcome.GetNetworkCongestion(),
+		TargetOverloaded:  outcome.GetTargetOverloaded(),
+		PolicyViolation:   outcome.GetPolicyViolation(),
+		Reward:            outcome.GetReward(),
+	}
+}
+
+func toDecision(dec decision.OffloadDecision) *capev1.Decision {
+	converted := &capev1.Decision{
+		DecisionId:        dec.DecisionID,
+		ShouldOffload:     dec.ShouldOffload,
+		Confidence:        dec.Confidence,
+		Score:             dec.Score,
+		Strategy:          string(dec.Strategy),
+		ExpectedBenefit:   dec.ExpectedBenefit,
+		EstimatedCost:     dec.EstimatedCost,
+		DataSize:          dec.DataSize,
+		TransferTime:      durationpb.New(dec.TransferTime),
+		RetrievalTime:     durationpb.New(dec.RetrievalTime),
+		PolicyViolations:  dec.PolicyViolations,
+		DecisionLatency:   durationpb.New(dec.DecisionLatency),
+		AlgorithmVersion:  dec.AlgorithmVersion,
+		EstimatedEnergyWh: float64(dec.EstimatedEnergy / units.WattHour),
+	}
+	if dec.ShouldOffload && dec.Target != nil {
+		converted.TargetId = dec.Target.ID
+	}
+	return converted
+}
+
+func toWeights(weights decision.AdaptiveWeights) *capev1.Weights {
+	return &capev1.Weights{
+		QueueDepth:    weights.QueueDepth,
+		ProcessorLoad: weights.ProcessorLoad,
+		NetworkCost:   weights.NetworkCost,
+		LatencyCost:   weights.LatencyCost,
+		EnergyCost:    weights.EnergyCost,
+		PolicyCost:    weights.PolicyCost,
+	}
+}
+
+func toStats(metrics algorithm.PerformanceMetrics) *capev1.Stats {
+	phases := make(map[string]*capev1.PhaseStat, len(metrics.PhaseStats))
+	for phase, stat := range metrics.PhaseStats {
+		phases[phase] = &capev1.PhaseStat{
+			Count: stat.Count,
+			Mean:  durationpb.New(stat.Mean),
+			Max:   durationpb.New(stat.Max),
+		}
+	}
+	windows := make(map[string]*capev1.WindowStats, len(metrics.Stats.Windows))
+	for name, window := range metrics.Stats.Windows {
+		windows[name] = &capev1.WindowStats{
+			Decisions:        window.Decisions,
+			Offloads:         window.Offloads,
+			DecisionRate:     window.DecisionRate,
+			MeanDecisionTime: durationpb.New(window.MeanDecisionTime),
+			Outcomes:         window.Outcomes,
+			SuccessRate:      window.SuccessRate,
+		}
+	}
+	return &capev1.Stats{
+		DecisionCount:      int64(metrics.DecisionCount),
+		CurrentWeights:     toWeights(metrics.CurrentWeights),
+		DiscoveredPatterns: int32(metrics.DiscoveredPatterns),
+		ValidatedPatterns:  int32(metrics.ValidatedPatterns),
+		PerformanceGain:    metrics.PerformanceGain,
+		IsConverged:        metrics.IsConverged,
+		PhaseStats:         phases,
+		Version:            metrics.Version,
+		Stats: &capev1.DecisionStats{
+			Decisions:          metrics.Stats.Decisions,
+			Offloads:           metrics.Stats.Offloads,
+			MeanDecisionTime:   durationpb.New(metrics.Stats.MeanDecisionTime),
+			RecentDecisionTime: durationpb.New(metrics.Stats.RecentDecisionTime),
+			Outcomes:           metrics.Stats.Outcomes,
+			SuccessRate:        metrics.Stats.SuccessRate,
+			RecentSuccessRate:  metrics.Stats.RecentSuccessRate,
+			Windows:            windows,
+		},
+	}
+}
+
+func toSpikeReport(report algorithm.SpikeReport) *capev1.SpikeReport {
+	return &capev1.SpikeReport{
+		Id:              report.ID,
+		StartedAt:       toTimestamp(report.StartedAt),
+		DetectedAt:      toTimestamp(report.DetectedAt),
+		EndedAt:         toTimestamp(report.EndedAt),
+		DetectionLag:    durationpb.New(report.DetectionLag),
+		PreScaleLead:    durationpb.New(report.PreScaleLead),
+		PeakQueueDepth:  int32(report.PeakQueueDepth),
+		Processes:       int32(report.Processes),
+		SlaViolations:   int32(report.SLAViolations),
+		PendingOutcomes: int32(report.PendingOutcomes),
+		Cost:            report.Cost,
+	}
+}
+
+func toChargebackRollup(rollup tenancy.ChargebackRollup) *capev1.ChargebackRollup {
+	return &capev1.ChargebackRollup{
+		Month:      rollup.Month,
+		TenantId:   rollup.TenantID,
+		ProjectId:  rollup.ProjectID,
+		Executions: int32(rollup.Executions),
+		Cost: &capev1.CostBreakdown{
+			Infra:    rollup.Cost.Infra,
+			Transfer: rollup.Cost.Transfer,
+			Energy:   rollup.Cost.Energy,
+		},
+		Currency: string(rollup.Currency),
+	}
+}
+
+// fromTimestamp converts a timestamp, leaving unset ones zero
+func fromTimestamp(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	return timestamp.AsTime()
+}
+
+// toTimestamp converts a time, leaving zero times unset
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
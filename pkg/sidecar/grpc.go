@@ -0,0 +1,99 @@
+package sidecar
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	capev1 "github.com/casperlundberg/colony-process-offloader-algorithm/api/proto/cape/v1"
+)
+
+// GRPCServer serves cape.v1.DecisionService over gRPC. It converts messages
+// to the domain types and delegates to a Service.
+type GRPCServer struct {
+	capev1.UnimplementedDecisionServiceServer
+	service *Service
+}
+
+// NewGRPCServer creates a gRPC transport for a decision service. Register it
+// with capev1.RegisterDecisionServiceServer.
+func NewGRPCServer(service *Service) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+// Decide returns placement advice for a process
+func (s *GRPCServer) Decide(ctx context.Context, request *capev1.DecideRequest) (*capev1.Decision, error) {
+	dec, err := s.service.Decide(ctx, fromDecideRequest(request))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toDecision(dec), nil
+}
+
+// ReportOutcome feeds the result of an executed decision into learning
+func (s *GRPCServer) ReportOutcome(ctx context.Context, outcome *capev1.Outcome) (*capev1.ReportOutcomeResponse, error) {
+	if err := s.service.ReportOutcome(ctx, fromOutcome(outcome)); err != nil {
+		return nil, grpcError(err)
+	}
+	return &capev1.ReportOutcomeResponse{}, nil
+}
+
+// GetWeights returns the objective weights decisions are scored with
+func (s *GRPCServer) GetWeights(ctx context.Context, _ *capev1.GetWeightsRequest) (*capev1.Weights, error) {
+	weights, err := s.service.GetWeights(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toWeights(weights), nil
+}
+
+// GetStats returns decision, learning and latency statistics
+func (s *GRPCServer) GetStats(ctx context.Context, _ *capev1.GetStatsRequest) (*capev1.Stats, error) {
+	metrics, err := s.service.GetStats(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toStats(metrics), nil
+}
+
+// ListSpikeReports returns the post-mortems of ended queue spikes
+func (s *GRPCServer) ListSpikeReports(ctx context.Context, _ *capev1.ListSpikeReportsRequest) (*capev1.ListSpikeReportsResponse, error) {
+	reports, err := s.service.ListSpikeReports(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	response := &capev1.ListSpikeReportsResponse{Reports: make([]*capev1.SpikeReport, 0, len(reports))}
+	for _, report := range reports {
+		response.Reports = append(response.Reports, toSpikeReport(report))
+	}
+	return response, nil
+}
+
+// GetChargeback returns the cost of executed offloads by month, tenant and
+// project
+func (s *GRPCServer) GetChargeback(ctx context.Context, request *capev1.GetChargebackRequest) (*capev1.GetChargebackResponse, error) {
+	rollups, err := s.service.GetChargeback(ctx, request.GetMonth())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	response := &capev1.GetChargebackResponse{Rollups: make([]*capev1.ChargebackRollup, 0, len(rollups))}
+	for _, rollup := range rollups {
+		response.Rollups = append(response.Rollups, toChargebackRollup(rollup))
+	}
+	return response, nil
+}
+
+// grpcError maps a service error to its gRPC status
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,145 @@
+// Package sidecar exposes CAPE as a standalone decision service for other
+// schedulers. Service implements the RPCs of cape.v1.DecisionService
+// (api/proto/cape/v1/cape.proto) on the domain types; GRPCServer serves it
+// over gRPC, converting the messages. cmd/cape-sidecar runs it standalone.
+package sidecar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/tenancy"
+)
+
+// ErrInvalidArgument marks requests rejected before reaching the algorithm;
+// transports map it to their invalid argument status
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// DecideRequest asks for placement advice for a process
+type DecideRequest struct {
+	Process models.Process         `json:"process"`
+	Targets []models.OffloadTarget `json:"targets"`
+	State   models.SystemState     `json:"state"`
+	Timeout time.Duration          `json:"timeout"` // Decision deadline (0 = MaxDecisionLatency only)
+}
+
+// Service serves decisions from one algorithm to concurrent callers
+type Service struct {
+	algorithm *algorithm.Algorithm
+	mu        sync.Mutex // The algorithm is not safe for concurrent use
+}
+
+// NewService creates a decision service around an algorithm
+func NewService(alg *algorithm.Algorithm) *Service {
+	return &Service{algorithm: alg}
+}
+
+// Decide returns placement advice for a process
+func (s *Service) Decide(ctx context.Context, request DecideRequest) (decision.OffloadDecision, error) {
+	if err := request.Process.Validate(); err != nil {
+		return decision.OffloadDecision{}, fmt.Errorf("%w: process: %v", ErrInvalidArgument, err)
+	}
+	if err := request.State.Validate(); err != nil {
+		return decision.OffloadDecision{}, fmt.Errorf("%w: state: %v", ErrInvalidArgument, err)
+	}
+	if request.Timeout < 0 {
+		return decision.OffloadDecision{}, fmt.Errorf("%w: timeout must be non-negative", ErrInvalidArgument)
+	}
+	if request.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, request.Timeout)
+		defer cancel()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return decision.OffloadDecision{}, err // Expired waiting for the lock
+	}
+	return s.algorithm.MakeOffloadDecisionContext(ctx, request.Process, request.Targets, request.State)
+}
+
+// ReportOutcome feeds the result of an executed decision into learning
+func (s *Service) ReportOutcome(ctx context.Context, outcome decision.OffloadOutcome) error {
+	if outcome.ProcessID == "" {
+		return fmt.Errorf("%w: outcome process ID cannot be empty", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.algorithm.ProcessOutcome(outcome)
+}
+
+// IngestOutcomes polls the algorithm's outcome topic once, between
+// decisions, and returns how many outcomes it learned from
+func (s *Service) IngestOutcomes(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return s.algorithm.IngestOutcomes(ctx)
+}
+
+// GetWeights returns the objective weights decisions are scored with
+func (s *Service) GetWeights(ctx context.Context) (decision.AdaptiveWeights, error) {
+	stats, err := s.GetStats(ctx)
+	return stats.CurrentWeights, err
+}
+
+// GetStats returns decision, learning and latency statistics
+func (s *Service) GetStats(ctx context.Context) (algorithm.PerformanceMetrics, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return algorithm.PerformanceMetrics{}, err
+	}
+	return s.algorithm.GetPerformanceMetrics(), nil
+}
+
+// ListSpikeReports returns the post-mortems of ended queue spikes, oldest
+// first; empty when spike detection is disabled
+func (s *Service) ListSpikeReports(ctx context.Context) ([]algorithm.SpikeReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	reports := s.algorithm.SpikeReports()
+	if reports == nil {
+		reports = []algorithm.SpikeReport{}
+	}
+	return reports, nil
+}
+
+// GetChargeback returns the cost of executed offloads in a month (YYYY-MM;
+// empty for every month) by month, tenant and project
+func (s *Service) GetChargeback(ctx context.Context, month string) ([]tenancy.ChargebackRollup, error) {
+	if month != "" {
+		if _, err := time.Parse(tenancy.MonthLayout, month); err != nil {
+			return nil, fmt.Errorf("%w: month must be YYYY-MM, got %q", ErrInvalidArgument, month)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.algorithm.Chargeback().Rollup(month), nil
+}
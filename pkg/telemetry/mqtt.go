@@ -0,0 +1,263 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// MQTT 3.1.1 control packet types, in the high nibble of the fixed header
+const (
+	packetConnect     = 0x10
+	packetConnAck     = 0x20
+	packetPublish     = 0x30
+	packetPubAck      = 0x40
+	packetSubscribe   = 0x80
+	packetSubAck      = 0x90
+	packetPingReq     = 0xC0
+	packetPingResp    = 0xD0
+	packetDisconnect  = 0xE0
+	mqttProtocolLevel = 4    // MQTT 3.1.1
+	subAckFailure     = 0x80 // SUBACK return code of a refused subscription
+	maxRemainingBytes = 4    // Length of the largest remaining length field
+)
+
+// brokerAddress returns the host:port of a broker URL and whether it is
+// reached over TLS. tcp:// and mqtt:// default to port 1883, ssl://, tls://
+// and mqtts:// to port 8883.
+func brokerAddress(broker string) (string, bool, error) {
+	parsed, err := url.Parse(broker)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid broker %q: %w", broker, err)
+	}
+	var secure bool
+	port := "1883"
+	switch parsed.Scheme {
+	case "tcp", "mqtt":
+	case "ssl", "tls", "mqtts":
+		secure, port = true, "8883"
+	default:
+		return "", false, fmt.Errorf("unsupported broker scheme %q", parsed.Scheme)
+	}
+	if parsed.Hostname() == "" {
+		return "", false, fmt.Errorf("broker %q has no host", broker)
+	}
+	if parsed.Port() != "" {
+		port = parsed.Port()
+	}
+	return net.JoinHostPort(parsed.Hostname(), port), secure, nil
+}
+
+// mqttConn is a connection to an MQTT broker. Packets are read by one
+// goroutine and written by any, serialized by the write lock.
+type mqttConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+}
+
+// dialBroker opens a connection to the configured broker and completes the
+// CONNECT handshake with a clean session
+func dialBroker(ctx context.Context, config Config, password string) (*mqttConn, error) {
+	address, secure, err := brokerAddress(config.Broker)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: config.KeepAlive}
+	var conn net.Conn
+	if secure {
+		host, _, _ := net.SplitHostPort(address)
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host}}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &mqttConn{conn: conn, reader: bufio.NewReader(conn)}
+	if err := c.connect(config, password); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// connect sends CONNECT and waits for the broker to accept it
+func (c *mqttConn) connect(config Config, password string) error {
+	flags := byte(0x02) // Clean session
+	body := appendString(nil, "MQTT")
+	if config.Username != "" {
+		flags |= 0x80
+		if password != "" {
+			flags |= 0x40
+		}
+	}
+	body = append(body, mqttProtocolLevel, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(config.KeepAlive/time.Second))
+	body = appendString(body, config.ClientID)
+	if config.Username != "" {
+		body = appendString(body, config.Username)
+		if password != "" {
+			body = appendString(body, password)
+		}
+	}
+	if err := c.write(packetConnect, body); err != nil {
+		return err
+	}
+
+	c.conn.SetReadDeadline(time.Now().Add(config.KeepAlive))
+	header, ack, err := c.read()
+	if err != nil {
+		return fmt.Errorf("waiting for CONNACK: %w", err)
+	}
+	if header&0xF0 != packetConnAck || len(ack) != 2 {
+		return fmt.Errorf("expected CONNACK, got packet type %#x", header&0xF0)
+	}
+	if ack[1] != 0 {
+		return fmt.Errorf("broker refused connection: %s", connectRefusal(ack[1]))
+	}
+	return nil
+}
+
+// connectRefusal describes a CONNACK return code
+func connectRefusal(code byte) string {
+	switch code {
+	case 1:
+		return "unacceptable protocol version"
+	case 2:
+		return "client identifier rejected"
+	case 3:
+		return "server unavailable"
+	case 4:
+		return "bad user name or password"
+	case 5:
+		return "not authorized"
+	}
+	return fmt.Sprintf("return code %d", code)
+}
+
+// subscribe requests the topic filters at a QoS, using packet ID 1. The
+// SUBACK is handled by the read loop.
+func (c *mqttConn) subscribe(filters []string, qos byte) error {
+	body := binary.BigEndian.AppendUint16(nil, 1)
+	for _, filter := range filters {
+		body = appendString(body, filter)
+		body = append(body, qos)
+	}
+	return c.write(packetSubscribe|0x02, body)
+}
+
+// write sends a packet with the fixed header byte and body
+func (c *mqttConn) write(header byte, body []byte) error {
+	packet := append([]byte{header}, encodeRemainingLength(len(body))...)
+	packet = append(packet, body...)
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.conn.Write(packet)
+	return err
+}
+
+// read receives the next packet, returning its fixed header byte and body
+func (c *mqttConn) read() (byte, []byte, error) {
+	header, err := c.reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == maxRemainingBytes {
+			return 0, nil, fmt.Errorf("malformed remaining length")
+		}
+		digit, err := c.reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7F) * multiplier
+		if digit&0x80 == 0 {
+			break
+		}
+		multiplier *= 128
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+// close sends DISCONNECT and closes the connection
+func (c *mqttConn) close() error {
+	c.write(packetDisconnect, nil)
+	return c.conn.Close()
+}
+
+// publish is a received PUBLISH packet
+type publish struct {
+	topic    string
+	payload  []byte
+	qos      byte
+	packetID uint16 // Zero at QoS 0
+}
+
+// parsePublish decodes the body of a PUBLISH packet
+func parsePublish(header byte, body []byte) (publish, error) {
+	msg := publish{qos: (header >> 1) & 0x03}
+	topic, rest, err := readString(body)
+	if err != nil {
+		return publish{}, err
+	}
+	msg.topic = topic
+	if msg.qos > 0 {
+		if len(rest) < 2 {
+			return publish{}, fmt.Errorf("PUBLISH is missing its packet ID")
+		}
+		msg.packetID = binary.BigEndian.Uint16(rest)
+		rest = rest[2:]
+	}
+	msg.payload = rest
+	return msg, nil
+}
+
+// encodeRemainingLength encodes a packet body length as MQTT's variable
+// length integer
+func encodeRemainingLength(length int) []byte {
+	encoded := make([]byte, 0, maxRemainingBytes)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		encoded = append(encoded, digit)
+		if length == 0 {
+			return encoded
+		}
+	}
+}
+
+// appendString appends a length-prefixed UTF-8 string
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// readString reads a length-prefixed UTF-8 string, returning the rest
+func readString(b []byte) (string, []byte, error) {
+	if len(b) < 2 {
+		return "", nil, fmt.Errorf("truncated string")
+	}
+	length := int(binary.BigEndian.Uint16(b))
+	if len(b) < 2+length {
+		return "", nil, fmt.Errorf("truncated string")
+	}
+	return string(b[2 : 2+length]), b[2+length:], nil
+}
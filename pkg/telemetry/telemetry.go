@@ -0,0 +1,409 @@
+// Package telemetry ingests executor telemetry that edge fleets publish over
+// MQTT, mapping telemetry topics onto readings for the targets they report.
+package telemetry
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Field is a value a topic mapping extracts from telemetry payloads
+type Field string
+
+// Telemetry fields
+const (
+	FieldTargetID          Field = "target_id"          // Target the message reports, when the topic does not name it
+	FieldTimestamp         Field = "timestamp"          // RFC 3339 or Unix seconds (absent = when received)
+	FieldLoad              Field = "load"               // Utilization (0.0-1.0)
+	FieldAvailableCapacity Field = "available_capacity" // Free processing capacity
+	FieldMemoryAvailable   Field = "memory_available"   // Free memory bytes
+	FieldTemperature       Field = "temperature"        // °C
+	FieldBatteryLevel      Field = "battery_level"      // State of charge (0.0-1.0)
+	FieldCharging          Field = "charging"           // On external power (true/false or 1/0)
+	FieldLatency           Field = "latency_ms"         // Round-trip latency to the executor, in milliseconds
+	FieldRequests          Field = "requests"           // Requests served since the previous message
+	FieldErrors            Field = "errors"             // Requests failed since the previous message
+)
+
+// valueFields are the fields carried as reading values
+var valueFields = []Field{
+	FieldLoad, FieldAvailableCapacity, FieldMemoryAvailable, FieldTemperature,
+	FieldBatteryLevel, FieldCharging, FieldLatency, FieldRequests, FieldErrors,
+}
+
+// TopicMapping maps the messages of a topic filter onto readings. The target
+// is named by the topic level matching the filter's first "+" wildcard, or
+// by the target_id field when the filter has none.
+type TopicMapping struct {
+	Filter string           `json:"filter"` // MQTT topic filter, e.g. "fleet/+/telemetry"
+	Fields map[Field]string `json:"fields"` // Payload JSON key of each field (unset = the field's name)
+}
+
+// Validate checks the filter and fields
+func (tm TopicMapping) Validate() error {
+	if tm.Filter == "" {
+		return fmt.Errorf("filter cannot be empty")
+	}
+	levels := strings.Split(tm.Filter, "/")
+	for i, level := range levels {
+		if strings.Contains(level, "#") && (level != "#" || i != len(levels)-1) {
+			return fmt.Errorf("filter %q: # must be the whole last level", tm.Filter)
+		}
+		if strings.Contains(level, "+") && level != "+" {
+			return fmt.Errorf("filter %q: + must be a whole level", tm.Filter)
+		}
+	}
+	for field := range tm.Fields {
+		if field != FieldTargetID && field != FieldTimestamp && !isValueField(field) {
+			return fmt.Errorf("filter %q: unknown field %q", tm.Filter, field)
+		}
+	}
+	return nil
+}
+
+func isValueField(field Field) bool {
+	for _, valueField := range valueFields {
+		if field == valueField {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the payload key of a field
+func (tm TopicMapping) key(field Field) string {
+	if key, exists := tm.Fields[field]; exists {
+		return key
+	}
+	return string(field)
+}
+
+// Match reports whether a topic matches the filter, returning the level
+// matching its first "+" wildcard
+func (tm TopicMapping) Match(topic string) (string, bool) {
+	filterLevels := strings.Split(tm.Filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	var wildcard string
+	var wildcarded bool
+	for i, level := range filterLevels {
+		if level == "#" {
+			// # does not match topics starting with $ at the first level
+			return wildcard, i > 0 || !strings.HasPrefix(topic, "$")
+		}
+		if i >= len(topicLevels) {
+			return "", false
+		}
+		switch level {
+		case "+":
+			if i == 0 && strings.HasPrefix(topic, "$") {
+				return "", false
+			}
+			if !wildcarded {
+				wildcard, wildcarded = topicLevels[i], true
+			}
+		case topicLevels[i]:
+		default:
+			return "", false
+		}
+	}
+	return wildcard, len(filterLevels) == len(topicLevels)
+}
+
+// Config configures the MQTT subscription and topic mappings
+type Config struct {
+	Enabled     bool           `json:"enabled"`
+	Broker      string         `json:"broker"`       // tcp://host:1883, or ssl://host:8883 for TLS
+	ClientID    string         `json:"client_id"`    // Default "cape-telemetry"
+	Username    string         `json:"username"`     // Empty = anonymous
+	PasswordEnv string         `json:"password_env"` // Environment variable holding the password (default MQTT_PASSWORD)
+	QoS         byte           `json:"qos"`          // 0 (at most once) or 1 (at least once)
+	KeepAlive   time.Duration  `json:"keep_alive"`   // Ping interval, and connect timeout (default 30s)
+	Reconnect   time.Duration  `json:"reconnect"`    // Delay before reconnecting after the connection drops (default 5s)
+	Topics      []TopicMapping `json:"topics"`
+}
+
+// Validate checks the telemetry configuration
+func (c Config) Validate() error {
+	if c.QoS > 1 {
+		return fmt.Errorf("qos must be 0 or 1, got %d", c.QoS)
+	}
+	if c.KeepAlive < 0 || c.Reconnect < 0 {
+		return fmt.Errorf("keep_alive and reconnect must be non-negative")
+	}
+	if c.KeepAlive > 0xFFFF*time.Second {
+		return fmt.Errorf("keep_alive must be at most %v", 0xFFFF*time.Second)
+	}
+	if c.Broker != "" {
+		if _, _, err := brokerAddress(c.Broker); err != nil {
+			return err
+		}
+	}
+	for i, mapping := range c.Topics {
+		if err := mapping.Validate(); err != nil {
+			return fmt.Errorf("topics[%d]: %w", i, err)
+		}
+	}
+	if c.Enabled && (c.Broker == "" || len(c.Topics) == 0) {
+		return fmt.Errorf("broker and topics are required")
+	}
+	return nil
+}
+
+// Reading is one telemetry message mapped onto a target
+type Reading struct {
+	TargetID  string            `json:"target_id"`
+	Topic     string            `json:"topic"`
+	Timestamp time.Time         `json:"timestamp"`
+	Values    map[Field]float64 `json:"values"` // The fields present in the message
+}
+
+// Value returns a field of the reading and whether the message carried it
+func (r Reading) Value(field Field) (float64, bool) {
+	value, exists := r.Values[field]
+	return value, exists
+}
+
+// Stats counts the messages a subscriber has received
+type Stats struct {
+	Connected   bool      `json:"connected"`
+	Connects    int       `json:"connects"`
+	Messages    int       `json:"messages"`
+	Unmapped    int       `json:"unmapped"` // Matched no topic mapping
+	Rejected    int       `json:"rejected"` // Malformed, or refused by the handler
+	LastMessage time.Time `json:"last_message"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// Subscriber subscribes to the configured topics and passes the readings
+// they map to to a handler
+type Subscriber struct {
+	config  Config
+	handler func(Reading) error
+	now     func() time.Time
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewSubscriber creates a subscriber delivering readings to the handler
+func NewSubscriber(config Config, handler func(Reading) error) (*Subscriber, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.ClientID == "" {
+		config.ClientID = "cape-telemetry"
+	}
+	if config.PasswordEnv == "" {
+		config.PasswordEnv = "MQTT_PASSWORD"
+	}
+	if config.KeepAlive == 0 {
+		config.KeepAlive = 30 * time.Second
+	}
+	if config.Reconnect == 0 {
+		config.Reconnect = 5 * time.Second
+	}
+	return &Subscriber{config: config, handler: handler, now: time.Now}, nil
+}
+
+// Map maps a message onto a reading by the first topic mapping matching its
+// topic. It returns false if no mapping matches.
+func (s *Subscriber) Map(topic string, payload []byte) (Reading, bool, error) {
+	for _, mapping := range s.config.Topics {
+		wildcard, matches := mapping.Match(topic)
+		if !matches {
+			continue
+		}
+		reading, err := mapping.read(topic, wildcard, payload, s.now())
+		return reading, true, err
+	}
+	return Reading{}, false, nil
+}
+
+// read extracts the mapped fields from a JSON payload
+func (tm TopicMapping) read(topic, wildcard string, payload []byte, received time.Time) (Reading, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(payload, &document); err != nil {
+		return Reading{}, fmt.Errorf("%s: invalid payload: %w", topic, err)
+	}
+
+	reading := Reading{TargetID: wildcard, Topic: topic, Timestamp: received, Values: make(map[Field]float64)}
+	if reading.TargetID == "" {
+		id, _ := document[tm.key(FieldTargetID)].(string)
+		if id == "" {
+			return Reading{}, fmt.Errorf("%s: no target ID in topic or payload", topic)
+		}
+		reading.TargetID = id
+	}
+	if raw, exists := document[tm.key(FieldTimestamp)]; exists {
+		timestamp, err := parseTimestamp(raw)
+		if err != nil {
+			return Reading{}, fmt.Errorf("%s: %w", topic, err)
+		}
+		reading.Timestamp = timestamp
+	}
+	for _, field := range valueFields {
+		raw, exists := document[tm.key(field)]
+		if !exists || raw == nil {
+			continue
+		}
+		value, err := parseValue(raw)
+		if err != nil {
+			return Reading{}, fmt.Errorf("%s: %s: %w", topic, field, err)
+		}
+		reading.Values[field] = value
+	}
+	return reading, nil
+}
+
+// parseTimestamp reads an RFC 3339 string or Unix seconds
+func parseTimestamp(raw interface{}) (time.Time, error) {
+	switch value := raw.(type) {
+	case string:
+		return time.Parse(time.RFC3339Nano, value)
+	case float64:
+		seconds := int64(value)
+		return time.Unix(seconds, int64((value-float64(seconds))*1e9)), nil
+	}
+	return time.Time{}, fmt.Errorf("timestamp must be RFC 3339 or Unix seconds")
+}
+
+// parseValue reads a number, a boolean as 1 or 0, or a numeric string
+func parseValue(raw interface{}) (float64, error) {
+	switch value := raw.(type) {
+	case float64:
+		return value, nil
+	case bool:
+		if value {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseFloat(value, 64)
+	}
+	return 0, fmt.Errorf("not a number")
+}
+
+// Handle maps a message and passes its reading to the handler
+func (s *Subscriber) Handle(topic string, payload []byte) error {
+	reading, mapped, err := s.Map(topic, payload)
+	if err == nil && mapped {
+		err = s.handler(reading)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Messages++
+	s.stats.LastMessage = s.now()
+	switch {
+	case err != nil:
+		s.stats.Rejected++
+		s.stats.LastError = err.Error()
+	case !mapped:
+		s.stats.Unmapped++
+	}
+	return err
+}
+
+// Stats returns the subscriber's message counts
+func (s *Subscriber) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// Run keeps a subscription to the configured topics, reconnecting after
+// Reconnect when the connection drops, until the context is cancelled
+func (s *Subscriber) Run(ctx context.Context) {
+	for {
+		err := s.session(ctx)
+		s.mu.Lock()
+		s.stats.Connected = false
+		if err != nil && ctx.Err() == nil {
+			s.stats.LastError = err.Error()
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.config.Reconnect):
+		}
+	}
+}
+
+// session connects, subscribes and handles messages until the connection
+// drops or the context is cancelled
+func (s *Subscriber) session(ctx context.Context) error {
+	conn, err := dialBroker(ctx, s.config, os.Getenv(s.config.PasswordEnv))
+	if err != nil {
+		return err
+	}
+	filters := make([]string, len(s.config.Topics))
+	for i, mapping := range s.config.Topics {
+		filters[i] = mapping.Filter
+	}
+	if err := conn.subscribe(filters, s.config.QoS); err != nil {
+		conn.close()
+		return err
+	}
+
+	s.mu.Lock()
+	s.stats.Connected = true
+	s.stats.Connects++
+	s.mu.Unlock()
+
+	// Ping within the keep alive, and disconnect when cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(s.config.KeepAlive / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.close()
+				return
+			case <-done:
+				conn.close()
+				return
+			case <-ticker.C:
+				conn.write(packetPingReq, nil)
+			}
+		}
+	}()
+
+	for {
+		conn.conn.SetReadDeadline(time.Now().Add(s.config.KeepAlive * 3 / 2))
+		header, body, err := conn.read()
+		if err != nil {
+			return err
+		}
+		switch header & 0xF0 {
+		case packetPublish:
+			msg, err := parsePublish(header, body)
+			if err != nil {
+				return err
+			}
+			s.Handle(msg.topic, msg.payload)
+			if msg.qos > 0 {
+				if err := conn.write(packetPubAck, binary.BigEndian.AppendUint16(nil, msg.packetID)); err != nil {
+					return err
+				}
+			}
+		case packetSubAck:
+			for i, code := range body[min(2, len(body)):] {
+				if code == subAckFailure && i < len(filters) {
+					return fmt.Errorf("broker refused subscription to %q", filters[i])
+				}
+			}
+		case packetPingResp:
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package tenancy
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// MonthLayout is the format of chargeback months
+const MonthLayout = "2006-01"
+
+// CostBreakdown splits a cost by what it paid for
+type CostBreakdown struct {
+	Infra    float64 `json:"infra"`    // Compute, as billed
+	Transfer float64 `json:"transfer"` // Moving input and output data
+	Energy   float64 `json:"energy"`
+}
+
+// Total returns the cost of all parts
+func (cb CostBreakdown) Total() float64 {
+	return cb.Infra + cb.Transfer + cb.Energy
+}
+
+// AttributeCost splits the cost of running a process on a target for the
+// elapsed time by the target's compute, network and energy prices. A known
+// cost is split in the same proportions; zero uses the priced cost. A zero
+// elapsed time uses the target's execution time estimate.
+func AttributeCost(process models.Process, target models.OffloadTarget, elapsed time.Duration, cost float64) CostBreakdown {
+	if elapsed <= 0 {
+		elapsed = target.EstimateExecutionTime(process)
+	}
+	breakdown := CostBreakdown{
+		Infra:    target.ComputeCost * target.BilledTime(elapsed).Hours(),
+		Transfer: target.TransferCost(units.DataSize(process.InputSize + process.OutputSize)),
+		Energy:   target.EnergyCostOver(process, elapsed),
+	}
+	if cost <= 0 {
+		return breakdown
+	}
+	if total := breakdown.Total(); total > 0 {
+		scale := cost / total
+		return CostBreakdown{Infra: breakdown.Infra * scale, Transfer: breakdown.Transfer * scale, Energy: breakdown.Energy * scale}
+	}
+	return CostBreakdown{Infra: cost}
+}
+
+// ChargebackRollup is the cost of a tenant's project in one month
+type ChargebackRollup struct {
+	Month      string         `json:"month"` // As YYYY-MM
+	TenantID   string         `json:"tenant_id"`
+	ProjectID  string         `json:"project_id"`
+	Executions int            `json:"executions"`
+	Cost       CostBreakdown  `json:"cost"`
+	Currency   units.Currency `json:"currency"` // Of the cost
+}
+
+// rollupKey identifies a rollup
+type rollupKey struct {
+	month     string
+	tenantID  string
+	projectID string
+}
+
+// Ledger attributes the cost of executed offloads to tenants and projects,
+// rolled up by month. Offloads are opened when decided and charged when
+// their outcome arrives; only the monthly totals are kept. Charges are kept
+// in the base currency and reported in the display currency.
+type Ledger struct {
+	location *time.Location // nil when months are read in each time's own zone
+	display  units.Config
+	open     map[string]openCharge
+	rollups  map[rollupKey]*ChargebackRollup
+	mu       sync.Mutex
+}
+
+// openCharge is an offload awaiting its outcome
+type openCharge struct {
+	process models.Process
+	target  models.OffloadTarget
+}
+
+// NewLedger creates a ledger closing months in the given location (nil =
+// each time's own zone)
+func NewLedger(location *time.Location) *Ledger {
+	return &Ledger{
+		location: location,
+		open:     make(map[string]openCharge),
+		rollups:  make(map[rollupKey]*ChargebackRollup),
+	}
+}
+
+// SetDisplay sets the currency rollups are reported in and the exchange
+// rates to it. The configuration must be valid.
+func (l *Ledger) SetDisplay(display units.Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.display = display
+}
+
+// Open starts charging an offload of a process to a target
+func (l *Ledger) Open(process models.Process, target models.OffloadTarget) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.open[process.ID] = openCharge{process: process, target: target}
+}
+
+// Close charges an opened offload that completed at the given time, with the
+// actual elapsed time and cost when known (zero uses the estimates)
+func (l *Ledger) Close(processID string, elapsed time.Duration, cost float64, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	charge, exists := l.open[processID]
+	if !exists {
+		return
+	}
+	delete(l.open, processID)
+	l.record(charge.process, AttributeCost(charge.process, charge.target, elapsed, cost), at)
+}
+
+// Cancel withdraws an opened offload that was not carried out
+func (l *Ledger) Cancel(processID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.open, processID)
+}
+
+// Record charges an executed offload directly, such as one read back from a
+// log
+func (l *Ledger) Record(process models.Process, breakdown CostBreakdown, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.record(process, breakdown, at)
+}
+
+// record adds a charge to its month's rollup; callers hold the lock
+func (l *Ledger) record(process models.Process, breakdown CostBreakdown, at time.Time) {
+	if l.location != nil {
+		at = at.In(l.location)
+	}
+	key := rollupKey{month: at.Format(MonthLayout), tenantID: process.TenantID, projectID: process.ProjectID}
+	rollup, exists := l.rollups[key]
+	if !exists {
+		rollup = &ChargebackRollup{Month: key.month, TenantID: key.tenantID, ProjectID: key.projectID}
+		l.rollups[key] = rollup
+	}
+	rollup.Executions++
+	rollup.Cost.Infra += breakdown.Infra
+	rollup.Cost.Transfer += breakdown.Transfer
+	rollup.Cost.Energy += breakdown.Energy
+}
+
+// Months returns the months with charges, oldest first
+func (l *Ledger) Months() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	seen := make(map[string]bool)
+	var months []string
+	for key := range l.rollups {
+		if !seen[key.month] {
+			seen[key.month] = true
+			months = append(months, key.month)
+		}
+	}
+	sort.Strings(months)
+	return months
+}
+
+// Rollup returns the charges of a month (empty = every month) by month,
+// tenant and project, in the display currency
+func (l *Ledger) Rollup(month string) []ChargebackRollup {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rollups := make([]ChargebackRollup, 0, len(l.rollups))
+	for key, rollup := range l.rollups {
+		if month == "" || key.month == month {
+			displayed := *rollup
+			displayed.Currency = l.display.DisplayCurrency.OrBase()
+			displayed.Cost = CostBreakdown{
+				Infra:    l.display.Display(rollup.Cost.Infra).Amount,
+				Transfer: l.display.Display(rollup.Cost.Transfer).Amount,
+				Energy:   l.display.Display(rollup.Cost.Energy).Amount,
+			}
+			rollups = append(rollups, displayed)
+		}
+	}
+	sort.Slice(rollups, func(i, j int) bool {
+		if rollups[i].Month != rollups[j].Month {
+			return rollups[i].Month < rollups[j].Month
+		}
+		if rollups[i].TenantID != rollups[j].TenantID {
+			return rollups[i].TenantID < rollups[j].TenantID
+		}
+		return rollups[i].ProjectID < rollups[j].ProjectID
+	})
+	return rollups
+}
+
+// WriteChargebackCSV writes rollups as CSV for finance, one row per month,
+// tenant and project
+func WriteChargebackCSV(w io.Writer, rollups []ChargebackRollup) error {
+	writer := csv.NewWriter(w)
+	header := []string{"month", "tenant_id", "project_id", "executions", "infra_cost", "transfer_cost", "energy_cost", "total_cost", "currency"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, rollup := range rollups {
+		row := []string{
+			rollup.Month, rollup.TenantID, rollup.ProjectID, strconv.Itoa(rollup.Executions),
+			formatCost(rollup.Cost.Infra), formatCost(rollup.Cost.Transfer),
+			formatCost(rollup.Cost.Energy), formatCost(rollup.Cost.Total()),
+			string(rollup.Currency.OrBase()),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatCost(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', -1, 64)
+}
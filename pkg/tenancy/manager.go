@@ -0,0 +1,219 @@
+package tenancy
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Quota limits a tenant's offloading within each quota period. Zero limits are
+// unlimited.
+type Quota struct {
+	CPUHours           float64 `json:"cpu_hours"`
+	Cost               float64 `json:"cost"`
+	ConcurrentOffloads int     `json:"concurrent_offloads"`
+	Weight             float64 `json:"weight"` // Fair share weight (0 = 1.0)
+}
+
+// Usage is a tenant's consumption in the current quota period
+type Usage struct {
+	CPUHours       float64 `json:"cpu_hours"`
+	Cost           float64 `json:"cost"`
+	ActiveOffloads int     `json:"active_offloads"`
+}
+
+// charge is the usage charged for one offloaded process
+type charge struct {
+	tenantID string
+	cores    float64
+	cpuHours float64
+	cost     float64
+}
+
+// Manager tracks per-tenant usage against quotas. Usage resets at the start of
+// every period; active offloads carry over.
+type Manager struct {
+	quotas      map[string]Quota
+	usage       map[string]*Usage
+	active      map[string]charge // By process ID
+	period      time.Duration
+	periodStart time.Time
+	mu          sync.Mutex
+	now         func() time.Time
+}
+
+// NewManager creates a tenant manager with the given quota period (0 = 24h)
+func NewManager(period time.Duration) *Manager {
+	if period <= 0 {
+		period = 24 * time.Hour
+	}
+	return &Manager{
+		quotas:      make(map[string]Quota),
+		usage:       make(map[string]*Usage),
+		active:      make(map[string]charge),
+		period:      period,
+		periodStart: time.Now(),
+		now:         time.Now,
+	}
+}
+
+// SetClock replaces the time source, for tests and simulations
+func (m *Manager) SetClock(now func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = now
+	m.periodStart = now()
+}
+
+// clock returns the current time of the manager's time source
+func (m *Manager) clock() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now()
+}
+
+// SetQuota sets a tenant's quota
+func (m *Manager) SetQuota(tenantID string, quota Quota) error {
+	if tenantID == "" {
+		return fmt.Errorf("tenant ID cannot be empty")
+	}
+	if quota.CPUHours < 0 || quota.Cost < 0 || quota.ConcurrentOffloads < 0 || quota.Weight < 0 {
+		return fmt.Errorf("tenant %s: quota limits must be non-negative", tenantID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.quotas[tenantID] = quota
+	return nil
+}
+
+// Quota returns a tenant's quota
+func (m *Manager) Quota(tenantID string) (Quota, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	quota, exists := m.quotas[tenantID]
+	return quota, exists
+}
+
+// Usage returns a tenant's usage in the current period
+func (m *Manager) Usage(tenantID string) Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rollPeriod()
+	if usage, exists := m.usage[tenantID]; exists {
+		return *usage
+	}
+	return Usage{}
+}
+
+// Admit charges an offload of the process to its tenant, or returns why the
+// tenant's quota does not allow it. The CPU-hour charge is cores times the
+// estimated duration. Processes without a tenant are always admitted and not
+// tracked.
+func (m *Manager) Admit(process models.Process, cores float64, duration time.Duration, cost float64) error {
+	if process.TenantID == "" {
+		return nil
+	}
+	cpuHours := cores * duration.Hours()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rollPeriod()
+	usage := m.usageFor(process.TenantID)
+	if quota, exists := m.quotas[process.TenantID]; exists {
+		if quota.ConcurrentOffloads > 0 && usage.ActiveOffloads >= quota.ConcurrentOffloads {
+			return fmt.Errorf("tenant %s at concurrent offload limit %d", process.TenantID, quota.ConcurrentOffloads)
+		}
+		if quota.CPUHours > 0 && usage.CPUHours+cpuHours > quota.CPUHours {
+			return fmt.Errorf("tenant %s would exceed CPU-hour quota %.2f", process.TenantID, quota.CPUHours)
+		}
+		if quota.Cost > 0 && usage.Cost+cost > quota.Cost {
+			return fmt.Errorf("tenant %s would exceed cost quota %.2f", process.TenantID, quota.Cost)
+		}
+	}
+
+	usage.CPUHours += cpuHours
+	usage.Cost += cost
+	usage.ActiveOffloads++
+	m.active[process.ID] = charge{tenantID: process.TenantID, cores: cores, cpuHours: cpuHours, cost: cost}
+	return nil
+}
+
+// Complete ends an admitted offload, replacing its estimated charge with the
+// actual elapsed time and cost when known (zero keeps the estimate)
+func (m *Manager) Complete(processID string, elapsed time.Duration, cost float64) {
+	m.release(processID, func(usage *Usage, admitted charge) {
+		if elapsed > 0 {
+			usage.CPUHours = math.Max(0.0, usage.CPUHours+admitted.cores*elapsed.Hours()-admitted.cpuHours)
+		}
+		if cost > 0 {
+			usage.Cost = math.Max(0.0, usage.Cost+cost-admitted.cost)
+		}
+	})
+}
+
+// Cancel withdraws an admitted offload that was not carried out, refunding its charge
+func (m *Manager) Cancel(processID string) {
+	m.release(processID, func(usage *Usage, admitted charge) {
+		usage.CPUHours = math.Max(0.0, usage.CPUHours-admitted.cpuHours)
+		usage.Cost = math.Max(0.0, usage.Cost-admitted.cost)
+	})
+}
+
+// release ends an admitted offload and applies a usage correction
+func (m *Manager) release(processID string, correct func(usage *Usage, admitted charge)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	admitted, exists := m.active[processID]
+	if !exists {
+		return
+	}
+	delete(m.active, processID)
+
+	m.rollPeriod()
+	usage := m.usageFor(admitted.tenantID)
+	usage.ActiveOffloads--
+	correct(usage, admitted)
+}
+
+// weight returns a tenant's fair share weight; callers hold the lock
+func (m *Manager) weight(tenantID string) float64 {
+	if quota, exists := m.quotas[tenantID]; exists && quota.Weight > 0 {
+		return quota.Weight
+	}
+	return 1.0
+}
+
+// usageFor returns the tenant's usage record, creating it; callers hold the lock
+func (m *Manager) usageFor(tenantID string) *Usage {
+	usage, exists := m.usage[tenantID]
+	if !exists {
+		usage = &Usage{}
+		m.usage[tenantID] = usage
+	}
+	return usage
+}
+
+// rollPeriod resets consumption when the quota period has elapsed, keeping
+// active offload counts; callers hold the lock
+func (m *Manager) rollPeriod() {
+	now := m.now()
+	if now.Sub(m.periodStart) < m.period {
+		return
+	}
+	m.periodStart = m.periodStart.Add(now.Sub(m.periodStart) / m.period * m.period)
+	for _, usage := range m.usage {
+		usage.CPUHours = 0
+		usage.Cost = 0
+	}
+}
@@ -0,0 +1,286 @@
+package tenancy
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// FairQueue releases queued processes using dominant resource fairness: the
+// next process comes from the tenant with the smallest weighted dominant share
+// of CPU-hours, cost and active offloads. Each tenant's processes are released
+// in priority order, then FIFO.
+//
+// A process with dependencies is held back until every process it depends
+// on has completed, then queued like any other.
+type FairQueue struct {
+	manager    *Manager
+	queues     map[string][]models.Process // By tenant ID
+	order      []string                    // Tenants in first-queued order, for stable tie-breaking
+	size       int
+	blocked    map[string]*blockedProcess // Processes waiting on dependencies, by ID
+	dependents map[string][]string        // Blocked process IDs by the dependency they wait on
+	completed  map[string]bool            // Completed processes, for dependencies pushed after them
+	stats      DependencyStats
+	mu         sync.Mutex
+}
+
+// blockedProcess is a process held back until its dependencies complete
+type blockedProcess struct {
+	process models.Process
+	waiting map[string]bool // Dependencies not yet completed
+	since   time.Time
+}
+
+// DependencyStats summarizes how long processes were blocked on their
+// dependencies
+type DependencyStats struct {
+	Blocked      int           `json:"blocked"`       // Processes currently waiting on dependencies
+	Released     int           `json:"released"`      // Processes queued once their dependencies completed
+	Failed       int           `json:"failed"`        // Processes dropped because a dependency failed
+	Cycles       int           `json:"cycles"`        // Processes refused for a dependency cycle
+	TotalBlocked time.Duration `json:"total_blocked"` // Time released processes spent blocked
+	MaxBlocked   time.Duration `json:"max_blocked"`
+}
+
+// MeanBlocked returns the mean time released processes spent blocked
+func (s DependencyStats) MeanBlocked() time.Duration {
+	if s.Released == 0 {
+		return 0
+	}
+	return s.TotalBlocked / time.Duration(s.Released)
+}
+
+// NewFairQueue creates a queue sharing usage with the tenant manager
+func NewFairQueue(manager *Manager) *FairQueue {
+	return &FairQueue{
+		manager:    manager,
+		queues:     make(map[string][]models.Process),
+		order:      make([]string, 0),
+		blocked:    make(map[string]*blockedProcess),
+		dependents: make(map[string][]string),
+		completed:  make(map[string]bool),
+	}
+}
+
+// Push queues a process under its tenant, or holds it back until the
+// processes it depends on have completed. A process that would close a
+// dependency cycle with the processes already held back is refused.
+func (fq *FairQueue) Push(process models.Process) error {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	waiting := make([]string, 0, len(process.Dependencies))
+	for _, dependency := range process.Dependencies {
+		if !fq.completed[dependency] {
+			waiting = append(waiting, dependency)
+		}
+	}
+	if len(waiting) == 0 {
+		fq.enqueue(process)
+		return nil
+	}
+
+	if cycle := fq.cycle(process.ID, waiting); cycle != nil {
+		fq.stats.Cycles++
+		return fmt.Errorf("process %s: dependency cycle %s", process.ID, strings.Join(cycle, " -> "))
+	}
+	blocked := &blockedProcess{process: process, waiting: make(map[string]bool, len(waiting)), since: fq.manager.clock()}
+	for _, dependency := range waiting {
+		blocked.waiting[dependency] = true
+		fq.dependents[dependency] = append(fq.dependents[dependency], process.ID)
+	}
+	fq.blocked[process.ID] = blocked
+	return nil
+}
+
+// cycle returns the dependency path from a process back to itself through
+// the blocked processes, or nil if its dependencies close no cycle
+func (fq *FairQueue) cycle(processID string, dependencies []string) []string {
+	visited := make(map[string]bool)
+	path := []string{processID}
+	var reaches func(id string) bool
+	reaches = func(id string) bool {
+		path = append(path, id)
+		if id == processID {
+			return true
+		}
+		if blocked, exists := fq.blocked[id]; exists && !visited[id] {
+			visited[id] = true
+			for _, dependency := range blocked.process.Dependencies {
+				if blocked.waiting[dependency] && reaches(dependency) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	for _, dependency := range dependencies {
+		if reaches(dependency) {
+			return path
+		}
+	}
+	return nil
+}
+
+// Complete records that a process has completed, queueing the processes
+// that were blocked only on it
+func (fq *FairQueue) Complete(processID string) {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	if fq.completed[processID] {
+		return
+	}
+	fq.completed[processID] = true
+	now := fq.manager.clock()
+	for _, id := range fq.dependents[processID] {
+		blocked, exists := fq.blocked[id]
+		if !exists {
+			continue
+		}
+		delete(blocked.waiting, processID)
+		if len(blocked.waiting) > 0 {
+			continue
+		}
+		delete(fq.blocked, id)
+		waited := now.Sub(blocked.since)
+		fq.stats.Released++
+		fq.stats.TotalBlocked += waited
+		fq.stats.MaxBlocked = max(fq.stats.MaxBlocked, waited)
+		fq.enqueue(blocked.process)
+	}
+	delete(fq.dependents, processID)
+}
+
+// Fail records that a process has failed, dropping the processes blocked on
+// it directly or transitively. The dropped processes are returned so they
+// can be failed in turn.
+func (fq *FairQueue) Fail(processID string) []models.Process {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	dropped := make([]models.Process, 0)
+	failed := []string{processID}
+	for len(failed) > 0 {
+		id := failed[0]
+		failed = failed[1:]
+		for _, dependent := range fq.dependents[id] {
+			blocked, exists := fq.blocked[dependent]
+			if !exists {
+				continue
+			}
+			delete(fq.blocked, dependent)
+			dropped = append(dropped, blocked.process)
+			failed = append(failed, dependent)
+		}
+		delete(fq.dependents, id)
+	}
+	fq.stats.Failed += len(dropped)
+	return dropped
+}
+
+// enqueue queues a schedulable process under its tenant
+func (fq *FairQueue) enqueue(process models.Process) {
+	queue, exists := fq.queues[process.TenantID]
+	if !exists {
+		fq.order = append(fq.order, process.TenantID)
+	}
+
+	// Insert after every process of equal or higher priority
+	i := len(queue)
+	for i > 0 && queue[i-1].Priority < process.Priority {
+		i--
+	}
+	queue = append(queue, models.Process{})
+	copy(queue[i+1:], queue[i:])
+	queue[i] = process
+
+	fq.queues[process.TenantID] = queue
+	fq.size++
+}
+
+// Pop releases the next process, or false if the queue is empty
+func (fq *FairQueue) Pop() (models.Process, bool) {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	if fq.size == 0 {
+		return models.Process{}, false
+	}
+
+	shares := fq.manager.DominantShares()
+	next := ""
+	best := math.Inf(1)
+	for _, tenantID := range fq.order {
+		if len(fq.queues[tenantID]) == 0 {
+			continue
+		}
+		if share := shares[tenantID]; share < best {
+			next, best = tenantID, share
+		}
+	}
+
+	queue := fq.queues[next]
+	process := queue[0]
+	fq.queues[next] = queue[1:]
+	fq.size--
+	return process, true
+}
+
+// Len returns the number of queued processes ready for release, excluding
+// those blocked on dependencies
+func (fq *FairQueue) Len() int {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	return fq.size
+}
+
+// DependencyStats returns the processes blocked on dependencies and how long
+// released ones waited
+func (fq *FairQueue) DependencyStats() DependencyStats {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	stats := fq.stats
+	stats.Blocked = len(fq.blocked)
+	return stats
+}
+
+// DominantShares returns each tenant's weighted dominant share: the largest
+// fraction it holds of total CPU-hours, cost or active offloads across all
+// tenants, divided by its weight
+func (m *Manager) DominantShares() map[string]float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rollPeriod()
+	var total Usage
+	for _, usage := range m.usage {
+		total.CPUHours += usage.CPUHours
+		total.Cost += usage.Cost
+		total.ActiveOffloads += usage.ActiveOffloads
+	}
+
+	shares := make(map[string]float64, len(m.usage))
+	for tenantID, usage := range m.usage {
+		dominant := 0.0
+		if total.CPUHours > 0 {
+			dominant = math.Max(dominant, usage.CPUHours/total.CPUHours)
+		}
+		if total.Cost > 0 {
+			dominant = math.Max(dominant, usage.Cost/total.Cost)
+		}
+		if total.ActiveOffloads > 0 {
+			dominant = math.Max(dominant, float64(usage.ActiveOffloads)/float64(total.ActiveOffloads))
+		}
+		shares[tenantID] = dominant / m.weight(tenantID)
+	}
+	return shares
+}
@@ -0,0 +1,95 @@
+package units
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DataSize is an amount of data in bytes
+type DataSize int64
+
+// Binary data sizes
+const (
+	Byte DataSize = 1
+	KiB           = 1024 * Byte
+	MiB           = 1024 * KiB
+	GiB           = 1024 * MiB
+	TiB           = 1024 * GiB
+)
+
+// dataSizeUnits are the suffixes ParseDataSize accepts, longest first so
+// "MiB" is not read as "B". Decimal suffixes are treated as binary, as the
+// sizes in this module always have been.
+var dataSizeUnits = []struct {
+	suffix string
+	size   DataSize
+}{
+	{"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB},
+	{"TB", TiB}, {"GB", GiB}, {"MB", MiB}, {"KB", KiB},
+	{"B", Byte},
+}
+
+// ParseDataSize parses sizes such as "512", "64KiB", "1.5 GiB" or "10MB"
+func ParseDataSize(s string) (DataSize, error) {
+	s = strings.TrimSpace(s)
+	size := Byte
+	for _, unit := range dataSizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			size = unit.size
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid data size %q", s)
+	}
+	return DataSize(value * float64(size)), nil
+}
+
+// Bytes returns the size in bytes
+func (d DataSize) Bytes() int64 {
+	return int64(d)
+}
+
+// MiB returns the size in mebibytes
+func (d DataSize) MiB() float64 {
+	return float64(d) / float64(MiB)
+}
+
+// GiB returns the size in gibibytes
+func (d DataSize) GiB() float64 {
+	return float64(d) / float64(GiB)
+}
+
+// String formats the size in the largest unit it has at least one of, e.g.
+// "1.5GiB"
+func (d DataSize) String() string {
+	for _, unit := range dataSizeUnits[:4] {
+		if d >= unit.size || -d >= unit.size {
+			return strconv.FormatFloat(float64(d)/float64(unit.size), 'f', -1, 64) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "B"
+}
+
+// UnmarshalJSON reads a size as a number of bytes or a string with a unit
+func (d *DataSize) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var bytes int64
+		if err := json.Unmarshal(data, &bytes); err != nil {
+			return fmt.Errorf("data size must be a number of bytes or a string such as \"10GiB\"")
+		}
+		*d = DataSize(bytes)
+		return nil
+	}
+	size, err := ParseDataSize(text)
+	if err != nil {
+		return err
+	}
+	*d = size
+	return nil
+}
@@ -0,0 +1,127 @@
+// Package units gives costs a currency and sizes and power a unit, so
+// amounts from different sources can be converted and compared instead of
+// being implicitly USD, bytes or watts.
+package units
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Currency is an ISO 4217 currency code, such as "USD" or "EUR"
+type Currency string
+
+// BaseCurrency is the currency costs are compared in. Target costs in other
+// currencies are converted to it before decisions.
+const BaseCurrency Currency = "USD"
+
+// OrBase returns the currency, or the base currency when it is empty
+func (c Currency) OrBase() Currency {
+	if c == "" {
+		return BaseCurrency
+	}
+	return c
+}
+
+// Validate checks the currency is a three-letter upper-case code
+func (c Currency) Validate() error {
+	if len(c) != 3 {
+		return fmt.Errorf("currency %q must be a three-letter ISO 4217 code", string(c))
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("currency %q must be a three-letter ISO 4217 code", string(c))
+		}
+	}
+	return nil
+}
+
+// Money is an amount in a currency
+type Money struct {
+	Amount   float64  `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// Base returns an amount in the base currency
+func Base(amount float64) Money {
+	return Money{Amount: amount, Currency: BaseCurrency}
+}
+
+// String formats the amount with its currency, e.g. "12.5 EUR"
+func (m Money) String() string {
+	return strconv.FormatFloat(m.Amount, 'f', -1, 64) + " " + string(m.Currency.OrBase())
+}
+
+// Rates are exchange rates as units of each currency per unit of the base
+// currency, e.g. {"EUR": 0.92}. The base currency always has rate 1.
+type Rates map[Currency]float64
+
+// Validate checks every currency code and that every rate is positive
+func (r Rates) Validate() error {
+	for currency, rate := range r {
+		if err := currency.Validate(); err != nil {
+			return err
+		}
+		if rate <= 0 {
+			return fmt.Errorf("rate of %s must be positive, got %v", currency, rate)
+		}
+	}
+	return nil
+}
+
+// Rate returns the units of a currency per unit of the base currency
+func (r Rates) Rate(currency Currency) (float64, bool) {
+	currency = currency.OrBase()
+	if currency == BaseCurrency {
+		return 1, true
+	}
+	rate, exists := r[currency]
+	return rate, exists
+}
+
+// Convert converts money to another currency
+func (r Rates) Convert(m Money, to Currency) (Money, error) {
+	from, to := m.Currency.OrBase(), to.OrBase()
+	if from == to {
+		return Money{Amount: m.Amount, Currency: to}, nil
+	}
+	fromRate, ok := r.Rate(from)
+	if !ok {
+		return Money{}, fmt.Errorf("no exchange rate for %s", from)
+	}
+	toRate, ok := r.Rate(to)
+	if !ok {
+		return Money{}, fmt.Errorf("no exchange rate for %s", to)
+	}
+	return Money{Amount: m.Amount / fromRate * toRate, Currency: to}, nil
+}
+
+// Config sets the currency reports are displayed in and the exchange rates
+// used to convert target costs and reports
+type Config struct {
+	DisplayCurrency Currency `json:"display_currency"` // Currency of reports (default USD)
+	Rates           Rates    `json:"rates"`            // Units of each currency per USD
+}
+
+// Validate checks the rates and that the display currency has one
+func (c Config) Validate() error {
+	if err := c.Rates.Validate(); err != nil {
+		return fmt.Errorf("rates: %w", err)
+	}
+	if c.DisplayCurrency != "" {
+		if err := c.DisplayCurrency.Validate(); err != nil {
+			return fmt.Errorf("display_currency: %w", err)
+		}
+		if _, ok := c.Rates.Rate(c.DisplayCurrency); !ok {
+			return fmt.Errorf("display_currency: no exchange rate for %s", c.DisplayCurrency)
+		}
+	}
+	return nil
+}
+
+// Display converts an amount in the base currency to the display currency.
+// The configuration must be valid.
+func (c Config) Display(amount float64) Money {
+	rate, _ := c.Rates.Rate(c.DisplayCurrency)
+	return Money{Amount: amount * rate, Currency: c.DisplayCurrency.OrBase()}
+}
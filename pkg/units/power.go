@@ -0,0 +1,49 @@
+package units
+
+import (
+	"strconv"
+	"time"
+)
+
+// Power is a rate of energy use in watts
+type Power float64
+
+// Power units
+const (
+	Watt     Power = 1
+	Kilowatt       = 1000 * Watt
+)
+
+// Energy is an amount of energy in watt-hours
+type Energy float64
+
+// Energy units
+const (
+	WattHour     Energy = 1
+	KilowattHour        = 1000 * WattHour
+)
+
+// Watts returns the power in watts
+func (p Power) Watts() float64 {
+	return float64(p)
+}
+
+// Over returns the energy used drawing the power for a duration
+func (p Power) Over(d time.Duration) Energy {
+	return Energy(float64(p) * d.Hours())
+}
+
+// String formats the power, e.g. "250W"
+func (p Power) String() string {
+	return strconv.FormatFloat(float64(p), 'f', -1, 64) + "W"
+}
+
+// KilowattHours returns the energy in kilowatt-hours
+func (e Energy) KilowattHours() float64 {
+	return float64(e / KilowattHour)
+}
+
+// String formats the energy, e.g. "12.5Wh"
+func (e Energy) String() string {
+	return strconv.FormatFloat(float64(e), 'f', -1, 64) + "Wh"
+}
@@ -0,0 +1,363 @@
+// Package harness drives CAPE end to end in memory for integration tests.
+// A Harness embeds the algorithm in an embedded.Service backed by a fleet
+// and system state the test controls and an executor that completes
+// processes instantly, or fails them on demand. Scenarios are scripted as a
+// sequence of steps, after which the test asserts on the placements made
+// and the algorithm's metrics.
+//
+//	h := harness.New(t, embedded.DefaultConfig())
+//	h.Run(
+//		harness.Submit(harness.Process("p1")),
+//		harness.RemoveTarget("local-1"),
+//		harness.Submit(harness.Process("p2")),
+//	)
+//	h.ExpectPlacements("local-1", "edge-1")
+package harness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/examples/embedded"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// ErrInjected is the error returned by executions failed with FailTarget
+var ErrInjected = errors.New("injected failure")
+
+// Execution is one execution of a process by the harness executor
+type Execution struct {
+	ProcessID string
+	TargetID  string // "local" when the process ran locally
+	Failed    bool
+}
+
+// Submission is the result of submitting one process
+type Submission struct {
+	Process models.Process
+	Result  embedded.Result
+	Err     error
+}
+
+// TargetID returns where the submission's last attempt ran
+func (s Submission) TargetID() string {
+	return s.Result.Outcome.TargetID
+}
+
+// Harness runs scenarios against an embedded CAPE service
+type Harness struct {
+	t       testing.TB
+	service *embedded.Service
+
+	mu          sync.Mutex
+	state       models.SystemState
+	fleet       []models.OffloadTarget
+	failures    map[string]int // Target ID to executions left to fail
+	executions  []Execution
+	submissions []Submission
+}
+
+// Step is one action of a scenario
+type Step func(h *Harness)
+
+// New creates a harness with the default state and fleet. A nil logger in
+// the config discards logs. The service is closed when the test ends.
+func New(t testing.TB, config algorithm.Config) *Harness {
+	t.Helper()
+	if config.Logger == nil {
+		config.Logger = logging.Discard()
+	}
+
+	h := &Harness{
+		t:        t,
+		state:    DefaultState(),
+		fleet:    DefaultFleet(),
+		failures: make(map[string]int),
+	}
+	service, err := embedded.NewService(config, h, embedded.ExecutorFunc(h.execute))
+	if err != nil {
+		t.Fatalf("harness: failed to create service: %v", err)
+	}
+	h.service = service
+	t.Cleanup(func() {
+		if err := service.Close(); err != nil {
+			t.Errorf("harness: failed to close service: %v", err)
+		}
+	})
+	return h
+}
+
+// DefaultState returns a system state with the queue above its threshold,
+// so offloading pays off
+func DefaultState() models.SystemState {
+	return models.SystemState{
+		QueueDepth:     25,
+		QueueThreshold: 20,
+		ComputeUsage:   0.75,
+		MemoryUsage:    0.60,
+		NetworkUsage:   0.30,
+		Timestamp:      time.Now(),
+	}
+}
+
+// DefaultFleet returns one healthy local, edge and cloud target:
+// local-1, edge-1 and cloud-1
+func DefaultFleet() []models.OffloadTarget {
+	return []models.OffloadTarget{
+		Target("local-1", models.LOCAL, time.Millisecond),
+		Target("edge-1", models.EDGE, 5*time.Millisecond),
+		Target("cloud-1", models.PUBLIC_CLOUD, 25*time.Millisecond),
+	}
+}
+
+// Target returns a healthy target of the given type and latency
+func Target(id string, targetType models.TargetType, latency time.Duration) models.OffloadTarget {
+	return models.OffloadTarget{
+		ID:                id,
+		Type:              targetType,
+		TotalCapacity:     16.0,
+		AvailableCapacity: 12.0,
+		MemoryTotal:       32 * 1024 * 1024 * 1024,
+		MemoryAvailable:   24 * 1024 * 1024 * 1024,
+		NetworkLatency:    latency,
+		NetworkBandwidth:  500 * 1024 * 1024,
+		NetworkStability:  0.98,
+		ProcessingSpeed:   1.5,
+		Reliability:       0.97,
+		ComputeCost:       0.05,
+		SecurityLevel:     5,
+		DataJurisdiction:  "domestic",
+		LastSeen:          time.Now(),
+	}
+}
+
+// Process returns a valid queued compute process
+func Process(id string) models.Process {
+	return models.Process{
+		ID:                id,
+		Type:              "compute",
+		Priority:          5,
+		CPURequirement:    2.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         10 * 1024 * 1024,
+		OutputSize:        1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Status:            models.QUEUED,
+	}
+}
+
+// Processes returns count processes with IDs prefix-0, prefix-1, ...
+func Processes(prefix string, count int) []models.Process {
+	processes := make([]models.Process, count)
+	for i := range processes {
+		processes[i] = Process(fmt.Sprintf("%s-%d", prefix, i))
+	}
+	return processes
+}
+
+// Submit submits processes in order. Submission errors are recorded, not
+// fatal; check them with ExpectNoErrors or Submissions.
+func Submit(processes ...models.Process) Step {
+	return func(h *Harness) {
+		for _, process := range processes {
+			result, err := h.service.Submit(context.Background(), process)
+			h.mu.Lock()
+			h.submissions = append(h.submissions, Submission{Process: process, Result: result, Err: err})
+			h.mu.Unlock()
+		}
+	}
+}
+
+// SetState replaces the system state reported to the algorithm
+func SetState(state models.SystemState) Step {
+	return func(h *Harness) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.state = state
+	}
+}
+
+// SetQueue changes the queue depth and threshold of the system state
+func SetQueue(depth, threshold int) Step {
+	return func(h *Harness) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.state.QueueDepth = depth
+		h.state.QueueThreshold = threshold
+	}
+}
+
+// AddTarget adds a target to the fleet, replacing any with the same ID
+func AddTarget(target models.OffloadTarget) Step {
+	return func(h *Harness) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.fleet = append(h.removeTarget(target.ID), target)
+	}
+}
+
+// RemoveTarget removes a target from the fleet
+func RemoveTarget(id string) Step {
+	return func(h *Harness) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.fleet = h.removeTarget(id)
+	}
+}
+
+// FailTarget makes the next count executions on a target fail with
+// ErrInjected. Use "local" for local executions.
+func FailTarget(id string, count int) Step {
+	return func(h *Harness) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.failures[id] += count
+	}
+}
+
+// Run executes the steps of a scenario in order
+func (h *Harness) Run(steps ...Step) {
+	for _, step := range steps {
+		step(h)
+	}
+}
+
+// Service returns the embedded service, for retry policies and dead letters
+func (h *Harness) Service() *embedded.Service {
+	return h.service
+}
+
+// Algorithm returns the embedded algorithm, for explanations and metrics
+func (h *Harness) Algorithm() *algorithm.Algorithm {
+	return h.service.Algorithm()
+}
+
+// Metrics returns the algorithm's performance metrics
+func (h *Harness) Metrics() algorithm.PerformanceMetrics {
+	return h.service.Algorithm().GetPerformanceMetrics()
+}
+
+// Submissions returns every submission, in order
+func (h *Harness) Submissions() []Submission {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Submission(nil), h.submissions...)
+}
+
+// Executions returns every execution attempt, in order
+func (h *Harness) Executions() []Execution {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Execution(nil), h.executions...)
+}
+
+// Placements returns the target ID of every submission's last attempt, in
+// order
+func (h *Harness) Placements() []string {
+	submissions := h.Submissions()
+	placements := make([]string, len(submissions))
+	for i, submission := range submissions {
+		placements[i] = submission.TargetID()
+	}
+	return placements
+}
+
+// ExpectNoErrors fails the test if any submission failed
+func (h *Harness) ExpectNoErrors() {
+	h.t.Helper()
+	for _, submission := range h.Submissions() {
+		if submission.Err != nil {
+			h.t.Errorf("submission of %s failed: %v", submission.Process.ID, submission.Err)
+		}
+	}
+}
+
+// ExpectPlacements fails the test unless the submissions' placements match
+// exactly. An empty expected ID matches any placement.
+func (h *Harness) ExpectPlacements(expected ...string) {
+	h.t.Helper()
+	submissions := h.Submissions()
+	if len(submissions) != len(expected) {
+		h.t.Errorf("expected %d placements, got %d: %v", len(expected), len(submissions), h.Placements())
+		return
+	}
+	for i, id := range expected {
+		if id != "" && submissions[i].TargetID() != id {
+			h.t.Errorf("placement %d (%s): expected %s, got %s", i, submissions[i].Process.ID, id, submissions[i].TargetID())
+		}
+	}
+}
+
+// ExpectNeverOn fails the test if any submission ran on one of the targets
+func (h *Harness) ExpectNeverOn(targetIDs ...string) {
+	h.t.Helper()
+	for _, execution := range h.Executions() {
+		for _, id := range targetIDs {
+			if execution.TargetID == id {
+				h.t.Errorf("process %s executed on %s", execution.ProcessID, id)
+			}
+		}
+	}
+}
+
+// ExpectMetrics fails the test with the error check returns for the
+// algorithm's metrics
+func (h *Harness) ExpectMetrics(check func(algorithm.PerformanceMetrics) error) {
+	h.t.Helper()
+	if err := check(h.Metrics()); err != nil {
+		h.t.Errorf("metrics: %v", err)
+	}
+}
+
+// SystemState implements embedded.MetricsSource
+func (h *Harness) SystemState(ctx context.Context) (models.SystemState, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.state, ctx.Err()
+}
+
+// Targets implements embedded.MetricsSource
+func (h *Harness) Targets(ctx context.Context) ([]models.OffloadTarget, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]models.OffloadTarget(nil), h.fleet...), ctx.Err()
+}
+
+// execute completes a process instantly, taking its estimated duration and
+// the target's latency and cost, unless a failure is pending on the target
+func (h *Harness) execute(ctx context.Context, process models.Process, target *models.OffloadTarget) (embedded.ExecutionResult, error) {
+	targetID := "local"
+	result := embedded.ExecutionResult{Duration: process.EstimatedDuration}
+	if target != nil {
+		targetID = target.ID
+		result.Latency = target.NetworkLatency
+		result.Cost = target.ComputeCost
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var err error
+	if h.failures[targetID] > 0 {
+		h.failures[targetID]--
+		err = ErrInjected
+	}
+	h.executions = append(h.executions, Execution{ProcessID: process.ID, TargetID: targetID, Failed: err != nil})
+	return result, err
+}
+
+// removeTarget returns the fleet without the target; callers hold h.mu
+func (h *Harness) removeTarget(id string) []models.OffloadTarget {
+	fleet := make([]models.OffloadTarget, 0, len(h.fleet))
+	for _, target := range h.fleet {
+		if target.ID != id {
+			fleet = append(fleet, target)
+		}
+	}
+	return fleet
+}
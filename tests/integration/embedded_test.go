@@ -0,0 +1,252 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/examples/embedded"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// Embedded service test requirements:
+// 1. Every submitted process must be decided, executed and learned from
+// 2. Every decision must be explainable by ID after the fact
+// 3. Safety-critical processes must never be offloaded
+// 4. Execution errors must be recorded as failed outcomes
+// 5. Targets removed from the metrics source must no longer be chosen
+// 6. Failed executions must be retried away from the failed target or class
+// 7. Processes failing every attempt must be dead-lettered
+
+type EmbeddedServiceTestSuite struct {
+	suite.Suite
+	metrics  *embedded.StaticMetrics
+	executed map[string]string // Process ID to target ID
+	attempts []string          // Target ID of every execution, in order
+	failWith error
+	failures int // Executions to fail before failWith applies
+	service  *embedded.Service
+}
+
+func (suite *EmbeddedServiceTestSuite) SetupTest() {
+	now := time.Now()
+	suite.metrics = &embedded.StaticMetrics{
+		State: models.SystemState{
+			QueueDepth:     25,
+			QueueThreshold: 20,
+			ComputeUsage:   0.75,
+			MemoryUsage:    0.60,
+			NetworkUsage:   0.30,
+			Timestamp:      now,
+		},
+		Fleet: []models.OffloadTarget{
+			suite.target("local-1", models.LOCAL, time.Millisecond, now),
+			suite.target("edge-1", models.EDGE, 5*time.Millisecond, now),
+			suite.target("cloud-1", models.PUBLIC_CLOUD, 25*time.Millisecond, now),
+		},
+	}
+	suite.executed = make(map[string]string)
+	suite.attempts = nil
+	suite.failWith = nil
+	suite.failures = 0
+
+	executor := embedded.ExecutorFunc(func(ctx context.Context, process models.Process, target *models.OffloadTarget) (embedded.ExecutionResult, error) {
+		targetID := "local"
+		result := embedded.ExecutionResult{Duration: process.EstimatedDuration}
+		if target != nil {
+			targetID = target.ID
+			result.Latency = target.NetworkLatency
+			result.Cost = target.ComputeCost
+		}
+		suite.executed[process.ID] = targetID
+		suite.attempts = append(suite.attempts, targetID)
+		if suite.failures > 0 {
+			suite.failures--
+			return result, errors.New("transient failure")
+		}
+		return result, suite.failWith
+	})
+
+	config := embedded.DefaultConfig()
+	config.Logger = logging.Discard()
+
+	var err error
+	suite.service, err = embedded.NewService(config, suite, executor)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *EmbeddedServiceTestSuite) TearDownTest() {
+	suite.NoError(suite.service.Close())
+}
+
+// SystemState and Targets make the suite a MetricsSource whose fleet can be
+// changed between submissions
+func (suite *EmbeddedServiceTestSuite) SystemState(ctx context.Context) (models.SystemState, error) {
+	return suite.metrics.SystemState(ctx)
+}
+
+func (suite *EmbeddedServiceTestSuite) Targets(ctx context.Context) ([]models.OffloadTarget, error) {
+	return suite.metrics.Targets(ctx)
+}
+
+func (suite *EmbeddedServiceTestSuite) target(id string, targetType models.TargetType, latency time.Duration, now time.Time) models.OffloadTarget {
+	return models.OffloadTarget{
+		ID:                id,
+		Type:              targetType,
+		TotalCapacity:     16.0,
+		AvailableCapacity: 12.0,
+		MemoryTotal:       32 * 1024 * 1024 * 1024,
+		MemoryAvailable:   24 * 1024 * 1024 * 1024,
+		NetworkLatency:    latency,
+		NetworkBandwidth:  500 * 1024 * 1024,
+		NetworkStability:  0.98,
+		ProcessingSpeed:   1.5,
+		Reliability:       0.97,
+		ComputeCost:       0.05,
+		SecurityLevel:     5,
+		DataJurisdiction:  "domestic",
+		LastSeen:          now,
+	}
+}
+
+func (suite *EmbeddedServiceTestSuite) process(id string) models.Process {
+	return models.Process{
+		ID:                id,
+		Type:              "compute",
+		Priority:          5,
+		CPURequirement:    2.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         10 * 1024 * 1024,
+		OutputSize:        1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Status:            models.QUEUED,
+	}
+}
+
+func (suite *EmbeddedServiceTestSuite) TestSubmitDecidesExecutesAndLearns() {
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		process := suite.process(fmt.Sprintf("process-%d", i))
+		result, err := suite.service.Submit(ctx, process)
+		require.NoError(suite.T(), err)
+
+		assert.NotEmpty(suite.T(), result.Decision.DecisionID)
+		assert.Equal(suite.T(), result.Decision.DecisionID, result.Outcome.DecisionID)
+		assert.Equal(suite.T(), suite.executed[process.ID], result.Outcome.TargetID)
+		assert.True(suite.T(), result.Outcome.Success)
+
+		explanation, err := suite.service.Algorithm().Explain(result.Decision.DecisionID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), process.ID, explanation.ProcessID)
+	}
+
+	metrics := suite.service.Algorithm().GetPerformanceMetrics()
+	assert.Equal(suite.T(), 20, metrics.DecisionCount)
+	assert.Equal(suite.T(), 20, metrics.LearningProgress.DecisionCount)
+}
+
+func (suite *EmbeddedServiceTestSuite) TestSafetyCriticalStaysLocal() {
+	process := suite.process("critical")
+	process.SafetyCritical = true
+
+	result, err := suite.service.Submit(context.Background(), process)
+	require.NoError(suite.T(), err)
+
+	if result.Decision.ShouldOffload {
+		require.NotNil(suite.T(), result.Decision.Target)
+		assert.Equal(suite.T(), models.LOCAL, result.Decision.Target.Type)
+	}
+	assert.NotEqual(suite.T(), "edge-1", suite.executed[process.ID])
+	assert.NotEqual(suite.T(), "cloud-1", suite.executed[process.ID])
+}
+
+func (suite *EmbeddedServiceTestSuite) TestExecutionErrorRecordedAsFailure() {
+	suite.failWith = errors.New("executor unavailable")
+
+	result, err := suite.service.Submit(context.Background(), suite.process("failing"))
+	require.Error(suite.T(), err)
+	assert.False(suite.T(), result.Outcome.Success)
+	assert.Equal(suite.T(), "executor unavailable", result.Outcome.ErrorType)
+	assert.Equal(suite.T(), 1, suite.service.Algorithm().GetPerformanceMetrics().LearningProgress.DecisionCount)
+}
+
+func (suite *EmbeddedServiceTestSuite) TestRemovedTargetsAreNotChosen() {
+	suite.metrics.Fleet = suite.metrics.Fleet[:1]
+
+	for i := 0; i < 5; i++ {
+		process := suite.process(fmt.Sprintf("process-%d", i))
+		_, err := suite.service.Submit(context.Background(), process)
+		require.NoError(suite.T(), err)
+		assert.Contains(suite.T(), []string{"local", "local-1"}, suite.executed[process.ID])
+	}
+	assert.Equal(suite.T(), 1, suite.service.Algorithm().TargetRegistry().Len())
+}
+
+func (suite *EmbeddedServiceTestSuite) TestRetryOnDifferentTarget() {
+	require.NoError(suite.T(), suite.service.SetRetryPolicy(policy.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}))
+	suite.failures = 1
+
+	result, err := suite.service.Submit(context.Background(), suite.process("retried"))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.Attempts)
+	require.Len(suite.T(), suite.attempts, 2)
+	if suite.attempts[0] != "local" {
+		assert.NotEqual(suite.T(), suite.attempts[0], suite.attempts[1], "The failed target is avoided")
+	}
+
+	stats := suite.service.Stats()
+	assert.Equal(suite.T(), embedded.RetryStats{Submitted: 1, Succeeded: 1, Retries: 1, Recovered: 1}, stats)
+	assert.Equal(suite.T(), 2, suite.service.Algorithm().GetPerformanceMetrics().LearningProgress.DecisionCount,
+		"Every attempt is learned from")
+}
+
+func (suite *EmbeddedServiceTestSuite) TestRetryAvoidsFailedClass() {
+	suite.metrics.Fleet = append(suite.metrics.Fleet,
+		suite.target("edge-2", models.EDGE, 5*time.Millisecond, time.Now()))
+	require.NoError(suite.T(), suite.service.SetRetryPolicy(policy.RetryPolicy{
+		MaxRetries: 1, BaseDelay: time.Millisecond, AvoidFailedClass: true,
+	}))
+	suite.failures = 1
+
+	_, err := suite.service.Submit(context.Background(), suite.process("class"))
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), suite.attempts, 2)
+	registry := suite.service.Algorithm().TargetRegistry()
+	first, offloaded := registry.Get(suite.attempts[0])
+	second, retried := registry.Get(suite.attempts[1])
+	if offloaded && retried {
+		assert.NotEqual(suite.T(), first.Type, second.Type)
+	}
+}
+
+func (suite *EmbeddedServiceTestSuite) TestDeadLetterAfterRetries() {
+	require.NoError(suite.T(), suite.service.SetRetryPolicy(policy.RetryPolicy{
+		MaxRetries: 2, BaseDelay: time.Millisecond, LocalFallback: true,
+	}))
+	suite.failWith = errors.New("executor unavailable")
+
+	result, err := suite.service.Submit(context.Background(), suite.process("doomed"))
+	require.Error(suite.T(), err)
+	assert.Equal(suite.T(), 3, result.Attempts)
+
+	deadLetters := suite.service.DeadLetters()
+	require.Len(suite.T(), deadLetters, 1)
+	assert.Equal(suite.T(), "doomed", deadLetters[0].Process.ID)
+	assert.Equal(suite.T(), 3, deadLetters[0].Attempts)
+	assert.ErrorContains(suite.T(), deadLetters[0].LastError, "executor unavailable")
+	assert.Equal(suite.T(), 1, suite.service.Stats().DeadLettered)
+
+	assert.Error(suite.T(), suite.service.SetRetryPolicy(policy.RetryPolicy{Backoff: "random"}))
+}
+
+func TestEmbeddedServiceSuite(t *testing.T) {
+	suite.Run(t, new(EmbeddedServiceTestSuite))
+}
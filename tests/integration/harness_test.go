@@ -0,0 +1,52 @@
+package integration_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/examples/embedded"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/tests/harness"
+)
+
+// Harness test requirements:
+// 1. Scripted scenarios must drive decisions, executions and learning in order
+// 2. Fleet, queue and failure changes must take effect for later submissions
+
+func TestHarnessScenario(t *testing.T) {
+	config := embedded.DefaultConfig()
+	config.Logger = nil
+	h := harness.New(t, config)
+	require.NoError(t, h.Service().SetRetryPolicy(policy.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}))
+
+	h.Run(
+		harness.Submit(harness.Process("a")),
+		harness.FailTarget("local-1", 1),
+		harness.Submit(harness.Process("b")),
+		harness.RemoveTarget("local-1"),
+		harness.Submit(harness.Processes("p", 3)...),
+		harness.SetQueue(2, 20),
+		harness.Submit(harness.Process("q")),
+	)
+
+	h.ExpectNoErrors()
+	h.ExpectPlacements("local-1", "edge-1", "edge-1", "edge-1", "edge-1", "local")
+	h.ExpectNeverOn("cloud-1")
+
+	executions := h.Executions()
+	require.Len(t, executions, 7, "b is retried once")
+	assert.Equal(t, harness.Execution{ProcessID: "b", TargetID: "local-1", Failed: true}, executions[1])
+	assert.Equal(t, 2, h.Submissions()[1].Result.Attempts)
+
+	h.ExpectMetrics(func(metrics algorithm.PerformanceMetrics) error {
+		if metrics.LearningProgress.DecisionCount != 7 {
+			return errors.New("every attempt must be learned from")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,1566 @@
+package algorithm_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/events"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/telemetry"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/tenancy"
+)
+
+// Algorithm test requirements:
+// 1. Every decision must carry an ID that can be explained afterwards
+// 2. Explanations must cover objective contributions, policy evaluations,
+//    data gravity and prediction inputs
+// 3. Every rejected candidate must have a counterfactual reason
+// 4. Explanations must be recorded in the audit log when audit logs are enabled
+// 5. Decision latency must be broken down by phase and aggregated in stats
+// 6. Offloads must stay within the cost budget, downgrading to cheaper targets
+// 7. Offloads beyond a tenant's quota must stay local until usage is released,
+//    including by decisions dropped without an outcome
+// 8. Replay must reproduce logged rewards for an identical configuration and
+//    reweight them when the candidate configuration decides differently
+// 9. Shadow decisions must be logged and compared without affecting live ones
+// 10. Canaried weights must not replace the stable weights until promoted
+// 11. Targets whose heartbeats report errors must lose placements
+// 12. Data gravity learned from outcomes must scale data size in scoring
+// 13. Recurring processes must be submitted once per run and pre-planned on
+//     the targets expected to take them
+// 14. Sampled strategies must decide with their weights and learn from outcomes
+// 15. Dry-run evaluations must rank every target like a decision would,
+//     without changing decision, policy or learning state
+// 16. Registered targets with persistently high heartbeat load must be
+//     recommended a larger size class
+// 17. Draining targets must take no new processes and be removed only once
+//     their active processes finish or the drain times out; idle targets
+//     must be reported for draining
+// 18. Offloads must fail over to the next ranked region when the preferred
+//     region has no healthy, unsaturated target, and stay local when none has
+// 19. Heartbeat load must be forecast per target, with predictor accuracy by
+//     target type reported in the performance metrics
+// 20. Queue spikes must be detected after the confirmation samples and get a
+//     post-mortem with their lag, pre-scale lead, peak and attributed outcomes
+// 21. Decision explanations must be bounded by History.Size, with evicted
+//     explanations spilled to the configured file; decisions awaiting
+//     outcomes must be bounded by it too, expire after History.PendingTTL
+//     even without new decisions, and be counted when dropped
+// 22. Decision statistics must weight every decision and outcome equally over
+//     the lifetime, and count only recent ones in the 5m, 1h and 24h windows
+// 23. Forecasts must predict the cost, latency and energy cost of a process or
+//     workflow on every target without making a decision
+// 24. Telemetry readings must update registered targets' runtime state and
+//     feed their load to heartbeat consumers, and be refused for unknown targets
+// 25. Decisions and outcomes must be published as events, and outcomes
+//     consumed from the event bus must be processed like reported ones
+// 26. Replaying the journal after a crash must restore learned weights,
+//     statistics and the offloads awaiting outcomes, discarding a torn final
+//     record
+// 27. Repeated calls of idempotent functions with the same arguments and input
+//     must be answered from the result cache until their result expires
+// 28. Targets must be registered from the executor catalog, and every decision
+//     and its explanation must record the catalog version it was made against
+// 29. Committed offloads must reserve target capacity until their outcomes,
+//     and the reserved capacity must keep targets from being downsized
+// 30. Decisions over a large fleet must stay near MaxDecisionLatency in every
+//     phase, explaining only the targets examined within it
+// 31. A configuration that fails part way through construction must not leave
+//     the log, audit or spill files opened before the failure open
+// 32. A configured clock must time decisions, quota and budget windows and
+//     the expiry of decisions awaiting outcomes, while decision latency is
+//     still measured on the wall clock
+
+type AlgorithmTestSuite struct {
+	suite.Suite
+	config  algorithm.Config
+	state   models.SystemState
+	targets []models.OffloadTarget
+}
+
+func (suite *AlgorithmTestSuite) SetupTest() {
+	suite.config = algorithm.Config{
+		InitialWeights: decision.AdaptiveWeights{
+			QueueDepth:    0.2,
+			ProcessorLoad: 0.2,
+			NetworkCost:   0.2,
+			LatencyCost:   0.2,
+			EnergyCost:    0.1,
+			PolicyCost:    0.1,
+		},
+		LearningConfig: learning.LearningConfig{
+			WindowSize:   100,
+			LearningRate: 0.01,
+			MinSamples:   10,
+		},
+		SafetyConstraints: policy.SafetyConstraints{
+			MinLocalCompute:       0.2,
+			MinLocalMemory:        0.2,
+			MaxConcurrentOffloads: 10,
+			MaxLatencyTolerance:   500 * time.Millisecond,
+			MinReliability:        0.5,
+		},
+		PerformanceTargets: algorithm.PerformanceTargets{
+			MaxDecisionLatency: 500 * time.Millisecond,
+		},
+	}
+
+	suite.state = models.SystemState{
+		QueueDepth:     25,
+		QueueThreshold: 20,
+		ComputeUsage:   0.75,
+		MemoryUsage:    0.60,
+		NetworkUsage:   0.40,
+		MasterUsage:    0.30,
+		Timestamp:      time.Now(),
+		TimeSlot:       12,
+		DayOfWeek:      3,
+	}
+
+	suite.targets = []models.OffloadTarget{
+		suite.target("edge-fast", models.EDGE, 10*time.Millisecond, 5),
+		suite.target("cloud-slow", models.PUBLIC_CLOUD, 80*time.Millisecond, 5),
+		suite.target("edge-insecure", models.EDGE, 10*time.Millisecond, 1),
+	}
+}
+
+func (suite *AlgorithmTestSuite) target(id string, targetType models.TargetType, latency time.Duration, security int) models.OffloadTarget {
+	return models.OffloadTarget{
+		ID:                id,
+		Type:              targetType,
+		TotalCapacity:     8.0,
+		AvailableCapacity: 6.0,
+		MemoryTotal:       16 * 1024 * 1024 * 1024,
+		MemoryAvailable:   10 * 1024 * 1024 * 1024,
+		NetworkLatency:    latency,
+		NetworkBandwidth:  10 * 1024 * 1024,
+		NetworkStability:  0.95,
+		ProcessingSpeed:   1.0,
+		Reliability:       0.95,
+		ComputeCost:       0.10,
+		SecurityLevel:     security,
+		LastSeen:          time.Now(),
+	}
+}
+
+func (suite *AlgorithmTestSuite) process(id string) models.Process {
+	return models.Process{
+		ID:                id,
+		CPURequirement:    2.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         20 * 1024 * 1024,
+		OutputSize:        1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		SecurityLevel:     3,
+		Status:            models.QUEUED,
+	}
+}
+
+func (suite *AlgorithmTestSuite) TestExplainDecision() {
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	dec, err := alg.MakeOffloadDecision(suite.process("explain-1"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	require.NotEmpty(suite.T(), dec.DecisionID)
+
+	explanation, err := alg.Explain(dec.DecisionID)
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "explain-1", explanation.ProcessID)
+	assert.Equal(suite.T(), dec.Target.ID, explanation.SelectedTargetID)
+	assert.Len(suite.T(), explanation.ObjectiveContributions, 6)
+	assert.Len(suite.T(), explanation.PolicyEvaluations, len(suite.targets))
+	assert.Equal(suite.T(), suite.state.QueueDepth, explanation.PredictionInputs.SystemState.QueueDepth)
+
+	require.NotNil(suite.T(), explanation.DataGravity)
+	assert.Equal(suite.T(), int64(21*1024*1024), explanation.DataGravity.DataSize)
+	assert.Greater(suite.T(), explanation.DataGravity.TransferTime, time.Duration(0))
+
+	require.Len(suite.T(), explanation.Candidates, len(suite.targets))
+	for _, candidate := range explanation.Candidates {
+		assert.NotEmpty(suite.T(), candidate.Counterfactual, "Candidate %s needs a reason", candidate.TargetID)
+		switch candidate.TargetID {
+		case dec.Target.ID:
+			assert.True(suite.T(), candidate.Selected)
+		case "edge-insecure":
+			assert.False(suite.T(), candidate.PolicyAllowed)
+			assert.Contains(suite.T(), candidate.Counterfactual, "rejected by policy")
+		default:
+			assert.Contains(suite.T(), candidate.Counterfactual, "below selected")
+		}
+	}
+
+	_, err = alg.Explain("decision_unknown")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AlgorithmTestSuite) TestExplanationInAuditLog() {
+	suite.config.MonitoringConfig.EnableAuditLogs = true
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	dec, err := alg.MakeOffloadDecision(suite.process("audit-1"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+
+	data, err := alg.ExplainJSON(dec.DecisionID)
+	require.NoError(suite.T(), err)
+
+	var decoded algorithm.DecisionExplanation
+	require.NoError(suite.T(), json.Unmarshal(data, &decoded))
+	assert.Equal(suite.T(), dec.DecisionID, decoded.DecisionID)
+
+	var found bool
+	for _, log := range alg.GetAuditLogs() {
+		if log.EventType == "decision_explanation" && log.Details["decision_id"] == dec.DecisionID {
+			found = true
+			assert.JSONEq(suite.T(), string(data), log.Details["explanation"].(string))
+		}
+	}
+	assert.True(suite.T(), found, "Explanation should be recorded in the audit log")
+}
+
+func (suite *AlgorithmTestSuite) TestPhaseTimings() {
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	const decisions = 5
+	for i := 0; i < decisions; i++ {
+		process := suite.process(fmt.Sprintf("phases-%d", i))
+		dec, err := alg.MakeOffloadDecision(process, suite.targets, suite.state)
+		require.NoError(suite.T(), err)
+
+		var total time.Duration
+		for _, duration := range dec.Phases.ByPhase() {
+			total += duration
+		}
+		assert.Greater(suite.T(), dec.Phases.Scoring, time.Duration(0))
+		assert.Greater(suite.T(), dec.Phases.PolicyEvaluation, time.Duration(0))
+		assert.LessOrEqual(suite.T(), dec.DecisionLatency, total,
+			"Engine latency is part of the phase breakdown")
+
+		require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+			ProcessID: process.ID,
+			TargetID:  dec.Target.ID,
+			Success:   true,
+			Reward:    0.5,
+		}))
+	}
+
+	stats := alg.GetPerformanceMetrics().PhaseStats
+	for _, phase := range []string{"state_snapshot", "feasibility_filter", "policy_evaluation", "scoring", "learner_update", "explanation"} {
+		require.Contains(suite.T(), stats, phase)
+		assert.Equal(suite.T(), int64(decisions), stats[phase].Count, phase)
+		assert.GreaterOrEqual(suite.T(), stats[phase].Max, stats[phase].Mean, phase)
+	}
+	assert.Equal(suite.T(), int64(decisions), stats[algorithm.PhaseOutcomeLearning].Count)
+}
+
+func (suite *AlgorithmTestSuite) TestCostBudget() {
+	pricey := suite.target("edge-pricey", models.EDGE, 5*time.Millisecond, 5)
+	pricey.ComputeCost = 100.0 // About 0.83 for a 30s process
+	cheap := suite.target("edge-cheap", models.EDGE, 40*time.Millisecond, 5)
+	targets := []models.OffloadTarget{pricey, cheap}
+
+	suite.config.Budget = policy.BudgetConfig{MaxCostPerHour: 0.5}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	dec, err := alg.MakeOffloadDecision(suite.process("budget-1"), targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), "edge-cheap", dec.Target.ID, "Targets beyond the budget are downgraded")
+	assert.InDelta(suite.T(), 0.5-dec.EstimatedCost, alg.RemainingBudget(), 1e-9)
+
+	// Actual cost corrects the estimate
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+		DecisionID: dec.DecisionID,
+		ProcessID:  "budget-1",
+		TargetID:   dec.Target.ID,
+		Success:    true,
+		CostActual: 0.45,
+	}))
+	assert.InDelta(suite.T(), 0.05, alg.RemainingBudget(), 1e-9)
+
+	// Nothing affordable keeps the work local
+	expensive := suite.process("budget-2")
+	expensive.EstimatedDuration = time.Hour
+	dec, err = alg.MakeOffloadDecision(expensive, targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), dec.ShouldOffload)
+	assert.Contains(suite.T(), dec.PolicyViolations, "cost budget exceeded")
+
+	suite.config.Budget = policy.BudgetConfig{}
+	unlimited, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), math.IsInf(unlimited.RemainingBudget(), 1))
+}
+
+func (suite *AlgorithmTestSuite) TestTenantQuota() {
+	suite.config.TenantQuotas = map[string]tenancy.Quota{"team-a": {ConcurrentOffloads: 1}}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	first := suite.process("tenant-1")
+	first.TenantID = "team-a"
+	dec, err := alg.MakeOffloadDecision(first, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), 1, alg.Tenants().Usage("team-a").ActiveOffloads)
+
+	second := suite.process("tenant-2")
+	second.TenantID = "team-a"
+	blocked, err := alg.MakeOffloadDecision(second, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), blocked.ShouldOffload)
+	require.Len(suite.T(), blocked.PolicyViolations, 1)
+	assert.Contains(suite.T(), blocked.PolicyViolations[0], "tenant quota exceeded")
+
+	// Other tenants are unaffected
+	other := suite.process("tenant-3")
+	other.TenantID = "team-b"
+	dec, err = alg.MakeOffloadDecision(other, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), dec.ShouldOffload)
+
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+		ProcessID:     "tenant-1",
+		TargetID:      "edge-1",
+		Success:       true,
+		ExecutionTime: 20 * time.Second,
+	}))
+	assert.Equal(suite.T(), 0, alg.Tenants().Usage("team-a").ActiveOffloads)
+
+	dec, err = alg.MakeOffloadDecision(second, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), dec.ShouldOffload)
+
+	// A decision whose outcome is lost gives its slot back once it expires
+	suite.config.History.PendingTTL = time.Millisecond
+	expiring, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	dec, err = expiring.MakeOffloadDecision(first, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	time.Sleep(5 * time.Millisecond)
+	dec, err = expiring.MakeOffloadDecision(second, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), dec.ShouldOffload, "The expired decision no longer counts against the quota")
+	assert.Equal(suite.T(), 1, expiring.Tenants().Usage("team-a").ActiveOffloads)
+	assert.Equal(suite.T(), int64(1), expiring.PendingStats().Expired)
+}
+
+func (suite *AlgorithmTestSuite) TestCounterfactualReplay() {
+	suite.config.ReplayLogSize = 3
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	for i := 0; i < 4; i++ {
+		process := suite.process(fmt.Sprintf("replay-%d", i))
+		dec, err := alg.MakeOffloadDecision(process, suite.targets, suite.state)
+		require.NoError(suite.T(), err)
+		require.True(suite.T(), dec.ShouldOffload)
+		require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+			DecisionID: dec.DecisionID,
+			ProcessID:  process.ID,
+			TargetID:   dec.Target.ID,
+			Success:    true,
+			Reward:     0.8,
+		}))
+	}
+
+	records := alg.ReplayLog()
+	require.Len(suite.T(), records, 3, "The replay log keeps only the most recent decisions")
+	assert.Equal(suite.T(), "replay-1", records[0].Process.ID)
+	assert.Equal(suite.T(), "edge-fast", records[0].Explanation.SelectedTargetID)
+
+	// The logged configuration reproduces the logged decisions and rewards
+	suite.config.ReplayLogSize = 0
+	report, err := algorithm.Replay(suite.config, records, algorithm.ReplayConfig{})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, report.Records)
+	assert.Equal(suite.T(), 1.0, report.Agreement)
+	assert.InDelta(suite.T(), 0.8, report.LoggedReward, 1e-9)
+	assert.InDelta(suite.T(), 0.8, report.EstimatedReward, 1e-9)
+	assert.InDelta(suite.T(), 3.0, report.EffectiveSampleSize, 1e-3)
+	assert.InDelta(suite.T(), 0.0, report.Improvement(), 1e-9)
+
+	// A candidate whose budget keeps work local never takes the logged action
+	suite.config.Budget = policy.BudgetConfig{MaxCostPerHour: 0.0001}
+	report, err = algorithm.Replay(suite.config, records, algorithm.ReplayConfig{})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0.0, report.Agreement)
+	assert.Equal(suite.T(), 0.0, report.EffectiveSampleSize, "No logged action has support under the candidate")
+
+	suite.config.InitialWeights.QueueDepth = 0.9
+	_, err = algorithm.Replay(suite.config, records, algorithm.ReplayConfig{})
+	assert.Error(suite.T(), err, "Invalid candidate configurations are rejected")
+}
+
+func (suite *AlgorithmTestSuite) TestShadowMode() {
+	live, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	// The candidate can only afford to keep work local
+	candidate := suite.config
+	candidate.Budget = policy.BudgetConfig{MaxCostPerHour: 0.0001}
+	shadow, err := algorithm.NewShadow(live, candidate, 2)
+	require.NoError(suite.T(), err)
+	defer shadow.Close()
+
+	for i := 0; i < 3; i++ {
+		process := suite.process(fmt.Sprintf("shadow-%d", i))
+		dec, err := shadow.MakeOffloadDecision(process, suite.targets, suite.state)
+		require.NoError(suite.T(), err)
+		require.True(suite.T(), dec.ShouldOffload, "The live decision is returned")
+		assert.Equal(suite.T(), "edge-fast", dec.Target.ID)
+	}
+	require.NoError(suite.T(), shadow.ProcessOutcome(decision.OffloadOutcome{
+		ProcessID: "shadow-2",
+		TargetID:  "edge-fast",
+		Success:   true,
+		Reward:    0.6,
+	}))
+
+	comparisons := shadow.Comparisons()
+	require.Len(suite.T(), comparisons, 2, "Only the most recent comparisons are kept")
+	assert.Equal(suite.T(), "shadow-1", comparisons[0].ProcessID)
+	assert.Equal(suite.T(), "local", comparisons[0].Shadow.TargetID)
+	assert.Nil(suite.T(), comparisons[0].Outcome)
+	require.NotNil(suite.T(), comparisons[1].Outcome)
+
+	report := shadow.Report()
+	assert.Equal(suite.T(), 2, report.Decisions)
+	assert.Equal(suite.T(), 1.0, report.DivergenceRate)
+	assert.Equal(suite.T(), map[string]int{"edge-fast->local": 2}, report.Transitions)
+	assert.Greater(suite.T(), report.LiveEstimatedCost, report.ShadowEstimatedCost)
+	assert.InDelta(suite.T(), 0.6, report.DivergedMeanReward, 1e-9)
+
+	_, err = algorithm.NewShadow(live, algorithm.Config{}, 0)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AlgorithmTestSuite) TestCanaryWeightRollout() {
+	suite.config.LearningConfig.Canary = learning.CanaryConfig{Enabled: true, Fraction: 0.5, Window: 2}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	initial := alg.GetPerformanceMetrics().CurrentWeights
+
+	for i := 0; i < 40 && len(alg.GetCanaryEvents()) == 0; i++ {
+		process := suite.process(fmt.Sprintf("canary-%d", i))
+		dec, err := alg.MakeOffloadDecision(process, suite.targets, suite.state)
+		require.NoError(suite.T(), err)
+		require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+			DecisionID:      dec.DecisionID,
+			ProcessID:       process.ID,
+			TargetID:        dec.Target.ID,
+			Success:         true,
+			CompletedOnTime: true,
+			Reward:          0.9,
+		}))
+		if len(alg.GetCanaryEvents()) == 0 {
+			assert.Equal(suite.T(), initial, alg.GetPerformanceMetrics().CurrentWeights, "Stable weights hold during the trial")
+		}
+	}
+
+	events := alg.GetCanaryEvents()
+	require.Len(suite.T(), events, 1)
+	assert.Equal(suite.T(), learning.CANARY_PROMOTED, events[0].Action)
+	assert.NotEqual(suite.T(), initial, alg.GetPerformanceMetrics().CurrentWeights)
+}
+
+func (suite *AlgorithmTestSuite) TestHeartbeatHealth() {
+	suite.config.Health = learning.HealthConfig{Enabled: true}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	healthy, err := alg.MakeOffloadDecision(suite.process("before"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), healthy.ShouldOffload)
+	selected := healthy.Target.ID
+
+	for i := 0; i < 5; i++ {
+		require.NoError(suite.T(), alg.RecordHeartbeat(learning.Heartbeat{
+			TargetID:  selected,
+			Timestamp: time.Now(),
+			Latency:   10 * time.Millisecond,
+			Requests:  10,
+			Errors:    8,
+		}))
+	}
+	health := alg.GetTargetHealth()
+	require.Len(suite.T(), health, 1)
+	assert.InDelta(suite.T(), 0.2, health[0].Score, 1e-9)
+
+	degraded, err := alg.MakeOffloadDecision(suite.process("after"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	if degraded.ShouldOffload {
+		assert.NotEqual(suite.T(), selected, degraded.Target.ID, "Failing target is no longer selected")
+	}
+}
+
+func (suite *AlgorithmTestSuite) TestDataGravityLearning() {
+	suite.config.DataGravity = learning.GravityConfig{Enabled: true, MinSamples: 5, Decay: 1}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	// Every GB moved adds 40s, five times the prior
+	observed := 0
+	for i := 0; i < 40 && observed < 10; i++ {
+		process := suite.process(fmt.Sprintf("gravity-%d", i))
+		process.InputSize = int64(i%5+1) * 20 * 1024 * 1024
+		dec, err := alg.MakeOffloadDecision(process, suite.targets, suite.state)
+		require.NoError(suite.T(), err)
+		if !dec.ShouldOffload {
+			continue
+		}
+		gb := float64(dec.DataSize) / (1024 * 1024 * 1024)
+		require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+			DecisionID:    dec.DecisionID,
+			ProcessID:     process.ID,
+			TargetID:      dec.Target.ID,
+			Success:       true,
+			ExecutionTime: time.Duration((30 + 40*gb) * float64(time.Second)),
+		}))
+		observed++
+	}
+	require.Equal(suite.T(), 10, observed)
+
+	gravity := alg.GetDataGravity()
+	require.NotEmpty(suite.T(), gravity)
+	assert.InDelta(suite.T(), 40, gravity[0].SecondsPerGB, 1e-6)
+	assert.Greater(suite.T(), gravity[0].Factor, 1.0)
+
+	dec, err := alg.MakeOffloadDecision(suite.process("gravity-check"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	explanation, err := alg.Explain(dec.DecisionID)
+	require.NoError(suite.T(), err)
+	scored := 0
+	for _, candidate := range explanation.Candidates {
+		if candidate.Scored {
+			assert.Equal(suite.T(), gravity[0].Factor, candidate.DataGravity.GravityFactor)
+			scored++
+		}
+	}
+	assert.Positive(suite.T(), scored)
+}
+
+func (suite *AlgorithmTestSuite) TestRecurringProcesses() {
+	template := suite.process("")
+	template.EstimatedDuration = 90 * time.Minute
+	suite.config.Recurring = []models.RecurringProcess{{ID: "nightly-etl", Schedule: "@hourly", Template: template}}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	now := time.Now()
+	assert.Empty(suite.T(), alg.DueRecurring(now.Add(-time.Minute)))
+	due := alg.DueRecurring(now.Add(2 * time.Hour))
+	require.Len(suite.T(), due, 2, "One run per hour boundary")
+	assert.True(suite.T(), due[0].SubmissionTime.Before(due[1].SubmissionTime))
+	assert.Contains(suite.T(), due[0].ID, "nightly-etl@")
+	assert.Empty(suite.T(), alg.DueRecurring(now.Add(2*time.Hour)), "Runs are submitted once")
+
+	// Four hourly runs of 90 minutes: two overlap at any time
+	from := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+	plan := alg.PlanRecurring(suite.targets, suite.state, from, from.Add(4*time.Hour))
+	require.Len(suite.T(), plan.Runs, 4)
+	require.Len(suite.T(), plan.Reservations, 1)
+	reservation := plan.Reservations[0]
+	assert.Equal(suite.T(), plan.Runs[0].TargetID, reservation.TargetID)
+	assert.NotEqual(suite.T(), "edge-insecure", reservation.TargetID, "Runs are planned on viable targets")
+	assert.Equal(suite.T(), 4, reservation.Runs)
+	assert.Equal(suite.T(), 2*template.CPURequirement, reservation.PeakCPU)
+	assert.Equal(suite.T(), 2*template.MemoryRequirement, reservation.PeakMemory)
+	assert.Equal(suite.T(), time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC), reservation.PeakAt)
+
+	alg.RemoveRecurring("nightly-etl")
+	assert.Empty(suite.T(), alg.DueRecurring(now.Add(5*time.Hour)))
+
+	suite.config.Recurring = []models.RecurringProcess{{ID: "bad", Schedule: "61 * * * *", Template: template}}
+	_, err = algorithm.NewAlgorithm(suite.config)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AlgorithmTestSuite) TestStrategySampling() {
+	suite.config.Strategies = learning.StrategyConfig{
+		Enabled: true,
+		Seed:    1,
+		Strategies: []learning.StrategyProfile{
+			{Name: "latency-first", Weights: decision.AdaptiveWeights{LatencyCost: 1}},
+		},
+	}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	dec, err := alg.MakeOffloadDecision(suite.process("strategy-1"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1.0, dec.ScoreComponents.WeightsUsed.LatencyCost, "Decided with the strategy's weights")
+	assert.Equal(suite.T(), suite.config.InitialWeights, alg.GetPerformanceMetrics().CurrentWeights,
+		"Learned weights are restored after the decision")
+
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+		DecisionID:      dec.DecisionID,
+		ProcessID:       "strategy-1",
+		Success:         true,
+		CompletedOnTime: true,
+	}))
+	stats := alg.GetStrategyStats()
+	require.Len(suite.T(), stats, 1)
+	assert.Equal(suite.T(), 1, stats[0].Successes)
+}
+
+func (suite *AlgorithmTestSuite) TestEvaluateOnly() {
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	evaluation, err := alg.EvaluateOnly(suite.process("whatif-1"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), evaluation.Targets, len(suite.targets), "Every target is evaluated")
+	assert.True(suite.T(), evaluation.Safe)
+
+	for i, target := range evaluation.Targets {
+		assert.Equal(suite.T(), i+1, target.Rank)
+		assert.NotEmpty(suite.T(), target.Contributions)
+		assert.Equal(suite.T(), target.TargetID, target.Policy.TargetID)
+		assert.Greater(suite.T(), target.Predicted.ExecutionTime, time.Duration(0))
+		assert.Greater(suite.T(), target.Predicted.EstimatedCost, 0.0)
+	}
+	assert.True(suite.T(), evaluation.Targets[0].Eligible)
+	last := evaluation.Targets[len(evaluation.Targets)-1]
+	assert.Equal(suite.T(), "edge-insecure", last.TargetID, "Ineligible targets rank last")
+	assert.False(suite.T(), last.Eligible)
+
+	metrics := alg.GetPerformanceMetrics()
+	assert.Zero(suite.T(), metrics.DecisionCount, "No decision is committed")
+	assert.Zero(suite.T(), metrics.PolicyStats.TotalEvaluations, "Policy statistics are not recorded")
+
+	dec, err := alg.MakeOffloadDecision(suite.process("whatif-1"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), dec.Target.ID, evaluation.Recommended, "The recommendation matches the decision")
+	assert.Equal(suite.T(), dec.EstimatedCost, evaluation.Targets[0].Predicted.EstimatedCost)
+}
+
+func (suite *AlgorithmTestSuite) TestResizeRecommendations() {
+	suite.config.Resize = learning.ResizeConfig{
+		Enabled: true,
+		Ladder: []learning.SizeClass{
+			{Name: "8-core", CPU: 8, Memory: 16 << 30, CostPerHour: 0.4},
+			{Name: "16-core", CPU: 16, Memory: 32 << 30, CostPerHour: 0.8},
+		},
+		Sustain: time.Nanosecond,
+	}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	alg.TargetRegistry().Upsert(suite.targets[0])
+
+	start := time.Now().Add(-time.Minute)
+	for i := 0; i < 3; i++ {
+		require.NoError(suite.T(), alg.RecordHeartbeat(learning.Heartbeat{
+			TargetID:   suite.targets[0].ID,
+			Timestamp:  start.Add(time.Duration(i) * time.Second),
+			ActualLoad: 0.95,
+		}))
+	}
+
+	recommendations := alg.ResizeRecommendations()
+	require.Len(suite.T(), recommendations, 1)
+	assert.Equal(suite.T(), "16-core", recommendations[0].To)
+	require.NotNil(suite.T(), alg.ResizeStats())
+	assert.Equal(suite.T(), learning.RULE_BASED, alg.ResizeStats().Mode)
+
+	suite.config.Resize.Enabled = false
+	disabled, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), disabled.ResizeRecommendations())
+	assert.Nil(suite.T(), disabled.ResizeStats())
+}
+
+func (suite *AlgorithmTestSuite) TestDrainTargets() {
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	for _, target := range suite.targets {
+		alg.TargetRegistry().Upsert(target)
+	}
+
+	dec, err := alg.MakeOffloadDecision(suite.process("drain-1"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	busy := dec.Target.ID
+
+	alg.DrainTarget(busy)
+	next, err := alg.MakeOffloadDecision(suite.process("drain-2"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	if next.ShouldOffload {
+		assert.NotEqual(suite.T(), busy, next.Target.ID, "Draining targets take no new processes")
+	}
+
+	statuses := alg.DrainStatuses()
+	require.Len(suite.T(), statuses, 1)
+	assert.Equal(suite.T(), []string{"drain-1"}, statuses[0].ActiveProcesses)
+	assert.False(suite.T(), statuses[0].Removable())
+	assert.Empty(suite.T(), alg.RemoveDrained(), "Active processes keep the target")
+
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+		DecisionID: dec.DecisionID,
+		ProcessID:  "drain-1",
+		TargetID:   busy,
+		Success:    true,
+	}))
+	removed := alg.RemoveDrained()
+	require.Len(suite.T(), removed, 1)
+	assert.True(suite.T(), removed[0].Drained)
+	_, registered := alg.TargetRegistry().Get(busy)
+	assert.False(suite.T(), registered)
+	assert.Empty(suite.T(), alg.DrainStatuses())
+}
+
+func (suite *AlgorithmTestSuite) TestDrainTimeoutAndIdleTargets() {
+	suite.config.Drain = algorithm.DrainConfig{IdleTimeout: time.Nanosecond, DrainTimeout: time.Nanosecond}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	for _, target := range suite.targets {
+		alg.TargetRegistry().Upsert(target)
+	}
+
+	assert.Empty(suite.T(), alg.IdleTargets(), "Idle clocks start when targets are first seen")
+	dec, err := alg.MakeOffloadDecision(suite.process("stuck-1"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	time.Sleep(time.Millisecond)
+
+	idle := alg.IdleTargets()
+	assert.NotContains(suite.T(), idle, dec.Target.ID, "Targets with active processes are not idle")
+	assert.Len(suite.T(), idle, len(suite.targets)-1)
+
+	alg.DrainTarget(dec.Target.ID)
+	time.Sleep(time.Millisecond)
+	removed := alg.RemoveDrained()
+	require.Len(suite.T(), removed, 1)
+	assert.True(suite.T(), removed[0].TimedOut)
+	assert.Equal(suite.T(), []string{"stuck-1"}, removed[0].ActiveProcesses, "Abandoned processes are reported for resubmission")
+}
+
+func (suite *AlgorithmTestSuite) TestRegionFailover() {
+	suite.config.Failover = policy.FailoverConfig{Enabled: true, Regions: []string{"eu-north", "eu-west"}}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	targets := append([]models.OffloadTarget(nil), suite.targets...)
+	targets[0].Location = "eu-north"
+	targets[1].Location = "eu-west"
+	targets[2].Location = "eu-north"
+
+	dec, err := alg.MakeOffloadDecision(suite.process("region-1"), targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), "eu-north", dec.Target.Location)
+	assert.Empty(suite.T(), alg.FailoverEvents())
+
+	targets[0].CurrentLoad = 0.95
+	targets[2].CurrentLoad = 0.95
+	dec, err = alg.MakeOffloadDecision(suite.process("region-2"), targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), "cloud-slow", dec.Target.ID)
+
+	targets[1].Reliability = 0.1
+	dec, err = alg.MakeOffloadDecision(suite.process("region-3"), targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), dec.ShouldOffload)
+
+	events := alg.FailoverEvents()
+	require.Len(suite.T(), events, 2)
+	assert.Equal(suite.T(), "eu-west", events[0].Region)
+	assert.Empty(suite.T(), events[1].Region)
+	stats := alg.FailoverStats()
+	require.NotNil(suite.T(), stats)
+	assert.Equal(suite.T(), 1, stats.Failovers)
+	assert.Equal(suite.T(), 1, stats.Exhausted)
+
+	suite.config.Failover.Enabled = false
+	disabled, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), disabled.FailoverStats())
+}
+
+func (suite *AlgorithmTestSuite) TestLoadForecasting() {
+	suite.config.Forecast = learning.ForecastConfig{Enabled: true, MinSamples: 3}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	alg.TargetRegistry().Upsert(suite.targets[0])
+
+	for i := 0; i < 5; i++ {
+		require.NoError(suite.T(), alg.RecordHeartbeat(learning.Heartbeat{
+			TargetID:   suite.targets[0].ID,
+			ActualLoad: 0.6,
+		}))
+	}
+	forecast, ok := alg.ForecastLoad(suite.targets[0].ID)
+	require.True(suite.T(), ok)
+	assert.InDelta(suite.T(), 0.6, forecast.Value, 1e-9)
+	_, ok = alg.ForecastLoad("unknown")
+	assert.False(suite.T(), ok)
+
+	accuracy := alg.GetPerformanceMetrics().ForecastAccuracy
+	require.NotEmpty(suite.T(), accuracy)
+	assert.Equal(suite.T(), string(models.EDGE), accuracy[0].ExecutorType)
+	assert.Equal(suite.T(), 4, accuracy[0].Samples)
+
+	suite.config.Forecast.Enabled = false
+	disabled, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), disabled.ForecastAccuracy())
+}
+
+func (suite *AlgorithmTestSuite) TestSpikePostMortem() {
+	suite.config.Spike = algorithm.SpikeConfig{Enabled: true, ConfirmSamples: 2}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	start := time.Now()
+	depths := []int{10, 10, 40, 50, 45, 10}
+	decisions := make([]decision.OffloadDecision, len(depths))
+	for i, depth := range depths {
+		targets := suite.targets[:2]
+		if i == 1 {
+			targets = suite.targets // Capacity added a minute before the spike
+		}
+		state := suite.state
+		state.QueueDepth, state.QueueThreshold = depth, 20
+		state.Timestamp = start.Add(time.Duration(i) * time.Minute)
+		decisions[i], err = alg.MakeOffloadDecision(suite.process(fmt.Sprintf("spike-%d", i)), targets, state)
+		require.NoError(suite.T(), err)
+	}
+
+	reports := alg.SpikeReports()
+	require.Len(suite.T(), reports, 1)
+	report := reports[0]
+	assert.Equal(suite.T(), start.Add(2*time.Minute), report.StartedAt)
+	assert.Equal(suite.T(), time.Minute, report.DetectionLag)
+	assert.Equal(suite.T(), time.Minute, report.PreScaleLead)
+	assert.Equal(suite.T(), 3*time.Minute, report.EndedAt.Sub(report.StartedAt))
+	assert.Equal(suite.T(), 50, report.PeakQueueDepth)
+	assert.Equal(suite.T(), 3, report.Processes)
+	assert.Equal(suite.T(), 3, report.PendingOutcomes)
+
+	for i := 2; i <= 3; i++ {
+		require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+			DecisionID:      decisions[i].DecisionID,
+			ProcessID:       fmt.Sprintf("spike-%d", i),
+			Success:         true,
+			CompletedOnTime: i == 2,
+			CostActual:      1.5,
+		}))
+	}
+	report = alg.SpikeReports()[0]
+	assert.Equal(suite.T(), 1, report.SLAViolations)
+	assert.Equal(suite.T(), 1, report.PendingOutcomes)
+	assert.InDelta(suite.T(), 3.0+decisions[4].EstimatedCost, report.Cost, 1e-9)
+
+	suite.config.Spike.Enabled = false
+	disabled, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), disabled.SpikeReports())
+}
+
+func (suite *AlgorithmTestSuite) TestDecisionHistory() {
+	spill := filepath.Join(suite.T().TempDir(), "explanations.jsonl")
+	suite.config.History = algorithm.HistoryConfig{Size: 3, SpillFile: spill}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		dec, err := alg.MakeOffloadDecision(suite.process(fmt.Sprintf("history-%d", i)), suite.targets, suite.state)
+		require.NoError(suite.T(), err)
+		ids = append(ids, dec.DecisionID)
+	}
+	for _, id := range ids[:2] {
+		_, err := alg.Explain(id)
+		assert.Error(suite.T(), err, "Decision %s is evicted", id)
+	}
+	for _, id := range ids[2:] {
+		_, err := alg.Explain(id)
+		assert.NoError(suite.T(), err)
+	}
+	pending := alg.PendingStats()
+	assert.Equal(suite.T(), 3, pending.Awaiting)
+	assert.Equal(suite.T(), int64(2), pending.Evicted, "Decisions whose outcomes never arrive are bounded")
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{ProcessID: "history-4", Success: true}))
+	assert.Equal(suite.T(), 2, alg.PendingStats().Awaiting)
+	require.NoError(suite.T(), alg.Close())
+
+	data, err := os.ReadFile(spill)
+	require.NoError(suite.T(), err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(suite.T(), lines, 2)
+	var spilled algorithm.DecisionExplanation
+	require.NoError(suite.T(), json.Unmarshal([]byte(lines[0]), &spilled))
+	assert.Equal(suite.T(), ids[0], spilled.DecisionID)
+
+	suite.config.History = algorithm.HistoryConfig{PendingTTL: 20 * time.Millisecond}
+	suite.config.Reservations.TTL = time.Hour
+	expiring, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	_, err = expiring.MakeOffloadDecision(suite.process("expiring-1"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	time.Sleep(30 * time.Millisecond)
+	_, err = expiring.MakeOffloadDecision(suite.process("expiring-2"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	pending = expiring.GetPerformanceMetrics().Pending
+	assert.Equal(suite.T(), 1, pending.Awaiting)
+	assert.Equal(suite.T(), int64(1), pending.Expired, "Decisions without outcomes expire after the pending TTL, not the reservation TTL")
+	time.Sleep(30 * time.Millisecond)
+	pending = expiring.PendingStats()
+	assert.Zero(suite.T(), pending.Awaiting, "Stats reads expire decisions without new ones")
+	assert.Equal(suite.T(), int64(2), pending.Expired)
+
+	process := suite.process("expiring-3")
+	process.TenantID = "team-a"
+	_, err = expiring.MakeOffloadDecision(process, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, expiring.Tenants().Usage("team-a").ActiveOffloads)
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(suite.T(), expiring.ProcessOutcome(decision.OffloadOutcome{ProcessID: "unknown", Success: true}))
+	assert.Zero(suite.T(), expiring.Tenants().Usage("team-a").ActiveOffloads, "Outcomes expire decisions too")
+	assert.Equal(suite.T(), int64(3), expiring.PendingStats().Expired)
+
+	suite.config.History = algorithm.HistoryConfig{Size: -1}
+	_, err = algorithm.NewAlgorithm(suite.config)
+	assert.Error(suite.T(), err)
+	suite.config.History = algorithm.HistoryConfig{PendingTTL: -time.Second}
+	_, err = algorithm.NewAlgorithm(suite.config)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AlgorithmTestSuite) TestDecisionStats() {
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	now := time.Now()
+	completed := []struct {
+		success bool
+		endTime time.Time
+	}{
+		{true, now.Add(-30 * time.Hour)},
+		{false, now.Add(-2 * time.Hour)},
+		{true, now.Add(-30 * time.Minute)},
+		{true, now},
+	}
+	for i, c := range completed {
+		process := suite.process(fmt.Sprintf("stats-%d", i))
+		dec, err := alg.MakeOffloadDecision(process, suite.targets, suite.state)
+		require.NoError(suite.T(), err)
+		require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+			ProcessID: process.ID,
+			TargetID:  dec.Target.ID,
+			Success:   c.success,
+			StartTime: c.endTime.Add(-time.Minute),
+			EndTime:   c.endTime,
+		}))
+	}
+
+	stats := alg.GetPerformanceMetrics().Stats
+	assert.Equal(suite.T(), int64(4), stats.Decisions)
+	assert.Equal(suite.T(), int64(4), stats.Offloads)
+	assert.Equal(suite.T(), int64(4), stats.Outcomes)
+	assert.InDelta(suite.T(), 0.75, stats.SuccessRate, 1e-9)
+	assert.Greater(suite.T(), stats.MeanDecisionTime, time.Duration(0))
+
+	// Starting from the first outcome, the recent rate moves a tenth of the
+	// way to each new one
+	recent := 1.0
+	for _, c := range completed[1:] {
+		success := 0.0
+		if c.success {
+			success = 1.0
+		}
+		recent += 0.1 * (success - recent)
+	}
+	assert.InDelta(suite.T(), recent, stats.RecentSuccessRate, 1e-9)
+
+	require.Contains(suite.T(), stats.Windows, "5m")
+	assert.Equal(suite.T(), int64(4), stats.Windows["5m"].Decisions, "Decisions count when they were made")
+	assert.Equal(suite.T(), int64(1), stats.Windows["5m"].Outcomes)
+	assert.Equal(suite.T(), int64(2), stats.Windows["1h"].Outcomes)
+	assert.Equal(suite.T(), int64(3), stats.Windows["24h"].Outcomes)
+	assert.InDelta(suite.T(), 2.0/3.0, stats.Windows["24h"].SuccessRate, 1e-9)
+	assert.InDelta(suite.T(), 4.0/300, stats.Windows["5m"].DecisionRate, 1e-9)
+}
+
+func (suite *AlgorithmTestSuite) TestForecast() {
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	targets := append([]models.OffloadTarget(nil), suite.targets...)
+	for i := range targets {
+		targets[i].EnergyCost = 0.5
+	}
+
+	process := suite.process("forecast-1")
+	forecast, err := alg.Forecast(process, targets, time.Hour)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), forecast.Targets, len(targets))
+	assert.WithinDuration(suite.T(), time.Now().Add(time.Hour), forecast.Start, time.Minute)
+
+	last := forecast.Targets[len(forecast.Targets)-1]
+	assert.Equal(suite.T(), "edge-insecure", last.TargetID, "Ineligible targets are ranked last")
+	assert.False(suite.T(), last.Eligible)
+	assert.NotEmpty(suite.T(), last.RejectionReason)
+
+	byID := make(map[string]models.OffloadTarget, len(targets))
+	for _, target := range targets {
+		byID[target.ID] = target
+	}
+	for _, report := range forecast.Targets {
+		target := byID[report.TargetID]
+		assert.InDelta(suite.T(), target.GetTotalCost(process), report.Cost, 1e-9, report.TargetID)
+		assert.InDelta(suite.T(), target.GetEnergyCost(process), report.EnergyCost, 1e-9, report.TargetID)
+		assert.Greater(suite.T(), report.EnergyCost, 0.0)
+		assert.Equal(suite.T(), target.EstimateExecutionTime(process), report.Latency, report.TargetID)
+	}
+	assert.LessOrEqual(suite.T(), forecast.Targets[0].Cost, forecast.Targets[1].Cost, "Eligible targets are ranked by cost")
+
+	// A diamond workflow runs its two middle stages side by side
+	workflow := suite.process("forecast-dag")
+	workflow.HasDAG = true
+	workflow.DAG = &models.DAG{
+		ID: "diamond",
+		Stages: []models.Stage{
+			{ID: "extract"},
+			{ID: "left", Dependencies: []string{"extract"}},
+			{ID: "right", Dependencies: []string{"extract"}},
+			{ID: "load", Dependencies: []string{"left", "right"}},
+		},
+	}
+	forecast, err = alg.Forecast(workflow, targets, 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "diamond", forecast.DAGID)
+	for _, report := range forecast.Targets {
+		require.Len(suite.T(), report.Stages, 4)
+		target := byID[report.TargetID]
+		stage := models.Stage{ID: "stage"}.ToProcess()
+		assert.True(suite.T(), report.Eligible, report.TargetID)
+		assert.InDelta(suite.T(), 4*target.GetTotalCost(stage), report.Cost, 1e-9, report.TargetID)
+		assert.Equal(suite.T(), 3*target.EstimateExecutionTime(stage), report.Latency, report.TargetID)
+		assert.Equal(suite.T(), "load", report.Stages[3].StageID)
+		assert.Equal(suite.T(), report.Latency, report.Stages[3].Finish)
+	}
+
+	_, err = alg.Forecast(process, targets, -time.Minute)
+	assert.Error(suite.T(), err)
+	assert.Zero(suite.T(), alg.GetStats().Decisions, "Forecasts make no decisions")
+}
+
+func (suite *AlgorithmTestSuite) TestApplyTelemetry() {
+	suite.config.Forecast = learning.ForecastConfig{Enabled: true, MinSamples: 1}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	alg.TargetRegistry().Upsert(suite.targets[0])
+
+	reported := time.Now().Add(time.Minute)
+	require.NoError(suite.T(), alg.ApplyTelemetry(telemetry.Reading{
+		TargetID:  suite.targets[0].ID,
+		Timestamp: reported,
+		Values: map[telemetry.Field]float64{
+			telemetry.FieldLoad:            0.7,
+			telemetry.FieldTemperature:     72,
+			telemetry.FieldBatteryLevel:    0.4,
+			telemetry.FieldMemoryAvailable: 2048,
+		},
+	}))
+
+	target, _ := alg.TargetRegistry().Get(suite.targets[0].ID)
+	assert.Equal(suite.T(), 0.7, target.CurrentLoad)
+	assert.Equal(suite.T(), 72.0, target.Temperature)
+	assert.Equal(suite.T(), int64(2048), target.MemoryAvailable)
+	assert.Equal(suite.T(), suite.targets[0].AvailableCapacity, target.AvailableCapacity, "Unreported fields are kept")
+	require.NotNil(suite.T(), target.Battery)
+	assert.Equal(suite.T(), 0.4, target.Battery.Level)
+	assert.True(suite.T(), target.LastSeen.Equal(reported))
+
+	forecast, ok := alg.ForecastLoad(suite.targets[0].ID)
+	require.True(suite.T(), ok)
+	assert.InDelta(suite.T(), 0.7, forecast.Value, 1e-9)
+
+	assert.Error(suite.T(), alg.ApplyTelemetry(telemetry.Reading{TargetID: "unknown"}))
+	_, enabled := alg.TelemetryStats()
+	assert.False(suite.T(), enabled)
+}
+
+func (suite *AlgorithmTestSuite) TestEvents() {
+	var mu sync.Mutex
+	published := make(map[string]int)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/topics/"):
+			var body struct {
+				Records []json.RawMessage `json:"records"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			published[strings.TrimPrefix(r.URL.Path, "/topics/")] += len(body.Records)
+			w.Write([]byte(`{"offsets": []}`))
+		case r.URL.Path == "/consumers/cape":
+			w.Write([]byte(`{"instance_id": "cape-1"}`))
+		case strings.HasSuffix(r.URL.Path, "/records"):
+			w.Write([]byte(`[{"value": {"process_id": "observed-1", "success": true}}]`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer proxy.Close()
+
+	suite.config.Events = events.Config{
+		Enabled:  true,
+		ProxyURL: proxy.URL,
+		Consumer: events.ConsumerConfig{Enabled: true},
+	}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	dec, err := alg.MakeOffloadDecision(suite.process("event-1"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{ProcessID: "event-1", DecisionID: dec.DecisionID, Success: true}))
+	stats, enabled := alg.EventStats()
+	require.True(suite.T(), enabled)
+	assert.Equal(suite.T(), 2, stats.Pending, "Events are queued, not published inline")
+
+	ingested, err := alg.IngestOutcomes(context.Background())
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, ingested)
+	assert.Equal(suite.T(), int64(2), alg.GetStats().Outcomes)
+
+	require.NoError(suite.T(), alg.Close())
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(suite.T(), map[string]int{"cape.decisions": 1, "cape.outcomes": 2}, published)
+}
+
+func (suite *AlgorithmTestSuite) TestJournalReplay() {
+	suite.config.Journal = algorithm.JournalConfig{Path: filepath.Join(suite.T().TempDir(), "journal.jsonl")}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	offloads := make([]string, 0)
+	for i := 0; i < 6; i++ {
+		process := suite.process(fmt.Sprintf("journal-%d", i))
+		dec, err := alg.MakeOffloadDecision(process, suite.targets, suite.state)
+		require.NoError(suite.T(), err)
+		if dec.ShouldOffload {
+			offloads = append(offloads, process.ID)
+		}
+	}
+	require.GreaterOrEqual(suite.T(), len(offloads), 2)
+	for _, processID := range offloads[1:] {
+		require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+			ProcessID: processID, TargetID: "edge-fast", Success: true, Reward: 0.8,
+		}))
+	}
+	before := alg.GetPerformanceMetrics()
+
+	// Crash without closing, mid-way through appending a record
+	file, err := os.OpenFile(suite.config.Journal.Path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(suite.T(), err)
+	_, err = file.WriteString(`{"kind":"outcome","time":"2024-`)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), file.Close())
+
+	restarted, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	defer restarted.Close()
+	replay, enabled := restarted.JournalReplay()
+	require.True(suite.T(), enabled)
+	assert.Equal(suite.T(), algorithm.JournalReplay{Decisions: 6, Outcomes: len(offloads) - 1, Pending: 1}, replay)
+
+	after := restarted.GetPerformanceMetrics()
+	assert.Equal(suite.T(), before.DecisionCount, after.DecisionCount)
+	assert.Equal(suite.T(), before.Stats.Decisions, after.Stats.Decisions)
+	assert.Equal(suite.T(), before.Stats.Outcomes, after.Stats.Outcomes)
+	assert.InDelta(suite.T(), before.CurrentWeights.QueueDepth, after.CurrentWeights.QueueDepth, 1e-9)
+	assert.InDelta(suite.T(), before.CurrentWeights.LatencyCost, after.CurrentWeights.LatencyCost, 1e-9)
+
+	// The offload that was awaiting its outcome still gets it, and the
+	// journal keeps appending after the discarded record
+	require.NoError(suite.T(), restarted.ProcessOutcome(decision.OffloadOutcome{ProcessID: offloads[0], Success: true}))
+	dec, err := restarted.MakeOffloadDecision(suite.process("journal-after"), suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "decision_7", dec.DecisionID)
+	require.NoError(suite.T(), restarted.Close())
+
+	again, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	defer again.Close()
+	replay, _ = again.JournalReplay()
+	assert.Equal(suite.T(), algorithm.JournalReplay{Decisions: 7, Outcomes: len(offloads), Pending: 1}, replay)
+}
+
+func (suite *AlgorithmTestSuite) TestMemo() {
+	suite.config.Memo = algorithm.MemoConfig{Enabled: true, Functions: []string{"echo"}}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	defer alg.Close()
+
+	call := func(id, funcName string, args ...string) decision.OffloadDecision {
+		process := suite.process(id)
+		process.FuncName = funcName
+		process.Args = args
+		process.InputHash = "sha256:ab12"
+		dec, err := alg.MakeOffloadDecision(process, suite.targets, suite.state)
+		require.NoError(suite.T(), err)
+		return dec
+	}
+
+	first := call("memo-1", "echo", "hello")
+	assert.NotEqual(suite.T(), decision.CACHED, first.Strategy)
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+		ProcessID: "memo-1", Success: true, Result: json.RawMessage(`"hello"`),
+	}))
+
+	repeated := call("memo-2", "echo", "hello")
+	assert.Equal(suite.T(), decision.CACHED, repeated.Strategy)
+	assert.False(suite.T(), repeated.ShouldOffload)
+	assert.JSONEq(suite.T(), `"hello"`, string(repeated.CachedResult))
+	assert.Equal(suite.T(), 1.0, repeated.ExpectedBenefit)
+	explanation, err := alg.Explain(repeated.DecisionID)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "result cached from an identical call", explanation.Reason)
+
+	// Other arguments, and functions not known to be idempotent, run
+	assert.NotEqual(suite.T(), decision.CACHED, call("memo-3", "echo", "world").Strategy)
+	call("memo-random", "random")
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+		ProcessID: "memo-random", Success: true, Result: json.RawMessage(`4`),
+	}))
+	assert.NotEqual(suite.T(), decision.CACHED, call("memo-random-2", "random").Strategy)
+
+	// Results expire after the TTL
+	call("memo-4", "echo", "stale")
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{
+		ProcessID: "memo-4", Success: true, Result: json.RawMessage(`"stale"`), EndTime: time.Now().Add(-2 * time.Hour),
+	}))
+	assert.NotEqual(suite.T(), decision.CACHED, call("memo-5", "echo", "stale").Strategy)
+
+	stats, enabled := alg.MemoStats()
+	require.True(suite.T(), enabled)
+	assert.Equal(suite.T(), int64(1), stats.Hits)
+	assert.Equal(suite.T(), int64(4), stats.Misses)
+	assert.Equal(suite.T(), int64(1), stats.Expired)
+	assert.Equal(suite.T(), 1, stats.Entries)
+	assert.Equal(suite.T(), 30*time.Second, stats.TimeSaved)
+	assert.InDelta(suite.T(), 0.2, stats.HitRate, 1e-9)
+	assert.InDelta(suite.T(), 0.25, stats.ThroughputGain, 1e-9)
+	assert.Equal(suite.T(), &stats, alg.GetPerformanceMetrics().Memo)
+}
+
+func (suite *AlgorithmTestSuite) TestExecutorCatalog() {
+	path := filepath.Join(suite.T().TempDir(), "catalog.json")
+	write := func(catalog decision.ExecutorCatalog) {
+		data, err := json.Marshal(catalog)
+		require.NoError(suite.T(), err)
+		require.NoError(suite.T(), os.WriteFile(path, data, 0o644))
+	}
+	write(decision.ExecutorCatalog{Version: "2024-03-01.1", Targets: suite.targets})
+	suite.config.Catalog = decision.ExecutorCatalogConfig{Source: path}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	defer alg.Close()
+
+	version, enabled := alg.CatalogVersion()
+	require.True(suite.T(), enabled)
+	assert.Equal(suite.T(), "2024-03-01.1", version)
+	assert.Equal(suite.T(), len(suite.targets), alg.TargetRegistry().Len())
+
+	dec, err := alg.MakeRegistryDecision(context.Background(), suite.process("catalog-1"), suite.state)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2024-03-01.1", dec.CatalogVersion)
+	explanation, err := alg.Explain(dec.DecisionID)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2024-03-01.1", explanation.CatalogVersion)
+	require.Len(suite.T(), alg.CatalogChanges(), 1)
+
+	// An invalid catalog keeps the algorithm from starting
+	write(decision.ExecutorCatalog{Targets: suite.targets})
+	_, err = algorithm.NewAlgorithm(suite.config)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AlgorithmTestSuite) TestReservations() {
+	suite.config.Reservations = decision.ReservationConfig{Enabled: true}
+	suite.config.Resize = learning.ResizeConfig{
+		Enabled: true,
+		Ladder: []learning.SizeClass{
+			{Name: "4-core", CPU: 4, Memory: 8 << 30, CostPerHour: 0.2},
+			{Name: "8-core", CPU: 8, Memory: 16 << 30, CostPerHour: 0.4},
+		},
+		Sustain: time.Nanosecond,
+	}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	defer alg.Close()
+	target := suite.targets[0]
+	alg.TargetRegistry().Upsert(target)
+
+	// Three processes of 2 cores fill the 6 available
+	for i := 1; i <= 3; i++ {
+		dec, err := alg.MakeOffloadDecision(suite.process(fmt.Sprintf("reserve-%d", i)), []models.OffloadTarget{target}, suite.state)
+		require.NoError(suite.T(), err)
+		require.True(suite.T(), dec.ShouldOffload, "decision %d", i)
+	}
+	full, err := alg.MakeOffloadDecision(suite.process("reserve-4"), []models.OffloadTarget{target}, suite.state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), full.ShouldOffload, "A fully reserved target is not double-booked")
+
+	stats, enabled := alg.ReservationStats()
+	require.True(suite.T(), enabled)
+	assert.Equal(suite.T(), 3, stats.Active)
+	assert.Equal(suite.T(), 6.0, stats.CPU)
+
+	// Low heartbeat load would downsize the target, but not with its
+	// capacity reserved
+	start := time.Now().Add(-time.Minute)
+	for i := 0; i < 3; i++ {
+		require.NoError(suite.T(), alg.RecordHeartbeat(learning.Heartbeat{
+			TargetID:   target.ID,
+			Timestamp:  start.Add(time.Duration(i) * time.Second),
+			ActualLoad: 0.1,
+		}))
+	}
+	assert.Empty(suite.T(), alg.ResizeRecommendations())
+
+	// Completion and failure both release the reservation
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{ProcessID: "reserve-1", Success: true}))
+	require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{ProcessID: "reserve-2", Success: false}))
+	dec, err := alg.MakeOffloadDecision(suite.process("reserve-5"), []models.OffloadTarget{target}, suite.state)
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), dec.ShouldOffload)
+
+	stats, _ = alg.ReservationStats()
+	assert.Equal(suite.T(), int64(4), stats.Reserved)
+	assert.Equal(suite.T(), int64(2), stats.Released)
+	assert.Equal(suite.T(), 2, stats.Active)
+
+	// Without reservations the low load downsizes it
+	for _, processID := range []string{"reserve-3", "reserve-5"} {
+		require.NoError(suite.T(), alg.ProcessOutcome(decision.OffloadOutcome{ProcessID: processID, Success: true}))
+	}
+	recommendations := alg.ResizeRecommendations()
+	require.Len(suite.T(), recommendations, 1)
+	assert.Equal(suite.T(), "4-core", recommendations[0].To)
+	assert.Equal(suite.T(), 0.0, recommendations[0].Reserved)
+
+	suite.config.Reservations.Enabled = false
+	disabled, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+	_, enabled = disabled.ReservationStats()
+	assert.False(suite.T(), enabled)
+}
+
+func (suite *AlgorithmTestSuite) TestLargeFleetLatencyBudget() {
+	suite.config.PerformanceTargets.MaxDecisionLatency = 5 * time.Millisecond
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	targets := make([]models.OffloadTarget, 10000)
+	for i := range targets {
+		targets[i] = suite.target(fmt.Sprintf("fleet-%d", i), models.EDGE, time.Duration(5+i%50)*time.Millisecond, 5)
+	}
+
+	for i := 0; i < 3; i++ {
+		start := time.Now()
+		dec, err := alg.MakeOffloadDecision(suite.process(fmt.Sprintf("fleet-%d", i)), targets, suite.state)
+		elapsed := time.Since(start)
+		require.NoError(suite.T(), err)
+
+		assert.True(suite.T(), dec.ShouldOffload)
+		assert.True(suite.T(), dec.BudgetExhausted)
+		assert.Less(suite.T(), elapsed, 50*time.Millisecond,
+			"Filtering, policy evaluation and explanation are bounded with scoring")
+
+		explanation, err := alg.Explain(dec.DecisionID)
+		require.NoError(suite.T(), err)
+		assert.Positive(suite.T(), explanation.Unevaluated)
+		assert.Equal(suite.T(), len(targets), len(explanation.Candidates)+explanation.Unevaluated)
+		assert.Less(suite.T(), len(explanation.PolicyEvaluations), len(targets))
+	}
+}
+
+func (suite *AlgorithmTestSuite) TestFailedConstructionClosesFiles() {
+	openFiles := func() int {
+		entries, err := os.ReadDir("/proc/self/fd")
+		if err != nil {
+			suite.T().Skip("open files cannot be counted on this platform")
+		}
+		return len(entries)
+	}
+	dir := suite.T().TempDir()
+	suite.config.Logger = nil
+	suite.config.MonitoringConfig.Logging = logging.Config{Output: logging.FILE, FilePath: filepath.Join(dir, "cape.log")}
+	suite.config.MonitoringConfig.AuditLog = policy.AuditConfig{Dir: filepath.Join(dir, "audit")}
+	suite.config.History.SpillFile = filepath.Join(dir, "explanations.jsonl")
+	suite.config.Catalog = decision.ExecutorCatalogConfig{Source: filepath.Join(dir, "missing.json")}
+
+	before := openFiles()
+	_, err := algorithm.NewAlgorithm(suite.config)
+	require.Error(suite.T(), err)
+	assert.Equal(suite.T(), before, openFiles())
+}
+
+func (suite *AlgorithmTestSuite) TestClock() {
+	now := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	suite.config.Clock = func() time.Time { return now }
+	suite.config.History.PendingTTL = time.Hour
+	suite.config.Budget = policy.BudgetConfig{MaxCostPerHour: 1000}
+	suite.config.TenantQuotas = map[string]tenancy.Quota{"team-a": {ConcurrentOffloads: 1}}
+	alg, err := algorithm.NewAlgorithm(suite.config)
+	require.NoError(suite.T(), err)
+
+	first := suite.process("clock-1")
+	first.TenantID = "team-a"
+	dec, err := alg.MakeOffloadDecision(first, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), now, dec.DecisionTime)
+	assert.Positive(suite.T(), dec.DecisionLatency)
+	assert.Less(suite.T(), alg.RemainingBudget(), 1000.0)
+
+	// Within the pending TTL the quota is still held
+	now = now.Add(30 * time.Minute)
+	second := suite.process("clock-2")
+	second.TenantID = "team-a"
+	dec, err = alg.MakeOffloadDecision(second, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), int64(0), alg.PendingStats().Expired)
+
+	// Past it the lost decision expires and the hourly budget window has moved on
+	now = now.Add(2 * time.Hour)
+	assert.Equal(suite.T(), int64(1), alg.PendingStats().Expired)
+	assert.Equal(suite.T(), 1000.0, alg.RemainingBudget())
+	dec, err = alg.MakeOffloadDecision(second, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), now, dec.DecisionTime)
+}
+
+func TestAlgorithmSuite(t *testing.T) {
+	suite.Run(t, new(AlgorithmTestSuite))
+}
+
+func BenchmarkMakeOffloadDecision(b *testing.B) {
+	for _, size := range []int{10, 100, 1000, 10000} {
+		for _, goals := range []int{0, 4} {
+			b.Run(fmt.Sprintf("targets=%d/goals=%d", size, goals), func(b *testing.B) {
+				benchmarkOffloadDecision(b, size, goals)
+			})
+		}
+	}
+}
+
+func benchmarkOffloadDecision(b *testing.B, fleetSize, goals int) {
+	config := algorithm.Config{
+		InitialWeights: decision.AdaptiveWeights{
+			QueueDepth:    0.2,
+			ProcessorLoad: 0.2,
+			NetworkCost:   0.2,
+			LatencyCost:   0.2,
+			EnergyCost:    0.1,
+			PolicyCost:    0.1,
+		},
+		LearningConfig: learning.LearningConfig{
+			WindowSize:   100,
+			LearningRate: 0.01,
+			MinSamples:   10,
+		},
+		SafetyConstraints: policy.SafetyConstraints{
+			MinLocalCompute:       0.1,
+			MinLocalMemory:        0.1,
+			MaxConcurrentOffloads: 10,
+			MaxLatencyTolerance:   500 * time.Millisecond,
+			MinReliability:        0.5,
+		},
+		PerformanceTargets: algorithm.PerformanceTargets{
+			MaxDecisionLatency: time.Second,
+		},
+		Logger: logging.Discard(),
+	}
+	for i := 0; i < goals; i++ {
+		name := fmt.Sprintf("benchmark-objective-%d", i)
+		// Registration is global; earlier benchmarks may have registered it
+		_ = decision.RegisterObjective(decision.NewObjectiveMetric(name,
+			func(_ models.Process, target models.OffloadTarget, _ models.SystemState) float64 {
+				return float64(len(target.ID)%10) / 10
+			}))
+		config.Objectives = append(config.Objectives, decision.ObjectiveGoal{Name: name, Weight: 0.5 / float64(goals)})
+	}
+	alg, err := algorithm.NewAlgorithm(config)
+	require.NoError(b, err)
+	defer alg.Close()
+
+	rng := rand.New(rand.NewSource(1))
+	targets := make([]models.OffloadTarget, fleetSize)
+	for i := range targets {
+		targets[i] = randomTarget(rng, fmt.Sprintf("target-%05d", i))
+	}
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		NetworkUsage:   0.20,
+		MasterUsage:    0.20,
+		Timestamp:      time.Now(),
+	}
+	process := randomProcess(rng, "benchmark")
+	process.SafetyCritical = false
+	process.LocalityRequired = false
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dec, err := alg.MakeOffloadDecision(process, targets, state)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		// Complete offloads right away so they stay under the concurrency limit
+		b.StopTimer()
+		if dec.ShouldOffload && dec.Target != nil {
+			_ = alg.ProcessOutcome(decision.OffloadOutcome{
+				DecisionID:      dec.DecisionID,
+				ProcessID:       process.ID,
+				TargetID:        dec.Target.ID,
+				Success:         true,
+				CompletedOnTime: true,
+				ExecutionTime:   time.Minute,
+				StartTime:       time.Now(),
+				EndTime:         time.Now().Add(time.Minute),
+				MeasurementTime: time.Now(),
+			})
+		}
+		b.StartTimer()
+	}
+}
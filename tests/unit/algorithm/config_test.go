@@ -0,0 +1,118 @@
+package algorithm_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// Config loading test requirements:
+// 1. Valid configurations must round-trip through JSON
+// 2. Unknown fields must be rejected with the line and column of the key
+// 3. Values of the wrong type must be rejected with their position
+// 4. Every semantic problem must be reported, not just the first
+
+type ConfigTestSuite struct {
+	suite.Suite
+	config algorithm.Config
+}
+
+func (suite *ConfigTestSuite) SetupTest() {
+	suite.config = algorithm.Config{
+		InitialWeights: decision.AdaptiveWeights{
+			QueueDepth:    0.2,
+			ProcessorLoad: 0.2,
+			NetworkCost:   0.2,
+			LatencyCost:   0.2,
+			EnergyCost:    0.1,
+			PolicyCost:    0.1,
+		},
+		LearningConfig: learning.LearningConfig{
+			WindowSize:   100,
+			LearningRate: 0.01,
+		},
+		PerformanceTargets: algorithm.PerformanceTargets{
+			MaxDecisionLatency: 500 * time.Millisecond,
+		},
+	}
+}
+
+func (suite *ConfigTestSuite) TestRoundTrip() {
+	data, err := json.Marshal(suite.config)
+	require.NoError(suite.T(), err)
+
+	parsed, err := algorithm.ParseConfig(data)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.config.InitialWeights, parsed.InitialWeights)
+	assert.Equal(suite.T(), 500*time.Millisecond, parsed.PerformanceTargets.MaxDecisionLatency)
+}
+
+func (suite *ConfigTestSuite) TestUnknownField() {
+	data := []byte(`{
+  "learning_config": {
+    "learning_rate": 0.01,
+    "windw_size": 10
+  }
+}`)
+
+	_, err := algorithm.ParseConfig(data)
+	var configErr *algorithm.ConfigError
+	require.ErrorAs(suite.T(), err, &configErr)
+	assert.Equal(suite.T(), 4, configErr.Line)
+	assert.Equal(suite.T(), 5, configErr.Column)
+	assert.Contains(suite.T(), configErr.Message, `"windw_size"`)
+}
+
+func (suite *ConfigTestSuite) TestTypeMismatch() {
+	data := []byte(`{
+  "performance_targets": {"max_decision_latency": "500ms"}
+}`)
+
+	_, err := algorithm.ParseConfig(data)
+	var configErr *algorithm.ConfigError
+	require.ErrorAs(suite.T(), err, &configErr)
+	assert.Equal(suite.T(), 2, configErr.Line)
+	assert.Contains(suite.T(), configErr.Message, "performance_targets.max_decision_latency")
+	assert.Contains(suite.T(), configErr.Message, "nanoseconds")
+
+	_, err = algorithm.ParseConfig([]byte(`{"replay_log_size": 1} {}`))
+	assert.ErrorAs(suite.T(), err, &configErr, "Trailing data is rejected")
+}
+
+func (suite *ConfigTestSuite) TestAllProblemsReported() {
+	suite.config.InitialWeights.QueueDepth = 0.9
+	suite.config.LearningConfig.ExplorationRate = 2.0
+	suite.config.LearningConfig.Drift.ResetPolicy = "sometimes"
+	suite.config.ReplayLogSize = -1
+	data, err := json.Marshal(suite.config)
+	require.NoError(suite.T(), err)
+
+	path := filepath.Join(suite.T().TempDir(), "config.json")
+	require.NoError(suite.T(), os.WriteFile(path, data, 0o644))
+
+	problems := algorithm.ValidateConfigFile(path)
+	require.Len(suite.T(), problems, 4)
+	assert.Contains(suite.T(), problems[0].Error(), path+": initial_weights")
+	assert.Contains(suite.T(), problems[1].Error(), "learning_config.exploration_rate")
+	assert.Contains(suite.T(), problems[2].Error(), "learning_config.drift: unknown reset policy")
+	assert.Contains(suite.T(), problems[3].Error(), "replay_log_size")
+
+	_, err = algorithm.NewAlgorithm(suite.config)
+	assert.Error(suite.T(), err, "NewAlgorithm applies the same validation")
+
+	assert.NotEmpty(suite.T(), algorithm.ValidateConfigFile(filepath.Join(suite.T().TempDir(), "missing.json")))
+}
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
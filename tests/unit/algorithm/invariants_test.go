@@ -0,0 +1,206 @@
+package algorithm_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// Decision invariant requirements:
+// 1. Offloads must never violate a hard policy rule or leave the offered targets
+// 2. Scores, confidence, benefit and cost must be finite, confidence in [0,1]
+// 3. Weights must stay non-negative and sum to 1 while learning from outcomes
+
+// invariantDecisions is the number of decisions per generated run
+const invariantDecisions = 50
+
+// checkDecisionInvariants runs randomized decisions and outcomes for a seed
+// and fails on the first broken invariant
+func checkDecisionInvariants(t *testing.T, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	weights := decision.AdaptiveWeights{
+		QueueDepth:    rng.Float64(),
+		ProcessorLoad: rng.Float64(),
+		NetworkCost:   rng.Float64(),
+		LatencyCost:   rng.Float64(),
+		EnergyCost:    rng.Float64(),
+		PolicyCost:    rng.Float64(),
+	}
+	weights.Normalize()
+	alg, err := algorithm.NewAlgorithm(algorithm.Config{
+		InitialWeights: weights,
+		LearningConfig: learning.LearningConfig{
+			WindowSize:   20,
+			LearningRate: 0.01 + rng.Float64()*0.2,
+			MinSamples:   5,
+		},
+		SafetyConstraints: policy.SafetyConstraints{
+			MinLocalCompute:       0.1,
+			MinLocalMemory:        0.1,
+			MaxConcurrentOffloads: 1 + rng.Intn(20),
+			MaxLatencyTolerance:   time.Duration(50+rng.Intn(450)) * time.Millisecond,
+			MinReliability:        rng.Float64() * 0.8,
+		},
+		PerformanceTargets: algorithm.PerformanceTargets{
+			MaxDecisionLatency: time.Second,
+		},
+		Logger: logging.Discard(),
+	})
+	require.NoError(t, err)
+	defer alg.Close()
+
+	for i := 0; i < invariantDecisions; i++ {
+		process := randomProcess(rng, fmt.Sprintf("process-%d", i))
+		targets := make([]models.OffloadTarget, rng.Intn(6))
+		for j := range targets {
+			targets[j] = randomTarget(rng, fmt.Sprintf("target-%d", j))
+		}
+		state := randomState(rng)
+
+		dec, err := alg.MakeOffloadDecision(process, targets, state)
+		if err != nil {
+			continue // Inputs the algorithm rejects make no decision
+		}
+		for _, violation := range alg.CheckInvariants(process, targets, dec) {
+			t.Fatalf("seed %d, decision %d: %v", seed, i, violation)
+		}
+
+		targetID := "local"
+		if dec.ShouldOffload && dec.Target != nil {
+			targetID = dec.Target.ID
+		}
+		executionTime := time.Duration(rng.Int63n(int64(2 * time.Minute)))
+		_ = alg.ProcessOutcome(decision.OffloadOutcome{
+			DecisionID:      dec.DecisionID,
+			ProcessID:       process.ID,
+			TargetID:        targetID,
+			Success:         rng.Float64() < 0.8,
+			CompletedOnTime: rng.Float64() < 0.7,
+			ExecutionTime:   executionTime,
+			LatencyActual:   time.Duration(rng.Int63n(int64(time.Second))),
+			CostActual:      rng.Float64(),
+			EnergyConsumed:  rng.Float64() * 10,
+			StartTime:       time.Now(),
+			EndTime:         time.Now().Add(executionTime),
+			MeasurementTime: time.Now(),
+		})
+	}
+
+	assert.Empty(t, alg.CheckInvariants(models.Process{}, nil, decision.OffloadDecision{}), "Weights must hold after learning")
+}
+
+func randomProcess(rng *rand.Rand, id string) models.Process {
+	return models.Process{
+		ID:                id,
+		Type:              []string{"compute", "io", "ml", ""}[rng.Intn(4)],
+		Priority:          1 + rng.Intn(10),
+		CPURequirement:    0.1 + rng.Float64()*16,
+		MemoryRequirement: rng.Int63n(32 * 1024 * 1024 * 1024),
+		InputSize:         rng.Int63n(1024 * 1024 * 1024),
+		OutputSize:        rng.Int63n(1024 * 1024 * 1024),
+		EstimatedDuration: time.Duration(1+rng.Int63n(int64(time.Hour))) * time.Nanosecond,
+		RealTime:          rng.Float64() < 0.2,
+		SafetyCritical:    rng.Float64() < 0.1,
+		LocalityRequired:  rng.Float64() < 0.1,
+		SecurityLevel:     rng.Intn(6),
+		DataSensitivity:   rng.Intn(6),
+		Status:            models.QUEUED,
+	}
+}
+
+func randomTarget(rng *rand.Rand, id string) models.OffloadTarget {
+	types := []models.TargetType{models.LOCAL, models.EDGE, models.PRIVATE_CLOUD, models.PUBLIC_CLOUD, models.HYBRID_CLOUD, models.FOG}
+	total := 1 + rng.Float64()*64
+	memory := 1 + rng.Int63n(128*1024*1024*1024)
+	return models.OffloadTarget{
+		ID:                id,
+		Type:              types[rng.Intn(len(types))],
+		TotalCapacity:     total,
+		AvailableCapacity: rng.Float64() * total,
+		MemoryTotal:       memory,
+		MemoryAvailable:   rng.Int63n(memory),
+		NetworkLatency:    time.Duration(rng.Int63n(int64(200 * time.Millisecond))),
+		NetworkBandwidth:  rng.Float64() * 1024 * 1024 * 1024,
+		NetworkStability:  0.5 + rng.Float64()*0.5,
+		ProcessingSpeed:   0.1 + rng.Float64()*3,
+		Reliability:       0.5 + rng.Float64()*0.5,
+		ComputeCost:       rng.Float64(),
+		EnergyCost:        rng.Float64(),
+		SecurityLevel:     rng.Intn(6),
+		DataJurisdiction:  []string{"domestic", "eu", "foreign"}[rng.Intn(3)],
+		CurrentLoad:       rng.Float64(),
+		LastSeen:          time.Now().Add(-time.Duration(rng.Int63n(int64(time.Minute)))),
+	}
+}
+
+func randomState(rng *rand.Rand) models.SystemState {
+	return models.SystemState{
+		QueueDepth:     rng.Intn(200),
+		QueueThreshold: 1 + rng.Intn(50),
+		ComputeUsage:   models.Utilization(rng.Float64()),
+		MemoryUsage:    models.Utilization(rng.Float64()),
+		NetworkUsage:   models.Utilization(rng.Float64()),
+		MasterUsage:    models.Utilization(rng.Float64()),
+		Timestamp:      time.Now(),
+		TimeSlot:       rng.Intn(24),
+		DayOfWeek:      rng.Intn(7),
+	}
+}
+
+func TestDecisionInvariants(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		checkDecisionInvariants(t, seed)
+	}
+}
+
+func TestCheckInvariantsReportsViolations(t *testing.T) {
+	alg, err := algorithm.NewAlgorithm(algorithm.Config{
+		InitialWeights:     decision.AdaptiveWeights{QueueDepth: 0.5, ProcessorLoad: 0.5},
+		LearningConfig:     learning.LearningConfig{WindowSize: 10, LearningRate: 0.01, MinSamples: 5},
+		PerformanceTargets: algorithm.PerformanceTargets{MaxDecisionLatency: time.Second},
+		Logger:             logging.Discard(),
+	})
+	require.NoError(t, err)
+	defer alg.Close()
+
+	process := randomProcess(rand.New(rand.NewSource(1)), "process")
+	process.SafetyCritical = true
+	target := randomTarget(rand.New(rand.NewSource(1)), "cloud")
+	target.Type = models.PUBLIC_CLOUD
+
+	violations := alg.CheckInvariants(process, nil, decision.OffloadDecision{
+		ShouldOffload: true,
+		Target:        &target,
+		Score:         math.NaN(),
+		Confidence:    1.5,
+	})
+	invariants := make([]string, len(violations))
+	for i, violation := range violations {
+		invariants[i] = violation.Invariant
+	}
+	assert.Contains(t, invariants, algorithm.InvariantHardConstraints)
+	assert.Contains(t, invariants, algorithm.InvariantOfferedTarget)
+	assert.Contains(t, invariants, algorithm.InvariantFiniteScores)
+	assert.Contains(t, invariants, algorithm.InvariantConfidence)
+}
+
+func FuzzDecisionInvariants(f *testing.F) {
+	for _, seed := range []int64{0, 1, 42, math.MaxInt64} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		checkDecisionInvariants(t, seed)
+	})
+}
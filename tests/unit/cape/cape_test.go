@@ -0,0 +1,86 @@
+package cape_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/cape"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// Facade test requirements:
+// 1. New without options must build a working algorithm from defaults
+// 2. Goals must become normalized objective weights, rejecting invalid goals
+// 3. Options must set the configuration fields they name
+// 4. Invalid resulting configurations must be rejected by New
+
+type CapeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CapeTestSuite) TestDefaults() {
+	alg, err := cape.New()
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), alg.IsHealthy())
+	assert.Equal(suite.T(), cape.DefaultConfig().InitialWeights, alg.GetConfiguration().InitialWeights)
+}
+
+func (suite *CapeTestSuite) TestGoals() {
+	config, err := cape.Config(cape.WithGoals(
+		cape.Goal{Objective: cape.LATENCY, Weight: 3},
+		cape.Goal{Objective: cape.ENERGY, Weight: 1},
+	))
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 0.75, config.InitialWeights.LatencyCost, 1e-9)
+	assert.InDelta(suite.T(), 0.25, config.InitialWeights.EnergyCost, 1e-9)
+	assert.Zero(suite.T(), config.InitialWeights.QueueDepth)
+
+	_, err = cape.Config(cape.WithGoals(cape.Goal{Objective: "speed", Weight: 1}))
+	assert.Error(suite.T(), err)
+	_, err = cape.Config(cape.WithGoals(cape.Goal{Objective: cape.NETWORK, Weight: -1}))
+	assert.Error(suite.T(), err)
+	_, err = cape.Config(cape.WithGoals())
+	assert.Error(suite.T(), err, "Goals need a positive total weight")
+}
+
+func (suite *CapeTestSuite) TestOptions() {
+	rules := filepath.Join(suite.T().TempDir(), "rules.yaml")
+	require.NoError(suite.T(), os.WriteFile(rules, []byte("rules: []\n"), 0o644))
+
+	alg, err := cape.New(
+		cape.WithPolicyFile(rules),
+		cape.WithSmoothing(),
+		cape.WithLearning(0.05, 0.2),
+		cape.WithMaxConcurrentOffloads(3),
+		cape.WithDecisionLatency(time.Second),
+		cape.WithConfig(func(config *algorithm.Config) { config.ReplayLogSize = 50 }),
+	)
+	require.NoError(suite.T(), err)
+
+	config := alg.GetConfiguration()
+	assert.Equal(suite.T(), rules, config.PolicyRulesFile)
+	assert.Equal(suite.T(), learning.DefaultSmoothingConfig(), config.Smoothing)
+	assert.Equal(suite.T(), 0.05, config.LearningConfig.LearningRate)
+	assert.Equal(suite.T(), 0.2, config.LearningConfig.ExplorationRate)
+	assert.Equal(suite.T(), 3, config.SafetyConstraints.MaxConcurrentOffloads)
+	assert.Equal(suite.T(), time.Second, config.PerformanceTargets.MaxDecisionLatency)
+	assert.Equal(suite.T(), 50, config.ReplayLogSize)
+}
+
+func (suite *CapeTestSuite) TestInvalidConfiguration() {
+	_, err := cape.New(cape.WithLearning(2, 0.1))
+	assert.Error(suite.T(), err)
+	_, err = cape.New(cape.WithPolicyFile(filepath.Join(suite.T().TempDir(), "missing.yaml")))
+	assert.Error(suite.T(), err)
+}
+
+func TestCapeSuite(t *testing.T) {
+	suite.Run(t, new(CapeTestSuite))
+}
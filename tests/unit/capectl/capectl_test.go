@@ -0,0 +1,584 @@
+package capectl_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	capev1 "github.com/casperlundberg/colony-process-offloader-algorithm/api/proto/cape/v1"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/internal/capectl"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// capectl test requirements:
+// 1. Unknown commands and missing arguments must exit with usage status 2
+// 2. validate-config must report every problem and fail on invalid files
+// 3. simulate runs must be reproducible for a seed and write a replay log
+// 4. replay must evaluate a configuration against a written replay log
+// 5. export must write one CSV row per logged decision and outcome
+// 6. simulate must report warm-up decisions separately from steady-state metrics
+// 7. plan must preview a placement for every process in a queue snapshot
+// 8. simulate must write a post-mortem for an injected queue spike
+// 9. simulate must inject spikes shaped by a -spike-script expression
+// 10. simulate must draw arrivals from bursty arrival processes
+// 11. simulate must trace target usage and temperature from the processes
+//     running on them
+// 12. simulate must compare simulated queueing waits with M/G/c theory
+// 13. verify-audit must accept intact audit trails and reject tampered ones
+// 14. golden must fail when a recorded scenario's metrics drift beyond their
+//     tolerance
+// 15. bench must report decision throughput per fleet size and goal count and
+//     append each run to its history file
+// 16. chargeback must roll a replay log's offload costs up by month, tenant
+//     and project as CSV, in the requested currency
+// 17. simulate must stage offloaded processes' data in and out around their
+//     compute phase and report the share of execution time spent staging
+// 18. simulate must time decisions and outcomes by the simulated arrivals from
+//     -start, not the wall clock
+// 19. serve-analytics must serve a replay log's summary, chargeback and replay
+//     estimate over HTTP until interrupted
+// 20. run-orchestrator must serve decisions over gRPC until interrupted
+
+type CapectlTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *CapectlTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+}
+
+func (suite *CapectlTestSuite) run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := capectl.Run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func (suite *CapectlTestSuite) TestUsage() {
+	code, _, stderr := suite.run()
+	assert.Equal(suite.T(), 2, code)
+	assert.Contains(suite.T(), stderr, "validate-config")
+
+	code, _, stderr = suite.run("orchestrate")
+	assert.Equal(suite.T(), 2, code)
+	assert.Contains(suite.T(), stderr, `unknown command "orchestrate"`)
+
+	code, _, _ = suite.run("replay")
+	assert.Equal(suite.T(), 2, code, "replay requires -log")
+}
+
+func (suite *CapectlTestSuite) TestValidateConfig() {
+	invalid := filepath.Join(suite.dir, "invalid.json")
+	require.NoError(suite.T(), os.WriteFile(invalid, []byte(`{"learning_config": {"learning_rate": 2}}`), 0o644))
+
+	code, _, stderr := suite.run("validate-config", invalid)
+	assert.Equal(suite.T(), 1, code)
+	assert.Contains(suite.T(), stderr, "initial_weights")
+	assert.Contains(suite.T(), stderr, "learning_config.learning_rate")
+	assert.Contains(suite.T(), stderr, "performance_targets.max_decision_latency")
+}
+
+func (suite *CapectlTestSuite) TestSimulateAndReplay() {
+	logPath := filepath.Join(suite.dir, "replay.json")
+	code, first, stderr := suite.run("simulate", "-seed", "42", "-decisions", "5", "-log-level", "error", "-replay-log", logPath)
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Contains(suite.T(), first, "Demo completed successfully!")
+
+	var records []algorithm.ReplayRecord
+	data, err := os.ReadFile(logPath)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), json.Unmarshal(data, &records))
+	assert.Len(suite.T(), records, 5)
+
+	// The same seed draws the same processes
+	_, second, _ := suite.run("simulate", "-seed", "42", "-decisions", "5", "-log-level", "error")
+	assert.Equal(suite.T(), processLines(first), processLines(second))
+
+	code, stdout, stderr := suite.run("replay", "-log", logPath, "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+	var report algorithm.ReplayReport
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &report))
+	assert.Equal(suite.T(), 5, report.Records)
+}
+
+func (suite *CapectlTestSuite) TestSimulateWarmup() {
+	code, stdout, stderr := suite.run("simulate", "-seed", "42", "-decisions", "5", "-warmup", "2", "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Contains(suite.T(), stdout, "Warm-up Metrics (2 decisions)")
+	assert.Contains(suite.T(), stdout, "Steady-State Metrics (3 decisions)")
+	assert.Contains(suite.T(), stdout, "SLA Compliance")
+
+	// Warm-up only changes reporting, not the decisions made
+	_, unwarmed, _ := suite.run("simulate", "-seed", "42", "-decisions", "5", "-log-level", "error")
+	assert.Equal(suite.T(), processLines(unwarmed), processLines(stdout))
+	assert.Contains(suite.T(), unwarmed, "Steady-State Metrics (5 decisions)")
+	assert.NotContains(suite.T(), unwarmed, "Warm-up Metrics")
+
+	code, _, _ = suite.run("simulate", "-decisions", "5", "-warmup", "5")
+	assert.Equal(suite.T(), 2, code)
+}
+
+func (suite *CapectlTestSuite) TestSimulateSpike() {
+	// Early, before the simulated queue drifts towards the spike threshold
+	code, stdout, stderr := suite.run("simulate", "-seed", "42", "-decisions", "12",
+		"-spike-at", "2", "-spike-length", "4", "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Contains(suite.T(), stdout, "[spike]")
+	assert.Contains(suite.T(), stdout, "Spike Post-Mortems (1 spikes)")
+	assert.Contains(suite.T(), stdout, "spike_1: 4m0s, detected after 2m0s")
+	assert.Contains(suite.T(), stdout, "4 processes")
+
+	_, plain, _ := suite.run("simulate", "-seed", "42", "-decisions", "12", "-log-level", "error")
+	assert.NotContains(suite.T(), plain, "Spike Post-Mortems")
+
+	code, _, _ = suite.run("simulate", "-decisions", "5", "-spike-at", "3", "-spike-length", "3")
+	assert.Equal(suite.T(), 2, code, "The spike must end within the simulation")
+}
+
+func (suite *CapectlTestSuite) TestSimulateSpikeScript() {
+	// The scripted equivalent of -spike-at 2 -spike-length 4
+	code, stdout, stderr := suite.run("simulate", "-seed", "42", "-decisions", "12",
+		"-spike-script", "(t >= 2 && t < 6) * 3 * threshold", "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+	_, injected, _ := suite.run("simulate", "-seed", "42", "-decisions", "12",
+		"-spike-at", "2", "-spike-length", "4", "-log-level", "error")
+	assert.Equal(suite.T(), processLines(injected), processLines(stdout))
+	assert.Contains(suite.T(), stdout, "spike_1: 4m0s, detected after 2m0s")
+
+	for _, script := range []string{"t +", "spike(t)", "max(t)", "(t > 2", "q * 2"} {
+		code, _, stderr = suite.run("simulate", "-decisions", "5", "-spike-script", script)
+		assert.Equal(suite.T(), 2, code, script)
+		assert.Contains(suite.T(), stderr, "Invalid -spike-script", script)
+	}
+
+	code, _, _ = suite.run("simulate", "-decisions", "5", "-spike-script", "t", "-spike-at", "2")
+	assert.Equal(suite.T(), 2, code)
+}
+
+func (suite *CapectlTestSuite) TestSimulateArrivals() {
+	for _, kind := range []string{"poisson", "mmpp", "pareto", "self-similar"} {
+		code, stdout, stderr := suite.run("simulate", "-seed", "3", "-decisions", "50",
+			"-arrivals", kind, "-log-level", "error")
+		require.Equal(suite.T(), 0, code, stderr)
+		assert.Contains(suite.T(), stdout, "Arrivals ("+kind+", 49 gaps)")
+		assert.Contains(suite.T(), stdout, "Gap Coefficient of Variation")
+	}
+
+	_, fixed, _ := suite.run("simulate", "-seed", "3", "-decisions", "5", "-log-level", "error")
+	assert.NotContains(suite.T(), fixed, "Arrivals (")
+
+	for _, args := range [][]string{
+		{"-arrivals", "uniform"},
+		{"-arrivals", "pareto", "-pareto-alpha", "1"},
+		{"-arrivals", "self-similar", "-hurst", "1"},
+		{"-arrivals", "mmpp", "-burst-factor", "0.5"},
+		{"-arrival-gap", "0s"},
+	} {
+		code, _, _ := suite.run(append([]string{"simulate", "-decisions", "5"}, args...)...)
+		assert.Equal(suite.T(), 2, code, args)
+	}
+}
+
+func (suite *CapectlTestSuite) TestSimulateResourceTraces() {
+	code, stdout, stderr := suite.run("simulate", "-seed", "3", "-decisions", "30",
+		"-resource-traces", "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Contains(suite.T(), stdout, "Resource Traces (30 samples)")
+	for _, target := range []string{"local-1", "edge-1", "cloud-1"} {
+		assert.Contains(suite.T(), stdout, "  "+target+": mean CPU")
+	}
+	assert.Regexp(suite.T(), `edge-1: .*, peak \d+\.\d°C, throttled `, stdout)
+
+	_, plain, _ := suite.run("simulate", "-seed", "3", "-decisions", "5", "-log-level", "error")
+	assert.NotContains(suite.T(), plain, "Resource Traces")
+}
+
+func (suite *CapectlTestSuite) TestSimulateQueueing() {
+	code, stdout, stderr := suite.run("simulate", "-seed", "5", "-decisions", "300",
+		"-arrivals", "poisson", "-arrival-gap", "40s", "-queueing", "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Contains(suite.T(), stdout, "Queueing Validation (3 pools)")
+	assert.Contains(suite.T(), stdout, "  edge: 4 slots")
+	assert.Contains(suite.T(), stdout, "  local: 2 slots")
+	assert.NotContains(suite.T(), stdout, "[DIVERGES]", "A correct FCFS simulation agrees with M/G/c theory")
+
+	// Offered load beyond the slots makes the analytical queue unstable
+	_, overloaded, _ := suite.run("simulate", "-seed", "5", "-decisions", "300",
+		"-arrivals", "poisson", "-arrival-gap", "20s", "-queueing", "-log-level", "error")
+	assert.Contains(suite.T(), overloaded, "M/G/c wait unstable")
+}
+
+func (suite *CapectlTestSuite) TestSimulateDataStaging() {
+	metricsPath := filepath.Join(suite.dir, "metrics.json")
+	code, stdout, stderr := suite.run("simulate", "-seed", "42", "-decisions", "20",
+		"-metrics-out", metricsPath, "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Regexp(suite.T(), `Data Staging: \d+\.\d+% of execution time \(stage-in \S+, stage-out \S+ per decision\)`, stdout)
+
+	data, err := os.ReadFile(metricsPath)
+	require.NoError(suite.T(), err)
+	var metrics map[string]float64
+	require.NoError(suite.T(), json.Unmarshal(data, &metrics))
+	assert.Greater(suite.T(), metrics["staging_share"], 0.0, "Offloaded processes move their data")
+	assert.Less(suite.T(), metrics["staging_share"], 1.0)
+}
+
+func (suite *CapectlTestSuite) TestSimulateClock() {
+	logPath := filepath.Join(suite.dir, "replay.json")
+	code, _, stderr := suite.run("simulate", "-seed", "42", "-decisions", "5", "-start", "2026-03-02T09:00:00Z",
+		"-arrival-gap", "10m", "-replay-log", logPath, "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+
+	var records []algorithm.ReplayRecord
+	data, err := os.ReadFile(logPath)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), json.Unmarshal(data, &records))
+	require.Len(suite.T(), records, 5)
+
+	start := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	assert.True(suite.T(), records[0].Explanation.Timestamp.Equal(start))
+	for _, record := range records {
+		decidedAt := record.Explanation.Timestamp
+		assert.True(suite.T(), decidedAt.Equal(record.State.Timestamp), "Decisions are made at the process's arrival")
+		assert.True(suite.T(), record.Outcome.StartTime.Equal(decidedAt))
+		assert.True(suite.T(), record.Outcome.EndTime.After(decidedAt))
+	}
+	last := records[len(records)-1].Explanation.Timestamp
+	assert.Greater(suite.T(), last.Sub(start), time.Minute, "Arrivals advance the simulated clock")
+}
+
+func (suite *CapectlTestSuite) TestVerifyAudit() {
+	suite.T().Setenv("CAPECTL_AUDIT_KEY", "secret")
+	dir := filepath.Join(suite.dir, "audit")
+	writer, err := policy.NewAuditWriter(policy.AuditConfig{Dir: dir, SigningKeyEnv: "CAPECTL_AUDIT_KEY"})
+	require.NoError(suite.T(), err)
+	for i := 0; i < 3; i++ {
+		require.NoError(suite.T(), writer.Append(policy.AuditLog{ID: "audit", EventType: "policy_evaluation"}))
+	}
+	require.NoError(suite.T(), writer.Close())
+
+	code, stdout, stderr := suite.run("verify-audit", "-dir", dir, "-key-env", "CAPECTL_AUDIT_KEY")
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Contains(suite.T(), stdout, "3 records in 1 files verified (signatures valid)")
+
+	path := filepath.Join(dir, "audit-000001.jsonl")
+	data, err := os.ReadFile(path)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), os.WriteFile(path, bytes.Replace(data, []byte("policy_evaluation"), []byte("policy_override"), 1), 0o640))
+	code, _, stderr = suite.run("verify-audit", "-dir", dir)
+	assert.Equal(suite.T(), 1, code)
+	assert.Contains(suite.T(), stderr, "audit-000001.jsonl:1")
+
+	code, _, _ = suite.run("verify-audit")
+	assert.Equal(suite.T(), 2, code, "verify-audit requires -dir")
+}
+
+func (suite *CapectlTestSuite) TestGoldenScenario() {
+	code, stdout, stderr := suite.run("golden", "-file", filepath.Join("testdata", "golden", "demo.json"))
+	require.Equal(suite.T(), 0, code, stdout+stderr)
+	assert.Contains(suite.T(), stdout, "✓ All metrics within tolerance")
+
+	path := filepath.Join(suite.dir, "golden.json")
+	code, _, stderr = suite.run("golden", "-file", path, "-update", "--",
+		"-seed", "7", "-decisions", "40", "-start", "2026-01-05T12:00:00Z", "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+
+	var baseline struct {
+		Metrics    map[string]float64 `json:"metrics"`
+		Tolerance  float64            `json:"tolerance"`
+		Tolerances map[string]float64 `json:"tolerances"`
+	}
+	data, err := os.ReadFile(path)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), json.Unmarshal(data, &baseline))
+	assert.Equal(suite.T(), 0.05, baseline.Tolerance)
+	for _, metric := range []string{"cost_per_decision", "offload_rate", "queue_p95", "sla_compliance", "throughput"} {
+		assert.Contains(suite.T(), baseline.Metrics, metric)
+	}
+
+	// Shift the recorded SLA compliance out of its band
+	var raw map[string]interface{}
+	require.NoError(suite.T(), json.Unmarshal(data, &raw))
+	raw["metrics"].(map[string]interface{})["sla_compliance"] = baseline.Metrics["sla_compliance"] * 1.2
+	suite.writeJSON(path, raw)
+	code, stdout, _ = suite.run("golden", "-file", path)
+	assert.Equal(suite.T(), 1, code)
+	assert.Regexp(suite.T(), `sla_compliance: .* \[DRIFT\]`, stdout)
+
+	// A per-metric tolerance widens the band
+	raw["tolerances"] = map[string]float64{"sla_compliance": 0.5}
+	suite.writeJSON(path, raw)
+	code, _, stderr = suite.run("golden", "-file", path)
+	assert.Equal(suite.T(), 0, code, stderr)
+
+	code, _, _ = suite.run("golden", "-file", path, "--", "-seed", "1")
+	assert.Equal(suite.T(), 2, code, "Arguments require -update")
+}
+
+func (suite *CapectlTestSuite) TestPlan() {
+	snapshot := map[string]interface{}{
+		"state": models.SystemState{
+			QueueDepth:     25,
+			QueueThreshold: 20,
+			ComputeUsage:   0.75,
+			MemoryUsage:    0.60,
+			Timestamp:      time.Now(),
+			TimeSlot:       12,
+		},
+		"processes": []models.Process{
+			{ID: "batch-1", CPURequirement: 2, MemoryRequirement: 1 << 30, InputSize: 1 << 20,
+				EstimatedDuration: 30 * time.Second, Priority: 5, SecurityLevel: 2, Status: models.QUEUED},
+			{ID: "critical-1", CPURequirement: 1, MemoryRequirement: 1 << 30, InputSize: 1 << 20,
+				EstimatedDuration: 30 * time.Second, Priority: 9, SafetyCritical: true, Status: models.QUEUED},
+		},
+	}
+	catalog := []models.OffloadTarget{{
+		ID: "edge-1", Type: models.EDGE, TotalCapacity: 16, AvailableCapacity: 12,
+		MemoryTotal: 32 << 30, MemoryAvailable: 24 << 30, NetworkLatency: 5 * time.Millisecond,
+		NetworkBandwidth: 500 << 20, NetworkStability: 0.98, ProcessingSpeed: 1.5, Reliability: 0.95,
+		ComputeCost: 0.05, SecurityLevel: 4, LastSeen: time.Now().Add(-time.Hour),
+	}}
+	queuePath := filepath.Join(suite.dir, "queue.json")
+	catalogPath := filepath.Join(suite.dir, "targets.json")
+	suite.writeJSON(queuePath, snapshot)
+	suite.writeJSON(catalogPath, catalog)
+
+	code, stdout, stderr := suite.run("plan", "-queue", queuePath, "-targets", catalogPath, "-log-level", "error")
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Contains(suite.T(), stdout, "Process batch-1 -> edge-1")
+	assert.Contains(suite.T(), stdout, "Process critical-1 -> local")
+	assert.Contains(suite.T(), stdout, "Planned 2 processes: 1 offloaded, 1 local, 0 failed")
+
+	code, _, _ = suite.run("plan", "-queue", queuePath)
+	assert.Equal(suite.T(), 2, code)
+}
+
+func (suite *CapectlTestSuite) TestBench() {
+	history := filepath.Join(suite.dir, "bench.json")
+	args := []string{"bench", "-targets", "5,20", "-goals", "0,2", "-decisions", "10", "-history", history, "-log-level", "error"}
+	code, stdout, stderr := suite.run(args...)
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Regexp(suite.T(), `\s20\s+2\s+[0-9.]+`, stdout)
+	code, stdout, stderr = suite.run(args...)
+	require.Equal(suite.T(), 0, code, stderr)
+	assert.Contains(suite.T(), stdout, "(2 runs)")
+
+	var runs []struct {
+		Version string `json:"version"`
+		Results []struct {
+			Targets         int     `json:"targets"`
+			Goals           int     `json:"goals"`
+			DecisionsPerSec float64 `json:"decisions_per_sec"`
+		} `json:"results"`
+	}
+	data, err := os.ReadFile(history)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), json.Unmarshal(data, &runs))
+	require.Len(suite.T(), runs, 2)
+	assert.NotEmpty(suite.T(), runs[0].Version)
+	require.Len(suite.T(), runs[0].Results, 4)
+	for _, result := range runs[0].Results {
+		assert.Greater(suite.T(), result.DecisionsPerSec, 0.0)
+	}
+
+	code, _, _ = suite.run("bench", "-targets", "0")
+	assert.Equal(suite.T(), 2, code)
+}
+
+func (suite *CapectlTestSuite) writeJSON(path string, value interface{}) {
+	data, err := json.Marshal(value)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), os.WriteFile(path, data, 0o644))
+}
+
+func (suite *CapectlTestSuite) TestExport() {
+	logPath := filepath.Join(suite.dir, "replay.json")
+	code, _, stderr := suite.run("simulate", "-seed", "7", "-decisions", "4", "-log-level", "error", "-replay-log", logPath)
+	require.Equal(suite.T(), 0, code, stderr)
+
+	outDir := filepath.Join(suite.dir, "results")
+	code, _, stderr = suite.run("export", "-log", logPath, "-out", outDir)
+	require.Equal(suite.T(), 0, code, stderr)
+
+	for _, file := range []string{"states.csv", "decisions.csv", "outcomes.csv"} {
+		data, err := os.ReadFile(filepath.Join(outDir, file))
+		require.NoError(suite.T(), err)
+		rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		require.NoError(suite.T(), err)
+		assert.Len(suite.T(), rows, 5, "%s has a header and one row per decision", file)
+	}
+	_, err := os.Stat(filepath.Join(outDir, "candidates.csv"))
+	assert.NoError(suite.T(), err)
+
+	code, _, _ = suite.run("export", "-log", logPath, "-format", "parquet")
+	assert.Equal(suite.T(), 2, code)
+}
+
+func (suite *CapectlTestSuite) TestChargeback() {
+	logPath := filepath.Join(suite.dir, "replay.json")
+	code, _, stderr := suite.run("simulate", "-seed", "7", "-decisions", "4", "-log-level", "error", "-replay-log", logPath)
+	require.Equal(suite.T(), 0, code, stderr)
+
+	code, stdout, stderr := suite.run("chargeback", "-log", logPath)
+	require.Equal(suite.T(), 0, code, stderr)
+	rows, err := csv.NewReader(bytes.NewReader([]byte(stdout))).ReadAll()
+	require.NoError(suite.T(), err)
+	require.GreaterOrEqual(suite.T(), len(rows), 2, "A header and at least one month")
+	assert.Equal(suite.T(), "month", rows[0][0])
+	assert.Equal(suite.T(), "total_cost", rows[0][7])
+
+	outPath := filepath.Join(suite.dir, "chargeback.csv")
+	code, _, stderr = suite.run("chargeback", "-log", logPath, "-month", "1999-01", "-out", outPath)
+	require.Equal(suite.T(), 0, code, stderr)
+	data, err := os.ReadFile(outPath)
+	require.NoError(suite.T(), err)
+	rows, err = csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), rows, 1, "No offloads in the month")
+
+	code, _, _ = suite.run("chargeback", "-log", logPath, "-month", "March")
+	assert.Equal(suite.T(), 2, code)
+
+	code, stdout, stderr = suite.run("chargeback", "-log", logPath, "-currency", "EUR", "-rates", "EUR=0.5")
+	require.Equal(suite.T(), 0, code, stderr)
+	rows, err = csv.NewReader(bytes.NewReader([]byte(stdout))).ReadAll()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "EUR", rows[1][8])
+
+	code, _, _ = suite.run("chargeback", "-log", logPath, "-currency", "EUR")
+	assert.Equal(suite.T(), 2, code, "No exchange rate for the display currency")
+}
+
+func (suite *CapectlTestSuite) TestServeAnalytics() {
+	logPath := filepath.Join(suite.dir, "replay.json")
+	code, _, stderr := suite.run("simulate", "-seed", "7", "-decisions", "4", "-log-level", "error", "-replay-log", logPath)
+	require.Equal(suite.T(), 0, code, stderr)
+
+	code, _, _ = suite.run("serve-analytics")
+	assert.Equal(suite.T(), 2, code, "serve-analytics requires -log")
+
+	addr := freeAddress(suite.T())
+	done := suite.serve("serve-analytics", "-log", logPath, "-log-level", "error", "-listen", addr)
+
+	var summary struct {
+		Records  int `json:"records"`
+		Offloads int `json:"offloads"`
+	}
+	require.Eventually(suite.T(), func() bool {
+		response, err := http.Get("http://" + addr + "/summary")
+		if err != nil {
+			return false
+		}
+		defer response.Body.Close()
+		return json.NewDecoder(response.Body).Decode(&summary) == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(suite.T(), 4, summary.Records)
+
+	response, err := http.Get("http://" + addr + "/chargeback")
+	require.NoError(suite.T(), err)
+	var rollups []map[string]interface{}
+	require.NoError(suite.T(), json.NewDecoder(response.Body).Decode(&rollups))
+	response.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, response.StatusCode)
+	if summary.Offloads > 0 {
+		assert.NotEmpty(suite.T(), rollups)
+	}
+
+	response, err = http.Get("http://" + addr + "/chargeback?month=March")
+	require.NoError(suite.T(), err)
+	response.Body.Close()
+	assert.Equal(suite.T(), http.StatusBadRequest, response.StatusCode)
+
+	response, err = http.Get("http://" + addr + "/replay")
+	require.NoError(suite.T(), err)
+	var report algorithm.ReplayReport
+	require.NoError(suite.T(), json.NewDecoder(response.Body).Decode(&report))
+	response.Body.Close()
+	assert.Equal(suite.T(), 4, report.Records)
+
+	interrupt(suite.T())
+	assert.Equal(suite.T(), 0, <-done)
+}
+
+func (suite *CapectlTestSuite) TestRunOrchestrator() {
+	code, _, _ := suite.run("run-orchestrator", "-ingest-interval", "0s")
+	assert.Equal(suite.T(), 2, code)
+
+	addr := freeAddress(suite.T())
+	done := suite.serve("run-orchestrator", "-log-level", "error", "-listen", addr)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(suite.T(), err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stats, err := capev1.NewDecisionServiceClient(conn).GetStats(ctx, &capev1.GetStatsRequest{}, grpc.WaitForReady(true))
+	require.NoError(suite.T(), err)
+	assert.Zero(suite.T(), stats.DecisionCount)
+
+	interrupt(suite.T())
+	assert.Equal(suite.T(), 0, <-done)
+}
+
+// serve runs a long-lived command in the background and returns its exit
+// code once it stops
+func (suite *CapectlTestSuite) serve(args ...string) <-chan int {
+	done := make(chan int, 1)
+	go func() {
+		var stdout, stderr bytes.Buffer
+		code := capectl.Run(args, &stdout, &stderr)
+		if code != 0 {
+			suite.T().Log(stderr.String())
+		}
+		done <- code
+	}()
+	return done
+}
+
+// freeAddress returns a loopback address with a port no one listens on
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+// interrupt sends SIGINT to the test process, which a serving command
+// handles by shutting down
+func interrupt(t *testing.T) {
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(os.Interrupt))
+}
+
+// processLines returns the per-process decisions of simulate output, without
+// scores
+func processLines(output string) []string {
+	lines := make([]string, 0)
+	for _, line := range bytes.Split([]byte(output), []byte("\n")) {
+		if bytes.HasPrefix(line, []byte("Process ")) {
+			decision, _, _ := bytes.Cut(line, []byte(" (score"))
+			lines = append(lines, string(decision))
+		}
+	}
+	return lines
+}
+
+func TestCapectlSuite(t *testing.T) {
+	suite.Run(t, new(CapectlTestSuite))
+}
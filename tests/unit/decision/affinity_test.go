@@ -0,0 +1,160 @@
+package decision_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Affinity test requirements:
+// 1. Co-located processes must land on their peer's target
+// 2. Anti-affinity and region spreading must place group members apart
+// 3. Avoided targets must never be chosen
+// 4. Preferred groups must lower scores instead of excluding targets
+// 5. Released placements must no longer constrain new decisions
+
+type AffinityTestSuite struct {
+	suite.Suite
+	engine  *decision.DecisionEngine
+	state   models.SystemState
+	targets []models.OffloadTarget
+}
+
+func (suite *AffinityTestSuite) SetupTest() {
+	suite.engine = decision.NewDecisionEngine(decision.AdaptiveWeights{
+		QueueDepth:    0.2,
+		ProcessorLoad: 0.2,
+		NetworkCost:   0.2,
+		LatencyCost:   0.2,
+		EnergyCost:    0.1,
+		PolicyCost:    0.1,
+	})
+
+	suite.state = models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		Timestamp:      time.Now(),
+	}
+
+	suite.targets = []models.OffloadTarget{
+		suite.target("edge-a", "eu-north", 5*time.Millisecond),
+		suite.target("edge-b", "eu-north", 6*time.Millisecond),
+		suite.target("edge-c", "eu-west", 7*time.Millisecond),
+	}
+}
+
+func (suite *AffinityTestSuite) target(id, location string, latency time.Duration) models.OffloadTarget {
+	return models.OffloadTarget{
+		ID:                id,
+		Type:              models.EDGE,
+		Location:          location,
+		TotalCapacity:     16.0,
+		AvailableCapacity: 12.0,
+		MemoryTotal:       32 * 1024 * 1024 * 1024,
+		MemoryAvailable:   24 * 1024 * 1024 * 1024,
+		NetworkLatency:    latency,
+		NetworkBandwidth:  100 * 1024 * 1024,
+		ProcessingSpeed:   1.5,
+		Reliability:       0.95,
+		SecurityLevel:     3,
+		LastSeen:          time.Now(),
+	}
+}
+
+func (suite *AffinityTestSuite) process(id string, affinity models.Affinity) models.Process {
+	return models.Process{
+		ID:                id,
+		CPURequirement:    1.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		Affinity:          affinity,
+		Status:            models.QUEUED,
+	}
+}
+
+func (suite *AffinityTestSuite) place(process models.Process) decision.OffloadDecision {
+	dec, err := suite.engine.MakeDecision(process, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	return dec
+}
+
+func (suite *AffinityTestSuite) TestCoLocateAndAvoid() {
+	first := suite.place(suite.process("writer", models.Affinity{AvoidTargets: []string{"edge-a"}}))
+	require.True(suite.T(), first.ShouldOffload)
+	assert.NotEqual(suite.T(), "edge-a", first.Target.ID)
+
+	reader := suite.place(suite.process("reader", models.Affinity{CoLocateWith: []string{"writer"}}))
+	require.True(suite.T(), reader.ShouldOffload)
+	assert.Equal(suite.T(), first.Target.ID, reader.Target.ID)
+
+	other := suite.place(suite.process("noisy", models.Affinity{AvoidProcesses: []string{"writer"}}))
+	require.True(suite.T(), other.ShouldOffload)
+	assert.NotEqual(suite.T(), first.Target.ID, other.Target.ID)
+}
+
+func (suite *AffinityTestSuite) TestAntiAffinityAndSpread() {
+	require.NoError(suite.T(), suite.engine.Affinity().DeclareGroup(models.AffinityGroup{ID: "replicas", AntiAffinity: true}))
+	require.NoError(suite.T(), suite.engine.Affinity().DeclareGroup(models.AffinityGroup{ID: "zones", SpreadRegions: true}))
+
+	used := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		dec := suite.place(suite.process(fmt.Sprintf("replica-%d", i), models.Affinity{Groups: []string{"replicas"}}))
+		require.True(suite.T(), dec.ShouldOffload)
+		assert.False(suite.T(), used[dec.Target.ID], "Replicas must not share a target")
+		used[dec.Target.ID] = true
+	}
+	extra := suite.place(suite.process("replica-3", models.Affinity{Groups: []string{"replicas"}}))
+	assert.False(suite.T(), extra.ShouldOffload, "No target left for another replica")
+
+	locations := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		dec := suite.place(suite.process(fmt.Sprintf("zone-%d", i), models.Affinity{Groups: []string{"zones"}}))
+		require.True(suite.T(), dec.ShouldOffload)
+		assert.False(suite.T(), locations[dec.Target.Location], "Members must spread across regions")
+		locations[dec.Target.Location] = true
+	}
+
+	suite.engine.Affinity().Release("replica-0")
+	freed := suite.place(suite.process("replica-4", models.Affinity{Groups: []string{"replicas"}}))
+	assert.True(suite.T(), freed.ShouldOffload, "Released placement frees its target")
+}
+
+func (suite *AffinityTestSuite) TestPreferredGroupPenalizes() {
+	suite.targets = suite.targets[:1]
+	require.NoError(suite.T(), suite.engine.Affinity().DeclareGroup(models.AffinityGroup{ID: "soft", AntiAffinity: true, Preferred: true}))
+
+	first := suite.place(suite.process("soft-0", models.Affinity{Groups: []string{"soft"}}))
+	require.True(suite.T(), first.ShouldOffload)
+
+	reason, penalty := suite.engine.Affinity().Evaluate(suite.process("soft-1", models.Affinity{Groups: []string{"soft"}}), suite.targets[0])
+	assert.Empty(suite.T(), reason, "Preferred groups never exclude targets")
+	assert.Greater(suite.T(), penalty, 0.0)
+
+	second := suite.place(suite.process("soft-1", models.Affinity{Groups: []string{"soft"}}))
+	if second.ShouldOffload {
+		assert.Less(suite.T(), second.Score, first.Score)
+	}
+}
+
+func (suite *AffinityTestSuite) TestInvalidGroups() {
+	tracker := suite.engine.Affinity()
+	assert.Error(suite.T(), tracker.DeclareGroup(models.AffinityGroup{AntiAffinity: true}))
+	assert.Error(suite.T(), tracker.DeclareGroup(models.AffinityGroup{ID: "empty"}))
+	assert.Error(suite.T(), tracker.DeclareGroup(models.AffinityGroup{ID: "both", CoLocate: true, AntiAffinity: true}))
+	assert.Empty(suite.T(), tracker.Groups())
+}
+
+func TestAffinitySuite(t *testing.T) {
+	suite.Run(t, new(AffinityTestSuite))
+}
@@ -0,0 +1,150 @@
+package decision_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Attribution test requirements:
+// 1. Shapley values must sum to the score difference from the baseline
+// 2. Factors with zero weight or no change from the baseline get no credit
+// 3. Attribution must stay consistent when the score is clamped
+// 4. Credit assignment must normalize magnitudes to one
+// 5. Offload decisions must carry attribution for every weighted factor
+
+type AttributionTestSuite struct {
+	suite.Suite
+	weights decision.AdaptiveWeights
+}
+
+func (suite *AttributionTestSuite) SetupTest() {
+	suite.weights = decision.AdaptiveWeights{
+		QueueDepth:    0.2,
+		ProcessorLoad: 0.2,
+		NetworkCost:   0.2,
+		LatencyCost:   0.2,
+		EnergyCost:    0.1,
+		PolicyCost:    0.1,
+	}
+}
+
+func (suite *AttributionTestSuite) sum(values map[string]float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+func (suite *AttributionTestSuite) TestEfficiency() {
+	actual := decision.ScoreBreakdown{
+		QueueImpact: 0.9, LoadBalance: 0.8, NetworkCost: 0.4,
+		LatencyImpact: 0.7, EnergyImpact: 0.5, PolicyMatch: 1.0,
+		WeightsUsed: suite.weights,
+	}
+	baseline := decision.ScoreBreakdown{
+		QueueImpact: 0.5, LoadBalance: 0.5, NetworkCost: 0.6,
+		LatencyImpact: 0.5, EnergyImpact: 0.5, PolicyMatch: 0.8,
+		WeightsUsed: suite.weights,
+	}
+
+	attribution := decision.ShapleyAttribution(actual, baseline)
+	require.Len(suite.T(), attribution, 6)
+	assert.InDelta(suite.T(), actual.WeightedScore()-baseline.WeightedScore(), suite.sum(attribution), 1e-9)
+
+	// Without clamping, Shapley values reduce to weighted differences
+	assert.InDelta(suite.T(), 0.2*0.4, attribution["QueueDepth"], 1e-9)
+	assert.InDelta(suite.T(), 0.2*-0.2, attribution["NetworkCost"], 1e-9)
+	assert.InDelta(suite.T(), 0.0, attribution["EnergyCost"], 1e-9, "Unchanged factors get no credit")
+}
+
+func (suite *AttributionTestSuite) TestZeroWeightGetsNoCredit() {
+	suite.weights.EnergyCost = 0
+	actual := decision.ScoreBreakdown{EnergyImpact: 1.0, QueueImpact: 1.0, WeightsUsed: suite.weights}
+	baseline := decision.ScoreBreakdown{WeightsUsed: suite.weights}
+
+	attribution := decision.ShapleyAttribution(actual, baseline)
+	assert.Equal(suite.T(), 0.0, attribution["EnergyCost"])
+	assert.InDelta(suite.T(), 0.2, attribution["QueueDepth"], 1e-9)
+}
+
+func (suite *AttributionTestSuite) TestClampedScore() {
+	heavy := decision.AdaptiveWeights{QueueDepth: 0.8, ProcessorLoad: 0.8}
+	actual := decision.ScoreBreakdown{QueueImpact: 1.0, LoadBalance: 1.0, WeightsUsed: heavy}
+	baseline := decision.ScoreBreakdown{WeightsUsed: heavy}
+
+	attribution := decision.ShapleyAttribution(actual, baseline)
+	assert.InDelta(suite.T(), 1.0, suite.sum(attribution), 1e-9, "Credit covers only the clamped score")
+	assert.InDelta(suite.T(), attribution["QueueDepth"], attribution["ProcessorLoad"], 1e-9, "Symmetric factors share credit")
+}
+
+func (suite *AttributionTestSuite) TestCreditAssignment() {
+	credit := decision.CreditAssignment(map[string]float64{"QueueDepth": 0.3, "NetworkCost": -0.1})
+	assert.InDelta(suite.T(), 0.75, credit["QueueDepth"], 1e-9)
+	assert.InDelta(suite.T(), -0.25, credit["NetworkCost"], 1e-9)
+
+	assert.Nil(suite.T(), decision.CreditAssignment(map[string]float64{"QueueDepth": 0}))
+}
+
+func (suite *AttributionTestSuite) TestDecisionCarriesAttribution() {
+	engine := decision.NewDecisionEngine(suite.weights)
+	process := models.Process{
+		ID:                "attributed",
+		CPURequirement:    2.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+	state := models.SystemState{
+		QueueDepth:     25,
+		QueueThreshold: 20,
+		ComputeUsage:   0.75,
+		MemoryUsage:    0.60,
+		NetworkUsage:   0.40,
+		Timestamp:      time.Now(),
+	}
+	target := func(id string, latency time.Duration) models.OffloadTarget {
+		return models.OffloadTarget{
+			ID:                id,
+			Type:              models.EDGE,
+			TotalCapacity:     8.0,
+			AvailableCapacity: 6.0,
+			MemoryTotal:       16 * 1024 * 1024 * 1024,
+			MemoryAvailable:   10 * 1024 * 1024 * 1024,
+			NetworkLatency:    latency,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			NetworkStability:  0.95,
+			ProcessingSpeed:   1.0,
+			Reliability:       0.95,
+			ComputeCost:       0.10,
+			SecurityLevel:     3,
+			LastSeen:          time.Now(),
+		}
+	}
+
+	dec, err := engine.MakeDecision(process, []models.OffloadTarget{
+		target("edge-near", 5*time.Millisecond),
+		target("edge-far", 200*time.Millisecond),
+	}, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	require.Len(suite.T(), dec.Attribution, 6)
+	assert.Greater(suite.T(), dec.Attribution["LatencyCost"], 0.0, "Lower latency than the average candidate earns credit")
+	for factor, value := range dec.Attribution {
+		assert.False(suite.T(), math.IsNaN(value), factor)
+	}
+}
+
+func TestAttributionSuite(t *testing.T) {
+	suite.Run(t, new(AttributionTestSuite))
+}
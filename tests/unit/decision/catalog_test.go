@@ -0,0 +1,163 @@
+package decision_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Data catalog test requirements:
+// 1. Placements must stage inputs, and outcomes must confirm them and
+//    materialize outputs, or drop them when the execution failed
+// 2. Cache hit probability must reflect replica state and staging success
+// 3. Targets must stay within cache capacity by evicting least recently used
+//    replicas, and replicas must expire after their TTL
+// 4. Placement scoring must favor targets already holding the input dataset
+
+type CatalogTestSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (suite *CatalogTestSuite) SetupTest() {
+	suite.now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *CatalogTestSuite) process(id, input, output string) models.Process {
+	return models.Process{
+		ID:              id,
+		InputSize:       100 * mb,
+		OutputSize:      10 * mb,
+		InputDatasetID:  input,
+		OutputDatasetID: output,
+	}
+}
+
+func (suite *CatalogTestSuite) TestPlacementLifecycle() {
+	catalog := decision.NewDataCatalog(decision.CatalogConfig{})
+
+	catalog.Place(suite.process("p1", "raw", "features"), "edge-1", suite.now)
+	replica, ok := catalog.Lookup("edge-1", "raw", suite.now)
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), decision.DATASET_STAGING, replica.State)
+	assert.Equal(suite.T(), int64(100*mb), replica.Size)
+
+	catalog.Complete("p1", true, suite.now)
+	replica, _ = catalog.Lookup("edge-1", "raw", suite.now)
+	assert.Equal(suite.T(), decision.DATASET_CACHED, replica.State)
+	features, ok := catalog.Lookup("edge-1", "features", suite.now)
+	require.True(suite.T(), ok, "Outputs are materialized where they ran")
+	assert.Equal(suite.T(), decision.DATASET_MATERIALIZED, features.State)
+	assert.Equal(suite.T(), []string{"edge-1"}, catalog.Locations("features", suite.now))
+
+	// Reuse is counted as a hit
+	catalog.Place(suite.process("p2", "raw", ""), "edge-1", suite.now)
+	replica, _ = catalog.Lookup("edge-1", "raw", suite.now)
+	assert.Equal(suite.T(), 1, replica.Hits)
+
+	// Failed executions drop what they staged
+	catalog.Place(suite.process("p3", "other", "out"), "edge-2", suite.now)
+	catalog.Complete("p3", false, suite.now)
+	_, ok = catalog.Lookup("edge-2", "other", suite.now)
+	assert.False(suite.T(), ok)
+	_, ok = catalog.Lookup("edge-2", "out", suite.now)
+	assert.False(suite.T(), ok)
+
+	// Revoked placements leave no trace
+	catalog.Place(suite.process("p4", "revoked", ""), "edge-2", suite.now)
+	catalog.Cancel("p4")
+	assert.Empty(suite.T(), catalog.Replicas("edge-2", suite.now))
+}
+
+func (suite *CatalogTestSuite) TestHitProbability() {
+	catalog := decision.NewDataCatalog(decision.CatalogConfig{})
+	assert.Equal(suite.T(), 0.0, catalog.HitProbability("edge-1", "raw", suite.now))
+
+	catalog.Record("edge-1", "raw", 100*mb, decision.DATASET_CACHED, suite.now)
+	assert.Equal(suite.T(), 1.0, catalog.HitProbability("edge-1", "raw", suite.now))
+
+	// Staging on a target that has failed before is less certain
+	for _, id := range []string{"f1", "f2", "f3"} {
+		catalog.Place(suite.process(id, "flaky-"+id, ""), "edge-2", suite.now)
+		catalog.Complete(id, id == "f1", suite.now)
+	}
+	catalog.Place(suite.process("p1", "pending", ""), "edge-2", suite.now)
+	assert.InDelta(suite.T(), 0.5, catalog.HitProbability("edge-2", "pending", suite.now), 1e-9)
+}
+
+func (suite *CatalogTestSuite) TestCapacityAndTTL() {
+	catalog := decision.NewDataCatalog(decision.CatalogConfig{
+		CacheCapacity: map[string]int64{"edge-1": 250 * mb},
+		TTL:           time.Hour,
+	})
+
+	catalog.Record("edge-1", "a", 100*mb, decision.DATASET_CACHED, suite.now)
+	catalog.Record("edge-1", "b", 100*mb, decision.DATASET_CACHED, suite.now.Add(time.Minute))
+	catalog.Place(suite.process("p1", "a", ""), "edge-1", suite.now.Add(2*time.Minute)) // Touches a
+	catalog.Record("edge-1", "c", 100*mb, decision.DATASET_CACHED, suite.now.Add(3*time.Minute))
+
+	at := suite.now.Add(3 * time.Minute)
+	replicas := catalog.Replicas("edge-1", at)
+	require.Len(suite.T(), replicas, 2)
+	assert.Equal(suite.T(), "a", replicas[0].DatasetID)
+	assert.Equal(suite.T(), "c", replicas[1].DatasetID, "The least recently used replica was evicted")
+
+	_, ok := catalog.Lookup("edge-1", "c", at.Add(2*time.Hour))
+	assert.False(suite.T(), ok, "Unused replicas expire")
+
+	assert.Error(suite.T(), decision.CatalogConfig{TTL: -time.Second}.Validate())
+}
+
+func (suite *CatalogTestSuite) TestCacheAffinity() {
+	engine := decision.NewDecisionEngine(decision.AdaptiveWeights{})
+	target := func(id string) models.OffloadTarget {
+		return models.OffloadTarget{
+			ID:                id,
+			Type:              models.EDGE,
+			TotalCapacity:     8.0,
+			AvailableCapacity: 6.0,
+			MemoryTotal:       16 * 1024 * mb,
+			MemoryAvailable:   10 * 1024 * mb,
+			NetworkLatency:    10 * time.Millisecond,
+			NetworkBandwidth:  10 * mb,
+			NetworkStability:  0.95,
+			ProcessingSpeed:   1.0,
+			Reliability:       0.95,
+			SecurityLevel:     5,
+			LastSeen:          time.Now(),
+		}
+	}
+	targets := []models.OffloadTarget{target("edge-a"), target("edge-b"), target("edge-c")}
+	state := models.SystemState{QueueDepth: 25, QueueThreshold: 20, ComputeUsage: 0.8, MemoryUsage: 0.6, Timestamp: time.Now()}
+	process := models.Process{
+		ID:                "p1",
+		CPURequirement:    1.0,
+		MemoryRequirement: mb,
+		InputSize:         80 * mb,
+		InputDatasetID:    "model-weights",
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+
+	engine.Catalog().Record("edge-b", "model-weights", 80*mb, decision.DATASET_CACHED, time.Now())
+	dec, err := engine.MakeDecision(process, targets, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), "edge-b", dec.Target.ID, "The target caching the input is preferred")
+	assert.Equal(suite.T(), int64(0), dec.DataSize)
+
+	explanations := engine.ExplainCandidates(process, targets, state)
+	assert.Equal(suite.T(), 0.0, explanations[0].DataGravity.CacheHit)
+	assert.Equal(suite.T(), 1.0, explanations[1].DataGravity.CacheHit)
+}
+
+func TestCatalogSuite(t *testing.T) {
+	suite.Run(t, new(CatalogTestSuite))
+}
@@ -1,6 +1,7 @@
 package decision_test
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
@@ -20,6 +21,8 @@ import (
 // 2. Decision must complete within 500ms
 // 3. All scores must be in [0.0, 1.0] range
 // 4. Decision quality must be explainable and auditable
+// 5. Heavy processes must be kept off hot targets, and throttled targets
+//    must be predicted to run slower
 
 type DecisionEngineTestSuite struct {
 	suite.Suite
@@ -354,6 +357,300 @@ func (suite *DecisionEngineTestSuite) TestScoreRangeCompliance() {
 }
 
 // Helper functions
+// Test that parallelizable workloads are split across targets when it shortens the makespan
+func (suite *DecisionEngineTestSuite) TestPartialOffloadSplit() {
+	process := models.Process{
+		ID:                "test-split",
+		CPURequirement:    4.0,
+		MemoryRequirement: 2 * 1024 * 1024 * 1024,
+		InputSize:         10 * 1024 * 1024,
+		OutputSize:        1024 * 1024,
+		EstimatedDuration: 10 * time.Minute,
+		Priority:          5,
+		Parallelizable:    true,
+		Status:            models.QUEUED,
+	}
+
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		NetworkUsage:   0.20,
+		MasterUsage:    0.20,
+		Timestamp:      time.Now(),
+		TimeSlot:       12,
+		DayOfWeek:      3,
+	}
+
+	newTarget := func(id string, targetType models.TargetType, speed float64) models.OffloadTarget {
+		return models.OffloadTarget{
+			ID:                id,
+			Type:              targetType,
+			TotalCapacity:     16.0,
+			AvailableCapacity: 12.0,
+			MemoryTotal:       32 * 1024 * 1024 * 1024,
+			MemoryAvailable:   24 * 1024 * 1024 * 1024,
+			NetworkLatency:    10 * time.Millisecond,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			NetworkStability:  0.95,
+			ProcessingSpeed:   speed,
+			Reliability:       0.95,
+			ComputeCost:       0.10,
+			SecurityLevel:     3,
+			LastSeen:          time.Now(),
+		}
+	}
+	targets := []models.OffloadTarget{
+		newTarget("cloud-split", models.PUBLIC_CLOUD, 2.1),
+		newTarget("edge-split", models.EDGE, 0.9),
+	}
+
+	result, err := suite.engine.MakeDecision(process, targets, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), result.ShouldOffload)
+
+	assert.Equal(suite.T(), decision.SPLIT, result.Strategy)
+	require.Len(suite.T(), result.Shards, 2)
+
+	totalFraction := 0.0
+	for _, shard := range result.Shards {
+		totalFraction += shard.Fraction
+		assert.NotNil(suite.T(), shard.Target)
+		assert.Greater(suite.T(), shard.EstimatedTime, time.Duration(0))
+	}
+	assert.InDelta(suite.T(), 1.0, totalFraction, 0.001, "Shard fractions should cover the whole workload")
+	assert.InDelta(suite.T(), 0.7, result.Shards[0].Fraction, 0.001, "Faster target should receive the larger share")
+	assert.Greater(suite.T(), result.MergeCost, time.Duration(0))
+
+	// Non-parallelizable processes are never split
+	process.Parallelizable = false
+	result, err = suite.engine.MakeDecision(process, targets, state)
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Shards)
+}
+
+// Test that gangs are offloaded all-or-nothing across as many targets as needed
+func (suite *DecisionEngineTestSuite) TestGangScheduling() {
+	process := models.Process{
+		ID:                "gang-1",
+		CPURequirement:    2.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         1024 * 1024,
+		EstimatedDuration: 5 * time.Minute,
+		Priority:          5,
+		Parallelizable:    true,
+		GangSize:          5,
+		Status:            models.QUEUED,
+	}
+
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		Timestamp:      time.Now(),
+	}
+
+	targets := make([]models.OffloadTarget, 0, 3)
+	for i := 0; i < 3; i++ {
+		targets = append(targets, models.OffloadTarget{
+			ID:                fmt.Sprintf("gang-edge-%d", i),
+			Type:              models.EDGE,
+			TotalCapacity:     8.0,
+			AvailableCapacity: 4.0, // Two members each
+			MemoryTotal:       16 * 1024 * 1024 * 1024,
+			MemoryAvailable:   8 * 1024 * 1024 * 1024,
+			NetworkLatency:    time.Duration(5+i) * time.Millisecond,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			ProcessingSpeed:   1.5,
+			Reliability:       0.95,
+			SecurityLevel:     3,
+			LastSeen:          time.Now(),
+		})
+	}
+
+	result, err := suite.engine.MakeDecision(process, targets, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), result.ShouldOffload)
+	assert.Equal(suite.T(), decision.GANG, result.Strategy)
+	assert.Empty(suite.T(), result.Shards, "Gangs are placed, not split")
+
+	placed := 0
+	for _, allocation := range result.Gang {
+		assert.LessOrEqual(suite.T(), allocation.Slots, 2)
+		placed += allocation.Slots
+	}
+	assert.Equal(suite.T(), 5, placed)
+	assert.Len(suite.T(), result.Gang, 3)
+	assert.Equal(suite.T(), result.Gang[0].Target.ID, result.Target.ID)
+
+	// A gang larger than the free slots stays local rather than partially offloading
+	process.GangSize = 7
+	result, err = suite.engine.MakeDecision(process, targets, state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), result.ShouldOffload)
+	assert.Empty(suite.T(), result.Gang)
+
+	process.GangSize = -1
+	_, err = suite.engine.MakeDecision(process, targets, state)
+	assert.Error(suite.T(), err)
+}
+
+// Test that shard outcomes attribute latency to the slowest shard
+func (suite *DecisionEngineTestSuite) TestAggregateShardOutcomes() {
+	outcomes := []decision.OffloadOutcome{
+		{TargetID: "fast", ExecutionTime: 2 * time.Minute, Success: true, CompletedOnTime: true, CostActual: 0.5},
+		{TargetID: "slow", ExecutionTime: 5 * time.Minute, Success: true, CompletedOnTime: false, CostActual: 0.25,
+			Attribution: map[string]float64{"NetworkCost": 0.9}},
+	}
+
+	aggregate := decision.AggregateShardOutcomes(outcomes)
+
+	assert.Equal(suite.T(), "slow", aggregate.TargetID)
+	assert.Equal(suite.T(), 5*time.Minute, aggregate.ExecutionTime)
+	assert.True(suite.T(), aggregate.Success)
+	assert.False(suite.T(), aggregate.CompletedOnTime)
+	assert.InDelta(suite.T(), 0.75, aggregate.CostActual, 0.001)
+	assert.Equal(suite.T(), 0.9, aggregate.Attribution["NetworkCost"])
+}
+
+// Test that a shared input dataset is only charged for transfer once per target
+func (suite *DecisionEngineTestSuite) TestSharedInputDatasetTransfer() {
+	process := models.Process{
+		ID:                "fan-out-1",
+		CPURequirement:    1.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         500 * 1024 * 1024,
+		OutputSize:        1024 * 1024,
+		InputDatasetID:    "shared-dataset",
+		EstimatedDuration: 2 * time.Minute,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		NetworkUsage:   0.20,
+		MasterUsage:    0.20,
+		Timestamp:      time.Now(),
+		TimeSlot:       12,
+		DayOfWeek:      3,
+	}
+
+	targets := []models.OffloadTarget{
+		{
+			ID:                "edge-dataset",
+			Type:              models.EDGE,
+			TotalCapacity:     16.0,
+			AvailableCapacity: 12.0,
+			MemoryTotal:       32 * 1024 * 1024 * 1024,
+			MemoryAvailable:   24 * 1024 * 1024 * 1024,
+			NetworkLatency:    10 * time.Millisecond,
+			NetworkBandwidth:  50 * 1024 * 1024,
+			NetworkStability:  0.95,
+			NetworkCost:       0.01,
+			ProcessingSpeed:   1.5,
+			Reliability:       0.95,
+			ComputeCost:       0.10,
+			SecurityLevel:     3,
+			LastSeen:          time.Now(),
+		},
+	}
+
+	first, err := suite.engine.MakeDecision(process, targets, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), first.ShouldOffload)
+	assert.True(suite.T(), suite.engine.IsDatasetStaged("edge-dataset", "shared-dataset"))
+
+	process.ID = "fan-out-2"
+	second, err := suite.engine.MakeDecision(process, targets, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), second.ShouldOffload)
+
+	assert.Less(suite.T(), second.EstimatedCost, first.EstimatedCost,
+		"Second process sharing the dataset should not pay for the input transfer again")
+	assert.GreaterOrEqual(suite.T(), second.Score, first.Score)
+
+	suite.engine.ClearStagedDatasets("edge-dataset")
+	assert.False(suite.T(), suite.engine.IsDatasetStaged("edge-dataset", "shared-dataset"))
+}
+
+// Test that an expired latency budget returns the most promising target
+// scored so far instead of scoring every target
+func (suite *DecisionEngineTestSuite) TestDecisionLatencyBudget() {
+	process := models.Process{
+		ID:                "budget-1",
+		CPURequirement:    1.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		NetworkUsage:   0.20,
+		MasterUsage:    0.20,
+		Timestamp:      time.Now(),
+		TimeSlot:       12,
+		DayOfWeek:      3,
+	}
+
+	targets := make([]models.OffloadTarget, 0, 50)
+	for i := 0; i < 50; i++ {
+		targets = append(targets, models.OffloadTarget{
+			ID:                fmt.Sprintf("edge-%d", i),
+			Type:              models.EDGE,
+			TotalCapacity:     8.0,
+			AvailableCapacity: 6.0,
+			MemoryTotal:       16 * 1024 * 1024 * 1024,
+			MemoryAvailable:   10 * 1024 * 1024 * 1024,
+			NetworkLatency:    time.Duration(10+i) * time.Millisecond,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			NetworkStability:  0.95,
+			ProcessingSpeed:   1.0,
+			Reliability:       0.95,
+			ComputeCost:       0.10,
+			SecurityLevel:     3,
+			LastSeen:          time.Now(),
+		})
+	}
+
+	full, err := suite.engine.MakeDecision(process, targets, state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), full.BudgetExhausted)
+	assert.Equal(suite.T(), len(targets), full.TargetsEvaluated)
+	require.Len(suite.T(), full.Candidates, len(targets), "Every target is explained from its score")
+	for _, candidate := range full.Candidates {
+		assert.True(suite.T(), candidate.Scored)
+		if candidate.TargetID == full.Target.ID {
+			assert.Equal(suite.T(), full.ScoreComponents.WeightedScore(), candidate.Score)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	process.ID = "budget-2"
+	budgeted, err := suite.engine.MakeDecisionContext(ctx, process, targets, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), budgeted.ShouldOffload)
+	assert.True(suite.T(), budgeted.BudgetExhausted)
+	assert.Equal(suite.T(), 1, budgeted.TargetsEvaluated, "Only the most promising target is scored")
+	assert.Equal(suite.T(), "edge-0", budgeted.Target.ID, "Lowest latency target ranks first by heuristic")
+	require.Len(suite.T(), budgeted.Candidates, 1, "Targets the budget left unexamined are not filtered or explained")
+	assert.Equal(suite.T(), "edge-0", budgeted.Candidates[0].TargetID)
+	assert.True(suite.T(), budgeted.Candidates[0].Scored)
+}
+
 func calculateAverageLatency(latencies []time.Duration) time.Duration {
 	if len(latencies) == 0 {
 		return 0
@@ -391,6 +688,284 @@ func calculatePercentileLatency(latencies []time.Duration, percentile int) time.
 }
 
 // Run the test suite
+// Test that data residency restricts placement to permitted jurisdictions and
+// scales transfer costs along the way
+func (suite *DecisionEngineTestSuite) TestJurisdictionTransfers() {
+	graph, err := models.NewJurisdictionGraph(
+		models.TransferEdge{From: "EU", To: "US", RequiresSCC: true, CostFactor: 3.0},
+	)
+	require.NoError(suite.T(), err)
+	suite.engine.SetJurisdictionGraph(graph)
+
+	process := models.Process{
+		ID:                "resident-1",
+		CPURequirement:    1.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         20 * 1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		DataResidency:     []string{"EU"},
+		Status:            models.QUEUED,
+	}
+
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		Timestamp:      time.Now(),
+	}
+
+	us := models.OffloadTarget{
+		ID:                "us-1",
+		Type:              models.PUBLIC_CLOUD,
+		TotalCapacity:     16.0,
+		AvailableCapacity: 12.0,
+		MemoryTotal:       32 * 1024 * 1024 * 1024,
+		MemoryAvailable:   24 * 1024 * 1024 * 1024,
+		NetworkLatency:    10 * time.Millisecond,
+		NetworkBandwidth:  100 * 1024 * 1024,
+		ProcessingSpeed:   1.5,
+		Reliability:       0.95,
+		SecurityLevel:     3,
+		DataJurisdiction:  "US",
+		LastSeen:          time.Now(),
+	}
+
+	blocked, err := suite.engine.MakeDecision(process, []models.OffloadTarget{us}, state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), blocked.ShouldOffload, "EU data cannot move to the US without SCC")
+
+	process.SCCApproved = true
+	allowed, err := suite.engine.MakeDecision(process, []models.OffloadTarget{us}, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), allowed.ShouldOffload)
+
+	local := process
+	local.DataResidency = []string{"US"}
+	unscaled, err := suite.engine.MakeDecision(local, []models.OffloadTarget{us}, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), unscaled.ShouldOffload)
+	assert.Less(suite.T(), allowed.ScoreComponents.NetworkCost, unscaled.ScoreComponents.NetworkCost,
+		"Cross-border transfer should score a higher network cost")
+}
+
+func (suite *DecisionEngineTestSuite) TestCapabilityRequirements() {
+	process := models.Process{
+		ID:                "inference-1",
+		CPURequirement:    1.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		Requirements:      "gpu_accelerated AND (region:eu OR low_latency)",
+		Status:            models.QUEUED,
+	}
+
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		Timestamp:      time.Now(),
+	}
+
+	target := func(id string, capabilities ...string) models.OffloadTarget {
+		return models.OffloadTarget{
+			ID:                id,
+			Type:              models.EDGE,
+			TotalCapacity:     16.0,
+			AvailableCapacity: 12.0,
+			MemoryTotal:       32 * 1024 * 1024 * 1024,
+			MemoryAvailable:   24 * 1024 * 1024 * 1024,
+			NetworkLatency:    10 * time.Millisecond,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			ProcessingSpeed:   1.5,
+			Reliability:       0.95,
+			Capabilities:      capabilities,
+			LastSeen:          time.Now(),
+		}
+	}
+
+	result, err := suite.engine.MakeDecision(process, []models.OffloadTarget{
+		target("cpu-eu", "region:eu"),
+		target("gpu-us", "gpu_accelerated", "region:us"),
+		target("gpu-eu", "gpu_accelerated", "region:eu"),
+	}, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), result.ShouldOffload)
+	assert.Equal(suite.T(), "gpu-eu", result.Target.ID, "Only gpu-eu satisfies the requirements")
+
+	unmet, err := suite.engine.MakeDecision(process, []models.OffloadTarget{target("gpu-us", "gpu_accelerated", "region:us")}, state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), unmet.ShouldOffload)
+}
+
+func (suite *DecisionEngineTestSuite) TestSecurityNegotiation() {
+	suite.engine.SetSecurityPolicy(models.SecurityPolicy{
+		Enabled:              true,
+		RequiredLevels:       []int{0, 1, 2, 4, 5, 5},
+		EncryptionThreshold:  3,
+		EncryptionThroughput: 50 * 1024 * 1024,
+	})
+
+	process := models.Process{
+		ID:                "records-1",
+		CPURequirement:    1.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         100 * 1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		SecurityLevel:     1,
+		DataSensitivity:   3,
+		Status:            models.QUEUED,
+	}
+
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		Timestamp:      time.Now(),
+	}
+
+	target := models.OffloadTarget{
+		ID:                "edge-1",
+		Type:              models.EDGE,
+		TotalCapacity:     16.0,
+		AvailableCapacity: 12.0,
+		MemoryTotal:       32 * 1024 * 1024 * 1024,
+		MemoryAvailable:   24 * 1024 * 1024 * 1024,
+		NetworkLatency:    10 * time.Millisecond,
+		NetworkBandwidth:  100 * 1024 * 1024,
+		ProcessingSpeed:   1.5,
+		Reliability:       0.95,
+		ComputeCost:       0.1,
+		SecurityLevel:     3,
+		LastSeen:          time.Now(),
+	}
+
+	blocked, err := suite.engine.MakeDecision(process, []models.OffloadTarget{target}, state)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), blocked.ShouldOffload, "Sensitivity 3 requires security level 4")
+
+	target.SecurityLevel = 4
+	encrypted := suite.engine.PredictOutcome(process, target)
+	assert.Equal(suite.T(), 2*time.Second, encrypted.EncryptionTime)
+
+	process.DataSensitivity = 2
+	plain := suite.engine.PredictOutcome(process, target)
+	assert.Zero(suite.T(), plain.EncryptionTime)
+	assert.Greater(suite.T(), encrypted.ExecutionTime, plain.ExecutionTime, "Encryption adds CPU time on the target")
+	assert.Greater(suite.T(), encrypted.EstimatedCost, plain.EstimatedCost)
+}
+
+func (suite *DecisionEngineTestSuite) TestEnergyModel() {
+	process := models.Process{
+		ID:                "batch-1",
+		CPURequirement:    4.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		EstimatedDuration: 10 * time.Minute,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		Timestamp:      time.Now(),
+	}
+
+	target := func(id string, peakWatts, pue float64) models.OffloadTarget {
+		return models.OffloadTarget{
+			ID:                id,
+			Type:              models.PRIVATE_CLOUD,
+			TotalCapacity:     16.0,
+			AvailableCapacity: 12.0,
+			MemoryTotal:       32 * 1024 * 1024 * 1024,
+			MemoryAvailable:   24 * 1024 * 1024 * 1024,
+			NetworkLatency:    10 * time.Millisecond,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			ProcessingSpeed:   1.0,
+			Reliability:       0.95,
+			Power:             &models.PowerProfile{IdleWatts: 100, PeakWatts: peakWatts, PUE: pue},
+			EnergyPrice:       0.25,
+			LastSeen:          time.Now(),
+		}
+	}
+
+	// Identical but for the power drawn and the site's overhead
+	result, err := suite.engine.MakeDecision(process, []models.OffloadTarget{
+		target("hungry", 900, 1.8),
+		target("efficient", 300, 1.1),
+	}, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), result.ShouldOffload)
+	assert.Equal(suite.T(), "efficient", result.Target.ID)
+	assert.Greater(suite.T(), float64(result.EstimatedEnergy), 0.0, "Decisions estimate the energy drawn")
+
+	hungry := suite.engine.PredictOutcome(process, target("hungry", 900, 1.8))
+	efficient := suite.engine.PredictOutcome(process, *result.Target)
+	assert.Greater(suite.T(), hungry.Energy, efficient.Energy)
+	assert.Greater(suite.T(), hungry.EnergyCost, efficient.EnergyCost)
+}
+
+func (suite *DecisionEngineTestSuite) TestThermalPlacement() {
+	suite.engine.SetThermalPolicy(models.ThermalPolicy{Enabled: true, ThrottleTemperature: 75, MaxTemperature: 90})
+
+	process := models.Process{
+		ID:                "render-1",
+		CPURequirement:    6.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		EstimatedDuration: 5 * time.Minute,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+
+	state := models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		Timestamp:      time.Now(),
+	}
+
+	target := func(id string, temperature float64) models.OffloadTarget {
+		return models.OffloadTarget{
+			ID:                id,
+			Type:              models.EDGE,
+			TotalCapacity:     16.0,
+			AvailableCapacity: 12.0,
+			MemoryTotal:       32 * 1024 * 1024 * 1024,
+			MemoryAvailable:   24 * 1024 * 1024 * 1024,
+			NetworkLatency:    10 * time.Millisecond,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			ProcessingSpeed:   1.5,
+			Reliability:       0.95,
+			Temperature:       temperature,
+			LastSeen:          time.Now(),
+		}
+	}
+
+	// A hot target is refused, and a warm one loses to a cool one
+	assert.Contains(suite.T(), suite.engine.RejectionReason(process, target("hot", 93)), "temperature")
+	result, err := suite.engine.MakeDecision(process, []models.OffloadTarget{
+		target("hot", 93),
+		target("warm", 85),
+		target("cool", 50),
+	}, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), result.ShouldOffload)
+	assert.Equal(suite.T(), "cool", result.Target.ID)
+
+	// Throttled targets run slower
+	warm := suite.engine.PredictOutcome(process, target("warm", 85))
+	cool := suite.engine.PredictOutcome(process, target("cool", 50))
+	assert.Greater(suite.T(), warm.ExecutionTime, cool.ExecutionTime)
+}
+
 func TestDecisionEngineSuite(t *testing.T) {
 	suite.Run(t, new(DecisionEngineTestSuite))
 }
\ No newline at end of file
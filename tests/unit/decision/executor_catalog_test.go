@@ -0,0 +1,167 @@
+package decision_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// ExecutorCatalog test requirements:
+// 1. Loading a catalog must register its targets and record its version
+// 2. A new version must add, update and remove targets in one step, leaving
+//    targets registered by other means alone
+// 3. A catalog that fails to validate, or changes its targets without a new
+//    version, must be refused and leave the applied version in place
+// 4. HTTP catalogs must be re-fetched conditionally on their ETag
+
+type ExecutorCatalogTestSuite struct {
+	suite.Suite
+	registry *decision.TargetRegistry
+	path     string
+	written  time.Time
+}
+
+func (suite *ExecutorCatalogTestSuite) SetupTest() {
+	suite.registry = decision.NewTargetRegistry()
+	suite.path = filepath.Join(suite.T().TempDir(), "catalog.json")
+	suite.written = time.Now().Add(-time.Hour)
+}
+
+func (suite *ExecutorCatalogTestSuite) target(id string, cost float64) models.OffloadTarget {
+	return models.OffloadTarget{ID: id, Type: models.EDGE, TotalCapacity: 8, ComputeCost: cost, Reliability: 0.9, NetworkStability: 0.9}
+}
+
+// write writes a catalog version with a fresh modification time
+func (suite *ExecutorCatalogTestSuite) write(catalog decision.ExecutorCatalog) {
+	data, err := json.Marshal(catalog)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), os.WriteFile(suite.path, data, 0o644))
+	suite.written = suite.written.Add(time.Second)
+	require.NoError(suite.T(), os.Chtimes(suite.path, suite.written, suite.written))
+}
+
+func (suite *ExecutorCatalogTestSuite) manager() *decision.CatalogManager {
+	manager, err := decision.NewCatalogManager(decision.ExecutorCatalogConfig{Source: suite.path}, suite.registry)
+	require.NoError(suite.T(), err)
+	return manager
+}
+
+func (suite *ExecutorCatalogTestSuite) TestLoadAndUpdate() {
+	suite.registry.Upsert(suite.target("manual", 0.1))
+	suite.write(decision.ExecutorCatalog{Version: "v1", Targets: []models.OffloadTarget{
+		suite.target("edge-a", 0.1), suite.target("edge-b", 0.1), suite.target("edge-c", 0.1),
+	}})
+	manager := suite.manager()
+
+	change, applied, err := manager.Load(context.Background())
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), applied)
+	assert.Equal(suite.T(), []string{"edge-a", "edge-b", "edge-c"}, change.Added)
+	assert.Empty(suite.T(), change.Previous)
+	assert.Equal(suite.T(), "v1", manager.Version())
+	assert.Equal(suite.T(), 4, suite.registry.Len())
+
+	_, applied, err = manager.Load(context.Background())
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), applied, "An unmodified file is not reloaded")
+
+	suite.write(decision.ExecutorCatalog{Version: "v2", Targets: []models.OffloadTarget{
+		suite.target("edge-a", 0.1), suite.target("edge-b", 0.3), suite.target("edge-d", 0.1),
+	}})
+	change, applied, err = manager.Load(context.Background())
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), applied)
+	assert.Equal(suite.T(), decision.CatalogChange{
+		Version: "v2", Previous: "v1",
+		Added: []string{"edge-d"}, Updated: []string{"edge-b"}, Removed: []string{"edge-c"},
+		AppliedAt: change.AppliedAt,
+	}, change)
+
+	updated, _ := suite.registry.Get("edge-b")
+	assert.Equal(suite.T(), 0.3, updated.ComputeCost)
+	_, exists := suite.registry.Get("edge-c")
+	assert.False(suite.T(), exists)
+	_, exists = suite.registry.Get("manual")
+	assert.True(suite.T(), exists, "Targets registered outside the catalog are kept")
+	assert.Len(suite.T(), manager.Changes(), 2)
+}
+
+func (suite *ExecutorCatalogTestSuite) TestInvalidVersionsRefused() {
+	suite.write(decision.ExecutorCatalog{Version: "v1", Targets: []models.OffloadTarget{suite.target("edge-a", 0.1)}})
+	manager := suite.manager()
+	var reported []error
+	manager.OnChange(func(change decision.CatalogChange, err error) { reported = append(reported, err) })
+	_, _, err := manager.Load(context.Background())
+	require.NoError(suite.T(), err)
+
+	invalid := []decision.ExecutorCatalog{
+		{Targets: []models.OffloadTarget{suite.target("edge-a", 0.1)}},
+		{Version: "v2", Targets: []models.OffloadTarget{suite.target("edge-a", 0.1), suite.target("edge-a", 0.2)}},
+		{Version: "v2", Targets: []models.OffloadTarget{suite.target("edge-b", -1)}},
+		{Version: "v1", Targets: []models.OffloadTarget{suite.target("edge-a", 0.5)}},
+	}
+	for _, catalog := range invalid {
+		suite.write(catalog)
+		_, applied, err := manager.Load(context.Background())
+		assert.Error(suite.T(), err, catalog)
+		assert.False(suite.T(), applied)
+	}
+	assert.Equal(suite.T(), "v1", manager.Version())
+	target, _ := suite.registry.Get("edge-a")
+	assert.Equal(suite.T(), 0.1, target.ComputeCost)
+	assert.Equal(suite.T(), 1, suite.registry.Len())
+	assert.Len(suite.T(), reported, 5, "The applied version and every refusal are reported")
+
+	_, err = decision.NewCatalogManager(decision.ExecutorCatalogConfig{}, suite.registry)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ExecutorCatalogTestSuite) TestHTTPSource() {
+	var mu sync.Mutex
+	requests, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(decision.ExecutorCatalog{Version: "v1", Targets: []models.OffloadTarget{suite.target("edge-a", 0.1)}})
+	}))
+	defer server.Close()
+
+	manager, err := decision.NewCatalogManager(decision.ExecutorCatalogConfig{Source: server.URL}, suite.registry)
+	require.NoError(suite.T(), err)
+	_, applied, err := manager.Load(context.Background())
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), applied)
+	_, applied, err = manager.Load(context.Background())
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), applied)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(suite.T(), 2, requests)
+	assert.Equal(suite.T(), 1, notModified)
+	assert.Equal(suite.T(), "v1", manager.Version())
+}
+
+func TestExecutorCatalogSuite(t *testing.T) {
+	suite.Run(t, new(ExecutorCatalogTestSuite))
+}
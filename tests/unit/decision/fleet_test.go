@@ -0,0 +1,93 @@
+package decision_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+)
+
+// Fleet optimization test requirements:
+// 1. The fleet must cover the required CPU and memory at the lowest cost,
+//    mixing instance types when that is cheaper than a single type
+// 2. Instance types that start too slowly must not be used
+// 3. Spot instances must cover at most the allowed share of the CPU
+// 4. Instance limits must be respected, and unmeetable requirements rejected
+
+type FleetTestSuite struct {
+	suite.Suite
+	types []decision.InstanceType
+}
+
+func (suite *FleetTestSuite) SetupTest() {
+	suite.types = []decision.InstanceType{
+		{Name: "small", CPU: 2, Memory: 4 << 30, CostPerHour: 0.10, StartupTime: 30 * time.Second},
+		{Name: "large", CPU: 8, Memory: 16 << 30, CostPerHour: 0.36, StartupTime: 2 * time.Minute},
+		{Name: "large-spot", CPU: 8, Memory: 16 << 30, CostPerHour: 0.12, StartupTime: 2 * time.Minute, Spot: true},
+	}
+}
+
+func (suite *FleetTestSuite) TestCheapestMix() {
+	// 18 cores on demand: 2 large + 1 small (0.82) beats 3 large (1.08) and 9 small (0.90)
+	plan, err := decision.OptimizeFleet(suite.types, decision.FleetRequirement{CPU: 18, Memory: 8 << 30})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []decision.FleetAllocation{
+		{InstanceType: "large", Count: 2},
+		{InstanceType: "small", Count: 1},
+	}, plan.Allocations)
+	assert.InDelta(suite.T(), 0.82, plan.CostPerHour, 1e-9)
+	assert.Equal(suite.T(), 18.0, plan.CPU)
+	assert.Equal(suite.T(), 3, plan.Instances)
+	assert.Equal(suite.T(), 2*time.Minute, plan.StartupTime)
+
+	// Memory can dominate the fleet size
+	plan, err = decision.OptimizeFleet(suite.types[:2], decision.FleetRequirement{CPU: 2, Memory: 16 << 30})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []decision.FleetAllocation{{InstanceType: "large", Count: 1}}, plan.Allocations)
+}
+
+func (suite *FleetTestSuite) TestStartupTimeConstraint() {
+	plan, err := decision.OptimizeFleet(suite.types, decision.FleetRequirement{CPU: 18, MaxStartupTime: time.Minute})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []decision.FleetAllocation{{InstanceType: "small", Count: 9}}, plan.Allocations)
+	assert.Equal(suite.T(), 30*time.Second, plan.StartupTime)
+}
+
+func (suite *FleetTestSuite) TestSpotShare() {
+	plan, err := decision.OptimizeFleet(suite.types, decision.FleetRequirement{CPU: 16, MaxSpotShare: 0.5})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []decision.FleetAllocation{
+		{InstanceType: "large", Count: 1},
+		{InstanceType: "large-spot", Count: 1},
+	}, plan.Allocations, "Half the cores on spot, the rest on demand")
+	assert.InDelta(suite.T(), 0.48, plan.CostPerHour, 1e-9)
+
+	plan, err = decision.OptimizeFleet(suite.types, decision.FleetRequirement{CPU: 16, MaxSpotShare: 1})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []decision.FleetAllocation{{InstanceType: "large-spot", Count: 2}}, plan.Allocations)
+}
+
+func (suite *FleetTestSuite) TestLimits() {
+	suite.types[0].MaxCount = 2
+	plan, err := decision.OptimizeFleet(suite.types[:1], decision.FleetRequirement{CPU: 4})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, plan.Instances)
+
+	_, err = decision.OptimizeFleet(suite.types[:1], decision.FleetRequirement{CPU: 6})
+	assert.Error(suite.T(), err, "Two small instances cannot provide six cores")
+
+	_, err = decision.OptimizeFleet(suite.types, decision.FleetRequirement{CPU: 4, MaxSpotShare: 2})
+	assert.Error(suite.T(), err)
+
+	plan, err = decision.OptimizeFleet(nil, decision.FleetRequirement{})
+	require.NoError(suite.T(), err, "Nothing required needs no instances")
+	assert.Zero(suite.T(), plan.Instances)
+}
+
+func TestFleetSuite(t *testing.T) {
+	suite.Run(t, new(FleetTestSuite))
+}
@@ -0,0 +1,129 @@
+package decision_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Custom objective test requirements:
+// 1. Objectives must be registered under unique names distinct from built-in factors
+// 2. Configured objectives must be resolved by name and rejected if unknown
+// 3. Objectives must take their share of the score, leaving the rest to built-in factors
+// 4. Objective values must be clamped to [0, 1]
+// 5. Weighted scores must not depend on map iteration order
+
+type ObjectiveTestSuite struct {
+	suite.Suite
+	targets []models.OffloadTarget
+	process models.Process
+	state   models.SystemState
+}
+
+func (suite *ObjectiveTestSuite) SetupTest() {
+	target := func(id, location string) models.OffloadTarget {
+		return models.OffloadTarget{
+			ID:                id,
+			Type:              models.EDGE,
+			Location:          location,
+			TotalCapacity:     8.0,
+			AvailableCapacity: 6.0,
+			MemoryTotal:       16 * 1024 * mb,
+			MemoryAvailable:   10 * 1024 * mb,
+			NetworkLatency:    10 * time.Millisecond,
+			NetworkBandwidth:  100 * mb,
+			NetworkStability:  0.95,
+			ProcessingSpeed:   1.0,
+			Reliability:       0.95,
+			SecurityLevel:     5,
+			LastSeen:          time.Now(),
+		}
+	}
+	suite.targets = []models.OffloadTarget{target("edge-1", "north"), target("edge-2", "south")}
+	suite.process = models.Process{
+		ID:                "p1",
+		CPURequirement:    1.0,
+		MemoryRequirement: mb,
+		InputSize:         mb,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+	suite.state = models.SystemState{QueueDepth: 25, QueueThreshold: 20, ComputeUsage: 0.8, MemoryUsage: 0.6, Timestamp: time.Now()}
+
+	require.NoError(suite.T(), decision.RegisterObjective(decision.NewObjectiveMetric("prefer-south",
+		func(process models.Process, target models.OffloadTarget, state models.SystemState) float64 {
+			if target.Location == "south" {
+				return 2.0 // Clamped to 1
+			}
+			return 0.0
+		})))
+}
+
+func (suite *ObjectiveTestSuite) TearDownTest() {
+	decision.UnregisterObjective("prefer-south")
+}
+
+func (suite *ObjectiveTestSuite) TestRegistration() {
+	assert.Contains(suite.T(), decision.RegisteredObjectives(), "prefer-south")
+
+	constant := func(models.Process, models.OffloadTarget, models.SystemState) float64 { return 1 }
+	assert.Error(suite.T(), decision.RegisterObjective(decision.NewObjectiveMetric("prefer-south", constant)), "Duplicate")
+	assert.Error(suite.T(), decision.RegisterObjective(decision.NewObjectiveMetric("NetworkCost", constant)), "Built-in")
+	assert.Error(suite.T(), decision.RegisterObjective(decision.NewObjectiveMetric("", constant)))
+
+	engine := decision.NewDecisionEngine(decision.AdaptiveWeights{})
+	assert.Error(suite.T(), engine.SetObjectives([]decision.ObjectiveGoal{{Name: "unknown", Weight: 0.5}}))
+	assert.Error(suite.T(), engine.SetObjectives([]decision.ObjectiveGoal{{Name: "prefer-south", Weight: 1.0}}))
+	assert.NoError(suite.T(), engine.SetObjectives(nil))
+}
+
+func (suite *ObjectiveTestSuite) TestScoreShare() {
+	engine := decision.NewDecisionEngine(decision.AdaptiveWeights{LatencyCost: 1.0})
+	before := engine.ExplainCandidates(suite.process, suite.targets, suite.state)
+	require.Len(suite.T(), before, 2)
+	assert.InDelta(suite.T(), before[0].Score, before[1].Score, 1e-9, "Identical targets tie on built-in factors")
+
+	require.NoError(suite.T(), engine.SetObjectives([]decision.ObjectiveGoal{{Name: "prefer-south", Weight: 0.4}}))
+	after := engine.ExplainCandidates(suite.process, suite.targets, suite.state)
+	assert.InDelta(suite.T(), 0.6*before[0].Score, after[0].Score, 1e-9)
+	assert.InDelta(suite.T(), 0.6*before[1].Score+0.4, after[1].Score, 1e-9)
+	assert.Equal(suite.T(), 1.0, after[1].Components.Objectives["prefer-south"])
+	assert.InDelta(suite.T(), 0.4, after[1].Contributions["prefer-south"], 1e-9)
+
+	dec, err := engine.MakeDecision(suite.process, suite.targets, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), "edge-2", dec.Target.ID)
+
+	// Custom objectives are not attributed; the built-in factors are equal
+	attribution := decision.ShapleyAttribution(after[1].Components, after[0].Components)
+	total := 0.0
+	for _, value := range attribution {
+		total += value
+	}
+	assert.InDelta(suite.T(), 0.0, total, 1e-9)
+}
+
+func (suite *ObjectiveTestSuite) TestDeterministicScore() {
+	breakdown := decision.ScoreBreakdown{
+		QueueImpact: 0.7, LoadBalance: 0.3, NetworkCost: 0.9, LatencyImpact: 0.1, EnergyImpact: 0.6, PolicyMatch: 1,
+		WeightsUsed:      decision.AdaptiveWeights{QueueDepth: 0.2, ProcessorLoad: 0.2, NetworkCost: 0.2, LatencyCost: 0.2, EnergyCost: 0.1, PolicyCost: 0.1},
+		Objectives:       map[string]float64{"a": 0.1, "b": 0.3, "c": 0.7, "d": 0.9, "e": 0.11, "f": 0.13},
+		ObjectiveWeights: map[string]float64{"a": 0.01, "b": 0.03, "c": 0.07, "d": 0.11, "e": 0.013, "f": 0.017},
+	}
+	score := breakdown.WeightedScore()
+	for i := 0; i < 1000; i++ {
+		require.Equal(suite.T(), score, breakdown.WeightedScore())
+	}
+}
+
+func TestObjectiveSuite(t *testing.T) {
+	suite.Run(t, new(ObjectiveTestSuite))
+}
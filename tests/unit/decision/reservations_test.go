@@ -0,0 +1,209 @@
+package decision_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Reservation ledger test requirements:
+// 1. Capacity reserved by an offload must be taken off the targets offered to
+//    later decisions, so a nearly full target is not picked twice
+// 2. Gangs and split workloads must reserve capacity on every target they use
+// 3. Settled reservations must no longer be taken off their target, and
+//    reservations must end when released or past their TTL
+// 4. Concurrent decisions must not reserve more capacity than a target has,
+//    falling back to the next best target instead
+
+const gb = 1024 * mb
+
+type ReservationTestSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func (suite *ReservationTestSuite) SetupTest() {
+	suite.start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *ReservationTestSuite) target(id string, available float64, latency time.Duration) models.OffloadTarget {
+	return models.OffloadTarget{
+		ID:                id,
+		Type:              models.EDGE,
+		TotalCapacity:     8.0,
+		AvailableCapacity: available,
+		MemoryTotal:       16 * gb,
+		MemoryAvailable:   8 * gb,
+		NetworkLatency:    latency,
+		NetworkBandwidth:  10 * mb,
+		NetworkStability:  0.95,
+		ProcessingSpeed:   1.0,
+		Reliability:       0.95,
+		SecurityLevel:     5,
+		LastSeen:          time.Now(),
+	}
+}
+
+func (suite *ReservationTestSuite) process(id string) models.Process {
+	return models.Process{
+		ID:                id,
+		CPURequirement:    1.0,
+		MemoryRequirement: gb,
+		InputSize:         mb,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+}
+
+func (suite *ReservationTestSuite) TestNoDoubleBooking() {
+	ledger := decision.NewReservationLedger(decision.ReservationConfig{})
+	engine := decision.NewDecisionEngine(decision.AdaptiveWeights{})
+	engine.SetReservations(ledger)
+	state := models.SystemState{QueueDepth: 25, QueueThreshold: 20, ComputeUsage: 0.8, MemoryUsage: 0.6, Timestamp: time.Now()}
+	targets := []models.OffloadTarget{
+		suite.target("edge-near", 1.5, 5*time.Millisecond),
+		suite.target("edge-far", 6.0, 80*time.Millisecond),
+	}
+
+	first, err := engine.MakeDecision(suite.process("p1"), targets, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), first.ShouldOffload)
+	require.Equal(suite.T(), "edge-near", first.Target.ID)
+
+	second, err := engine.MakeDecision(suite.process("p2"), targets, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), second.ShouldOffload)
+	assert.Equal(suite.T(), "edge-far", second.Target.ID, "The reserved target has no room left")
+
+	cpu, memory := ledger.Reserved("edge-near")
+	assert.Equal(suite.T(), 1.0, cpu)
+	assert.Equal(suite.T(), int64(gb), memory)
+
+	engine.ReleaseReservation("p1")
+	engine.ReleaseReservation("p2")
+	third, err := engine.MakeDecision(suite.process("p3"), targets, state)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "edge-near", third.Target.ID)
+}
+
+func (suite *ReservationTestSuite) TestGangsAndShards() {
+	ledger := decision.NewReservationLedger(decision.ReservationConfig{})
+	a, b := suite.target("edge-a", 6, 0), suite.target("edge-b", 6, 0)
+
+	ledger.Reserve(suite.process("gang"), decision.OffloadDecision{
+		ShouldOffload: true,
+		Target:        &a,
+		Gang:          []decision.GangAllocation{{Target: &a, Slots: 3}, {Target: &b, Slots: 1}},
+	}, suite.start)
+	shard := suite.process("split")
+	shard.CPURequirement = 0.5
+	shard.MemoryRequirement = gb / 2
+	ledger.Reserve(suite.process("split"), decision.OffloadDecision{
+		ShouldOffload: true,
+		Target:        &a,
+		Shards:        []decision.WorkloadShard{{Target: &a, Process: shard}, {Target: &b, Process: shard}},
+	}, suite.start)
+	ledger.Reserve(suite.process("local"), decision.OffloadDecision{}, suite.start)
+
+	applied := ledger.ApplyTargets([]models.OffloadTarget{a, b}, suite.start)
+	assert.Equal(suite.T(), 2.5, applied[0].AvailableCapacity)
+	assert.Equal(suite.T(), int64(8*gb-3*gb-gb/2), applied[0].MemoryAvailable)
+	assert.InDelta(suite.T(), 1.0-2.5/8.0, applied[0].CurrentLoad, 1e-9)
+	assert.Equal(suite.T(), 4.5, applied[1].AvailableCapacity)
+	assert.Equal(suite.T(), 6.0, a.AvailableCapacity, "The offered targets are not modified")
+
+	assert.Len(suite.T(), ledger.Reservations(), 4)
+	assert.Equal(suite.T(), 2, ledger.Stats().Active)
+}
+
+func (suite *ReservationTestSuite) TestSettleAndExpire() {
+	ledger := decision.NewReservationLedger(decision.ReservationConfig{TTL: time.Minute})
+	target := suite.target("edge-a", 6, 0)
+	offload := func(id string, at time.Time) {
+		ledger.Reserve(suite.process(id), decision.OffloadDecision{ShouldOffload: true, Target: &target}, at)
+	}
+
+	offload("p1", suite.start)
+	offload("p2", suite.start.Add(10*time.Second))
+	ledger.Settle("edge-a", suite.start.Add(5*time.Second))
+	cpu, _ := ledger.Reserved("edge-a")
+	assert.Equal(suite.T(), 1.0, cpu, "Only the reservation made after the reading is unsettled")
+	assert.Equal(suite.T(), 2, ledger.Stats().Active, "Settled reservations are held until released")
+
+	assert.True(suite.T(), ledger.Release("p2"))
+	assert.False(suite.T(), ledger.Release("p2"))
+
+	offload("p3", suite.start.Add(90*time.Second))
+	applied := ledger.ApplyTargets([]models.OffloadTarget{target}, suite.start.Add(2*time.Minute))
+	assert.Equal(suite.T(), 5.0, applied[0].AvailableCapacity)
+
+	stats := ledger.Stats()
+	assert.Equal(suite.T(), int64(3), stats.Reserved)
+	assert.Equal(suite.T(), int64(1), stats.Released)
+	assert.Equal(suite.T(), int64(1), stats.Expired)
+	assert.Equal(suite.T(), 1, stats.Active)
+
+	assert.Error(suite.T(), decision.ReservationConfig{TTL: -time.Second}.Validate())
+}
+
+func (suite *ReservationTestSuite) TestConcurrentDecisions() {
+	ledger := decision.NewReservationLedger(decision.ReservationConfig{})
+	target := suite.target("edge-a", 1.5, 0)
+	offload := decision.OffloadDecision{ShouldOffload: true, Target: &target}
+
+	_, reserved := ledger.TryReserve(suite.process("p1"), offload, []models.OffloadTarget{target}, suite.start)
+	require.True(suite.T(), reserved)
+	conflict, reserved := ledger.TryReserve(suite.process("p2"), offload, []models.OffloadTarget{target}, suite.start)
+	assert.False(suite.T(), reserved, "A decision made before p1 reserved finds no room left")
+	assert.Equal(suite.T(), "edge-a", conflict)
+	_, reserved = ledger.TryReserve(suite.process("p1"), offload, []models.OffloadTarget{target}, suite.start)
+	assert.True(suite.T(), reserved, "A process's own reservation is replaced")
+	assert.Equal(suite.T(), int64(2), ledger.Stats().Reserved)
+
+	ledger = decision.NewReservationLedger(decision.ReservationConfig{})
+	engine := decision.NewDecisionEngine(decision.AdaptiveWeights{})
+	engine.SetReservations(ledger)
+	state := models.SystemState{QueueDepth: 25, QueueThreshold: 20, ComputeUsage: 0.8, MemoryUsage: 0.6, Timestamp: time.Now()}
+	targets := []models.OffloadTarget{
+		suite.target("edge-near", 3.0, 5*time.Millisecond),
+		suite.target("edge-far", 6.0, 80*time.Millisecond),
+	}
+
+	const decisions = 8
+	var wg sync.WaitGroup
+	placed := make([]string, decisions)
+	for i := 0; i < decisions; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			dec, err := engine.MakeDecision(suite.process(fmt.Sprintf("p%d", i)), targets, state)
+			if err == nil && dec.ShouldOffload {
+				placed[i] = dec.Target.ID
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	counts := make(map[string]int)
+	for _, targetID := range placed {
+		counts[targetID]++
+	}
+	assert.Equal(suite.T(), 3, counts["edge-near"], "Only as many as fit are placed on the nearest target")
+	assert.Equal(suite.T(), decisions-3, counts["edge-far"])
+	cpu, _ := ledger.Reserved("edge-near")
+	assert.Equal(suite.T(), 3.0, cpu)
+	assert.Equal(suite.T(), decisions, ledger.Stats().Active)
+}
+
+func TestReservationSuite(t *testing.T) {
+	suite.Run(t, new(ReservationTestSuite))
+}
@@ -0,0 +1,170 @@
+package decision_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Parallel scoring test requirements:
+// 1. Parallel scoring must select the same target as sequential scoring
+// 2. An expired budget must still score the most promising target
+// 3. Benchmarks must cover sequential and parallel paths for large fleets
+
+type ScoringTestSuite struct {
+	suite.Suite
+	weights decision.AdaptiveWeights
+	process models.Process
+	state   models.SystemState
+}
+
+func (suite *ScoringTestSuite) SetupTest() {
+	suite.weights = decision.AdaptiveWeights{
+		QueueDepth:    0.2,
+		ProcessorLoad: 0.2,
+		NetworkCost:   0.2,
+		LatencyCost:   0.2,
+		EnergyCost:    0.1,
+		PolicyCost:    0.1,
+	}
+	suite.process = scoringProcess()
+	suite.state = scoringState()
+}
+
+func (suite *ScoringTestSuite) TestParallelMatchesSequential() {
+	targets := scoringFleet(1000)
+
+	sequential := decision.NewDecisionEngine(suite.weights)
+	sequential.SetScoringConfig(decision.ScoringConfig{Parallel: false})
+	parallel := decision.NewDecisionEngine(suite.weights)
+	parallel.SetScoringConfig(decision.ScoringConfig{Parallel: true, Threshold: 100, Workers: 4, ChunkSize: 32})
+
+	expected, err := sequential.MakeDecision(suite.process, targets, suite.state)
+	require.NoError(suite.T(), err)
+	actual, err := parallel.MakeDecision(suite.process, targets, suite.state)
+	require.NoError(suite.T(), err)
+
+	require.True(suite.T(), expected.ShouldOffload)
+	require.True(suite.T(), actual.ShouldOffload)
+	assert.Equal(suite.T(), expected.Target.ID, actual.Target.ID)
+	assert.Equal(suite.T(), expected.Score, actual.Score)
+	assert.Equal(suite.T(), len(targets), actual.TargetsEvaluated)
+	assert.False(suite.T(), actual.BudgetExhausted)
+}
+
+func (suite *ScoringTestSuite) TestParallelRespectsBudget() {
+	engine := decision.NewDecisionEngine(suite.weights)
+	engine.SetScoringConfig(decision.ScoringConfig{Parallel: true, Threshold: 100, Workers: 4, ChunkSize: 32})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dec, err := engine.MakeDecisionContext(ctx, suite.process, scoringFleet(1000), suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.True(suite.T(), dec.BudgetExhausted)
+	assert.Equal(suite.T(), 1, dec.TargetsEvaluated, "Only the most promising target is scored")
+}
+
+func TestScoringSuite(t *testing.T) {
+	suite.Run(t, new(ScoringTestSuite))
+}
+
+func BenchmarkDecisionSequential1000(b *testing.B) {
+	benchmarkDecision(b, 1000, decision.ScoringConfig{Parallel: false})
+}
+
+func BenchmarkDecisionParallel1000(b *testing.B) {
+	benchmarkDecision(b, 1000, decision.DefaultScoringConfig())
+}
+
+func BenchmarkDecisionSequential10000(b *testing.B) {
+	benchmarkDecision(b, 10000, decision.ScoringConfig{Parallel: false})
+}
+
+func BenchmarkDecisionParallel10000(b *testing.B) {
+	benchmarkDecision(b, 10000, decision.DefaultScoringConfig())
+}
+
+func benchmarkDecision(b *testing.B, fleetSize int, config decision.ScoringConfig) {
+	engine := decision.NewDecisionEngine(decision.AdaptiveWeights{
+		QueueDepth:    0.2,
+		ProcessorLoad: 0.2,
+		NetworkCost:   0.2,
+		LatencyCost:   0.2,
+		EnergyCost:    0.1,
+		PolicyCost:    0.1,
+	})
+	engine.SetScoringConfig(config)
+
+	process := scoringProcess()
+	state := scoringState()
+	targets := scoringFleet(fleetSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := engine.MakeDecision(process, targets, state); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func scoringProcess() models.Process {
+	return models.Process{
+		ID:                "scoring-1",
+		CPURequirement:    1.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         10 * 1024 * 1024,
+		OutputSize:        1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+}
+
+func scoringState() models.SystemState {
+	return models.SystemState{
+		QueueDepth:     30,
+		QueueThreshold: 20,
+		ComputeUsage:   0.85,
+		MemoryUsage:    0.70,
+		NetworkUsage:   0.20,
+		MasterUsage:    0.20,
+		Timestamp:      time.Now(),
+		TimeSlot:       12,
+		DayOfWeek:      3,
+	}
+}
+
+func scoringFleet(size int) []models.OffloadTarget {
+	targets := make([]models.OffloadTarget, 0, size)
+	for i := 0; i < size; i++ {
+		targets = append(targets, models.OffloadTarget{
+			ID:                fmt.Sprintf("edge-%04d", i),
+			Type:              models.EDGE,
+			TotalCapacity:     8.0,
+			AvailableCapacity: 6.0,
+			MemoryTotal:       16 * 1024 * 1024 * 1024,
+			MemoryAvailable:   10 * 1024 * 1024 * 1024,
+			NetworkLatency:    time.Duration(10+i%200) * time.Millisecond,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			NetworkStability:  0.95,
+			ProcessingSpeed:   1.0 + float64(i%7)/10,
+			Reliability:       0.95,
+			ComputeCost:       0.10,
+			EnergyCost:        float64(i % 5),
+			CurrentLoad:       float64(i%10) / 20,
+			SecurityLevel:     3,
+			LastSeen:          time.Now(),
+		})
+	}
+	return targets
+}
@@ -0,0 +1,113 @@
+package decision_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Storage tier test requirements:
+// 1. Retrieval time must be the tier latency plus the input over its throughput
+// 2. Retrieval cost must scale with the input size
+// 3. Decisions must carry retrieval time and cost for the input's tier
+// 4. Tier overrides must be validated and replace the defaults
+
+type StorageTierTestSuite struct {
+	suite.Suite
+	target models.OffloadTarget
+	state  models.SystemState
+}
+
+func (suite *StorageTierTestSuite) SetupTest() {
+	suite.target = models.OffloadTarget{
+		ID:                "edge-1",
+		Type:              models.EDGE,
+		TotalCapacity:     8.0,
+		AvailableCapacity: 6.0,
+		MemoryTotal:       16 * 1024 * mb,
+		MemoryAvailable:   10 * 1024 * mb,
+		NetworkLatency:    10 * time.Millisecond,
+		NetworkBandwidth:  100 * mb,
+		NetworkStability:  0.95,
+		ProcessingSpeed:   1.0,
+		Reliability:       0.95,
+		SecurityLevel:     5,
+		LastSeen:          time.Now(),
+	}
+	suite.state = models.SystemState{QueueDepth: 25, QueueThreshold: 20, ComputeUsage: 0.8, MemoryUsage: 0.6, Timestamp: time.Now()}
+}
+
+func (suite *StorageTierTestSuite) process(tier models.StorageTier) models.Process {
+	return models.Process{
+		ID:                "p1",
+		CPURequirement:    1.0,
+		MemoryRequirement: mb,
+		InputSize:         1024 * mb,
+		InputStorageTier:  tier,
+		EstimatedDuration: 5 * time.Minute,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+}
+
+func (suite *StorageTierTestSuite) TestRetrievalSpec() {
+	spec := models.StorageTierSpec{RetrievalLatency: 50 * time.Millisecond, Throughput: 100 * mb, CostPerGB: 0.01}
+
+	assert.Equal(suite.T(), 50*time.Millisecond+2*time.Second, spec.RetrievalTime(200*mb))
+	assert.Equal(suite.T(), time.Duration(0), spec.RetrievalTime(0), "Nothing to retrieve")
+	assert.InDelta(suite.T(), 0.02, spec.RetrievalCost(2048*mb), 1e-9)
+	assert.Error(suite.T(), models.StorageTierSpec{Throughput: -1}.Validate())
+}
+
+func (suite *StorageTierTestSuite) TestDecisionRetrieval() {
+	engine := decision.NewDecisionEngine(decision.AdaptiveWeights{})
+
+	hot, err := engine.MakeDecision(suite.process(models.HOT_STORAGE), []models.OffloadTarget{suite.target}, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), hot.ShouldOffload)
+
+	cold, err := engine.MakeDecision(suite.process(models.COLD_STORAGE), []models.OffloadTarget{suite.target}, suite.state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), cold.ShouldOffload)
+
+	// 1GB from cold storage: 100ms latency plus 10.24s at 100MB/s
+	assert.Equal(suite.T(), 100*time.Millisecond+10240*time.Millisecond, cold.RetrievalTime)
+	assert.Less(suite.T(), hot.RetrievalTime, time.Second)
+	assert.InDelta(suite.T(), 0.01, cold.EstimatedCost-hot.EstimatedCost, 1e-9,
+		"Cold retrieval costs 0.01 per GB more than hot")
+
+	untiered, err := engine.MakeDecision(suite.process(""), []models.OffloadTarget{suite.target}, suite.state)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), time.Duration(0), untiered.RetrievalTime, "Tier not modeled")
+}
+
+func (suite *StorageTierTestSuite) TestTierOverrides() {
+	engine := decision.NewDecisionEngine(decision.AdaptiveWeights{})
+	engine.SetStorageTiers(map[models.StorageTier]models.StorageTierSpec{
+		models.ARCHIVE_STORAGE: {RetrievalLatency: time.Minute},
+	})
+
+	archived, err := engine.MakeDecision(suite.process(models.ARCHIVE_STORAGE), []models.OffloadTarget{suite.target}, suite.state)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), time.Minute, archived.RetrievalTime, "Zero throughput does not limit retrieval")
+
+	explanations := engine.ExplainCandidates(suite.process(models.WARM_STORAGE), []models.OffloadTarget{suite.target}, suite.state)
+	require.Len(suite.T(), explanations, 1)
+	assert.Equal(suite.T(), models.WARM_STORAGE, explanations[0].DataGravity.StorageTier)
+	assert.Greater(suite.T(), explanations[0].DataGravity.RetrievalTime, 20*time.Millisecond, "Other tiers keep their defaults")
+
+	config := decision.TransferConfig{StorageTiers: map[models.StorageTier]models.StorageTierSpec{"tape": {}}}
+	assert.Error(suite.T(), config.Validate())
+	config.StorageTiers = map[models.StorageTier]models.StorageTierSpec{models.COLD_STORAGE: {CostPerGB: -1}}
+	assert.Error(suite.T(), config.Validate())
+}
+
+func TestStorageTierSuite(t *testing.T) {
+	suite.Run(t, new(StorageTierTestSuite))
+}
@@ -0,0 +1,136 @@
+package decision_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// TargetRegistry test requirements:
+// 1. Queries must return exactly the targets matching every constraint
+// 2. Updating or removing a target must keep the indexes consistent
+// 3. Process placement constraints must narrow the candidate set, including
+//    the capabilities requirements AND together and the jurisdictions
+//    process data may be transferred to
+
+type TargetRegistryTestSuite struct {
+	suite.Suite
+	registry *decision.TargetRegistry
+}
+
+func (suite *TargetRegistryTestSuite) SetupTest() {
+	suite.registry = decision.NewTargetRegistry()
+
+	types := []models.TargetType{models.LOCAL, models.EDGE, models.PRIVATE_CLOUD, models.PUBLIC_CLOUD}
+	regions := []string{"eu-north", "eu-west", "us-east"}
+	for i := 0; i < 1000; i++ {
+		target := models.OffloadTarget{
+			ID:               fmt.Sprintf("target-%04d", i),
+			Type:             types[i%len(types)],
+			Location:         regions[i%len(regions)],
+			DataJurisdiction: "EU",
+			LastSeen:         time.Now(),
+		}
+		if target.Location == "us-east" {
+			target.DataJurisdiction = "US"
+		}
+		if i%10 == 0 {
+			target.Capabilities = []string{"gpu"}
+		}
+		suite.registry.Upsert(target)
+	}
+}
+
+func (suite *TargetRegistryTestSuite) TestQueryIntersectsConstraints() {
+	assert.Equal(suite.T(), 1000, suite.registry.Len())
+	assert.Len(suite.T(), suite.registry.All(), 1000)
+
+	gpus := suite.registry.Query(decision.TargetQuery{Capabilities: []string{"gpu"}})
+	assert.Len(suite.T(), gpus, 100)
+
+	results := suite.registry.Query(decision.TargetQuery{
+		Types:         []models.TargetType{models.PRIVATE_CLOUD},
+		Capabilities:  []string{"gpu"},
+		Jurisdictions: []string{"EU"},
+	})
+	require.NotEmpty(suite.T(), results)
+	for _, target := range results {
+		assert.Equal(suite.T(), models.PRIVATE_CLOUD, target.Type)
+		assert.True(suite.T(), target.HasCapability("gpu"))
+		assert.Equal(suite.T(), "EU", target.DataJurisdiction)
+	}
+
+	regions := suite.registry.Query(decision.TargetQuery{Regions: []string{"eu-north", "eu-west"}})
+	assert.Len(suite.T(), regions, 667)
+
+	assert.Empty(suite.T(), suite.registry.Query(decision.TargetQuery{Capabilities: []string{"fpga"}}))
+}
+
+func (suite *TargetRegistryTestSuite) TestUpsertAndRemoveKeepIndexesConsistent() {
+	target, exists := suite.registry.Get("target-0000")
+	require.True(suite.T(), exists)
+
+	target.Capabilities = nil
+	target.Location = "ap-south"
+	suite.registry.Upsert(target)
+
+	assert.Len(suite.T(), suite.registry.Query(decision.TargetQuery{Capabilities: []string{"gpu"}}), 99)
+	southern := suite.registry.Query(decision.TargetQuery{Regions: []string{"ap-south"}})
+	require.Len(suite.T(), southern, 1)
+	assert.Equal(suite.T(), "target-0000", southern[0].ID)
+
+	suite.registry.Remove("target-0000")
+	assert.Equal(suite.T(), 999, suite.registry.Len())
+	assert.Empty(suite.T(), suite.registry.Query(decision.TargetQuery{Regions: []string{"ap-south"}}))
+}
+
+func (suite *TargetRegistryTestSuite) TestCandidatesForProcess() {
+	critical := suite.registry.CandidatesFor(models.Process{SafetyCritical: true})
+	assert.Len(suite.T(), critical, 250)
+	for _, target := range critical {
+		assert.Equal(suite.T(), models.LOCAL, target.Type)
+	}
+
+	local := suite.registry.CandidatesFor(models.Process{LocalityRequired: true})
+	assert.Len(suite.T(), local, 500)
+
+	assert.Len(suite.T(), suite.registry.CandidatesFor(models.Process{}), 1000)
+
+	for i := 0; i < 1000; i += 10 {
+		target, _ := suite.registry.Get(fmt.Sprintf("target-%04d", i))
+		target.Capabilities = []string{"gpu_accelerated"}
+		if i%20 == 0 {
+			target.Capabilities = append(target.Capabilities, "ssd_storage")
+		}
+		suite.registry.Upsert(target)
+	}
+	accelerated := suite.registry.CandidatesFor(models.Process{Requirements: "gpu_accelerated AND ssd_storage AND (low_latency OR NOT trusted_enclave)"})
+	assert.Len(suite.T(), accelerated, 50, "Only capabilities ANDed at the top level narrow candidates")
+	for _, target := range accelerated {
+		assert.True(suite.T(), target.HasCapability("ssd_storage"))
+	}
+	assert.Len(suite.T(), suite.registry.CandidatesFor(models.Process{Requirements: "gpu_accelerated OR ssd_storage"}), 1000)
+
+	graph, err := models.NewJurisdictionGraph(models.TransferEdge{From: "EU", To: "US", RequiresSCC: true})
+	require.NoError(suite.T(), err)
+	suite.registry.SetJurisdictionGraph(graph)
+	resident := suite.registry.CandidatesFor(models.Process{DataResidency: []string{"EU"}})
+	assert.Len(suite.T(), resident, 667)
+	for _, target := range resident {
+		assert.Equal(suite.T(), "EU", target.DataJurisdiction)
+	}
+	assert.Len(suite.T(), suite.registry.CandidatesFor(models.Process{DataResidency: []string{"EU"}, SCCApproved: true}), 1000)
+	assert.Empty(suite.T(), suite.registry.CandidatesFor(models.Process{DataResidency: []string{"CN"}}))
+	assert.Len(suite.T(), suite.registry.CandidatesFor(models.Process{}), 1000, "Processes without residency are unrestricted")
+}
+
+func TestTargetRegistrySuite(t *testing.T) {
+	suite.Run(t, new(TargetRegistryTestSuite))
+}
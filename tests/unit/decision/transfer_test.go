@@ -0,0 +1,157 @@
+package decision_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Transfer estimation test requirements:
+// 1. A lone transfer must take its size over the link capacity
+// 2. Concurrent transfers must share the link, and progress as time passes
+// 3. Transfers beyond the concurrency limit must queue
+// 4. Hourly congestion must reduce the capacity available to transfers
+// 5. Decisions must carry transfer times from the model
+
+const mb = 1024 * 1024
+
+type TransferTestSuite struct {
+	suite.Suite
+	target models.OffloadTarget
+	start  time.Time
+}
+
+func (suite *TransferTestSuite) SetupTest() {
+	suite.target = models.OffloadTarget{ID: "cloud-1", NetworkBandwidth: 100 * mb}
+	suite.start = time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+}
+
+func (suite *TransferTestSuite) estimator(config decision.TransferConfig) *decision.TransferEstimator {
+	estimator, err := decision.NewTransferEstimator(config)
+	require.NoError(suite.T(), err)
+	return estimator
+}
+
+func (suite *TransferTestSuite) TestSingleTransfer() {
+	estimator := suite.estimator(decision.TransferConfig{})
+
+	assert.Equal(suite.T(), 2*time.Second, estimator.Estimate(suite.target, 200*mb, suite.start).Round(time.Millisecond))
+	assert.Equal(suite.T(), time.Duration(0), estimator.Estimate(suite.target, 0, suite.start))
+	assert.Equal(suite.T(), time.Duration(0), estimator.Estimate(models.OffloadTarget{ID: "unknown"}, mb, suite.start),
+		"Targets without bandwidth have no estimate")
+}
+
+func (suite *TransferTestSuite) TestSharedLink() {
+	estimator := suite.estimator(decision.TransferConfig{
+		Links: []decision.LinkConfig{{ID: "wan", Capacity: 100 * mb, Targets: []string{"cloud-1", "cloud-2"}}},
+	})
+	other := models.OffloadTarget{ID: "cloud-2", NetworkBandwidth: 1000 * mb}
+
+	estimator.Start("p1", other, 100*mb, suite.start)
+	// 100MB each at half capacity: both finish after 2s
+	assert.Equal(suite.T(), 2*time.Second, estimator.Estimate(suite.target, 100*mb, suite.start).Round(time.Millisecond))
+
+	// After 0.5s, 50MB of p1 remain: shared for 1s, then 50MB alone for 0.5s
+	later := suite.start.Add(500 * time.Millisecond)
+	assert.Equal(suite.T(), 1500*time.Millisecond, estimator.Estimate(suite.target, 100*mb, later).Round(time.Millisecond))
+
+	active, _ := estimator.InFlight(suite.target, suite.start.Add(2*time.Second))
+	assert.Equal(suite.T(), 0, active, "Transfers complete on their own")
+
+	estimator.Start("p2", other, 100*mb, suite.start.Add(3*time.Second))
+	estimator.Complete("p2")
+	assert.Equal(suite.T(), time.Second, estimator.Estimate(suite.target, 100*mb, suite.start.Add(3*time.Second)).Round(time.Millisecond))
+}
+
+func (suite *TransferTestSuite) TestQueuing() {
+	estimator := suite.estimator(decision.TransferConfig{
+		Links: []decision.LinkConfig{{ID: "wan", Capacity: 100 * mb, MaxConcurrent: 1, Targets: []string{"cloud-1"}}},
+	})
+	estimator.Start("p1", suite.target, 100*mb, suite.start)
+	estimator.Start("p2", suite.target, 100*mb, suite.start)
+
+	active, queued := estimator.InFlight(suite.target, suite.start)
+	assert.Equal(suite.T(), 1, active)
+	assert.Equal(suite.T(), 1, queued)
+
+	// Waits for both transfers ahead of it
+	assert.Equal(suite.T(), 3*time.Second, estimator.Estimate(suite.target, 100*mb, suite.start).Round(time.Millisecond))
+
+	// Releasing the queued transfer shortens the wait
+	estimator.Complete("p2")
+	assert.Equal(suite.T(), 2*time.Second, estimator.Estimate(suite.target, 100*mb, suite.start).Round(time.Millisecond))
+}
+
+func (suite *TransferTestSuite) TestCongestionProfile() {
+	load := make([]float64, 24)
+	load[12] = 0.75
+	estimator := suite.estimator(decision.TransferConfig{HourlyLoad: load})
+
+	night := suite.start
+	noon := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(suite.T(), time.Second, estimator.Estimate(suite.target, 100*mb, night).Round(time.Millisecond))
+	assert.Equal(suite.T(), 4*time.Second, estimator.Estimate(suite.target, 100*mb, noon).Round(time.Millisecond))
+
+	// Transfers spanning the end of a congested hour speed up
+	beforeOne := time.Date(2024, 1, 1, 12, 59, 59, 0, time.UTC)
+	assert.Equal(suite.T(), 1750*time.Millisecond, estimator.Estimate(suite.target, 100*mb, beforeOne).Round(time.Millisecond))
+
+	_, err := decision.NewTransferEstimator(decision.TransferConfig{HourlyLoad: []float64{0.5}})
+	assert.Error(suite.T(), err)
+	_, err = decision.NewTransferEstimator(decision.TransferConfig{Links: []decision.LinkConfig{{ID: "wan"}}})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TransferTestSuite) TestDecisionTransferTime() {
+	engine := decision.NewDecisionEngine(decision.AdaptiveWeights{})
+	engine.SetTransferEstimator(suite.estimator(decision.TransferConfig{}))
+
+	target := models.OffloadTarget{
+		ID:                "edge-1",
+		Type:              models.EDGE,
+		TotalCapacity:     8.0,
+		AvailableCapacity: 6.0,
+		MemoryTotal:       16 * 1024 * mb,
+		MemoryAvailable:   10 * 1024 * mb,
+		NetworkLatency:    10 * time.Millisecond,
+		NetworkBandwidth:  10 * mb,
+		NetworkStability:  0.95,
+		ProcessingSpeed:   1.0,
+		Reliability:       0.95,
+		SecurityLevel:     5,
+		LastSeen:          time.Now(),
+	}
+	state := models.SystemState{QueueDepth: 25, QueueThreshold: 20, ComputeUsage: 0.8, MemoryUsage: 0.6, Timestamp: time.Now()}
+	process := func(id string) models.Process {
+		return models.Process{
+			ID:                id,
+			CPURequirement:    1.0,
+			MemoryRequirement: mb,
+			InputSize:         20 * mb,
+			EstimatedDuration: 30 * time.Second,
+			Priority:          5,
+			Status:            models.QUEUED,
+		}
+	}
+
+	first, err := engine.MakeDecision(process("p1"), []models.OffloadTarget{target}, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), first.ShouldOffload)
+	assert.InDelta(suite.T(), float64(2*time.Second), float64(first.TransferTime), float64(50*time.Millisecond))
+
+	second, err := engine.MakeDecision(process("p2"), []models.OffloadTarget{target}, state)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), second.ShouldOffload)
+	assert.InDelta(suite.T(), float64(4*time.Second), float64(second.TransferTime), float64(50*time.Millisecond),
+		"The second transfer shares the link with the first")
+}
+
+func TestTransferSuite(t *testing.T) {
+	suite.Run(t, new(TransferTestSuite))
+}
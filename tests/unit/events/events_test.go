@@ -0,0 +1,229 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/events"
+)
+
+// Events test requirements:
+// 1. Events must be published to their type's topic in batches, keyed and
+//    authenticated, as Kafka REST Proxy JSON records
+// 2. Events must stay queued while the proxy is unreachable, dropping the
+//    oldest beyond the buffer, and records Kafka refuses must be counted
+// 3. The consumer must join its group, subscribe to its topic and read both
+//    bare outcomes and outcome events, skipping malformed records
+// 4. A consumer instance the proxy has expired must be recreated
+
+type EventsTestSuite struct {
+	suite.Suite
+}
+
+// fakeProxy records produce requests and serves consumer records
+type fakeProxy struct {
+	mu        sync.Mutex
+	down      bool
+	produced  map[string][][]json.RawMessage // Records per request, by topic
+	auth      []string
+	instances int
+	expired   bool
+	records   string
+	deleted   []string
+}
+
+func (fp *fakeProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	if fp.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	user, password, _ := r.BasicAuth()
+	fp.auth = append(fp.auth, user+":"+password)
+
+	switch {
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/topics/"):
+		var body struct {
+			Records []struct {
+				Key   string          `json:"key"`
+				Value json.RawMessage `json:"value"`
+			} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		topic := strings.TrimPrefix(r.URL.Path, "/topics/")
+		values := make([]json.RawMessage, len(body.Records))
+		offsets := make([]map[string]interface{}, len(body.Records))
+		for i, record := range body.Records {
+			values[i] = record.Value
+			offsets[i] = map[string]interface{}{"partition": 0, "offset": i}
+			if record.Key == "refused" {
+				offsets[i]["error_code"] = 40403
+				offsets[i]["error"] = "schema not found"
+			}
+		}
+		fp.produced[topic] = append(fp.produced[topic], values)
+		json.NewEncoder(w).Encode(map[string]interface{}{"offsets": offsets})
+	case r.Method == http.MethodPost && r.URL.Path == "/consumers/cape":
+		fp.instances++
+		fp.expired = false
+		json.NewEncoder(w).Encode(map[string]string{"instance_id": "cape-1"})
+	case r.Method == http.MethodPost && r.URL.Path == "/consumers/cape/instances/cape-1/subscription":
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && r.URL.Path == "/consumers/cape/instances/cape-1/records":
+		if fp.expired {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code": 40403, "message": "Consumer instance not found."}`))
+			return
+		}
+		w.Write([]byte(fp.records))
+		fp.records = "[]"
+	case r.Method == http.MethodDelete:
+		fp.deleted = append(fp.deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (suite *EventsTestSuite) proxy() (*fakeProxy, string) {
+	proxy := &fakeProxy{produced: make(map[string][][]json.RawMessage), records: "[]"}
+	server := httptest.NewServer(proxy)
+	suite.T().Cleanup(server.Close)
+	return proxy, server.URL
+}
+
+func (suite *EventsTestSuite) TestProducer() {
+	proxy, url := suite.proxy()
+	suite.T().Setenv("KAFKA_REST_PASSWORD", "secret")
+	producer, err := events.NewProducer(events.Config{
+		Enabled:   true,
+		ProxyURL:  url,
+		Username:  "cape",
+		BatchSize: 2,
+		Topics:    events.Topics{Decisions: "placements"},
+	})
+	require.NoError(suite.T(), err)
+
+	for _, key := range []string{"p1", "p2", "p3"} {
+		producer.Emit(events.Event{Type: events.TypeDecision, Key: key, Data: map[string]string{"process_id": key}})
+	}
+	producer.Emit(events.Event{Type: events.TypeOutcome, Key: "p1"})
+	producer.Emit(events.Event{Type: events.TypeOutcome, Key: "refused"})
+	require.NoError(suite.T(), producer.Flush(context.Background()))
+
+	proxy.mu.Lock()
+	require.Len(suite.T(), proxy.produced["placements"], 2, "Three decisions in batches of two")
+	assert.Len(suite.T(), proxy.produced["placements"][0], 2)
+	assert.Len(suite.T(), proxy.produced["cape.outcomes"], 1)
+	var event events.Event
+	require.NoError(suite.T(), json.Unmarshal(proxy.produced["placements"][1][0], &event))
+	assert.Equal(suite.T(), events.TypeDecision, event.Type)
+	assert.Equal(suite.T(), "p3", event.Key)
+	assert.False(suite.T(), event.Time.IsZero())
+	assert.Equal(suite.T(), "cape:secret", proxy.auth[0])
+	proxy.mu.Unlock()
+
+	stats := producer.Stats()
+	assert.Equal(suite.T(), 4, stats.Published)
+	assert.Equal(suite.T(), 1, stats.Failed)
+	assert.Zero(suite.T(), stats.Pending)
+}
+
+func (suite *EventsTestSuite) TestProducerBuffersWhileUnreachable() {
+	proxy, url := suite.proxy()
+	producer, err := events.NewProducer(events.Config{Enabled: true, ProxyURL: url, BufferSize: 3})
+	require.NoError(suite.T(), err)
+
+	proxy.down = true
+	for _, key := range []string{"p1", "p2"} {
+		producer.Emit(events.Event{Type: events.TypeOutcome, Key: key})
+	}
+	assert.Error(suite.T(), producer.Flush(context.Background()))
+	for _, key := range []string{"p3", "p4"} {
+		producer.Emit(events.Event{Type: events.TypeOutcome, Key: key})
+	}
+	stats := producer.Stats()
+	assert.Equal(suite.T(), 3, stats.Pending)
+	assert.Equal(suite.T(), 1, stats.Dropped)
+	assert.NotEmpty(suite.T(), stats.LastError)
+
+	proxy.mu.Lock()
+	proxy.down = false
+	proxy.mu.Unlock()
+	require.NoError(suite.T(), producer.Close())
+
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+	require.Len(suite.T(), proxy.produced["cape.outcomes"], 1)
+	keys := make([]string, 0)
+	for _, value := range proxy.produced["cape.outcomes"][0] {
+		var event events.Event
+		require.NoError(suite.T(), json.Unmarshal(value, &event))
+		keys = append(keys, event.Key)
+	}
+	assert.Equal(suite.T(), []string{"p2", "p3", "p4"}, keys, "The oldest event is dropped")
+}
+
+func (suite *EventsTestSuite) TestConsumer() {
+	proxy, url := suite.proxy()
+	consumer, err := events.NewConsumer(events.Config{
+		ProxyURL: url,
+		Consumer: events.ConsumerConfig{Enabled: true},
+	})
+	require.NoError(suite.T(), err)
+
+	proxy.records = `[
+		{"topic": "cape.outcomes.observed", "offset": 0, "value": {"process_id": "p1", "success": true}},
+		{"topic": "cape.outcomes.observed", "offset": 1, "value": {"type": "outcome", "key": "p2", "data": {"process_id": "p2"}}},
+		{"topic": "cape.outcomes.observed", "offset": 2, "value": {"type": "decision", "data": {}}},
+		{"topic": "cape.outcomes.observed", "offset": 3, "value": "garbage"}
+	]`
+	outcomes, err := consumer.Poll(context.Background())
+	assert.Error(suite.T(), err, "Malformed records are reported")
+	require.Len(suite.T(), outcomes, 2)
+	assert.Equal(suite.T(), "p1", outcomes[0].ProcessID)
+	assert.True(suite.T(), outcomes[0].Success)
+	assert.Equal(suite.T(), "p2", outcomes[1].ProcessID)
+
+	outcomes, err = consumer.Poll(context.Background())
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), outcomes)
+
+	// An expired instance is recreated on the next poll
+	proxy.mu.Lock()
+	proxy.expired = true
+	proxy.mu.Unlock()
+	_, err = consumer.Poll(context.Background())
+	assert.Error(suite.T(), err)
+	_, err = consumer.Poll(context.Background())
+	require.NoError(suite.T(), err)
+
+	require.NoError(suite.T(), consumer.Close())
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+	assert.Equal(suite.T(), 2, proxy.instances)
+	assert.Equal(suite.T(), []string{"/consumers/cape/instances/cape-1"}, proxy.deleted)
+}
+
+func (suite *EventsTestSuite) TestValidate() {
+	assert.NoError(suite.T(), events.Config{}.Validate())
+	assert.Error(suite.T(), events.Config{Enabled: true}.Validate())
+	assert.Error(suite.T(), events.Config{Consumer: events.ConsumerConfig{Enabled: true}}.Validate())
+	assert.Error(suite.T(), events.Config{ProxyURL: "kafka:9092"}.Validate())
+	assert.Error(suite.T(), events.Config{ProxyURL: "http://proxy", BatchSize: -1}.Validate())
+}
+
+func TestEventsSuite(t *testing.T) {
+	suite.Run(t, new(EventsTestSuite))
+}
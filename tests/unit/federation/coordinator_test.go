@@ -0,0 +1,161 @@
+package federation_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/federation"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Federation test requirements:
+// 1. Colony summaries must aggregate only healthy targets and forecast queue drain
+// 2. Polling must update reachable colonies even when others fail
+// 3. Stale and out-of-order summaries must not influence decisions
+// 4. The coordinator must never offload a process back to its origin colony
+
+type FederationTestSuite struct {
+	suite.Suite
+	state models.SystemState
+}
+
+func (suite *FederationTestSuite) SetupTest() {
+	suite.state = models.SystemState{
+		QueueDepth:      40,
+		QueueThreshold:  20,
+		QueueThroughput: 0.5,
+		ComputeUsage:    0.9,
+		MemoryUsage:     0.8,
+		NetworkUsage:    0.3,
+		MasterUsage:     0.3,
+		Timestamp:       time.Now(),
+		TimeSlot:        12,
+		DayOfWeek:       3,
+	}
+}
+
+func (suite *FederationTestSuite) targets(prefix string, count int, load float64) []models.OffloadTarget {
+	targets := make([]models.OffloadTarget, 0, count)
+	for i := 0; i < count; i++ {
+		targets = append(targets, models.OffloadTarget{
+			ID:                fmt.Sprintf("%s-%d", prefix, i),
+			Type:              models.EDGE,
+			TotalCapacity:     8.0,
+			AvailableCapacity: 8.0 * (1 - load),
+			MemoryTotal:       16 * 1024 * 1024 * 1024,
+			MemoryAvailable:   8 * 1024 * 1024 * 1024,
+			NetworkBandwidth:  100 * 1024 * 1024,
+			ProcessingSpeed:   1.0,
+			Reliability:       0.95,
+			ComputeCost:       0.1,
+			SecurityLevel:     3,
+			DataJurisdiction:  "EU",
+			CurrentLoad:       load,
+			LastSeen:          time.Now(),
+		})
+	}
+	return targets
+}
+
+func (suite *FederationTestSuite) process() models.Process {
+	return models.Process{
+		ID:                "federated-1",
+		CPURequirement:    2.0,
+		MemoryRequirement: 1024 * 1024 * 1024,
+		InputSize:         1024 * 1024,
+		EstimatedDuration: time.Minute,
+		Priority:          5,
+		Status:            models.QUEUED,
+	}
+}
+
+func (suite *FederationTestSuite) TestSummarize() {
+	targets := suite.targets("site-a", 3, 0.5)
+	targets[2].Reliability = 0.1 // Unhealthy targets are excluded
+
+	summary := federation.Summarize("site-a", suite.state, targets, 30*time.Second)
+
+	assert.Equal(suite.T(), 3, summary.TargetCount)
+	assert.Equal(suite.T(), 2, summary.HealthyTargets)
+	assert.Equal(suite.T(), 16.0, summary.TotalCapacity)
+	assert.Equal(suite.T(), 4.0, summary.LargestAvailableCPU)
+	assert.Equal(suite.T(), []string{"EU"}, summary.Jurisdictions)
+	assert.Equal(suite.T(), 25, summary.ForecastQueueDepth, "40 queued minus 0.5/s over 30s")
+
+	target := summary.AsTarget(20 * time.Millisecond)
+	assert.Equal(suite.T(), federation.ColonyTargetID("site-a"), target.ID)
+	assert.Equal(suite.T(), "site-a", target.Location)
+	assert.Equal(suite.T(), "EU", target.DataJurisdiction)
+}
+
+func (suite *FederationTestSuite) TestCrossColonyDecision() {
+	coordinator := federation.NewCoordinator(federation.DefaultCoordinatorConfig())
+
+	source := func(colonyID string, load float64) federation.SummarySource {
+		return federation.SummarySourceFunc(func(ctx context.Context) (federation.ColonySummary, error) {
+			return federation.Summarize(colonyID, suite.state, suite.targets(colonyID, 4, load), time.Minute), nil
+		})
+	}
+	coordinator.Register("site-a", source("site-a", 0.1), 10*time.Millisecond)
+	coordinator.Register("site-b", source("site-b", 0.2), 30*time.Millisecond)
+	coordinator.Register("site-down", federation.SummarySourceFunc(
+		func(ctx context.Context) (federation.ColonySummary, error) {
+			return federation.ColonySummary{}, fmt.Errorf("unreachable")
+		}), 5*time.Millisecond)
+
+	err := coordinator.Poll(context.Background())
+	require.Error(suite.T(), err, "Unreachable colony should be reported")
+	assert.Contains(suite.T(), err.Error(), "site-down")
+
+	summaries := coordinator.Summaries()
+	assert.Len(suite.T(), summaries, 2)
+
+	// site-a is the least loaded colony, but it is the origin
+	result, err := coordinator.Decide(suite.process(), "site-a", suite.state)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.ColoniesViewed)
+	require.True(suite.T(), result.ShouldOffload)
+	assert.Equal(suite.T(), "site-b", result.TargetColony)
+
+	result, err = coordinator.Decide(suite.process(), "site-b", suite.state)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "site-a", result.TargetColony)
+}
+
+func (suite *FederationTestSuite) TestStaleAndOutOfOrderSummaries() {
+	config := federation.DefaultCoordinatorConfig()
+	config.StaleAfter = time.Minute
+	coordinator := federation.NewCoordinator(config)
+	coordinator.Register("site-b", nil, 10*time.Millisecond)
+	coordinator.Register("site-c", nil, 10*time.Millisecond)
+
+	fresh := federation.Summarize("site-b", suite.state, suite.targets("site-b", 2, 0.2), time.Minute)
+	require.NoError(suite.T(), coordinator.Update(fresh))
+
+	older := fresh
+	older.Timestamp = fresh.Timestamp.Add(-10 * time.Second)
+	older.HealthyTargets = 0
+	require.NoError(suite.T(), coordinator.Update(older))
+	assert.Equal(suite.T(), 2, coordinator.Summaries()["site-b"].HealthyTargets, "Older summary is ignored")
+
+	stale := federation.Summarize("site-c", suite.state, suite.targets("site-c", 2, 0.0), time.Minute)
+	stale.Timestamp = time.Now().Add(-2 * time.Minute)
+	require.NoError(suite.T(), coordinator.Update(stale))
+	assert.NotContains(suite.T(), coordinator.Summaries(), "site-c")
+
+	result, err := coordinator.Decide(suite.process(), "site-a", suite.state)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.ColoniesViewed)
+
+	assert.Error(suite.T(), coordinator.Update(federation.ColonySummary{ColonyID: "unknown"}))
+}
+
+func TestFederationSuite(t *testing.T) {
+	suite.Run(t, new(FederationTestSuite))
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
@@ -18,6 +19,9 @@ import (
 // 2. Weight adaptation must converge within 200 decisions
 // 3. Learning must improve performance by >10% over static baseline
 // 4. Pattern discovery should discover >10 useful patterns in diverse environments
+// 5. Weight history must be bounded by HistorySize, keeping decision counts
+// 6. Weight evolution must be indexed by decisions made, including those whose
+//    outcomes never arrive
 
 type AdaptiveLearnerTestSuite struct {
 	suite.Suite
@@ -349,6 +353,107 @@ func (suite *AdaptiveLearnerTestSuite) TestPatternDiscoveryRequirement() {
 		"Should discover at least some patterns in diverse environment")
 }
 
+// Test that weight evolution is indexed by decision count and reports convergence
+func (suite *AdaptiveLearnerTestSuite) TestWeightEvolutionByDecisionCount() {
+	weights := decision.AdaptiveWeights{
+		QueueDepth:    0.2,
+		ProcessorLoad: 0.2,
+		NetworkCost:   0.2,
+		LatencyCost:   0.2,
+		EnergyCost:    0.1,
+		PolicyCost:    0.1,
+	}
+
+	for i := 0; i < 45; i++ {
+		suite.learner.UpdateWeights(&weights, decision.OffloadOutcome{
+			DecisionID: fmt.Sprintf("evolution-%d", i),
+			Success:    true,
+			Reward:     0.1,
+		})
+	}
+
+	evolution := suite.learner.GetWeightEvolution(10)
+	assert.Equal(suite.T(), 10, evolution.Step)
+	assert.Len(suite.T(), evolution.Points, 5, "Points at 10, 20, 30, 40 and the final decision")
+	assert.Equal(suite.T(), 10, evolution.Points[0].DecisionCount)
+	assert.Equal(suite.T(), 45, evolution.Points[len(evolution.Points)-1].DecisionCount)
+	assert.Equal(suite.T(), weights, evolution.FinalWeights)
+
+	// Small, steady rewards keep weights stable so convergence is detected
+	// as soon as the first full window is available
+	assert.True(suite.T(), evolution.IsConverged)
+	assert.Equal(suite.T(), 20, evolution.ConvergencePoint)
+	assert.Equal(suite.T(), suite.learner.GetConvergenceTime(), evolution.ConvergencePoint)
+}
+
+func (suite *AdaptiveLearnerTestSuite) TestWeightEvolutionCountsDecisionsWithoutOutcomes() {
+	weights := decision.AdaptiveWeights{
+		QueueDepth:    0.2,
+		ProcessorLoad: 0.2,
+		NetworkCost:   0.2,
+		LatencyCost:   0.2,
+		EnergyCost:    0.1,
+		PolicyCost:    0.1,
+	}
+
+	// Only every third decision reports an outcome
+	var learned []decision.AdaptiveWeights
+	for i := 1; i <= 35; i++ {
+		suite.learner.ObserveDecision()
+		if i%3 == 0 && i <= 30 {
+			suite.learner.UpdateWeights(&weights, decision.OffloadOutcome{
+				DecisionID: fmt.Sprintf("sparse-%d", i),
+				Success:    true,
+				Reward:     0.8,
+			})
+			learned = append(learned, weights)
+		}
+	}
+
+	evolution := suite.learner.GetWeightEvolution(10)
+	require.Len(suite.T(), evolution.Points, 4, "Points at 10, 20, 30 and the final decision")
+	for i, decisionCount := range []int{10, 20, 30, 35} {
+		assert.Equal(suite.T(), decisionCount, evolution.Points[i].DecisionCount)
+	}
+	assert.Equal(suite.T(), learned[2], evolution.Points[0].Weights, "Weights learned from the outcome of decision 9")
+	assert.Equal(suite.T(), learned[5], evolution.Points[1].Weights)
+	assert.Equal(suite.T(), learned[9], evolution.Points[3].Weights)
+}
+
+func (suite *AdaptiveLearnerTestSuite) TestHistorySize() {
+	config := suite.config
+	config.HistorySize = 30
+	learner := learning.NewAdaptiveLearner(config)
+	weights := decision.AdaptiveWeights{
+		QueueDepth:    0.2,
+		ProcessorLoad: 0.2,
+		NetworkCost:   0.2,
+		LatencyCost:   0.2,
+		EnergyCost:    0.1,
+		PolicyCost:    0.1,
+	}
+
+	for i := 0; i < 100; i++ {
+		outcome := decision.OffloadOutcome{
+			DecisionID: fmt.Sprintf("history-%d", i),
+			Success:    true,
+			Reward:     0.1,
+		}
+		learner.UpdateWeights(&weights, outcome)
+		learner.DiscoverPatterns(models.SystemState{}, models.Process{}, outcome)
+	}
+
+	assert.Len(suite.T(), learner.GetProgress().WeightHistory, 30)
+	assert.Equal(suite.T(), 100, learner.GetProgress().WeightUpdates)
+
+	evolution := learner.GetWeightEvolution(10)
+	assert.Len(suite.T(), evolution.Points, 3, "Points at 80, 90 and 100")
+	assert.Equal(suite.T(), 80, evolution.Points[0].DecisionCount)
+	assert.Equal(suite.T(), 100, evolution.Points[len(evolution.Points)-1].DecisionCount)
+	assert.Equal(suite.T(), weights, evolution.FinalWeights)
+	assert.Equal(suite.T(), 90, evolution.ConvergencePoint, "First full window within the kept history")
+}
+
 // Run the test suite
 func TestAdaptiveLearnerSuite(t *testing.T) {
 	suite.Run(t, new(AdaptiveLearnerTestSuite))
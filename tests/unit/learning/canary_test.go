@@ -0,0 +1,129 @@
+package learning_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// Canary rollout test requirements:
+// 1. Without a trial every decision must use the stable weights
+// 2. During a trial only about the configured fraction may use the candidate
+// 3. A candidate whose cohort regresses SLA or cost must be rolled back
+// 4. A candidate performing as well as the stable weights must be promoted
+
+type CanaryTestSuite struct {
+	suite.Suite
+	stable    decision.AdaptiveWeights
+	candidate decision.AdaptiveWeights
+	config    learning.CanaryConfig
+}
+
+func (suite *CanaryTestSuite) SetupTest() {
+	suite.stable = decision.AdaptiveWeights{
+		QueueDepth: 0.2, ProcessorLoad: 0.2, NetworkCost: 0.2,
+		LatencyCost: 0.2, EnergyCost: 0.1, PolicyCost: 0.1,
+	}
+	suite.candidate = suite.stable
+	suite.candidate.QueueDepth = 0.3
+	suite.candidate.NetworkCost = 0.1
+	suite.config = learning.CanaryConfig{Enabled: true, Fraction: 0.5, Window: 10}
+}
+
+// runTrial decides processes until both cohorts have a full window, reporting
+// outcomes produced by the given cohort functions
+func (suite *CanaryTestSuite) runTrial(
+	cc *learning.CanaryController,
+	outcome func(canary bool, processID string) decision.OffloadOutcome,
+) *learning.CanaryEvent {
+	for i := 0; i < 1000; i++ {
+		processID := fmt.Sprintf("process-%d", i)
+		canary := cc.Weights(processID) == suite.candidate
+		if event := cc.Observe(outcome(canary, processID)); event != nil {
+			return event
+		}
+	}
+	suite.T().Fatal("Trial did not resolve")
+	return nil
+}
+
+func (suite *CanaryTestSuite) TestStableWithoutTrial() {
+	cc := learning.NewCanaryController(suite.config, suite.stable)
+	assert.False(suite.T(), cc.IsActive())
+	assert.Equal(suite.T(), suite.stable, cc.Weights("process-1"))
+
+	cc.Propose(suite.stable)
+	assert.False(suite.T(), cc.IsActive(), "Unchanged weights do not start a trial")
+}
+
+func (suite *CanaryTestSuite) TestCohortFraction() {
+	suite.config.Fraction = 0.2
+	cc := learning.NewCanaryController(suite.config, suite.stable)
+	cc.Propose(suite.candidate)
+	require.True(suite.T(), cc.IsActive())
+
+	canaries := 0
+	for i := 0; i < 2000; i++ {
+		if cc.Weights(fmt.Sprintf("process-%d", i)) == suite.candidate {
+			canaries++
+		}
+	}
+	assert.InDelta(suite.T(), 0.2, float64(canaries)/2000, 0.05)
+	assert.Equal(suite.T(), cc.Weights("process-7"), cc.Weights("process-7"), "Cohort assignment is deterministic")
+}
+
+func (suite *CanaryTestSuite) TestRollbackOnRegression() {
+	cc := learning.NewCanaryController(suite.config, suite.stable)
+	cc.Propose(suite.candidate)
+
+	event := suite.runTrial(cc, func(canary bool, processID string) decision.OffloadOutcome {
+		return decision.OffloadOutcome{
+			ProcessID:       processID,
+			Success:         true,
+			CompletedOnTime: !canary, // Canary cohort misses every deadline
+			CostActual:      1.0,
+		}
+	})
+
+	assert.Equal(suite.T(), learning.CANARY_ROLLED_BACK, event.Action)
+	assert.Equal(suite.T(), 1.0, event.StableSLA)
+	assert.Equal(suite.T(), 0.0, event.CanarySLA)
+	assert.Equal(suite.T(), suite.stable, cc.Stable())
+	assert.Equal(suite.T(), suite.stable, cc.Candidate())
+	assert.False(suite.T(), cc.IsActive())
+
+	// Cost regressions roll back too
+	cc.Propose(suite.candidate)
+	event = suite.runTrial(cc, func(canary bool, processID string) decision.OffloadOutcome {
+		cost := 1.0
+		if canary {
+			cost = 1.5
+		}
+		return decision.OffloadOutcome{ProcessID: processID, Success: true, CompletedOnTime: true, CostActual: cost}
+	})
+	assert.Equal(suite.T(), learning.CANARY_ROLLED_BACK, event.Action)
+	assert.Len(suite.T(), cc.Events(), 2)
+}
+
+func (suite *CanaryTestSuite) TestPromotion() {
+	cc := learning.NewCanaryController(suite.config, suite.stable)
+	cc.Propose(suite.candidate)
+
+	event := suite.runTrial(cc, func(canary bool, processID string) decision.OffloadOutcome {
+		return decision.OffloadOutcome{ProcessID: processID, Success: true, CompletedOnTime: true, CostActual: 1.0}
+	})
+
+	assert.Equal(suite.T(), learning.CANARY_PROMOTED, event.Action)
+	assert.Equal(suite.T(), suite.candidate, cc.Stable())
+	assert.Equal(suite.T(), suite.candidate, cc.Weights("process-1"), "Promoted weights apply to every decision")
+}
+
+func TestCanarySuite(t *testing.T) {
+	suite.Run(t, new(CanaryTestSuite))
+}
@@ -0,0 +1,120 @@
+package learning_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// ConvergenceMonitor test requirements:
+// 1. Converged event must fire once weight deltas stay below epsilon for the window
+// 2. Auto-freeze must stop weight adaptation
+// 3. A shift in the reward distribution must unfreeze learning
+
+type ConvergenceMonitorTestSuite struct {
+	suite.Suite
+	weights decision.AdaptiveWeights
+}
+
+func (suite *ConvergenceMonitorTestSuite) SetupTest() {
+	suite.weights = decision.AdaptiveWeights{
+		QueueDepth:    0.2,
+		ProcessorLoad: 0.2,
+		NetworkCost:   0.2,
+		LatencyCost:   0.2,
+		EnergyCost:    0.1,
+		PolicyCost:    0.1,
+	}
+}
+
+func (suite *ConvergenceMonitorTestSuite) TestConvergedEvent() {
+	monitor := learning.NewConvergenceMonitor(learning.ConvergenceConfig{Window: 5, Epsilon: 0.01})
+
+	var received []learning.ConvergenceEvent
+	monitor.OnEvent(func(event learning.ConvergenceEvent) {
+		received = append(received, event)
+	})
+
+	for i := 0; i < 4; i++ {
+		monitor.Observe(suite.weights, suite.weights, 0.5)
+	}
+	assert.False(suite.T(), monitor.IsConverged())
+
+	monitor.Observe(suite.weights, suite.weights, 0.5)
+	assert.True(suite.T(), monitor.IsConverged())
+	assert.False(suite.T(), monitor.IsFrozen(), "Weights are only frozen with AutoFreeze")
+
+	require.Len(suite.T(), received, 1)
+	assert.Equal(suite.T(), learning.CONVERGED, received[0].Type)
+	assert.Equal(suite.T(), 5, received[0].DecisionCount)
+
+	// A large weight change resets convergence
+	moved := suite.weights
+	moved.QueueDepth += 0.1
+	monitor.Observe(suite.weights, moved, 0.5)
+	assert.False(suite.T(), monitor.IsConverged())
+}
+
+func (suite *ConvergenceMonitorTestSuite) TestAutoFreezeAndUnfreezeOnShift() {
+	learner := learning.NewAdaptiveLearner(learning.LearningConfig{
+		WindowSize:   100,
+		LearningRate: 0.01,
+		MinSamples:   10,
+		Convergence: learning.ConvergenceConfig{
+			Window:              10,
+			Epsilon:             0.01,
+			AutoFreeze:          true,
+			UnfreezeRewardShift: 0.3,
+		},
+	})
+
+	weights := suite.weights
+	for i := 0; i < 10; i++ {
+		learner.UpdateWeights(&weights, decision.OffloadOutcome{Success: true, Reward: 0.1})
+	}
+	require.True(suite.T(), learner.IsFrozen())
+
+	// Frozen weights ignore even strongly attributed rewards
+	frozen := weights
+	learner.UpdateWeights(&weights, decision.OffloadOutcome{
+		Success:     true,
+		Reward:      0.1,
+		Attribution: map[string]float64{"QueueDepth": 1.0},
+	})
+	assert.Equal(suite.T(), frozen, weights)
+
+	// Sustained drop in reward unfreezes learning
+	for i := 0; i < 10 && learner.IsFrozen(); i++ {
+		learner.UpdateWeights(&weights, decision.OffloadOutcome{Success: false, Reward: -1.0})
+	}
+	assert.False(suite.T(), learner.IsFrozen())
+
+	events := learner.GetConvergenceEvents()
+	require.Len(suite.T(), events, 2)
+	assert.Equal(suite.T(), learning.CONVERGED, events[0].Type)
+	assert.Equal(suite.T(), learning.UNFROZEN, events[1].Type)
+}
+
+func (suite *ConvergenceMonitorTestSuite) TestConvergenceTimeout() {
+	monitor := learning.NewConvergenceMonitor(learning.ConvergenceConfig{Window: 5, Epsilon: 0.001, Timeout: 3})
+
+	moved := suite.weights
+	for i := 0; i < 5; i++ {
+		moved.QueueDepth += 0.05
+		monitor.Observe(suite.weights, moved, 0.0)
+	}
+
+	events := monitor.GetEvents()
+	require.Len(suite.T(), events, 1, "Timeout event should fire only once")
+	assert.Equal(suite.T(), learning.CONVERGENCE_TIMEOUT, events[0].Type)
+	assert.Equal(suite.T(), 3, events[0].DecisionCount)
+}
+
+func TestConvergenceMonitorSuite(t *testing.T) {
+	suite.Run(t, new(ConvergenceMonitorTestSuite))
+}
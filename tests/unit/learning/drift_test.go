@@ -0,0 +1,138 @@
+package learning_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// Drift detection test requirements:
+// 1. Page-Hinkley must not alarm on a stationary reward stream
+// 2. A sustained drop in reward must be detected
+// 3. The configured reset policy must be applied to the learned weights
+// 4. Unset drift settings must take their defaults, unknown reset policies
+//    must be rejected, and only the most recent drift events kept
+
+type DriftDetectionTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DriftDetectionTestSuite) TestPageHinkleyStationaryAndShift() {
+	ph := learning.NewPageHinkley(0.05, 3.0, 20)
+
+	for i := 0; i < 200; i++ {
+		reward := 0.6
+		if i%2 == 0 {
+			reward = 0.4
+		}
+		detected, _, _ := ph.Add(reward)
+		require.False(suite.T(), detected, "No drift expected on stationary stream (sample %d)", i)
+	}
+
+	detectedAt := -1
+	var direction learning.DriftDirection
+	for i := 0; i < 100; i++ {
+		detected, dir, _ := ph.Add(-0.5)
+		if detected {
+			detectedAt = i
+			direction = dir
+			break
+		}
+	}
+	assert.GreaterOrEqual(suite.T(), detectedAt, 0, "Drop in reward should be detected")
+	assert.Less(suite.T(), detectedAt, 20, "Drop should be detected quickly")
+	assert.Equal(suite.T(), learning.DRIFT_DOWN, direction)
+}
+
+func (suite *DriftDetectionTestSuite) TestResetPolicies() {
+	baseline := decision.AdaptiveWeights{
+		QueueDepth:    0.2,
+		ProcessorLoad: 0.2,
+		NetworkCost:   0.2,
+		LatencyCost:   0.2,
+		EnergyCost:    0.1,
+		PolicyCost:    0.1,
+	}
+
+	for _, policy := range []learning.ResetPolicy{learning.RESET_FULL, learning.RESET_PARTIAL, learning.RESET_NONE} {
+		drift := learning.DefaultDriftConfig()
+		drift.ResetPolicy = policy
+		learner := learning.NewAdaptiveLearner(learning.LearningConfig{
+			WindowSize:   100,
+			LearningRate: 0.1,
+			MinSamples:   10,
+			Drift:        drift,
+		})
+
+		weights := baseline
+		for i := 0; i < 100; i++ {
+			learner.UpdateWeights(&weights, decision.OffloadOutcome{
+				Success:     true,
+				Reward:      1.0,
+				Attribution: map[string]float64{"QueueDepth": 1.0},
+			})
+		}
+		skewed := weights
+		require.Greater(suite.T(), skewed.QueueDepth, baseline.QueueDepth)
+
+		for i := 0; i < 50 && len(learner.GetDriftEvents()) == 0; i++ {
+			learner.UpdateWeights(&weights, decision.OffloadOutcome{Success: false, Reward: -1.0})
+		}
+
+		events := learner.GetDriftEvents()
+		require.Len(suite.T(), events, 1, "Policy %s: drift should be detected once", policy)
+		assert.Equal(suite.T(), learning.DRIFT_DOWN, events[0].Direction)
+		assert.Equal(suite.T(), policy, events[0].Policy)
+
+		switch policy {
+		case learning.RESET_FULL:
+			assert.Equal(suite.T(), baseline, events[0].WeightsAfter)
+		case learning.RESET_PARTIAL:
+			assert.Less(suite.T(), events[0].WeightsAfter.QueueDepth, events[0].WeightsBefore.QueueDepth)
+			assert.Greater(suite.T(), events[0].WeightsAfter.QueueDepth, baseline.QueueDepth)
+		case learning.RESET_NONE:
+			assert.Equal(suite.T(), events[0].WeightsBefore, events[0].WeightsAfter)
+		}
+		assert.InDelta(suite.T(), 1.0, events[0].WeightsAfter.Sum(), 0.001)
+	}
+}
+
+func (suite *DriftDetectionTestSuite) TestDefaultsAndLimits() {
+	learner := learning.NewAdaptiveLearner(learning.LearningConfig{
+		WindowSize:   100,
+		LearningRate: 0.1,
+		MinSamples:   10,
+		Drift:        learning.DriftConfig{Enabled: true, Threshold: 1.0, MinSamples: 5},
+	})
+
+	weights := decision.AdaptiveWeights{QueueDepth: 0.2, ProcessorLoad: 0.2, NetworkCost: 0.2, LatencyCost: 0.2, EnergyCost: 0.1, PolicyCost: 0.1}
+	for block := 0; block < 300; block++ {
+		reward := 1.0
+		if block%2 == 1 {
+			reward = -1.0
+		}
+		for i := 0; i < 10; i++ {
+			learner.UpdateWeights(&weights, decision.OffloadOutcome{Success: reward > 0, Reward: reward})
+		}
+	}
+
+	events := learner.GetDriftEvents()
+	require.NotEmpty(suite.T(), events)
+	assert.Equal(suite.T(), learning.RESET_PARTIAL, events[0].Policy, "An unset reset policy defaults to partial")
+	assert.Len(suite.T(), events, 100, "Only the most recent drift events are kept")
+	assert.Greater(suite.T(), events[0].DecisionCount, 0)
+
+	assert.NoError(suite.T(), learning.DriftConfig{}.Validate())
+	assert.NoError(suite.T(), learning.DefaultDriftConfig().Validate())
+	assert.Error(suite.T(), learning.DriftConfig{ResetPolicy: "sometimes"}.Validate())
+	assert.Error(suite.T(), learning.DriftConfig{PartialResetFactor: 1.5}.Validate())
+}
+
+func TestDriftDetectionSuite(t *testing.T) {
+	suite.Run(t, new(DriftDetectionTestSuite))
+}
@@ -0,0 +1,121 @@
+package learning_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// Forecaster test requirements:
+// 1. Every predictor's RMSE and MAPE must be tracked per executor type
+// 2. Auto-selection must route forecasts to the most accurate predictor for
+//    the series' executor type once it has enough samples
+// 3. Registered predictors must compete with the built-ins, and a fixed
+//    predictor must always be used when configured
+// 4. Invalid configurations must be rejected
+
+type ForecastTestSuite struct {
+	suite.Suite
+}
+
+// constantPredictor always forecasts the same value
+type constantPredictor struct{ value float64 }
+
+func (cp constantPredictor) Observe(float64)          {}
+func (cp constantPredictor) Predict() (float64, bool) { return cp.value, true }
+
+// step feeds a series that jumps from 0.2 to 0.8 and stays there
+func step(forecaster *learning.Forecaster, key, executorType string) {
+	forecaster.Observe(key, executorType, 0.2)
+	for i := 0; i < 10; i++ {
+		forecaster.Observe(key, executorType, 0.8)
+	}
+}
+
+// accuracyOf returns a predictor's accuracy for an executor type
+func accuracyOf(accuracy []learning.ForecastAccuracy, executorType, predictor string) learning.ForecastAccuracy {
+	for _, entry := range accuracy {
+		if entry.ExecutorType == executorType && entry.Predictor == predictor {
+			return entry
+		}
+	}
+	return learning.ForecastAccuracy{}
+}
+
+func (suite *ForecastTestSuite) TestAccuracyAndAutoSelection() {
+	forecaster := learning.NewForecaster(learning.ForecastConfig{Enabled: true, MinSamples: 5})
+
+	forecaster.Observe("edge-1", "edge", 0.2)
+	forecast, ok := forecaster.Forecast("edge-1")
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), learning.PredictorEWMA, forecast.Predictor, "EWMA forecasts until others have enough samples")
+
+	for i := 0; i < 10; i++ {
+		forecaster.Observe("edge-1", "edge", 0.8)
+	}
+	// Alternating load favours averaging over repeating the last value
+	for i := 0; i < 20; i++ {
+		forecaster.Observe("cloud-1", "cloud", 0.4+0.2*float64(i%2))
+	}
+
+	accuracy := forecaster.Accuracy()
+	naive := accuracyOf(accuracy, "edge", learning.PredictorNaive)
+	assert.Equal(suite.T(), 10, naive.Samples)
+	assert.InDelta(suite.T(), 0.1897, naive.RMSE, 1e-4)
+	assert.InDelta(suite.T(), 0.075, naive.MAPE, 1e-9)
+	assert.True(suite.T(), naive.Selected)
+	assert.Greater(suite.T(), accuracyOf(accuracy, "edge", learning.PredictorEWMA).RMSE, naive.RMSE)
+	assert.False(suite.T(), accuracyOf(accuracy, "cloud", learning.PredictorNaive).Selected)
+
+	forecast, ok = forecaster.Forecast("edge-1")
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), learning.PredictorNaive, forecast.Predictor)
+	assert.Equal(suite.T(), 0.8, forecast.Value)
+
+	forecast, _ = forecaster.Forecast("cloud-1")
+	assert.NotEqual(suite.T(), learning.PredictorNaive, forecast.Predictor)
+
+	forecaster.Forget("edge-1")
+	_, ok = forecaster.Forecast("edge-1")
+	assert.False(suite.T(), ok)
+}
+
+func (suite *ForecastTestSuite) TestRegisteredAndFixedPredictors() {
+	config := learning.ForecastConfig{
+		Enabled:    true,
+		MinSamples: 5,
+		Predictors: map[string]learning.PredictorFactory{
+			"oracle": func() learning.Predictor { return constantPredictor{value: 0.8} },
+		},
+	}
+	forecaster := learning.NewForecaster(config)
+	step(forecaster, "edge-1", "edge")
+	forecast, ok := forecaster.Forecast("edge-1")
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), "oracle", forecast.Predictor)
+	assert.Len(suite.T(), forecaster.Accuracy(), 4)
+
+	config.Predictor = learning.PredictorKalman
+	forecaster = learning.NewForecaster(config)
+	step(forecaster, "edge-1", "edge")
+	forecast, _ = forecaster.Forecast("edge-1")
+	assert.Equal(suite.T(), learning.PredictorKalman, forecast.Predictor)
+}
+
+func (suite *ForecastTestSuite) TestInvalidConfig() {
+	assert.Error(suite.T(), learning.ForecastConfig{Window: -1}.Validate())
+	assert.Error(suite.T(), learning.ForecastConfig{Metric: "mae"}.Validate())
+	assert.Error(suite.T(), learning.ForecastConfig{Predictor: "arima"}.Validate())
+	assert.Error(suite.T(), learning.ForecastConfig{Predictors: map[string]learning.PredictorFactory{
+		learning.PredictorEWMA: func() learning.Predictor { return constantPredictor{} },
+	}}.Validate(), "Built-in names cannot be replaced")
+	assert.NoError(suite.T(), learning.ForecastConfig{Metric: learning.MAPE, Predictor: learning.PredictorNaive}.Validate())
+}
+
+func TestForecastSuite(t *testing.T) {
+	suite.Run(t, new(ForecastTestSuite))
+}
@@ -0,0 +1,101 @@
+package learning_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// Data gravity test requirements:
+// 1. Latency and cost slopes against data moved must be recovered per location
+// 2. Confidence bounds must contain the true slope and narrow with evidence
+// 3. The scoring factor must stay at the prior until the bounds exclude it
+// 4. Locations without enough samples must not get their own factor
+
+type GravityTestSuite struct {
+	suite.Suite
+	learner *learning.GravityLearner
+	rng     *rand.Rand
+}
+
+func (suite *GravityTestSuite) SetupTest() {
+	suite.learner = learning.NewGravityLearner(learning.GravityConfig{Enabled: true, Decay: 1})
+	suite.rng = rand.New(rand.NewSource(42))
+}
+
+// observe adds offloads whose latency grows by secondsPerGB and cost by
+// costPerGB, with noise
+func (suite *GravityTestSuite) observe(location string, n int, secondsPerGB, costPerGB float64) {
+	for i := 0; i < n; i++ {
+		gb := suite.rng.Float64() * 4
+		seconds := 5 + secondsPerGB*gb + suite.rng.NormFloat64()*0.5
+		suite.learner.Observe(learning.GravityObservation{
+			Location: location,
+			DataSize: int64(gb * 1024 * 1024 * 1024),
+			Latency:  time.Duration(seconds * float64(time.Second)),
+			Cost:     0.1 + costPerGB*gb,
+		})
+	}
+}
+
+func (suite *GravityTestSuite) TestRecoversSlopes() {
+	suite.observe("us-east", 200, 20, 0.05)
+
+	estimate := suite.learner.Estimate("us-east")
+	assert.True(suite.T(), estimate.Fitted)
+	assert.Equal(suite.T(), 200, estimate.Samples)
+	assert.InDelta(suite.T(), 20, estimate.SecondsPerGB, 0.5)
+	assert.Less(suite.T(), estimate.SecondsLower, 20.0)
+	assert.Greater(suite.T(), estimate.SecondsUpper, 20.0)
+	assert.InDelta(suite.T(), 0.05, estimate.CostPerGB, 1e-9)
+
+	// Slower data movement than the 8 s/GB prior weighs data more heavily
+	assert.InDelta(suite.T(), estimate.SecondsLower/8, estimate.Factor, 1e-9)
+	assert.Greater(suite.T(), estimate.Factor, 2.0)
+}
+
+func (suite *GravityTestSuite) TestBoundsNarrow() {
+	suite.observe("eu-west", 15, 12, 0)
+	early := suite.learner.Estimate("eu-west")
+	suite.observe("eu-west", 300, 12, 0)
+	late := suite.learner.Estimate("eu-west")
+
+	assert.Less(suite.T(), late.SecondsUpper-late.SecondsLower, early.SecondsUpper-early.SecondsLower)
+}
+
+func (suite *GravityTestSuite) TestPriorHeldWithoutEvidence() {
+	suite.observe("eu-west", 100, 8, 0)
+	assert.Equal(suite.T(), 1.0, suite.learner.Estimate("eu-west").Factor, "Bounds containing the prior keep it")
+
+	// Identical data sizes cannot support a slope
+	for i := 0; i < 20; i++ {
+		suite.learner.Observe(learning.GravityObservation{Location: "flat", DataSize: 1024, Latency: time.Second})
+	}
+	flat := suite.learner.Estimate("flat")
+	assert.False(suite.T(), flat.Fitted)
+	assert.Equal(suite.T(), 1.0, flat.Factor)
+}
+
+func (suite *GravityTestSuite) TestFactorsPerLocation() {
+	suite.observe("us-east", 50, 20, 0)
+	suite.observe("ap-south", 5, 20, 0)
+
+	factors := suite.learner.Factors()
+	assert.Contains(suite.T(), factors, "us-east")
+	assert.Contains(suite.T(), factors, "", "The overall factor covers other locations")
+	assert.NotContains(suite.T(), factors, "ap-south", "Too few samples")
+
+	estimates := suite.learner.Estimates()
+	assert.Len(suite.T(), estimates, 3)
+	assert.Equal(suite.T(), "", estimates[0].Location)
+	assert.Equal(suite.T(), 55, estimates[0].Samples)
+}
+
+func TestGravitySuite(t *testing.T) {
+	suite.Run(t, new(GravityTestSuite))
+}
@@ -0,0 +1,146 @@
+package learning_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Health scoring test requirements:
+// 1. Steady, error-free heartbeats with accurate load reports must score near 1
+// 2. Errors, latency jitter and inaccurate load reports must each lower the score
+// 3. Health must recover as bad observations decay, and decay when heartbeats stop
+// 4. Reliability used for placement must be scaled by health
+
+type HealthTestSuite struct {
+	suite.Suite
+	monitor *learning.HealthMonitor
+	start   time.Time
+}
+
+func (suite *HealthTestSuite) SetupTest() {
+	suite.monitor = learning.NewHealthMonitor(learning.HealthConfig{
+		Enabled:  true,
+		Interval: 10 * time.Second,
+		HalfLife: 60 * time.Second,
+	})
+	suite.start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+}
+
+// stream sends n heartbeats at the expected interval, returning the time of the last
+func (suite *HealthTestSuite) stream(targetID string, from time.Time, n int, heartbeat func(i int) learning.Heartbeat) time.Time {
+	at := from
+	for i := 0; i < n; i++ {
+		at = from.Add(time.Duration(i) * 10 * time.Second)
+		hb := heartbeat(i)
+		hb.TargetID = targetID
+		hb.Timestamp = at
+		require.NoError(suite.T(), suite.monitor.Observe(hb))
+	}
+	return at
+}
+
+func steady(i int) learning.Heartbeat {
+	return learning.Heartbeat{Latency: 20 * time.Millisecond, Requests: 100, ReportedLoad: 0.5, ActualLoad: 0.5}
+}
+
+func (suite *HealthTestSuite) TestSteadyTargetIsHealthy() {
+	last := suite.stream("edge-1", suite.start, 20, steady)
+
+	score, ok := suite.monitor.Score("edge-1", last)
+	require.True(suite.T(), ok)
+	assert.InDelta(suite.T(), 1.0, score.Score, 1e-9)
+	assert.Equal(suite.T(), 20, score.Heartbeats)
+
+	_, ok = suite.monitor.Score("unknown", last)
+	assert.False(suite.T(), ok)
+}
+
+func (suite *HealthTestSuite) TestDegradationLowersScore() {
+	last := suite.stream("errors", suite.start, 20, func(i int) learning.Heartbeat {
+		hb := steady(i)
+		hb.Errors = 40
+		return hb
+	})
+	suite.stream("jitter", suite.start, 20, func(i int) learning.Heartbeat {
+		hb := steady(i)
+		if i%2 == 0 {
+			hb.Latency = 200 * time.Millisecond
+		}
+		return hb
+	})
+	suite.stream("misreporting", suite.start, 20, func(i int) learning.Heartbeat {
+		hb := steady(i)
+		hb.ReportedLoad = 0.1
+		hb.ActualLoad = 0.9
+		return hb
+	})
+
+	errors, _ := suite.monitor.Score("errors", last)
+	assert.InDelta(suite.T(), 0.4, errors.ErrorRate, 1e-9)
+	assert.InDelta(suite.T(), 0.6, errors.Score, 1e-9)
+
+	jitter, _ := suite.monitor.Score("jitter", last)
+	assert.Greater(suite.T(), jitter.Jitter, 0.3)
+	assert.Less(suite.T(), jitter.Score, 0.8)
+
+	misreporting, _ := suite.monitor.Score("misreporting", last)
+	assert.InDelta(suite.T(), 0.2, misreporting.LoadAccuracy, 1e-9)
+
+	assert.Len(suite.T(), suite.monitor.Scores(last), 3)
+}
+
+func (suite *HealthTestSuite) TestRecoveryAndStaleness() {
+	last := suite.stream("edge-1", suite.start, 10, func(i int) learning.Heartbeat {
+		hb := steady(i)
+		hb.Errors = 100
+		return hb
+	})
+	failing, _ := suite.monitor.Score("edge-1", last)
+	assert.InDelta(suite.T(), 0.0, failing.Score, 1e-9)
+
+	last = suite.stream("edge-1", last.Add(10*time.Second), 30, steady)
+	recovered, _ := suite.monitor.Score("edge-1", last)
+	assert.Greater(suite.T(), recovered.Score, 0.9, "Old errors decay away")
+
+	// A few missed heartbeats are tolerated, then health decays
+	quiet, _ := suite.monitor.Score("edge-1", last.Add(30*time.Second))
+	assert.Equal(suite.T(), 1.0, quiet.Freshness)
+	silent, _ := suite.monitor.Score("edge-1", last.Add(90*time.Second))
+	assert.InDelta(suite.T(), 0.5, silent.Freshness, 1e-9)
+
+	// Out-of-order heartbeats are ignored
+	require.NoError(suite.T(), suite.monitor.Observe(learning.Heartbeat{TargetID: "edge-1", Timestamp: suite.start, Requests: 1, Errors: 1}))
+	after, _ := suite.monitor.Score("edge-1", last)
+	assert.Equal(suite.T(), recovered, after)
+
+	assert.Error(suite.T(), suite.monitor.Observe(learning.Heartbeat{}))
+}
+
+func (suite *HealthTestSuite) TestApplyTargets() {
+	last := suite.stream("edge-1", suite.start, 10, func(i int) learning.Heartbeat {
+		hb := steady(i)
+		hb.Errors = 50
+		return hb
+	})
+	targets := []models.OffloadTarget{
+		{ID: "edge-1", Reliability: 0.9},
+		{ID: "edge-2", Reliability: 0.9},
+	}
+
+	applied := suite.monitor.ApplyTargets(targets, last)
+	assert.InDelta(suite.T(), 0.45, applied[0].Reliability, 1e-9)
+	assert.False(suite.T(), applied[0].IsHealthy())
+	assert.Equal(suite.T(), 0.9, applied[1].Reliability, "Targets without heartbeats are unchanged")
+	assert.Equal(suite.T(), 0.9, targets[0].Reliability, "Input targets are not modified")
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
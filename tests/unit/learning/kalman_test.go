@@ -0,0 +1,104 @@
+package learning_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Metric smoothing test requirements:
+// 1. Kalman filter must reduce measurement jitter around a stable value
+// 2. Smoothed utilizations must stay within [0.0, 1.0]
+// 3. Only configured metrics are smoothed
+
+type KalmanFilterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *KalmanFilterTestSuite) TestFilterReducesJitter() {
+	filter := learning.NewKalmanFilter(0.001, 0.1)
+
+	rawDeviation := 0.0
+	smoothedDeviation := 0.0
+	for i := 0; i < 200; i++ {
+		noise := 0.2 * math.Sin(float64(i)*1.7)
+		measurement := 0.5 + noise
+		estimate := filter.Update(measurement)
+
+		if i >= 50 {
+			rawDeviation += math.Abs(measurement - 0.5)
+			smoothedDeviation += math.Abs(estimate - 0.5)
+		}
+	}
+
+	assert.Less(suite.T(), smoothedDeviation, rawDeviation/2,
+		"Smoothed estimates should deviate much less from the true value than raw measurements")
+
+	filter.Reset()
+	assert.Equal(suite.T(), 0.9, filter.Update(0.9), "First measurement after reset initializes the estimate")
+}
+
+func (suite *KalmanFilterTestSuite) TestSmoothStateOnlyConfiguredMetrics() {
+	smoother := learning.NewMetricSmoother(learning.SmoothingConfig{
+		Enabled: true,
+		Metrics: map[string]learning.KalmanConfig{
+			learning.MetricComputeUsage: {ProcessNoise: 0.001, MeasurementNoise: 0.1},
+		},
+	})
+
+	state := models.SystemState{
+		QueueDepth:     10,
+		QueueThreshold: 20,
+		ComputeUsage:   0.5,
+		MemoryUsage:    0.5,
+		Timestamp:      time.Now(),
+	}
+	smoother.SmoothState(state)
+
+	state.ComputeUsage = 1.0
+	state.MemoryUsage = 1.0
+	smoothed := smoother.SmoothState(state)
+
+	assert.Less(suite.T(), float64(smoothed.ComputeUsage), 1.0, "Compute spike should be damped")
+	assert.GreaterOrEqual(suite.T(), float64(smoothed.ComputeUsage), 0.5)
+	assert.Equal(suite.T(), models.Utilization(1.0), smoothed.MemoryUsage, "Unconfigured metrics pass through")
+	assert.Equal(suite.T(), 10, smoothed.QueueDepth)
+}
+
+func (suite *KalmanFilterTestSuite) TestSmoothTargetsPerTarget() {
+	smoother := learning.NewMetricSmoother(learning.DefaultSmoothingConfig())
+
+	targets := []models.OffloadTarget{
+		{ID: "edge-1", NetworkLatency: 10 * time.Millisecond},
+		{ID: "cloud-1", NetworkLatency: 50 * time.Millisecond},
+	}
+	smoother.SmoothTargets(targets)
+
+	targets[0].NetworkLatency = 100 * time.Millisecond
+	smoothed := smoother.SmoothTargets(targets)
+
+	assert.Less(suite.T(), smoothed[0].NetworkLatency, 100*time.Millisecond, "Latency spike should be damped")
+	assert.Equal(suite.T(), 50*time.Millisecond, smoothed[1].NetworkLatency,
+		"Each target has its own filter")
+}
+
+func (suite *KalmanFilterTestSuite) TestDisabledSmootherPassesThrough() {
+	config := learning.DefaultSmoothingConfig()
+	config.Enabled = false
+	smoother := learning.NewMetricSmoother(config)
+
+	state := models.SystemState{ComputeUsage: 0.1}
+	smoother.SmoothState(state)
+	state.ComputeUsage = 0.9
+	assert.Equal(suite.T(), models.Utilization(0.9), smoother.SmoothState(state).ComputeUsage)
+}
+
+func TestKalmanFilterSuite(t *testing.T) {
+	suite.Run(t, new(KalmanFilterTestSuite))
+}
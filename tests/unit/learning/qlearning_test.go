@@ -0,0 +1,76 @@
+package learning_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// QLearner test requirements:
+// 1. Updates must move action values towards the reward plus the discounted
+//    value of the next state
+// 2. Epsilon must decay after every update down to its floor
+// 3. Exploitation must pick the highest valued action; exploration must try
+//    every action
+// 4. Invalid configurations must be rejected
+
+type QLearnerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *QLearnerTestSuite) TestUpdate() {
+	learner := learning.NewQLearner(learning.QLearningConfig{LearningRate: 0.5, Discount: 0.5, Seed: 1}, 3)
+
+	learner.Update("a", 1, 1.0, "b")
+	assert.InDelta(suite.T(), 0.5, learner.Values("a")[1], 1e-9)
+
+	learner.Update("b", 0, 2.0, "c")
+	learner.Update("a", 1, 1.0, "b")
+	// Target 1.0 + 0.5*1.0, halfway from 0.5
+	assert.InDelta(suite.T(), 1.0, learner.Values("a")[1], 1e-9)
+	assert.Equal(suite.T(), []float64{0, 0, 0}, learner.Values("unseen"))
+	assert.Equal(suite.T(), 3, learner.Updates())
+}
+
+func (suite *QLearnerTestSuite) TestEpsilonDecay() {
+	learner := learning.NewQLearner(learning.QLearningConfig{Epsilon: 1.0, EpsilonDecay: 0.5, MinEpsilon: 0.1, Seed: 1}, 2)
+	assert.Equal(suite.T(), 1.0, learner.Epsilon())
+
+	learner.Update("a", 0, 0, "a")
+	assert.InDelta(suite.T(), 0.5, learner.Epsilon(), 1e-9)
+	for i := 0; i < 5; i++ {
+		learner.Update("a", 0, 0, "a")
+	}
+	assert.InDelta(suite.T(), 0.1, learner.Epsilon(), 1e-9)
+
+	greedy := learning.NewQLearner(learning.QLearningConfig{Epsilon: 0.01, MinEpsilon: 0.1, Seed: 1}, 2)
+	greedy.Update("a", 0, 0, "a")
+	assert.InDelta(suite.T(), 0.01, greedy.Epsilon(), 1e-9, "The floor does not raise a lower starting epsilon")
+}
+
+func (suite *QLearnerTestSuite) TestChoose() {
+	greedy := learning.NewQLearner(learning.QLearningConfig{Epsilon: 1e-9, Seed: 1}, 3)
+	assert.Equal(suite.T(), 0, greedy.Choose("a"), "Ties prefer the first action")
+	greedy.Update("a", 2, 1.0, "b")
+	assert.Equal(suite.T(), 2, greedy.Choose("a"))
+
+	explorer := learning.NewQLearner(learning.QLearningConfig{Epsilon: 1.0, EpsilonDecay: 1.0, Seed: 1}, 3)
+	chosen := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		chosen[explorer.Choose("a")] = true
+	}
+	assert.Len(suite.T(), chosen, 3)
+}
+
+func (suite *QLearnerTestSuite) TestInvalidConfig() {
+	assert.Error(suite.T(), learning.QLearningConfig{LearningRate: 1.5}.Validate())
+	assert.Error(suite.T(), learning.QLearningConfig{EpsilonDecay: -0.1}.Validate())
+	assert.NoError(suite.T(), learning.QLearningConfig{}.Validate())
+}
+
+func TestQLearnerSuite(t *testing.T) {
+	suite.Run(t, new(QLearnerTestSuite))
+}
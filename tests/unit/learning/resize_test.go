@@ -0,0 +1,193 @@
+package learning_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Resize recommendation test requirements:
+// 1. Persistently high load must recommend the next larger class with its
+//    cost delta, resize cost and downtime
+// 2. Persistently low load must recommend the next smaller class, unless the
+//    projected load would be too high, once the time paid on the target's
+//    lease is used
+// 3. Load that is not sustained, or a class at the end of the ladder, must
+//    not be resized
+// 4. Invalid ladders must be rejected
+// 5. In reinforcement mode, resizes must be learned from SLA and cost
+//    rewards, with rewards reported for comparison with rule-based mode
+
+type ResizeTestSuite struct {
+	suite.Suite
+	config  learning.ResizeConfig
+	advisor *learning.ResizeAdvisor
+	start   time.Time
+}
+
+func (suite *ResizeTestSuite) SetupTest() {
+	suite.config = learning.ResizeConfig{
+		Enabled: true,
+		Ladder: []learning.SizeClass{
+			{Name: "small", CPU: 2, Memory: 4 << 30, CostPerHour: 0.1},
+			{Name: "medium", CPU: 4, Memory: 8 << 30, CostPerHour: 0.2},
+			{Name: "large", CPU: 8, Memory: 16 << 30, CostPerHour: 0.4},
+		},
+		Sustain:    5 * time.Minute,
+		Downtime:   30 * time.Second,
+		ResizeCost: 0.05,
+	}
+	suite.advisor = learning.NewResizeAdvisor(suite.config)
+	suite.start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+}
+
+// load sends a heartbeat with the given load every minute for n minutes,
+// returning the time of the last
+func (suite *ResizeTestSuite) load(targetID string, from time.Time, n int, load float64) time.Time {
+	at := from
+	for i := 0; i < n; i++ {
+		at = from.Add(time.Duration(i) * time.Minute)
+		require.NoError(suite.T(), suite.advisor.Observe(learning.Heartbeat{TargetID: targetID, Timestamp: at, ActualLoad: load}))
+	}
+	return at
+}
+
+func target(id string, cpu float64) models.OffloadTarget {
+	return models.OffloadTarget{ID: id, TotalCapacity: cpu}
+}
+
+func (suite *ResizeTestSuite) TestHighLoadScalesUp() {
+	last := suite.load("edge-1", suite.start, 6, 0.9)
+
+	recommendations := suite.advisor.Recommend([]models.OffloadTarget{target("edge-1", 4)}, last)
+	require.Len(suite.T(), recommendations, 1)
+	rec := recommendations[0]
+	assert.Equal(suite.T(), learning.SCALE_UP, rec.Direction)
+	assert.Equal(suite.T(), "medium", rec.From)
+	assert.Equal(suite.T(), "large", rec.To)
+	assert.InDelta(suite.T(), 0.9, rec.Load, 1e-9)
+	assert.InDelta(suite.T(), 0.45, rec.ProjectedLoad, 1e-9)
+	assert.InDelta(suite.T(), 0.2, rec.HourlyCostDelta, 1e-9)
+	assert.Equal(suite.T(), 0.05, rec.ResizeCost)
+	assert.Equal(suite.T(), 30*time.Second, rec.Downtime)
+}
+
+func (suite *ResizeTestSuite) TestLowLoadScalesDown() {
+	last := suite.load("edge-1", suite.start, 6, 0.1)
+	suite.load("edge-2", suite.start, 6, 0.3)
+	// Halving edge-2 would leave it at 0.6, still below the high threshold;
+	// edge-3 is already the smallest class
+	suite.load("edge-3", suite.start, 6, 0.1)
+
+	recommendations := suite.advisor.Recommend([]models.OffloadTarget{
+		target("edge-1", 8), target("edge-2", 4), target("edge-3", 2),
+	}, last)
+	require.Len(suite.T(), recommendations, 2)
+	assert.Equal(suite.T(), "edge-1", recommendations[0].TargetID)
+	assert.Equal(suite.T(), learning.SCALE_DOWN, recommendations[0].Direction)
+	assert.Equal(suite.T(), "medium", recommendations[0].To)
+	assert.InDelta(suite.T(), -0.2, recommendations[0].HourlyCostDelta, 1e-9)
+	assert.Equal(suite.T(), "small", recommendations[1].To)
+	assert.True(suite.T(), recommendations[0].NotBefore.IsZero(), "Targets without a lease can be resized right away")
+
+	leased := target("edge-1", 8)
+	leased.LeaseStart = suite.start.Add(-20 * time.Minute)
+	leased.BillingIncrement = time.Hour
+	recommendations = suite.advisor.Recommend([]models.OffloadTarget{leased}, last)
+	require.Len(suite.T(), recommendations, 1)
+	assert.Equal(suite.T(), leased.LeaseStart.Add(time.Hour), recommendations[0].NotBefore,
+		"Downsizing waits for the paid hour to end")
+
+	// A low threshold close to the high one must not downsize into overload
+	suite.config.LowLoad = 0.5
+	suite.config.HighLoad = 0.9
+	suite.advisor = learning.NewResizeAdvisor(suite.config)
+	last = suite.load("edge-4", suite.start, 6, 0.5)
+	assert.Empty(suite.T(), suite.advisor.Recommend([]models.OffloadTarget{target("edge-4", 4)}, last))
+}
+
+func (suite *ResizeTestSuite) TestUnsustainedLoadIsNotResized() {
+	last := suite.load("edge-1", suite.start, 3, 0.9)
+	assert.Empty(suite.T(), suite.advisor.Recommend([]models.OffloadTarget{target("edge-1", 4)}, last))
+
+	// A normal heartbeat breaks the streak
+	last = suite.load("edge-1", last.Add(time.Minute), 1, 0.5)
+	last = suite.load("edge-1", last.Add(time.Minute), 4, 0.9)
+	assert.Empty(suite.T(), suite.advisor.Recommend([]models.OffloadTarget{target("edge-1", 4)}, last))
+
+	last = suite.load("edge-2", suite.start, 6, 0.9)
+	assert.Empty(suite.T(), suite.advisor.Recommend([]models.OffloadTarget{target("edge-2", 8)}, last),
+		"The largest class cannot scale up")
+
+	suite.advisor.Forget("edge-1")
+	assert.Empty(suite.T(), suite.advisor.Recommend([]models.OffloadTarget{target("edge-1", 4)}, last.Add(time.Hour)))
+}
+
+func (suite *ResizeTestSuite) TestReinforcementMode() {
+	suite.config.Mode = learning.REINFORCEMENT
+	suite.config.QLearning = learning.QLearningConfig{Epsilon: 1e-9, Seed: 1}
+	suite.advisor = learning.NewResizeAdvisor(suite.config)
+	targets := []models.OffloadTarget{target("edge-1", 4)}
+
+	// The untrained learner holds
+	last := suite.load("edge-1", suite.start, 6, 0.9)
+	assert.Empty(suite.T(), suite.advisor.Recommend(targets, last))
+	assert.Empty(suite.T(), suite.advisor.Recommend(targets, last.Add(time.Minute)), "No heartbeats, no decision period")
+
+	// Missed SLAs while holding under high load teach it to scale up
+	last = suite.load("edge-1", last.Add(time.Minute), 6, 0.9)
+	suite.advisor.ObserveOutcome(decision.OffloadOutcome{TargetID: "edge-1", Success: false, EndTime: last})
+	recommendations := suite.advisor.Recommend(targets, last)
+	require.Len(suite.T(), recommendations, 1)
+	assert.Equal(suite.T(), learning.SCALE_UP, recommendations[0].Direction)
+	assert.Equal(suite.T(), "large", recommendations[0].To)
+
+	stats := suite.advisor.Stats()
+	assert.Equal(suite.T(), learning.REINFORCEMENT, stats.Mode)
+	assert.Equal(suite.T(), 1, stats.Steps)
+	assert.Less(suite.T(), stats.MeanReward, 0.0)
+
+	// Rule-based mode earns rewards the same way
+	rules := learning.NewResizeAdvisor(learning.ResizeConfig{Enabled: true, Ladder: suite.config.Ladder, Sustain: 5 * time.Minute})
+	for i := 0; i < 2; i++ {
+		at := suite.start.Add(time.Duration(i) * time.Hour)
+		require.NoError(suite.T(), rules.Observe(learning.Heartbeat{TargetID: "edge-1", Timestamp: at, ActualLoad: 0.5}))
+		rules.ObserveOutcome(decision.OffloadOutcome{TargetID: "edge-1", Success: true, CompletedOnTime: true, EndTime: at})
+		rules.Recommend(targets, at)
+	}
+	stats = rules.Stats()
+	assert.Equal(suite.T(), learning.RULE_BASED, stats.Mode)
+	assert.Equal(suite.T(), 1, stats.Steps)
+	assert.InDelta(suite.T(), 0.8, stats.MeanReward, 1e-9, "Full SLA compliance less an hour of the medium class")
+}
+
+func (suite *ResizeTestSuite) TestInvalidLadder() {
+	config := suite.config
+	config.Ladder = []learning.SizeClass{suite.config.Ladder[1], suite.config.Ladder[0]}
+	assert.Error(suite.T(), config.Validate(), "Classes out of order")
+
+	config = suite.config
+	config.Ladder = suite.config.Ladder[:1]
+	assert.Error(suite.T(), config.Validate(), "A single class cannot be resized")
+
+	config = suite.config
+	config.LowLoad, config.HighLoad = 0.8, 0.5
+	assert.Error(suite.T(), config.Validate())
+
+	config = suite.config
+	config.Mode = "predictive"
+	assert.Error(suite.T(), config.Validate())
+
+	assert.NoError(suite.T(), suite.config.Validate())
+}
+
+func TestResizeSuite(t *testing.T) {
+	suite.Run(t, new(ResizeTestSuite))
+}
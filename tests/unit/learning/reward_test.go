@@ -0,0 +1,77 @@
+package learning_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// RewardFunction test requirements:
+// 1. Default rewards must stay within [-1.0, 1.0]
+// 2. SLA violations must be penalized
+// 3. Custom reward shaping must be usable through the RewardFunction interface
+
+type RewardFunctionTestSuite struct {
+	suite.Suite
+	reward *learning.DefaultRewardFunction
+	sla    learning.SLAPolicy
+}
+
+func (suite *RewardFunctionTestSuite) SetupTest() {
+	suite.reward = learning.NewDefaultRewardFunction()
+	suite.sla = learning.DefaultSLAPolicy()
+	suite.sla.MaxLatency = 50 * time.Millisecond
+}
+
+func (suite *RewardFunctionTestSuite) TestDefaultRewards() {
+	offload := decision.OffloadDecision{ShouldOffload: true}
+	local := decision.OffloadDecision{ShouldOffload: false}
+	success := decision.OffloadOutcome{Success: true, CompletedOnTime: true, LatencyActual: 10 * time.Millisecond}
+
+	assert.Equal(suite.T(), 1.0, suite.reward.Evaluate(offload, success, suite.sla))
+	assert.Equal(suite.T(), 0.3, suite.reward.Evaluate(local, success, suite.sla))
+
+	failed := success
+	failed.Success = false
+	assert.Equal(suite.T(), -1.0, suite.reward.Evaluate(offload, failed, suite.sla))
+}
+
+func (suite *RewardFunctionTestSuite) TestSLAViolationsPenalized() {
+	offload := decision.OffloadDecision{ShouldOffload: true}
+	onTime := decision.OffloadOutcome{Success: true, CompletedOnTime: true, LatencyActual: 10 * time.Millisecond}
+
+	slow := onTime
+	slow.LatencyActual = 100 * time.Millisecond
+	assert.Equal(suite.T(), -0.5, suite.reward.Evaluate(offload, slow, suite.sla),
+		"Latency above the SLA should yield the latency violation reward")
+
+	late := onTime
+	late.CompletedOnTime = false
+	assert.InDelta(suite.T(), 0.8, suite.reward.Evaluate(offload, late, suite.sla), 0.001,
+		"Missed deadlines should subtract the deadline penalty")
+
+	worst := slow
+	worst.CompletedOnTime = false
+	worst.PolicyViolation = true
+	assert.GreaterOrEqual(suite.T(), suite.reward.Evaluate(offload, worst, suite.sla), -1.0,
+		"Rewards should be clamped to -1.0")
+}
+
+func (suite *RewardFunctionTestSuite) TestCustomRewardFunction() {
+	var custom learning.RewardFunction = learning.RewardFunc(
+		func(dec decision.OffloadDecision, outcome decision.OffloadOutcome, sla learning.SLAPolicy) float64 {
+			return -outcome.CostActual
+		})
+
+	reward := custom.Evaluate(decision.OffloadDecision{}, decision.OffloadOutcome{CostActual: 0.25}, suite.sla)
+	assert.Equal(suite.T(), -0.25, reward)
+}
+
+func TestRewardFunctionSuite(t *testing.T) {
+	suite.Run(t, new(RewardFunctionTestSuite))
+}
@@ -0,0 +1,123 @@
+package learning_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+)
+
+// CompositePredictor test requirements:
+// 1. Demand must be split into trend, seasonal and residual components that
+//    sum to the observed values
+// 2. Forecasts must extrapolate the trend and continue the season at the
+//    right phase as history slides
+// 3. Until two cycles are seen, the mean must be forecast
+// 4. The forecaster must auto-select the seasonal predictor for daily cycles
+// 5. Invalid configurations must be rejected
+
+type SeasonalTestSuite struct {
+	suite.Suite
+	season []float64
+}
+
+func (suite *SeasonalTestSuite) SetupTest() {
+	suite.season = []float64{2, -1, 0, -1}
+}
+
+// demand is a rising series with a four-sample cycle
+func (suite *SeasonalTestSuite) demand(i int) float64 {
+	return 10 + 0.1*float64(i) + suite.season[i%4]
+}
+
+func (suite *SeasonalTestSuite) TestDecompose() {
+	predictor := learning.NewCompositePredictor(learning.SeasonalConfig{Period: 4})
+	for i := 0; i < 12; i++ {
+		predictor.Observe(suite.demand(i))
+	}
+
+	decomposition, ok := predictor.Decompose()
+	require.True(suite.T(), ok)
+	require.Len(suite.T(), decomposition.Trend, 12)
+	assert.InDelta(suite.T(), 0.1, decomposition.Trend[1]-decomposition.Trend[0], 1e-9)
+	for phase, value := range suite.season {
+		assert.InDelta(suite.T(), value, decomposition.Season[phase], 1e-9)
+	}
+	for i := range decomposition.Trend {
+		assert.InDelta(suite.T(), 0, decomposition.Residual[i], 1e-9)
+		sum := decomposition.Trend[i] + decomposition.Seasonal[i] + decomposition.Residual[i]
+		assert.InDelta(suite.T(), suite.demand(i), sum, 1e-9)
+	}
+
+	forecast, ok := predictor.Predict()
+	require.True(suite.T(), ok)
+	assert.InDelta(suite.T(), suite.demand(12), forecast, 1e-9)
+}
+
+func (suite *SeasonalTestSuite) TestSlidingHistory() {
+	predictor := learning.NewCompositePredictor(learning.SeasonalConfig{Period: 4, Cycles: 3})
+	for i := 0; i < 22; i++ {
+		predictor.Observe(suite.demand(i))
+	}
+
+	decomposition, ok := predictor.Decompose()
+	require.True(suite.T(), ok)
+	assert.Len(suite.T(), decomposition.Trend, 12, "Only the configured cycles are kept")
+
+	forecast, _ := predictor.Predict()
+	assert.InDelta(suite.T(), suite.demand(22), forecast, 1e-9, "The next value is at phase 2 of the cycle")
+}
+
+func (suite *SeasonalTestSuite) TestMeanBeforeTwoCycles() {
+	predictor := learning.NewCompositePredictor(learning.SeasonalConfig{Period: 4})
+	_, ok := predictor.Predict()
+	assert.False(suite.T(), ok)
+
+	for _, value := range []float64{1, 2, 3, 6, 3} {
+		predictor.Observe(value)
+	}
+	_, ok = predictor.Decompose()
+	assert.False(suite.T(), ok)
+	forecast, ok := predictor.Predict()
+	require.True(suite.T(), ok)
+	assert.InDelta(suite.T(), 3.0, forecast, 1e-9)
+}
+
+func (suite *SeasonalTestSuite) TestAutoSelectedForDailyCycles() {
+	forecaster := learning.NewForecaster(learning.ForecastConfig{
+		Enabled:  true,
+		Seasonal: learning.SeasonalConfig{Period: 24},
+	})
+	// Enough days for the error window to hold only decomposed forecasts
+	for hour := 0; hour < 10*24; hour++ {
+		load := 0.5 + 0.3*math.Sin(2*math.Pi*float64(hour)/24)
+		forecaster.Observe("cloud-1", "cloud", load)
+	}
+
+	forecast, ok := forecaster.Forecast("cloud-1")
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), learning.PredictorSeasonal, forecast.Predictor)
+	assert.InDelta(suite.T(), 0.5, forecast.Value, 1e-6, "Hour 0 of the eleventh day")
+}
+
+func (suite *SeasonalTestSuite) TestInvalidConfig() {
+	assert.Error(suite.T(), learning.SeasonalConfig{Period: 1}.Validate())
+	assert.Error(suite.T(), learning.SeasonalConfig{Period: 24, Cycles: 1}.Validate())
+	assert.Error(suite.T(), learning.SeasonalConfig{Period: 24, Alpha: 2}.Validate())
+	assert.NoError(suite.T(), learning.SeasonalConfig{Period: 24}.Validate())
+
+	assert.Error(suite.T(), learning.ForecastConfig{Predictor: learning.PredictorSeasonal}.Validate(),
+		"The seasonal predictor needs a period")
+	assert.NoError(suite.T(), learning.ForecastConfig{
+		Predictor: learning.PredictorSeasonal,
+		Seasonal:  learning.SeasonalConfig{Period: 24},
+	}.Validate())
+}
+
+func TestSeasonalSuite(t *testing.T) {
+	suite.Run(t, new(SeasonalTestSuite))
+}
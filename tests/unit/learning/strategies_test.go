@@ -0,0 +1,135 @@
+package learning_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Strategy sampling test requirements:
+// 1. Only strategies applicable to the process and hour may be selected
+// 2. Sampling must converge on the strategy whose decisions succeed
+// 3. Outcomes must only update the strategy that decided the process
+// 4. Invalid strategy sets must be rejected
+// 5. Strategy hours and business days must be read in the sampler's calendar
+
+type StrategyTestSuite struct {
+	suite.Suite
+	config learning.StrategyConfig
+	night  time.Time
+	noon   time.Time
+}
+
+func (suite *StrategyTestSuite) SetupTest() {
+	interactive := true
+	suite.config = learning.StrategyConfig{
+		Enabled: true,
+		Seed:    42,
+		Strategies: []learning.StrategyProfile{
+			{Name: "night-batch", Weights: decision.AdaptiveWeights{QueueDepth: 1, EnergyCost: 1}, Hours: []int{22, 23, 0, 1, 2, 3, 4, 5}},
+			{Name: "interactive-first", Weights: decision.AdaptiveWeights{LatencyCost: 1}, RealTime: &interactive},
+			{Name: "balanced", Weights: decision.AdaptiveWeights{QueueDepth: 1, ProcessorLoad: 1, NetworkCost: 1, LatencyCost: 1}},
+		},
+	}
+	suite.night = time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	suite.noon = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *StrategyTestSuite) sampler() *learning.ThompsonSampler {
+	sampler, err := learning.NewThompsonSampler(suite.config)
+	require.NoError(suite.T(), err)
+	return sampler
+}
+
+func (suite *StrategyTestSuite) TestApplicability() {
+	sampler := suite.sampler()
+	for i := 0; i < 50; i++ {
+		strategy, ok := sampler.Select(models.Process{ID: "batch"}, suite.noon)
+		require.True(suite.T(), ok)
+		assert.Equal(suite.T(), "balanced", strategy.Name, "Only balanced applies to batch work at noon")
+	}
+
+	strategy, _ := sampler.Select(models.Process{ID: "rt", RealTime: true}, suite.noon)
+	assert.NotEqual(suite.T(), "night-batch", strategy.Name)
+	assert.InDelta(suite.T(), 1.0, strategy.Weights.Sum(), 1e-9, "Profile weights are normalized")
+
+	suite.config.Strategies = suite.config.Strategies[:1]
+	_, ok := suite.sampler().Select(models.Process{ID: "p"}, suite.noon)
+	assert.False(suite.T(), ok)
+}
+
+func (suite *StrategyTestSuite) TestCalendar() {
+	weekdays := true
+	suite.config.Strategies = []learning.StrategyProfile{
+		{Name: "office-hours", Weights: decision.AdaptiveWeights{LatencyCost: 1}, Hours: []int{9, 10, 11, 12, 13, 14, 15, 16}, BusinessDays: &weekdays},
+	}
+	sampler := suite.sampler()
+	calendar, err := models.NewCalendar(models.CalendarConfig{TimeZone: "Europe/Stockholm", Holidays: []string{"2024-01-01"}})
+	require.NoError(suite.T(), err)
+	sampler.SetCalendar(calendar)
+
+	// 15:00 UTC is 16:00 in Stockholm, and 16:00 UTC is already past office hours
+	tuesday := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	_, ok := sampler.Select(models.Process{ID: "p1"}, tuesday)
+	assert.True(suite.T(), ok)
+	_, ok = sampler.Select(models.Process{ID: "p2"}, tuesday.Add(time.Hour))
+	assert.False(suite.T(), ok)
+
+	_, ok = sampler.Select(models.Process{ID: "p3"}, tuesday.Add(-24*time.Hour))
+	assert.False(suite.T(), ok, "New Year's Day is a holiday")
+	_, ok = sampler.Select(models.Process{ID: "p4"}, tuesday.Add(4*24*time.Hour))
+	assert.False(suite.T(), ok, "Saturday is not a business day")
+}
+
+func (suite *StrategyTestSuite) TestConvergesOnSuccessfulStrategy() {
+	sampler := suite.sampler()
+	for i := 0; i < 300; i++ {
+		process := models.Process{ID: fmt.Sprintf("p%d", i)}
+		strategy, ok := sampler.Select(process, suite.night)
+		require.True(suite.T(), ok)
+		success := strategy.Name == "night-batch"
+		sampler.Observe(decision.OffloadOutcome{ProcessID: process.ID, Success: success, CompletedOnTime: success})
+	}
+
+	stats := sampler.Stats()
+	require.Len(suite.T(), stats, 3)
+	assert.Equal(suite.T(), "balanced", stats[0].Name)
+	assert.Equal(suite.T(), "night-batch", stats[2].Name)
+	assert.Greater(suite.T(), stats[2].Selections, 250)
+	assert.Equal(suite.T(), stats[2].Selections, stats[2].Successes)
+	assert.Greater(suite.T(), stats[2].Mean, stats[0].Mean)
+	assert.Zero(suite.T(), stats[1].Selections, "interactive-first does not apply to batch work")
+
+	// Unknown processes do not update any strategy
+	sampler.Observe(decision.OffloadOutcome{ProcessID: "unknown", Success: true})
+	assert.Equal(suite.T(), stats, sampler.Stats())
+}
+
+func (suite *StrategyTestSuite) TestValidation() {
+	assert.NoError(suite.T(), suite.config.Validate())
+
+	invalid := suite.config
+	invalid.Strategies = append([]learning.StrategyProfile{}, suite.config.Strategies...)
+	invalid.Strategies[1].Name = "night-batch"
+	assert.Error(suite.T(), invalid.Validate(), "Duplicate names")
+
+	invalid.Strategies = []learning.StrategyProfile{{Name: "empty"}}
+	assert.Error(suite.T(), invalid.Validate(), "Weights must be positive")
+
+	invalid.Strategies = []learning.StrategyProfile{{Name: "late", Weights: decision.AdaptiveWeights{QueueDepth: 1}, Hours: []int{24}}}
+	assert.Error(suite.T(), invalid.Validate())
+
+	assert.Error(suite.T(), learning.StrategyConfig{Enabled: true}.Validate(), "Enabled without strategies")
+}
+
+func TestStrategySuite(t *testing.T) {
+	suite.Run(t, new(StrategyTestSuite))
+}
@@ -0,0 +1,86 @@
+package logging_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/logging"
+)
+
+// Logging test requirements:
+// 1. Level names must map to slog levels, rejecting unknown names
+// 2. File output with JSON format must write one structured record per line
+// 3. Records below the configured level must be dropped
+// 4. Invalid outputs and formats must be rejected
+
+type LoggingTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LoggingTestSuite) TestParseLevel() {
+	cases := map[string]slog.Level{
+		"":        slog.LevelInfo,
+		"debug":   slog.LevelDebug,
+		"INFO":    slog.LevelInfo,
+		"warning": slog.LevelWarn,
+		"error":   slog.LevelError,
+	}
+	for name, expected := range cases {
+		level, err := logging.ParseLevel(name)
+		require.NoError(suite.T(), err, name)
+		assert.Equal(suite.T(), expected, level, name)
+	}
+
+	_, err := logging.ParseLevel("verbose")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *LoggingTestSuite) TestJSONFileOutput() {
+	path := filepath.Join(suite.T().TempDir(), "offloader.log")
+
+	logger, closer, err := logging.New(logging.Config{
+		Level:    "warn",
+		Format:   logging.JSON,
+		Output:   logging.FILE,
+		FilePath: path,
+	})
+	require.NoError(suite.T(), err)
+
+	logger.Info("dropped below level")
+	logger.Warn("decision latency exceeds target", "process_id", "p-1")
+	require.NoError(suite.T(), closer.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(suite.T(), err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(suite.T(), lines, 1)
+
+	var record map[string]interface{}
+	require.NoError(suite.T(), json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(suite.T(), "WARN", record["level"])
+	assert.Equal(suite.T(), "p-1", record["process_id"])
+}
+
+func (suite *LoggingTestSuite) TestInvalidConfig() {
+	_, _, err := logging.New(logging.Config{Output: logging.FILE})
+	assert.Error(suite.T(), err, "File output requires a path")
+
+	_, _, err = logging.New(logging.Config{Output: "kafka"})
+	assert.Error(suite.T(), err)
+
+	_, _, err = logging.New(logging.Config{Format: "xml"})
+	assert.Error(suite.T(), err)
+}
+
+func TestLoggingSuite(t *testing.T) {
+	suite.Run(t, new(LoggingTestSuite))
+}
@@ -0,0 +1,88 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// Battery test requirements:
+// 1. Devices below the minimum charge, or with their duty cycle used up,
+//    must refuse offloads; charging and mains-powered targets must not
+// 2. Processes that would drain a device below the minimum must be refused
+// 3. Devices must be rated by the share of their energy budget a process
+//    leaves
+// 4. Invalid battery states and policies must be rejected
+
+type BatteryTestSuite struct {
+	suite.Suite
+	policy  models.BatteryPolicy
+	process models.Process
+}
+
+func (suite *BatteryTestSuite) SetupTest() {
+	suite.policy = models.BatteryPolicy{Enabled: true, MinLevel: 0.25}
+	suite.process = models.Process{CPURequirement: 2, EstimatedDuration: time.Hour}
+}
+
+// device returns a battery-powered target drawing 10W more when running the
+// process
+func (suite *BatteryTestSuite) device(level, capacityWh float64) models.OffloadTarget {
+	return models.OffloadTarget{
+		ID:              "sensor-1",
+		TotalCapacity:   2,
+		ProcessingSpeed: 1,
+		Power:           &models.PowerProfile{IdleWatts: 2, PeakWatts: 12},
+		Battery:         &models.BatteryState{Level: level, CapacityWh: capacityWh},
+	}
+}
+
+func (suite *BatteryTestSuite) TestRefusal() {
+	assert.Empty(suite.T(), suite.policy.Refusal(suite.process, suite.device(0.8, 100)))
+	assert.Contains(suite.T(), suite.policy.Refusal(suite.process, suite.device(0.2, 100)), "below minimum")
+
+	charging := suite.device(0.2, 100)
+	charging.Battery.Charging = true
+	assert.Empty(suite.T(), suite.policy.Refusal(suite.process, charging))
+
+	busy := suite.device(0.8, 100)
+	busy.Battery.DutyCycleLimit = 0.1
+	busy.Battery.DutyCycleUsed = 0.1
+	assert.Contains(suite.T(), suite.policy.Refusal(suite.process, busy), "duty cycle")
+
+	// 10Wh is more than the 5Wh left above the minimum
+	assert.Contains(suite.T(), suite.policy.Refusal(suite.process, suite.device(0.3, 100)), "drain")
+
+	assert.Empty(suite.T(), suite.policy.Refusal(suite.process, models.OffloadTarget{}), "Mains powered")
+	disabled := models.BatteryPolicy{}
+	assert.Empty(suite.T(), disabled.Refusal(suite.process, suite.device(0.1, 100)))
+}
+
+func (suite *BatteryTestSuite) TestEnergyBudget() {
+	full := suite.device(0.75, 100)
+	assert.Equal(suite.T(), units.Energy(50), suite.policy.EnergyBudget(full))
+	assert.InDelta(suite.T(), 0.8, suite.policy.BudgetHeadroom(suite.process, full, time.Hour), 1e-9)
+
+	low := suite.device(0.35, 100)
+	assert.InDelta(suite.T(), 0.0, suite.policy.BudgetHeadroom(suite.process, low, time.Hour), 1e-9)
+	assert.Equal(suite.T(), 1.0, suite.policy.BudgetHeadroom(suite.process, models.OffloadTarget{}, time.Hour))
+}
+
+func (suite *BatteryTestSuite) TestValidation() {
+	assert.NoError(suite.T(), suite.policy.Validate())
+	assert.Error(suite.T(), models.BatteryPolicy{MinLevel: 1}.Validate())
+	assert.Error(suite.T(), models.BatteryState{Level: 1.2}.Validate())
+	assert.Error(suite.T(), models.BatteryState{Level: 0.5, DutyCycleLimit: 2}.Validate())
+
+	target := suite.device(0.5, -1)
+	assert.Error(suite.T(), target.Validate())
+}
+
+func TestBatterySuite(t *testing.T) {
+	suite.Run(t, new(BatteryTestSuite))
+}
@@ -0,0 +1,75 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Calendar test requirements:
+// 1. Days must be read in the calendar's time zone, or the time's own when it
+//    has none
+// 2. Configured weekends and holidays, and those of added providers, must
+//    not be business days
+// 3. Unknown time zones, weekdays and malformed dates must be rejected
+
+type CalendarTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CalendarTestSuite) TestTimeZone() {
+	calendar, err := models.NewCalendar(models.CalendarConfig{TimeZone: "Asia/Tokyo"})
+	require.NoError(suite.T(), err)
+
+	// Friday 20:00 UTC is already Saturday in Tokyo
+	friday := time.Date(2024, 1, 5, 20, 0, 0, 0, time.UTC)
+	assert.Equal(suite.T(), time.Saturday, calendar.In(friday).Weekday())
+	assert.Equal(suite.T(), 5, calendar.In(friday).Hour())
+	assert.False(suite.T(), calendar.IsBusinessDay(friday))
+	assert.True(suite.T(), calendar.IsBusinessDay(friday.Add(-12*time.Hour)))
+
+	own := models.DefaultCalendar()
+	assert.Nil(suite.T(), own.Location())
+	assert.Equal(suite.T(), friday, own.In(friday), "Times are read in their own zone")
+	assert.True(suite.T(), own.IsBusinessDay(friday))
+}
+
+func (suite *CalendarTestSuite) TestHolidays() {
+	// A Sunday to Thursday working week
+	calendar, err := models.NewCalendar(models.CalendarConfig{
+		TimeZone: "UTC",
+		Weekend:  []time.Weekday{time.Friday, time.Saturday},
+		Holidays: []string{"2024-01-02"},
+	})
+	require.NoError(suite.T(), err)
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 12, 0, 0, 0, time.UTC) }
+	assert.True(suite.T(), calendar.IsBusinessDay(day(1)))
+	assert.False(suite.T(), calendar.IsBusinessDay(day(2)), "Configured holiday")
+	assert.False(suite.T(), calendar.IsBusinessDay(day(5)), "Friday is a weekend day")
+	assert.True(suite.T(), calendar.IsBusinessDay(day(7)), "Sunday is a working day")
+
+	calendar.AddHolidayProvider(models.HolidayDates{"2024-01-03": true})
+	assert.True(suite.T(), calendar.IsHoliday(day(3)))
+	assert.False(suite.T(), calendar.IsBusinessDay(day(3)))
+}
+
+func (suite *CalendarTestSuite) TestInvalidConfig() {
+	for _, config := range []models.CalendarConfig{
+		{TimeZone: "Mars/Olympus_Mons"},
+		{Weekend: []time.Weekday{7}},
+		{Holidays: []string{"01/02/2024"}},
+	} {
+		_, err := models.NewCalendar(config)
+		assert.Error(suite.T(), err, "%+v", config)
+	}
+}
+
+func TestCalendarSuite(t *testing.T) {
+	suite.Run(t, new(CalendarTestSuite))
+}
@@ -0,0 +1,89 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Capability requirements test requirements:
+// 1. Capabilities must be taxonomy flags or values in a known namespace
+// 2. AND must bind tighter than OR, with NOT and parentheses supported
+// 3. Malformed expressions and unknown capabilities must fail to parse
+// 4. Process validation must reject requirements that do not parse
+
+type CapabilityTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CapabilityTestSuite) TestTaxonomy() {
+	assert.True(suite.T(), models.GPU_ACCELERATED.IsValid())
+	assert.True(suite.T(), models.Capability("region:eu").IsValid())
+	assert.True(suite.T(), models.Capability("arch:arm64").IsValid())
+	assert.False(suite.T(), models.Capability("gpu").IsValid(), "Not a taxonomy flag")
+	assert.False(suite.T(), models.Capability("planet:mars").IsValid(), "Unknown namespace")
+	assert.False(suite.T(), models.Capability("region:").IsValid(), "Empty value")
+	assert.False(suite.T(), models.Capability("region:EU").IsValid(), "Values are lower-case")
+}
+
+func (suite *CapabilityTestSuite) TestMatching() {
+	requirement, err := models.ParseRequirements("gpu_accelerated AND (region:eu OR low_latency)")
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), requirement.Matches([]string{"gpu_accelerated", "region:eu"}))
+	assert.True(suite.T(), requirement.Matches([]string{"low_latency", "gpu_accelerated"}))
+	assert.False(suite.T(), requirement.Matches([]string{"gpu_accelerated", "region:us"}))
+	assert.False(suite.T(), requirement.Matches([]string{"region:eu", "low_latency"}))
+	assert.Equal(suite.T(), "gpu_accelerated AND (region:eu OR low_latency)", requirement.String())
+
+	// AND binds tighter than OR
+	requirement, err = models.ParseRequirements("ssd_storage or high_memory and not region:us")
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), requirement.Matches([]string{"ssd_storage", "region:us"}))
+	assert.True(suite.T(), requirement.Matches([]string{"high_memory"}))
+	assert.False(suite.T(), requirement.Matches([]string{"high_memory", "region:us"}))
+
+	requirement, err = models.ParseRequirements("  ")
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), requirement.Matches(nil), "Empty requirements match every target")
+}
+
+func (suite *CapabilityTestSuite) TestParseErrors() {
+	for _, expression := range []string{
+		"gpu",
+		"gpu_accelerated AND",
+		"(gpu_accelerated OR low_latency",
+		"gpu_accelerated low_latency",
+		"OR low_latency",
+		"region:eu)",
+	} {
+		_, err := models.ParseRequirements(expression)
+		assert.Error(suite.T(), err, expression)
+	}
+}
+
+func (suite *CapabilityTestSuite) TestProcessValidation() {
+	process := models.Process{
+		ID:                "inference-1",
+		Type:              "ml",
+		Priority:          5,
+		CPURequirement:    2.0,
+		MemoryRequirement: 4 * 1024 * 1024 * 1024,
+		EstimatedDuration: 30 * time.Second,
+		Requirements:      "gpu_accelerated AND region:eu",
+	}
+	assert.NoError(suite.T(), process.Validate())
+
+	process.Requirements = "gpu_accelerated AND cuda"
+	err := process.Validate()
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "Requirements")
+}
+
+func TestCapabilitySuite(t *testing.T) {
+	suite.Run(t, new(CapabilityTestSuite))
+}
@@ -0,0 +1,88 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// Energy test requirements:
+// 1. Power must rise from idle to peak along the utilization curve, or
+//    linearly without one
+// 2. A process's energy must be the extra power its load draws, times its
+//    duration and the site's PUE
+// 3. Energy must be priced per kWh with a power profile, and by the energy
+//    cost factor per hour without one
+// 4. Site PUEs must apply only to profiles without their own
+// 5. Invalid profiles and site PUEs must be rejected
+
+type EnergyTestSuite struct {
+	suite.Suite
+}
+
+func (suite *EnergyTestSuite) TestPowerCurve() {
+	linear := models.PowerProfile{IdleWatts: 100, PeakWatts: 300}
+	assert.Equal(suite.T(), units.Power(100), linear.Draw(0))
+	assert.Equal(suite.T(), units.Power(200), linear.Draw(0.5))
+	assert.Equal(suite.T(), units.Power(300), linear.Draw(2), "Utilization is clamped")
+
+	// Servers draw most of their dynamic power at low utilization
+	curved := models.PowerProfile{IdleWatts: 100, PeakWatts: 300, Curve: []float64{0, 0.6, 1}}
+	assert.InDelta(suite.T(), 220.0, curved.Draw(0.5).Watts(), 1e-9)
+	assert.InDelta(suite.T(), 160.0, curved.Draw(0.25).Watts(), 1e-9)
+	assert.InDelta(suite.T(), 300.0, curved.Draw(1).Watts(), 1e-9)
+}
+
+func (suite *EnergyTestSuite) TestEstimateEnergy() {
+	target := models.OffloadTarget{
+		TotalCapacity: 4,
+		CurrentLoad:   0.25,
+		Power:         &models.PowerProfile{IdleWatts: 100, PeakWatts: 500, PUE: 1.5},
+		EnergyPrice:   0.2,
+	}
+	process := models.Process{CPURequirement: 2}
+
+	// Half the capacity adds 200W, for 2h at a PUE of 1.5
+	energy := target.EstimateEnergy(process, 2*time.Hour)
+	assert.InDelta(suite.T(), 600.0, float64(energy), 1e-9)
+	assert.InDelta(suite.T(), 0.12, target.EnergyCostOver(process, 2*time.Hour), 1e-9)
+
+	// Without a profile the energy cost factor is per hour
+	target.Power = nil
+	target.EnergyCost = 0.3
+	assert.Zero(suite.T(), target.EstimateEnergy(process, 2*time.Hour))
+	assert.InDelta(suite.T(), 0.6, target.EnergyCostOver(process, 2*time.Hour), 1e-9)
+}
+
+func (suite *EnergyTestSuite) TestSitePUE() {
+	config := models.EnergyConfig{SitePUE: map[string]float64{"dc-1": 1.4}}
+	require.NoError(suite.T(), config.Validate())
+
+	target := config.ApplyTarget(models.OffloadTarget{Location: "dc-1", Power: &models.PowerProfile{PeakWatts: 100}})
+	assert.Equal(suite.T(), 1.4, target.Power.PUE)
+
+	own := config.ApplyTarget(models.OffloadTarget{Location: "dc-1", Power: &models.PowerProfile{PeakWatts: 100, PUE: 1.1}})
+	assert.Equal(suite.T(), 1.1, own.Power.PUE, "A profile's own PUE takes precedence")
+
+	unprofiled := config.ApplyTarget(models.OffloadTarget{Location: "dc-1"})
+	assert.Nil(suite.T(), unprofiled.Power)
+}
+
+func (suite *EnergyTestSuite) TestValidation() {
+	assert.NoError(suite.T(), models.PowerProfile{IdleWatts: 50, PeakWatts: 50}.Validate())
+	assert.Error(suite.T(), models.PowerProfile{IdleWatts: 100, PeakWatts: 50}.Validate())
+	assert.Error(suite.T(), models.PowerProfile{PeakWatts: 50, Curve: []float64{0.5}}.Validate())
+	assert.Error(suite.T(), models.PowerProfile{PeakWatts: 50, Curve: []float64{0, 1.5}}.Validate())
+	assert.Error(suite.T(), models.PowerProfile{PeakWatts: 50, PUE: 0.9}.Validate())
+	assert.Error(suite.T(), models.EnergyConfig{SitePUE: map[string]float64{"dc-1": 0.5}}.Validate())
+}
+
+func TestEnergySuite(t *testing.T) {
+	suite.Run(t, new(EnergyTestSuite))
+}
@@ -0,0 +1,93 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// JurisdictionGraph test requirements:
+// 1. Transfers within a jurisdiction are always allowed
+// 2. Cross-jurisdiction transfers require an explicit edge
+// 3. SCC-gated edges are only usable by processes with SCC approval
+// 4. Every residency tag of a process must reach the target jurisdiction
+// 5. Cost factors must take the most expensive required transfer
+
+type JurisdictionGraphTestSuite struct {
+	suite.Suite
+	graph *models.JurisdictionGraph
+}
+
+func (suite *JurisdictionGraphTestSuite) SetupTest() {
+	var err error
+	suite.graph, err = models.NewJurisdictionGraph(
+		models.TransferEdge{From: "EU", To: "domestic"},
+		models.TransferEdge{From: "EU", To: "US", RequiresSCC: true, CostFactor: 2.0},
+		models.TransferEdge{From: "domestic", To: "US", CostFactor: 1.5},
+	)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *JurisdictionGraphTestSuite) TestCanTransfer() {
+	ok, _ := suite.graph.CanTransfer("EU", "eu", false)
+	assert.True(suite.T(), ok, "Same jurisdiction, case-insensitive")
+
+	ok, _ = suite.graph.CanTransfer("EU", "domestic", false)
+	assert.True(suite.T(), ok)
+
+	ok, reason := suite.graph.CanTransfer("domestic", "EU", false)
+	assert.False(suite.T(), ok, "Edges are directed")
+	assert.Contains(suite.T(), reason, "not permitted")
+
+	ok, reason = suite.graph.CanTransfer("EU", "US", false)
+	assert.False(suite.T(), ok)
+	assert.Contains(suite.T(), reason, "standard contractual clauses")
+
+	ok, _ = suite.graph.CanTransfer("EU", "US", true)
+	assert.True(suite.T(), ok)
+
+	ok, _ = suite.graph.CanTransfer("EU", "", false)
+	assert.False(suite.T(), ok, "Unknown target jurisdiction")
+}
+
+func (suite *JurisdictionGraphTestSuite) TestCheckPlacement() {
+	us := models.OffloadTarget{ID: "us-1", DataJurisdiction: "US"}
+
+	unrestricted := models.Process{ID: "p-0"}
+	ok, _ := suite.graph.CheckPlacement(unrestricted, us)
+	assert.True(suite.T(), ok, "Processes without residency tags are unrestricted")
+
+	mixed := models.Process{ID: "p-1", DataResidency: []string{"domestic", "EU"}}
+	ok, _ = suite.graph.CheckPlacement(mixed, us)
+	assert.False(suite.T(), ok, "EU data needs SCC")
+
+	mixed.SCCApproved = true
+	ok, _ = suite.graph.CheckPlacement(mixed, us)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), 2.0, suite.graph.TransferCostFactor(mixed, us))
+
+	local := models.OffloadTarget{ID: "eu-1", DataJurisdiction: "EU"}
+	assert.Equal(suite.T(), 1.0, suite.graph.TransferCostFactor(models.Process{DataResidency: []string{"EU"}}, local))
+}
+
+func (suite *JurisdictionGraphTestSuite) TestInvalidEdges() {
+	_, err := models.NewJurisdictionGraph(models.TransferEdge{From: "EU"})
+	assert.Error(suite.T(), err)
+
+	_, err = models.NewJurisdictionGraph(models.TransferEdge{From: "EU", To: "US", CostFactor: -1})
+	assert.Error(suite.T(), err)
+
+	edges := suite.graph.Edges()
+	require.Len(suite.T(), edges, 3)
+	assert.Equal(suite.T(), "domestic", edges[0].From)
+	assert.Equal(suite.T(), "EU", edges[1].From)
+	assert.Equal(suite.T(), "domestic", edges[1].To)
+}
+
+func TestJurisdictionGraphSuite(t *testing.T) {
+	suite.Run(t, new(JurisdictionGraphTestSuite))
+}
@@ -16,6 +16,8 @@ import (
 // 2. All capacity metrics must be non-negative
 // 3. Scores must be in [0.0, 1.0] range
 // 4. Latency must be measurable and recent (< 60s old)
+// 5. Compute must be billed for at least the minimum lease in whole billing
+//    increments, and paid lease time must be known
 
 type OffloadTargetTestSuite struct {
 	suite.Suite
@@ -610,6 +612,46 @@ func (suite *OffloadTargetTestSuite) TestEconomicFactors() {
 	}
 }
 
+// Test billing granularity and lease accounting
+func (suite *OffloadTargetTestSuite) TestBilling() {
+	target := models.OffloadTarget{
+		ID:               "cloud-1",
+		Type:             models.PUBLIC_CLOUD,
+		ProcessingSpeed:  1.0,
+		ComputeCost:      1.0, // $1.00/hour
+		BillingIncrement: time.Hour,
+		MinimumLease:     time.Hour,
+	}
+	assert.Equal(suite.T(), time.Hour, target.BilledTime(10*time.Minute), "Short use is billed the minimum lease")
+	assert.Equal(suite.T(), 2*time.Hour, target.BilledTime(61*time.Minute), "Billed in whole increments")
+	assert.Equal(suite.T(), 2*time.Hour, target.BilledTime(2*time.Hour))
+
+	process := models.Process{ID: "short", CPURequirement: 1, EstimatedDuration: 10 * time.Minute}
+	assert.InDelta(suite.T(), 1.0, target.GetTotalCost(process), 1e-9, "Compute cost covers the billed hour")
+
+	perSecond := target
+	perSecond.BillingIncrement, perSecond.MinimumLease = time.Second, 0
+	assert.InDelta(suite.T(), perSecond.EstimateExecutionTime(process).Hours(), perSecond.GetTotalCost(process), 1e-6)
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(suite.T(), target.PaidUntil(start).IsZero(), "Not leased")
+	target.LeaseStart = start
+	assert.Equal(suite.T(), start.Add(time.Hour), target.PaidUntil(start.Add(20*time.Minute)))
+	assert.Equal(suite.T(), start.Add(2*time.Hour), target.PaidUntil(start.Add(time.Hour)), "The next hour starts at the boundary")
+
+	continuous := target
+	continuous.BillingIncrement, continuous.MinimumLease = 0, 30*time.Minute
+	assert.Equal(suite.T(), start.Add(30*time.Minute), continuous.PaidUntil(start.Add(10*time.Minute)))
+	assert.Equal(suite.T(), start.Add(time.Hour), continuous.PaidUntil(start.Add(time.Hour)))
+
+	target.BillingIncrement = -time.Second
+	target.NetworkLatency = 10 * time.Millisecond
+	target.TotalCapacity = 1
+	err := target.Validate()
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "BillingIncrement")
+}
+
 // Test policy compliance attributes
 func (suite *OffloadTargetTestSuite) TestPolicyComplianceAttributes() {
 	target := models.OffloadTarget{
@@ -0,0 +1,123 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// RecurringProcess test requirements:
+// 1. Cron expressions must support values, ranges, lists, steps and descriptors
+// 2. Invalid expressions must be rejected
+// 3. Day of month and day of week must match either when both are restricted
+// 4. Runs must stop at the definition's end and produce distinct instances
+// 5. Schedules must be matched in the definition's or calendar's time zone,
+//    skipping weekends and holidays when restricted to business days
+
+type RecurringProcessTestSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func (suite *RecurringProcessTestSuite) SetupTest() {
+	suite.start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // A Monday
+}
+
+func (suite *RecurringProcessTestSuite) next(expr string, after time.Time) time.Time {
+	schedule, err := models.ParseCron(expr)
+	require.NoError(suite.T(), err)
+	return schedule.Next(after)
+}
+
+func (suite *RecurringProcessTestSuite) TestNext() {
+	assert.Equal(suite.T(), suite.start.Add(15*time.Minute), suite.next("*/15 * * * *", suite.start))
+	assert.Equal(suite.T(), suite.start.Add(time.Hour), suite.next("@hourly", suite.start))
+	assert.Equal(suite.T(), time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC), suite.next("30 9 * * 2", suite.start),
+		"Next Tuesday")
+	assert.Equal(suite.T(), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), suite.next("0 0 * * 7", suite.start),
+		"7 is Sunday")
+	assert.Equal(suite.T(), time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC), suite.next("0 6 1 3,6 *", suite.start))
+	assert.Equal(suite.T(), time.Date(2024, 1, 1, 1, 5, 0, 0, time.UTC), suite.next("5 1-3 * * *", suite.start))
+	assert.True(suite.T(), suite.next("0 0 30 2 *", suite.start).IsZero(), "February 30th never comes")
+}
+
+func (suite *RecurringProcessTestSuite) TestInvalidExpressions() {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := models.ParseCron(expr)
+		assert.Error(suite.T(), err, expr)
+	}
+}
+
+func (suite *RecurringProcessTestSuite) TestDayOfMonthOrWeek() {
+	// The 15th or any Friday, whichever comes first
+	assert.Equal(suite.T(), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), suite.next("0 0 15 * 5", suite.start))
+	assert.Equal(suite.T(), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		suite.next("0 0 15 * 5", time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC)))
+}
+
+func (suite *RecurringProcessTestSuite) TestRuns() {
+	definition := models.RecurringProcess{
+		ID:       "report",
+		Schedule: "0 */6 * * *",
+		Template: models.Process{Priority: 5, EstimatedDuration: time.Minute, Status: models.EXECUTING},
+		Until:    suite.start.Add(12 * time.Hour),
+	}
+	require.NoError(suite.T(), definition.Validate())
+
+	runs, err := definition.Runs(suite.start, suite.start.Add(24*time.Hour), nil)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []time.Time{suite.start.Add(6 * time.Hour), suite.start.Add(12 * time.Hour)}, runs)
+
+	first, second := definition.Instance(runs[0]), definition.Instance(runs[1])
+	assert.NotEqual(suite.T(), first.ID, second.ID)
+	assert.Equal(suite.T(), runs[0], first.SubmissionTime)
+	assert.Equal(suite.T(), models.QUEUED, first.Status)
+
+	definition.Template.Priority = 0
+	assert.Error(suite.T(), definition.Validate(), "Template must be a valid process")
+}
+
+func (suite *RecurringProcessTestSuite) TestTimeZones() {
+	calendar, err := models.NewCalendar(models.CalendarConfig{TimeZone: "America/New_York", Holidays: []string{"2024-01-03"}})
+	require.NoError(suite.T(), err)
+	definition := models.RecurringProcess{
+		ID:       "nightly",
+		Schedule: "0 2 * * *",
+		Template: models.Process{Priority: 5, EstimatedDuration: time.Minute},
+	}
+
+	// 02:00 in New York is 07:00 UTC in winter
+	runs, err := definition.Runs(suite.start, suite.start.Add(24*time.Hour), calendar)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), runs, 1)
+	assert.True(suite.T(), runs[0].Equal(suite.start.Add(7*time.Hour)))
+
+	definition.TimeZone = "Asia/Tokyo"
+	runs, err = definition.Runs(suite.start, suite.start.Add(24*time.Hour), calendar)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), runs, 1)
+	assert.True(suite.T(), runs[0].Equal(suite.start.Add(17*time.Hour)), "The definition's zone wins")
+
+	// Monday January 1st to Monday January 8th, without the holiday and weekend
+	definition.TimeZone = ""
+	definition.BusinessDays = true
+	runs, err = definition.Runs(suite.start, suite.start.Add(7*24*time.Hour), calendar)
+	require.NoError(suite.T(), err)
+	days := make([]int, 0, len(runs))
+	for _, at := range runs {
+		days = append(days, at.Day())
+	}
+	assert.Equal(suite.T(), []int{1, 2, 4, 5}, days)
+
+	definition.TimeZone = "Mars/Olympus_Mons"
+	assert.Error(suite.T(), definition.Validate())
+}
+
+func TestRecurringProcessSuite(t *testing.T) {
+	suite.Run(t, new(RecurringProcessTestSuite))
+}
@@ -0,0 +1,76 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// SecurityPolicy test requirements:
+// 1. Data sensitivity must raise the required target security level
+// 2. Transfers at or above the encryption threshold must be encrypted
+// 3. Encryption overhead must grow with the bytes moved, plus a handshake
+// 4. A disabled policy must leave security levels and transfers unchanged
+
+type SecurityPolicyTestSuite struct {
+	suite.Suite
+	policy models.SecurityPolicy
+}
+
+func (suite *SecurityPolicyTestSuite) SetupTest() {
+	suite.policy = models.SecurityPolicy{
+		Enabled:              true,
+		RequiredLevels:       []int{0, 1, 2, 4, 5, 5},
+		EncryptionThreshold:  3,
+		EncryptionThroughput: 100 * 1024 * 1024,
+		HandshakeLatency:     10 * time.Millisecond,
+	}
+}
+
+func (suite *SecurityPolicyTestSuite) TestRequiredLevel() {
+	assert.Equal(suite.T(), 4, suite.policy.RequiredLevel(models.Process{DataSensitivity: 3, SecurityLevel: 1}))
+	assert.Equal(suite.T(), 3, suite.policy.RequiredLevel(models.Process{DataSensitivity: 1, SecurityLevel: 3}),
+		"The process's own security level still applies")
+
+	identity := models.SecurityPolicy{Enabled: true}
+	assert.Equal(suite.T(), 3, identity.RequiredLevel(models.Process{DataSensitivity: 3}),
+		"Without required levels the sensitivity is the level")
+
+	assert.Equal(suite.T(), 1, models.SecurityPolicy{}.RequiredLevel(models.Process{DataSensitivity: 5, SecurityLevel: 1}))
+}
+
+func (suite *SecurityPolicyTestSuite) TestEncryptionOverhead() {
+	process := models.Process{DataSensitivity: 3, InputSize: 200 * 1024 * 1024}
+	assert.True(suite.T(), suite.policy.RequiresEncryption(process))
+	assert.Equal(suite.T(), 2*time.Second+10*time.Millisecond, suite.policy.EncryptionOverhead(process))
+
+	process.DataSensitivity = 2
+	assert.False(suite.T(), suite.policy.RequiresEncryption(process))
+	assert.Zero(suite.T(), suite.policy.EncryptionOverhead(process))
+
+	assert.Zero(suite.T(), suite.policy.EncryptionOverhead(models.Process{DataSensitivity: 5}), "No data, no encryption")
+	assert.Zero(suite.T(), models.SecurityPolicy{}.EncryptionOverhead(models.Process{DataSensitivity: 5, InputSize: 1 << 30}))
+}
+
+func (suite *SecurityPolicyTestSuite) TestValidate() {
+	assert.NoError(suite.T(), suite.policy.Validate())
+
+	invalid := suite.policy
+	invalid.RequiredLevels = []int{0, 1, 2}
+	assert.Error(suite.T(), invalid.Validate(), "A level for each sensitivity")
+
+	invalid.RequiredLevels = []int{0, 3, 2, 4, 5, 5}
+	assert.Error(suite.T(), invalid.Validate(), "Levels must not decrease")
+
+	invalid = suite.policy
+	invalid.EncryptionThroughput = -1
+	assert.Error(suite.T(), invalid.Validate())
+}
+
+func TestSecurityPolicySuite(t *testing.T) {
+	suite.Run(t, new(SecurityPolicyTestSuite))
+}
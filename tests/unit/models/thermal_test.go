@@ -0,0 +1,72 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+)
+
+// Thermal test requirements:
+// 1. Targets must run at full speed up to the throttle temperature, then
+//    slow by the throttle rate per degree
+// 2. Heavy processes must be refused by targets at the maximum temperature,
+//    and have less headroom on hotter targets
+// 3. Light processes and targets that report no temperature must be
+//    unaffected
+// 4. Maximum temperatures at or below the throttle temperature must be
+//    rejected
+
+type ThermalTestSuite struct {
+	suite.Suite
+	policy models.ThermalPolicy
+}
+
+func (suite *ThermalTestSuite) SetupTest() {
+	suite.policy = models.ThermalPolicy{Enabled: true, ThrottleTemperature: 80, MaxTemperature: 90, ThrottlePerDegree: 0.05}
+}
+
+func (suite *ThermalTestSuite) TestThrottling() {
+	assert.Equal(suite.T(), 1.0, suite.policy.SpeedFactor(80))
+	assert.InDelta(suite.T(), 0.75, suite.policy.SpeedFactor(85), 1e-9)
+	assert.InDelta(suite.T(), 0.2, suite.policy.SpeedFactor(200), 1e-9, "Throttling is bounded")
+
+	hot := models.OffloadTarget{Temperature: 85}
+	assert.Equal(suite.T(), 4*time.Minute, suite.policy.Throttled(3*time.Minute, hot))
+
+	disabled := suite.policy
+	disabled.Enabled = false
+	assert.Equal(suite.T(), 3*time.Minute, disabled.Throttled(3*time.Minute, hot))
+}
+
+func (suite *ThermalTestSuite) TestHeavyProcesses() {
+	heavy := models.Process{CPURequirement: 8}
+	light := models.Process{CPURequirement: 1}
+
+	hot := models.OffloadTarget{Temperature: 92}
+	warm := models.OffloadTarget{Temperature: 85}
+	cool := models.OffloadTarget{Temperature: 60}
+
+	assert.True(suite.T(), suite.policy.TooHot(heavy, hot))
+	assert.False(suite.T(), suite.policy.TooHot(light, hot))
+	assert.False(suite.T(), suite.policy.TooHot(heavy, models.OffloadTarget{}), "No temperature reported")
+
+	assert.Equal(suite.T(), 1.0, suite.policy.Headroom(heavy, cool))
+	assert.InDelta(suite.T(), 0.5, suite.policy.Headroom(heavy, warm), 1e-9)
+	assert.Equal(suite.T(), 1.0, suite.policy.Headroom(light, warm))
+}
+
+func (suite *ThermalTestSuite) TestValidation() {
+	assert.NoError(suite.T(), suite.policy.Validate())
+	assert.NoError(suite.T(), models.ThermalPolicy{Enabled: true}.Validate(), "Defaults are valid")
+	assert.Error(suite.T(), models.ThermalPolicy{ThrottleTemperature: 90, MaxTemperature: 90}.Validate())
+	assert.Error(suite.T(), models.ThermalPolicy{ThrottlePerDegree: 1}.Validate())
+	assert.Error(suite.T(), models.ThermalPolicy{HeavyCPU: -1}.Validate())
+}
+
+func TestThermalSuite(t *testing.T) {
+	suite.Run(t, new(ThermalTestSuite))
+}
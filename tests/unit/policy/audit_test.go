@@ -0,0 +1,125 @@
+package policy_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// AuditWriter test requirements:
+// 1. Every audit log entry must be appended as a hash-chained record
+// 2. Files must rotate by size and the chain must continue across files
+// 3. Reopening a directory must resume its chain
+// 4. Edited, removed or reordered records must fail verification
+// 5. Signed trails must only verify with the signing key
+
+type AuditTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *AuditTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+	suite.T().Setenv("AUDIT_TEST_KEY", "secret")
+}
+
+func (suite *AuditTestSuite) write(config policy.AuditConfig, count int) {
+	writer, err := policy.NewAuditWriter(config)
+	require.NoError(suite.T(), err)
+	for i := 0; i < count; i++ {
+		require.NoError(suite.T(), writer.Append(policy.AuditLog{
+			ID:        fmt.Sprintf("audit_%d", i+1),
+			Timestamp: time.Now(),
+			EventType: "policy_evaluation",
+			ProcessID: fmt.Sprintf("process-%d", i+1),
+			Decision:  "allowed",
+		}))
+	}
+	require.NoError(suite.T(), writer.Close())
+}
+
+func (suite *AuditTestSuite) TestChainAcrossRotationAndReopen() {
+	config := policy.AuditConfig{Dir: suite.dir, MaxFileBytes: 1024}
+	suite.write(config, 10)
+	suite.write(config, 5)
+
+	files, err := filepath.Glob(filepath.Join(suite.dir, "audit-*.jsonl"))
+	require.NoError(suite.T(), err)
+	assert.Greater(suite.T(), len(files), 1, "Small files must rotate")
+
+	result, err := policy.VerifyAuditTrail(suite.dir, nil)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(15), result.Records)
+	assert.Equal(suite.T(), len(files), result.Files)
+}
+
+func (suite *AuditTestSuite) TestTamperDetection() {
+	suite.write(policy.AuditConfig{Dir: suite.dir}, 3)
+	path := filepath.Join(suite.dir, "audit-000001.jsonl")
+	original, err := os.ReadFile(path)
+	require.NoError(suite.T(), err)
+	lines := strings.SplitAfter(string(original), "\n")
+
+	edited := strings.Replace(string(original), `"decision":"allowed"`, `"decision":"blocked"`, 1)
+	require.NoError(suite.T(), os.WriteFile(path, []byte(edited), 0o640))
+	_, err = policy.VerifyAuditTrail(suite.dir, nil)
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "audit-000001.jsonl:1")
+
+	removed := lines[0] + lines[2]
+	require.NoError(suite.T(), os.WriteFile(path, []byte(removed), 0o640))
+	_, err = policy.VerifyAuditTrail(suite.dir, nil)
+	assert.Error(suite.T(), err)
+
+	reordered := lines[1] + lines[0] + lines[2]
+	require.NoError(suite.T(), os.WriteFile(path, []byte(reordered), 0o640))
+	_, err = policy.VerifyAuditTrail(suite.dir, nil)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AuditTestSuite) TestSignatures() {
+	suite.write(policy.AuditConfig{Dir: suite.dir, SigningKeyEnv: "AUDIT_TEST_KEY"}, 3)
+
+	result, err := policy.VerifyAuditTrail(suite.dir, []byte("secret"))
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), result.Signed)
+
+	_, err = policy.VerifyAuditTrail(suite.dir, []byte("guess"))
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "invalid signature")
+
+	_, err = policy.NewAuditWriter(policy.AuditConfig{Dir: suite.dir, SigningKeyEnv: "AUDIT_TEST_MISSING_KEY"})
+	assert.Error(suite.T(), err, "A configured key must be present")
+}
+
+func (suite *AuditTestSuite) TestPolicyEnginePersistsAuditLogs() {
+	writer, err := policy.NewAuditWriter(policy.AuditConfig{Dir: suite.dir})
+	require.NoError(suite.T(), err)
+	engine := policy.NewPolicyEngine()
+	engine.SetAuditWriter(writer)
+
+	engine.EvaluatePolicy(
+		models.Process{ID: "process-1", Priority: 5, EstimatedDuration: time.Second},
+		models.OffloadTarget{ID: "edge-1", Type: models.EDGE, SecurityLevel: 3},
+	)
+	engine.RecordAuditEvent("decision_explanation", "process-1", "edge-1", "offload", nil)
+	require.NoError(suite.T(), writer.Close())
+
+	result, err := policy.VerifyAuditTrail(suite.dir, nil)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(len(engine.GetAuditLogs())), result.Records)
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(AuditTestSuite))
+}
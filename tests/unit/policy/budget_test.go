@@ -0,0 +1,96 @@
+package policy_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// BudgetManager test requirements:
+// 1. Spend must be tracked over rolling hourly and daily windows
+// 2. Remaining budget is the tightest window's headroom
+// 3. Cost pressure must start at the soft threshold and saturate at the limit
+// 4. Invalid configurations must be rejected
+
+type BudgetTestSuite struct {
+	suite.Suite
+	now     time.Time
+	manager *policy.BudgetManager
+}
+
+func (suite *BudgetTestSuite) SetupTest() {
+	suite.now = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var err error
+	suite.manager, err = policy.NewBudgetManager(policy.BudgetConfig{
+		MaxCostPerHour: 10.0,
+		MaxCostPerDay:  50.0,
+	})
+	require.NoError(suite.T(), err)
+	suite.manager.SetClock(func() time.Time { return suite.now })
+}
+
+func (suite *BudgetTestSuite) TestRollingWindows() {
+	suite.manager.Record(4.0)
+	suite.now = suite.now.Add(30 * time.Minute)
+	suite.manager.Record(4.0)
+
+	assert.InDelta(suite.T(), 8.0, suite.manager.Spent(time.Hour), 1e-9)
+	assert.InDelta(suite.T(), 2.0, suite.manager.Remaining(), 1e-9)
+	assert.True(suite.T(), suite.manager.Allows(2.0))
+	assert.False(suite.T(), suite.manager.Allows(2.5))
+
+	// The first charge leaves the hourly window
+	suite.now = suite.now.Add(45 * time.Minute)
+	assert.InDelta(suite.T(), 4.0, suite.manager.Spent(time.Hour), 1e-9)
+	assert.InDelta(suite.T(), 6.0, suite.manager.Remaining(), 1e-9)
+
+	// The daily budget binds once hourly spend is spread out
+	for i := 0; i < 5; i++ {
+		suite.now = suite.now.Add(2 * time.Hour)
+		suite.manager.Record(8.0)
+	}
+	assert.InDelta(suite.T(), 48.0, suite.manager.Spent(24*time.Hour), 1e-9)
+	assert.InDelta(suite.T(), 2.0, suite.manager.Remaining(), 1e-9)
+
+	// Corrections lower the spend
+	suite.manager.Record(-1.0)
+	assert.InDelta(suite.T(), 3.0, suite.manager.Remaining(), 1e-9)
+}
+
+func (suite *BudgetTestSuite) TestPressure() {
+	assert.Equal(suite.T(), 0.0, suite.manager.Pressure())
+
+	suite.manager.Record(5.0)
+	assert.InDelta(suite.T(), 0.0, suite.manager.Pressure(), 1e-9, "At the soft threshold")
+
+	suite.manager.Record(2.5)
+	assert.InDelta(suite.T(), 0.5, suite.manager.Pressure(), 1e-9)
+
+	suite.manager.Record(5.0)
+	assert.Equal(suite.T(), 1.0, suite.manager.Pressure())
+	assert.Equal(suite.T(), 0.0, suite.manager.Remaining())
+}
+
+func (suite *BudgetTestSuite) TestConfiguration() {
+	assert.False(suite.T(), policy.BudgetConfig{}.IsEnabled())
+
+	unlimited, err := policy.NewBudgetManager(policy.BudgetConfig{})
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), math.IsInf(unlimited.Remaining(), 1))
+
+	_, err = policy.NewBudgetManager(policy.BudgetConfig{MaxCostPerHour: -1})
+	assert.Error(suite.T(), err)
+	_, err = policy.NewBudgetManager(policy.BudgetConfig{MaxCostPerHour: 1, SoftThreshold: 1})
+	assert.Error(suite.T(), err)
+}
+
+func TestBudgetSuite(t *testing.T) {
+	suite.Run(t, new(BudgetTestSuite))
+}
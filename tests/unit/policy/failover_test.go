@@ -0,0 +1,132 @@
+package policy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// FailoverPolicy test requirements:
+// 1. Offloads must stay in the preferred region while it has a usable target
+// 2. Unhealthy or saturated preferred regions must fail over to the next
+//    ranked region, recording and counting the failover
+// 3. Targets outside the ranked regions must never be used
+// 4. Invalid configurations must be rejected
+
+type FailoverTestSuite struct {
+	suite.Suite
+	now    time.Time
+	policy *policy.FailoverPolicy
+}
+
+func (suite *FailoverTestSuite) SetupTest() {
+	suite.now = time.Now()
+
+	var err error
+	suite.policy, err = policy.NewFailoverPolicy(policy.FailoverConfig{
+		Enabled: true,
+		Regions: []string{"eu-north", "eu-west", "eu-central"},
+	})
+	require.NoError(suite.T(), err)
+}
+
+func (suite *FailoverTestSuite) target(id, region string) models.OffloadTarget {
+	return models.OffloadTarget{
+		ID:                id,
+		Location:          region,
+		TotalCapacity:     8.0,
+		AvailableCapacity: 4.0,
+		MemoryAvailable:   8 << 30,
+		CurrentLoad:       0.5,
+		Reliability:       0.95,
+		LastSeen:          suite.now,
+	}
+}
+
+func (suite *FailoverTestSuite) TestPreferredRegion() {
+	targets := []models.OffloadTarget{
+		suite.target("west-1", "eu-west"),
+		suite.target("north-1", "eu-north"),
+		suite.target("north-2", "eu-north"),
+	}
+
+	selected, event, ok := suite.policy.Select("proc-1", targets, suite.now)
+	require.True(suite.T(), ok)
+	assert.Nil(suite.T(), event)
+	require.Len(suite.T(), selected, 2)
+	assert.Equal(suite.T(), "north-1", selected[0].ID)
+	assert.Equal(suite.T(), "north-2", selected[1].ID)
+
+	stats := suite.policy.Stats()
+	assert.Equal(suite.T(), 1, stats.Selections)
+	assert.Zero(suite.T(), stats.Failovers)
+	assert.Empty(suite.T(), suite.policy.Events())
+}
+
+func (suite *FailoverTestSuite) TestFailover() {
+	unhealthy := suite.target("north-1", "eu-north")
+	unhealthy.Reliability = 0.2
+	saturated := suite.target("west-1", "eu-west")
+	saturated.CurrentLoad = 0.95
+	targets := []models.OffloadTarget{unhealthy, saturated, suite.target("central-1", "eu-central")}
+
+	selected, event, ok := suite.policy.Select("proc-1", targets, suite.now)
+	require.True(suite.T(), ok)
+	require.Len(suite.T(), selected, 1)
+	assert.Equal(suite.T(), "central-1", selected[0].ID)
+	require.NotNil(suite.T(), event)
+	assert.Equal(suite.T(), "eu-north", event.PreferredRegion)
+	assert.Equal(suite.T(), "eu-central", event.Region)
+	assert.Equal(suite.T(), "all targets unhealthy", event.Reason)
+
+	// With the preferred region saturated rather than down
+	healthy := suite.target("north-1", "eu-north")
+	healthy.CurrentLoad = 0.95
+	_, event, ok = suite.policy.Select("proc-2", []models.OffloadTarget{healthy, suite.target("west-2", "eu-west")}, suite.now)
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), "eu-west", event.Region)
+	assert.Equal(suite.T(), "all healthy targets saturated", event.Reason)
+
+	// Nothing usable anywhere
+	_, event, ok = suite.policy.Select("proc-3", []models.OffloadTarget{unhealthy, saturated}, suite.now)
+	assert.False(suite.T(), ok)
+	require.NotNil(suite.T(), event)
+	assert.Empty(suite.T(), event.Region)
+
+	stats := suite.policy.Stats()
+	assert.Equal(suite.T(), 3, stats.Selections)
+	assert.Equal(suite.T(), 2, stats.Failovers)
+	assert.Equal(suite.T(), 1, stats.Exhausted)
+	assert.Equal(suite.T(), map[string]int{"eu-central": 1, "eu-west": 1}, stats.ByRegion)
+
+	events := suite.policy.Events()
+	require.Len(suite.T(), events, 3)
+	assert.Equal(suite.T(), "proc-1", events[0].ProcessID)
+	assert.Equal(suite.T(), "proc-3", events[2].ProcessID)
+}
+
+func (suite *FailoverTestSuite) TestUnrankedRegionsAreNotUsed() {
+	selected, event, ok := suite.policy.Select("proc-1", []models.OffloadTarget{suite.target("us-1", "us-east")}, suite.now)
+	assert.False(suite.T(), ok)
+	assert.Empty(suite.T(), selected)
+	require.NotNil(suite.T(), event)
+	assert.Equal(suite.T(), "no policy-compliant targets", event.Reason)
+}
+
+func (suite *FailoverTestSuite) TestInvalidConfig() {
+	assert.Error(suite.T(), policy.FailoverConfig{Enabled: true}.Validate(), "Regions are required")
+	assert.Error(suite.T(), policy.FailoverConfig{Regions: []string{"eu-north", "eu-north"}}.Validate())
+	assert.Error(suite.T(), policy.FailoverConfig{Regions: []string{""}}.Validate())
+	assert.Error(suite.T(), policy.FailoverConfig{MaxLoad: 1.5}.Validate())
+	assert.NoError(suite.T(), policy.FailoverConfig{}.Validate())
+}
+
+func TestFailoverSuite(t *testing.T) {
+	suite.Run(t, new(FailoverTestSuite))
+}
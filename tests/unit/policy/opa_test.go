@@ -0,0 +1,149 @@
+package policy_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// OPA backend test requirements:
+// 1. Process and target must be sent as the OPA input document
+// 2. Boolean and object policy results must both be understood
+// 3. Denied targets must be blocked by hard OPA rules and penalized by soft ones
+// 4. Unreachable or failing OPA servers must resolve to the FailOpen setting
+
+type OPATestSuite struct {
+	suite.Suite
+	server  *httptest.Server
+	paths   []string
+	process models.Process
+	eu      models.OffloadTarget
+	us      models.OffloadTarget
+}
+
+func (suite *OPATestSuite) SetupTest() {
+	suite.paths = nil
+
+	// Emulates a Rego policy keeping sensitive data inside the EU
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.paths = append(suite.paths, r.URL.Path)
+
+		var body struct {
+			Input struct {
+				Process map[string]interface{} `json:"process"`
+				Target  map[string]interface{} `json:"target"`
+			} `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		sensitive := body.Input.Process["data_sensitivity"].(float64) >= 4
+		inEU := body.Input.Target["data_jurisdiction"] == "EU"
+
+		switch r.URL.Path {
+		case "/v1/data/cape/allow":
+			json.NewEncoder(w).Encode(map[string]interface{}{"result": !sensitive || inEU})
+		case "/v1/data/cape/placement":
+			result := map[string]interface{}{"allow": true, "deny": []string{}}
+			if sensitive && !inEU {
+				result["deny"] = []string{"sensitive data must stay in the EU"}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"result": result})
+		case "/v1/data/cape/undefined":
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		default:
+			http.Error(w, "not found", http.StatusInternalServerError)
+		}
+	}))
+
+	suite.process = models.Process{ID: "p-1", DataSensitivity: 5}
+	suite.eu = models.OffloadTarget{ID: "eu-1", DataJurisdiction: "EU"}
+	suite.us = models.OffloadTarget{ID: "us-1", DataJurisdiction: "US"}
+}
+
+func (suite *OPATestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *OPATestSuite) engineWith(config policy.OPAConfig) *policy.PolicyEngine {
+	config.URL = suite.server.URL
+	client, err := policy.NewOPAClient(config)
+	require.NoError(suite.T(), err)
+
+	engine := policy.NewPolicyEngine()
+	require.NoError(suite.T(), engine.ReplaceRules(policy.OPASource, []policy.PolicyRule{client.Rule()}))
+	return engine
+}
+
+func (suite *OPATestSuite) TestBooleanResult() {
+	engine := suite.engineWith(policy.OPAConfig{Path: "cape/allow"})
+
+	assert.True(suite.T(), engine.EvaluatePolicy(suite.process, suite.eu).Allowed)
+	assert.False(suite.T(), engine.EvaluatePolicy(suite.process, suite.us).Allowed)
+	assert.Equal(suite.T(), "/v1/data/cape/allow", suite.paths[0])
+
+	public := suite.process
+	public.DataSensitivity = 1
+	assert.True(suite.T(), engine.EvaluatePolicy(public, suite.us).Allowed)
+}
+
+func (suite *OPATestSuite) TestObjectResultWithDenyReasons() {
+	config := policy.OPAConfig{URL: suite.server.URL, Path: "cape/placement"}
+	client, err := policy.NewOPAClient(config)
+	require.NoError(suite.T(), err)
+
+	decision, err := client.Query(context.Background(), suite.process, suite.us)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), decision.Allow)
+	assert.Equal(suite.T(), []string{"sensitive data must stay in the EU"}, decision.Deny)
+
+	evaluation := suite.engineWith(config).EvaluatePolicy(suite.process, suite.us)
+	assert.False(suite.T(), evaluation.Allowed)
+	require.Len(suite.T(), evaluation.ViolatedRules, 1)
+	assert.Equal(suite.T(), "opa:cape/placement", evaluation.ViolatedRules[0].ID)
+}
+
+func (suite *OPATestSuite) TestSoftRulePenalizes() {
+	engine := suite.engineWith(policy.OPAConfig{Path: "cape/allow", Type: models.SOFT, Penalty: 0.4})
+
+	evaluation := engine.EvaluatePolicy(suite.process, suite.us)
+	assert.True(suite.T(), evaluation.Allowed)
+	assert.InDelta(suite.T(), -0.4, evaluation.ScoreAdjustment, 1e-9)
+}
+
+func (suite *OPATestSuite) TestFailures() {
+	closed := suite.engineWith(policy.OPAConfig{Path: "cape/missing"})
+	assert.False(suite.T(), closed.EvaluatePolicy(suite.process, suite.eu).Allowed, "Fail closed by default")
+
+	open := suite.engineWith(policy.OPAConfig{Path: "cape/missing", FailOpen: true})
+	assert.True(suite.T(), open.EvaluatePolicy(suite.process, suite.eu).Allowed)
+
+	undefined := suite.engineWith(policy.OPAConfig{Path: "cape/undefined"})
+	assert.False(suite.T(), undefined.EvaluatePolicy(suite.process, suite.eu).Allowed)
+
+	client, err := policy.NewOPAClient(policy.OPAConfig{URL: "http://127.0.0.1:1", Path: "cape/allow", Timeout: 50 * time.Millisecond})
+	require.NoError(suite.T(), err)
+	_, err = client.Query(context.Background(), suite.process, suite.eu)
+	assert.Error(suite.T(), err)
+
+	_, err = policy.NewOPAClient(policy.OPAConfig{Path: "cape/allow"})
+	assert.Error(suite.T(), err)
+	_, err = policy.NewOPAClient(policy.OPAConfig{URL: suite.server.URL})
+	assert.Error(suite.T(), err)
+}
+
+func TestOPASuite(t *testing.T) {
+	suite.Run(t, new(OPATestSuite))
+}
@@ -371,10 +371,12 @@ func (suite *PolicyEngineTestSuite) TestAuditLogging() {
 	}
 	
 	evaluationCount := 0
+	var evalTime time.Duration
 	for _, process := range processes {
 		for _, target := range targets {
-			suite.policyEngine.EvaluatePolicy(process, target)
+			evaluation := suite.policyEngine.EvaluatePolicy(process, target)
 			evaluationCount++
+			evalTime += evaluation.EvaluationTime
 		}
 	}
 	
@@ -401,6 +403,8 @@ func (suite *PolicyEngineTestSuite) TestAuditLogging() {
 		"Statistics should track violations")
 	assert.Greater(suite.T(), stats.AverageEvalTime, time.Duration(0),
 		"Statistics should track evaluation time")
+	assert.InDelta(suite.T(), float64(evalTime)/float64(evaluationCount), float64(stats.AverageEvalTime), float64(evaluationCount),
+		"Every evaluation should weigh equally in the average")
 }
 
 // Run the test suite
@@ -0,0 +1,58 @@
+package policy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// Retry policy test requirements:
+// 1. Each backoff strategy must grow delays as specified, capped at MaxDelay
+// 2. The policy must derive from the safety constraints
+// 3. Invalid policies must be rejected
+
+type RetryPolicyTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RetryPolicyTestSuite) TestBackoffDelays() {
+	exponential := policy.RetryPolicy{Backoff: policy.EXPONENTIAL, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	assert.Equal(suite.T(), time.Second, exponential.Delay(1))
+	assert.Equal(suite.T(), 2*time.Second, exponential.Delay(2))
+	assert.Equal(suite.T(), 4*time.Second, exponential.Delay(3))
+	assert.Equal(suite.T(), 5*time.Second, exponential.Delay(10), "Delays are capped")
+
+	linear := policy.RetryPolicy{Backoff: policy.LINEAR, BaseDelay: time.Second}
+	assert.Equal(suite.T(), 3*time.Second, linear.Delay(3))
+
+	fixed := policy.RetryPolicy{Backoff: policy.FIXED, BaseDelay: time.Second}
+	assert.Equal(suite.T(), time.Second, fixed.Delay(5))
+
+	assert.Equal(suite.T(), 100*time.Millisecond, policy.RetryPolicy{}.Delay(1), "Defaults apply")
+}
+
+func (suite *RetryPolicyTestSuite) TestFromSafetyConstraints() {
+	retry := policy.RetryPolicyFor(policy.SafetyConstraints{
+		MaxRetries:      3,
+		BackoffStrategy: policy.LINEAR,
+		LocalFallback:   true,
+	})
+	assert.Equal(suite.T(), 3, retry.MaxRetries)
+	assert.Equal(suite.T(), policy.LINEAR, retry.Backoff)
+	assert.True(suite.T(), retry.LocalFallback)
+	assert.NoError(suite.T(), retry.Validate())
+}
+
+func (suite *RetryPolicyTestSuite) TestValidate() {
+	assert.Error(suite.T(), policy.RetryPolicy{MaxRetries: -1}.Validate())
+	assert.Error(suite.T(), policy.RetryPolicy{BaseDelay: -time.Second}.Validate())
+	assert.Error(suite.T(), policy.RetryPolicy{Backoff: "random"}.Validate())
+}
+
+func TestRetryPolicySuite(t *testing.T) {
+	suite.Run(t, new(RetryPolicyTestSuite))
+}
@@ -0,0 +1,217 @@
+package policy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+)
+
+// Rule DSL test requirements:
+// 1. JSON and YAML rule files must compile to equivalent rules
+// 2. Hard rules block, soft rules apply their configured penalty
+// 3. Invalid rules must be rejected with the offending rule ID
+// 4. Reloading a rule file must replace only the rules from that file
+// 5. Membership operators must test against list fields named by value_field
+
+const yamlRules = `
+rules:
+  - id: eu-sensitive-data
+    description: Sensitive data must stay in EU jurisdiction
+    type: hard
+    condition:
+      any:
+        - field: process.data_sensitivity
+          op: lt
+          value: 4
+        - field: target.data_jurisdiction
+          op: in
+          value: [EU, SE]
+  - id: security-clearance
+    description: Target must meet process security level
+    type: hard
+    condition:
+      field: target.security_level
+      op: ge
+      value_field: process.security_level
+  - id: green-energy
+    description: Prefer renewable energy
+    type: soft
+    penalty: 0.35
+    condition:
+      field: target.compliance_flags
+      op: contains
+      value: renewable
+`
+
+const jsonRules = `{
+  "rules": [
+    {
+      "id": "low-latency",
+      "description": "Real-time processes need targets under 20ms",
+      "type": "hard",
+      "condition": {
+        "any": [
+          {"not": {"field": "process.real_time", "op": "eq", "value": true}},
+          {"field": "target.network_latency", "op": "lt", "value": 20}
+        ]
+      }
+    }
+  ]
+}`
+
+type RuleDSLTestSuite struct {
+	suite.Suite
+	engine *policy.PolicyEngine
+	target models.OffloadTarget
+}
+
+func (suite *RuleDSLTestSuite) SetupTest() {
+	suite.engine = policy.NewPolicyEngine()
+	suite.target = models.OffloadTarget{
+		ID:               "edge-1",
+		Type:             models.EDGE,
+		SecurityLevel:    3,
+		DataJurisdiction: "EU",
+		NetworkLatency:   10 * time.Millisecond,
+		ComplianceFlags:  []string{"iso27001"},
+	}
+}
+
+func (suite *RuleDSLTestSuite) TestYAMLRules() {
+	rules, err := policy.ParseRules([]byte(yamlRules), "yaml")
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), rules, 3)
+	require.NoError(suite.T(), suite.engine.ReplaceRules("compliance.yaml", rules))
+
+	process := models.Process{ID: "p-1", DataSensitivity: 5, SecurityLevel: 2}
+
+	evaluation := suite.engine.EvaluatePolicy(process, suite.target)
+	assert.True(suite.T(), evaluation.Allowed)
+	assert.InDelta(suite.T(), -0.35, evaluation.ScoreAdjustment, 1e-9, "Soft rule uses its own penalty")
+
+	suite.target.DataJurisdiction = "US"
+	assert.False(suite.T(), suite.engine.EvaluatePolicy(process, suite.target).Allowed,
+		"Sensitive data cannot leave the EU")
+
+	process.DataSensitivity = 1
+	assert.True(suite.T(), suite.engine.EvaluatePolicy(process, suite.target).Allowed)
+
+	process.SecurityLevel = 5
+	assert.False(suite.T(), suite.engine.EvaluatePolicy(process, suite.target).Allowed,
+		"Field-to-field comparison enforces the security level")
+}
+
+func (suite *RuleDSLTestSuite) TestJSONRules() {
+	rules, err := policy.ParseRules([]byte(jsonRules), "json")
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), suite.engine.ReplaceRules("latency.json", rules))
+
+	process := models.Process{ID: "p-1", RealTime: true}
+	assert.True(suite.T(), suite.engine.EvaluatePolicy(process, suite.target).Allowed)
+
+	suite.target.NetworkLatency = 50 * time.Millisecond
+	assert.False(suite.T(), suite.engine.EvaluatePolicy(process, suite.target).Allowed)
+
+	process.RealTime = false
+	assert.True(suite.T(), suite.engine.EvaluatePolicy(process, suite.target).Allowed)
+}
+
+func (suite *RuleDSLTestSuite) TestFieldMembership() {
+	rules, err := policy.ParseRules([]byte(`{"rules":[
+		{"id":"capable","type":"hard","condition":{"field":"process.type","op":"in","value_field":"target.capabilities"}},
+		{"id":"uncertified","type":"hard","condition":{"field":"process.type","op":"not_in","value_field":"target.compliance_flags"}}
+	]}`), "json")
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), suite.engine.ReplaceRules("membership.json", rules))
+
+	process := models.Process{ID: "p-1", Type: "gpu"}
+	suite.target.Capabilities = []string{"gpu"}
+	assert.True(suite.T(), suite.engine.EvaluatePolicy(process, suite.target).Allowed)
+
+	suite.target.ComplianceFlags = []string{"iso27001", "gpu"}
+	assert.False(suite.T(), suite.engine.EvaluatePolicy(process, suite.target).Allowed)
+
+	suite.target.ComplianceFlags = nil
+	suite.target.Capabilities = []string{"fpga"}
+	assert.False(suite.T(), suite.engine.EvaluatePolicy(process, suite.target).Allowed)
+}
+
+func (suite *RuleDSLTestSuite) TestInvalidRules() {
+	cases := map[string]string{
+		"unknown field":          `{"rules":[{"id":"r1","type":"hard","condition":{"field":"target.color","op":"eq","value":"red"}}]}`,
+		"unknown operator":       `{"rules":[{"id":"r1","type":"hard","condition":{"field":"target.reliability","op":"approx","value":1}}]}`,
+		"unknown field operator": `{"rules":[{"id":"r1","type":"hard","condition":{"field":"target.security_level","op":"approx","value_field":"process.security_level"}}]}`,
+		"field between":          `{"rules":[{"id":"r1","type":"hard","condition":{"field":"target.security_level","op":"between","value_field":"process.security_level"}}]}`,
+		"non-numeric":            `{"rules":[{"id":"r1","type":"hard","condition":{"field":"target.reliability","op":"gt","value":"high"}}]}`,
+		"bad type":               `{"rules":[{"id":"r1","type":"maybe","condition":{"field":"target.reliability","op":"gt","value":0.5}}]}`,
+		"duplicate id":           `{"rules":[{"id":"r1","type":"hard","condition":{"field":"target.reliability","op":"gt","value":0.5}},{"id":"r1","type":"soft","condition":{"field":"target.reliability","op":"gt","value":0.5}}]}`,
+	}
+	for name, data := range cases {
+		_, err := policy.ParseRules([]byte(data), "json")
+		require.Error(suite.T(), err, name)
+		assert.Contains(suite.T(), err.Error(), "r1", name)
+	}
+
+	_, err := policy.ParseRules([]byte(jsonRules), "toml")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RuleDSLTestSuite) TestHotReload() {
+	path := filepath.Join(suite.T().TempDir(), "rules.yaml")
+	require.NoError(suite.T(), os.WriteFile(path, []byte(yamlRules), 0o644))
+
+	// A built-in rule must survive reloads
+	require.NoError(suite.T(), suite.engine.AddRule(policy.PolicyRule{
+		ID:          "builtin",
+		Type:        models.SOFT,
+		Condition:   func(p models.Process, t models.OffloadTarget) bool { return true },
+		Description: "Built-in rule",
+	}))
+
+	watcher := policy.NewRuleWatcher(suite.engine, path, time.Second)
+	var reloads int
+	watcher.OnReload(func(rules []policy.PolicyRule, err error) { reloads++ })
+
+	require.NoError(suite.T(), watcher.Load())
+	assert.Len(suite.T(), suite.engine.GetRules(), 4)
+
+	// A broken file keeps the previous rules
+	require.NoError(suite.T(), os.WriteFile(path, []byte("rules: [{id: broken"), 0o644))
+	assert.Error(suite.T(), watcher.Load())
+	assert.Len(suite.T(), suite.engine.GetRules(), 4)
+
+	require.NoError(suite.T(), os.WriteFile(path, []byte(jsonRulesAsYAML), 0o644))
+	require.NoError(suite.T(), watcher.Load())
+
+	rules := suite.engine.GetRules()
+	require.Len(suite.T(), rules, 2)
+	assert.Equal(suite.T(), "builtin", rules[0].ID)
+	assert.Equal(suite.T(), "low-latency", rules[1].ID)
+	assert.Equal(suite.T(), path, rules[1].Source)
+	assert.Equal(suite.T(), 3, reloads)
+
+	suite.engine.SetImmutable(true)
+	assert.Error(suite.T(), watcher.Load(), "Rules cannot change while the engine is immutable")
+}
+
+const jsonRulesAsYAML = `
+rules:
+  - id: low-latency
+    type: hard
+    condition:
+      field: target.network_latency
+      op: lt
+      value: 20
+`
+
+func TestRuleDSLSuite(t *testing.T) {
+	suite.Run(t, new(RuleDSLTestSuite))
+}
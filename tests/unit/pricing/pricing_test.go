@@ -0,0 +1,229 @@
+package pricing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/pricing"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// Pricing test requirements:
+// 1. Current prices must replace the configured compute cost of mapped
+//    targets, at the spot price for spot targets, in the target's currency
+// 2. Later providers must take precedence, and a failed refresh must keep a
+//    provider's previous quotes
+// 3. AWS prices must come from signed Pricing API and spot price history
+//    requests, at the cheapest availability zone's spot price
+// 4. GCP machine types must be priced from the per-core and per-GiB SKUs of
+//    their family and region, across catalog pages
+
+type PricingTestSuite struct {
+	suite.Suite
+}
+
+// providerFunc is a provider backed by a function
+type providerFunc struct {
+	name   string
+	quotes func() ([]pricing.Quote, error)
+}
+
+func (pf providerFunc) Name() string { return pf.name }
+
+func (pf providerFunc) Quotes(ctx context.Context, wanted []pricing.Key) ([]pricing.Quote, error) {
+	return pf.quotes()
+}
+
+func (suite *PricingTestSuite) TestApplyTargets() {
+	catalog, err := pricing.NewCatalog(pricing.Config{
+		Enabled: true,
+		Targets: map[string]pricing.TargetPricing{
+			"on-demand": {InstanceType: "m5.large"},
+			"spot":      {InstanceType: "m5.large", Spot: true},
+			"elsewhere": {InstanceType: "m5.large", Region: "eu-north-1"},
+		},
+		Static: []pricing.Quote{
+			{InstanceType: "m5.large", Region: "us-east-1", OnDemand: 0.096, Spot: 0.035},
+		},
+	})
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), catalog.RefreshAll(context.Background()))
+
+	targets := catalog.ApplyTargets([]models.OffloadTarget{
+		{ID: "on-demand", Location: "us-east-1", ComputeCost: 1.0},
+		{ID: "spot", Location: "us-east-1", ComputeCost: 1.0},
+		{ID: "elsewhere", Location: "us-east-1", ComputeCost: 1.0},
+		{ID: "unmapped", Location: "us-east-1", ComputeCost: 1.0},
+	})
+	assert.InDelta(suite.T(), 0.096, targets[0].ComputeCost, 1e-9)
+	assert.InDelta(suite.T(), 0.035, targets[1].ComputeCost, 1e-9)
+	assert.Equal(suite.T(), 1.0, targets[2].ComputeCost, "No quote for the mapped region")
+	assert.Equal(suite.T(), 1.0, targets[3].ComputeCost)
+
+	// Quotes are converted to the target's currency when there is a rate
+	catalog.SetRates(units.Rates{"EUR": 0.5})
+	targets = catalog.ApplyTargets([]models.OffloadTarget{
+		{ID: "on-demand", Location: "us-east-1", ComputeCost: 1.0, Currency: "EUR"},
+		{ID: "spot", Location: "us-east-1", ComputeCost: 1.0, Currency: "SEK"},
+	})
+	assert.InDelta(suite.T(), 0.048, targets[0].ComputeCost, 1e-9)
+	assert.Equal(suite.T(), 1.0, targets[1].ComputeCost, "No rate for the target's currency")
+
+	quote, ok := catalog.Quote("m5.large", "us-east-1")
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), "static", quote.Provider)
+	assert.False(suite.T(), quote.UpdatedAt.IsZero())
+
+	_, err = pricing.NewCatalog(pricing.Config{Targets: map[string]pricing.TargetPricing{"t": {}}})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *PricingTestSuite) TestPrecedenceAndFailures() {
+	catalog, err := pricing.NewCatalog(pricing.Config{
+		Enabled: true,
+		Static:  []pricing.Quote{{InstanceType: "m5.large", Region: "us-east-1", OnDemand: 0.10}},
+	})
+	require.NoError(suite.T(), err)
+
+	fail := false
+	catalog.Register(providerFunc{name: "market", quotes: func() ([]pricing.Quote, error) {
+		if fail {
+			return nil, errors.New("unavailable")
+		}
+		return []pricing.Quote{{InstanceType: "m5.large", Region: "us-east-1", OnDemand: 0.08}}, nil
+	}})
+
+	require.NoError(suite.T(), catalog.RefreshAll(context.Background()))
+	quote, _ := catalog.Quote("m5.large", "us-east-1")
+	assert.Equal(suite.T(), "market", quote.Provider)
+	assert.InDelta(suite.T(), 0.08, quote.OnDemand, 1e-9)
+
+	fail = true
+	err = catalog.RefreshAll(context.Background())
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "market")
+	quote, _ = catalog.Quote("m5.large", "us-east-1")
+	assert.InDelta(suite.T(), 0.08, quote.OnDemand, 1e-9, "Stale quotes are kept")
+
+	statuses := catalog.Status()
+	require.Len(suite.T(), statuses, 2)
+	assert.Empty(suite.T(), statuses[0].LastError)
+	assert.Equal(suite.T(), "unavailable", statuses[1].LastError)
+	assert.Len(suite.T(), catalog.Quotes(), 1)
+}
+
+func (suite *PricingTestSuite) TestAWSProvider() {
+	suite.T().Setenv("TEST_AWS_KEY", "AKIDEXAMPLE")
+	suite.T().Setenv("TEST_AWS_SECRET", "secret")
+
+	var targets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+			http.Error(w, "unsigned", http.StatusForbidden)
+			return
+		}
+		if r.Method == http.MethodPost {
+			targets = append(targets, r.Header.Get("X-Amz-Target"))
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(suite.T(), string(body), `"Value":"m5.large"`)
+			item := `{"terms":{"OnDemand":{"SKU.JRTCKXETXF":{"priceDimensions":{"SKU.JRTCKXETXF.6YS6EN2CT7":{"unit":"Hrs","pricePerUnit":{"USD":"0.0960000000"}}}}}}}`
+			json.NewEncoder(w).Encode(map[string][]string{"PriceList": {item}})
+			return
+		}
+		assert.Equal(suite.T(), "DescribeSpotPriceHistory", r.URL.Query().Get("Action"))
+		fmt.Fprint(w, `<DescribeSpotPriceHistoryResponse><spotPriceHistorySet>
+			<item><instanceType>m5.large</instanceType><spotPrice>0.0410</spotPrice><availabilityZone>us-east-1a</availabilityZone></item>
+			<item><instanceType>m5.large</instanceType><spotPrice>0.0350</spotPrice><availabilityZone>us-east-1b</availabilityZone></item>
+		</spotPriceHistorySet></DescribeSpotPriceHistoryResponse>`)
+	}))
+	defer server.Close()
+
+	provider := pricing.NewAWSProvider(pricing.AWSConfig{
+		Enabled:         true,
+		Spot:            true,
+		PricingEndpoint: server.URL,
+		EC2Endpoint:     server.URL,
+		AccessKeyEnv:    "TEST_AWS_KEY",
+		SecretKeyEnv:    "TEST_AWS_SECRET",
+	})
+	quotes, err := provider.Quotes(context.Background(), []pricing.Key{{InstanceType: "m5.large", Region: "us-east-1"}})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), quotes, 1)
+	assert.InDelta(suite.T(), 0.096, quotes[0].OnDemand, 1e-9)
+	assert.InDelta(suite.T(), 0.035, quotes[0].Spot, 1e-9)
+	assert.Equal(suite.T(), []string{"AWSPriceListService.GetProducts"}, targets)
+
+	unsigned := pricing.NewAWSProvider(pricing.AWSConfig{PricingEndpoint: server.URL, AccessKeyEnv: "TEST_AWS_UNSET"})
+	_, err = unsigned.Quotes(context.Background(), []pricing.Key{{InstanceType: "m5.large", Region: "us-east-1"}})
+	assert.ErrorContains(suite.T(), err, "TEST_AWS_UNSET")
+}
+
+func (suite *PricingTestSuite) TestGCPProvider() {
+	suite.T().Setenv("TEST_GCP_KEY", "key")
+
+	sku := func(description, usageType string, nanos int64) map[string]interface{} {
+		return map[string]interface{}{
+			"description":    description,
+			"category":       map[string]string{"resourceFamily": "Compute", "usageType": usageType},
+			"serviceRegions": []string{"us-central1"},
+			"pricingInfo": []interface{}{map[string]interface{}{
+				"pricingExpression": map[string]interface{}{
+					"tieredRates": []interface{}{map[string]interface{}{"unitPrice": map[string]interface{}{"units": "0", "nanos": nanos}}},
+				},
+			}},
+		}
+	}
+	pages := map[string]interface{}{
+		"": map[string]interface{}{
+			"skus": []interface{}{
+				sku("N2 Instance Core running in Americas", "OnDemand", 31611000),
+				sku("N2 Custom Instance Core running in Americas", "OnDemand", 99000000),
+			},
+			"nextPageToken": "page-2",
+		},
+		"page-2": map[string]interface{}{
+			"skus": []interface{}{
+				sku("N2 Instance Ram running in Americas", "OnDemand", 4237000),
+				sku("Spot Preemptible N2 Instance Core running in Americas", "Preemptible", 7650000),
+				sku("Spot Preemptible N2 Instance Ram running in Americas", "Preemptible", 1025000),
+			},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(suite.T(), "key", r.URL.Query().Get("key"))
+		assert.True(suite.T(), strings.HasSuffix(r.URL.Path, "/skus"))
+		json.NewEncoder(w).Encode(pages[r.URL.Query().Get("pageToken")])
+	}))
+	defer server.Close()
+
+	provider := pricing.NewGCPProvider(pricing.GCPConfig{Enabled: true, Endpoint: server.URL, APIKeyEnv: "TEST_GCP_KEY"})
+	shape, ok := provider.Shape("n2-standard-4")
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), pricing.MachineShape{Family: "N2", CPU: 4, MemoryGiB: 16}, shape)
+
+	quotes, err := provider.Quotes(context.Background(), []pricing.Key{
+		{InstanceType: "n2-standard-4", Region: "us-central1"},
+		{InstanceType: "n2-standard-4", Region: "europe-north1"},
+		{InstanceType: "mystery", Region: "us-central1"},
+	})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), quotes, 1, "Only the machine type with a known shape and region is quoted")
+	assert.InDelta(suite.T(), 4*0.031611+16*0.004237, quotes[0].OnDemand, 1e-9)
+	assert.InDelta(suite.T(), 4*0.00765+16*0.001025, quotes[0].Spot, 1e-9)
+}
+
+func TestPricingSuite(t *testing.T) {
+	suite.Run(t, new(PricingTestSuite))
+}
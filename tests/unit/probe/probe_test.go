@@ -0,0 +1,145 @@
+package probe_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/probe"
+)
+
+// Latency probing test requirements:
+// 1. TCP and HTTP endpoints must be probed for latency, and HTTP for bandwidth
+// 2. Estimates must be rolling means over the configured window
+// 3. Failed probes must lower stability without skewing latency
+// 4. Probe estimates must replace the configured target network metrics
+
+type ProbeTestSuite struct {
+	suite.Suite
+}
+
+// sequence returns a prober yielding the given latencies in turn; zero fails
+func sequence(latencies ...time.Duration) probe.Prober {
+	i := 0
+	return probe.ProberFunc(func(ctx context.Context) (probe.Result, error) {
+		latency := latencies[i%len(latencies)]
+		i++
+		if latency == 0 {
+			return probe.Result{}, errors.New("unreachable")
+		}
+		return probe.Result{Latency: latency}, nil
+	})
+}
+
+func (suite *ProbeTestSuite) TestEndpoints() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(suite.T(), err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("x", 256*1024)))
+	}))
+	defer server.Close()
+
+	monitor, err := probe.NewMonitor(probe.Config{
+		Enabled: true,
+		Endpoints: map[string]string{
+			"tcp-target":  "tcp://" + listener.Addr().String(),
+			"http-target": server.URL,
+		},
+	})
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), monitor.ProbeAll(context.Background()))
+
+	tcp, ok := monitor.Estimate("tcp-target")
+	require.True(suite.T(), ok)
+	assert.Greater(suite.T(), tcp.Latency, time.Duration(0))
+	assert.Equal(suite.T(), 0.0, tcp.Bandwidth)
+	assert.Equal(suite.T(), 1.0, tcp.Stability)
+
+	web, ok := monitor.Estimate("http-target")
+	require.True(suite.T(), ok)
+	assert.Greater(suite.T(), web.Latency, time.Duration(0))
+	assert.Greater(suite.T(), web.Bandwidth, 0.0)
+
+	_, err = probe.NewProber("icmp://host")
+	assert.Error(suite.T(), err)
+	_, err = probe.NewMonitor(probe.Config{Endpoints: map[string]string{"bad": "tcp://"}})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ProbeTestSuite) TestRollingWindow() {
+	monitor, err := probe.NewMonitor(probe.Config{Window: 3})
+	require.NoError(suite.T(), err)
+	monitor.Register("edge-1", sequence(100*time.Millisecond, 10*time.Millisecond, 20*time.Millisecond, 30*time.Millisecond))
+
+	for i := 0; i < 4; i++ {
+		require.NoError(suite.T(), monitor.ProbeAll(context.Background()))
+	}
+
+	estimate, ok := monitor.Estimate("edge-1")
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), 3, estimate.Samples)
+	assert.Equal(suite.T(), 20*time.Millisecond, estimate.Latency, "The oldest probe left the window")
+	assert.InDelta(suite.T(), float64(8165*time.Microsecond), float64(estimate.Jitter), float64(time.Microsecond))
+}
+
+func (suite *ProbeTestSuite) TestFailedProbes() {
+	monitor, err := probe.NewMonitor(probe.Config{Window: 4})
+	require.NoError(suite.T(), err)
+	monitor.Register("edge-1", sequence(10*time.Millisecond, 0))
+
+	for i := 0; i < 4; i++ {
+		err = monitor.ProbeAll(context.Background())
+	}
+	assert.ErrorContains(suite.T(), err, "edge-1")
+
+	estimate, _ := monitor.Estimate("edge-1")
+	assert.Equal(suite.T(), 0.5, estimate.Stability)
+	assert.Equal(suite.T(), 10*time.Millisecond, estimate.Latency)
+	assert.Equal(suite.T(), "unreachable", estimate.LastError)
+}
+
+func (suite *ProbeTestSuite) TestApplyTargets() {
+	monitor, err := probe.NewMonitor(probe.Config{})
+	require.NoError(suite.T(), err)
+	monitor.Register("edge-1", probe.ProberFunc(func(ctx context.Context) (probe.Result, error) {
+		return probe.Result{Latency: 40 * time.Millisecond, Bandwidth: 5e6}, nil
+	}))
+	require.NoError(suite.T(), monitor.ProbeAll(context.Background()))
+
+	targets := []models.OffloadTarget{
+		{ID: "edge-1", NetworkLatency: 5 * time.Millisecond, NetworkBandwidth: 1e9, NetworkStability: 0.5},
+		{ID: "edge-2", NetworkLatency: 5 * time.Millisecond},
+	}
+	applied := monitor.ApplyTargets(targets)
+
+	assert.Equal(suite.T(), 40*time.Millisecond, applied[0].NetworkLatency)
+	assert.Equal(suite.T(), 5e6, applied[0].NetworkBandwidth)
+	assert.Equal(suite.T(), 1.0, applied[0].NetworkStability)
+	assert.Equal(suite.T(), targets[1], applied[1], "Unprobed targets are unchanged")
+	assert.Equal(suite.T(), 5*time.Millisecond, targets[0].NetworkLatency, "Input targets are not modified")
+}
+
+func TestProbeSuite(t *testing.T) {
+	suite.Run(t, new(ProbeTestSuite))
+}
@@ -0,0 +1,154 @@
+package sidecar_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	capev1 "github.com/casperlundberg/colony-process-offloader-algorithm/api/proto/cape/v1"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/cape"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/sidecar"
+)
+
+// gRPC transport test requirements:
+// 1. Decisions and outcomes must round-trip through the protobuf messages
+// 2. Invalid requests must fail with the InvalidArgument status
+// 3. Stats, weights and chargeback must be served from the same algorithm
+
+type GRPCTestSuite struct {
+	suite.Suite
+	client capev1.DecisionServiceClient
+	server *grpc.Server
+	conn   *grpc.ClientConn
+}
+
+func (suite *GRPCTestSuite) SetupTest() {
+	alg, err := algorithm.NewAlgorithm(cape.DefaultConfig())
+	require.NoError(suite.T(), err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	suite.server = grpc.NewServer()
+	capev1.RegisterDecisionServiceServer(suite.server, sidecar.NewGRPCServer(sidecar.NewService(alg)))
+	go suite.server.Serve(listener)
+
+	suite.conn, err = grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(suite.T(), err)
+	suite.client = capev1.NewDecisionServiceClient(suite.conn)
+}
+
+func (suite *GRPCTestSuite) TearDownTest() {
+	suite.conn.Close()
+	suite.server.Stop()
+}
+
+func (suite *GRPCTestSuite) request() *capev1.DecideRequest {
+	return &capev1.DecideRequest{
+		Process: &capev1.Process{
+			Id:                "p1",
+			TenantId:          "team-a",
+			Priority:          5,
+			CpuRequirement:    2.0,
+			MemoryRequirement: 1024 * 1024 * 1024,
+			InputSize:         20 * 1024 * 1024,
+			EstimatedDuration: durationpb.New(30 * time.Second),
+		},
+		Targets: []*capev1.OffloadTarget{{
+			Id:                "edge-1",
+			Type:              "edge",
+			TotalCapacity:     8.0,
+			AvailableCapacity: 6.0,
+			MemoryTotal:       16 * 1024 * 1024 * 1024,
+			MemoryAvailable:   10 * 1024 * 1024 * 1024,
+			NetworkLatency:    durationpb.New(10 * time.Millisecond),
+			NetworkBandwidth:  10 * 1024 * 1024,
+			NetworkStability:  0.95,
+			ProcessingSpeed:   1.0,
+			Reliability:       0.95,
+			SecurityLevel:     5,
+			LastSeen:          timestamppb.Now(),
+		}},
+		State: &capev1.SystemState{
+			QueueDepth:     25,
+			QueueThreshold: 20,
+			ComputeUsage:   0.75,
+			MemoryUsage:    0.6,
+			Timestamp:      timestamppb.Now(),
+		},
+	}
+}
+
+func (suite *GRPCTestSuite) TestDecideAndReport() {
+	ctx := context.Background()
+	dec, err := suite.client.Decide(ctx, suite.request())
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.Equal(suite.T(), "edge-1", dec.TargetId)
+	assert.NotEmpty(suite.T(), dec.DecisionId)
+	assert.Positive(suite.T(), dec.DecisionLatency.AsDuration())
+
+	_, err = suite.client.ReportOutcome(ctx, &capev1.Outcome{
+		DecisionId:    dec.DecisionId,
+		ProcessId:     "p1",
+		TargetId:      dec.TargetId,
+		Success:       true,
+		ExecutionTime: durationpb.New(20 * time.Second),
+		CostActual:    0.5,
+	})
+	require.NoError(suite.T(), err)
+
+	stats, err := suite.client.GetStats(ctx, &capev1.GetStatsRequest{})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), stats.DecisionCount)
+	assert.Equal(suite.T(), int64(1), stats.Stats.Outcomes)
+	weights, err := suite.client.GetWeights(ctx, &capev1.GetWeightsRequest{})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), stats.CurrentWeights.QueueDepth, weights.QueueDepth)
+
+	chargeback, err := suite.client.GetChargeback(ctx, &capev1.GetChargebackRequest{})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), chargeback.Rollups, 1)
+	assert.Equal(suite.T(), "team-a", chargeback.Rollups[0].TenantId)
+
+	spikes, err := suite.client.ListSpikeReports(ctx, &capev1.ListSpikeReportsRequest{})
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), spikes.Reports)
+}
+
+func (suite *GRPCTestSuite) TestInvalidArguments() {
+	ctx := context.Background()
+
+	_, err := suite.client.Decide(ctx, &capev1.DecideRequest{})
+	assert.Equal(suite.T(), codes.InvalidArgument, status.Code(err))
+
+	request := suite.request()
+	request.Timeout = durationpb.New(-time.Second)
+	_, err = suite.client.Decide(ctx, request)
+	assert.Equal(suite.T(), codes.InvalidArgument, status.Code(err))
+
+	_, err = suite.client.ReportOutcome(ctx, &capev1.Outcome{})
+	assert.Equal(suite.T(), codes.InvalidArgument, status.Code(err))
+
+	_, err = suite.client.GetChargeback(ctx, &capev1.GetChargebackRequest{Month: "March"})
+	assert.Equal(suite.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCSuite(t *testing.T) {
+	suite.Run(t, new(GRPCTestSuite))
+}
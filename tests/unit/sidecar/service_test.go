@@ -0,0 +1,202 @@
+package sidecar_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/algorithm"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/decision"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/learning"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/policy"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/sidecar"
+)
+
+// Sidecar service test requirements:
+// 1. Decide must return placement advice that outcomes can be reported against
+// 2. Invalid requests must be rejected with ErrInvalidArgument
+// 3. Concurrent callers must be served safely
+// 4. Weights and stats must reflect the decisions served
+// 5. Spike post-mortems must be listed, empty when detection is disabled
+// 6. Reported outcomes must be charged to their tenant and project by month
+
+type ServiceTestSuite struct {
+	suite.Suite
+	service *sidecar.Service
+	request sidecar.DecideRequest
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	alg, err := algorithm.NewAlgorithm(algorithm.Config{
+		InitialWeights: decision.AdaptiveWeights{
+			QueueDepth:    0.2,
+			ProcessorLoad: 0.2,
+			NetworkCost:   0.2,
+			LatencyCost:   0.2,
+			EnergyCost:    0.1,
+			PolicyCost:    0.1,
+		},
+		LearningConfig: learning.LearningConfig{WindowSize: 100, LearningRate: 0.01, MinSamples: 10},
+		SafetyConstraints: policy.SafetyConstraints{
+			MinLocalCompute:       0.2,
+			MinLocalMemory:        0.2,
+			MaxConcurrentOffloads: 100,
+			MaxLatencyTolerance:   500 * time.Millisecond,
+			MinReliability:        0.5,
+		},
+		PerformanceTargets: algorithm.PerformanceTargets{MaxDecisionLatency: 500 * time.Millisecond},
+	})
+	require.NoError(suite.T(), err)
+	suite.service = sidecar.NewService(alg)
+
+	suite.request = sidecar.DecideRequest{
+		Process: models.Process{
+			ID:                "p1",
+			CPURequirement:    2.0,
+			MemoryRequirement: 1024 * 1024 * 1024,
+			InputSize:         20 * 1024 * 1024,
+			EstimatedDuration: 30 * time.Second,
+			Priority:          5,
+			Status:            models.QUEUED,
+		},
+		Targets: []models.OffloadTarget{{
+			ID:                "edge-1",
+			Type:              models.EDGE,
+			TotalCapacity:     8.0,
+			AvailableCapacity: 6.0,
+			MemoryTotal:       16 * 1024 * 1024 * 1024,
+			MemoryAvailable:   10 * 1024 * 1024 * 1024,
+			NetworkLatency:    10 * time.Millisecond,
+			NetworkBandwidth:  10 * 1024 * 1024,
+			NetworkStability:  0.95,
+			ProcessingSpeed:   1.0,
+			Reliability:       0.95,
+			SecurityLevel:     5,
+			LastSeen:          time.Now(),
+		}},
+		State: models.SystemState{QueueDepth: 25, QueueThreshold: 20, ComputeUsage: 0.75, MemoryUsage: 0.6, Timestamp: time.Now()},
+	}
+}
+
+func (suite *ServiceTestSuite) TestDecideAndReport() {
+	ctx := context.Background()
+	dec, err := suite.service.Decide(ctx, suite.request)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+	assert.NotEmpty(suite.T(), dec.DecisionID)
+
+	require.NoError(suite.T(), suite.service.ReportOutcome(ctx, decision.OffloadOutcome{
+		DecisionID:    dec.DecisionID,
+		ProcessID:     suite.request.Process.ID,
+		TargetID:      dec.Target.ID,
+		Success:       true,
+		ExecutionTime: 20 * time.Second,
+	}))
+
+	stats, err := suite.service.GetStats(ctx)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, stats.DecisionCount)
+	weights, err := suite.service.GetWeights(ctx)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), stats.CurrentWeights, weights)
+}
+
+func (suite *ServiceTestSuite) TestInvalidRequests() {
+	ctx := context.Background()
+
+	invalid := suite.request
+	invalid.Process.Priority = 0
+	_, err := suite.service.Decide(ctx, invalid)
+	assert.True(suite.T(), errors.Is(err, sidecar.ErrInvalidArgument))
+
+	invalid = suite.request
+	invalid.Timeout = -time.Second
+	_, err = suite.service.Decide(ctx, invalid)
+	assert.True(suite.T(), errors.Is(err, sidecar.ErrInvalidArgument))
+
+	err = suite.service.ReportOutcome(ctx, decision.OffloadOutcome{})
+	assert.True(suite.T(), errors.Is(err, sidecar.ErrInvalidArgument))
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = suite.service.Decide(cancelled, suite.request)
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+}
+
+func (suite *ServiceTestSuite) TestConcurrentDecisions() {
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			request := suite.request
+			request.Process.ID = "p" + string(rune('a'+i))
+			_, err := suite.service.Decide(context.Background(), request)
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(suite.T(), err)
+	}
+
+	stats, err := suite.service.GetStats(context.Background())
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 20, stats.DecisionCount)
+}
+
+func (suite *ServiceTestSuite) TestListSpikeReports() {
+	reports, err := suite.service.ListSpikeReports(context.Background())
+	require.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), reports)
+	assert.Empty(suite.T(), reports)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = suite.service.ListSpikeReports(ctx)
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+}
+
+func (suite *ServiceTestSuite) TestGetChargeback() {
+	ctx := context.Background()
+	suite.request.Process.TenantID = "team-a"
+	suite.request.Process.ProjectID = "etl"
+	suite.request.Targets[0].ComputeCost = 0.5
+	dec, err := suite.service.Decide(ctx, suite.request)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), dec.ShouldOffload)
+
+	end := time.Now()
+	require.NoError(suite.T(), suite.service.ReportOutcome(ctx, decision.OffloadOutcome{
+		DecisionID:    dec.DecisionID,
+		ProcessID:     suite.request.Process.ID,
+		TargetID:      dec.Target.ID,
+		Success:       true,
+		ExecutionTime: 20 * time.Second,
+		CostActual:    1.25,
+		EndTime:       end,
+	}))
+
+	rollups, err := suite.service.GetChargeback(ctx, end.Format("2006-01"))
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), rollups, 1)
+	assert.Equal(suite.T(), "team-a", rollups[0].TenantID)
+	assert.Equal(suite.T(), "etl", rollups[0].ProjectID)
+	assert.InDelta(suite.T(), 1.25, rollups[0].Cost.Total(), 1e-9)
+
+	_, err = suite.service.GetChargeback(ctx, "March")
+	assert.ErrorIs(suite.T(), err, sidecar.ErrInvalidArgument)
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
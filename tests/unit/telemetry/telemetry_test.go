@@ -0,0 +1,265 @@
+package telemetry_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/telemetry"
+)
+
+// Telemetry test requirements:
+// 1. Topic filters must match with MQTT's + and # wildcards, naming the
+//    target by the first + level or the payload's target ID
+// 2. Mapped payload keys must be read as fields, with booleans, numeric
+//    strings and RFC 3339 or Unix timestamps accepted
+// 3. Invalid filters, fields and QoS must be refused
+// 4. The subscriber must connect with credentials, subscribe at the
+//    configured QoS, acknowledge QoS 1 messages and count unmapped ones
+
+type TelemetryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TelemetryTestSuite) TestMatch() {
+	mapping := telemetry.TopicMapping{Filter: "fleet/+/sensors/#"}
+
+	target, ok := mapping.Match("fleet/edge-1/sensors/cpu/load")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "edge-1", target)
+
+	_, ok = mapping.Match("fleet/edge-1/sensors")
+	assert.True(suite.T(), ok, "# also matches the parent level")
+	_, ok = mapping.Match("fleet/edge-1/status")
+	assert.False(suite.T(), ok)
+	_, ok = mapping.Match("$SYS/edge-1/sensors")
+	assert.False(suite.T(), ok)
+
+	exact := telemetry.TopicMapping{Filter: "fleet/telemetry"}
+	_, ok = exact.Match("fleet/telemetry")
+	assert.True(suite.T(), ok)
+	_, ok = exact.Match("fleet/telemetry/extra")
+	assert.False(suite.T(), ok)
+}
+
+func (suite *TelemetryTestSuite) TestMap() {
+	subscriber, err := telemetry.NewSubscriber(telemetry.Config{
+		Topics: []telemetry.TopicMapping{
+			{Filter: "fleet/+/telemetry", Fields: map[telemetry.Field]string{telemetry.FieldLoad: "cpu"}},
+			{Filter: "shared/#", Fields: map[telemetry.Field]string{telemetry.FieldTargetID: "device"}},
+		},
+	}, nil)
+	require.NoError(suite.T(), err)
+
+	reading, mapped, err := subscriber.Map("fleet/edge-1/telemetry",
+		[]byte(`{"cpu": 0.5, "temperature": "61.5", "charging": true, "timestamp": 1700000000}`))
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), mapped)
+	assert.Equal(suite.T(), "edge-1", reading.TargetID)
+	assert.Equal(suite.T(), map[telemetry.Field]float64{
+		telemetry.FieldLoad:        0.5,
+		telemetry.FieldTemperature: 61.5,
+		telemetry.FieldCharging:    1,
+	}, reading.Values)
+	assert.Equal(suite.T(), int64(1700000000), reading.Timestamp.Unix())
+
+	reading, mapped, err = subscriber.Map("shared/all",
+		[]byte(`{"device": "edge-2", "timestamp": "2024-01-02T03:04:05Z"}`))
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), mapped)
+	assert.Equal(suite.T(), "edge-2", reading.TargetID)
+	assert.Equal(suite.T(), time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), reading.Timestamp.UTC())
+
+	_, mapped, err = subscriber.Map("other/topic", []byte(`{}`))
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), mapped)
+
+	_, _, err = subscriber.Map("shared/all", []byte(`{"load": 0.5}`))
+	assert.Error(suite.T(), err, "No target ID")
+	_, _, err = subscriber.Map("fleet/edge-1/telemetry", []byte(`{"cpu": "busy"}`))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TelemetryTestSuite) TestValidate() {
+	valid := telemetry.Config{
+		Enabled: true,
+		Broker:  "tcp://broker:1883",
+		Topics:  []telemetry.TopicMapping{{Filter: "fleet/+/telemetry"}},
+	}
+	assert.NoError(suite.T(), valid.Validate())
+
+	invalid := []func(c *telemetry.Config){
+		func(c *telemetry.Config) { c.QoS = 2 },
+		func(c *telemetry.Config) { c.Broker = "http://broker" },
+		func(c *telemetry.Config) { c.Broker = "" },
+		func(c *telemetry.Config) { c.Topics = nil },
+		func(c *telemetry.Config) { c.Topics = []telemetry.TopicMapping{{Filter: "fleet/#/telemetry"}} },
+		func(c *telemetry.Config) { c.Topics = []telemetry.TopicMapping{{Filter: "fleet/edge+"}} },
+		func(c *telemetry.Config) {
+			c.Topics = []telemetry.TopicMapping{{Filter: "fleet/+", Fields: map[telemetry.Field]string{"humidity": "h"}}}
+		},
+	}
+	for i, mutate := range invalid {
+		config := valid
+		mutate(&config)
+		assert.Error(suite.T(), config.Validate(), "case %d", i)
+	}
+}
+
+// fakeBroker accepts one MQTT connection, records its CONNECT and SUBSCRIBE
+// and publishes messages to it
+type fakeBroker struct {
+	listener net.Listener
+	conn     net.Conn
+	reader   *bufio.Reader
+	ready    chan struct{}
+
+	mu        sync.Mutex
+	connect   []byte
+	subscribe []byte
+	pubAcks   []uint16
+}
+
+func newFakeBroker(t *testing.T) *fakeBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	broker := &fakeBroker{listener: listener, ready: make(chan struct{})}
+	go broker.serve()
+	t.Cleanup(func() { listener.Close() })
+	return broker
+}
+
+func (fb *fakeBroker) url() string {
+	return "tcp://" + fb.listener.Addr().String()
+}
+
+func (fb *fakeBroker) serve() {
+	conn, err := fb.listener.Accept()
+	if err != nil {
+		return
+	}
+	fb.conn, fb.reader = conn, bufio.NewReader(conn)
+
+	_, body := fb.read()
+	fb.mu.Lock()
+	fb.connect = body
+	fb.mu.Unlock()
+	conn.Write([]byte{0x20, 2, 0, 0})
+
+	_, body = fb.read()
+	fb.mu.Lock()
+	fb.subscribe = body
+	fb.mu.Unlock()
+	conn.Write([]byte{0x90, 3, body[0], body[1], body[len(body)-1]})
+	close(fb.ready)
+
+	for {
+		header, body := fb.read()
+		switch header & 0xF0 {
+		case 0x40:
+			fb.mu.Lock()
+			fb.pubAcks = append(fb.pubAcks, binary.BigEndian.Uint16(body))
+			fb.mu.Unlock()
+		case 0xC0:
+			conn.Write([]byte{0xD0, 0})
+		case 0:
+			return
+		}
+	}
+}
+
+// read reads a packet with a one-byte remaining length
+func (fb *fakeBroker) read() (byte, []byte) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(fb.reader, header); err != nil {
+		return 0, nil
+	}
+	body := make([]byte, header[1])
+	io.ReadFull(fb.reader, body)
+	return header[0], body
+}
+
+// publish sends a QoS 1 message with a packet ID
+func (fb *fakeBroker) publish(topic string, payload string, packetID uint16) {
+	body := binary.BigEndian.AppendUint16(nil, uint16(len(topic)))
+	body = append(body, topic...)
+	body = binary.BigEndian.AppendUint16(body, packetID)
+	body = append(body, payload...)
+	fb.conn.Write(append([]byte{0x32, byte(len(body))}, body...))
+}
+
+func (suite *TelemetryTestSuite) TestSubscriber() {
+	broker := newFakeBroker(suite.T())
+	suite.T().Setenv("FLEET_MQTT_PASSWORD", "secret")
+
+	readings := make(chan telemetry.Reading, 4)
+	subscriber, err := telemetry.NewSubscriber(telemetry.Config{
+		Enabled:     true,
+		Broker:      broker.url(),
+		ClientID:    "cape-test",
+		Username:    "cape",
+		PasswordEnv: "FLEET_MQTT_PASSWORD",
+		QoS:         1,
+		KeepAlive:   10 * time.Second,
+		Topics:      []telemetry.TopicMapping{{Filter: "fleet/+/telemetry"}},
+	}, func(reading telemetry.Reading) error {
+		readings <- reading
+		return nil
+	})
+	require.NoError(suite.T(), err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go subscriber.Run(ctx)
+
+	select {
+	case <-broker.ready:
+	case <-time.After(5 * time.Second):
+		suite.T().Fatal("subscriber did not subscribe")
+	}
+	broker.mu.Lock()
+	assert.Contains(suite.T(), string(broker.connect), "cape-test")
+	assert.Contains(suite.T(), string(broker.connect), "secret")
+	assert.Equal(suite.T(), byte(0xC2), broker.connect[7], "Clean session with user name and password")
+	assert.Contains(suite.T(), string(broker.subscribe), "fleet/+/telemetry")
+	assert.Equal(suite.T(), byte(1), broker.subscribe[len(broker.subscribe)-1], "Subscribed at QoS 1")
+	broker.mu.Unlock()
+
+	broker.publish("fleet/edge-1/telemetry", `{"load": 0.8}`, 7)
+	broker.publish("fleet/edge-1/status", `{}`, 8)
+	select {
+	case reading := <-readings:
+		assert.Equal(suite.T(), "edge-1", reading.TargetID)
+		assert.Equal(suite.T(), map[telemetry.Field]float64{telemetry.FieldLoad: 0.8}, reading.Values)
+	case <-time.After(5 * time.Second):
+		suite.T().Fatal("no reading delivered")
+	}
+
+	assert.Eventually(suite.T(), func() bool {
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		return len(broker.pubAcks) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	broker.mu.Lock()
+	assert.Equal(suite.T(), []uint16{7, 8}, broker.pubAcks)
+	broker.mu.Unlock()
+
+	stats := subscriber.Stats()
+	assert.True(suite.T(), stats.Connected)
+	assert.Equal(suite.T(), 1, stats.Connects)
+	assert.Equal(suite.T(), 2, stats.Messages)
+	assert.Equal(suite.T(), 1, stats.Unmapped)
+}
+
+func TestTelemetrySuite(t *testing.T) {
+	suite.Run(t, new(TelemetryTestSuite))
+}
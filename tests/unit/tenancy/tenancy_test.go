@@ -0,0 +1,272 @@
+package tenancy_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/tenancy"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// Tenancy test requirements:
+// 1. Offloads beyond a tenant's CPU-hour, cost or concurrency quota must be refused
+// 2. Completed offloads must replace estimates with actual usage
+// 3. Consumption must reset every quota period while active offloads carry over
+// 4. The fair queue must release from the tenant with the smallest weighted dominant share
+// 5. Within a tenant, processes are released by priority, then FIFO
+// 6. Executed offloads must be charged to their tenant and project by month,
+//    split into infra, transfer and energy cost, and exported as CSV
+// 7. The fair queue must hold processes back until their dependencies
+//    complete, refuse dependency cycles, drop the dependents of failed
+//    processes and report the time spent blocked
+
+type TenancyTestSuite struct {
+	suite.Suite
+	now     time.Time
+	manager *tenancy.Manager
+}
+
+func (suite *TenancyTestSuite) SetupTest() {
+	suite.now = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	suite.manager = tenancy.NewManager(24 * time.Hour)
+	suite.manager.SetClock(func() time.Time { return suite.now })
+}
+
+func (suite *TenancyTestSuite) process(id, tenantID string, priority int) models.Process {
+	return models.Process{ID: id, TenantID: tenantID, Priority: priority, CPURequirement: 2.0}
+}
+
+func (suite *TenancyTestSuite) TestQuotaEnforcement() {
+	require.NoError(suite.T(), suite.manager.SetQuota("team-a", tenancy.Quota{
+		CPUHours:           4.0,
+		Cost:               10.0,
+		ConcurrentOffloads: 2,
+	}))
+
+	require.NoError(suite.T(), suite.manager.Admit(suite.process("a-1", "team-a", 5), 2.0, time.Hour, 3.0))
+	require.NoError(suite.T(), suite.manager.Admit(suite.process("a-2", "team-a", 5), 1.0, time.Hour, 3.0))
+
+	err := suite.manager.Admit(suite.process("a-3", "team-a", 5), 0.5, time.Hour, 1.0)
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "concurrent")
+
+	// Actual usage replaces the estimate: a-1 ran for 30 minutes
+	suite.manager.Complete("a-1", 30*time.Minute, 2.0)
+	usage := suite.manager.Usage("team-a")
+	assert.InDelta(suite.T(), 2.0, usage.CPUHours, 1e-9)
+	assert.InDelta(suite.T(), 5.0, usage.Cost, 1e-9)
+	assert.Equal(suite.T(), 1, usage.ActiveOffloads)
+
+	err = suite.manager.Admit(suite.process("a-4", "team-a", 5), 2.0, 90*time.Minute, 1.0)
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "CPU-hour")
+
+	err = suite.manager.Admit(suite.process("a-5", "team-a", 5), 1.0, time.Hour, 6.0)
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "cost")
+
+	suite.manager.Cancel("a-2")
+	assert.Equal(suite.T(), tenancy.Usage{CPUHours: 1.0, Cost: 2.0}, suite.manager.Usage("team-a"))
+
+	// Untenanted processes are not tracked
+	assert.NoError(suite.T(), suite.manager.Admit(suite.process("x-1", "", 5), 100.0, time.Hour, 100.0))
+	assert.Equal(suite.T(), tenancy.Usage{}, suite.manager.Usage(""))
+}
+
+func (suite *TenancyTestSuite) TestQuotaPeriodReset() {
+	require.NoError(suite.T(), suite.manager.SetQuota("team-a", tenancy.Quota{Cost: 5.0}))
+	require.NoError(suite.T(), suite.manager.Admit(suite.process("a-1", "team-a", 5), 1.0, time.Hour, 5.0))
+	assert.Error(suite.T(), suite.manager.Admit(suite.process("a-2", "team-a", 5), 1.0, time.Hour, 1.0))
+
+	suite.now = suite.now.Add(25 * time.Hour)
+	usage := suite.manager.Usage("team-a")
+	assert.Equal(suite.T(), 0.0, usage.Cost)
+	assert.Equal(suite.T(), 1, usage.ActiveOffloads, "Running offloads carry over")
+	assert.NoError(suite.T(), suite.manager.Admit(suite.process("a-2", "team-a", 5), 1.0, time.Hour, 1.0))
+}
+
+func (suite *TenancyTestSuite) TestFairQueue() {
+	require.NoError(suite.T(), suite.manager.SetQuota("heavy", tenancy.Quota{Weight: 1.0}))
+	require.NoError(suite.T(), suite.manager.SetQuota("light", tenancy.Quota{Weight: 1.0}))
+	require.NoError(suite.T(), suite.manager.SetQuota("vip", tenancy.Quota{Weight: 4.0}))
+
+	// heavy already holds most of the resources
+	require.NoError(suite.T(), suite.manager.Admit(suite.process("h-0", "heavy", 5), 8.0, time.Hour, 8.0))
+	require.NoError(suite.T(), suite.manager.Admit(suite.process("l-0", "light", 5), 1.0, time.Hour, 1.0))
+	require.NoError(suite.T(), suite.manager.Admit(suite.process("v-0", "vip", 5), 2.0, time.Hour, 2.0))
+
+	queue := tenancy.NewFairQueue(suite.manager)
+	for i := 0; i < 3; i++ {
+		queue.Push(suite.process("h", "heavy", 5))
+	}
+	queue.Push(suite.process("l-low", "light", 2))
+	queue.Push(suite.process("l-high", "light", 9))
+	queue.Push(suite.process("v", "vip", 5))
+	assert.Equal(suite.T(), 6, queue.Len())
+
+	// vip: 2/11 / 4 = 0.045, light: 1/11 = 0.09, heavy: 8/11 = 0.73
+	next, ok := queue.Pop()
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), "vip", next.TenantID)
+
+	next, _ = queue.Pop()
+	assert.Equal(suite.T(), "l-high", next.ID, "Higher priority first within a tenant")
+	next, _ = queue.Pop()
+	assert.Equal(suite.T(), "l-low", next.ID)
+
+	for i := 0; i < 3; i++ {
+		next, ok = queue.Pop()
+		require.True(suite.T(), ok)
+		assert.Equal(suite.T(), "heavy", next.TenantID)
+	}
+	_, ok = queue.Pop()
+	assert.False(suite.T(), ok)
+}
+
+func (suite *TenancyTestSuite) TestDependencyRelease() {
+	queue := tenancy.NewFairQueue(suite.manager)
+	extract := suite.process("extract", "team-a", 5)
+	transform := suite.process("transform", "team-a", 5)
+	transform.Dependencies = []string{"extract"}
+	load := suite.process("load", "team-a", 9)
+	load.Dependencies = []string{"extract", "transform"}
+
+	require.NoError(suite.T(), queue.Push(load))
+	require.NoError(suite.T(), queue.Push(transform))
+	require.NoError(suite.T(), queue.Push(extract))
+	assert.Equal(suite.T(), 1, queue.Len(), "Only the process without dependencies is ready")
+	assert.Equal(suite.T(), 2, queue.DependencyStats().Blocked)
+
+	next, _ := queue.Pop()
+	assert.Equal(suite.T(), "extract", next.ID)
+	_, ok := queue.Pop()
+	assert.False(suite.T(), ok, "load still waits on transform")
+
+	suite.now = suite.now.Add(time.Minute)
+	queue.Complete("extract")
+	next, _ = queue.Pop()
+	assert.Equal(suite.T(), "transform", next.ID)
+	_, ok = queue.Pop()
+	assert.False(suite.T(), ok)
+
+	suite.now = suite.now.Add(2 * time.Minute)
+	queue.Complete("transform")
+	next, _ = queue.Pop()
+	assert.Equal(suite.T(), "load", next.ID)
+
+	// Dependencies completed before a process is pushed do not block it
+	report := suite.process("report", "team-a", 5)
+	report.Dependencies = []string{"extract"}
+	require.NoError(suite.T(), queue.Push(report))
+	assert.Equal(suite.T(), 1, queue.Len())
+
+	stats := queue.DependencyStats()
+	assert.Zero(suite.T(), stats.Blocked)
+	assert.Equal(suite.T(), 2, stats.Released)
+	assert.Equal(suite.T(), 3*time.Minute, stats.MaxBlocked)
+	assert.Equal(suite.T(), 4*time.Minute, stats.TotalBlocked)
+	assert.Equal(suite.T(), 2*time.Minute, stats.MeanBlocked())
+}
+
+func (suite *TenancyTestSuite) TestDependencyCycleAndFailure() {
+	queue := tenancy.NewFairQueue(suite.manager)
+	a := suite.process("a", "team-a", 5)
+	a.Dependencies = []string{"c"}
+	b := suite.process("b", "team-a", 5)
+	b.Dependencies = []string{"a"}
+	c := suite.process("c", "team-a", 5)
+	c.Dependencies = []string{"b"}
+
+	require.NoError(suite.T(), queue.Push(a))
+	require.NoError(suite.T(), queue.Push(b))
+	err := queue.Push(c)
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "c -> b -> a -> c")
+	assert.Equal(suite.T(), 1, queue.DependencyStats().Cycles)
+
+	// A failed dependency drops everything blocked on it, transitively
+	dropped := queue.Fail("c")
+	require.Len(suite.T(), dropped, 2)
+	assert.Equal(suite.T(), "a", dropped[0].ID)
+	assert.Equal(suite.T(), "b", dropped[1].ID)
+	stats := queue.DependencyStats()
+	assert.Zero(suite.T(), stats.Blocked)
+	assert.Equal(suite.T(), 2, stats.Failed)
+	assert.Zero(suite.T(), queue.Len())
+}
+
+func (suite *TenancyTestSuite) TestInvalidQuota() {
+	assert.Error(suite.T(), suite.manager.SetQuota("", tenancy.Quota{}))
+	assert.Error(suite.T(), suite.manager.SetQuota("team-a", tenancy.Quota{Cost: -1}))
+}
+
+func (suite *TenancyTestSuite) TestChargeback() {
+	target := models.OffloadTarget{
+		ID:          "cloud-1",
+		ComputeCost: 2.0,
+		NetworkCost: 0.01,
+		EnergyCost:  0.5,
+	}
+	process := suite.process("p-1", "team-a", 5)
+	process.ProjectID = "etl"
+	process.InputSize = 100 * 1024 * 1024
+
+	ledger := tenancy.NewLedger(time.UTC)
+	ledger.Open(process, target)
+	ledger.Close("p-1", time.Hour, 0, suite.now)
+
+	rollups := ledger.Rollup("2024-03")
+	require.Len(suite.T(), rollups, 1)
+	assert.Equal(suite.T(), "team-a", rollups[0].TenantID)
+	assert.Equal(suite.T(), "etl", rollups[0].ProjectID)
+	assert.Equal(suite.T(), 1, rollups[0].Executions)
+	assert.InDelta(suite.T(), 2.0, rollups[0].Cost.Infra, 1e-9)
+	assert.InDelta(suite.T(), 1.0, rollups[0].Cost.Transfer, 1e-9)
+	assert.InDelta(suite.T(), 0.5, rollups[0].Cost.Energy, 1e-9)
+
+	// A reported cost is split in the priced proportions
+	breakdown := tenancy.AttributeCost(process, target, time.Hour, 7.0)
+	assert.InDelta(suite.T(), 7.0, breakdown.Total(), 1e-9)
+	assert.InDelta(suite.T(), 4.0, breakdown.Infra, 1e-9)
+
+	// Months close in the ledger's location
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	require.NoError(suite.T(), err)
+	local := tenancy.NewLedger(stockholm)
+	local.Record(process, breakdown, time.Date(2024, 3, 31, 23, 30, 0, 0, time.UTC))
+	assert.Equal(suite.T(), []string{"2024-04"}, local.Months())
+
+	// Cancelled offloads are never charged
+	ledger.Open(suite.process("p-2", "team-b", 5), target)
+	ledger.Cancel("p-2")
+	ledger.Close("p-2", time.Hour, 0, suite.now)
+	ledger.Record(suite.process("p-3", "team-b", 5), breakdown, suite.now.AddDate(0, 1, 0))
+	assert.Equal(suite.T(), []string{"2024-03", "2024-04"}, ledger.Months())
+	assert.Len(suite.T(), ledger.Rollup(""), 2)
+
+	var out bytes.Buffer
+	require.NoError(suite.T(), tenancy.WriteChargebackCSV(&out, ledger.Rollup("")))
+	rows, err := csv.NewReader(&out).ReadAll()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), rows, 3)
+	assert.Equal(suite.T(), []string{"2024-03", "team-a", "etl", "1", "2", "1", "0.5", "3.5", "USD"}, rows[1])
+	assert.Equal(suite.T(), "team-b", rows[2][1])
+
+	// Rollups are reported in the display currency
+	ledger.SetDisplay(units.Config{DisplayCurrency: "EUR", Rates: units.Rates{"EUR": 0.5}})
+	rollups = ledger.Rollup("2024-03")
+	require.Len(suite.T(), rollups, 1)
+	assert.Equal(suite.T(), units.Currency("EUR"), rollups[0].Currency)
+	assert.InDelta(suite.T(), 1.75, rollups[0].Cost.Total(), 1e-9)
+}
+
+func TestTenancySuite(t *testing.T) {
+	suite.Run(t, new(TenancyTestSuite))
+}
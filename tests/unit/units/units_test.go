@@ -0,0 +1,112 @@
+package units_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/models"
+	"github.com/casperlundberg/colony-process-offloader-algorithm/pkg/units"
+)
+
+// Units test requirements:
+// 1. Money must convert between currencies through the base currency, and
+//    fail for currencies without an exchange rate
+// 2. Display currencies must have an exchange rate, and reports must be
+//    converted to them
+// 3. Data sizes must parse and format with binary units, and read from JSON
+//    as bytes or strings
+// 4. Target costs must convert to a currency together
+
+type UnitsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *UnitsTestSuite) TestMoney() {
+	rates := units.Rates{"EUR": 0.9, "SEK": 10.8}
+
+	eur, err := rates.Convert(units.Base(10), "EUR")
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 9.0, eur.Amount, 1e-9)
+	assert.Equal(suite.T(), units.Currency("EUR"), eur.Currency)
+
+	// Non-base currencies convert through the base currency
+	sek, err := rates.Convert(eur, "SEK")
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 108.0, sek.Amount, 1e-9)
+
+	// An empty currency is the base currency
+	usd, err := rates.Convert(units.Money{Amount: 3}, "")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), units.BaseCurrency, usd.Currency)
+
+	_, err = rates.Convert(units.Base(1), "GBP")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "9 EUR", eur.String())
+}
+
+func (suite *UnitsTestSuite) TestConfig() {
+	assert.NoError(suite.T(), units.Config{}.Validate())
+	assert.Error(suite.T(), units.Config{DisplayCurrency: "EUR"}.Validate(), "No exchange rate")
+	assert.Error(suite.T(), units.Config{DisplayCurrency: "eur", Rates: units.Rates{"eur": 1}}.Validate())
+	assert.Error(suite.T(), units.Config{Rates: units.Rates{"EUR": 0}}.Validate())
+
+	display := units.Config{DisplayCurrency: "EUR", Rates: units.Rates{"EUR": 0.5}}
+	require.NoError(suite.T(), display.Validate())
+	assert.Equal(suite.T(), units.Money{Amount: 2, Currency: "EUR"}, display.Display(4))
+	assert.Equal(suite.T(), units.Base(4), units.Config{}.Display(4))
+}
+
+func (suite *UnitsTestSuite) TestDataSize() {
+	for text, expected := range map[string]units.DataSize{
+		"512":     512,
+		"64KiB":   64 * units.KiB,
+		"1.5 GiB": 3 * units.GiB / 2,
+		"10MB":    10 * units.MiB,
+	} {
+		size, err := units.ParseDataSize(text)
+		require.NoError(suite.T(), err, text)
+		assert.Equal(suite.T(), expected, size, text)
+	}
+	_, err := units.ParseDataSize("ten MB")
+	assert.Error(suite.T(), err)
+
+	assert.Equal(suite.T(), "1.5GiB", (3 * units.GiB / 2).String())
+	assert.Equal(suite.T(), "100B", units.DataSize(100).String())
+	assert.InDelta(suite.T(), 0.5, (512 * units.KiB).MiB(), 1e-9)
+
+	var sizes []units.DataSize
+	require.NoError(suite.T(), json.Unmarshal([]byte(`[1024, "2MiB"]`), &sizes))
+	assert.Equal(suite.T(), []units.DataSize{units.KiB, 2 * units.MiB}, sizes)
+	assert.Error(suite.T(), json.Unmarshal([]byte(`true`), &sizes[0]))
+}
+
+func (suite *UnitsTestSuite) TestPower() {
+	energy := (250 * units.Watt).Over(4 * time.Hour)
+	assert.Equal(suite.T(), units.KilowattHour, energy)
+	assert.InDelta(suite.T(), 1.0, energy.KilowattHours(), 1e-9)
+}
+
+func (suite *UnitsTestSuite) TestTargetCurrency() {
+	target := models.OffloadTarget{ID: "eu", ComputeCost: 0.9, NetworkCost: 0.09, EnergyCost: 0.18, Currency: "EUR"}
+	rates := units.Rates{"EUR": 0.9}
+
+	based, err := target.InCurrency(rates, units.BaseCurrency)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), units.BaseCurrency, based.Currency)
+	assert.InDelta(suite.T(), 1.0, based.ComputeCost, 1e-9)
+	assert.InDelta(suite.T(), 0.1, based.NetworkCost, 1e-9)
+	assert.InDelta(suite.T(), 0.2, based.EnergyCost, 1e-9)
+	assert.InDelta(suite.T(), 0.2, based.TransferCost(2*units.MiB), 1e-9)
+
+	_, err = target.InCurrency(units.Rates{}, units.BaseCurrency)
+	assert.Error(suite.T(), err)
+}
+
+func TestUnitsSuite(t *testing.T) {
+	suite.Run(t, new(UnitsTestSuite))
+}